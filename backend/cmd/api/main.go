@@ -1,3 +1,5 @@
+//go:build !inmemory
+
 package main
 
 import (
@@ -8,15 +10,49 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	usercommands "github.com/chun-wei0413/pingnom/internal/application/commands/user"
 	authcommands "github.com/chun-wei0413/pingnom/internal/application/commands/auth"
+	friendshipcommands "github.com/chun-wei0413/pingnom/internal/application/commands/friendship"
+	pingcommands "github.com/chun-wei0413/pingnom/internal/application/commands/ping"
+	notificationcommands "github.com/chun-wei0413/pingnom/internal/application/commands/notification"
+	availabilitycommands "github.com/chun-wei0413/pingnom/internal/application/commands/availability"
+	reviewcommands "github.com/chun-wei0413/pingnom/internal/application/commands/review"
+	restaurantcommands "github.com/chun-wei0413/pingnom/internal/application/commands/restaurant"
 	userqueries "github.com/chun-wei0413/pingnom/internal/application/queries/user"
+	friendshipqueries "github.com/chun-wei0413/pingnom/internal/application/queries/friendship"
+	pingqueries "github.com/chun-wei0413/pingnom/internal/application/queries/ping"
+	notificationqueries "github.com/chun-wei0413/pingnom/internal/application/queries/notification"
+	availabilityqueries "github.com/chun-wei0413/pingnom/internal/application/queries/availability"
+	reviewqueries "github.com/chun-wei0413/pingnom/internal/application/queries/review"
+	restaurantqueries "github.com/chun-wei0413/pingnom/internal/application/queries/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/services"
+	groupdiningusecases "github.com/chun-wei0413/pingnom/internal/application/groupdining/usecases"
+	appservices "github.com/chun-wei0413/pingnom/internal/application/services"
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
-	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/migrations"
+	groupdiningrepos "github.com/chun-wei0413/pingnom/internal/infrastructure/groupdining/repositories"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/mail"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/push"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/routing"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/messaging"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/controllers"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/routes"
@@ -29,25 +65,150 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 	
-	// 依賴注入 - 建立 InMemory Repository
-	userRepo := inmemory.NewInMemoryUserRepository()
+	// 連接資料庫
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	
+	// 啟動時自動套用 migration (可由 database.auto_migrate 關閉)
+	if cfg.Database.AutoMigrate {
+		migrator, err := migrations.NewMigrator(db)
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		log.Printf("Applied %d migration(s)", len(applied))
+	}
+	
+	// 依賴注入 - 建立 PostgreSQL Repository
+	userRepo := persistence.NewPostgreSQLUserRepository(db)
+	friendshipRepo := persistence.NewPostgreSQLFriendshipRepository(db)
+	pingRepo := persistence.NewPostgreSQLPingRepository(db)
+	pingSeriesRepo := persistence.NewPostgreSQLPingSeriesRepository(db)
+	restaurantRepo := persistence.NewPostgreSQLRestaurantRepository(db)
+	refreshTokenRepo := persistence.NewPostgreSQLRefreshTokenRepository(db)
+	revocationList := persistence.NewPostgreSQLRevocationList(db)
+	actionTokenRepo := persistence.NewPostgreSQLActionTokenRepository(db)
+	outboxStore := persistence.NewPostgreSQLOutboxStore(db)
+	notificationRepo := persistence.NewPostgreSQLNotificationRepository(db)
+	availabilityRepo := persistence.NewPostgreSQLAvailabilityRepository(db)
+	calendarFeedRepo := persistence.NewPostgreSQLCalendarFeedRepository(db)
+	reviewRepo := persistence.NewPostgreSQLReviewRepository(db)
+	leaseStore := persistence.NewPostgreSQLLeaseStore(db)
+	jobRunStore := persistence.NewPostgreSQLJobRunStore(db)
+	
+	// Group Dining repositories
+	groupDiningPlanRepo := groupdiningrepos.NewGroupDiningPlanRepositoryPostgres(db)
+	voteRepo := groupdiningrepos.NewVoteRepositoryPostgres(db)
 	
 	// 依賴注入 - 建立 JWT Service
-	jwtService := auth.NewJWTService("your-secret-key", 24) // 24小時過期
+	jwtKeys, err := auth.NewKeySetFromConfig(cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	jwtService := auth.NewJWTServiceWithKeys(jwtKeys, cfg.JWT.AccessTokenTTL, cfg.JWT.Issuer)
+	sessionService := session.NewService(refreshTokenRepo, revocationList, cfg.JWT.RefreshTokenTTL, cfg.JWT.AccessTokenTTL)
 	
 	// 依賴注入 - 建立 Domain Services
 	userService := user.NewUserService(userRepo)
+	eventHub := messaging.NewHub(messaging.DefaultBufferSize)
+	friendshipService := friendship.NewFriendshipService(friendshipRepo)
+	interactionPolicy := social.NewInteractionPolicy(friendshipRepo, userRepo)
+	pingService := ping.NewService(pingRepo, interactionPolicy)
+	pingSeriesService := ping.NewSeriesService(pingSeriesRepo, pingRepo, interactionPolicy, cfg.Scheduler.PingSeriesHorizon)
+	routingProvider, err := routing.NewRoutingProviderFromConfig(cfg.Routing)
+	if err != nil {
+		log.Fatalf("Failed to create routing provider: %v", err)
+	}
+	diningHistory := appservices.NewDiningHistoryService(pingRepo, groupDiningPlanRepo, reviewRepo, restaurantRepo)
+	restaurantRecommendationService := restaurant.NewRecommendationService(restaurantRepo, routingProvider, diningHistory, recommendationWeightProfiles(cfg.Recommendation))
+	accountService := user.NewAccountService(userRepo, actionTokenRepo, cfg.Account.TokenSecret, cfg.Account.VerificationTokenTTL, cfg.Account.PasswordResetTokenTTL)
+	
+	// 依賴注入 - 建立 Mailer 與帳號 Email 服務
+	mailer, err := mail.NewMailerFromConfig(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to create mailer: %v", err)
+	}
+	accountMailService := appservices.NewAccountMailService(accountService, mailer, cfg.Account.AppBaseURL)
+	
+	// 依賴注入 - 建立通知服務與送達管道
+	notificationChannels := []notification.Channel{
+		notification.NewInAppChannel(eventHub),
+		notification.NewEmailChannel(mailer),
+	}
+	pushProvider, err := push.NewPushProviderFromConfig(cfg.Notification)
+	if err != nil {
+		log.Fatalf("Failed to create push provider: %v", err)
+	}
+	if pushProvider != nil {
+		notificationChannels = append(notificationChannels, notification.NewPushChannel(pushProvider))
+	}
+	notificationService := notification.NewService(notificationRepo, userRepo, notificationChannels...)
 	
 	// 依賴注入 - 建立 Command Handlers
-	registerUserHandler := usercommands.NewRegisterUserHandler(userService)
+	registerUserHandler := usercommands.NewRegisterUserHandler(userService, accountMailService)
 	updateProfileHandler := usercommands.NewUpdateProfileHandler(userService)
 	updatePreferencesHandler := usercommands.NewUpdatePreferencesHandler(userService)
 	updatePrivacyHandler := usercommands.NewUpdatePrivacyHandler(userService)
+	updateNotificationPreferencesHandler := usercommands.NewUpdateNotificationPreferencesHandler(userService)
 	changePasswordHandler := usercommands.NewChangePasswordHandler(userService)
 	
 	// 依賴注入 - 建立 Query Handlers
 	getUserProfileHandler := userqueries.NewGetUserProfileHandler(userRepo)
-	searchUsersHandler := userqueries.NewSearchUsersHandler(userService)
+	searchUsersHandler := userqueries.NewSearchUsersHandler(userService, interactionPolicy)
+	
+	// 依賴注入 - 建立 Friendship Command Handlers
+	sendRequestHandler := friendshipcommands.NewSendFriendRequestHandler(friendshipService)
+	acceptRequestHandler := friendshipcommands.NewAcceptFriendRequestHandler(friendshipService)
+	declineRequestHandler := friendshipcommands.NewDeclineFriendRequestHandler(friendshipService)
+	blockUserHandler := friendshipcommands.NewBlockUserHandler(friendshipService)
+	removeFriendHandler := friendshipcommands.NewRemoveFriendHandler(friendshipService)
+	
+	// 依賴注入 - 建立 Friendship Query Handlers
+	getFriendsHandler := friendshipqueries.NewGetFriendsHandler(friendshipService)
+	getPendingHandler := friendshipqueries.NewGetPendingRequestsHandler(friendshipService)
+	getSentHandler := friendshipqueries.NewGetSentRequestsHandler(friendshipService)
+	
+	// 依賴注入 - 建立 Ping Command Handlers
+	createPingHandler := pingcommands.NewCreatePingHandler(pingService)
+	respondToPingHandler := pingcommands.NewRespondToPingHandler(pingService)
+	createPingSeriesHandler := pingcommands.NewCreatePingSeriesHandler(pingSeriesService)
+	updatePingSeriesHandler := pingcommands.NewUpdatePingSeriesHandler(pingSeriesService)
+	cancelPingSeriesHandler := pingcommands.NewCancelPingSeriesHandler(pingSeriesService)
+	skipOccurrenceHandler := pingcommands.NewSkipOccurrenceHandler(pingSeriesService)
+	editOccurrenceHandler := pingcommands.NewEditOccurrenceHandler(pingSeriesService)
+	
+	// 依賴注入 - 建立 Ping Query Handlers
+	getUserPingsHandler := pingqueries.NewGetUserPingsHandler(pingService, interactionPolicy)
+	getPingSeriesHandler := pingqueries.NewGetPingSeriesHandler(pingSeriesService)
+	getUserPingSeriesHandler := pingqueries.NewGetUserPingSeriesHandler(pingSeriesService, interactionPolicy)
+	
+	// 依賴注入 - 建立 Restaurant Query Handlers
+	searchRestaurantsHandler := restaurantqueries.NewSearchRestaurantsHandler(restaurantRepo)
+	getRestaurantRecommendationsHandler := restaurantqueries.NewGetRestaurantRecommendationsHandler(restaurantRecommendationService, pingRepo)
+	getTasteProfileHandler := restaurantqueries.NewGetTasteProfileHandler(restaurantRecommendationService, pingRepo)
+	
+	// 依賴注入 - 建立 Group Dining Service & Controller
+	groupDiningService := services.NewGroupDiningService(
+		groupDiningPlanRepo,
+		voteRepo,
+		services.NewCatalogRatingLookup(restaurantRepo),
+		services.NewScheduleAvailabilityLookup(availabilityRepo),
+		services.NewSocialBlockLookup(interactionPolicy),
+		services.NewUserProfileLookup(userRepo),
+		services.NewSocialInvitePolicy(interactionPolicy),
+		groupdiningusecases.InviteLinkSettings{
+			Signer:     aggregates.NewInviteLinkSigner(cfg.GroupDining.InviteSecret),
+			DefaultTTL: cfg.GroupDining.InviteLinkTTL,
+			MaxTTL:     cfg.GroupDining.InviteLinkMaxTTL,
+			JoinURL:    cfg.Account.AppBaseURL + "/group-dining/join",
+		},
+	)
+	groupDiningController := controllers.NewGroupDiningController(groupDiningService)
 	
 	// 依賴注入 - 建立 HTTP Handlers
 	userHandler := handlers.NewUserHandler(
@@ -55,38 +216,95 @@ func main() {
 		updateProfileHandler,
 		updatePreferencesHandler,
 		updatePrivacyHandler,
+		updateNotificationPreferencesHandler,
 		changePasswordHandler,
 		getUserProfileHandler,
 		searchUsersHandler,
 	)
 	
 	// 依賴注入 - 建立 Auth Command Handlers
-	loginHandler := authcommands.NewLoginHandler(userService, jwtService)
+	loginHandler := authcommands.NewLoginHandler(userService, jwtService, sessionService)
+	refreshTokenHandler := authcommands.NewRefreshTokenHandler(userService, jwtService, sessionService)
+	logoutHandler := authcommands.NewLogoutHandler(sessionService)
+	verifyEmailHandler := authcommands.NewVerifyEmailHandler(accountService)
+	resendVerificationHandler := authcommands.NewResendVerificationHandler(accountMailService)
+	forgotPasswordHandler := authcommands.NewForgotPasswordHandler(accountMailService)
+	resetPasswordHandler := authcommands.NewResetPasswordHandler(accountService, sessionService)
 	
 	// 依賴注入 - 建立 Auth HTTP Handler
-	authHandler := handlers.NewAuthHandler(loginHandler)
-	friendshipHandler := &handlers.FriendshipHandler{}
-	pingHandler := &handlers.PingHandler{}
-	restaurantHandler := &handlers.RestaurantHandler{}
-	
-	// 建立測試帳號
-	ctx := context.Background()
-	testUser1, err := userService.RegisterUser(ctx, "testuser@pingnom.app", "", "TestPassword2024!", "Frank Li")
-	if err != nil {
-		log.Printf("Failed to create test user Frank Li: %v", err)
-	} else {
-		log.Printf("Test user Frank Li created successfully: %s", testUser1.ID.String())
-	}
-	
-	testUser2, err := userService.RegisterUser(ctx, "alice@pingnom.app", "", "AlicePassword2024!", "Alice Wang")
-	if err != nil {
-		log.Printf("Failed to create test user Alice Wang: %v", err)
-	} else {
-		log.Printf("Test user Alice Wang created successfully: %s", testUser2.ID.String())
-	}
+	authHandler := handlers.NewAuthHandler(loginHandler, refreshTokenHandler, logoutHandler)
+	jwksHandler := handlers.NewJWKSHandler(jwtService)
+	realtimeHandler := handlers.NewRealtimeHandler(eventHub, revocationList)
+	notificationHandler := handlers.NewNotificationHandler(
+		notificationqueries.NewGetNotificationsHandler(notificationService),
+		notificationqueries.NewGetUnreadCountHandler(notificationService),
+		notificationcommands.NewMarkReadHandler(notificationService),
+		notificationcommands.NewMarkAllReadHandler(notificationService),
+	)
+	availabilityService := availability.NewService(availabilityRepo)
+	availabilityHandler := handlers.NewAvailabilityHandler(
+		availabilityqueries.NewGetAvailabilityHandler(availabilityService),
+		availabilitycommands.NewUpdateAvailabilityHandler(availabilityService),
+	)
+	calendarHandler := handlers.NewCalendarHandler(
+		appservices.NewCalendarService(pingRepo, groupDiningPlanRepo, userRepo, calendarFeedRepo, cfg.Calendar.RefreshInterval),
+		cfg.Calendar.APIBaseURL,
+	)
+	reviewService := review.NewService(reviewRepo, restaurantRepo, appservices.NewReviewVisitVerifier(pingRepo, groupDiningPlanRepo))
+	reviewHandler := handlers.NewReviewHandler(
+		reviewcommands.NewCreateReviewHandler(reviewService),
+		reviewcommands.NewEditReviewHandler(reviewService),
+		reviewcommands.NewDeleteReviewHandler(reviewService),
+		reviewcommands.NewRemoveReviewHandler(reviewService),
+		reviewcommands.NewSetHelpfulHandler(reviewService),
+		reviewqueries.NewGetRestaurantReviewsHandler(reviewService, interactionPolicy),
+		reviewqueries.NewGetUserReviewsHandler(reviewService),
+	)
+	catalogService := restaurant.NewCatalogService(restaurantRepo)
+	restaurantAdminHandler := handlers.NewRestaurantAdminHandler(
+		restaurantqueries.NewGetRestaurantHandler(catalogService),
+		restaurantcommands.NewCreateRestaurantHandler(catalogService),
+		restaurantcommands.NewUpdateRestaurantHandler(catalogService),
+		restaurantcommands.NewDeleteRestaurantHandler(catalogService),
+		restaurantcommands.NewImportRestaurantsHandler(catalogService),
+	)
+	userAdminHandler := handlers.NewUserAdminHandler(
+		usercommands.NewChangeUserRoleHandler(userService, sessionService),
+		usercommands.NewDeactivateUserHandler(userService, sessionService),
+	)
+	accountHandler := handlers.NewAccountHandler(verifyEmailHandler, resendVerificationHandler, forgotPasswordHandler, resetPasswordHandler)
+	friendshipHandler := handlers.NewFriendshipHandler(
+		sendRequestHandler,
+		acceptRequestHandler,
+		declineRequestHandler,
+		blockUserHandler,
+		removeFriendHandler,
+		getFriendsHandler,
+		getPendingHandler,
+		getSentHandler,
+	)
+	pingHandler := handlers.NewPingHandler(
+		createPingHandler,
+		respondToPingHandler,
+		getUserPingsHandler,
+	)
+	pingSeriesHandler := handlers.NewPingSeriesHandler(
+		createPingSeriesHandler,
+		updatePingSeriesHandler,
+		cancelPingSeriesHandler,
+		skipOccurrenceHandler,
+		editOccurrenceHandler,
+		getPingSeriesHandler,
+		getUserPingSeriesHandler,
+	)
+	restaurantHandler := handlers.NewRestaurantHandler(
+		searchRestaurantsHandler,
+		getRestaurantRecommendationsHandler,
+		getTasteProfileHandler,
+	)
 	
 	// 依賴注入 - 建立 Middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, revocationList)
 	
 	// 設定 Gin 模式
 	if cfg.Environment == "production" {
@@ -100,10 +318,67 @@ func main() {
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 	engine.Use(middleware.CORS(cfg))
+	engine.Use(middleware.ErrorHandler())
 	
 	// 設定路由
 	router := routes.NewRouter(userHandler, authHandler, friendshipHandler, pingHandler, restaurantHandler, authMiddleware)
 	router.SetupRoutes(engine)
+	routes.SetupGroupDiningRoutes(engine, groupDiningController, authMiddleware)
+	routes.SetupWellKnownRoutes(engine, jwksHandler)
+	routes.SetupAccountRoutes(engine, accountHandler, authMiddleware)
+	routes.SetupRealtimeRoutes(engine, realtimeHandler, authMiddleware)
+	routes.SetupNotificationRoutes(engine, notificationHandler, authMiddleware)
+	routes.SetupAvailabilityRoutes(engine, availabilityHandler, authMiddleware)
+	routes.SetupCalendarRoutes(engine, calendarHandler, authMiddleware)
+	routes.SetupReviewRoutes(engine, reviewHandler, authMiddleware)
+	routes.SetupPingSeriesRoutes(engine, pingSeriesHandler, authMiddleware)
+	routes.SetupRestaurantAdminRoutes(engine, restaurantAdminHandler, authMiddleware)
+	routes.SetupUserAdminRoutes(engine, userAdminHandler, authMiddleware)
+	
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	
+	// 背景工作：派送 outbox 中的領域事件
+	dispatcher := events.NewDispatcher(outboxStore, events.DispatcherOptions{
+		PollInterval: cfg.Events.PollInterval,
+		BatchSize:    cfg.Events.BatchSize,
+		MaxAttempts:  cfg.Events.MaxAttempts,
+		Lease:        cfg.Events.Lease,
+		Retention:    cfg.Events.Retention,
+	})
+	appservices.NewRealtimeEventForwarder(eventHub, groupDiningPlanRepo).Register(dispatcher)
+	appservices.NewNotificationEventHandler(notificationService, userRepo).Register(dispatcher)
+	go dispatcher.Run(workerCtx)
+	
+	// 背景工作：排程器 (只在取得 leader 租約的 instance 上執行)
+	schedulerDone := make(chan struct{})
+	if cfg.Scheduler.Enabled {
+		jobScheduler := scheduler.NewScheduler(leaseStore, jobRunStore, scheduler.Options{
+			TickInterval: cfg.Scheduler.TickInterval,
+			LeaseTTL:     cfg.Scheduler.LeaseTTL,
+			Retention:    cfg.Scheduler.Retention,
+		})
+		sweepInterval := cfg.Account.SweepInterval
+		if cfg.Account.UnverifiedMaxAgeDays <= 0 {
+			sweepInterval = 0
+		}
+		sweeper := appservices.NewUnverifiedAccountSweeper(accountService, sessionService, cfg.Account.UnverifiedMaxAgeDays)
+		mealReminders := appservices.NewMealReminderJob(pingRepo, groupDiningPlanRepo, notificationService, cfg.Scheduler.ReminderLeadTime)
+		jobScheduler.Register(scheduler.Job{Name: appservices.JobExpirePings, Interval: cfg.Scheduler.PingExpiryInterval, Run: pingService.ExpirePings})
+		jobScheduler.Register(scheduler.Job{Name: appservices.JobCloseOverdueVoting, Interval: cfg.Scheduler.VotingDeadlineInterval, Run: func(ctx context.Context) (int, error) {
+			return groupDiningService.CloseOverdueVoting(time.Now())
+		}})
+		jobScheduler.Register(scheduler.Job{Name: appservices.JobMealReminders, Interval: cfg.Scheduler.ReminderInterval, Run: mealReminders.SendDue})
+		jobScheduler.Register(scheduler.Job{Name: appservices.JobSweepUnverifiedAccounts, Interval: sweepInterval, Run: sweeper.SweepOnce})
+		jobScheduler.Register(scheduler.Job{Name: appservices.JobMaterializePingSeries, Interval: cfg.Scheduler.PingSeriesInterval, Run: pingSeriesService.MaterializeDue})
+		log.Printf("Scheduler instance %s started", jobScheduler.InstanceID())
+		go func() {
+			defer close(schedulerDone)
+			jobScheduler.Run(workerCtx)
+		}()
+	} else {
+		close(schedulerDone)
+	}
 	
 	// 建立 HTTP 服務器
 	server := &http.Server{
@@ -127,14 +402,37 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopWorkers()
 	
 	// 優雅關閉服務器
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	
+	// 等待執行中的排程工作結束並釋放 leader 租約
+	select {
+	case <-schedulerDone:
+	case <-ctx.Done():
+		log.Println("Scheduler did not stop before shutdown timeout")
+	}
+	
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 	
 	log.Println("Server exited")
-}
\ No newline at end of file
+}
+// recommendationWeightProfiles 以設定檔的權重設定新增或覆寫內建的權重設定
+func recommendationWeightProfiles(cfg config.RecommendationConfig) restaurant.WeightProfiles {
+	profiles := restaurant.DefaultWeightProfiles()
+	for name, weights := range cfg.WeightProfiles {
+		profiles[name] = restaurant.ScoringWeights{
+			Distance:     weights.Distance,
+			Rating:       weights.Rating,
+			Cuisine:      weights.Cuisine,
+			Price:        weights.Price,
+			Restrictions: weights.Restrictions,
+			Affinity:     weights.Affinity,
+		}
+	}
+	return profiles
+}
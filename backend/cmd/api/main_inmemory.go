@@ -1,7 +1,12 @@
+//go:build inmemory
+
 package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -14,62 +19,132 @@ import (
 	usercommands "github.com/chun-wei0413/pingnom/internal/application/commands/user"
 	friendshipcommands "github.com/chun-wei0413/pingnom/internal/application/commands/friendship"
 	pingcommands "github.com/chun-wei0413/pingnom/internal/application/commands/ping"
+	notificationcommands "github.com/chun-wei0413/pingnom/internal/application/commands/notification"
+	availabilitycommands "github.com/chun-wei0413/pingnom/internal/application/commands/availability"
+	reviewcommands "github.com/chun-wei0413/pingnom/internal/application/commands/review"
+	restaurantcommands "github.com/chun-wei0413/pingnom/internal/application/commands/restaurant"
 	userqueries "github.com/chun-wei0413/pingnom/internal/application/queries/user"
 	friendshipqueries "github.com/chun-wei0413/pingnom/internal/application/queries/friendship"
 	pingqueries "github.com/chun-wei0413/pingnom/internal/application/queries/ping"
+	notificationqueries "github.com/chun-wei0413/pingnom/internal/application/queries/notification"
+	availabilityqueries "github.com/chun-wei0413/pingnom/internal/application/queries/availability"
+	reviewqueries "github.com/chun-wei0413/pingnom/internal/application/queries/review"
 	restaurantqueries "github.com/chun-wei0413/pingnom/internal/application/queries/restaurant"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
 	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
 	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
 	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/mail"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/push"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/routing"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/messaging"
 	friendshipInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
 	pingInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
 	restaurantInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+	sessionInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/routes"
 	
 	// Group Dining imports
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/services"
+	groupdiningusecases "github.com/chun-wei0413/pingnom/internal/application/groupdining/usecases"
+	appservices "github.com/chun-wei0413/pingnom/internal/application/services"
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
 	groupdiningrepos "github.com/chun-wei0413/pingnom/internal/infrastructure/groupdining/repositories"
 	"github.com/chun-wei0413/pingnom/internal/interfaces/http/controllers"
 )
 
 func main() {
 	// 依賴注入 - 建立 InMemory Repository
-	userRepo := inmemory.NewInMemoryUserRepository()
-	friendshipRepo := friendshipInmemory.NewInMemoryFriendshipRepository()
-	pingRepo := pingInmemory.NewPingRepository()
+	// 領域事件 outbox，由下方的 dispatcher 派送
+	outbox := sessionInmemory.NewOutbox()
+	userRepo := inmemory.NewInMemoryUserRepository(outbox)
+	friendshipRepo := friendshipInmemory.NewInMemoryFriendshipRepository(outbox)
+	pingRepo := pingInmemory.NewPingRepository(outbox)
+	pingSeriesRepo := pingInmemory.NewPingSeriesRepository()
 	restaurantRepo := restaurantInmemory.NewRestaurantRepository()
+	refreshTokenRepo := sessionInmemory.NewRefreshTokenRepository()
+	revocationList := sessionInmemory.NewRevocationList()
+	actionTokenRepo := sessionInmemory.NewActionTokenRepository()
+	notificationRepo := sessionInmemory.NewNotificationRepository()
+	availabilityRepo := sessionInmemory.NewAvailabilityRepository()
+	calendarFeedRepo := sessionInmemory.NewCalendarFeedRepository()
+	reviewRepo := sessionInmemory.NewReviewRepository()
 	
 	// Group Dining repositories
-	groupDiningPlanRepo := groupdiningrepos.NewGroupDiningPlanRepositoryInMemory()
+	groupDiningPlanRepo := groupdiningrepos.NewGroupDiningPlanRepositoryInMemory(outbox)
 	voteRepo := groupdiningrepos.NewVoteRepositoryInMemory()
 	
 	// 依賴注入 - 建立 Domain Services
 	userService := user.NewUserService(userRepo)
+	eventHub := messaging.NewHub(messaging.DefaultBufferSize)
 	friendshipService := friendship.NewFriendshipService(friendshipRepo)
-	pingService := ping.NewService(pingRepo)
-	restaurantRecommendationService := restaurant.NewRecommendationService(restaurantRepo)
+	interactionPolicy := social.NewInteractionPolicy(friendshipRepo, userRepo)
+	pingService := ping.NewService(pingRepo, interactionPolicy)
+	pingSeriesService := ping.NewSeriesService(pingSeriesRepo, pingRepo, interactionPolicy, 7*24*time.Hour)
+	diningHistory := appservices.NewDiningHistoryService(pingRepo, groupDiningPlanRepo, reviewRepo, restaurantRepo)
+	restaurantRecommendationService := restaurant.NewRecommendationService(restaurantRepo, routing.NewStraightLineProvider(0, 0), diningHistory, nil)
 	
 	// 依賴注入 - 建立 JWT Service
-	jwtService := auth.NewJWTService("your-secret-key-here", 24*time.Hour)
+	// 開發環境每次啟動產生臨時的 EdDSA 金鑰，不再使用寫死的 secret
+	devKey, err := auth.GenerateEd25519Key(fmt.Sprintf("dev-%d", time.Now().Unix()))
+	if err != nil {
+		log.Fatalf("Failed to generate JWT key: %v", err)
+	}
+	jwtKeys, err := auth.NewKeySet(devKey)
+	if err != nil {
+		log.Fatalf("Failed to create JWT key set: %v", err)
+	}
+	jwtService := auth.NewJWTServiceWithKeys(jwtKeys, 24*time.Hour, "pingnom-api")
+	sessionService := session.NewService(refreshTokenRepo, revocationList, 7*24*time.Hour, jwtService.TokenDuration())
+	
+	// 依賴注入 - 建立帳號驗證服務 (臨時 secret，驗證信內容輸出到 log)
+	accountSecret := make([]byte, 32)
+	if _, err := rand.Read(accountSecret); err != nil {
+		log.Fatalf("Failed to generate account token secret: %v", err)
+	}
+	accountService := user.NewAccountService(userRepo, actionTokenRepo, hex.EncodeToString(accountSecret), 24*time.Hour, time.Hour)
+	mailer := mail.NewLogMailer("Pingnom <no-reply@pingnom.app>")
+	accountMailService := appservices.NewAccountMailService(accountService, mailer, "http://localhost:3000")
+	
+	// 依賴注入 - 建立通知服務與送達管道 (推播使用 fake provider)
+	notificationService := notification.NewService(notificationRepo, userRepo,
+		notification.NewInAppChannel(eventHub),
+		notification.NewEmailChannel(mailer),
+		notification.NewPushChannel(push.NewFakeProvider()),
+	)
 	
 	// 依賴注入 - 建立 Auth Handlers
-	loginHandler := authcommands.NewLoginHandler(userService, jwtService)
+	loginHandler := authcommands.NewLoginHandler(userService, jwtService, sessionService)
+	refreshTokenHandler := authcommands.NewRefreshTokenHandler(userService, jwtService, sessionService)
+	logoutHandler := authcommands.NewLogoutHandler(sessionService)
+	verifyEmailHandler := authcommands.NewVerifyEmailHandler(accountService)
+	resendVerificationHandler := authcommands.NewResendVerificationHandler(accountMailService)
+	forgotPasswordHandler := authcommands.NewForgotPasswordHandler(accountMailService)
+	resetPasswordHandler := authcommands.NewResetPasswordHandler(accountService, sessionService)
 	
 	// 依賴注入 - 建立 Command Handlers
-	registerUserHandler := usercommands.NewRegisterUserHandler(userService)
+	registerUserHandler := usercommands.NewRegisterUserHandler(userService, accountMailService)
 	updateProfileHandler := usercommands.NewUpdateProfileHandler(userService)
 	updatePreferencesHandler := usercommands.NewUpdatePreferencesHandler(userService)
 	updatePrivacyHandler := usercommands.NewUpdatePrivacyHandler(userService)
+	updateNotificationPreferencesHandler := usercommands.NewUpdateNotificationPreferencesHandler(userService)
 	changePasswordHandler := usercommands.NewChangePasswordHandler(userService)
 	
 	// 依賴注入 - 建立 Query Handlers
 	getUserProfileHandler := userqueries.NewGetUserProfileHandler(userRepo)
-	searchUsersHandler := userqueries.NewSearchUsersHandler(userService)
+	searchUsersHandler := userqueries.NewSearchUsersHandler(userService, interactionPolicy)
 	
 	// 依賴注入 - 建立 Friendship Command Handlers
 	sendRequestHandler := friendshipcommands.NewSendFriendRequestHandler(friendshipService)
@@ -86,28 +161,96 @@ func main() {
 	// 依賴注入 - 建立 Ping Command Handlers
 	createPingHandler := pingcommands.NewCreatePingHandler(pingService)
 	respondToPingHandler := pingcommands.NewRespondToPingHandler(pingService)
+	createPingSeriesHandler := pingcommands.NewCreatePingSeriesHandler(pingSeriesService)
+	updatePingSeriesHandler := pingcommands.NewUpdatePingSeriesHandler(pingSeriesService)
+	cancelPingSeriesHandler := pingcommands.NewCancelPingSeriesHandler(pingSeriesService)
+	skipOccurrenceHandler := pingcommands.NewSkipOccurrenceHandler(pingSeriesService)
+	editOccurrenceHandler := pingcommands.NewEditOccurrenceHandler(pingSeriesService)
 	
 	// 依賴注入 - 建立 Ping Query Handlers
-	getUserPingsHandler := pingqueries.NewGetUserPingsHandler(pingService)
+	getUserPingsHandler := pingqueries.NewGetUserPingsHandler(pingService, interactionPolicy)
+	getPingSeriesHandler := pingqueries.NewGetPingSeriesHandler(pingSeriesService)
+	getUserPingSeriesHandler := pingqueries.NewGetUserPingSeriesHandler(pingSeriesService, interactionPolicy)
 	
 	// 依賴注入 - 建立 Restaurant Query Handlers
 	searchRestaurantsHandler := restaurantqueries.NewSearchRestaurantsHandler(restaurantRepo)
-	getRestaurantRecommendationsHandler := restaurantqueries.NewGetRestaurantRecommendationsHandler(restaurantRecommendationService)
+	getRestaurantRecommendationsHandler := restaurantqueries.NewGetRestaurantRecommendationsHandler(restaurantRecommendationService, pingRepo)
+	getTasteProfileHandler := restaurantqueries.NewGetTasteProfileHandler(restaurantRecommendationService, pingRepo)
 	
 	// 依賴注入 - 建立 Group Dining Service & Controller
-	groupDiningService := services.NewGroupDiningService(groupDiningPlanRepo, voteRepo)
+	// 邀請連結使用臨時 secret，重新啟動後舊連結失效
+	inviteSecret := make([]byte, 32)
+	if _, err := rand.Read(inviteSecret); err != nil {
+		log.Fatalf("Failed to generate invite link secret: %v", err)
+	}
+	groupDiningService := services.NewGroupDiningService(
+		groupDiningPlanRepo,
+		voteRepo,
+		services.NewCatalogRatingLookup(restaurantRepo),
+		services.NewScheduleAvailabilityLookup(availabilityRepo),
+		services.NewSocialBlockLookup(interactionPolicy),
+		services.NewUserProfileLookup(userRepo),
+		services.NewSocialInvitePolicy(interactionPolicy),
+		groupdiningusecases.InviteLinkSettings{
+			Signer:     aggregates.NewInviteLinkSigner(hex.EncodeToString(inviteSecret)),
+			DefaultTTL: 72 * time.Hour,
+			MaxTTL:     30 * 24 * time.Hour,
+			JoinURL:    "http://localhost:3000/group-dining/join",
+		},
+	)
 	groupDiningController := controllers.NewGroupDiningController(groupDiningService)
 	
 	// 依賴注入 - 建立 Middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, revocationList)
 	
 	// 依賴注入 - 建立 HTTP Handlers
-	authHandler := handlers.NewAuthHandler(loginHandler)
+	authHandler := handlers.NewAuthHandler(loginHandler, refreshTokenHandler, logoutHandler)
+	jwksHandler := handlers.NewJWKSHandler(jwtService)
+	realtimeHandler := handlers.NewRealtimeHandler(eventHub, revocationList)
+	notificationHandler := handlers.NewNotificationHandler(
+		notificationqueries.NewGetNotificationsHandler(notificationService),
+		notificationqueries.NewGetUnreadCountHandler(notificationService),
+		notificationcommands.NewMarkReadHandler(notificationService),
+		notificationcommands.NewMarkAllReadHandler(notificationService),
+	)
+	availabilityService := availability.NewService(availabilityRepo)
+	availabilityHandler := handlers.NewAvailabilityHandler(
+		availabilityqueries.NewGetAvailabilityHandler(availabilityService),
+		availabilitycommands.NewUpdateAvailabilityHandler(availabilityService),
+	)
+	calendarHandler := handlers.NewCalendarHandler(
+		appservices.NewCalendarService(pingRepo, groupDiningPlanRepo, userRepo, calendarFeedRepo, time.Hour),
+		"http://localhost:8090",
+	)
+	reviewService := review.NewService(reviewRepo, restaurantRepo, appservices.NewReviewVisitVerifier(pingRepo, groupDiningPlanRepo))
+	reviewHandler := handlers.NewReviewHandler(
+		reviewcommands.NewCreateReviewHandler(reviewService),
+		reviewcommands.NewEditReviewHandler(reviewService),
+		reviewcommands.NewDeleteReviewHandler(reviewService),
+		reviewcommands.NewRemoveReviewHandler(reviewService),
+		reviewcommands.NewSetHelpfulHandler(reviewService),
+		reviewqueries.NewGetRestaurantReviewsHandler(reviewService, interactionPolicy),
+		reviewqueries.NewGetUserReviewsHandler(reviewService),
+	)
+	catalogService := restaurant.NewCatalogService(restaurantRepo)
+	restaurantAdminHandler := handlers.NewRestaurantAdminHandler(
+		restaurantqueries.NewGetRestaurantHandler(catalogService),
+		restaurantcommands.NewCreateRestaurantHandler(catalogService),
+		restaurantcommands.NewUpdateRestaurantHandler(catalogService),
+		restaurantcommands.NewDeleteRestaurantHandler(catalogService),
+		restaurantcommands.NewImportRestaurantsHandler(catalogService),
+	)
+	userAdminHandler := handlers.NewUserAdminHandler(
+		usercommands.NewChangeUserRoleHandler(userService, sessionService),
+		usercommands.NewDeactivateUserHandler(userService, sessionService),
+	)
+	accountHandler := handlers.NewAccountHandler(verifyEmailHandler, resendVerificationHandler, forgotPasswordHandler, resetPasswordHandler)
 	userHandler := handlers.NewUserHandler(
 		registerUserHandler,
 		updateProfileHandler,
 		updatePreferencesHandler,
 		updatePrivacyHandler,
+		updateNotificationPreferencesHandler,
 		changePasswordHandler,
 		getUserProfileHandler,
 		searchUsersHandler,
@@ -127,9 +270,19 @@ func main() {
 		respondToPingHandler,
 		getUserPingsHandler,
 	)
+	pingSeriesHandler := handlers.NewPingSeriesHandler(
+		createPingSeriesHandler,
+		updatePingSeriesHandler,
+		cancelPingSeriesHandler,
+		skipOccurrenceHandler,
+		editOccurrenceHandler,
+		getPingSeriesHandler,
+		getUserPingSeriesHandler,
+	)
 	restaurantHandler := handlers.NewRestaurantHandler(
 		searchRestaurantsHandler,
 		getRestaurantRecommendationsHandler,
+		getTasteProfileHandler,
 	)
 	
 	// 設定 Gin 為開發模式
@@ -142,34 +295,50 @@ func main() {
 	engine.Use(gin.Logger())
 	engine.Use(gin.Recovery())
 	engine.Use(corsMiddleware())
+	engine.Use(middleware.ErrorHandler())
 	
 	// 使用新的 Router 來設定路由
 	router := routes.NewRouter(userHandler, authHandler, friendshipHandler, pingHandler, restaurantHandler, authMiddleware)
 	router.SetupRoutes(engine)
 	
 	// Group Dining 路由 (Require Auth)
-	groupDining := engine.Group("/api/v1/group-dining")
-	groupDining.Use(authMiddleware.RequireAuth())
-	{
-		// Create & Get Group Dining Plans
-		groupDining.POST("/plans", groupDiningController.CreateGroupDiningPlan)
-		groupDining.GET("/plans/:id", groupDiningController.GetGroupDiningPlan)
-		groupDining.GET("/plans", groupDiningController.GetGroupDiningPlansByCreator)
-		groupDining.GET("/participants/plans", groupDiningController.GetGroupDiningPlansByParticipant)
-		
-		// Manage Time Slots & Restaurant Options
-		groupDining.POST("/plans/:id/time-slots", groupDiningController.AddTimeSlot)
-		groupDining.POST("/plans/:id/restaurants", groupDiningController.AddRestaurantOption)
-		
-		// Join Plan & Voting
-		groupDining.POST("/plans/:id/join", groupDiningController.JoinGroupDiningPlan)
-		groupDining.POST("/plans/:id/start-voting", groupDiningController.StartVoting)
-		groupDining.POST("/plans/:id/vote", groupDiningController.SubmitVote)
-		groupDining.GET("/plans/:id/results", groupDiningController.GetVotingResults)
-		
-		// Finalize Plan
-		groupDining.POST("/plans/:id/finalize", groupDiningController.FinalizeGroupDiningPlan)
-	}
+	routes.SetupGroupDiningRoutes(engine, groupDiningController, authMiddleware)
+	routes.SetupWellKnownRoutes(engine, jwksHandler)
+	routes.SetupAccountRoutes(engine, accountHandler, authMiddleware)
+	routes.SetupRealtimeRoutes(engine, realtimeHandler, authMiddleware)
+	routes.SetupNotificationRoutes(engine, notificationHandler, authMiddleware)
+	routes.SetupAvailabilityRoutes(engine, availabilityHandler, authMiddleware)
+	routes.SetupCalendarRoutes(engine, calendarHandler, authMiddleware)
+	routes.SetupReviewRoutes(engine, reviewHandler, authMiddleware)
+	routes.SetupPingSeriesRoutes(engine, pingSeriesHandler, authMiddleware)
+	routes.SetupRestaurantAdminRoutes(engine, restaurantAdminHandler, authMiddleware)
+	routes.SetupUserAdminRoutes(engine, userAdminHandler, authMiddleware)
+	
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	
+	// 背景工作：派送 outbox 中的領域事件
+	dispatcher := events.NewDispatcher(outbox, events.DefaultDispatcherOptions())
+	appservices.NewRealtimeEventForwarder(eventHub, groupDiningPlanRepo).Register(dispatcher)
+	appservices.NewNotificationEventHandler(notificationService, userRepo).Register(dispatcher)
+	go dispatcher.Run(workerCtx)
+	
+	// 背景工作：排程器 (ping 到期、投票截止、用餐前 30 分鐘提醒、停用註冊 14 天仍未驗證的帳號、建立重複 ping 7 天內的發生)
+	jobScheduler := scheduler.NewScheduler(sessionInmemory.NewLeaseStore(), sessionInmemory.NewJobRunStore(), scheduler.DefaultOptions())
+	sweeper := appservices.NewUnverifiedAccountSweeper(accountService, sessionService, 14)
+	mealReminders := appservices.NewMealReminderJob(pingRepo, groupDiningPlanRepo, notificationService, 30*time.Minute)
+	jobScheduler.Register(scheduler.Job{Name: appservices.JobExpirePings, Interval: time.Minute, Run: pingService.ExpirePings})
+	jobScheduler.Register(scheduler.Job{Name: appservices.JobCloseOverdueVoting, Interval: time.Minute, Run: func(ctx context.Context) (int, error) {
+		return groupDiningService.CloseOverdueVoting(time.Now())
+	}})
+	jobScheduler.Register(scheduler.Job{Name: appservices.JobMealReminders, Interval: time.Minute, Run: mealReminders.SendDue})
+	jobScheduler.Register(scheduler.Job{Name: appservices.JobSweepUnverifiedAccounts, Interval: time.Hour, Run: sweeper.SweepOnce})
+	jobScheduler.Register(scheduler.Job{Name: appservices.JobMaterializePingSeries, Interval: time.Hour, Run: pingSeriesService.MaterializeDue})
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		jobScheduler.Run(workerCtx)
+	}()
 	
 	// 建立 HTTP 服務器
 	server := &http.Server{
@@ -181,7 +350,7 @@ func main() {
 	}
 	
 	// 創建測試帳號
-	createTestUsers(registerUserHandler)
+	createTestUsers(registerUserHandler, userService)
 	
 	// 創建測試餐廳資料
 	createTestRestaurants(restaurantRepo)
@@ -202,11 +371,19 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("🛑 Shutting down server...")
+	stopWorkers()
 	
 	// 優雅關閉服務器
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	
+	// 等待執行中的排程工作結束
+	select {
+	case <-schedulerDone:
+	case <-ctx.Done():
+		log.Println("Scheduler did not stop before shutdown timeout")
+	}
+	
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
@@ -214,8 +391,8 @@ func main() {
 	log.Println("✅ Server exited")
 }
 
-// createTestUsers 創建測試帳號
-func createTestUsers(registerHandler *usercommands.RegisterUserHandler) {
+// createTestUsers 創建測試帳號，Frank Li 為管理者
+func createTestUsers(registerHandler *usercommands.RegisterUserHandler, userService *user.UserService) {
 	ctx := context.Background()
 	
 	// 創建 Frank Li 測試帳號
@@ -225,10 +402,12 @@ func createTestUsers(registerHandler *usercommands.RegisterUserHandler) {
 		DisplayName: "Frank Li",
 	}
 	
-	if _, err := registerHandler.Handle(ctx, frankCmd); err != nil {
+	if frank, err := registerHandler.Handle(ctx, frankCmd); err != nil {
 		log.Printf("⚠️  Failed to create Frank Li test user: %v", err)
+	} else if err := makeAdmin(ctx, userService, frank.UserID); err != nil {
+		log.Printf("⚠️  Failed to make Frank Li an admin: %v", err)
 	} else {
-		log.Printf("✅ Created test user: Frank Li (testuser@pingnom.app)")
+		log.Printf("✅ Created test user: Frank Li (testuser@pingnom.app, admin)")
 	}
 	
 	// 創建 Alice Wang 測試帳號
@@ -245,6 +424,15 @@ func createTestUsers(registerHandler *usercommands.RegisterUserHandler) {
 	}
 }
 
+func makeAdmin(ctx context.Context, userService *user.UserService, rawUserID string) error {
+	userID, err := shared.NewUserIDFromString(rawUserID)
+	if err != nil {
+		return err
+	}
+	_, err = userService.ChangeUserRole(ctx, userID, user.RoleAdmin)
+	return err
+}
+
 // createTestRestaurants 創建測試餐廳資料
 func createTestRestaurants(restaurantRepo restaurant.Repository) {
 	ctx := context.Background()
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	restaurantcommands "github.com/chun-wei0413/pingnom/internal/application/commands/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence"
+)
+
+const usage = `Usage: import-restaurants [flags] <file>
+
+以 CSV 或 GeoJSON 檔案批次匯入餐廳到目錄，<file> 為 - 時從標準輸入讀取
+同名且距離 200 公尺內的餐廳視為重複，不會再次建立
+有資料列無法匯入時以狀態碼 1 結束
+
+Flags:
+`
+
+func main() {
+	configPath := flag.String("config", "", "配置檔目錄")
+	format := flag.String("format", "", "檔案格式 csv 或 geojson (預設依副檔名判斷)")
+	dryRun := flag.Bool("dry-run", false, "只驗證並列出匯入結果，不寫入資料庫")
+	jsonOutput := flag.Bool("json", false, "以 JSON 輸出完整的匯入報告")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path := flag.Arg(0)
+	importFormat := restaurant.ImportFormat(*format)
+	if importFormat == "" {
+		importFormat = restaurant.ImportFormatFromFilename(path)
+	}
+	if importFormat == "" {
+		log.Fatalf("Cannot detect the format of %s, use -format csv or -format geojson", path)
+	}
+
+	var file io.Reader = os.Stdin
+	if path != "-" {
+		opened, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open import file: %v", err)
+		}
+		defer opened.Close()
+		file = opened
+	}
+
+	// 載入配置
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 連接資料庫
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	catalogService := restaurant.NewCatalogService(persistence.NewPostgreSQLRestaurantRepository(db))
+	report, err := restaurantcommands.NewImportRestaurantsHandler(catalogService).Handle(context.Background(), restaurantcommands.ImportRestaurantsCommand{
+		Format: importFormat,
+		File:   file,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if report.Invalid > 0 {
+		os.Exit(1)
+	}
+}
+
+// printReport 列出未建立的資料列與統計
+func printReport(report *restaurant.ImportReport) {
+	for _, row := range report.Rows {
+		switch row.Status {
+		case restaurant.ImportStatusDuplicate:
+			if row.DuplicateOf != nil {
+				fmt.Printf("row %d  duplicate  %s (existing restaurant %s)\n", row.Row, row.Name, row.DuplicateOf)
+			} else {
+				fmt.Printf("row %d  duplicate  %s (same as row %d)\n", row.Row, row.Name, row.DuplicateRow)
+			}
+		case restaurant.ImportStatusInvalid:
+			fmt.Printf("row %d  invalid    %s: %s\n", row.Row, row.Name, row.Error)
+		}
+	}
+
+	if report.DryRun {
+		fmt.Printf("Dry run: %d rows, %d would be created, %d duplicates, %d invalid\n", report.Total, report.Valid, report.Duplicates, report.Invalid)
+		return
+	}
+	fmt.Printf("Imported %d rows: %d created, %d duplicates, %d invalid\n", report.Total, report.Created, report.Duplicates, report.Invalid)
+}
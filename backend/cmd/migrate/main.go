@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/migrations"
+)
+
+const usage = `Usage: migrate [flags] <command>
+
+Commands:
+  up        套用所有尚未執行的 migration
+  down      回滾最近的 migration (預設 1 個，可用 -steps 指定)
+  status    列出所有 migration 與套用時間
+  unlock    強制釋放 migration 鎖 (僅在前次執行中斷時使用)
+
+Flags:
+`
+
+func main() {
+	configPath := flag.String("config", "", "配置檔目錄")
+	steps := flag.Int("steps", 1, "down 要回滾的 migration 數量")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 載入配置
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 連接資料庫
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		reportMigrations("Applied", applied)
+		exitOnError(err)
+		if len(applied) == 0 {
+			log.Println("Database is up to date")
+		}
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		reportMigrations("Rolled back", rolledBack)
+		exitOnError(err)
+		if len(rolledBack) == 0 {
+			log.Println("Nothing to roll back")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		exitOnError(err)
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d  %-40s  %s\n", status.Version, status.Name, appliedAt)
+		}
+	case "unlock":
+		exitOnError(migrator.Unlock(ctx))
+		log.Println("Migration lock released")
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", command)
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func reportMigrations(action string, applied []migrations.Migration) {
+	for _, migration := range applied {
+		log.Printf("%s %04d_%s", action, migration.Version, migration.Name)
+	}
+}
+
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, migrations.ErrLocked) {
+		log.Fatalf("%v; run `migrate unlock` if the previous run crashed", err)
+	}
+	log.Fatalf("Migration failed: %v", err)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	usercommands "github.com/chun-wei0413/pingnom/internal/application/commands/user"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence"
+)
+
+const usage = `Usage: set-role [flags] <email> <role>
+
+變更用戶角色 (user、moderator、admin)，用於指定第一位管理者
+之後的角色變更可由管理者透過 PUT /api/v1/admin/users/:id/role 進行
+變更後會撤銷該用戶的所有 session，重新登入後取得帶有新角色的 token
+
+Flags:
+`
+
+func main() {
+	configPath := flag.String("config", "", "配置檔目錄")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	role, err := user.ParseRole(flag.Arg(1))
+	if err != nil {
+		log.Fatalf("Invalid role: %v", err)
+	}
+
+	// 載入配置
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 連接資料庫
+	db, err := config.NewDatabase(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	ctx := context.Background()
+	userService := user.NewUserService(persistence.NewPostgreSQLUserRepository(db))
+	sessionService := session.NewService(
+		persistence.NewPostgreSQLRefreshTokenRepository(db),
+		persistence.NewPostgreSQLRevocationList(db),
+		cfg.JWT.RefreshTokenTTL,
+		cfg.JWT.AccessTokenTTL,
+	)
+
+	target, err := userService.GetUserByEmail(ctx, flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to find user %s: %v", flag.Arg(0), err)
+	}
+
+	updated, err := usercommands.NewChangeUserRoleHandler(userService, sessionService).Handle(ctx, usercommands.ChangeUserRoleCommand{
+		UserID: target.ID,
+		Role:   role,
+	})
+	if err != nil {
+		log.Fatalf("Failed to change role: %v", err)
+	}
+
+	fmt.Printf("%s (%s) is now %s\n", updated.Email, updated.ID, updated.Role)
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/application/services"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+type VerifyEmailCommand struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type VerifyEmailResult struct {
+	UserID     string `json:"userId"`
+	Email      string `json:"email"`
+	IsVerified bool   `json:"isVerified"`
+}
+
+type VerifyEmailHandler struct {
+	accountService *user.AccountService
+}
+
+func NewVerifyEmailHandler(accountService *user.AccountService) *VerifyEmailHandler {
+	return &VerifyEmailHandler{
+		accountService: accountService,
+	}
+}
+
+func (h *VerifyEmailHandler) Handle(ctx context.Context, cmd VerifyEmailCommand) (*VerifyEmailResult, error) {
+	verified, err := h.accountService.VerifyEmail(ctx, cmd.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VerifyEmailResult{
+		UserID:     verified.ID.String(),
+		Email:      verified.Email,
+		IsVerified: verified.IsVerified,
+	}, nil
+}
+
+type ResendVerificationCommand struct {
+	UserID shared.UserID `json:"-"`
+}
+
+type ResendVerificationHandler struct {
+	mailService *services.AccountMailService
+}
+
+func NewResendVerificationHandler(mailService *services.AccountMailService) *ResendVerificationHandler {
+	return &ResendVerificationHandler{
+		mailService: mailService,
+	}
+}
+
+func (h *ResendVerificationHandler) Handle(ctx context.Context, cmd ResendVerificationCommand) error {
+	return h.mailService.SendVerification(ctx, cmd.UserID)
+}
@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
@@ -11,24 +12,29 @@ import (
 type LoginCommand struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8"`
+	DeviceID string `json:"deviceId,omitempty"` // 同一裝置重新登入會取代該裝置先前的 session
 }
 
 type LoginResult struct {
-	AccessToken string               `json:"accessToken"`
-	TokenType   string               `json:"tokenType"`
-	ExpiresIn   int64                `json:"expiresIn"`
-	User        *user.UserProfile    `json:"user"`
+	AccessToken      string            `json:"accessToken"`
+	TokenType        string            `json:"tokenType"`
+	ExpiresIn        int64             `json:"expiresIn"`
+	RefreshToken     string            `json:"refreshToken"`
+	RefreshExpiresIn int64             `json:"refreshExpiresIn"`
+	User             *user.UserProfile `json:"user"`
 }
 
 type LoginHandler struct {
-	userService *user.UserService
-	jwtService  *auth.JWTService
+	userService    *user.UserService
+	jwtService     *auth.JWTService
+	sessionService *session.Service
 }
 
-func NewLoginHandler(userService *user.UserService, jwtService *auth.JWTService) *LoginHandler {
+func NewLoginHandler(userService *user.UserService, jwtService *auth.JWTService, sessionService *session.Service) *LoginHandler {
 	return &LoginHandler{
-		userService: userService,
-		jwtService:  jwtService,
+		userService:    userService,
+		jwtService:     jwtService,
+		sessionService: sessionService,
 	}
 }
 
@@ -52,19 +58,31 @@ func (h *LoginHandler) Handle(ctx context.Context, cmd LoginCommand) (*LoginResu
 		return nil, shared.ErrUserInactive
 	}
 
-	// 生成 JWT Token
-	token, err := h.jwtService.GenerateToken(foundUser.ID, foundUser.Email)
+	// 建立 session 並發出 refresh token
+	refreshToken, rawRefreshToken, err := h.sessionService.Start(ctx, foundUser.ID, cmd.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTokenResult(h.jwtService, h.sessionService, foundUser, refreshToken, rawRefreshToken)
+}
+
+// newTokenResult 產生綁定 session 的 access token 並組成回應
+func newTokenResult(jwtService *auth.JWTService, sessionService *session.Service, u *user.User, refreshToken *session.RefreshToken, rawRefreshToken string) (*LoginResult, error) {
+	token, err := jwtService.GenerateSessionToken(u.ID, u.Email, string(u.Role.OrDefault()), refreshToken.FamilyID.String())
 	if err != nil {
 		return nil, err
 	}
 
 	// 建立用戶檔案
-	profile := &foundUser.Profile
+	profile := &u.Profile
 
 	return &LoginResult{
-		AccessToken: token,
-		TokenType:   "Bearer",
-		ExpiresIn:   24 * 60 * 60, // 24 hours in seconds
-		User:        profile,
+		AccessToken:      token,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(jwtService.TokenDuration().Seconds()),
+		RefreshToken:     rawRefreshToken,
+		RefreshExpiresIn: int64(sessionService.RefreshTTL().Seconds()),
+		User:             profile,
 	}, nil
-}
\ No newline at end of file
+}
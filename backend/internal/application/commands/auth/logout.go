@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+type LogoutCommand struct {
+	UserID         shared.UserID `json:"-"`
+	SessionID      string        `json:"-"` // 目前 access token 的 sid
+	TokenID        string        `json:"-"` // 目前 access token 的 jti
+	TokenExpiresAt time.Time     `json:"-"`
+	RefreshToken   string        `json:"refreshToken,omitempty"`
+	AllDevices     bool          `json:"allDevices,omitempty"`
+}
+
+type LogoutHandler struct {
+	sessionService *session.Service
+}
+
+func NewLogoutHandler(sessionService *session.Service) *LogoutHandler {
+	return &LogoutHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *LogoutHandler) Handle(ctx context.Context, cmd LogoutCommand) error {
+	// 登出所有裝置
+	if cmd.AllDevices {
+		if err := h.sessionService.RevokeAll(ctx, cmd.UserID); err != nil {
+			return err
+		}
+		return h.sessionService.RevokeAccessToken(ctx, cmd.TokenID, cmd.TokenExpiresAt)
+	}
+
+	// 撤銷目前的 session
+	if cmd.SessionID != "" {
+		familyID, err := shared.ParseID(cmd.SessionID)
+		if err != nil {
+			return shared.ErrInvalidInput
+		}
+		if err := h.sessionService.RevokeSession(ctx, familyID); err != nil {
+			return err
+		}
+	}
+
+	// 一併撤銷請求中帶的 refresh token (僅限本人的 token)
+	if cmd.RefreshToken != "" {
+		if err := h.sessionService.RevokeToken(ctx, cmd.UserID, cmd.RefreshToken); err != nil {
+			return err
+		}
+	}
+
+	// 沒有 sid 的舊 token 也要立即失效
+	return h.sessionService.RevokeAccessToken(ctx, cmd.TokenID, cmd.TokenExpiresAt)
+}
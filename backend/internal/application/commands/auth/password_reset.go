@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/application/services"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+type ForgotPasswordCommand struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ForgotPasswordHandler struct {
+	mailService *services.AccountMailService
+}
+
+func NewForgotPasswordHandler(mailService *services.AccountMailService) *ForgotPasswordHandler {
+	return &ForgotPasswordHandler{
+		mailService: mailService,
+	}
+}
+
+// Handle 不論帳號是否存在都回傳成功，避免被用來探測已註冊的 Email
+func (h *ForgotPasswordHandler) Handle(ctx context.Context, cmd ForgotPasswordCommand) error {
+	return h.mailService.SendPasswordReset(ctx, cmd.Email)
+}
+
+type ResetPasswordCommand struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=8"`
+}
+
+type ResetPasswordHandler struct {
+	accountService *user.AccountService
+	sessionService *session.Service
+}
+
+func NewResetPasswordHandler(accountService *user.AccountService, sessionService *session.Service) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		accountService: accountService,
+		sessionService: sessionService,
+	}
+}
+
+func (h *ResetPasswordHandler) Handle(ctx context.Context, cmd ResetPasswordCommand) error {
+	updated, err := h.accountService.ResetPassword(ctx, cmd.Token, cmd.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	// 密碼重設後登出所有裝置
+	return h.sessionService.RevokeAll(ctx, updated.ID)
+}
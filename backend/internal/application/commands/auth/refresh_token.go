@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
+)
+
+type RefreshTokenCommand struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type RefreshTokenHandler struct {
+	userService    *user.UserService
+	jwtService     *auth.JWTService
+	sessionService *session.Service
+}
+
+func NewRefreshTokenHandler(userService *user.UserService, jwtService *auth.JWTService, sessionService *session.Service) *RefreshTokenHandler {
+	return &RefreshTokenHandler{
+		userService:    userService,
+		jwtService:     jwtService,
+		sessionService: sessionService,
+	}
+}
+
+func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenCommand) (*LoginResult, error) {
+	// 換發 refresh token，舊 token 立即失效
+	refreshToken, rawRefreshToken, err := h.sessionService.Rotate(ctx, cmd.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	foundUser, err := h.userService.GetUserByID(ctx, refreshToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 停用的帳號不得再換發 token
+	if !foundUser.IsActive {
+		if err := h.sessionService.RevokeSession(ctx, refreshToken.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, shared.ErrUserInactive
+	}
+
+	return newTokenResult(h.jwtService, h.sessionService, foundUser, refreshToken, rawRefreshToken)
+}
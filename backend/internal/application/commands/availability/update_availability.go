@@ -0,0 +1,30 @@
+package availability
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// UpdateAvailabilityCommand 取代用戶的每週可用時段與行程
+type UpdateAvailabilityCommand struct {
+	UserID     shared.UserID               `json:"-"`
+	TimeZone   string                      `json:"timeZone"`
+	Windows    []availability.WeeklyWindow `json:"windows"`
+	BusyBlocks []availability.BusyBlock    `json:"busyBlocks"`
+}
+
+type UpdateAvailabilityHandler struct {
+	availabilityService *availability.Service
+}
+
+func NewUpdateAvailabilityHandler(availabilityService *availability.Service) *UpdateAvailabilityHandler {
+	return &UpdateAvailabilityHandler{
+		availabilityService: availabilityService,
+	}
+}
+
+func (h *UpdateAvailabilityHandler) Handle(ctx context.Context, cmd UpdateAvailabilityCommand) (*availability.Schedule, error) {
+	return h.availabilityService.UpdateSchedule(ctx, cmd.UserID, cmd.TimeZone, cmd.Windows, cmd.BusyBlocks)
+}
@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// MarkReadCommand 將一則通知標記為已讀
+type MarkReadCommand struct {
+	UserID         shared.UserID `json:"-"`
+	NotificationID shared.ID     `json:"-"`
+}
+
+type MarkReadHandler struct {
+	notificationService *notification.Service
+}
+
+func NewMarkReadHandler(notificationService *notification.Service) *MarkReadHandler {
+	return &MarkReadHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (h *MarkReadHandler) Handle(ctx context.Context, cmd MarkReadCommand) error {
+	return h.notificationService.MarkRead(ctx, cmd.UserID, cmd.NotificationID)
+}
+
+// MarkAllReadCommand 將用戶所有未讀通知標記為已讀
+type MarkAllReadCommand struct {
+	UserID shared.UserID `json:"-"`
+}
+
+type MarkAllReadHandler struct {
+	notificationService *notification.Service
+}
+
+func NewMarkAllReadHandler(notificationService *notification.Service) *MarkAllReadHandler {
+	return &MarkAllReadHandler{
+		notificationService: notificationService,
+	}
+}
+
+// Handle 回傳標記為已讀的通知數
+func (h *MarkAllReadHandler) Handle(ctx context.Context, cmd MarkAllReadCommand) (int64, error) {
+	return h.notificationService.MarkAllRead(ctx, cmd.UserID)
+}
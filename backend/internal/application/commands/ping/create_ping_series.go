@@ -0,0 +1,51 @@
+package ping
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// CreatePingSeriesCommand 建立重複的 ping，例如每週的團隊午餐
+type CreatePingSeriesCommand struct {
+	CreatedBy   shared.UserID   `json:"-"`
+	Title       string          `json:"title" validate:"required,min=1,max=100"`
+	Description string          `json:"description" validate:"max=500"`
+	PingType    ping.PingType   `json:"pingType" validate:"required,oneof=breakfast lunch dinner snack"`
+	StartAt     time.Time       `json:"startAt" validate:"required"`
+	TimeZone    string          `json:"timeZone"`                  // IANA 時區，預設 Asia/Taipei
+	RRule       string          `json:"rrule" validate:"required"` // 例如 FREQ=WEEKLY;BYDAY=TU;COUNT=10
+	Invitees    []shared.UserID `json:"invitees" validate:"required,min=1"`
+}
+
+type CreatePingSeriesHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewCreatePingSeriesHandler(seriesService *ping.SeriesService) *CreatePingSeriesHandler {
+	return &CreatePingSeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// Handle creates the series and returns it with the occurrences created so far
+func (h *CreatePingSeriesHandler) Handle(ctx context.Context, cmd CreatePingSeriesCommand) (*ping.Series, []*ping.Ping, error) {
+	rule, err := ping.ParseRecurrenceRule(cmd.RRule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return h.seriesService.CreateSeries(
+		ctx,
+		cmd.CreatedBy,
+		cmd.Title,
+		cmd.Description,
+		cmd.PingType,
+		cmd.StartAt,
+		cmd.TimeZone,
+		rule,
+		cmd.Invitees,
+	)
+}
@@ -0,0 +1,143 @@
+package ping
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// UpdatePingSeriesCommand 修改整個系列，未單獨修改過的發生會一併更新
+type UpdatePingSeriesCommand struct {
+	UserID      shared.UserID    `json:"-"`
+	SeriesID    shared.ID        `json:"-"`
+	Title       string           `json:"title" validate:"required,min=1,max=100"`
+	Description string           `json:"description" validate:"max=500"`
+	Location    *shared.Location `json:"location"`
+	StartAt     time.Time        `json:"startAt" validate:"required"`
+	TimeZone    string           `json:"timeZone"`
+	RRule       string           `json:"rrule" validate:"required"`
+}
+
+type UpdatePingSeriesHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewUpdatePingSeriesHandler(seriesService *ping.SeriesService) *UpdatePingSeriesHandler {
+	return &UpdatePingSeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *UpdatePingSeriesHandler) Handle(ctx context.Context, cmd UpdatePingSeriesCommand) (*ping.Series, []*ping.Ping, error) {
+	rule, err := ping.ParseRecurrenceRule(cmd.RRule)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := validLocation(cmd.Location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return h.seriesService.UpdateSeries(
+		ctx,
+		cmd.SeriesID,
+		cmd.UserID,
+		cmd.Title,
+		cmd.Description,
+		location,
+		cmd.StartAt,
+		cmd.TimeZone,
+		rule,
+	)
+}
+
+// CancelPingSeriesCommand 取消整個系列與之後的所有發生
+type CancelPingSeriesCommand struct {
+	UserID   shared.UserID `json:"-"`
+	SeriesID shared.ID     `json:"-"`
+}
+
+type CancelPingSeriesHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewCancelPingSeriesHandler(seriesService *ping.SeriesService) *CancelPingSeriesHandler {
+	return &CancelPingSeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *CancelPingSeriesHandler) Handle(ctx context.Context, cmd CancelPingSeriesCommand) (*ping.Series, error) {
+	return h.seriesService.CancelSeries(ctx, cmd.SeriesID, cmd.UserID)
+}
+
+// SkipOccurrenceCommand 跳過系列中的單一發生
+type SkipOccurrenceCommand struct {
+	UserID shared.UserID `json:"-"`
+	PingID shared.ID     `json:"-"`
+}
+
+type SkipOccurrenceHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewSkipOccurrenceHandler(seriesService *ping.SeriesService) *SkipOccurrenceHandler {
+	return &SkipOccurrenceHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *SkipOccurrenceHandler) Handle(ctx context.Context, cmd SkipOccurrenceCommand) (*ping.Ping, error) {
+	return h.seriesService.SkipOccurrence(ctx, cmd.PingID, cmd.UserID)
+}
+
+// EditOccurrenceCommand 只修改系列中的單一發生，不影響其他發生
+type EditOccurrenceCommand struct {
+	UserID      shared.UserID    `json:"-"`
+	PingID      shared.ID        `json:"-"`
+	Title       string           `json:"title" validate:"required,min=1,max=100"`
+	Description string           `json:"description" validate:"max=500"`
+	ScheduledAt time.Time        `json:"scheduledAt" validate:"required"`
+	Location    *shared.Location `json:"location"`
+}
+
+type EditOccurrenceHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewEditOccurrenceHandler(seriesService *ping.SeriesService) *EditOccurrenceHandler {
+	return &EditOccurrenceHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *EditOccurrenceHandler) Handle(ctx context.Context, cmd EditOccurrenceCommand) (*ping.Ping, error) {
+	location, err := validLocation(cmd.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.seriesService.EditOccurrence(
+		ctx,
+		cmd.PingID,
+		cmd.UserID,
+		cmd.Title,
+		cmd.Description,
+		cmd.ScheduledAt,
+		location,
+	)
+}
+
+// validLocation 檢查選填地點的經緯度
+func validLocation(location *shared.Location) (*shared.Location, error) {
+	if location == nil {
+		return nil, nil
+	}
+	valid, err := shared.NewLocation(location.Latitude, location.Longitude, location.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &valid, nil
+}
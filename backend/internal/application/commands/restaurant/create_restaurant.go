@@ -0,0 +1,26 @@
+package restaurant
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+)
+
+// CreateRestaurantCommand 新增餐廳到目錄 (管理者)
+type CreateRestaurantCommand struct {
+	restaurant.RestaurantDetails
+}
+
+type CreateRestaurantHandler struct {
+	catalogService *restaurant.CatalogService
+}
+
+func NewCreateRestaurantHandler(catalogService *restaurant.CatalogService) *CreateRestaurantHandler {
+	return &CreateRestaurantHandler{
+		catalogService: catalogService,
+	}
+}
+
+func (h *CreateRestaurantHandler) Handle(ctx context.Context, cmd CreateRestaurantCommand) (*restaurant.Restaurant, error) {
+	return h.catalogService.CreateRestaurant(ctx, cmd.RestaurantDetails)
+}
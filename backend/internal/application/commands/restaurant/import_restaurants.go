@@ -0,0 +1,33 @@
+package restaurant
+
+import (
+	"context"
+	"io"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+)
+
+// ImportRestaurantsCommand 以 CSV 或 GeoJSON 檔案批次匯入餐廳，DryRun 時只產生報告
+type ImportRestaurantsCommand struct {
+	Format restaurant.ImportFormat
+	File   io.Reader
+	DryRun bool
+}
+
+type ImportRestaurantsHandler struct {
+	catalogService *restaurant.CatalogService
+}
+
+func NewImportRestaurantsHandler(catalogService *restaurant.CatalogService) *ImportRestaurantsHandler {
+	return &ImportRestaurantsHandler{
+		catalogService: catalogService,
+	}
+}
+
+func (h *ImportRestaurantsHandler) Handle(ctx context.Context, cmd ImportRestaurantsCommand) (*restaurant.ImportReport, error) {
+	records, err := restaurant.ParseImportFile(cmd.Format, cmd.File)
+	if err != nil {
+		return nil, err
+	}
+	return h.catalogService.Import(ctx, records, cmd.DryRun)
+}
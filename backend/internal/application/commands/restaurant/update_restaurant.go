@@ -0,0 +1,48 @@
+package restaurant
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// UpdateRestaurantCommand 更新目錄中的餐廳 (管理者)，IsActive 不為 nil 時同時啟用或停用
+type UpdateRestaurantCommand struct {
+	RestaurantID shared.RestaurantID `json:"-"`
+	restaurant.RestaurantDetails
+	IsActive *bool `json:"isActive,omitempty"`
+}
+
+type UpdateRestaurantHandler struct {
+	catalogService *restaurant.CatalogService
+}
+
+func NewUpdateRestaurantHandler(catalogService *restaurant.CatalogService) *UpdateRestaurantHandler {
+	return &UpdateRestaurantHandler{
+		catalogService: catalogService,
+	}
+}
+
+func (h *UpdateRestaurantHandler) Handle(ctx context.Context, cmd UpdateRestaurantCommand) (*restaurant.Restaurant, error) {
+	return h.catalogService.UpdateRestaurant(ctx, cmd.RestaurantID, cmd.RestaurantDetails, cmd.IsActive)
+}
+
+// DeleteRestaurantCommand 從目錄移除餐廳 (管理者)
+type DeleteRestaurantCommand struct {
+	RestaurantID shared.RestaurantID `json:"-"`
+}
+
+type DeleteRestaurantHandler struct {
+	catalogService *restaurant.CatalogService
+}
+
+func NewDeleteRestaurantHandler(catalogService *restaurant.CatalogService) *DeleteRestaurantHandler {
+	return &DeleteRestaurantHandler{
+		catalogService: catalogService,
+	}
+}
+
+func (h *DeleteRestaurantHandler) Handle(ctx context.Context, cmd DeleteRestaurantCommand) error {
+	return h.catalogService.DeleteRestaurant(ctx, cmd.RestaurantID)
+}
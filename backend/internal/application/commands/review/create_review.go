@@ -0,0 +1,33 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// CreateReviewCommand 為一次已完成的用餐撰寫餐廳評論
+type CreateReviewCommand struct {
+	UserID       shared.UserID       `json:"-"`
+	RestaurantID shared.RestaurantID `json:"-"`
+	VisitType    review.VisitType    `json:"visitType" validate:"required,oneof=ping group_dining_plan"`
+	VisitID      string              `json:"visitId" validate:"required"`
+	Stars        int                 `json:"stars" validate:"required,min=1,max=5"`
+	Text         string              `json:"text" validate:"max=2000"`
+	PhotoURLs    []string            `json:"photoUrls" validate:"max=10"`
+}
+
+type CreateReviewHandler struct {
+	reviewService *review.Service
+}
+
+func NewCreateReviewHandler(reviewService *review.Service) *CreateReviewHandler {
+	return &CreateReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *CreateReviewHandler) Handle(ctx context.Context, cmd CreateReviewCommand) (*review.Review, error) {
+	return h.reviewService.CreateReview(ctx, cmd.UserID, cmd.RestaurantID, cmd.VisitType, cmd.VisitID, cmd.Stars, cmd.Text, cmd.PhotoURLs)
+}
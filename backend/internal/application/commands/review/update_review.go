@@ -0,0 +1,91 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// EditReviewCommand 更新自己寫的評論
+type EditReviewCommand struct {
+	UserID    shared.UserID `json:"-"`
+	ReviewID  shared.ID     `json:"-"`
+	Stars     int           `json:"stars" validate:"required,min=1,max=5"`
+	Text      string        `json:"text" validate:"max=2000"`
+	PhotoURLs []string      `json:"photoUrls" validate:"max=10"`
+}
+
+type EditReviewHandler struct {
+	reviewService *review.Service
+}
+
+func NewEditReviewHandler(reviewService *review.Service) *EditReviewHandler {
+	return &EditReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *EditReviewHandler) Handle(ctx context.Context, cmd EditReviewCommand) (*review.Review, error) {
+	return h.reviewService.EditReview(ctx, cmd.UserID, cmd.ReviewID, cmd.Stars, cmd.Text, cmd.PhotoURLs)
+}
+
+// DeleteReviewCommand 刪除自己寫的評論
+type DeleteReviewCommand struct {
+	UserID   shared.UserID `json:"-"`
+	ReviewID shared.ID     `json:"-"`
+}
+
+type DeleteReviewHandler struct {
+	reviewService *review.Service
+}
+
+func NewDeleteReviewHandler(reviewService *review.Service) *DeleteReviewHandler {
+	return &DeleteReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *DeleteReviewHandler) Handle(ctx context.Context, cmd DeleteReviewCommand) error {
+	return h.reviewService.DeleteReview(ctx, cmd.UserID, cmd.ReviewID)
+}
+
+// RemoveReviewCommand 版主移除任何人的評論
+type RemoveReviewCommand struct {
+	ReviewID shared.ID `json:"-"`
+}
+
+type RemoveReviewHandler struct {
+	reviewService *review.Service
+}
+
+func NewRemoveReviewHandler(reviewService *review.Service) *RemoveReviewHandler {
+	return &RemoveReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *RemoveReviewHandler) Handle(ctx context.Context, cmd RemoveReviewCommand) error {
+	return h.reviewService.RemoveReview(ctx, cmd.ReviewID)
+}
+
+// SetHelpfulCommand 標記或取消標記別人的評論為有幫助
+type SetHelpfulCommand struct {
+	UserID   shared.UserID `json:"-"`
+	ReviewID shared.ID     `json:"-"`
+	Helpful  bool          `json:"-"`
+}
+
+type SetHelpfulHandler struct {
+	reviewService *review.Service
+}
+
+func NewSetHelpfulHandler(reviewService *review.Service) *SetHelpfulHandler {
+	return &SetHelpfulHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *SetHelpfulHandler) Handle(ctx context.Context, cmd SetHelpfulCommand) (*review.Review, error) {
+	return h.reviewService.SetHelpful(ctx, cmd.UserID, cmd.ReviewID, cmd.Helpful)
+}
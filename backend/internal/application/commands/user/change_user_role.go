@@ -0,0 +1,52 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// ChangeUserRoleCommand 管理者變更其他用戶的角色
+type ChangeUserRoleCommand struct {
+	ActorID shared.UserID `json:"-"`
+	UserID  shared.UserID `json:"-"`
+	Role    user.Role     `json:"role" binding:"required"`
+}
+
+type ChangeUserRoleHandler struct {
+	userService    *user.UserService
+	sessionService *session.Service
+}
+
+func NewChangeUserRoleHandler(userService *user.UserService, sessionService *session.Service) *ChangeUserRoleHandler {
+	return &ChangeUserRoleHandler{
+		userService:    userService,
+		sessionService: sessionService,
+	}
+}
+
+func (h *ChangeUserRoleHandler) Handle(ctx context.Context, cmd ChangeUserRoleCommand) (*user.User, error) {
+	// 避免管理者誤將自己降級而無人能管理角色
+	if cmd.ActorID == cmd.UserID {
+		return nil, fmt.Errorf("%w: cannot change your own role", shared.ErrPermissionDenied)
+	}
+
+	role, err := user.ParseRole(string(cmd.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := h.userService.ChangeUserRole(ctx, cmd.UserID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	// 角色記錄在 access token 中，撤銷既有 session 讓用戶以新角色重新登入
+	if err := h.sessionService.RevokeAll(ctx, cmd.UserID); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// DeactivateUserCommand 管理者停用其他用戶的帳號
+type DeactivateUserCommand struct {
+	ActorID shared.UserID `json:"-"`
+	UserID  shared.UserID `json:"-"`
+}
+
+type DeactivateUserHandler struct {
+	userService    *user.UserService
+	sessionService *session.Service
+}
+
+func NewDeactivateUserHandler(userService *user.UserService, sessionService *session.Service) *DeactivateUserHandler {
+	return &DeactivateUserHandler{
+		userService:    userService,
+		sessionService: sessionService,
+	}
+}
+
+func (h *DeactivateUserHandler) Handle(ctx context.Context, cmd DeactivateUserCommand) error {
+	if cmd.ActorID == cmd.UserID {
+		return fmt.Errorf("%w: cannot deactivate your own account", shared.ErrPermissionDenied)
+	}
+
+	if err := h.userService.DeactivateUser(ctx, cmd.UserID); err != nil {
+		return err
+	}
+
+	// 停用的帳號無法再登入，同時撤銷已發出的 token
+	return h.sessionService.RevokeAll(ctx, cmd.UserID)
+}
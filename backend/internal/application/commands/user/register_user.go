@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"log"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
@@ -22,13 +23,20 @@ type RegisterUserResult struct {
 	CreatedAt   string `json:"createdAt"`
 }
 
+// VerificationSender 寄送 Email 驗證信
+type VerificationSender interface {
+	SendVerification(ctx context.Context, userID shared.UserID) error
+}
+
 type RegisterUserHandler struct {
-	userService *user.UserService
+	userService        *user.UserService
+	verificationSender VerificationSender
 }
 
-func NewRegisterUserHandler(userService *user.UserService) *RegisterUserHandler {
+func NewRegisterUserHandler(userService *user.UserService, verificationSender VerificationSender) *RegisterUserHandler {
 	return &RegisterUserHandler{
-		userService: userService,
+		userService:        userService,
+		verificationSender: verificationSender,
 	}
 }
 
@@ -39,6 +47,13 @@ func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserComman
 		return nil, err
 	}
 	
+	// 寄送驗證信失敗不影響註冊，用戶可之後重新寄送
+	if h.verificationSender != nil {
+		if err := h.verificationSender.SendVerification(ctx, newUser.ID); err != nil {
+			log.Printf("Failed to send verification email to user %s: %v", newUser.ID, err)
+		}
+	}
+	
 	return &RegisterUserResult{
 		UserID:      newUser.ID.String(),
 		Email:       newUser.Email,
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+type UpdateNotificationPreferencesCommand struct {
+	UserID         shared.UserID   `json:"-"`
+	InApp          bool            `json:"inApp"`
+	Email          bool            `json:"email"`
+	Push           bool            `json:"push"`
+	PingInvites    bool            `json:"pingInvites"`
+	FriendRequests bool            `json:"friendRequests"`
+	MealReminders  bool            `json:"mealReminders"`
+	QuietHours     user.QuietHours `json:"quietHours"`
+}
+
+type UpdateNotificationPreferencesHandler struct {
+	userService *user.UserService
+}
+
+func NewUpdateNotificationPreferencesHandler(userService *user.UserService) *UpdateNotificationPreferencesHandler {
+	return &UpdateNotificationPreferencesHandler{
+		userService: userService,
+	}
+}
+
+func (h *UpdateNotificationPreferencesHandler) Handle(ctx context.Context, cmd UpdateNotificationPreferencesCommand) error {
+	preferences := user.NotificationPreferences{
+		InApp:          cmd.InApp,
+		Email:          cmd.Email,
+		Push:           cmd.Push,
+		PingInvites:    cmd.PingInvites,
+		FriendRequests: cmd.FriendRequests,
+		MealReminders:  cmd.MealReminders,
+		QuietHours:     cmd.QuietHours,
+	}
+
+	return h.userService.UpdateNotificationPreferences(ctx, cmd.UserID, preferences)
+}
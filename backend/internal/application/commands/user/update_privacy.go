@@ -12,6 +12,7 @@ type UpdatePrivacyCommand struct {
 	IsDiscoverable     bool         `json:"isDiscoverable"`
 	ShowLocation       bool         `json:"showLocation"`
 	AllowFriendRequest bool         `json:"allowFriendRequest"`
+	PingAudience       string       `json:"pingAudience"` // friends (預設) 或 everyone
 }
 
 type UpdatePrivacyHandler struct {
@@ -25,10 +26,16 @@ func NewUpdatePrivacyHandler(userService *user.UserService) *UpdatePrivacyHandle
 }
 
 func (h *UpdatePrivacyHandler) Handle(ctx context.Context, cmd UpdatePrivacyCommand) error {
+	audience, err := user.ParsePingAudience(cmd.PingAudience)
+	if err != nil {
+		return err
+	}
+
 	settings := user.PrivacySettings{
 		IsDiscoverable:     cmd.IsDiscoverable,
 		ShowLocation:       cmd.ShowLocation,
 		AllowFriendRequest: cmd.AllowFriendRequest,
+		PingAudience:       audience,
 	}
 	
 	return h.userService.UpdatePrivacySettings(ctx, cmd.UserID, settings)
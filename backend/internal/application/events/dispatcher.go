@@ -0,0 +1,193 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// AllEvents 訂閱所有事件類型 (例如稽核、分析用途)
+const AllEvents = "*"
+
+// maxRetryDelay 派送失敗後重試間隔的上限
+const maxRetryDelay = 5 * time.Minute
+
+// Subscriber 處理派送來的領域事件；回傳錯誤時事件稍後會重新派送
+type Subscriber interface {
+	HandleEvent(ctx context.Context, message Message) error
+}
+
+// SubscriberFunc 讓一般函式可作為 Subscriber
+type SubscriberFunc func(ctx context.Context, message Message) error
+
+func (f SubscriberFunc) HandleEvent(ctx context.Context, message Message) error {
+	return f(ctx, message)
+}
+
+// DispatcherOptions 設定 Dispatcher 的輪詢與重試行為，零值欄位使用預設值
+type DispatcherOptions struct {
+	PollInterval time.Duration // 檢查 outbox 的間隔
+	BatchSize    int           // 每次取出的事件數
+	MaxAttempts  int           // 派送失敗超過此次數即放棄
+	Lease        time.Duration // 取出的事件被鎖定的時間，須大於處理一批事件所需時間
+	Retention    time.Duration // 已派送事件保留多久後刪除，0 代表不刪除
+}
+
+// DefaultDispatcherOptions 回傳預設的 Dispatcher 設定
+func DefaultDispatcherOptions() DispatcherOptions {
+	return DispatcherOptions{
+		PollInterval: 500 * time.Millisecond,
+		BatchSize:    100,
+		MaxAttempts:  10,
+		Lease:        time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// Dispatcher 在程序內將 outbox 中的事件派送給訂閱者
+type Dispatcher struct {
+	store   OutboxStore
+	options DispatcherOptions
+
+	mu          sync.RWMutex
+	subscribers map[string][]Subscriber
+}
+
+func NewDispatcher(store OutboxStore, options DispatcherOptions) *Dispatcher {
+	defaults := DefaultDispatcherOptions()
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaults.PollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaults.BatchSize
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaults.MaxAttempts
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaults.Lease
+	}
+
+	return &Dispatcher{
+		store:       store,
+		options:     options,
+		subscribers: make(map[string][]Subscriber),
+	}
+}
+
+// Subscribe 註冊 eventType 的訂閱者；eventType 為 AllEvents 時接收所有事件
+func (d *Dispatcher) Subscribe(eventType string, subscriber Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers[eventType] = append(d.subscribers[eventType], subscriber)
+}
+
+// Run 持續派送 outbox 中的事件直到 ctx 結束
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.options.PollInterval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		// 一次取滿代表可能還有積壓，繼續派送直到清空
+		for {
+			dispatched, err := d.DispatchPending(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("Outbox dispatch failed: %v", err)
+			}
+			if err != nil || dispatched < d.options.BatchSize {
+				break
+			}
+		}
+
+		if d.options.Retention > 0 && time.Since(lastPurge) >= time.Hour {
+			lastPurge = time.Now()
+			if purged, err := d.store.PurgeDispatched(ctx, lastPurge.Add(-d.options.Retention)); err != nil && ctx.Err() == nil {
+				log.Printf("Outbox purge failed: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d dispatched outbox event(s)", purged)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending 取出一批待派送的事件交給訂閱者，回傳處理的事件數
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	messages, err := d.store.ClaimPending(ctx, d.options.BatchSize, d.options.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		if err := d.deliver(ctx, message); err != nil {
+			d.fail(ctx, message, err)
+			continue
+		}
+		if err := d.store.MarkDispatched(ctx, message.ID, time.Now()); err != nil {
+			return 0, err
+		}
+	}
+	return len(messages), nil
+}
+
+// deliver 依序交給所有訂閱者，任一訂閱者失敗即回傳錯誤
+func (d *Dispatcher) deliver(ctx context.Context, message Message) error {
+	d.mu.RLock()
+	subscribers := append(append([]Subscriber(nil), d.subscribers[message.EventType]...), d.subscribers[AllEvents]...)
+	d.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		if err := handleSafely(ctx, subscriber, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fail 記錄派送失敗並安排重試，超過 MaxAttempts 後放棄
+func (d *Dispatcher) fail(ctx context.Context, message Message, cause error) {
+	attempts := message.Attempts + 1
+
+	var retryAt *time.Time
+	if attempts < d.options.MaxAttempts {
+		next := time.Now().Add(retryDelay(attempts))
+		retryAt = &next
+		log.Printf("Event %s (%s) failed on attempt %d, retrying at %s: %v", message.ID, message.EventType, attempts, next.Format(time.RFC3339), cause)
+	} else {
+		log.Printf("Event %s (%s) failed %d times, giving up: %v", message.ID, message.EventType, attempts, cause)
+	}
+
+	if err := d.store.MarkFailed(ctx, message.ID, cause.Error(), retryAt); err != nil {
+		log.Printf("Failed to record failure of event %s: %v", message.ID, err)
+	}
+}
+
+// handleSafely 呼叫訂閱者並將 panic 轉為錯誤，避免單一訂閱者拖垮派送流程
+func handleSafely(ctx context.Context, subscriber Subscriber, message Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("subscriber panicked: %v", r)
+		}
+	}()
+	return subscriber.HandleEvent(ctx, message)
+}
+
+// retryDelay 以指數退避計算第 attempts 次失敗後的重試間隔
+func retryDelay(attempts int) time.Duration {
+	if attempts >= 9 {
+		return maxRetryDelay
+	}
+	delay := time.Second << uint(attempts)
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
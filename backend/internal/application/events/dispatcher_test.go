@@ -0,0 +1,91 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+type testEvent struct {
+	Name string `json:"name"`
+}
+
+func (e testEvent) EventName() string   { return e.Name }
+func (e testEvent) AggregateID() string { return "aggregate-1" }
+
+func appendEvents(t *testing.T, outbox *inmemory.Outbox, names ...string) {
+	t.Helper()
+
+	var recorder shared.EventRecorder
+	for _, name := range names {
+		recorder.Record(testEvent{Name: name})
+	}
+	if err := outbox.Append(&recorder); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+}
+
+func TestDispatchPendingDeliversToMatchingSubscribers(t *testing.T) {
+	ctx := context.Background()
+	outbox := inmemory.NewOutbox()
+	appendEvents(t, outbox, "a.happened", "b.happened")
+
+	dispatcher := events.NewDispatcher(outbox, events.DispatcherOptions{})
+	var onlyA, all []string
+	dispatcher.Subscribe("a.happened", events.SubscriberFunc(func(ctx context.Context, message events.Message) error {
+		onlyA = append(onlyA, message.EventType)
+		return nil
+	}))
+	dispatcher.Subscribe(events.AllEvents, events.SubscriberFunc(func(ctx context.Context, message events.Message) error {
+		all = append(all, message.EventType)
+		return nil
+	}))
+
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 2 {
+		t.Fatalf("DispatchPending() = %d, %v, want 2", n, err)
+	}
+	if len(onlyA) != 1 || onlyA[0] != "a.happened" {
+		t.Errorf("a.happened subscriber received %v", onlyA)
+	}
+	if len(all) != 2 || all[0] != "a.happened" || all[1] != "b.happened" {
+		t.Errorf("AllEvents subscriber received %v, want both events in order", all)
+	}
+
+	// 已派送的事件不會再次派送
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 {
+		t.Errorf("DispatchPending() again = %d, %v, want 0", n, err)
+	}
+}
+
+func TestDispatchPendingGivesUpOnFailingSubscribers(t *testing.T) {
+	ctx := context.Background()
+	outbox := inmemory.NewOutbox()
+	appendEvents(t, outbox, "a.happened", "a.happened")
+
+	dispatcher := events.NewDispatcher(outbox, events.DispatcherOptions{MaxAttempts: 1})
+	calls := 0
+	dispatcher.Subscribe("a.happened", events.SubscriberFunc(func(ctx context.Context, message events.Message) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporarily unavailable")
+		}
+		panic("subscriber bug")
+	}))
+
+	// 失敗與 panic 都不會中斷同一批次的其他事件
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 2 {
+		t.Fatalf("DispatchPending() = %d, %v, want 2", n, err)
+	}
+	if calls != 2 {
+		t.Errorf("subscriber called %d times, want 2", calls)
+	}
+
+	// MaxAttempts 為 1 時失敗即放棄，不再重新派送
+	if n, err := dispatcher.DispatchPending(ctx); err != nil || n != 0 {
+		t.Errorf("DispatchPending() after giving up = %d, %v, want 0", n, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package events 將聚合記錄的領域事件經由 transactional outbox 派送給訂閱者
+//
+// repository 在儲存聚合的同一個交易內把事件寫入 outbox，Dispatcher 於交易
+// 提交後從 outbox 取出事件交給訂閱者，因此程序在提交後崩潰也不會遺失事件。
+// 派送語意為 at-least-once：訂閱者可能收到重複的事件，須以 Message.ID 做冪等處理。
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Message 是 outbox 中的一筆領域事件
+type Message struct {
+	ID          string          `json:"id"`
+	EventType   string          `json:"eventType"`
+	AggregateID string          `json:"aggregateId"`
+	Payload     json.RawMessage `json:"payload"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Attempts    int             `json:"attempts"` // 先前派送失敗的次數
+}
+
+// NewMessages 將聚合記錄的事件序列化為 outbox 訊息
+func NewMessages(recorded []shared.RecordedEvent) ([]Message, error) {
+	messages := make([]Message, 0, len(recorded))
+	for _, r := range recorded {
+		payload, err := json.Marshal(r.Event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s event: %w", r.Event.EventName(), err)
+		}
+		messages = append(messages, Message{
+			ID:          r.ID,
+			EventType:   r.Event.EventName(),
+			AggregateID: r.Event.AggregateID(),
+			Payload:     payload,
+			OccurredAt:  r.OccurredAt,
+		})
+	}
+	return messages, nil
+}
+
+// Decode 將事件內容還原為對應的領域事件結構，例如 ping.PingResponded
+func (m Message) Decode(v interface{}) error {
+	if err := json.Unmarshal(m.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s event %s: %w", m.EventType, m.ID, err)
+	}
+	return nil
+}
+
+// OutboxStore 讀取並更新 outbox 中待派送的事件
+type OutboxStore interface {
+	// ClaimPending 依發生順序取出最多 limit 筆可派送的事件，並鎖定 lease 時間，
+	// 避免多個 instance 同時派送同一筆事件
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+	// MarkDispatched 標記事件已派送完成
+	MarkDispatched(ctx context.Context, id string, dispatchedAt time.Time) error
+	// MarkFailed 記錄派送失敗；retryAt 為 nil 代表不再重試
+	MarkFailed(ctx context.Context, id string, reason string, retryAt *time.Time) error
+	// PurgeDispatched 刪除在 before 之前已派送完成的事件，回傳刪除筆數
+	PurgeDispatched(ctx context.Context, before time.Time) (int64, error)
+}
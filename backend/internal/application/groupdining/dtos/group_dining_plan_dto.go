@@ -6,14 +6,23 @@ import (
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
 )
 
+// CreateGroupDiningPlanRequest 的 CreatedBy 與以下請求的 ActorID、UserID 皆為 access token 中的用戶，不接受由請求內容指定
 type CreateGroupDiningPlanRequest struct {
-	CreatedBy   string `json:"created_by" validate:"required"`
+	CreatedBy   string `json:"-"`
 	Title       string `json:"title" validate:"required,min=1,max=100"`
 	Description string `json:"description" validate:"max=500"`
+	// VotingMethod 預設為 approval
+	VotingMethod string `json:"voting_method,omitempty" validate:"omitempty,oneof=approval ranked_choice borda score"`
+	// AutoFinalize 全員投票或投票截止時自動確認最高票的時段與餐廳
+	AutoFinalize bool   `json:"auto_finalize"`
+	TieBreak     string `json:"tie_break,omitempty" validate:"omitempty,oneof=earliest_slot highest_rating creator_decides random"`
+	// TieBreakSeed 只用於 random 規則，未提供時隨機產生
+	TieBreakSeed *int64 `json:"tie_break_seed,omitempty"`
 }
 
 type AddTimeSlotRequest struct {
 	PlanID      string    `json:"plan_id" validate:"required"`
+	ActorID     string    `json:"-"`
 	StartTime   time.Time `json:"start_time" validate:"required"`
 	EndTime     time.Time `json:"end_time" validate:"required"`
 	Description string    `json:"description" validate:"max=100"`
@@ -21,6 +30,7 @@ type AddTimeSlotRequest struct {
 
 type AddRestaurantOptionRequest struct {
 	PlanID      string  `json:"plan_id" validate:"required"`
+	ActorID     string  `json:"-"`
 	Name        string  `json:"name" validate:"required,min=1,max=100"`
 	Address     string  `json:"address" validate:"max=200"`
 	Latitude    float64 `json:"latitude" validate:"min=-90,max=90"`
@@ -28,31 +38,79 @@ type AddRestaurantOptionRequest struct {
 	CuisineType string  `json:"cuisine_type" validate:"max=50"`
 }
 
+// JoinGroupDiningPlanRequest 沒有 InviteToken 時只有受邀者可以加入 (等同接受邀請)
+// 以邀請連結加入時 PlanID 可以省略，由 token 決定；參與者名稱一律取自用戶的個人檔案
 type JoinGroupDiningPlanRequest struct {
-	PlanID      string `json:"plan_id" validate:"required"`
-	UserID      string `json:"user_id" validate:"required"`
-	DisplayName string `json:"display_name" validate:"required,min=1,max=50"`
+	PlanID      string `json:"plan_id"`
+	UserID      string `json:"-"`
+	InviteToken string `json:"invite_token,omitempty"`
+}
+
+// InviteParticipantsRequest 建立者邀請用戶加入計畫，受邀者需接受後才成為參與者
+type InviteParticipantsRequest struct {
+	PlanID  string   `json:"plan_id" validate:"required"`
+	ActorID string   `json:"-"`
+	UserIDs []string `json:"user_ids" validate:"required,min=1,max=20"`
+}
+
+type RespondToInvitationRequest struct {
+	PlanID string `json:"plan_id" validate:"required"`
+	UserID string `json:"-"`
+	Accept bool   `json:"accept"`
+}
+
+// CreateInviteLinkRequest 未提供 expires_in_hours 時使用設定的預設有效時間
+type CreateInviteLinkRequest struct {
+	PlanID         string `json:"plan_id" validate:"required"`
+	ActorID        string `json:"-"`
+	ExpiresInHours int    `json:"expires_in_hours,omitempty" validate:"min=0"`
+	SingleUse      bool   `json:"single_use"`
+	// MaxParticipants 透過此連結加入時計畫最多可有的參與者人數 (含建立者)，0 表示不限
+	MaxParticipants int `json:"max_participants,omitempty" validate:"min=0,max=100"`
 }
 
 type StartVotingRequest struct {
 	PlanID         string     `json:"plan_id" validate:"required"`
+	ActorID        string     `json:"-"`
 	VotingDeadline *time.Time `json:"voting_deadline,omitempty"`
 }
 
 type SubmitVoteRequest struct {
 	PlanID        string   `json:"plan_id" validate:"required"`
-	UserID        string   `json:"user_id" validate:"required"`
+	UserID        string   `json:"-"`
 	TimeSlotIDs   []string `json:"time_slot_ids" validate:"required,min=1"`
 	RestaurantIDs []string `json:"restaurant_ids" validate:"required,min=1"`
 	Comment       string   `json:"comment,omitempty" validate:"max=200"`
+	// Scores 只用於評分投票，以選項 ID 為 key，每個選到的選項 1-5 分
+	Scores map[string]int `json:"scores,omitempty"`
 }
 
 type FinalizeGroupDiningPlanRequest struct {
 	PlanID       string `json:"plan_id" validate:"required"`
+	ActorID      string `json:"-"`
 	TimeSlotID   string `json:"time_slot_id" validate:"required"`
 	RestaurantID string `json:"restaurant_id" validate:"required"`
 }
 
+// SuggestTimeSlotsRequest 依參與者公開的可用時間建議時段，未提供的欄位使用預設值
+type SuggestTimeSlotsRequest struct {
+	PlanID          string    `json:"plan_id" validate:"required"`
+	ActorID         string    `json:"-"`
+	MealType        string    `json:"meal_type" validate:"required,oneof=breakfast lunch snack dinner"`
+	From            time.Time `json:"from,omitempty"`             // 預設為現在
+	To              time.Time `json:"to,omitempty"`               // 預設為 from 之後 7 天
+	DurationMinutes int       `json:"duration_minutes,omitempty"` // 預設 90 分鐘
+	TimeZone        string    `json:"time_zone,omitempty"`        // 餐別時間的時區，預設為建立者的時區
+	Limit           int       `json:"limit,omitempty"`            // 預設 3 個，最多 10 個
+	// Apply 直接將建議的時段加入計畫的時間選項
+	Apply bool `json:"apply"`
+}
+
+type CancelGroupDiningPlanRequest struct {
+	PlanID  string `json:"plan_id" validate:"required"`
+	ActorID string `json:"-"`
+}
+
 type GroupDiningPlanResponse struct {
 	ID                  string                       `json:"id"`
 	CreatedBy           string                       `json:"created_by"`
@@ -67,6 +125,33 @@ type GroupDiningPlanResponse struct {
 	CreatedAt           time.Time                    `json:"created_at"`
 	UpdatedAt           time.Time                    `json:"updated_at"`
 	VotingDeadline      *time.Time                  `json:"voting_deadline,omitempty"`
+	VotingMethod        string                       `json:"voting_method"`
+	AutoFinalize        *AutoFinalizeResponse        `json:"auto_finalize,omitempty"`
+	Finalization        *FinalizationAuditResponse   `json:"finalization,omitempty"`
+}
+
+type AutoFinalizeResponse struct {
+	TieBreak string `json:"tie_break"`
+	Seed     int64  `json:"seed,omitempty"`
+}
+
+// FinalizationAuditResponse 說明確認的時段與餐廳是如何選出的
+type FinalizationAuditResponse struct {
+	Trigger    string                  `json:"trigger"`
+	TieBreak   string                  `json:"tie_break,omitempty"`
+	Seed       int64                   `json:"seed,omitempty"`
+	TimeSlot   OptionSelectionResponse `json:"time_slot"`
+	Restaurant OptionSelectionResponse `json:"restaurant"`
+	DecidedBy  string                  `json:"decided_by,omitempty"`
+	DecidedAt  time.Time               `json:"decided_at"`
+}
+
+type OptionSelectionResponse struct {
+	WinnerID string             `json:"winner_id,omitempty"`
+	TopVotes int                `json:"top_votes"`
+	Tied     []string           `json:"tied,omitempty"`
+	Ratings  map[string]float64 `json:"ratings,omitempty"`
+	Method   string             `json:"method"`
 }
 
 type TimeSlotResponse struct {
@@ -87,11 +172,36 @@ type RestaurantOptionResponse struct {
 	VoteCount   int     `json:"vote_count"`
 }
 
+// ParticipantResponse 的 status 為 pending、accepted 或 declined，只有 accepted 的用戶參與投票
 type ParticipantResponse struct {
-	UserID      string    `json:"user_id"`
-	DisplayName string    `json:"display_name"`
-	JoinedAt    time.Time `json:"joined_at"`
-	HasVoted    bool      `json:"has_voted"`
+	UserID      string     `json:"user_id"`
+	DisplayName string     `json:"display_name"`
+	Status      string     `json:"status"`
+	InvitedBy   string     `json:"invited_by,omitempty"`
+	JoinedAt    time.Time  `json:"joined_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	HasVoted    bool       `json:"has_voted"`
+}
+
+// InviteLinkResponse 的 token 只在建立時回傳一次
+type InviteLinkResponse struct {
+	ID              string    `json:"id"`
+	PlanID          string    `json:"plan_id"`
+	Token           string    `json:"token"`
+	URL             string    `json:"url"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	SingleUse       bool      `json:"single_use"`
+	MaxParticipants int       `json:"max_participants"`
+}
+
+// InvitationResponse 用戶收到且尚未回覆的計畫邀請
+type InvitationResponse struct {
+	PlanID     string    `json:"plan_id"`
+	Title      string    `json:"title"`
+	CreatedBy  string    `json:"created_by"`
+	InvitedBy  string    `json:"invited_by"`
+	InvitedAt  time.Time `json:"invited_at"`
+	PlanStatus string    `json:"plan_status"`
 }
 
 type VoteResponse struct {
@@ -107,14 +217,127 @@ type VoteChoice struct {
 	ID       string `json:"id"`
 	Type     string `json:"type"`
 	OptionID string `json:"option_id"`
+	Score    int    `json:"score,omitempty"`
 }
 
+// VotingResultsResponse 的 vote_count 為依投票方式計算的分數
 type VotingResultsResponse struct {
 	TotalParticipants int                          `json:"total_participants"`
 	VotedParticipants int                          `json:"voted_participants"`
 	VotingProgress    float64                      `json:"voting_progress"`
+	VotingMethod      string                       `json:"voting_method"`
 	TimeSlots         []TimeSlotResponse           `json:"time_slots"`
 	Restaurants       []RestaurantOptionResponse   `json:"restaurants"`
+	// 只有 ranked_choice 會有每輪的計票過程
+	TimeSlotRounds   []RunoffRoundResponse `json:"time_slot_rounds,omitempty"`
+	RestaurantRounds []RunoffRoundResponse `json:"restaurant_rounds,omitempty"`
+}
+
+type RunoffRoundResponse struct {
+	Round      int            `json:"round"`
+	Counts     map[string]int `json:"counts"`
+	Eliminated []string       `json:"eliminated,omitempty"`
+}
+
+type TimeSlotSuggestionsResponse struct {
+	PlanID      string                       `json:"plan_id"`
+	MealType    string                       `json:"meal_type"`
+	TimeZone    string                       `json:"time_zone"`
+	Suggestions []TimeSlotSuggestionResponse `json:"suggestions"`
+	// MissingParticipants 尚未設定可用時間、不列入計算的參與者
+	MissingParticipants []string `json:"missing_participants"`
+	// Plan 只有 apply 時回傳加入時段後的計畫
+	Plan *GroupDiningPlanResponse `json:"plan,omitempty"`
+}
+
+type TimeSlotSuggestionResponse struct {
+	StartTime               time.Time `json:"start_time"`
+	EndTime                 time.Time `json:"end_time"`
+	AvailableParticipants   []string  `json:"available_participants"`
+	UnavailableParticipants []string  `json:"unavailable_participants"`
+}
+
+// VoteSubmittedPayload 是 communication.EventPlanVoteSubmitted 的內容，附上最新的投票結果
+type VoteSubmittedPayload struct {
+	PlanID  string                 `json:"plan_id"`
+	VoterID string                 `json:"voter_id"`
+	Results *VotingResultsResponse `json:"results"`
+}
+
+// VotingClosedPayload 是 communication.EventPlanVotingClosed 的內容，附上截止時的投票結果
+type VotingClosedPayload struct {
+	PlanID  string                 `json:"plan_id"`
+	Results *VotingResultsResponse `json:"results"`
+}
+
+func ToVotingResultsResponse(plan *aggregates.GroupDiningPlan) *VotingResultsResponse {
+	results := plan.GetVotingResults()
+
+	timeSlots := make([]TimeSlotResponse, len(plan.TimeSlots))
+	for i, ts := range plan.TimeSlots {
+		timeSlots[i] = TimeSlotResponse{
+			ID:          ts.ID,
+			StartTime:   ts.StartTime,
+			EndTime:     ts.EndTime,
+			Description: ts.Description,
+			VoteCount:   ts.VoteCount,
+		}
+	}
+
+	restaurants := make([]RestaurantOptionResponse, len(plan.RestaurantOptions))
+	for i, ro := range plan.RestaurantOptions {
+		restaurants[i] = RestaurantOptionResponse{
+			ID:          ro.ID,
+			Name:        ro.Name,
+			Address:     ro.Address,
+			Latitude:    ro.Latitude,
+			Longitude:   ro.Longitude,
+			CuisineType: ro.CuisineType,
+			VoteCount:   ro.VoteCount,
+		}
+	}
+
+	return &VotingResultsResponse{
+		TotalParticipants: results["total_participants"].(int),
+		VotedParticipants: results["voted_participants"].(int),
+		VotingProgress:    results["voting_progress"].(float64),
+		VotingMethod:      string(plan.VotingMethod),
+		TimeSlots:         timeSlots,
+		Restaurants:       restaurants,
+	}
+}
+
+// ToVotingResultsResponseFromTally 以實際計票結果取代計畫上保存的票數
+func ToVotingResultsResponseFromTally(plan *aggregates.GroupDiningPlan, tally aggregates.VotingTally) *VotingResultsResponse {
+	response := ToVotingResultsResponse(plan)
+	response.VotingMethod = string(tally.Method)
+
+	for i := range response.TimeSlots {
+		response.TimeSlots[i].VoteCount = tally.TimeSlots.Counts[response.TimeSlots[i].ID]
+	}
+	for i := range response.Restaurants {
+		response.Restaurants[i].VoteCount = tally.Restaurants.Counts[response.Restaurants[i].ID]
+	}
+	response.TimeSlotRounds = toRunoffRoundResponses(tally.TimeSlots.Rounds)
+	response.RestaurantRounds = toRunoffRoundResponses(tally.Restaurants.Rounds)
+
+	return response
+}
+
+func toRunoffRoundResponses(rounds []aggregates.RunoffRound) []RunoffRoundResponse {
+	if len(rounds) == 0 {
+		return nil
+	}
+
+	responses := make([]RunoffRoundResponse, len(rounds))
+	for i, round := range rounds {
+		responses[i] = RunoffRoundResponse{
+			Round:      i + 1,
+			Counts:     round.Counts,
+			Eliminated: round.Eliminated,
+		}
+	}
+	return responses
 }
 
 func ToGroupDiningPlanResponse(plan *aggregates.GroupDiningPlan) *GroupDiningPlanResponse {
@@ -147,7 +370,10 @@ func ToGroupDiningPlanResponse(plan *aggregates.GroupDiningPlan) *GroupDiningPla
 		participants[i] = ParticipantResponse{
 			UserID:      p.UserID,
 			DisplayName: p.DisplayName,
+			Status:      string(p.Status.OrDefault()),
+			InvitedBy:   p.InvitedBy,
 			JoinedAt:    p.JoinedAt,
+			RespondedAt: p.RespondedAt,
 			HasVoted:    p.HasVoted,
 		}
 	}
@@ -164,6 +390,18 @@ func ToGroupDiningPlanResponse(plan *aggregates.GroupDiningPlan) *GroupDiningPla
 		CreatedAt:         plan.CreatedAt,
 		UpdatedAt:         plan.UpdatedAt,
 		VotingDeadline:    plan.VotingDeadline,
+		VotingMethod:      string(plan.VotingMethod),
+	}
+
+	if plan.AutoFinalize.Enabled {
+		response.AutoFinalize = &AutoFinalizeResponse{
+			TieBreak: string(plan.AutoFinalize.TieBreak),
+			Seed:     plan.AutoFinalize.Seed,
+		}
+	}
+
+	if plan.Finalization != nil {
+		response.Finalization = ToFinalizationAuditResponse(plan.Finalization)
 	}
 
 	if plan.ConfirmedTimeSlot != nil {
@@ -191,6 +429,46 @@ func ToGroupDiningPlanResponse(plan *aggregates.GroupDiningPlan) *GroupDiningPla
 	return response
 }
 
+// ToInvitationResponse 回傳用戶在計畫中的邀請，沒有邀請時回傳 nil
+func ToInvitationResponse(plan *aggregates.GroupDiningPlan, userID string) *InvitationResponse {
+	for _, p := range plan.Participants {
+		if p.UserID != userID {
+			continue
+		}
+		return &InvitationResponse{
+			PlanID:     plan.ID,
+			Title:      plan.Title,
+			CreatedBy:  plan.CreatedBy,
+			InvitedBy:  p.InvitedBy,
+			InvitedAt:  p.JoinedAt,
+			PlanStatus: string(plan.Status),
+		}
+	}
+	return nil
+}
+
+func ToFinalizationAuditResponse(audit *aggregates.FinalizationAudit) *FinalizationAuditResponse {
+	toSelection := func(selection aggregates.OptionSelection) OptionSelectionResponse {
+		return OptionSelectionResponse{
+			WinnerID: selection.WinnerID,
+			TopVotes: selection.TopVotes,
+			Tied:     selection.Tied,
+			Ratings:  selection.Ratings,
+			Method:   string(selection.Method),
+		}
+	}
+
+	return &FinalizationAuditResponse{
+		Trigger:    string(audit.Trigger),
+		TieBreak:   string(audit.TieBreak),
+		Seed:       audit.Seed,
+		TimeSlot:   toSelection(audit.TimeSlot),
+		Restaurant: toSelection(audit.Restaurant),
+		DecidedBy:  audit.DecidedBy,
+		DecidedAt:  audit.DecidedAt,
+	}
+}
+
 func ToVoteResponse(vote *aggregates.Vote) *VoteResponse {
 	choices := make([]VoteChoice, len(vote.Choices))
 	for i, choice := range vote.Choices {
@@ -198,6 +476,7 @@ func ToVoteResponse(vote *aggregates.Vote) *VoteResponse {
 			ID:       choice.ID,
 			Type:     string(choice.Type),
 			OptionID: choice.OptionID,
+			Score:    choice.Score,
 		}
 	}
 
@@ -1,6 +1,9 @@
 package interfaces
 
 import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
 )
 
@@ -8,10 +11,20 @@ type GroupDiningPlanRepository interface {
 	Create(plan *aggregates.GroupDiningPlan) error
 	GetByID(id string) (*aggregates.GroupDiningPlan, error)
 	GetByCreator(createdBy string) ([]*aggregates.GroupDiningPlan, error)
-	GetByParticipant(userID string) ([]*aggregates.GroupDiningPlan, error)
+	// GetByParticipant 取得用戶已加入 (accepted) 的計畫，不含 excludeCreators 建立的計畫
+	GetByParticipant(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error)
+	// GetByInvitee 取得用戶受邀但尚未回覆 (pending) 的計畫，不含 excludeCreators 建立的計畫
+	GetByInvitee(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error)
 	Update(plan *aggregates.GroupDiningPlan) error
+	// RedeemInviteLink 儲存用戶以邀請連結加入的結果，以已儲存的使用次數與參與人數重新檢查連結限制，
+	// 同時使用同一連結時單次連結只有一位用戶能加入 (ErrInviteLinkUsed)，人數也不會超過上限 (ErrPlanFull)
+	RedeemInviteLink(plan *aggregates.GroupDiningPlan, linkID, userID string) error
 	Delete(id string) error
 	List(limit, offset int) ([]*aggregates.GroupDiningPlan, error)
+	// GetVotingPastDeadline 取得仍在投票中但截止時間已到 (<= now) 的計畫
+	GetVotingPastDeadline(now time.Time) ([]*aggregates.GroupDiningPlan, error)
+	// GetConfirmedStartingBetween 取得已確認且確認時段在 [from, to) 開始的計畫
+	GetConfirmedStartingBetween(from, to time.Time) ([]*aggregates.GroupDiningPlan, error)
 }
 
 type VoteRepository interface {
@@ -21,4 +34,34 @@ type VoteRepository interface {
 	GetByPlan(planID string) ([]*aggregates.Vote, error)
 	Update(vote *aggregates.Vote) error
 	Delete(id string) error
-}
\ No newline at end of file
+}
+
+// RestaurantRatingLookup 查詢餐廳選項在餐廳目錄中的評分，供 highest_rating 平手規則使用
+type RestaurantRatingLookup interface {
+	// Ratings 回傳以選項 ID 為 key 的評分，找不到對應餐廳的選項不會出現在結果中
+	Ratings(options []aggregates.RestaurantOption) (map[string]float64, error)
+}
+
+// AvailabilityLookup 查詢參與者公開的可用時間，供建議時段使用
+type AvailabilityLookup interface {
+	// Schedules 回傳有設定可用時間的用戶，尚未設定的用戶不會出現在結果中
+	Schedules(userIDs []string) ([]*availability.Schedule, error)
+}
+
+// BlockLookup 查詢用戶之間的封鎖關係，有封鎖關係的用戶不能一起參加聚餐，也看不到對方建立的計畫
+type BlockLookup interface {
+	// BlockedUsers 回傳與用戶有封鎖關係的用戶 ID，不論是誰封鎖誰
+	BlockedUsers(userID string) (map[string]bool, error)
+}
+
+// ProfileLookup 查詢用戶的個人檔案，參與者名稱一律取自用戶的顯示名稱
+type ProfileLookup interface {
+	// DisplayName 回傳用戶的顯示名稱，用戶不存在時回傳 shared.ErrUserNotFound
+	DisplayName(userID string) (string, error)
+}
+
+// InvitePolicy 檢查建立者是否可以邀請用戶加入計畫
+type InvitePolicy interface {
+	// CheckInvite 回傳不能邀請的原因，例如對方只接受好友的邀請或雙方有封鎖關係
+	CheckInvite(inviterID, inviteeID string) error
+}
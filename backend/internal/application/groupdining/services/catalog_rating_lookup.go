@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+)
+
+// CatalogRatingLookup 以餐廳目錄的評分實作 interfaces.RestaurantRatingLookup
+type CatalogRatingLookup struct {
+	restaurantRepo restaurant.Repository
+}
+
+func NewCatalogRatingLookup(restaurantRepo restaurant.Repository) *CatalogRatingLookup {
+	return &CatalogRatingLookup{
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+func (l *CatalogRatingLookup) Ratings(options []aggregates.RestaurantOption) (map[string]float64, error) {
+	ctx := context.Background()
+
+	ratings := make(map[string]float64, len(options))
+	for _, option := range options {
+		// 沒有座標的選項無法對應到目錄中的餐廳
+		if option.Latitude == 0 && option.Longitude == 0 {
+			continue
+		}
+
+		// 餐廳選項只記錄名稱與座標，附近同名的目錄餐廳視為同一間
+		location := restaurant.Location{Latitude: option.Latitude, Longitude: option.Longitude}
+		nearby, err := l.restaurantRepo.FindByLocation(ctx, option.Latitude, option.Longitude, restaurant.PlaceMatchRadiusKm)
+		if err != nil {
+			return nil, err
+		}
+		for _, rest := range nearby {
+			if rest.MatchesPlace(option.Name, location) {
+				ratings[option.ID] = rest.Rating
+				break
+			}
+		}
+	}
+
+	return ratings, nil
+}
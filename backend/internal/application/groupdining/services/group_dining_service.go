@@ -1,6 +1,8 @@
 package services
 
 import (
+	"time"
+
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/usecases"
@@ -11,27 +13,45 @@ type GroupDiningService struct {
 	addTimeSlotUC      *usecases.AddTimeSlotUseCase
 	addRestaurantUC    *usecases.AddRestaurantOptionUseCase
 	joinPlanUC         *usecases.JoinGroupDiningPlanUseCase
+	inviteUC           *usecases.InviteParticipantsUseCase
+	respondUC          *usecases.RespondToInvitationUseCase
+	createInviteLinkUC *usecases.CreateInviteLinkUseCase
 	startVotingUC      *usecases.StartVotingUseCase
 	submitVoteUC       *usecases.SubmitVoteUseCase
 	finalizePlanUC     *usecases.FinalizeGroupDiningPlanUseCase
+	cancelPlanUC       *usecases.CancelGroupDiningPlanUseCase
 	getPlanUC          *usecases.GetGroupDiningPlanUseCase
 	getVotingResultsUC *usecases.GetVotingResultsUseCase
+	closeVotingUC      *usecases.CloseOverdueVotingUseCase
+	suggestSlotsUC     *usecases.SuggestTimeSlotsUseCase
 }
 
 func NewGroupDiningService(
 	planRepo interfaces.GroupDiningPlanRepository,
 	voteRepo interfaces.VoteRepository,
+	ratings interfaces.RestaurantRatingLookup,
+	availability interfaces.AvailabilityLookup,
+	blocks interfaces.BlockLookup,
+	profiles interfaces.ProfileLookup,
+	invites interfaces.InvitePolicy,
+	inviteLinks usecases.InviteLinkSettings,
 ) *GroupDiningService {
 	return &GroupDiningService{
-		createPlanUC:       usecases.NewCreateGroupDiningPlanUseCase(planRepo),
+		createPlanUC:       usecases.NewCreateGroupDiningPlanUseCase(planRepo, profiles),
 		addTimeSlotUC:      usecases.NewAddTimeSlotUseCase(planRepo),
 		addRestaurantUC:    usecases.NewAddRestaurantOptionUseCase(planRepo),
-		joinPlanUC:         usecases.NewJoinGroupDiningPlanUseCase(planRepo),
+		joinPlanUC:         usecases.NewJoinGroupDiningPlanUseCase(planRepo, blocks, profiles, inviteLinks.Signer),
+		inviteUC:           usecases.NewInviteParticipantsUseCase(planRepo, blocks, profiles, invites),
+		respondUC:          usecases.NewRespondToInvitationUseCase(planRepo, blocks),
+		createInviteLinkUC: usecases.NewCreateInviteLinkUseCase(planRepo, inviteLinks),
 		startVotingUC:      usecases.NewStartVotingUseCase(planRepo),
-		submitVoteUC:       usecases.NewSubmitVoteUseCase(planRepo, voteRepo),
+		submitVoteUC:       usecases.NewSubmitVoteUseCase(planRepo, voteRepo, ratings),
 		finalizePlanUC:     usecases.NewFinalizeGroupDiningPlanUseCase(planRepo),
-		getPlanUC:          usecases.NewGetGroupDiningPlanUseCase(planRepo),
-		getVotingResultsUC: usecases.NewGetVotingResultsUseCase(planRepo),
+		cancelPlanUC:       usecases.NewCancelGroupDiningPlanUseCase(planRepo),
+		getPlanUC:          usecases.NewGetGroupDiningPlanUseCase(planRepo, blocks),
+		getVotingResultsUC: usecases.NewGetVotingResultsUseCase(planRepo, voteRepo),
+		closeVotingUC:      usecases.NewCloseOverdueVotingUseCase(planRepo, voteRepo, ratings),
+		suggestSlotsUC:     usecases.NewSuggestTimeSlotsUseCase(planRepo, availability),
 	}
 }
 
@@ -43,6 +63,11 @@ func (s *GroupDiningService) AddTimeSlot(req *dtos.AddTimeSlotRequest) (*dtos.Gr
 	return s.addTimeSlotUC.Execute(req)
 }
 
+// SuggestTimeSlots 依參與者的可用時間建議時段，apply 時直接加入計畫
+func (s *GroupDiningService) SuggestTimeSlots(req *dtos.SuggestTimeSlotsRequest) (*dtos.TimeSlotSuggestionsResponse, error) {
+	return s.suggestSlotsUC.Execute(req)
+}
+
 func (s *GroupDiningService) AddRestaurantOption(req *dtos.AddRestaurantOptionRequest) (*dtos.GroupDiningPlanResponse, error) {
 	return s.addRestaurantUC.Execute(req)
 }
@@ -51,6 +76,26 @@ func (s *GroupDiningService) JoinGroupDiningPlan(req *dtos.JoinGroupDiningPlanRe
 	return s.joinPlanUC.Execute(req)
 }
 
+// InviteParticipants 建立者邀請用戶加入計畫
+func (s *GroupDiningService) InviteParticipants(req *dtos.InviteParticipantsRequest) (*dtos.GroupDiningPlanResponse, error) {
+	return s.inviteUC.Execute(req)
+}
+
+// RespondToInvitation 受邀者接受或婉拒邀請
+func (s *GroupDiningService) RespondToInvitation(req *dtos.RespondToInvitationRequest) (*dtos.GroupDiningPlanResponse, error) {
+	return s.respondUC.Execute(req)
+}
+
+// CreateInviteLink 建立者建立可分享的邀請連結
+func (s *GroupDiningService) CreateInviteLink(req *dtos.CreateInviteLinkRequest) (*dtos.InviteLinkResponse, error) {
+	return s.createInviteLinkUC.Execute(req)
+}
+
+// GetPendingInvitations 列出用戶尚未回覆的邀請
+func (s *GroupDiningService) GetPendingInvitations(userID string) ([]*dtos.InvitationResponse, error) {
+	return s.getPlanUC.ExecuteInvitations(userID)
+}
+
 func (s *GroupDiningService) StartVoting(req *dtos.StartVotingRequest) (*dtos.GroupDiningPlanResponse, error) {
 	return s.startVotingUC.Execute(req)
 }
@@ -63,8 +108,12 @@ func (s *GroupDiningService) FinalizeGroupDiningPlan(req *dtos.FinalizeGroupDini
 	return s.finalizePlanUC.Execute(req)
 }
 
-func (s *GroupDiningService) GetGroupDiningPlanByID(planID string) (*dtos.GroupDiningPlanResponse, error) {
-	return s.getPlanUC.ExecuteByID(planID)
+func (s *GroupDiningService) CancelGroupDiningPlan(req *dtos.CancelGroupDiningPlanRequest) (*dtos.GroupDiningPlanResponse, error) {
+	return s.cancelPlanUC.Execute(req)
+}
+
+func (s *GroupDiningService) GetGroupDiningPlanByID(planID, userID string) (*dtos.GroupDiningPlanResponse, error) {
+	return s.getPlanUC.ExecuteByID(planID, userID)
 }
 
 func (s *GroupDiningService) GetGroupDiningPlansByCreator(createdBy string) ([]*dtos.GroupDiningPlanResponse, error) {
@@ -75,6 +124,11 @@ func (s *GroupDiningService) GetGroupDiningPlansByParticipant(userID string) ([]
 	return s.getPlanUC.ExecuteByParticipant(userID)
 }
 
-func (s *GroupDiningService) GetVotingResults(planID string) (*dtos.VotingResultsResponse, error) {
-	return s.getVotingResultsUC.Execute(planID)
-}
\ No newline at end of file
+func (s *GroupDiningService) GetVotingResults(planID, userID string) (*dtos.VotingResultsResponse, error) {
+	return s.getVotingResultsUC.Execute(planID, userID)
+}
+
+// CloseOverdueVoting 關閉截止時間已到的投票並自動確認啟用此功能的計畫，由排程器定期呼叫
+func (s *GroupDiningService) CloseOverdueVoting(now time.Time) (int, error) {
+	return s.closeVotingUC.Execute(now)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// ScheduleAvailabilityLookup 以用戶的可用時間實作 interfaces.AvailabilityLookup
+type ScheduleAvailabilityLookup struct {
+	availabilityRepo availability.Repository
+}
+
+func NewScheduleAvailabilityLookup(availabilityRepo availability.Repository) *ScheduleAvailabilityLookup {
+	return &ScheduleAvailabilityLookup{
+		availabilityRepo: availabilityRepo,
+	}
+}
+
+func (l *ScheduleAvailabilityLookup) Schedules(userIDs []string) ([]*availability.Schedule, error) {
+	ids := make([]shared.UserID, 0, len(userIDs))
+	for _, id := range userIDs {
+		// 聚餐參與者的 ID 不一定是註冊用戶，無法解析的 ID 視為沒有可用時間
+		userID, err := shared.NewUserIDFromString(id)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, userID)
+	}
+
+	return l.availabilityRepo.FindByUsers(context.Background(), ids)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+)
+
+// SocialBlockLookup 以好友關係中的封鎖記錄實作 interfaces.BlockLookup
+type SocialBlockLookup struct {
+	policy *social.InteractionPolicy
+}
+
+func NewSocialBlockLookup(policy *social.InteractionPolicy) *SocialBlockLookup {
+	return &SocialBlockLookup{
+		policy: policy,
+	}
+}
+
+func (l *SocialBlockLookup) BlockedUsers(userID string) (map[string]bool, error) {
+	// 聚餐參與者的 ID 不一定是註冊用戶，無法解析的 ID 視為沒有封鎖關係
+	id, err := shared.NewUserIDFromString(userID)
+	if err != nil {
+		return map[string]bool{}, nil
+	}
+
+	blockList, err := l.policy.BlockedUsers(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[string]bool, len(blockList))
+	for blockedID := range blockList {
+		blocked[blockedID.String()] = true
+	}
+	return blocked, nil
+}
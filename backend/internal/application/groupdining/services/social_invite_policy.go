@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+)
+
+// SocialInvitePolicy 以好友關係與用戶的隱私設定實作 interfaces.InvitePolicy，規則與邀請 ping 相同
+type SocialInvitePolicy struct {
+	policy *social.InteractionPolicy
+}
+
+func NewSocialInvitePolicy(policy *social.InteractionPolicy) *SocialInvitePolicy {
+	return &SocialInvitePolicy{
+		policy: policy,
+	}
+}
+
+func (p *SocialInvitePolicy) CheckInvite(inviterID, inviteeID string) error {
+	inviter, err := shared.NewUserIDFromString(inviterID)
+	if err != nil {
+		return shared.ErrInvalidInput.WithMessage("invalid inviter ID")
+	}
+	invitee, err := shared.NewUserIDFromString(inviteeID)
+	if err != nil {
+		return shared.ErrInvalidInput.WithMessage("invalid user ID")
+	}
+
+	return p.policy.CheckInvite(context.Background(), inviter, invitee)
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// UserProfileLookup 以用戶的個人檔案實作 interfaces.ProfileLookup
+type UserProfileLookup struct {
+	userRepo user.UserRepository
+}
+
+func NewUserProfileLookup(userRepo user.UserRepository) *UserProfileLookup {
+	return &UserProfileLookup{
+		userRepo: userRepo,
+	}
+}
+
+func (l *UserProfileLookup) DisplayName(userID string) (string, error) {
+	id, err := shared.NewUserIDFromString(userID)
+	if err != nil {
+		return "", shared.ErrUserNotFound
+	}
+
+	u, err := l.userRepo.FindByID(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	return u.Profile.DisplayName, nil
+}
@@ -1,10 +1,10 @@
 package usecases
 
 import (
-	"errors"
-
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type AddRestaurantOptionUseCase struct {
@@ -19,7 +19,7 @@ func NewAddRestaurantOptionUseCase(planRepo interfaces.GroupDiningPlanRepository
 
 func (uc *AddRestaurantOptionUseCase) Execute(req *dtos.AddRestaurantOptionRequest) (*dtos.GroupDiningPlanResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
 	plan, err := uc.planRepo.GetByID(req.PlanID)
@@ -28,7 +28,11 @@ func (uc *AddRestaurantOptionUseCase) Execute(req *dtos.AddRestaurantOptionReque
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionAddRestaurantOption); err != nil {
+		return nil, err
 	}
 
 	if err := plan.AddRestaurantOption(req.Name, req.Address, req.Latitude, req.Longitude, req.CuisineType); err != nil {
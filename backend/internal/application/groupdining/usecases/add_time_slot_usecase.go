@@ -1,10 +1,10 @@
 package usecases
 
 import (
-	"errors"
-
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type AddTimeSlotUseCase struct {
@@ -19,7 +19,7 @@ func NewAddTimeSlotUseCase(planRepo interfaces.GroupDiningPlanRepository) *AddTi
 
 func (uc *AddTimeSlotUseCase) Execute(req *dtos.AddTimeSlotRequest) (*dtos.GroupDiningPlanResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
 	plan, err := uc.planRepo.GetByID(req.PlanID)
@@ -28,7 +28,11 @@ func (uc *AddTimeSlotUseCase) Execute(req *dtos.AddTimeSlotRequest) (*dtos.Group
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionAddTimeSlot); err != nil {
+		return nil, err
 	}
 
 	if err := plan.AddTimeSlot(req.StartTime, req.EndTime, req.Description); err != nil {
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+type CancelGroupDiningPlanUseCase struct {
+	planRepo interfaces.GroupDiningPlanRepository
+}
+
+func NewCancelGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository) *CancelGroupDiningPlanUseCase {
+	return &CancelGroupDiningPlanUseCase{
+		planRepo: planRepo,
+	}
+}
+
+// Execute 只有建立者可以取消尚未確認的計畫
+func (uc *CancelGroupDiningPlanUseCase) Execute(req *dtos.CancelGroupDiningPlanRequest) (*dtos.GroupDiningPlanResponse, error) {
+	if req == nil {
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
+	}
+
+	plan, err := uc.planRepo.GetByID(req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionCancel); err != nil {
+		return nil, err
+	}
+
+	if err := plan.CancelPlan(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	return dtos.ToGroupDiningPlanResponse(plan), nil
+}
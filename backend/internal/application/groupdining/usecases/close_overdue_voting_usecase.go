@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+)
+
+// CloseOverdueVotingUseCase 關閉已過截止時間仍在投票中的計畫，啟用自動確認的計畫會同時確認
+type CloseOverdueVotingUseCase struct {
+	planRepo  interfaces.GroupDiningPlanRepository
+	finalizer *planFinalizer
+}
+
+func NewCloseOverdueVotingUseCase(planRepo interfaces.GroupDiningPlanRepository, voteRepo interfaces.VoteRepository, ratings interfaces.RestaurantRatingLookup) *CloseOverdueVotingUseCase {
+	return &CloseOverdueVotingUseCase{
+		planRepo:  planRepo,
+		finalizer: &planFinalizer{votes: voteRepo, ratings: ratings},
+	}
+}
+
+// Execute 回傳關閉投票的計畫數；單一計畫失敗時繼續處理其他計畫，最後一併回報讓排程紀錄為失敗
+func (uc *CloseOverdueVotingUseCase) Execute(now time.Time) (int, error) {
+	plans, err := uc.planRepo.GetVotingPastDeadline(now)
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+	var failures []error
+	for _, plan := range plans {
+		if plan.AutoFinalize.Enabled {
+			if _, err := uc.finalizer.finalize(plan, aggregates.FinalizationTriggerDeadline); err != nil {
+				log.Printf("Failed to finalize plan %s: %v", plan.ID, err)
+				failures = append(failures, fmt.Errorf("finalize plan %s: %w", plan.ID, err))
+				continue
+			}
+		} else if err := plan.CloseVoting(); err != nil {
+			log.Printf("Failed to close voting of plan %s: %v", plan.ID, err)
+			failures = append(failures, fmt.Errorf("close voting of plan %s: %w", plan.ID, err))
+			continue
+		}
+		if err := uc.planRepo.Update(plan); err != nil {
+			log.Printf("Failed to close voting of plan %s: %v", plan.ID, err)
+			failures = append(failures, fmt.Errorf("save plan %s: %w", plan.ID, err))
+			continue
+		}
+		closed++
+	}
+
+	return closed, errors.Join(failures...)
+}
@@ -1,33 +1,59 @@
 package usecases
 
 import (
-	"errors"
+	"math/rand"
 
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type CreateGroupDiningPlanUseCase struct {
 	planRepo interfaces.GroupDiningPlanRepository
+	profiles interfaces.ProfileLookup
 }
 
-func NewCreateGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository) *CreateGroupDiningPlanUseCase {
+func NewCreateGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository, profiles interfaces.ProfileLookup) *CreateGroupDiningPlanUseCase {
 	return &CreateGroupDiningPlanUseCase{
 		planRepo: planRepo,
+		profiles: profiles,
 	}
 }
 
 func (uc *CreateGroupDiningPlanUseCase) Execute(req *dtos.CreateGroupDiningPlanRequest) (*dtos.GroupDiningPlanResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
-	plan, err := aggregates.NewGroupDiningPlan(req.CreatedBy, req.Title, req.Description)
+	creatorName, err := uc.profiles.DisplayName(req.CreatedBy)
 	if err != nil {
 		return nil, err
 	}
 
+	plan, err := aggregates.NewGroupDiningPlan(req.CreatedBy, creatorName, req.Title, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plan.ConfigureVotingMethod(aggregates.VotingMethod(req.VotingMethod)); err != nil {
+		return nil, err
+	}
+
+	if req.AutoFinalize {
+		rule := aggregates.TieBreakRule(req.TieBreak)
+		var seed int64
+		if rule == aggregates.TieBreakRandom {
+			seed = rand.Int63()
+			if req.TieBreakSeed != nil {
+				seed = *req.TieBreakSeed
+			}
+		}
+		if err := plan.ConfigureAutoFinalize(rule, seed); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := uc.planRepo.Create(plan); err != nil {
 		return nil, err
 	}
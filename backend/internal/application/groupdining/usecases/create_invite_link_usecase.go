@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// InviteLinkSettings 邀請連結的簽章與有效時間設定
+type InviteLinkSettings struct {
+	Signer     *aggregates.InviteLinkSigner
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+	JoinURL    string // 分享的前端網址，token 以 query string 附加在後面
+}
+
+type CreateInviteLinkUseCase struct {
+	planRepo interfaces.GroupDiningPlanRepository
+	settings InviteLinkSettings
+}
+
+func NewCreateInviteLinkUseCase(planRepo interfaces.GroupDiningPlanRepository, settings InviteLinkSettings) *CreateInviteLinkUseCase {
+	return &CreateInviteLinkUseCase{
+		planRepo: planRepo,
+		settings: settings,
+	}
+}
+
+func (uc *CreateInviteLinkUseCase) Execute(req *dtos.CreateInviteLinkRequest) (*dtos.InviteLinkResponse, error) {
+	if req == nil {
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
+	}
+
+	ttl := uc.settings.DefaultTTL
+	if req.ExpiresInHours < 0 {
+		return nil, shared.ErrInvalidInput.WithMessage("expires_in_hours cannot be negative")
+	}
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > uc.settings.MaxTTL {
+		return nil, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("invite links cannot be valid for more than %d hours", int(uc.settings.MaxTTL/time.Hour)))
+	}
+
+	plan, err := uc.planRepo.GetByID(req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionCreateInviteLink); err != nil {
+		return nil, err
+	}
+
+	link, err := plan.CreateInviteLink(req.ActorID, ttl, req.SingleUse, req.MaxParticipants, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := uc.settings.Signer.Sign(plan.ID, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	return &dtos.InviteLinkResponse{
+		ID:              link.ID,
+		PlanID:          plan.ID,
+		Token:           token,
+		URL:             uc.settings.JoinURL + "?token=" + url.QueryEscape(token),
+		ExpiresAt:       link.ExpiresAt,
+		SingleUse:       link.SingleUse,
+		MaxParticipants: link.MaxParticipants,
+	}, nil
+}
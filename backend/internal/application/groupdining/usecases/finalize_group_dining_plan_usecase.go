@@ -1,10 +1,10 @@
 package usecases
 
 import (
-	"errors"
-
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type FinalizeGroupDiningPlanUseCase struct {
@@ -17,9 +17,10 @@ func NewFinalizeGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanReposi
 	}
 }
 
+// Execute 只有建立者可以確認計畫
 func (uc *FinalizeGroupDiningPlanUseCase) Execute(req *dtos.FinalizeGroupDiningPlanRequest) (*dtos.GroupDiningPlanResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
 	plan, err := uc.planRepo.GetByID(req.PlanID)
@@ -28,7 +29,11 @@ func (uc *FinalizeGroupDiningPlanUseCase) Execute(req *dtos.FinalizeGroupDiningP
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionFinalize); err != nil {
+		return nil, err
 	}
 
 	if err := plan.ConfirmPlan(req.TimeSlotID, req.RestaurantID); err != nil {
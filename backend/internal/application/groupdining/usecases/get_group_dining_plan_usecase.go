@@ -1,25 +1,29 @@
 package usecases
 
 import (
-	"errors"
-
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type GetGroupDiningPlanUseCase struct {
 	planRepo interfaces.GroupDiningPlanRepository
+	blocks   interfaces.BlockLookup
 }
 
-func NewGetGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository) *GetGroupDiningPlanUseCase {
+func NewGetGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository, blocks interfaces.BlockLookup) *GetGroupDiningPlanUseCase {
 	return &GetGroupDiningPlanUseCase{
 		planRepo: planRepo,
+		blocks:   blocks,
 	}
 }
 
-func (uc *GetGroupDiningPlanUseCase) ExecuteByID(planID string) (*dtos.GroupDiningPlanResponse, error) {
+// ExecuteByID 只有建立者、參與者與尚未回覆的受邀者可以查看計畫，且不顯示有封鎖關係的用戶建立的計畫
+func (uc *GetGroupDiningPlanUseCase) ExecuteByID(planID, userID string) (*dtos.GroupDiningPlanResponse, error) {
 	if planID == "" {
-		return nil, errors.New("plan ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
 	}
 
 	plan, err := uc.planRepo.GetByID(planID)
@@ -28,7 +32,19 @@ func (uc *GetGroupDiningPlanUseCase) ExecuteByID(planID string) (*dtos.GroupDini
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(userID, aggregates.PlanActionView); err != nil {
+		return nil, err
+	}
+
+	blocked, err := uc.blocks.BlockedUsers(userID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked[plan.CreatedBy] {
+		return nil, friendship.ErrFriendshipBlocked.WithMessage("cannot view a plan created by a blocked user")
 	}
 
 	return dtos.ToGroupDiningPlanResponse(plan), nil
@@ -36,7 +52,7 @@ func (uc *GetGroupDiningPlanUseCase) ExecuteByID(planID string) (*dtos.GroupDini
 
 func (uc *GetGroupDiningPlanUseCase) ExecuteByCreator(createdBy string) ([]*dtos.GroupDiningPlanResponse, error) {
 	if createdBy == "" {
-		return nil, errors.New("creator ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("creator ID cannot be empty")
 	}
 
 	plans, err := uc.planRepo.GetByCreator(createdBy)
@@ -54,10 +70,16 @@ func (uc *GetGroupDiningPlanUseCase) ExecuteByCreator(createdBy string) ([]*dtos
 
 func (uc *GetGroupDiningPlanUseCase) ExecuteByParticipant(userID string) ([]*dtos.GroupDiningPlanResponse, error) {
 	if userID == "" {
-		return nil, errors.New("user ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
 	}
 
-	plans, err := uc.planRepo.GetByParticipant(userID)
+	// 不列出有封鎖關係的用戶建立的計畫，由查詢排除
+	blocked, err := uc.blockedUserIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := uc.planRepo.GetByParticipant(userID, blocked)
 	if err != nil {
 		return nil, err
 	}
@@ -68,4 +90,42 @@ func (uc *GetGroupDiningPlanUseCase) ExecuteByParticipant(userID string) ([]*dto
 	}
 
 	return responses, nil
-}
\ No newline at end of file
+}
+// ExecuteInvitations 列出用戶尚未回覆的邀請，不列出有封鎖關係的用戶建立的計畫
+func (uc *GetGroupDiningPlanUseCase) ExecuteInvitations(userID string) ([]*dtos.InvitationResponse, error) {
+	if userID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+
+	blocked, err := uc.blockedUserIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := uc.planRepo.GetByInvitee(userID, blocked)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dtos.InvitationResponse, 0, len(plans))
+	for _, plan := range plans {
+		if invitation := dtos.ToInvitationResponse(plan, userID); invitation != nil {
+			responses = append(responses, invitation)
+		}
+	}
+
+	return responses, nil
+}
+
+// blockedUserIDs 回傳與用戶有封鎖關係的用戶 ID，供查詢排除這些用戶建立的計畫
+func (uc *GetGroupDiningPlanUseCase) blockedUserIDs(userID string) ([]string, error) {
+	blocked, err := uc.blocks.BlockedUsers(userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(blocked))
+	for id := range blocked {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
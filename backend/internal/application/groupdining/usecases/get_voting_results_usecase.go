@@ -1,25 +1,28 @@
 package usecases
 
 import (
-	"errors"
-
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type GetVotingResultsUseCase struct {
 	planRepo interfaces.GroupDiningPlanRepository
+	voteRepo interfaces.VoteRepository
 }
 
-func NewGetVotingResultsUseCase(planRepo interfaces.GroupDiningPlanRepository) *GetVotingResultsUseCase {
+func NewGetVotingResultsUseCase(planRepo interfaces.GroupDiningPlanRepository, voteRepo interfaces.VoteRepository) *GetVotingResultsUseCase {
 	return &GetVotingResultsUseCase{
 		planRepo: planRepo,
+		voteRepo: voteRepo,
 	}
 }
 
-func (uc *GetVotingResultsUseCase) Execute(planID string) (*dtos.VotingResultsResponse, error) {
+// Execute 只有參與者可以查看投票結果
+func (uc *GetVotingResultsUseCase) Execute(planID, userID string) (*dtos.VotingResultsResponse, error) {
 	if planID == "" {
-		return nil, errors.New("plan ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
 	}
 
 	plan, err := uc.planRepo.GetByID(planID)
@@ -28,40 +31,18 @@ func (uc *GetVotingResultsUseCase) Execute(planID string) (*dtos.VotingResultsRe
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
 	}
 
-	results := plan.GetVotingResults()
-	
-	timeSlots := make([]dtos.TimeSlotResponse, len(plan.TimeSlots))
-	for i, ts := range plan.TimeSlots {
-		timeSlots[i] = dtos.TimeSlotResponse{
-			ID:          ts.ID,
-			StartTime:   ts.StartTime,
-			EndTime:     ts.EndTime,
-			Description: ts.Description,
-			VoteCount:   ts.VoteCount,
-		}
+	if err := plan.Authorize(userID, aggregates.PlanActionViewResults); err != nil {
+		return nil, err
 	}
 
-	restaurants := make([]dtos.RestaurantOptionResponse, len(plan.RestaurantOptions))
-	for i, ro := range plan.RestaurantOptions {
-		restaurants[i] = dtos.RestaurantOptionResponse{
-			ID:          ro.ID,
-			Name:        ro.Name,
-			Address:     ro.Address,
-			Latitude:    ro.Latitude,
-			Longitude:   ro.Longitude,
-			CuisineType: ro.CuisineType,
-			VoteCount:   ro.VoteCount,
-		}
+	// 結果一律由已儲存的投票重新計算，不依賴計畫上的票數
+	votes, err := uc.voteRepo.GetByPlan(planID)
+	if err != nil {
+		return nil, err
 	}
 
-	return &dtos.VotingResultsResponse{
-		TotalParticipants: results["total_participants"].(int),
-		VotedParticipants: results["voted_participants"].(int),
-		VotingProgress:    results["voting_progress"].(float64),
-		TimeSlots:         timeSlots,
-		Restaurants:       restaurants,
-	}, nil
+	return dtos.ToVotingResultsResponseFromTally(plan, plan.Tally(votes)), nil
 }
\ No newline at end of file
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+type InviteParticipantsUseCase struct {
+	planRepo interfaces.GroupDiningPlanRepository
+	blocks   interfaces.BlockLookup
+	profiles interfaces.ProfileLookup
+	invites  interfaces.InvitePolicy
+}
+
+func NewInviteParticipantsUseCase(planRepo interfaces.GroupDiningPlanRepository, blocks interfaces.BlockLookup, profiles interfaces.ProfileLookup, invites interfaces.InvitePolicy) *InviteParticipantsUseCase {
+	return &InviteParticipantsUseCase{
+		planRepo: planRepo,
+		blocks:   blocks,
+		profiles: profiles,
+		invites:  invites,
+	}
+}
+
+// Execute 邀請多位用戶，任一位不能邀請時整批都不會送出
+func (uc *InviteParticipantsUseCase) Execute(req *dtos.InviteParticipantsRequest) (*dtos.GroupDiningPlanResponse, error) {
+	if req == nil {
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
+	}
+	if len(req.UserIDs) == 0 {
+		return nil, shared.ErrInvalidInput.WithMessage("at least one user must be invited")
+	}
+
+	plan, err := uc.planRepo.GetByID(req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.ActorID, aggregates.PlanActionInvite); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, userID := range req.UserIDs {
+		if err := uc.invites.CheckInvite(req.ActorID, userID); err != nil {
+			return nil, err
+		}
+		if err := checkNotBlocked(uc.blocks, plan, userID); err != nil {
+			return nil, err
+		}
+
+		displayName, err := uc.profiles.DisplayName(userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := plan.InviteParticipant(req.ActorID, userID, displayName, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	return dtos.ToGroupDiningPlanResponse(plan), nil
+}
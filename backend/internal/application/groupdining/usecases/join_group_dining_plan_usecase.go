@@ -1,43 +1,117 @@
 package usecases
 
 import (
-	"errors"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type JoinGroupDiningPlanUseCase struct {
 	planRepo interfaces.GroupDiningPlanRepository
+	blocks   interfaces.BlockLookup
+	profiles interfaces.ProfileLookup
+	signer   *aggregates.InviteLinkSigner
 }
 
-func NewJoinGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository) *JoinGroupDiningPlanUseCase {
+func NewJoinGroupDiningPlanUseCase(planRepo interfaces.GroupDiningPlanRepository, blocks interfaces.BlockLookup, profiles interfaces.ProfileLookup, signer *aggregates.InviteLinkSigner) *JoinGroupDiningPlanUseCase {
 	return &JoinGroupDiningPlanUseCase{
 		planRepo: planRepo,
+		blocks:   blocks,
+		profiles: profiles,
+		signer:   signer,
 	}
 }
 
+// Execute 受邀者直接加入 (接受邀請)，其他用戶必須提供邀請連結的 token
 func (uc *JoinGroupDiningPlanUseCase) Execute(req *dtos.JoinGroupDiningPlanRequest) (*dtos.GroupDiningPlanResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
-	plan, err := uc.planRepo.GetByID(req.PlanID)
+	now := time.Now()
+	planID := req.PlanID
+	var claims *aggregates.InviteLinkClaims
+	if req.InviteToken != "" {
+		verified, err := uc.signer.Verify(req.InviteToken, now)
+		if err != nil {
+			return nil, err
+		}
+		if planID != "" && planID != verified.PlanID {
+			return nil, aggregates.ErrInvalidInviteLink.WithMessage("invite link belongs to another plan")
+		}
+		planID = verified.PlanID
+		claims = &verified
+	}
+	if planID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID is required")
+	}
+
+	plan, err := uc.planRepo.GetByID(planID)
 	if err != nil {
 		return nil, err
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	invited := plan.HasPendingInvitation(req.UserID)
+	if !invited && claims == nil {
+		if plan.IsParticipant(req.UserID) {
+			return nil, aggregates.ErrAlreadyParticipant
+		}
+		return nil, aggregates.ErrInvitationRequired
+	}
+
+	if err := checkNotBlocked(uc.blocks, plan, req.UserID); err != nil {
+		return nil, err
+	}
+
+	displayName, err := uc.profiles.DisplayName(req.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := plan.AddParticipant(req.UserID, req.DisplayName); err != nil {
+	// 有邀請時以接受邀請加入，不消耗連結的使用次數
+	if invited {
+		err = plan.AddParticipant(req.UserID, displayName)
+	} else {
+		err = plan.JoinWithInviteLink(claims.LinkID, req.UserID, displayName, now)
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	if err := uc.planRepo.Update(plan); err != nil {
+	if invited {
+		err = uc.planRepo.Update(plan)
+	} else {
+		err = uc.planRepo.RedeemInviteLink(plan, claims.LinkID, req.UserID)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	return dtos.ToGroupDiningPlanResponse(plan), nil
-}
\ No newline at end of file
+}
+
+// checkNotBlocked 與建立者或任一位參與者有封鎖關係的用戶不能加入或受邀加入計畫
+func checkNotBlocked(blocks interfaces.BlockLookup, plan *aggregates.GroupDiningPlan, userID string) error {
+	blocked, err := blocks.BlockedUsers(userID)
+	if err != nil {
+		return err
+	}
+
+	if blocked[plan.CreatedBy] {
+		return friendship.ErrFriendshipBlocked.WithMessage("cannot join a plan created by a blocked user")
+	}
+	for _, participant := range plan.Participants {
+		if participant.IsAccepted() && blocked[participant.UserID] {
+			return friendship.ErrFriendshipBlocked.WithMessage("cannot join a plan with a blocked participant")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"log"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+)
+
+// planFinalizer 以儲存的選票自動確認計畫，並在需要時查詢餐廳評分
+type planFinalizer struct {
+	votes   interfaces.VoteRepository
+	ratings interfaces.RestaurantRatingLookup
+}
+
+// finalizeIfAllVoted 所有參與者都已投票時自動確認計畫；呼叫端負責儲存計畫
+func (f *planFinalizer) finalizeIfAllVoted(plan *aggregates.GroupDiningPlan) (bool, error) {
+	votes, err := f.votes.GetByPlan(plan.ID)
+	if err != nil {
+		return false, err
+	}
+	if !plan.AllParticipantsVoted(votes) {
+		return false, nil
+	}
+	return f.finalizeWithVotes(plan, aggregates.FinalizationTriggerAllVoted, votes)
+}
+
+// finalize 呼叫端負責儲存計畫；回傳計畫是否已確認
+func (f *planFinalizer) finalize(plan *aggregates.GroupDiningPlan, trigger aggregates.FinalizationTrigger) (bool, error) {
+	votes, err := f.votes.GetByPlan(plan.ID)
+	if err != nil {
+		return false, err
+	}
+	return f.finalizeWithVotes(plan, trigger, votes)
+}
+
+func (f *planFinalizer) finalizeWithVotes(plan *aggregates.GroupDiningPlan, trigger aggregates.FinalizationTrigger, votes []*aggregates.Vote) (bool, error) {
+	var ratings map[string]float64
+	if plan.AutoFinalize.TieBreak == aggregates.TieBreakHighestRating && f.ratings != nil {
+		var err error
+		// 查不到評分時所有餐廳視為同分，仍可依提出順序確認，不讓投票或排程因此失敗
+		if ratings, err = f.ratings.Ratings(plan.RestaurantOptions); err != nil {
+			log.Printf("Failed to look up restaurant ratings of plan %s: %v", plan.ID, err)
+		}
+	}
+
+	return plan.FinalizeAutomatically(trigger, votes, ratings)
+}
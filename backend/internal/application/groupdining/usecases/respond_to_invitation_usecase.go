@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+type RespondToInvitationUseCase struct {
+	planRepo interfaces.GroupDiningPlanRepository
+	blocks   interfaces.BlockLookup
+}
+
+func NewRespondToInvitationUseCase(planRepo interfaces.GroupDiningPlanRepository, blocks interfaces.BlockLookup) *RespondToInvitationUseCase {
+	return &RespondToInvitationUseCase{
+		planRepo: planRepo,
+		blocks:   blocks,
+	}
+}
+
+func (uc *RespondToInvitationUseCase) Execute(req *dtos.RespondToInvitationRequest) (*dtos.GroupDiningPlanResponse, error) {
+	if req == nil {
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
+	}
+
+	plan, err := uc.planRepo.GetByID(req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	// 受邀後才出現封鎖關係時不能接受，但仍可婉拒
+	if req.Accept && plan.HasPendingInvitation(req.UserID) {
+		if err := checkNotBlocked(uc.blocks, plan, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := plan.RespondToInvitation(req.UserID, req.Accept, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	return dtos.ToGroupDiningPlanResponse(plan), nil
+}
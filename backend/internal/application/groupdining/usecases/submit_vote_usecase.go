@@ -6,23 +6,26 @@ import (
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type SubmitVoteUseCase struct {
-	planRepo interfaces.GroupDiningPlanRepository
-	voteRepo interfaces.VoteRepository
+	planRepo  interfaces.GroupDiningPlanRepository
+	voteRepo  interfaces.VoteRepository
+	finalizer *planFinalizer
 }
 
-func NewSubmitVoteUseCase(planRepo interfaces.GroupDiningPlanRepository, voteRepo interfaces.VoteRepository) *SubmitVoteUseCase {
+func NewSubmitVoteUseCase(planRepo interfaces.GroupDiningPlanRepository, voteRepo interfaces.VoteRepository, ratings interfaces.RestaurantRatingLookup) *SubmitVoteUseCase {
 	return &SubmitVoteUseCase{
-		planRepo: planRepo,
-		voteRepo: voteRepo,
+		planRepo:  planRepo,
+		voteRepo:  voteRepo,
+		finalizer: &planFinalizer{votes: voteRepo, ratings: ratings},
 	}
 }
 
 func (uc *SubmitVoteUseCase) Execute(req *dtos.SubmitVoteRequest) (*dtos.VoteResponse, error) {
 	if req == nil {
-		return nil, errors.New("request cannot be nil")
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
 	}
 
 	plan, err := uc.planRepo.GetByID(req.PlanID)
@@ -31,23 +34,31 @@ func (uc *SubmitVoteUseCase) Execute(req *dtos.SubmitVoteRequest) (*dtos.VoteRes
 	}
 
 	if plan == nil {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	if err := plan.Authorize(req.UserID, aggregates.PlanActionVote); err != nil {
+		return nil, err
 	}
 
 	existingVote, err := uc.voteRepo.GetByPlanAndUser(req.PlanID, req.UserID)
-	if err != nil && err.Error() != "vote not found" {
+	if err != nil && !errors.Is(err, aggregates.ErrVoteNotFound) {
+		return nil, err
+	}
+
+	previousVotes, err := uc.voteRepo.GetByPlan(req.PlanID)
+	if err != nil {
 		return nil, err
 	}
 
-	var vote *aggregates.Vote
+	// 重新投票時沿用原本的 ID，選項依請求順序重建 (排序投票以此為偏好順序)
+	vote, err := aggregates.NewVote(req.PlanID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
 	if existingVote != nil {
-		vote = existingVote
-		vote.Choices = make([]aggregates.VoteChoice, 0)
-	} else {
-		vote, err = aggregates.NewVote(req.PlanID, req.UserID)
-		if err != nil {
-			return nil, err
-		}
+		vote.ID = existingVote.ID
+		vote.Comment = existingVote.Comment
 	}
 
 	for _, timeSlotID := range req.TimeSlotIDs {
@@ -62,11 +73,17 @@ func (uc *SubmitVoteUseCase) Execute(req *dtos.SubmitVoteRequest) (*dtos.VoteRes
 		}
 	}
 
+	for optionID, score := range req.Scores {
+		if err := vote.SetScore(optionID, score); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Comment != "" {
 		vote.SetComment(req.Comment)
 	}
 
-	if err := vote.IsValid(); err != nil {
+	if err := plan.RecordVote(vote, previousVotes); err != nil {
 		return nil, err
 	}
 
@@ -80,8 +97,11 @@ func (uc *SubmitVoteUseCase) Execute(req *dtos.SubmitVoteRequest) (*dtos.VoteRes
 		}
 	}
 
-	if err := plan.RecordVote(req.UserID, req.TimeSlotIDs, req.RestaurantIDs); err != nil {
-		return nil, err
+	// 最後一位參與者投票後不必等到截止時間；以儲存的選票判斷，同時送出的選票也會算進去
+	if plan.AutoFinalize.Enabled {
+		if _, err := uc.finalizer.finalizeIfAllVoted(plan); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := uc.planRepo.Update(plan); err != nil {
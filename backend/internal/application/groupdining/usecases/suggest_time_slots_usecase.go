@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// defaultSuggestionRange 未指定結束時間時往後找的天數
+const defaultSuggestionRange = 7 * 24 * time.Hour
+
+type SuggestTimeSlotsUseCase struct {
+	planRepo           interfaces.GroupDiningPlanRepository
+	availabilityLookup interfaces.AvailabilityLookup
+}
+
+func NewSuggestTimeSlotsUseCase(planRepo interfaces.GroupDiningPlanRepository, availabilityLookup interfaces.AvailabilityLookup) *SuggestTimeSlotsUseCase {
+	return &SuggestTimeSlotsUseCase{
+		planRepo:           planRepo,
+		availabilityLookup: availabilityLookup,
+	}
+}
+
+func (uc *SuggestTimeSlotsUseCase) Execute(req *dtos.SuggestTimeSlotsRequest) (*dtos.TimeSlotSuggestionsResponse, error) {
+	if req == nil {
+		return nil, shared.ErrInvalidInput.WithMessage("request cannot be nil")
+	}
+
+	plan, err := uc.planRepo.GetByID(req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return nil, aggregates.ErrPlanNotFound
+	}
+
+	// 參與者可以查看建議，只有建立者可以直接加入計畫
+	action := aggregates.PlanActionSuggestTimeSlots
+	if req.Apply {
+		action = aggregates.PlanActionApplySuggestions
+	}
+	if err := plan.Authorize(req.ActorID, action); err != nil {
+		return nil, err
+	}
+
+	// 只有投票開始前可以加入建議時段
+	if req.Apply {
+		switch plan.Status {
+		case aggregates.PlanStatusCreated:
+		case aggregates.PlanStatusVoting, aggregates.PlanStatusVotingClosed:
+			return nil, aggregates.ErrVotingStarted.WithMessage("cannot add suggested time slots after voting has started")
+		default:
+			return nil, aggregates.ErrInvalidPlanStatus.WithMessage("cannot add suggested time slots to a " + string(plan.Status) + " plan")
+		}
+	}
+
+	participants := plan.AcceptedParticipants()
+	participantIDs := make([]string, len(participants))
+	for i, participant := range participants {
+		participantIDs[i] = participant.UserID
+	}
+
+	schedules, err := uc.availabilityLookup.Schedules(participantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestionReq := uc.suggestionRequest(req, plan.CreatedBy, schedules)
+	suggestions, err := availability.Suggest(schedules, suggestionReq)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dtos.TimeSlotSuggestionsResponse{
+		PlanID:              plan.ID,
+		MealType:            req.MealType,
+		TimeZone:            suggestionReq.TimeZone,
+		Suggestions:         make([]dtos.TimeSlotSuggestionResponse, len(suggestions)),
+		MissingParticipants: missingParticipants(participantIDs, schedules),
+	}
+	for i, suggestion := range suggestions {
+		response.Suggestions[i] = dtos.TimeSlotSuggestionResponse{
+			StartTime:               suggestion.Start,
+			EndTime:                 suggestion.End,
+			AvailableParticipants:   make([]string, len(suggestion.Available)),
+			UnavailableParticipants: make([]string, len(suggestion.Unavailable)),
+		}
+		for j, userID := range suggestion.Available {
+			response.Suggestions[i].AvailableParticipants[j] = userID.String()
+		}
+		for j, userID := range suggestion.Unavailable {
+			response.Suggestions[i].UnavailableParticipants[j] = userID.String()
+		}
+	}
+
+	if !req.Apply {
+		return response, nil
+	}
+
+	for _, suggestion := range suggestions {
+		if hasTimeSlot(plan, suggestion.Start, suggestion.End) {
+			continue
+		}
+		description := fmt.Sprintf("Suggested %s (%d/%d available)", req.MealType, len(suggestion.Available), len(participantIDs))
+		if err := plan.AddTimeSlot(suggestion.Start, suggestion.End, description); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.planRepo.Update(plan); err != nil {
+		return nil, err
+	}
+
+	response.Plan = dtos.ToGroupDiningPlanResponse(plan)
+	return response, nil
+}
+
+// suggestionRequest 補上預設值；時區預設使用建立者的可用時間時區
+func (uc *SuggestTimeSlotsUseCase) suggestionRequest(req *dtos.SuggestTimeSlotsRequest, createdBy string, schedules []*availability.Schedule) availability.SuggestionRequest {
+	suggestionReq := availability.SuggestionRequest{
+		MealType: ping.PingType(req.MealType),
+		From:     req.From,
+		To:       req.To,
+		Duration: time.Duration(req.DurationMinutes) * time.Minute,
+		TimeZone: req.TimeZone,
+		Limit:    req.Limit,
+	}
+
+	if suggestionReq.From.IsZero() {
+		suggestionReq.From = time.Now()
+	}
+	if suggestionReq.To.IsZero() {
+		suggestionReq.To = suggestionReq.From.Add(defaultSuggestionRange)
+	}
+	if req.DurationMinutes == 0 {
+		suggestionReq.Duration = availability.DefaultMealDuration
+	}
+	if suggestionReq.Limit == 0 {
+		suggestionReq.Limit = availability.DefaultSuggestionLimit
+	}
+	if suggestionReq.TimeZone == "" {
+		suggestionReq.TimeZone = availability.DefaultTimeZone
+		for _, schedule := range schedules {
+			if schedule.UserID.String() == createdBy {
+				suggestionReq.TimeZone = schedule.TimeZone
+			}
+		}
+	}
+
+	return suggestionReq
+}
+
+func missingParticipants(participantIDs []string, schedules []*availability.Schedule) []string {
+	published := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		published[schedule.UserID.String()] = true
+	}
+
+	missing := []string{}
+	for _, id := range participantIDs {
+		if !published[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func hasTimeSlot(plan *aggregates.GroupDiningPlan, start, end time.Time) bool {
+	for _, slot := range plan.TimeSlots {
+		if slot.StartTime.Equal(start) && slot.EndTime.Equal(end) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package availability
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// GetAvailabilityQuery 取得用戶的可用時間
+type GetAvailabilityQuery struct {
+	UserID shared.UserID `json:"-"`
+}
+
+type GetAvailabilityHandler struct {
+	availabilityService *availability.Service
+}
+
+func NewGetAvailabilityHandler(availabilityService *availability.Service) *GetAvailabilityHandler {
+	return &GetAvailabilityHandler{
+		availabilityService: availabilityService,
+	}
+}
+
+func (h *GetAvailabilityHandler) Handle(ctx context.Context, query GetAvailabilityQuery) (*availability.Schedule, error) {
+	return h.availabilityService.GetSchedule(ctx, query.UserID)
+}
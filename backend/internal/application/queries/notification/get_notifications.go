@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// GetNotificationsQuery 列出用戶收件匣中的通知
+type GetNotificationsQuery struct {
+	UserID     shared.UserID `json:"-"`
+	UnreadOnly bool          `json:"unreadOnly"`
+	Limit      int           `json:"limit" validate:"min=1,max=100"`
+	Offset     int           `json:"offset" validate:"min=0"`
+}
+
+// GetNotificationsResult 通知列表與未讀數
+type GetNotificationsResult struct {
+	Notifications []*notification.Notification `json:"notifications"`
+	UnreadCount   int64                        `json:"unreadCount"`
+}
+
+type GetNotificationsHandler struct {
+	notificationService *notification.Service
+}
+
+func NewGetNotificationsHandler(notificationService *notification.Service) *GetNotificationsHandler {
+	return &GetNotificationsHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (h *GetNotificationsHandler) Handle(ctx context.Context, query GetNotificationsQuery) (*GetNotificationsResult, error) {
+	notifications, err := h.notificationService.ListNotifications(ctx, query.UserID, query.UnreadOnly, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := h.notificationService.CountUnread(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetNotificationsResult{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	}, nil
+}
+
+// GetUnreadCountQuery 取得用戶的未讀通知數
+type GetUnreadCountQuery struct {
+	UserID shared.UserID `json:"-"`
+}
+
+type GetUnreadCountHandler struct {
+	notificationService *notification.Service
+}
+
+func NewGetUnreadCountHandler(notificationService *notification.Service) *GetUnreadCountHandler {
+	return &GetUnreadCountHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (h *GetUnreadCountHandler) Handle(ctx context.Context, query GetUnreadCountQuery) (int64, error) {
+	return h.notificationService.CountUnread(ctx, query.UserID)
+}
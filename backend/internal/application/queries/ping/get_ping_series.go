@@ -0,0 +1,136 @@
+package ping
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+)
+
+// PingSeriesDTO represents a recurring ping series for API responses
+type PingSeriesDTO struct {
+	ID               shared.ID         `json:"id"`
+	CreatedBy        shared.UserID     `json:"createdBy"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description"`
+	PingType         ping.PingType     `json:"pingType"`
+	Location         *shared.Location  `json:"location,omitempty"`
+	Invitees         []shared.UserID   `json:"invitees"`
+	StartAt          time.Time         `json:"startAt"`
+	TimeZone         string            `json:"timeZone"`
+	RRule            string            `json:"rrule"`
+	Status           ping.SeriesStatus `json:"status"`
+	NextOccurrenceAt *time.Time        `json:"nextOccurrenceAt,omitempty"`
+	Occurrences      []PingDTO         `json:"occurrences,omitempty"` // 已建立的之後發生，列表查詢不含
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+}
+
+// NewPingSeriesDTO converts a series and its upcoming occurrences into the API representation
+func NewPingSeriesDTO(s *ping.Series, occurrences []*ping.Ping) PingSeriesDTO {
+	dto := PingSeriesDTO{
+		ID:          s.ID(),
+		CreatedBy:   s.CreatedBy(),
+		Title:       s.Title(),
+		Description: s.Description(),
+		PingType:    s.PingType(),
+		Location:    s.Location(),
+		Invitees:    s.Invitees(),
+		StartAt:     s.StartAt(),
+		TimeZone:    s.TimeZone(),
+		RRule:       s.Rule().String(),
+		Status:      s.Status(),
+		CreatedAt:   s.CreatedAt(),
+		UpdatedAt:   s.UpdatedAt(),
+	}
+
+	if s.Status() == ping.SeriesStatusActive {
+		if next, ok := s.NextOccurrence(time.Now()); ok {
+			dto.NextOccurrenceAt = &next
+		}
+	}
+
+	if occurrences != nil {
+		dto.Occurrences = make([]PingDTO, len(occurrences))
+		for i, p := range occurrences {
+			dto.Occurrences[i] = NewPingDTO(p)
+		}
+	}
+
+	return dto
+}
+
+// GetPingSeriesQuery 查看單一系列與之後的發生
+type GetPingSeriesQuery struct {
+	UserID   shared.UserID `json:"-"`
+	SeriesID shared.ID     `json:"-"`
+}
+
+type GetPingSeriesHandler struct {
+	seriesService *ping.SeriesService
+}
+
+func NewGetPingSeriesHandler(seriesService *ping.SeriesService) *GetPingSeriesHandler {
+	return &GetPingSeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+func (h *GetPingSeriesHandler) Handle(ctx context.Context, query GetPingSeriesQuery) (*PingSeriesDTO, error) {
+	series, occurrences, err := h.seriesService.GetSeries(ctx, query.SeriesID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := NewPingSeriesDTO(series, occurrences)
+	return &dto, nil
+}
+
+// GetUserPingSeriesQuery 列出用戶建立或受邀的系列
+type GetUserPingSeriesQuery struct {
+	UserID shared.UserID `json:"-"`
+}
+
+// GetUserPingSeriesResult represents the result of listing a user's series
+type GetUserPingSeriesResult struct {
+	Series []PingSeriesDTO `json:"series"`
+	Total  int             `json:"total"`
+}
+
+type GetUserPingSeriesHandler struct {
+	seriesService *ping.SeriesService
+	policy        *social.InteractionPolicy
+}
+
+func NewGetUserPingSeriesHandler(seriesService *ping.SeriesService, policy *social.InteractionPolicy) *GetUserPingSeriesHandler {
+	return &GetUserPingSeriesHandler{
+		seriesService: seriesService,
+		policy:        policy,
+	}
+}
+
+// Handle 不顯示有封鎖關係的用戶發起的系列
+func (h *GetUserPingSeriesHandler) Handle(ctx context.Context, query GetUserPingSeriesQuery) (*GetUserPingSeriesResult, error) {
+	series, err := h.seriesService.GetUserSeries(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := h.policy.BlockedUsers(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]PingSeriesDTO, 0, len(series))
+	for _, s := range series {
+		if !blocked.Contains(s.CreatedBy()) {
+			dtos = append(dtos, NewPingSeriesDTO(s, nil))
+		}
+	}
+
+	return &GetUserPingSeriesResult{
+		Series: dtos,
+		Total:  len(dtos),
+	}, nil
+}
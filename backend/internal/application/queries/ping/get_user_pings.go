@@ -6,6 +6,7 @@ import (
 
 	"github.com/chun-wei0413/pingnom/internal/domain/ping"
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
 )
 
 // GetUserPingsQuery represents the query to get user's pings
@@ -37,6 +38,9 @@ type PingDTO struct {
 	InviteeCount int               `json:"inviteeCount"`
 	AcceptedCount int              `json:"acceptedCount"`
 	PendingCount int               `json:"pendingCount"`
+	SeriesID     *shared.ID        `json:"seriesId,omitempty"`
+	OccurrenceAt *time.Time        `json:"occurrenceAt,omitempty"`
+	Modified     bool              `json:"modified,omitempty"`
 	CreatedAt    time.Time         `json:"createdAt"`
 	UpdatedAt    time.Time         `json:"updatedAt"`
 }
@@ -50,12 +54,14 @@ type GetUserPingsResult struct {
 // GetUserPingsHandler handles queries for user's pings
 type GetUserPingsHandler struct {
 	pingService *ping.Service
+	policy      *social.InteractionPolicy
 }
 
 // NewGetUserPingsHandler creates a new get user pings handler
-func NewGetUserPingsHandler(pingService *ping.Service) *GetUserPingsHandler {
+func NewGetUserPingsHandler(pingService *ping.Service, policy *social.InteractionPolicy) *GetUserPingsHandler {
 	return &GetUserPingsHandler{
 		pingService: pingService,
+		policy:      policy,
 	}
 }
 
@@ -66,8 +72,14 @@ func (h *GetUserPingsHandler) Handle(ctx context.Context, query GetUserPingsQuer
 		query.Limit = 20
 	}
 
+	// 不顯示有封鎖關係的用戶發起的 ping，排除在分頁前進行
+	blocked, err := h.policy.BlockedUsers(ctx, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get pings from service
-	pings, err := h.pingService.GetUserPings(ctx, query.UserID, query.Limit, query.Offset)
+	pings, err := h.pingService.GetUserPings(ctx, query.UserID, blocked.UserIDs(), query.Limit, query.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -75,37 +87,53 @@ func (h *GetUserPingsHandler) Handle(ctx context.Context, query GetUserPingsQuer
 	// Convert to DTOs
 	pingDTOs := make([]PingDTO, len(pings))
 	for i, p := range pings {
-		// Convert responses
-		responses := make([]PingResponseDTO, len(p.Responses()))
-		for j, response := range p.Responses() {
-			responses[j] = PingResponseDTO{
-				UserID:      response.UserID,
-				Status:      response.Status,
-				Message:     response.Message,
-				RespondedAt: response.RespondedAt,
-			}
-		}
-
-		pingDTOs[i] = PingDTO{
-			ID:            p.ID(),
-			CreatedBy:     p.CreatedBy(),
-			Title:         p.Title(),
-			Description:   p.Description(),
-			PingType:      p.PingType(),
-			Status:        p.Status(),
-			ScheduledAt:   p.ScheduledAt(),
-			Location:      p.Location(),
-			Responses:     responses,
-			InviteeCount:  len(p.Invitees()),
-			AcceptedCount: p.GetAcceptedCount(),
-			PendingCount:  p.GetPendingCount(),
-			CreatedAt:     p.CreatedAt(),
-			UpdatedAt:     p.UpdatedAt(),
-		}
+		pingDTOs[i] = NewPingDTO(p)
 	}
 
 	return &GetUserPingsResult{
 		Pings: pingDTOs,
 		Total: len(pingDTOs),
 	}, nil
+}
+
+// NewPingDTO converts a ping into its API representation
+func NewPingDTO(p *ping.Ping) PingDTO {
+	// Convert responses
+	responses := make([]PingResponseDTO, len(p.Responses()))
+	for i, response := range p.Responses() {
+		responses[i] = PingResponseDTO{
+			UserID:      response.UserID,
+			Status:      response.Status,
+			Message:     response.Message,
+			RespondedAt: response.RespondedAt,
+		}
+	}
+
+	dto := PingDTO{
+		ID:            p.ID(),
+		CreatedBy:     p.CreatedBy(),
+		Title:         p.Title(),
+		Description:   p.Description(),
+		PingType:      p.PingType(),
+		Status:        p.Status(),
+		ScheduledAt:   p.ScheduledAt(),
+		Location:      p.Location(),
+		Responses:     responses,
+		InviteeCount:  len(p.Invitees()),
+		AcceptedCount: p.GetAcceptedCount(),
+		PendingCount:  p.GetPendingCount(),
+		CreatedAt:     p.CreatedAt(),
+		UpdatedAt:     p.UpdatedAt(),
+	}
+
+	// 重複 ping 的發生帶上所屬系列
+	if occurrence := p.Occurrence(); occurrence != nil {
+		seriesID := occurrence.SeriesID
+		occurrenceAt := occurrence.OccurrenceAt
+		dto.SeriesID = &seriesID
+		dto.OccurrenceAt = &occurrenceAt
+		dto.Modified = occurrence.Modified
+	}
+
+	return dto
 }
\ No newline at end of file
@@ -0,0 +1,30 @@
+package restaurant
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// GetRestaurantQuery 取得目錄中的餐廳，包含已停用的餐廳
+type GetRestaurantQuery struct {
+	RestaurantID shared.RestaurantID `json:"-"`
+}
+
+// GetRestaurantHandler 取得餐廳處理器
+type GetRestaurantHandler struct {
+	catalogService *restaurant.CatalogService
+}
+
+// NewGetRestaurantHandler 建立取得餐廳處理器
+func NewGetRestaurantHandler(catalogService *restaurant.CatalogService) *GetRestaurantHandler {
+	return &GetRestaurantHandler{
+		catalogService: catalogService,
+	}
+}
+
+// Handle 處理取得餐廳查詢
+func (h *GetRestaurantHandler) Handle(ctx context.Context, query GetRestaurantQuery) (*restaurant.Restaurant, error) {
+	return h.catalogService.GetRestaurant(ctx, query.RestaurantID)
+}
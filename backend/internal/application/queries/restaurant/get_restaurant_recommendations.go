@@ -2,8 +2,11 @@ package restaurant
 
 import (
 	"context"
+	"time"
 
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
 	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 // GetRestaurantRecommendationsQuery 獲取餐廳推薦查詢
@@ -14,19 +17,27 @@ type GetRestaurantRecommendationsQuery struct {
 	DietaryRestrictions  []restaurant.DietaryRestriction `json:"dietaryRestrictions,omitempty"`
 	MaxDistance          float64                         `json:"maxDistance,omitempty"` // 公里
 	MaxResults           int                             `json:"maxResults,omitempty"`
+	MealTime             *time.Time                      `json:"mealTime,omitempty"` // 用餐時間，排除當時未營業的餐廳
+	PingID               string                          `json:"pingId,omitempty"`   // 以 ping 的預定時間作為用餐時間，並依 ping 成員的用餐紀錄個人化
+	UserID               shared.UserID                   `json:"-"`                  // 提出請求的用戶，指定 PingID 時必須是 ping 的參加者
+	MeetingPointStrategy restaurant.MeetingPointStrategy   `json:"meetingPointStrategy,omitempty"`
+	WeightProfile        string                          `json:"weightProfile,omitempty"`
 }
 
 // GetRestaurantRecommendationsHandler 獲取餐廳推薦處理器
 type GetRestaurantRecommendationsHandler struct {
 	recommendationService *restaurant.RecommendationService
+	pingRepo              ping.Repository
 }
 
 // NewGetRestaurantRecommendationsHandler 建立獲取餐廳推薦處理器
 func NewGetRestaurantRecommendationsHandler(
 	recommendationService *restaurant.RecommendationService,
+	pingRepo ping.Repository,
 ) *GetRestaurantRecommendationsHandler {
 	return &GetRestaurantRecommendationsHandler{
 		recommendationService: recommendationService,
+		pingRepo:              pingRepo,
 	}
 }
 
@@ -43,6 +54,30 @@ func (h *GetRestaurantRecommendationsHandler) Handle(
 		DietaryRestrictions:  query.DietaryRestrictions,
 		MaxDistance:          query.MaxDistance,
 		MaxResults:           query.MaxResults,
+		MealTime:             query.MealTime,
+		MeetingPointStrategy: query.MeetingPointStrategy,
+		WeightProfile:        query.WeightProfile,
+	}
+	if !query.UserID.IsEmpty() {
+		req.ParticipantIDs = []shared.UserID{query.UserID}
+	}
+
+	// 指定 ping 時以其預定時間排除未營業的餐廳，並以發起人與受邀者的用餐紀錄個人化
+	if query.PingID != "" {
+		pingID, err := shared.ParseID(query.PingID)
+		if err != nil {
+			return nil, shared.ErrInvalidInput
+		}
+		p, err := h.pingRepo.GetByID(ctx, pingID)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsParticipant(query.UserID) {
+			return nil, shared.ErrPermissionDenied
+		}
+		scheduledAt := p.ScheduledAt()
+		req.MealTime = &scheduledAt
+		req.ParticipantIDs = append([]shared.UserID{p.CreatedBy()}, p.Invitees()...)
 	}
 
 	// 呼叫 domain service
@@ -0,0 +1,51 @@
+package restaurant
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// GetTasteProfileQuery 取得用戶的口味檔案，指定 PingID 時取得 ping 成員的群組口味檔案
+type GetTasteProfileQuery struct {
+	UserID shared.UserID `json:"-"`
+	PingID string        `json:"pingId,omitempty"`
+}
+
+// GetTasteProfileHandler 取得口味檔案處理器
+type GetTasteProfileHandler struct {
+	recommendationService *restaurant.RecommendationService
+	pingRepo              ping.Repository
+}
+
+// NewGetTasteProfileHandler 建立取得口味檔案處理器
+func NewGetTasteProfileHandler(recommendationService *restaurant.RecommendationService, pingRepo ping.Repository) *GetTasteProfileHandler {
+	return &GetTasteProfileHandler{
+		recommendationService: recommendationService,
+		pingRepo:              pingRepo,
+	}
+}
+
+// Handle 處理取得口味檔案查詢
+func (h *GetTasteProfileHandler) Handle(ctx context.Context, query GetTasteProfileQuery) (*restaurant.TasteProfile, error) {
+	userIDs := []shared.UserID{query.UserID}
+
+	if query.PingID != "" {
+		pingID, err := shared.ParseID(query.PingID)
+		if err != nil {
+			return nil, shared.ErrInvalidInput
+		}
+		p, err := h.pingRepo.GetByID(ctx, pingID)
+		if err != nil {
+			return nil, err
+		}
+		if !p.IsParticipant(query.UserID) {
+			return nil, shared.ErrPermissionDenied
+		}
+		userIDs = append([]shared.UserID{p.CreatedBy()}, p.Invitees()...)
+	}
+
+	return h.recommendationService.TasteProfile(ctx, userIDs)
+}
@@ -2,6 +2,7 @@ package restaurant
 
 import (
 	"context"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
 )
@@ -26,6 +27,7 @@ type SearchRestaurantsQuery struct {
 	// 其他條件
 	MinRating           float64 `json:"minRating,omitempty"`
 	AcceptsReservations *bool   `json:"acceptsReservations,omitempty"`
+	OpenAt              *time.Time `json:"openAt,omitempty"`
 }
 
 // SearchRestaurantsHandler 搜尋餐廳處理器
@@ -59,6 +61,7 @@ func (h *SearchRestaurantsHandler) Handle(
 		MinRating:           query.MinRating,
 		AcceptsReservations: query.AcceptsReservations,
 		IsActive:            boolPtr(true),
+		OpenAt:              query.OpenAt,
 	}
 
 	return h.repository.Search(ctx, criteria)
@@ -0,0 +1,104 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+)
+
+// ReviewView 評論與目前用戶看到的有幫助狀態
+type ReviewView struct {
+	*review.Review
+	HelpfulCount  int  `json:"helpfulCount"`
+	MarkedHelpful bool `json:"markedHelpful"` // 目前用戶是否已標記為有幫助
+}
+
+// NewReviewView 以目前用戶的角度呈現評論
+func NewReviewView(r *review.Review, viewer shared.UserID) ReviewView {
+	return ReviewView{
+		Review:        r,
+		HelpfulCount:  r.HelpfulCount(),
+		MarkedHelpful: r.IsHelpfulTo(viewer),
+	}
+}
+
+func newReviewViews(reviews []*review.Review, viewer shared.UserID) []ReviewView {
+	views := make([]ReviewView, len(reviews))
+	for i, r := range reviews {
+		views[i] = NewReviewView(r, viewer)
+	}
+	return views
+}
+
+// GetRestaurantReviewsQuery 列出餐廳的評論
+type GetRestaurantReviewsQuery struct {
+	ViewerID     shared.UserID       `json:"-"`
+	RestaurantID shared.RestaurantID `json:"-"`
+	Sort         review.SortOrder    `json:"sort"`
+	Limit        int                 `json:"limit" validate:"min=1,max=100"`
+	Offset       int                 `json:"offset" validate:"min=0"`
+}
+
+// GetRestaurantReviewsResult 評論列表與評分統計
+type GetRestaurantReviewsResult struct {
+	Reviews []ReviewView         `json:"reviews"`
+	Summary review.RatingSummary `json:"summary"`
+}
+
+type GetRestaurantReviewsHandler struct {
+	reviewService *review.Service
+	policy        *social.InteractionPolicy
+}
+
+func NewGetRestaurantReviewsHandler(reviewService *review.Service, policy *social.InteractionPolicy) *GetRestaurantReviewsHandler {
+	return &GetRestaurantReviewsHandler{
+		reviewService: reviewService,
+		policy:        policy,
+	}
+}
+
+// Handle 不顯示與目前用戶有封鎖關係的用戶寫的評論，評分統計仍包含所有評論
+// 排除在分頁前進行，每頁仍有完整的筆數
+func (h *GetRestaurantReviewsHandler) Handle(ctx context.Context, query GetRestaurantReviewsQuery) (*GetRestaurantReviewsResult, error) {
+	blocked, err := h.policy.BlockedUsers(ctx, query.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, summary, err := h.reviewService.ListForRestaurant(ctx, query.RestaurantID, query.Sort, blocked.UserIDs(), query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetRestaurantReviewsResult{
+		Reviews: newReviewViews(reviews, query.ViewerID),
+		Summary: summary,
+	}, nil
+}
+
+// GetUserReviewsQuery 列出用戶寫的評論
+type GetUserReviewsQuery struct {
+	UserID shared.UserID `json:"-"`
+	Limit  int           `json:"limit" validate:"min=1,max=100"`
+	Offset int           `json:"offset" validate:"min=0"`
+}
+
+type GetUserReviewsHandler struct {
+	reviewService *review.Service
+}
+
+func NewGetUserReviewsHandler(reviewService *review.Service) *GetUserReviewsHandler {
+	return &GetUserReviewsHandler{
+		reviewService: reviewService,
+	}
+}
+
+func (h *GetUserReviewsHandler) Handle(ctx context.Context, query GetUserReviewsQuery) ([]ReviewView, error) {
+	reviews, err := h.reviewService.ListForUser(ctx, query.UserID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return newReviewViews(reviews, query.UserID), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
 )
 
@@ -12,16 +13,17 @@ type GetUserProfileQuery struct {
 }
 
 type UserProfileResult struct {
-	ID              string                 `json:"id"`
-	Email           string                 `json:"email"`
-	PhoneNumber     string                 `json:"phoneNumber,omitempty"`
-	Profile         user.UserProfile       `json:"profile"`
-	Preferences     user.DietaryPreferences `json:"preferences"`
-	PrivacySettings user.PrivacySettings   `json:"privacySettings"`
-	IsActive        bool                   `json:"isActive"`
-	IsVerified      bool                   `json:"isVerified"`
-	CreatedAt       string                 `json:"createdAt"`
-	UpdatedAt       string                 `json:"updatedAt"`
+	ID                      string                       `json:"id"`
+	Email                   string                       `json:"email"`
+	PhoneNumber             string                       `json:"phoneNumber,omitempty"`
+	Profile                 user.UserProfile             `json:"profile"`
+	Preferences             user.DietaryPreferences      `json:"preferences"`
+	PrivacySettings         user.PrivacySettings         `json:"privacySettings"`
+	NotificationPreferences user.NotificationPreferences `json:"notificationPreferences"`
+	IsActive                bool                         `json:"isActive"`
+	IsVerified              bool                         `json:"isVerified"`
+	CreatedAt               string                       `json:"createdAt"`
+	UpdatedAt               string                       `json:"updatedAt"`
 }
 
 type GetUserProfileHandler struct {
@@ -41,23 +43,25 @@ func (h *GetUserProfileHandler) Handle(ctx context.Context, query GetUserProfile
 	}
 	
 	return &UserProfileResult{
-		ID:              user.ID.String(),
-		Email:           user.Email,
-		PhoneNumber:     user.PhoneNumber,
-		Profile:         user.Profile,
-		Preferences:     user.Preferences,
-		PrivacySettings: user.PrivacySettings,
-		IsActive:        user.IsActive,
-		IsVerified:      user.IsVerified,
-		CreatedAt:       user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:       user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                      user.ID.String(),
+		Email:                   user.Email,
+		PhoneNumber:             user.PhoneNumber,
+		Profile:                 user.Profile,
+		Preferences:             user.Preferences,
+		PrivacySettings:         user.PrivacySettings,
+		NotificationPreferences: user.NotificationPreferences,
+		IsActive:                user.IsActive,
+		IsVerified:              user.IsVerified,
+		CreatedAt:               user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:               user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}, nil
 }
 
 type SearchUsersQuery struct {
-	Query  string `json:"query"`
-	Limit  int    `json:"limit" validate:"min=1,max=100"`
-	Offset int    `json:"offset" validate:"min=0"`
+	ViewerID shared.UserID `json:"-"`
+	Query    string        `json:"query"`
+	Limit    int           `json:"limit" validate:"min=1,max=100"`
+	Offset   int           `json:"offset" validate:"min=0"`
 }
 
 type SearchUsersResult struct {
@@ -76,30 +80,38 @@ type UserSearchResult struct {
 
 type SearchUsersHandler struct {
 	userService *user.UserService
+	policy      *social.InteractionPolicy
 }
 
-func NewSearchUsersHandler(userService *user.UserService) *SearchUsersHandler {
+func NewSearchUsersHandler(userService *user.UserService, policy *social.InteractionPolicy) *SearchUsersHandler {
 	return &SearchUsersHandler{
 		userService: userService,
+		policy:      policy,
 	}
 }
 
 func (h *SearchUsersHandler) Handle(ctx context.Context, query SearchUsersQuery) (*SearchUsersResult, error) {
-	users, err := h.userService.SearchDiscoverableUsers(ctx, query.Query, query.Limit, query.Offset)
+	// 搜尋結果不包含與查詢者有封鎖關係的用戶，排除在分頁前進行
+	blocked, err := h.policy.BlockedUsers(ctx, query.ViewerID)
 	if err != nil {
 		return nil, err
 	}
 	
-	results := make([]UserSearchResult, len(users))
-	for i, u := range users {
-		results[i] = UserSearchResult{
+	users, err := h.userService.SearchDiscoverableUsers(ctx, query.Query, blocked.UserIDs(), query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+	
+	results := make([]UserSearchResult, 0, len(users))
+	for _, u := range users {
+		results = append(results, UserSearchResult{
 			ID:          u.ID.String(),
 			Email:       u.Email,
 			DisplayName: u.Profile.DisplayName,
 			Avatar:      u.Profile.Avatar,
 			Bio:         u.Profile.Bio,
 			IsVerified:  u.IsVerified,
-		}
+		})
 	}
 	
 	return &SearchUsersResult{
@@ -0,0 +1,275 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// LeaseName 是排程器 leader 租約的名稱
+const LeaseName = "scheduler"
+
+// storeTimeout 關閉時寫入執行紀錄與釋放租約的時間上限
+const storeTimeout = 5 * time.Second
+
+// JobFunc 執行一次工作，回傳處理的項目數
+type JobFunc func(ctx context.Context) (int, error)
+
+// Job 是依固定間隔執行的背景工作
+type Job struct {
+	Name     string
+	Interval time.Duration // 執行間隔，0 代表停用此工作
+	Run      JobFunc
+}
+
+// Options 設定排程器，零值欄位使用預設值
+type Options struct {
+	InstanceID   string        // 辨識此 instance，預設為 hostname 與 process ID
+	TickInterval time.Duration // 檢查到期工作並續約租約的間隔
+	LeaseTTL     time.Duration // 租約有效時間，須大於 TickInterval 與單一工作的執行時間
+	Retention    time.Duration // 執行紀錄保留多久後刪除，0 代表不刪除
+}
+
+// DefaultOptions 回傳預設的排程器設定
+func DefaultOptions() Options {
+	return Options{
+		TickInterval: 5 * time.Second,
+		LeaseTTL:     30 * time.Second,
+		Retention:    7 * 24 * time.Hour,
+	}
+}
+
+// Scheduler 在取得 leader 租約的 instance 上依序執行到期的工作
+type Scheduler struct {
+	leases  LeaseStore
+	runs    RunStore
+	options Options
+
+	mu        sync.Mutex
+	jobs      []*scheduledJob
+	leader    bool
+	lastPurge time.Time
+}
+
+type scheduledJob struct {
+	Job
+	nextRun time.Time // 零值代表尚未排程，需依執行紀錄計算
+}
+
+func NewScheduler(leases LeaseStore, runs RunStore, options Options) *Scheduler {
+	defaults := DefaultOptions()
+	if options.InstanceID == "" {
+		options.InstanceID = defaultInstanceID()
+	}
+	if options.TickInterval <= 0 {
+		options.TickInterval = defaults.TickInterval
+	}
+	if options.LeaseTTL <= 0 {
+		options.LeaseTTL = defaults.LeaseTTL
+	}
+
+	return &Scheduler{
+		leases:  leases,
+		runs:    runs,
+		options: options,
+	}
+}
+
+// InstanceID 回傳此 instance 在租約與執行紀錄中的識別
+func (s *Scheduler) InstanceID() string {
+	return s.options.InstanceID
+}
+
+// Register 加入定期執行的工作；Interval 不大於 0 的工作視為停用
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 || job.Run == nil {
+		log.Printf("Scheduled job %s is disabled", job.Name)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &scheduledJob{Job: job})
+}
+
+// Run 持續執行到期的工作直到 ctx 結束；結束前等待執行中的工作返回並釋放租約
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.options.TickInterval)
+	defer ticker.Stop()
+	defer s.resign(ctx)
+
+	for {
+		if _, err := s.RunDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Scheduler tick failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue 取得或續約 leader 租約後執行到期的工作，回傳執行的工作數；
+// 未取得租約時不執行任何工作
+func (s *Scheduler) RunDue(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	leader, err := s.acquire(ctx)
+	if err != nil || !leader {
+		return 0, err
+	}
+
+	ran := 0
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		due, err := s.isDue(ctx, job, time.Now())
+		if err != nil {
+			return ran, err
+		}
+		if !due {
+			continue
+		}
+
+		// 每個工作執行前續約，避免前一個工作執行太久讓租約過期被其他 instance 接手
+		if ran > 0 {
+			if leader, err := s.acquire(ctx); err != nil || !leader {
+				return ran, err
+			}
+		}
+		s.execute(ctx, job)
+		ran++
+	}
+
+	s.purge(ctx)
+	return ran, nil
+}
+
+// acquire 取得或續約租約；leader 身分改變時清除排程，之後依執行紀錄重新計算
+func (s *Scheduler) acquire(ctx context.Context) (bool, error) {
+	leader, err := s.leases.TryAcquire(ctx, LeaseName, s.options.InstanceID, s.options.LeaseTTL)
+	if err != nil {
+		leader = false
+	}
+
+	if leader != s.leader {
+		if leader {
+			log.Printf("Scheduler instance %s became leader", s.options.InstanceID)
+		} else {
+			log.Printf("Scheduler instance %s is no longer leader", s.options.InstanceID)
+		}
+		s.leader = leader
+		for _, job := range s.jobs {
+			job.nextRun = time.Time{}
+		}
+	}
+	return leader, err
+}
+
+// isDue 檢查工作是否到期；尚未排程的工作以上次執行時間加上間隔計算，從未執行過則立即執行
+func (s *Scheduler) isDue(ctx context.Context, job *scheduledJob, now time.Time) (bool, error) {
+	if job.nextRun.IsZero() {
+		last, err := s.runs.LastRun(ctx, job.Name)
+		if err != nil {
+			return false, err
+		}
+		job.nextRun = now
+		if last != nil {
+			job.nextRun = last.StartedAt.Add(job.Interval)
+		}
+	}
+	return !now.Before(job.nextRun), nil
+}
+
+// execute 執行工作並寫入執行紀錄；失敗的工作等到下一個間隔再執行
+func (s *Scheduler) execute(ctx context.Context, job *scheduledJob) {
+	startedAt := time.Now()
+	processed, err := runSafely(ctx, job.Run)
+	job.nextRun = startedAt.Add(job.Interval)
+
+	run := JobRun{
+		ID:         shared.NewID().String(),
+		JobName:    job.Name,
+		InstanceID: s.options.InstanceID,
+		Status:     RunStatusSucceeded,
+		Processed:  processed,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+	}
+	if err != nil {
+		run.Status = RunStatusFailed
+		run.Error = err.Error()
+		log.Printf("Scheduled job %s failed: %v", job.Name, err)
+	} else if processed > 0 {
+		log.Printf("Scheduled job %s processed %d item(s)", job.Name, processed)
+	}
+
+	// 關閉中的工作仍要留下紀錄，因此不沿用已取消的 ctx
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+	if err := s.runs.RecordRun(recordCtx, run); err != nil {
+		log.Printf("Failed to record run of scheduled job %s: %v", job.Name, err)
+	}
+}
+
+// purge 每小時刪除一次超過保留時間的執行紀錄
+func (s *Scheduler) purge(ctx context.Context) {
+	if s.options.Retention <= 0 || time.Since(s.lastPurge) < time.Hour {
+		return
+	}
+
+	s.lastPurge = time.Now()
+	if purged, err := s.runs.PurgeRuns(ctx, s.lastPurge.Add(-s.options.Retention)); err != nil && ctx.Err() == nil {
+		log.Printf("Job run purge failed: %v", err)
+	} else if purged > 0 {
+		log.Printf("Purged %d job run record(s)", purged)
+	}
+}
+
+// resign 關閉時釋放租約，讓其他 instance 不必等租約過期即可接手
+func (s *Scheduler) resign(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.leader {
+		return
+	}
+	s.leader = false
+
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), storeTimeout)
+	defer cancel()
+	if err := s.leases.Release(releaseCtx, LeaseName, s.options.InstanceID); err != nil {
+		log.Printf("Failed to release scheduler lease: %v", err)
+		return
+	}
+	log.Printf("Scheduler instance %s released leadership", s.options.InstanceID)
+}
+
+// runSafely 執行工作並將 panic 轉為錯誤，避免單一工作拖垮排程器
+func runSafely(ctx context.Context, run JobFunc) (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// defaultInstanceID 以 hostname、process ID 與隨機字串辨識 instance，避免同主機上的 instance 衝突
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), shared.NewID().String()[:8])
+}
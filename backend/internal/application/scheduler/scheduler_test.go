@@ -0,0 +1,124 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+// countingJob 記錄工作被執行的次數
+type countingJob struct {
+	calls int
+	err   error
+}
+
+func (j *countingJob) run(ctx context.Context) (int, error) {
+	j.calls++
+	return 1, j.err
+}
+
+func newTestScheduler(leases scheduler.LeaseStore, runs scheduler.RunStore, instanceID string, jobs map[string]*countingJob) *scheduler.Scheduler {
+	s := scheduler.NewScheduler(leases, runs, scheduler.Options{
+		InstanceID: instanceID,
+		LeaseTTL:   time.Minute,
+	})
+	for name, job := range jobs {
+		s.Register(scheduler.Job{Name: name, Interval: time.Hour, Run: job.run})
+	}
+	return s
+}
+
+func TestRunDueOnlyRunsJobsOnLeader(t *testing.T) {
+	ctx := context.Background()
+	leases, runs := inmemory.NewLeaseStore(), inmemory.NewJobRunStore()
+
+	job := &countingJob{}
+	leader := newTestScheduler(leases, runs, "instance-a", map[string]*countingJob{"expire_pings": job})
+	follower := newTestScheduler(leases, runs, "instance-b", map[string]*countingJob{"expire_pings": job})
+
+	if ran, err := leader.RunDue(ctx); err != nil || ran != 1 {
+		t.Fatalf("leader RunDue() = %d, %v, want 1", ran, err)
+	}
+	if ran, err := follower.RunDue(ctx); err != nil || ran != 0 {
+		t.Fatalf("follower RunDue() = %d, %v, want 0", ran, err)
+	}
+	// 尚未到下一次執行時間
+	if ran, err := leader.RunDue(ctx); err != nil || ran != 0 {
+		t.Fatalf("leader second RunDue() = %d, %v, want 0", ran, err)
+	}
+	if job.calls != 1 {
+		t.Errorf("job ran %d times, want 1", job.calls)
+	}
+
+	last, err := runs.LastRun(ctx, "expire_pings")
+	if err != nil || last == nil {
+		t.Fatalf("LastRun() = %+v, %v, want a run", last, err)
+	}
+	if last.InstanceID != "instance-a" || last.Status != scheduler.RunStatusSucceeded || last.Processed != 1 {
+		t.Errorf("LastRun() = %+v, want succeeded run on instance-a processing 1 item", last)
+	}
+}
+
+func TestRunDueRecordsFailedRuns(t *testing.T) {
+	ctx := context.Background()
+	runs := inmemory.NewJobRunStore()
+
+	job := &countingJob{err: errors.New("database unavailable")}
+	s := newTestScheduler(inmemory.NewLeaseStore(), runs, "instance-a", map[string]*countingJob{"meal_reminders": job})
+	if _, err := s.RunDue(ctx); err != nil {
+		t.Fatalf("RunDue() error = %v", err)
+	}
+
+	last, _ := runs.LastRun(ctx, "meal_reminders")
+	if last == nil || last.Status != scheduler.RunStatusFailed || last.Error != "database unavailable" {
+		t.Errorf("LastRun() = %+v, want failed run with error message", last)
+	}
+}
+
+func TestNewLeaderSchedulesFromRunHistory(t *testing.T) {
+	leases, runs := inmemory.NewLeaseStore(), inmemory.NewJobRunStore()
+
+	first := &countingJob{}
+	leader := newTestScheduler(leases, runs, "instance-a", map[string]*countingJob{"expire_pings": first})
+
+	// Run 在 ctx 結束後返回並釋放租約
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		leader.Run(ctx)
+	}()
+	waitFor(t, func() bool {
+		last, _ := runs.LastRun(context.Background(), "expire_pings")
+		return last != nil
+	})
+	cancel()
+	<-done
+
+	// 接手的 instance 不會立即重跑剛執行過的工作
+	never := &countingJob{}
+	jobs := map[string]*countingJob{"expire_pings": never, "close_overdue_voting": {}}
+	takeover := newTestScheduler(leases, runs, "instance-b", jobs)
+	if n, err := takeover.RunDue(context.Background()); err != nil || n != 1 {
+		t.Fatalf("takeover RunDue() = %d, %v, want 1", n, err)
+	}
+	if never.calls != 0 || jobs["close_overdue_voting"].calls != 1 {
+		t.Errorf("takeover ran expire_pings %d times and close_overdue_voting %d times, want 0 and 1", never.calls, jobs["close_overdue_voting"].calls)
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
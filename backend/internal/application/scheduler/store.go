@@ -0,0 +1,51 @@
+// Package scheduler 在程序內定期執行背景工作 (ping 到期、投票截止、用餐提醒等)
+//
+// 多個 instance 同時運行時以 leader 租約選出一個 instance 執行工作，
+// 其他 instance 只定期嘗試取得租約，leader 停止續約後由其中一個接手。
+// 每次執行都會寫入執行紀錄；新 leader 依紀錄中的上次執行時間排程，
+// 不會因為換手而立即重跑所有工作。工作本身仍須可重複執行 (冪等)。
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// RunStatus 工作執行結果
+type RunStatus string
+
+const (
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+// JobRun 是一次工作執行的紀錄
+type JobRun struct {
+	ID         string    `json:"id"`
+	JobName    string    `json:"jobName"`
+	InstanceID string    `json:"instanceId"` // 執行工作的 instance
+	Status     RunStatus `json:"status"`
+	Processed  int       `json:"processed"` // 工作處理的項目數，例如到期的 ping 數
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// LeaseStore 管理 leader 租約
+type LeaseStore interface {
+	// TryAcquire 在租約未被持有、已過期或本來就由 holder 持有時取得 (或續約) 租約，
+	// 並將到期時間設為 now + ttl；租約由其他 holder 持有時回傳 false
+	TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release 釋放 holder 持有的租約，讓其他 instance 不必等到過期即可接手
+	Release(ctx context.Context, name, holder string) error
+}
+
+// RunStore 保存工作執行紀錄
+type RunStore interface {
+	// RecordRun 寫入一筆執行紀錄
+	RecordRun(ctx context.Context, run JobRun) error
+	// LastRun 取得工作最近一次開始的執行紀錄，沒有紀錄時回傳 nil
+	LastRun(ctx context.Context, jobName string) (*JobRun, error)
+	// PurgeRuns 刪除在 before 之前開始的執行紀錄，回傳刪除筆數
+	PurgeRuns(ctx context.Context, before time.Time) (int64, error)
+}
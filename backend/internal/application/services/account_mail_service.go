@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// AccountMailService 簽發帳號 token 並寄出對應的 Email
+type AccountMailService struct {
+	accountService *user.AccountService
+	mailer         communication.Mailer
+	appBaseURL     string
+}
+
+func NewAccountMailService(accountService *user.AccountService, mailer communication.Mailer, appBaseURL string) *AccountMailService {
+	return &AccountMailService{
+		accountService: accountService,
+		mailer:         mailer,
+		appBaseURL:     strings.TrimRight(appBaseURL, "/"),
+	}
+}
+
+// SendVerification 寄出 Email 驗證信
+func (s *AccountMailService) SendVerification(ctx context.Context, userID shared.UserID) error {
+	target, token, err := s.accountService.IssueVerificationToken(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	link := s.link("/verify-email", token)
+	return s.mailer.Send(ctx, communication.Message{
+		To:      target.Email,
+		Subject: "請驗證你的 Pingnom Email",
+		TextBody: fmt.Sprintf("Hi %s,\n\n請點擊以下連結完成 Email 驗證：\n%s\n\n連結將在 %s 後失效。\n",
+			target.Profile.DisplayName, link, formatTTL(s.accountService.VerificationTTL())),
+	})
+}
+
+// SendPasswordReset 寄出密碼重設信
+// 帳號不存在或已停用時不寄信也不回傳錯誤，避免洩漏帳號是否存在
+func (s *AccountMailService) SendPasswordReset(ctx context.Context, email string) error {
+	target, token, err := s.accountService.IssuePasswordReset(ctx, email)
+	if err != nil {
+		if errors.Is(err, shared.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	link := s.link("/reset-password", token)
+	return s.mailer.Send(ctx, communication.Message{
+		To:      target.Email,
+		Subject: "重設你的 Pingnom 密碼",
+		TextBody: fmt.Sprintf("Hi %s,\n\n請點擊以下連結重設密碼：\n%s\n\n連結將在 %s 後失效。如果你沒有申請重設密碼，請忽略這封信。\n",
+			target.Profile.DisplayName, link, formatTTL(s.accountService.PasswordResetTTL())),
+	})
+}
+
+func (s *AccountMailService) link(path, token string) string {
+	return s.appBaseURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func formatTTL(ttl time.Duration) string {
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		return fmt.Sprintf("%d 小時", int(ttl/time.Hour))
+	}
+	return fmt.Sprintf("%d 分鐘", int(ttl/time.Minute))
+}
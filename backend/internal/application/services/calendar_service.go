@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+const (
+	// feedLookback 訂閱中保留已開始的用餐多久，讓當天的用餐不會在開始後立即消失
+	feedLookback = 24 * time.Hour
+	eventDomain  = "@pingnom.app"
+)
+
+// ErrPlanNotConfirmed 聚餐尚未確認時間與餐廳，無法匯出
+var ErrPlanNotConfirmed = shared.NewDomainError(shared.KindConflict, "GROUP_DINING_PLAN_NOT_CONFIRMED", "group dining plan has not been confirmed")
+
+// CalendarService 將 ping 與已確認的聚餐匯出為 iCalendar，並管理用戶的行事曆訂閱
+type CalendarService struct {
+	pingRepo        ping.Repository
+	planRepo        interfaces.GroupDiningPlanRepository
+	userRepo        user.UserRepository
+	feedRepo        calendar.FeedRepository
+	refreshInterval time.Duration
+}
+
+func NewCalendarService(pingRepo ping.Repository, planRepo interfaces.GroupDiningPlanRepository, userRepo user.UserRepository, feedRepo calendar.FeedRepository, refreshInterval time.Duration) *CalendarService {
+	return &CalendarService{
+		pingRepo:        pingRepo,
+		planRepo:        planRepo,
+		userRepo:        userRepo,
+		feedRepo:        feedRepo,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// PingCalendar 匯出單一 ping，只有發起人與受邀者可以匯出
+// 已取消的 ping 以 METHOD:CANCEL 匯出，匯入後行事曆會移除先前的事件
+func (s *CalendarService) PingCalendar(ctx context.Context, requester shared.UserID, pingID shared.ID) (calendar.Calendar, error) {
+	p, err := s.pingRepo.GetByID(ctx, pingID)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	if !p.IsParticipant(requester) {
+		return calendar.Calendar{}, shared.ErrPermissionDenied
+	}
+
+	method := calendar.MethodPublish
+	if p.Status() == ping.PingStatusCancelled {
+		method = calendar.MethodCancel
+	}
+	return calendar.Calendar{
+		Method: method,
+		Events: []calendar.Event{s.pingEvent(ctx, p, newNameCache())},
+	}, nil
+}
+
+// PlanCalendar 匯出已確認的聚餐計畫，只有參與者可以匯出
+func (s *CalendarService) PlanCalendar(ctx context.Context, requester shared.UserID, planID string) (calendar.Calendar, error) {
+	plan, err := s.planRepo.GetByID(planID)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	if !isPlanParticipant(plan, requester) {
+		return calendar.Calendar{}, shared.ErrPermissionDenied
+	}
+	if plan.Status != aggregates.PlanStatusConfirmed || plan.ConfirmedTimeSlot == nil {
+		return calendar.Calendar{}, ErrPlanNotConfirmed
+	}
+
+	return calendar.Calendar{
+		Method: calendar.MethodPublish,
+		Events: []calendar.Event{planEvent(plan)},
+	}, nil
+}
+
+// CreateFeed 建立或輪替用戶的訂閱，回傳只會出現這一次的 token
+func (s *CalendarService) CreateFeed(ctx context.Context, userID shared.UserID) (*calendar.Feed, string, error) {
+	feed, token, err := calendar.NewFeed(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.feedRepo.Save(ctx, feed); err != nil {
+		return nil, "", err
+	}
+	return feed, token, nil
+}
+
+// DeleteFeed 撤銷用戶的訂閱
+func (s *CalendarService) DeleteFeed(ctx context.Context, userID shared.UserID) error {
+	return s.feedRepo.Delete(ctx, userID)
+}
+
+// FeedCalendar 以訂閱 token 產生用戶即將到來的用餐：
+// 自己發起或已接受的 ping (已取消的以 STATUS:CANCELLED 保留，讓行事曆移除) 與已確認的聚餐
+func (s *CalendarService) FeedCalendar(ctx context.Context, token string) (calendar.Calendar, error) {
+	feed, err := s.feedRepo.FindByTokenHash(ctx, calendar.HashFeedToken(token))
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+
+	from := time.Now().Add(-feedLookback)
+	names := newNameCache()
+	var events []calendar.Event
+
+	pings, err := s.pingRepo.GetUpcomingForUser(ctx, feed.UserID, from)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	for _, p := range pings {
+		if p.Status() == ping.PingStatusExpired {
+			continue
+		}
+		if !p.CreatedBy().Equals(feed.UserID) && responseStatus(p, feed.UserID) != ping.ResponseStatusAccepted {
+			continue
+		}
+		events = append(events, s.pingEvent(ctx, p, names))
+	}
+
+	plans, err := s.planRepo.GetByParticipant(feed.UserID.String(), nil)
+	if err != nil {
+		return calendar.Calendar{}, err
+	}
+	for _, plan := range plans {
+		if plan.Status != aggregates.PlanStatusConfirmed || plan.ConfirmedTimeSlot == nil || plan.ConfirmedTimeSlot.StartTime.Before(from) {
+			continue
+		}
+		events = append(events, planEvent(plan))
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return calendar.Calendar{
+		Name:            "Pingnom",
+		RefreshInterval: s.refreshInterval,
+		Events:          events,
+	}, nil
+}
+
+func (s *CalendarService) pingEvent(ctx context.Context, p *ping.Ping, names nameCache) calendar.Event {
+	event := calendar.Event{
+		UID:          "ping-" + p.ID().String() + eventDomain,
+		Sequence:     p.Sequence(),
+		Status:       calendar.EventConfirmed,
+		Summary:      p.Title(),
+		Description:  p.Description(),
+		Start:        p.ScheduledAt(),
+		End:          p.ScheduledAt().Add(availability.DefaultMealDuration),
+		Organizer:    calendar.Attendee{ID: p.CreatedBy().String(), Name: s.displayName(ctx, p.CreatedBy(), names)},
+		Created:      p.CreatedAt(),
+		LastModified: p.UpdatedAt(),
+	}
+	if p.Status() == ping.PingStatusCancelled {
+		event.Status = calendar.EventCancelled
+	}
+	if location := p.Location(); location != nil {
+		event.Location = location.Address
+		event.Geo = location
+	}
+
+	for _, invitee := range p.Invitees() {
+		event.Attendees = append(event.Attendees, calendar.Attendee{
+			ID:     invitee.String(),
+			Name:   s.displayName(ctx, invitee, names),
+			Status: participationStatus(responseStatus(p, invitee)),
+		})
+	}
+	return event
+}
+
+// planEvent 將已確認的聚餐轉為事件；確認後的計畫無法再變更或取消，因此 SEQUENCE 固定為 0
+func planEvent(plan *aggregates.GroupDiningPlan) calendar.Event {
+	event := calendar.Event{
+		UID:          "plan-" + plan.ID + eventDomain,
+		Status:       calendar.EventConfirmed,
+		Summary:      plan.Title,
+		Description:  plan.Description,
+		Start:        plan.ConfirmedTimeSlot.StartTime,
+		End:          plan.ConfirmedTimeSlot.EndTime,
+		Organizer:    calendar.Attendee{ID: plan.CreatedBy},
+		Created:      plan.CreatedAt,
+		LastModified: plan.UpdatedAt,
+	}
+
+	if restaurant := plan.ConfirmedRestaurant; restaurant != nil {
+		event.Location = restaurant.Name
+		if restaurant.Address != "" {
+			event.Location += ", " + restaurant.Address
+		}
+		if restaurant.Latitude != 0 || restaurant.Longitude != 0 {
+			event.Geo = &shared.Location{Latitude: restaurant.Latitude, Longitude: restaurant.Longitude}
+		}
+	}
+
+	for _, participant := range plan.Participants {
+		if participant.UserID == plan.CreatedBy {
+			event.Organizer.Name = participant.DisplayName
+			continue
+		}
+		event.Attendees = append(event.Attendees, calendar.Attendee{
+			ID:     participant.UserID,
+			Name:   participant.DisplayName,
+			Status: planParticipationStatus(participant.Status),
+		})
+	}
+	return event
+}
+
+// nameCache 避免同一份行事曆重複查詢同一位用戶
+type nameCache map[shared.UserID]string
+
+func newNameCache() nameCache {
+	return make(nameCache)
+}
+
+// displayName 查不到用戶時回傳空字串，事件仍以 urn:uuid 標示參加者
+func (s *CalendarService) displayName(ctx context.Context, userID shared.UserID, names nameCache) string {
+	if name, ok := names[userID]; ok {
+		return name
+	}
+
+	var name string
+	if u, err := s.userRepo.FindByID(ctx, userID); err == nil {
+		name = u.Profile.DisplayName
+	}
+	names[userID] = name
+	return name
+}
+
+// planParticipationStatus 尚未回覆的受邀者為 NEEDS-ACTION
+func planParticipationStatus(status aggregates.ParticipantStatus) calendar.ParticipationStatus {
+	switch status.OrDefault() {
+	case aggregates.ParticipantStatusPending:
+		return calendar.ParticipationNeedsAction
+	case aggregates.ParticipantStatusDeclined:
+		return calendar.ParticipationDeclined
+	default:
+		return calendar.ParticipationAccepted
+	}
+}
+
+// isPlanParticipant 只有建立者與已接受邀請的參與者算是計畫的成員
+func isPlanParticipant(plan *aggregates.GroupDiningPlan, userID shared.UserID) bool {
+	return plan.IsCreator(userID.String()) || plan.IsParticipant(userID.String())
+}
+
+func responseStatus(p *ping.Ping, userID shared.UserID) ping.ResponseStatus {
+	for _, response := range p.Responses() {
+		if response.UserID.Equals(userID) {
+			return response.Status
+		}
+	}
+	return ping.ResponseStatusPending
+}
+
+func participationStatus(status ping.ResponseStatus) calendar.ParticipationStatus {
+	switch status {
+	case ping.ResponseStatusAccepted:
+		return calendar.ParticipationAccepted
+	case ping.ResponseStatusDeclined:
+		return calendar.ParticipationDeclined
+	default:
+		return calendar.ParticipationNeedsAction
+	}
+}
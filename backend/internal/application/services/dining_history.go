@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	// diningHistoryLookback 只參考這段期間內的 ping 與聚餐，更早的紀錄衰減後影響很小
+	diningHistoryLookback = 2 * 365 * 24 * time.Hour
+
+	// diningHistoryReviewLimit 參考的最近評論數
+	diningHistoryReviewLimit = 200
+)
+
+// DiningHistoryService 以已完成的 ping、已確認的聚餐與評論實作 restaurant.DiningHistory
+// ping 與聚餐只記錄地點，需對應到目錄中的餐廳才會成為用餐紀錄
+type DiningHistoryService struct {
+	pingRepo       ping.Repository
+	planRepo       interfaces.GroupDiningPlanRepository
+	reviewRepo     review.Repository
+	restaurantRepo restaurant.Repository
+	now            func() time.Time
+}
+
+func NewDiningHistoryService(pingRepo ping.Repository, planRepo interfaces.GroupDiningPlanRepository, reviewRepo review.Repository, restaurantRepo restaurant.Repository) *DiningHistoryService {
+	return &DiningHistoryService{
+		pingRepo:       pingRepo,
+		planRepo:       planRepo,
+		reviewRepo:     reviewRepo,
+		restaurantRepo: restaurantRepo,
+		now:            time.Now,
+	}
+}
+
+func (s *DiningHistoryService) DiningSignals(ctx context.Context, userID shared.UserID) ([]restaurant.DiningSignal, error) {
+	now := s.now()
+	var signals []restaurant.DiningSignal
+
+	pings, err := s.pingRepo.GetUpcomingForUser(ctx, userID, now.Add(-diningHistoryLookback))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range pings {
+		if p.Status() != ping.PingStatusCompleted || p.ScheduledAt().After(now) {
+			continue
+		}
+		if !p.CreatedBy().Equals(userID) && responseStatus(p, userID) != ping.ResponseStatusAccepted {
+			continue
+		}
+		loc := p.Location()
+		if loc == nil || (loc.Latitude == 0 && loc.Longitude == 0) {
+			continue
+		}
+		rest, err := s.restaurantAt(ctx, "", restaurant.Location{Latitude: loc.Latitude, Longitude: loc.Longitude})
+		if err != nil {
+			return nil, err
+		}
+		if rest != nil {
+			signals = append(signals, restaurant.DiningSignal{Restaurant: rest, Source: restaurant.DiningSourcePing, OccurredAt: p.ScheduledAt()})
+		}
+	}
+
+	plans, err := s.planRepo.GetByParticipant(userID.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if plan.Status != aggregates.PlanStatusConfirmed || plan.ConfirmedTimeSlot == nil || plan.ConfirmedRestaurant == nil {
+			continue
+		}
+		startTime := plan.ConfirmedTimeSlot.StartTime
+		if startTime.After(now) || startTime.Before(now.Add(-diningHistoryLookback)) {
+			continue
+		}
+		option := plan.ConfirmedRestaurant
+		rest, err := s.restaurantAt(ctx, option.Name, restaurant.Location{Latitude: option.Latitude, Longitude: option.Longitude})
+		if err != nil {
+			return nil, err
+		}
+		if rest != nil {
+			signals = append(signals, restaurant.DiningSignal{Restaurant: rest, Source: restaurant.DiningSourceGroupDiningPlan, OccurredAt: startTime})
+		}
+	}
+
+	reviews, err := s.reviewRepo.FindByUser(ctx, userID, diningHistoryReviewLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range reviews {
+		rest, err := s.restaurantRepo.FindByID(ctx, r.RestaurantID)
+		if errors.Is(err, shared.ErrRestaurantNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		signals = append(signals, restaurant.DiningSignal{Restaurant: rest, Source: restaurant.DiningSourceReview, Stars: r.Stars, OccurredAt: r.UpdatedAt})
+	}
+
+	return signals, nil
+}
+
+// restaurantAt 找出地點附近的目錄餐廳；有名稱時需名稱相符，沒有名稱時取最近的一間
+func (s *DiningHistoryService) restaurantAt(ctx context.Context, name string, location restaurant.Location) (*restaurant.Restaurant, error) {
+	if location.Latitude == 0 && location.Longitude == 0 {
+		return nil, nil
+	}
+
+	nearby, err := s.restaurantRepo.FindNearby(ctx, location, restaurant.PlaceMatchRadiusKm, restaurant.SearchCriteria{
+		SortBy:    restaurant.SortByDistance,
+		SortOrder: restaurant.OrderAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, rest := range nearby {
+		if name == "" || rest.MatchesPlace(name, location) {
+			return rest, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// MealReminderJob 在 ping 或已確認的聚餐開始前 leadTime 內提醒參加者
+type MealReminderJob struct {
+	pingRepo            ping.Repository
+	planRepo            interfaces.GroupDiningPlanRepository
+	notificationService *notification.Service
+	leadTime            time.Duration
+}
+
+func NewMealReminderJob(pingRepo ping.Repository, planRepo interfaces.GroupDiningPlanRepository, notificationService *notification.Service, leadTime time.Duration) *MealReminderJob {
+	return &MealReminderJob{
+		pingRepo:            pingRepo,
+		planRepo:            planRepo,
+		notificationService: notificationService,
+		leadTime:            leadTime,
+	}
+}
+
+// SendDue 提醒即將開始的用餐，回傳成功提醒的用餐數
+// 每位參加者每場用餐只會收到一次提醒 (以 dedup key 去除重複)，因此可每分鐘執行
+// 單一用餐提醒失敗時記錄後繼續提醒其他用餐，最後一併回報錯誤
+func (j *MealReminderJob) SendDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	until := now.Add(j.leadTime)
+
+	pings, err := j.pingRepo.GetScheduledBetween(ctx, ping.PingStatusActive, now, until)
+	if err != nil {
+		return 0, err
+	}
+	reminded := 0
+	var failures []error
+	for _, p := range pings {
+		if err := j.remindPing(ctx, p, now); err != nil {
+			log.Printf("Failed to send meal reminder for ping %s: %v", p.ID(), err)
+			failures = append(failures, fmt.Errorf("remind ping %s: %w", p.ID(), err))
+			continue
+		}
+		reminded++
+	}
+
+	plans, err := j.planRepo.GetConfirmedStartingBetween(now, until)
+	if err != nil {
+		return reminded, errors.Join(append(failures, err)...)
+	}
+	for _, plan := range plans {
+		if err := j.remindPlan(ctx, plan, now); err != nil {
+			log.Printf("Failed to send meal reminder for plan %s: %v", plan.ID, err)
+			failures = append(failures, fmt.Errorf("remind plan %s: %w", plan.ID, err))
+			continue
+		}
+		reminded++
+	}
+
+	return reminded, errors.Join(failures...)
+}
+
+// remindPing 提醒發起人與已接受邀請的受邀者
+func (j *MealReminderJob) remindPing(ctx context.Context, p *ping.Ping, now time.Time) error {
+	recipients := []shared.UserID{p.CreatedBy()}
+	for _, response := range p.Responses() {
+		if response.Status == ping.ResponseStatusAccepted {
+			recipients = append(recipients, response.UserID)
+		}
+	}
+
+	body := p.Description()
+	if location := p.Location(); location != nil && location.Address != "" {
+		body = location.Address
+	}
+
+	data := map[string]string{
+		"pingId":   p.ID().String(),
+		"startsAt": p.ScheduledAt().UTC().Format(time.RFC3339),
+	}
+	return j.notify(ctx, recipients, startsInTitle(p.Title(), p.ScheduledAt(), now), body, data, "meal-reminder:ping:"+p.ID().String())
+}
+
+// remindPlan 提醒聚餐計畫的所有參與者
+func (j *MealReminderJob) remindPlan(ctx context.Context, plan *aggregates.GroupDiningPlan, now time.Time) error {
+	var recipients []shared.UserID
+	for _, participant := range plan.AcceptedParticipants() {
+		if userID, err := shared.NewUserIDFromString(participant.UserID); err == nil {
+			recipients = append(recipients, userID)
+		}
+	}
+
+	var body string
+	if restaurant := plan.ConfirmedRestaurant; restaurant != nil {
+		body = restaurant.Name
+		if restaurant.Address != "" {
+			body += ", " + restaurant.Address
+		}
+	}
+
+	start := plan.ConfirmedTimeSlot.StartTime
+	data := map[string]string{
+		"planId":   plan.ID,
+		"startsAt": start.UTC().Format(time.RFC3339),
+	}
+	return j.notify(ctx, recipients, startsInTitle(plan.Title, start, now), body, data, "meal-reminder:plan:"+plan.ID)
+}
+
+func (j *MealReminderJob) notify(ctx context.Context, recipients []shared.UserID, title, body string, data map[string]string, dedupKey string) error {
+	for _, recipient := range recipients {
+		n, err := notification.NewNotification(recipient, notification.TypeMealReminder, title, body, data, dedupKey)
+		if err != nil {
+			return err
+		}
+		if err := j.notificationService.Notify(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startsInTitle 產生 "Lunch starts in 30 minutes" 形式的標題，分鐘數無條件進位
+func startsInTitle(title string, start, now time.Time) string {
+	minutes := int(math.Ceil(start.Sub(now).Minutes()))
+	if minutes <= 1 {
+		return fmt.Sprintf("%s starts in 1 minute", title)
+	}
+	return fmt.Sprintf("%s starts in %d minutes", title, minutes)
+}
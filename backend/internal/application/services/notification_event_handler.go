@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// NotificationEventHandler 訂閱 outbox 派送的領域事件，通知受影響的用戶
+type NotificationEventHandler struct {
+	notificationService *notification.Service
+	userRepo            user.UserRepository
+}
+
+func NewNotificationEventHandler(notificationService *notification.Service, userRepo user.UserRepository) *NotificationEventHandler {
+	return &NotificationEventHandler{
+		notificationService: notificationService,
+		userRepo:            userRepo,
+	}
+}
+
+// Register 向 dispatcher 訂閱需要通知用戶的事件
+func (h *NotificationEventHandler) Register(dispatcher *events.Dispatcher) {
+	dispatcher.Subscribe(ping.EventPingCreated, events.SubscriberFunc(h.handlePingCreated))
+	dispatcher.Subscribe(friendship.EventFriendRequestSent, events.SubscriberFunc(h.handleFriendRequestSent))
+	dispatcher.Subscribe(aggregates.EventParticipantInvited, events.SubscriberFunc(h.handleParticipantInvited))
+}
+
+// handlePingCreated 通知每位受邀者收到了新的 ping
+func (h *NotificationEventHandler) handlePingCreated(ctx context.Context, message events.Message) error {
+	var event ping.PingCreated
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	sender, err := h.displayName(ctx, event.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	title := fmt.Sprintf("%s invited you to %s", sender, event.PingType)
+	data := map[string]string{
+		"pingId":    event.PingID.String(),
+		"createdBy": event.CreatedBy.String(),
+	}
+	if event.SeriesID != nil {
+		data["seriesId"] = event.SeriesID.String()
+	}
+	for _, invitee := range event.Invitees {
+		if err := h.notify(ctx, message, invitee, notification.TypePingInvite, title, event.Title, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleFriendRequestSent 通知被邀請者收到了好友邀請
+func (h *NotificationEventHandler) handleFriendRequestSent(ctx context.Context, message events.Message) error {
+	var event friendship.FriendRequestSent
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	sender, err := h.displayName(ctx, event.RequesterID)
+	if err != nil {
+		return err
+	}
+
+	return h.notify(ctx, message, event.AddresseeID, notification.TypeFriendRequest,
+		fmt.Sprintf("%s sent you a friend request", sender),
+		event.Message,
+		map[string]string{
+			"friendshipId": event.FriendshipID.String(),
+			"requesterId":  event.RequesterID.String(),
+		})
+}
+
+// handleParticipantInvited 通知受邀者被邀請加入聚餐計畫
+func (h *NotificationEventHandler) handleParticipantInvited(ctx context.Context, message events.Message) error {
+	var event aggregates.ParticipantInvited
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	// 無法解析的 ID 不是註冊用戶，沒有收件匣可以通知
+	invitee, err := shared.NewUserIDFromString(event.UserID)
+	if err != nil {
+		return nil
+	}
+	inviterID, err := shared.NewUserIDFromString(event.InvitedBy)
+	if err != nil {
+		return nil
+	}
+
+	sender, err := h.displayName(ctx, inviterID)
+	if err != nil {
+		return err
+	}
+
+	return h.notify(ctx, message, invitee, notification.TypePlanInvite,
+		fmt.Sprintf("%s invited you to a group dining plan", sender),
+		event.Title,
+		map[string]string{
+			"planId":    event.PlanID,
+			"invitedBy": event.InvitedBy,
+		})
+}
+
+// notify 以事件 ID 作為 dedup key，事件重複派送時不會重複通知
+func (h *NotificationEventHandler) notify(ctx context.Context, message events.Message, recipient shared.UserID, notificationType notification.Type, title, body string, data map[string]string) error {
+	n, err := notification.NewNotification(recipient, notificationType, title, body, data, message.ID)
+	if err != nil {
+		return err
+	}
+	return h.notificationService.Notify(ctx, n)
+}
+
+// displayName 取得發送者的顯示名稱，用戶已不存在時以 "Someone" 代替
+func (h *NotificationEventHandler) displayName(ctx context.Context, userID shared.UserID) (string, error) {
+	sender, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, shared.ErrUserNotFound) {
+			return "Someone", nil
+		}
+		return "", err
+	}
+	return sender.Profile.DisplayName, nil
+}
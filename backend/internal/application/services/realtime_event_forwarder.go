@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// PingRespondedPayload 是 communication.EventPingResponded 的內容
+type PingRespondedPayload struct {
+	PingID        string              `json:"pingId"`
+	UserID        string              `json:"userId"`
+	Status        ping.ResponseStatus `json:"status"`
+	Message       string              `json:"message,omitempty"`
+	AcceptedCount int                 `json:"acceptedCount"`
+	PendingCount  int                 `json:"pendingCount"`
+}
+
+// FriendRequestAcceptedPayload 是 communication.EventFriendRequestAccepted 的內容
+type FriendRequestAcceptedPayload struct {
+	FriendshipID string     `json:"friendshipId"`
+	RequesterID  string     `json:"requesterId"`
+	AddresseeID  string     `json:"addresseeId"`
+	AcceptedAt   *time.Time `json:"acceptedAt,omitempty"`
+}
+
+// RealtimeEventForwarder 訂閱 outbox 派送的領域事件，轉為即時事件推送給相關用戶
+type RealtimeEventForwarder struct {
+	publisher communication.Publisher
+	planRepo  interfaces.GroupDiningPlanRepository
+}
+
+func NewRealtimeEventForwarder(publisher communication.Publisher, planRepo interfaces.GroupDiningPlanRepository) *RealtimeEventForwarder {
+	return &RealtimeEventForwarder{
+		publisher: publisher,
+		planRepo:  planRepo,
+	}
+}
+
+// Register 向 dispatcher 訂閱需要即時推送的事件
+func (f *RealtimeEventForwarder) Register(dispatcher *events.Dispatcher) {
+	dispatcher.Subscribe(ping.EventPingResponded, events.SubscriberFunc(f.handlePingResponded))
+	dispatcher.Subscribe(friendship.EventFriendRequestAccepted, events.SubscriberFunc(f.handleFriendRequestAccepted))
+	dispatcher.Subscribe(aggregates.EventPlanVoteSubmitted, events.SubscriberFunc(f.handlePlanVoteSubmitted))
+	dispatcher.Subscribe(aggregates.EventPlanVotingClosed, events.SubscriberFunc(f.handlePlanVotingClosed))
+}
+
+// handlePingResponded 通知發起人與所有受邀者
+func (f *RealtimeEventForwarder) handlePingResponded(ctx context.Context, message events.Message) error {
+	var event ping.PingResponded
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	recipients := append([]shared.UserID{event.CreatedBy}, event.Invitees...)
+	f.publish(ctx, message, communication.EventPingResponded, recipients, PingRespondedPayload{
+		PingID:        event.PingID.String(),
+		UserID:        event.UserID.String(),
+		Status:        event.Status,
+		Message:       event.Message,
+		AcceptedCount: event.AcceptedCount,
+		PendingCount:  event.PendingCount,
+	})
+	return nil
+}
+
+// handleFriendRequestAccepted 通知雙方好友關係已建立
+func (f *RealtimeEventForwarder) handleFriendRequestAccepted(ctx context.Context, message events.Message) error {
+	var event friendship.FriendRequestAccepted
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	recipients := []shared.UserID{event.RequesterID, event.AddresseeID}
+	f.publish(ctx, message, communication.EventFriendRequestAccepted, recipients, FriendRequestAcceptedPayload{
+		FriendshipID: event.FriendshipID.String(),
+		RequesterID:  event.RequesterID.String(),
+		AddresseeID:  event.AddresseeID.String(),
+		AcceptedAt:   &event.AcceptedAt,
+	})
+	return nil
+}
+
+// handlePlanVoteSubmitted 將最新票數推送給發起人與所有參與者
+func (f *RealtimeEventForwarder) handlePlanVoteSubmitted(ctx context.Context, message events.Message) error {
+	var event aggregates.PlanVoteSubmitted
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	plan, err := f.planRepo.GetByID(event.PlanID)
+	if err != nil {
+		return err
+	}
+
+	f.publish(ctx, message, communication.EventPlanVoteSubmitted, planRecipients(event.CreatedBy, event.Participants), dtos.VoteSubmittedPayload{
+		PlanID:  event.PlanID,
+		VoterID: event.VoterID,
+		Results: dtos.ToVotingResultsResponse(plan),
+	})
+	return nil
+}
+
+// handlePlanVotingClosed 投票截止時將最終票數推送給發起人與所有參與者
+func (f *RealtimeEventForwarder) handlePlanVotingClosed(ctx context.Context, message events.Message) error {
+	var event aggregates.PlanVotingClosed
+	if err := message.Decode(&event); err != nil {
+		return err
+	}
+
+	plan, err := f.planRepo.GetByID(event.PlanID)
+	if err != nil {
+		return err
+	}
+
+	f.publish(ctx, message, communication.EventPlanVotingClosed, planRecipients(event.CreatedBy, event.Participants), dtos.VotingClosedPayload{
+		PlanID:  event.PlanID,
+		Results: dtos.ToVotingResultsResponse(plan),
+	})
+	return nil
+}
+
+// publish 沿用 outbox 訊息的 ID 與發生時間，讓客戶端能以 ID 去除重複派送的事件
+func (f *RealtimeEventForwarder) publish(ctx context.Context, message events.Message, eventType communication.EventType, recipients []shared.UserID, payload interface{}) {
+	event := communication.NewEvent(eventType, recipients, payload)
+	event.ID = message.ID
+	event.OccurredAt = message.OccurredAt
+	f.publisher.Publish(ctx, event)
+}
+
+// planRecipients 將計畫發起人與參與者的 ID 轉為收件者，略過無法解析的 ID
+func planRecipients(createdBy string, participants []string) []shared.UserID {
+	recipients := make([]shared.UserID, 0, len(participants)+1)
+	for _, id := range append([]string{createdBy}, participants...) {
+		if userID, err := shared.NewUserIDFromString(id); err == nil {
+			recipients = append(recipients, userID)
+		}
+	}
+	return recipients
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// ReviewVisitVerifier 以 ping 與聚餐計畫實作 review.VisitVerifier
+//   - ping：已完成或已過預定時間 (expired)，用戶是發起人或已接受邀請；ping 有地點時需在餐廳附近
+//   - 聚餐計畫：已確認且確認時段已開始，用戶是參與者，確認的餐廳選項需對應到該餐廳
+type ReviewVisitVerifier struct {
+	pingRepo ping.Repository
+	planRepo interfaces.GroupDiningPlanRepository
+	now      func() time.Time
+}
+
+func NewReviewVisitVerifier(pingRepo ping.Repository, planRepo interfaces.GroupDiningPlanRepository) *ReviewVisitVerifier {
+	return &ReviewVisitVerifier{
+		pingRepo: pingRepo,
+		planRepo: planRepo,
+		now:      time.Now,
+	}
+}
+
+func (v *ReviewVisitVerifier) VerifyVisit(ctx context.Context, userID shared.UserID, rest *restaurant.Restaurant, visitType review.VisitType, visitID string) (time.Time, error) {
+	switch visitType {
+	case review.VisitTypePing:
+		return v.verifyPing(ctx, userID, rest, visitID)
+	case review.VisitTypeGroupDiningPlan:
+		return v.verifyPlan(userID, rest, visitID)
+	default:
+		return time.Time{}, shared.ErrInvalidInput
+	}
+}
+
+func (v *ReviewVisitVerifier) verifyPing(ctx context.Context, userID shared.UserID, rest *restaurant.Restaurant, visitID string) (time.Time, error) {
+	pingID, err := shared.ParseID(visitID)
+	if err != nil {
+		return time.Time{}, shared.ErrInvalidInput
+	}
+	p, err := v.pingRepo.GetByID(ctx, pingID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if p.Status() != ping.PingStatusCompleted && p.Status() != ping.PingStatusExpired {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	if p.ScheduledAt().After(v.now()) {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	if !p.CreatedBy().Equals(userID) && responseStatus(p, userID) != ping.ResponseStatusAccepted {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	if loc := p.Location(); loc != nil && (loc.Latitude != 0 || loc.Longitude != 0) {
+		if !rest.IsNear(restaurant.Location{Latitude: loc.Latitude, Longitude: loc.Longitude}) {
+			return time.Time{}, shared.ErrVisitNotEligible
+		}
+	}
+
+	return p.ScheduledAt(), nil
+}
+
+func (v *ReviewVisitVerifier) verifyPlan(userID shared.UserID, rest *restaurant.Restaurant, visitID string) (time.Time, error) {
+	plan, err := v.planRepo.GetByID(visitID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if plan.Status != aggregates.PlanStatusConfirmed || plan.ConfirmedTimeSlot == nil || plan.ConfirmedRestaurant == nil {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	if plan.ConfirmedTimeSlot.StartTime.After(v.now()) {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	if !isPlanParticipant(plan, userID) {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+
+	option := plan.ConfirmedRestaurant
+	if !rest.MatchesPlace(option.Name, restaurant.Location{Latitude: option.Latitude, Longitude: option.Longitude}) {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+
+	return plan.ConfirmedTimeSlot.StartTime, nil
+}
@@ -0,0 +1,10 @@
+package services
+
+// 排程工作名稱，同時作為執行紀錄中的 job_name
+const (
+	JobExpirePings             = "expire_pings"
+	JobCloseOverdueVoting      = "close_overdue_voting"
+	JobMealReminders           = "meal_reminders"
+	JobSweepUnverifiedAccounts = "sweep_unverified_accounts"
+	JobMaterializePingSeries   = "materialize_ping_series"
+)
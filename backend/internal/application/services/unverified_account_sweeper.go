@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// UnverifiedAccountSweeper 停用註冊後長時間未驗證 Email 的帳號，由排程器定期執行
+type UnverifiedAccountSweeper struct {
+	accountService *user.AccountService
+	sessionService *session.Service
+	maxAgeDays     int
+}
+
+func NewUnverifiedAccountSweeper(accountService *user.AccountService, sessionService *session.Service, maxAgeDays int) *UnverifiedAccountSweeper {
+	return &UnverifiedAccountSweeper{
+		accountService: accountService,
+		sessionService: sessionService,
+		maxAgeDays:     maxAgeDays,
+	}
+}
+
+// SweepOnce 停用逾期未驗證的帳號並撤銷其所有 session，回傳停用的帳號數；maxAgeDays 為 0 時不執行
+func (s *UnverifiedAccountSweeper) SweepOnce(ctx context.Context) (int, error) {
+	if s.maxAgeDays <= 0 {
+		return 0, nil
+	}
+
+	deactivated, err := s.accountService.DeactivateUnverified(ctx, s.maxAgeDays)
+	for _, userID := range deactivated {
+		if revokeErr := s.sessionService.RevokeAll(ctx, userID); revokeErr != nil {
+			log.Printf("Failed to revoke sessions of deactivated user %s: %v", userID, revokeErr)
+		}
+	}
+	if len(deactivated) > 0 {
+		log.Printf("Deactivated %d unverified account(s)", len(deactivated))
+	}
+	return len(deactivated), err
+}
@@ -0,0 +1,221 @@
+package availability
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	DefaultTimeZone = "Asia/Taipei"
+	MaxWindows      = 50
+	MaxBusyBlocks   = 200
+)
+
+// WeeklyWindow 每週固定的可用時段，Start、End 為 "HH:MM" 格式的當地時間，不可跨越午夜
+type WeeklyWindow struct {
+	Day   time.Weekday `json:"day"` // 0 = Sunday
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+}
+
+// BusyBlock 特定時間的行程，期間即使落在每週可用時段內也視為沒空
+type BusyBlock struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Title string    `json:"title,omitempty"`
+}
+
+// Interval is a half-open time range [Start, End)
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Contains checks if other lies completely within the interval
+func (i Interval) Contains(other Interval) bool {
+	return !other.Start.Before(i.Start) && !other.End.After(i.End)
+}
+
+// Schedule 是用戶公開的可用時間，供聚餐計畫建議時段使用
+type Schedule struct {
+	UserID     shared.UserID  `json:"userId"`
+	TimeZone   string         `json:"timeZone"` // IANA 時區，每週時段以此時區解讀
+	Windows    []WeeklyWindow `json:"windows"`
+	BusyBlocks []BusyBlock    `json:"busyBlocks"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// NewSchedule 建立並驗證用戶的可用時間
+func NewSchedule(userID shared.UserID, timeZone string, windows []WeeklyWindow, busyBlocks []BusyBlock) (*Schedule, error) {
+	if userID.IsEmpty() {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+
+	schedule := &Schedule{
+		UserID:     userID,
+		TimeZone:   timeZone,
+		Windows:    windows,
+		BusyBlocks: busyBlocks,
+		UpdatedAt:  time.Now(),
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(schedule.BusyBlocks, func(i, j int) bool {
+		return schedule.BusyBlocks[i].Start.Before(schedule.BusyBlocks[j].Start)
+	})
+	return schedule, nil
+}
+
+// EmptySchedule 尚未設定可用時間的用戶視為沒有任何可用時段
+func EmptySchedule(userID shared.UserID) *Schedule {
+	return &Schedule{
+		UserID:     userID,
+		TimeZone:   DefaultTimeZone,
+		Windows:    []WeeklyWindow{},
+		BusyBlocks: []BusyBlock{},
+	}
+}
+
+// Validate 檢查時區、每週時段與行程的格式
+func (s *Schedule) Validate() error {
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return shared.ErrInvalidInput.WithMessage("time zone is invalid")
+	}
+	if len(s.Windows) > MaxWindows {
+		return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("cannot have more than %d weekly windows", MaxWindows))
+	}
+	if len(s.BusyBlocks) > MaxBusyBlocks {
+		return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("cannot have more than %d busy blocks", MaxBusyBlocks))
+	}
+
+	for _, window := range s.Windows {
+		if window.Day < time.Sunday || window.Day > time.Saturday {
+			return shared.ErrInvalidInput.WithMessage("window day must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		start, err := parseClock(window.Start)
+		if err != nil {
+			return shared.ErrInvalidInput.WithMessage("window start must be in HH:MM format")
+		}
+		end, err := parseClock(window.End)
+		if err != nil {
+			return shared.ErrInvalidInput.WithMessage("window end must be in HH:MM format")
+		}
+		if start >= end {
+			return shared.ErrInvalidInput.WithMessage("window start must be before its end")
+		}
+	}
+
+	for _, block := range s.BusyBlocks {
+		if !block.Start.Before(block.End) {
+			return shared.ErrInvalidInput.WithMessage("busy block start must be before its end")
+		}
+	}
+	return nil
+}
+
+// FreeIntervals 回傳 [from, to) 之間用戶有空的時段：每週時段展開後扣除行程，依時間排序
+func (s *Schedule) FreeIntervals(from, to time.Time) []Interval {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	var free []Interval
+	// 以當地日期逐日展開，前後各多算一天以涵蓋時區差
+	day := time.Date(from.In(location).Year(), from.In(location).Month(), from.In(location).Day()-1, 0, 0, 0, 0, location)
+	for ; day.Before(to.Add(24 * time.Hour)); day = day.AddDate(0, 0, 1) {
+		for _, window := range s.Windows {
+			if window.Day != day.Weekday() {
+				continue
+			}
+			start, _ := parseClock(window.Start)
+			end, _ := parseClock(window.End)
+			interval := Interval{
+				Start: clockOn(day, start, location),
+				End:   clockOn(day, end, location),
+			}
+			if clipped, ok := clip(interval, from, to); ok {
+				free = append(free, clipped)
+			}
+		}
+	}
+
+	free = merge(free)
+	for _, block := range s.BusyBlocks {
+		free = subtract(free, Interval{Start: block.Start, End: block.End})
+	}
+	return free
+}
+
+// clockOn 回傳當地日期 day 的第 minute 分鐘；用 time.Date 計算讓日光節約時間的日子也正確
+func clockOn(day time.Time, minute int, location *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, location)
+}
+
+func clip(interval Interval, from, to time.Time) (Interval, bool) {
+	if interval.Start.Before(from) {
+		interval.Start = from
+	}
+	if interval.End.After(to) {
+		interval.End = to
+	}
+	return interval, interval.Start.Before(interval.End)
+}
+
+// merge 合併重疊或相鄰的時段
+func merge(intervals []Interval) []Interval {
+	if len(intervals) == 0 {
+		return intervals
+	}
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	merged := []Interval{intervals[0]}
+	for _, interval := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if interval.Start.After(last.End) {
+			merged = append(merged, interval)
+			continue
+		}
+		if interval.End.After(last.End) {
+			last.End = interval.End
+		}
+	}
+	return merged
+}
+
+// subtract 從排序好的時段中扣除 busy
+func subtract(intervals []Interval, busy Interval) []Interval {
+	result := make([]Interval, 0, len(intervals))
+	for _, interval := range intervals {
+		if !busy.Start.Before(interval.End) || !busy.End.After(interval.Start) {
+			result = append(result, interval)
+			continue
+		}
+		if interval.Start.Before(busy.Start) {
+			result = append(result, Interval{Start: interval.Start, End: busy.Start})
+		}
+		if busy.End.Before(interval.End) {
+			result = append(result, Interval{Start: busy.End, End: interval.End})
+		}
+	}
+	return result
+}
+
+// parseClock 將 "HH:MM" 轉為當天的第幾分鐘
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,19 @@
+package availability
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Repository 定義用戶可用時間的儲存介面，每個用戶最多一筆
+type Repository interface {
+	// Save 新增或取代用戶的可用時間
+	Save(ctx context.Context, schedule *Schedule) error
+
+	// FindByUser 取得用戶的可用時間，尚未設定時回傳 shared.ErrAvailabilityNotFound
+	FindByUser(ctx context.Context, userID shared.UserID) (*Schedule, error)
+
+	// FindByUsers 取得多個用戶的可用時間，尚未設定的用戶不會出現在結果中
+	FindByUsers(ctx context.Context, userIDs []shared.UserID) ([]*Schedule, error)
+}
@@ -0,0 +1,52 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Service 處理用戶可用時間的查詢與更新
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetSchedule 取得用戶的可用時間，尚未設定時回傳空的可用時間
+func (s *Service) GetSchedule(ctx context.Context, userID shared.UserID) (*Schedule, error) {
+	schedule, err := s.repo.FindByUser(ctx, userID)
+	if errors.Is(err, shared.ErrAvailabilityNotFound) {
+		return EmptySchedule(userID), nil
+	}
+	return schedule, err
+}
+
+// UpdateSchedule 取代用戶的可用時間；已結束的行程不再保留
+func (s *Service) UpdateSchedule(ctx context.Context, userID shared.UserID, timeZone string, windows []WeeklyWindow, busyBlocks []BusyBlock) (*Schedule, error) {
+	now := time.Now()
+	upcoming := make([]BusyBlock, 0, len(busyBlocks))
+	for _, block := range busyBlocks {
+		if block.End.After(now) {
+			upcoming = append(upcoming, block)
+		}
+	}
+	if windows == nil {
+		windows = []WeeklyWindow{}
+	}
+
+	schedule, err := NewSchedule(userID, timeZone, windows, upcoming)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,160 @@
+package availability
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	DefaultSuggestionLimit = 3
+	MaxSuggestionLimit     = 10
+	DefaultMealDuration    = 90 * time.Minute
+	MaxSuggestionRange     = 31 * 24 * time.Hour
+
+	// suggestionStep 候選時段開始時間的間隔
+	suggestionStep = 15 * time.Minute
+)
+
+// MealWindow 餐別可安排的當地時間範圍，以當天的第幾分鐘表示
+type MealWindow struct {
+	Start int
+	End   int
+}
+
+var mealWindows = map[ping.PingType]MealWindow{
+	ping.PingTypeBreakfast: {Start: 7 * 60, End: 10*60 + 30},
+	ping.PingTypeLunch:     {Start: 11 * 60, End: 14*60 + 30},
+	ping.PingTypeSnack:     {Start: 14*60 + 30, End: 17 * 60},
+	ping.PingTypeDinner:    {Start: 17 * 60, End: 21*60 + 30},
+}
+
+// MealWindowFor 回傳餐別的時間範圍
+func MealWindowFor(mealType ping.PingType) (MealWindow, bool) {
+	window, ok := mealWindows[mealType]
+	return window, ok
+}
+
+// SuggestionRequest 描述要為聚餐找的時段
+type SuggestionRequest struct {
+	MealType ping.PingType
+	From     time.Time
+	To       time.Time
+	Duration time.Duration
+	TimeZone string // 餐別時間範圍以此時區解讀
+	Limit    int
+}
+
+// Suggestion 是一個候選時段與參與者是否有空
+type Suggestion struct {
+	Start       time.Time       `json:"start"`
+	End         time.Time       `json:"end"`
+	Available   []shared.UserID `json:"available"`
+	Unavailable []shared.UserID `json:"unavailable"`
+}
+
+// Validate 檢查建議條件
+func (r SuggestionRequest) Validate() error {
+	if _, ok := mealWindows[r.MealType]; !ok {
+		return shared.ErrInvalidInput.WithMessage("meal type must be breakfast, lunch, snack or dinner")
+	}
+	if !r.From.Before(r.To) {
+		return shared.ErrInvalidInput.WithMessage("suggestion range start must be before its end")
+	}
+	if r.To.Sub(r.From) > MaxSuggestionRange {
+		return shared.ErrInvalidInput.WithMessage("suggestion range cannot exceed 31 days")
+	}
+	if r.Duration <= 0 {
+		return shared.ErrInvalidInput.WithMessage("duration must be positive")
+	}
+	if r.Limit < 1 || r.Limit > MaxSuggestionLimit {
+		return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("limit must be between 1 and %d", MaxSuggestionLimit))
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return shared.ErrInvalidInput.WithMessage("time zone is invalid")
+	}
+	return nil
+}
+
+// Suggest 在餐別時間範圍內找出最多人有空的時段
+// 排序依序為有空人數多、開始時間早；回傳的時段互不重疊。沒有任何人有空的時段不會列出
+func Suggest(schedules []*Schedule, req SuggestionRequest) ([]Suggestion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if len(schedules) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	location, _ := time.LoadLocation(req.TimeZone)
+	meal := mealWindows[req.MealType]
+
+	free := make([][]Interval, len(schedules))
+	for i, schedule := range schedules {
+		free[i] = schedule.FreeIntervals(req.From, req.To)
+	}
+
+	var candidates []Suggestion
+	from := req.From.In(location)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, location); day.Before(req.To); day = day.AddDate(0, 0, 1) {
+		mealEnd := clockOn(day, meal.End, location)
+		for start := clockOn(day, meal.Start, location); !start.Add(req.Duration).After(mealEnd); start = start.Add(suggestionStep) {
+			slot := Interval{Start: start, End: start.Add(req.Duration)}
+			if slot.Start.Before(req.From) || slot.End.After(req.To) {
+				continue
+			}
+
+			candidate := Suggestion{Start: slot.Start, End: slot.End, Available: []shared.UserID{}, Unavailable: []shared.UserID{}}
+			for i, schedule := range schedules {
+				if containsInterval(free[i], slot) {
+					candidate.Available = append(candidate.Available, schedule.UserID)
+				} else {
+					candidate.Unavailable = append(candidate.Unavailable, schedule.UserID)
+				}
+			}
+			if len(candidate.Available) > 0 {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if len(candidates[i].Available) != len(candidates[j].Available) {
+			return len(candidates[i].Available) > len(candidates[j].Available)
+		}
+		return candidates[i].Start.Before(candidates[j].Start)
+	})
+
+	suggestions := make([]Suggestion, 0, req.Limit)
+	for _, candidate := range candidates {
+		if len(suggestions) == req.Limit {
+			break
+		}
+		if overlapsAny(suggestions, candidate) {
+			continue
+		}
+		suggestions = append(suggestions, candidate)
+	}
+	return suggestions, nil
+}
+
+func containsInterval(intervals []Interval, slot Interval) bool {
+	for _, interval := range intervals {
+		if interval.Contains(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+func overlapsAny(suggestions []Suggestion, candidate Suggestion) bool {
+	for _, s := range suggestions {
+		if candidate.Start.Before(s.End) && s.Start.Before(candidate.End) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package availability
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) error = %v", name, err)
+	}
+	return location
+}
+
+func mustSchedule(t *testing.T, timeZone string, windows []WeeklyWindow, busy []BusyBlock) *Schedule {
+	t.Helper()
+	schedule, err := NewSchedule(shared.NewUserID(), timeZone, windows, busy)
+	if err != nil {
+		t.Fatalf("NewSchedule() error = %v", err)
+	}
+	return schedule
+}
+
+func TestSuggestIntersectsAvailabilityAcrossTimeZones(t *testing.T) {
+	taipei := mustLocation(t, "Asia/Taipei")
+	monday := time.Date(2030, time.January, 7, 0, 0, 0, 0, taipei)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2030, time.January, 7, hour, minute, 0, 0, taipei)
+	}
+
+	alice := mustSchedule(t, "Asia/Taipei", []WeeklyWindow{{Day: time.Monday, Start: "11:30", End: "13:30"}}, nil)
+	// 東京比台北快一小時，相當於台北 11:00 - 14:00
+	bob := mustSchedule(t, "Asia/Tokyo", []WeeklyWindow{{Day: time.Monday, Start: "12:00", End: "15:00"}}, nil)
+	carol := mustSchedule(t, "Asia/Taipei",
+		[]WeeklyWindow{{Day: time.Monday, Start: "11:00", End: "14:30"}},
+		[]BusyBlock{{Start: at(12, 30), End: at(13, 0), Title: "1:1"}},
+	)
+
+	suggestions, err := Suggest([]*Schedule{alice, bob, carol}, SuggestionRequest{
+		MealType: ping.PingTypeLunch,
+		From:     monday,
+		To:       monday.AddDate(0, 0, 1),
+		Duration: time.Hour,
+		TimeZone: "Asia/Taipei",
+		Limit:    2,
+	})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Suggest() returned %d suggestions, want 2", len(suggestions))
+	}
+
+	// 三人都有空的只有 11:30 - 12:30，其次是 carol 有行程的 12:30 - 13:30
+	if first := suggestions[0]; !first.Start.Equal(at(11, 30)) || len(first.Available) != 3 {
+		t.Errorf("suggestions[0] = %s with %d available, want 11:30 with 3", first.Start.In(taipei), len(first.Available))
+	}
+	second := suggestions[1]
+	if !second.Start.Equal(at(12, 30)) || len(second.Available) != 2 {
+		t.Errorf("suggestions[1] = %s with %d available, want 12:30 with 2", second.Start.In(taipei), len(second.Available))
+	}
+	if len(second.Unavailable) != 1 || second.Unavailable[0] != carol.UserID {
+		t.Errorf("suggestions[1].Unavailable = %v, want [%s]", second.Unavailable, carol.UserID)
+	}
+}
+
+func TestSuggestUsesMealWindowInRequestedTimeZone(t *testing.T) {
+	// 整天有空的用戶，晚餐時段以東京時間解讀
+	allDay := mustSchedule(t, "UTC", []WeeklyWindow{
+		{Day: time.Monday, Start: "00:00", End: "23:59"},
+		{Day: time.Tuesday, Start: "00:00", End: "23:59"},
+	}, nil)
+	tokyo := mustLocation(t, "Asia/Tokyo")
+	from := time.Date(2030, time.January, 7, 0, 0, 0, 0, tokyo)
+
+	suggestions, err := Suggest([]*Schedule{allDay}, SuggestionRequest{
+		MealType: ping.PingTypeDinner,
+		From:     from,
+		To:       from.AddDate(0, 0, 1),
+		Duration: DefaultMealDuration,
+		TimeZone: "Asia/Tokyo",
+		Limit:    1,
+	})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 1 {
+		t.Fatalf("Suggest() returned %d suggestions, want 1", len(suggestions))
+	}
+	if got := suggestions[0].Start.In(tokyo); got.Hour() != 17 || got.Minute() != 0 {
+		t.Errorf("suggestions[0].Start = %s, want 17:00 Tokyo time", got)
+	}
+}
+
+func TestSuggestValidatesRequest(t *testing.T) {
+	from := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	valid := SuggestionRequest{
+		MealType: ping.PingTypeLunch,
+		From:     from,
+		To:       from.AddDate(0, 0, 7),
+		Duration: time.Hour,
+		TimeZone: "UTC",
+		Limit:    3,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *SuggestionRequest)
+	}{
+		{"unknown meal type", func(r *SuggestionRequest) { r.MealType = "brunch" }},
+		{"empty range", func(r *SuggestionRequest) { r.To = r.From }},
+		{"range too long", func(r *SuggestionRequest) { r.To = r.From.AddDate(0, 2, 0) }},
+		{"limit too large", func(r *SuggestionRequest) { r.Limit = MaxSuggestionLimit + 1 }},
+		{"invalid time zone", func(r *SuggestionRequest) { r.TimeZone = "Mars/Olympus" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := Suggest(nil, req); !errors.Is(err, shared.ErrInvalidInput) {
+				t.Errorf("Suggest() error = %v, want %v", err, shared.ErrInvalidInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Method 是 iCalendar 物件的 METHOD (RFC 5546)
+type Method string
+
+const (
+	MethodPublish Method = "PUBLISH"
+	MethodCancel  Method = "CANCEL"
+)
+
+// EventStatus 是 VEVENT 的 STATUS
+type EventStatus string
+
+const (
+	EventConfirmed EventStatus = "CONFIRMED"
+	EventCancelled EventStatus = "CANCELLED"
+)
+
+// ParticipationStatus 是 ATTENDEE 的 PARTSTAT
+type ParticipationStatus string
+
+const (
+	ParticipationAccepted    ParticipationStatus = "ACCEPTED"
+	ParticipationDeclined    ParticipationStatus = "DECLINED"
+	ParticipationNeedsAction ParticipationStatus = "NEEDS-ACTION"
+)
+
+// Attendee 是用餐的參加者；以 urn:uuid 表示而不公開 Email
+type Attendee struct {
+	ID     string
+	Name   string
+	Status ParticipationStatus
+}
+
+// Event 是一場用餐在行事曆中的樣子
+type Event struct {
+	UID string
+	// Sequence 在時間、地點變更或取消時遞增，讓行事曆以新版本取代舊版本
+	Sequence     int
+	Status       EventStatus
+	Summary      string
+	Description  string
+	Start        time.Time
+	End          time.Time
+	Location     string
+	Geo          *shared.Location
+	Organizer    Attendee
+	Attendees    []Attendee
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Calendar 是一份 .ics 檔案的內容
+type Calendar struct {
+	Name   string // X-WR-CALNAME，訂閱行事曆顯示的名稱
+	Method Method
+	// RefreshInterval 建議訂閱者重新抓取的間隔，0 代表不指定
+	RefreshInterval time.Duration
+	Events          []Event
+}
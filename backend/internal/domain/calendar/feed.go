@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Feed 是用戶的行事曆訂閱；訂閱網址帶有 token，行事曆 App 無法送出 Authorization header
+// 資料庫只保存 token 的 SHA-256，因此 token 只在建立時回傳一次
+type Feed struct {
+	UserID    shared.UserID `json:"userId"`
+	TokenHash string        `json:"-"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// FeedRepository 定義行事曆訂閱的儲存介面，每個用戶最多一個訂閱
+type FeedRepository interface {
+	// Save 新增或取代用戶的訂閱，舊的 token 隨即失效
+	Save(ctx context.Context, feed *Feed) error
+
+	// FindByTokenHash 以 token 的雜湊查找訂閱，找不到時回傳 shared.ErrCalendarFeedNotFound
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Feed, error)
+
+	// Delete 刪除用戶的訂閱，沒有訂閱時不回傳錯誤
+	Delete(ctx context.Context, userID shared.UserID) error
+}
+
+// NewFeed 為用戶產生新的訂閱與 token
+func NewFeed(userID shared.UserID) (*Feed, string, error) {
+	if userID.IsEmpty() {
+		return nil, "", shared.ErrInvalidInput
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	return &Feed{
+		UserID:    userID,
+		TokenHash: HashFeedToken(token),
+		CreatedAt: time.Now(),
+	}, token, nil
+}
+
+// HashFeedToken 回傳 token 儲存用的雜湊
+func HashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,153 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	productID = "-//Pingnom//Pingnom Calendar//EN"
+	// maxLineOctets RFC 5545 3.1：每行不超過 75 octets，超過的部分折行
+	maxLineOctets = 75
+	utcFormat     = "20060102T150405Z"
+)
+
+// Encode 將行事曆輸出為 RFC 5545 格式；now 作為每個 VEVENT 的 DTSTAMP
+func (c Calendar) Encode(now time.Time) []byte {
+	w := &icsWriter{}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + productID)
+	w.line("CALSCALE:GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD:" + string(c.Method))
+	}
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		duration := formatDuration(c.RefreshInterval)
+		w.line("REFRESH-INTERVAL;VALUE=DURATION:" + duration)
+		w.line("X-PUBLISHED-TTL:" + duration)
+	}
+
+	for _, event := range c.Events {
+		w.event(event, now)
+	}
+
+	w.line("END:VCALENDAR")
+	return []byte(w.String())
+}
+
+type icsWriter struct {
+	strings.Builder
+}
+
+func (w *icsWriter) event(e Event, now time.Time) {
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + e.UID)
+	w.line("DTSTAMP:" + formatTime(now))
+	w.line("DTSTART:" + formatTime(e.Start))
+	w.line("DTEND:" + formatTime(e.End))
+	w.line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	if e.Status != "" {
+		w.line("STATUS:" + string(e.Status))
+	}
+	w.line("SUMMARY:" + escapeText(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION:" + escapeText(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION:" + escapeText(e.Location))
+	}
+	if e.Geo != nil {
+		w.line(fmt.Sprintf("GEO:%f;%f", e.Geo.Latitude, e.Geo.Longitude))
+	}
+	if e.Organizer.ID != "" {
+		w.line("ORGANIZER" + nameParam(e.Organizer.Name) + ":" + calAddress(e.Organizer.ID))
+	}
+	for _, attendee := range e.Attendees {
+		status := attendee.Status
+		if status == "" {
+			status = ParticipationNeedsAction
+		}
+		w.line("ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=" + string(status) + nameParam(attendee.Name) + ":" + calAddress(attendee.ID))
+	}
+	if !e.Created.IsZero() {
+		w.line("CREATED:" + formatTime(e.Created))
+	}
+	if !e.LastModified.IsZero() {
+		w.line("LAST-MODIFIED:" + formatTime(e.LastModified))
+	}
+	w.line("END:VEVENT")
+}
+
+// line 寫入一行內容，超過 75 octets 時以 CRLF 加一個空白折行，不切斷 UTF-8 字元
+func (w *icsWriter) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// 續行開頭的空白也算在 75 octets 內
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
+
+// escapeText 依 RFC 5545 3.3.11 跳脫 TEXT 值
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// nameParam 產生 CN 參數；參數值不能包含雙引號，因此直接移除
+func nameParam(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, `"`, ""))
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
+
+func calAddress(id string) string {
+	return "urn:uuid:" + id
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(utcFormat)
+}
+
+// formatDuration 將間隔轉為 RFC 5545 的 DURATION，例如 PT1H30M
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+	if hours := minutes / 60; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes%60 > 0 {
+		fmt.Fprintf(&b, "%dM", minutes%60)
+	}
+	return b.String()
+}
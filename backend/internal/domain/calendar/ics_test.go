@@ -0,0 +1,99 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func TestCalendarEncode(t *testing.T) {
+	taipei := time.FixedZone("Asia/Taipei", 8*60*60)
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, taipei)
+	now := time.Date(2024, 2, 20, 9, 30, 0, 0, time.UTC)
+
+	cal := Calendar{
+		Name:            "Pingnom",
+		Method:          MethodPublish,
+		RefreshInterval: 90 * time.Minute,
+		Events: []Event{{
+			UID:         "ping-1@pingnom.app",
+			Sequence:    2,
+			Status:      EventConfirmed,
+			Summary:     "Lunch; ramen, maybe",
+			Description: "line one\nline two",
+			Start:       start,
+			End:         start.Add(90 * time.Minute),
+			Location:    "台北市信義區信義路五段7號",
+			Geo:         &shared.Location{Latitude: 25.033964, Longitude: 121.564468},
+			Organizer:   Attendee{ID: "a1", Name: `Alice "Al"`},
+			Attendees: []Attendee{
+				{ID: "b2", Name: "Bob", Status: ParticipationAccepted},
+				{ID: "c3"},
+			},
+		}},
+	}
+
+	out := string(cal.Encode(now))
+
+	if !strings.HasSuffix(out, "\r\n") || strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Fatalf("Encode() lines must end with CRLF:\n%q", out)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line %q is %d octets, want <= %d", line, len(line), maxLineOctets)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %q splits a UTF-8 character", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"METHOD:PUBLISH\r\n",
+		"X-WR-CALNAME:Pingnom\r\n",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT1H30M\r\n",
+		"UID:ping-1@pingnom.app\r\n",
+		"DTSTAMP:20240220T093000Z\r\n",
+		"DTSTART:20240301T040000Z\r\n",
+		"DTEND:20240301T053000Z\r\n",
+		"SEQUENCE:2\r\n",
+		"STATUS:CONFIRMED\r\n",
+		`SUMMARY:Lunch\; ramen\, maybe` + "\r\n",
+		`DESCRIPTION:line one\nline two` + "\r\n",
+		"LOCATION:台北市信義區信義路五段7號\r\n",
+		"GEO:25.033964;121.564468\r\n",
+		`ORGANIZER;CN="Alice Al":urn:uuid:a1` + "\r\n",
+		`ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=ACCEPTED;CN="Bob":urn:uuid:b2` + "\r\n",
+		"ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION:urn:uuid:c3\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("Encode() missing %q in:\n%s", want, unfolded)
+		}
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	w := &icsWriter{}
+	w.line("DESCRIPTION:" + strings.Repeat("好", 40))
+
+	lines := strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("line() produced %d lines, want folded output", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("lines[%d] is %d octets, want <= %d", i, len(line), maxLineOctets)
+		}
+		if i > 0 && !strings.HasPrefix(line, " ") {
+			t.Errorf("lines[%d] = %q, continuation lines must start with a space", i, line)
+		}
+	}
+	if got := strings.ReplaceAll(w.String(), "\r\n ", ""); got != "DESCRIPTION:"+strings.Repeat("好", 40)+"\r\n" {
+		t.Errorf("unfolded = %q, want original content", got)
+	}
+}
@@ -0,0 +1,56 @@
+package communication
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// EventType 即時事件的類型，客戶端依此決定如何更新畫面
+type EventType string
+
+const (
+	EventPingResponded         EventType = "ping.responded"
+	EventFriendRequestAccepted EventType = "friend_request.accepted"
+	EventPlanVoteSubmitted     EventType = "group_dining.vote_submitted"
+	EventPlanVotingClosed      EventType = "group_dining.voting_closed"
+	EventNotificationCreated   EventType = "notification.created"
+)
+
+// Event 是推送給特定用戶的即時事件
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	Recipients []shared.UserID `json:"-"`
+	Payload    interface{}     `json:"payload"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// NewEvent 建立事件，重複的收件者只保留一個
+func NewEvent(eventType EventType, recipients []shared.UserID, payload interface{}) Event {
+	seen := make(map[shared.UserID]bool, len(recipients))
+	unique := make([]shared.UserID, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.IsEmpty() || seen[recipient] {
+			continue
+		}
+		seen[recipient] = true
+		unique = append(unique, recipient)
+	}
+
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		Recipients: unique,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+}
+
+// Publisher 將即時事件推送給在線的收件者
+// 推送為盡力而為 (best effort)，不應影響觸發事件的業務操作，因此不回傳錯誤
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
@@ -0,0 +1,15 @@
+package communication
+
+import "context"
+
+// Message 是一封要寄出的 Email
+type Message struct {
+	To       string
+	Subject  string
+	TextBody string
+}
+
+// Mailer 定義寄送 Email 的介面，實作在 Infrastructure Layer (例如 SMTP 或開發用的檔案輸出)
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,20 @@
+package communication
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// PushMessage 是送到用戶手機的推播
+type PushMessage struct {
+	Title string
+	Body  string
+	Data  map[string]string
+}
+
+// PushProvider 定義手機推播的介面，實作在 Infrastructure Layer (例如 FCM/APNs 或開發用的 fake provider)
+// 由實作負責查詢用戶已註冊的裝置
+type PushProvider interface {
+	Send(ctx context.Context, userID shared.UserID, message PushMessage) error
+}
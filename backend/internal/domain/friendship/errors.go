@@ -0,0 +1,12 @@
+package friendship
+
+import "github.com/chun-wei0413/pingnom/internal/domain/shared"
+
+// 好友關係的領域錯誤，其餘共用錯誤 (例如 shared.ErrFriendshipExists) 定義在 shared
+var (
+	ErrFriendRequestPending    = shared.NewDomainError(shared.KindConflict, "FRIEND_REQUEST_PENDING", "friend request is pending")
+	ErrFriendshipBlocked       = shared.NewDomainError(shared.KindForbidden, "FRIENDSHIP_BLOCKED", "friendship is blocked")
+	ErrNotAddressee            = shared.NewDomainError(shared.KindForbidden, "FRIEND_REQUEST_ADDRESSEE_REQUIRED", "only the addressee can respond to the friend request")
+	ErrNotFriends              = shared.NewDomainError(shared.KindConflict, "NOT_FRIENDS", "users are not friends")
+	ErrInvalidFriendshipStatus = shared.NewDomainError(shared.KindConflict, "FRIENDSHIP_INVALID_STATUS", "operation is not allowed in the current friendship status")
+)
@@ -0,0 +1,68 @@
+package friendship
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Friendship 聚合的領域事件名稱
+const (
+	EventFriendRequestSent     = "friend_request.sent"
+	EventFriendRequestAccepted = "friend_request.accepted"
+	EventFriendRequestDeclined = "friend_request.declined"
+	EventUserBlocked           = "friendship.blocked"
+	EventUserUnblocked         = "friendship.unblocked"
+)
+
+// FriendRequestSent 用戶送出了好友邀請
+type FriendRequestSent struct {
+	FriendshipID shared.FriendshipID `json:"friendshipId"`
+	RequesterID  shared.UserID       `json:"requesterId"`
+	AddresseeID  shared.UserID       `json:"addresseeId"`
+	Message      string              `json:"message,omitempty"`
+}
+
+func (e FriendRequestSent) EventName() string   { return EventFriendRequestSent }
+func (e FriendRequestSent) AggregateID() string { return e.FriendshipID.String() }
+
+// FriendRequestAccepted 被邀請者接受了好友邀請
+type FriendRequestAccepted struct {
+	FriendshipID shared.FriendshipID `json:"friendshipId"`
+	RequesterID  shared.UserID       `json:"requesterId"`
+	AddresseeID  shared.UserID       `json:"addresseeId"`
+	AcceptedAt   time.Time           `json:"acceptedAt"`
+}
+
+func (e FriendRequestAccepted) EventName() string   { return EventFriendRequestAccepted }
+func (e FriendRequestAccepted) AggregateID() string { return e.FriendshipID.String() }
+
+// FriendRequestDeclined 被邀請者拒絕了好友邀請
+type FriendRequestDeclined struct {
+	FriendshipID shared.FriendshipID `json:"friendshipId"`
+	RequesterID  shared.UserID       `json:"requesterId"`
+	AddresseeID  shared.UserID       `json:"addresseeId"`
+}
+
+func (e FriendRequestDeclined) EventName() string   { return EventFriendRequestDeclined }
+func (e FriendRequestDeclined) AggregateID() string { return e.FriendshipID.String() }
+
+// UserBlocked 用戶封鎖了另一位用戶
+type UserBlocked struct {
+	FriendshipID shared.FriendshipID `json:"friendshipId"`
+	BlockerID    shared.UserID       `json:"blockerId"`
+	BlockedID    shared.UserID       `json:"blockedId"`
+}
+
+func (e UserBlocked) EventName() string   { return EventUserBlocked }
+func (e UserBlocked) AggregateID() string { return e.FriendshipID.String() }
+
+// UserUnblocked 用戶解除了封鎖
+type UserUnblocked struct {
+	FriendshipID shared.FriendshipID `json:"friendshipId"`
+	UnblockerID  shared.UserID       `json:"unblockerId"`
+	UnblockedID  shared.UserID       `json:"unblockedId"`
+}
+
+func (e UserUnblocked) EventName() string   { return EventUserUnblocked }
+func (e UserUnblocked) AggregateID() string { return e.FriendshipID.String() }
@@ -2,7 +2,6 @@ package friendship
 
 import (
 	"time"
-	"errors"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -34,6 +33,8 @@ func (s FriendshipStatus) String() string {
 
 // Friendship 代表兩個用戶之間的好友關係
 type Friendship struct {
+	shared.EventRecorder
+
 	ID           shared.FriendshipID `json:"id"`
 	RequesterID  shared.UserID       `json:"requesterId"`  // 發起好友邀請的用戶
 	AddresseeID  shared.UserID       `json:"addresseeId"`  // 被邀請的用戶
@@ -53,11 +54,11 @@ func NewFriendshipRequest(requesterID, addresseeID shared.UserID, message string
 
 	// 驗證邀請訊息長度
 	if len(message) > 200 {
-		return nil, errors.New("friendship request message too long")
+		return nil, shared.ErrInvalidInput.WithMessage("friendship request message too long")
 	}
 
 	now := time.Now()
-	return &Friendship{
+	f := &Friendship{
 		ID:          shared.NewFriendshipID(),
 		RequesterID: requesterID,
 		AddresseeID: addresseeID,
@@ -65,50 +66,99 @@ func NewFriendshipRequest(requesterID, addresseeID shared.UserID, message string
 		Message:     message,
 		CreatedAt:   now,
 		UpdatedAt:   now,
-	}, nil
+	}
+	f.Record(FriendRequestSent{
+		FriendshipID: f.ID,
+		RequesterID:  requesterID,
+		AddresseeID:  addresseeID,
+		Message:      message,
+	})
+	return f, nil
+}
+
+// NewBlock 在兩人尚無關係時直接建立封鎖關係
+func NewBlock(blockerID, blockedID shared.UserID) (*Friendship, error) {
+	if blockerID == blockedID {
+		return nil, shared.ErrSelfFriendRequest
+	}
+
+	now := time.Now()
+	f := &Friendship{
+		ID:          shared.NewFriendshipID(),
+		RequesterID: blockerID,
+		AddresseeID: blockedID,
+		Status:      StatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := f.Block(blockerID); err != nil {
+		return nil, err
+	}
+	return f, nil
 }
 
 // Accept 接受好友邀請
 func (f *Friendship) Accept() error {
 	if f.Status != StatusPending {
-		return errors.New("can only accept pending friend requests")
+		return ErrInvalidFriendshipStatus.WithMessage("can only accept pending friend requests")
 	}
 
 	now := time.Now()
 	f.Status = StatusAccepted
 	f.AcceptedAt = &now
 	f.UpdatedAt = now
+	f.Record(FriendRequestAccepted{
+		FriendshipID: f.ID,
+		RequesterID:  f.RequesterID,
+		AddresseeID:  f.AddresseeID,
+		AcceptedAt:   now,
+	})
 	return nil
 }
 
 // Decline 拒絕好友邀請
 func (f *Friendship) Decline() error {
 	if f.Status != StatusPending {
-		return errors.New("can only decline pending friend requests")
+		return ErrInvalidFriendshipStatus.WithMessage("can only decline pending friend requests")
 	}
 
 	f.Status = StatusDeclined
 	f.UpdatedAt = time.Now()
+	f.Record(FriendRequestDeclined{
+		FriendshipID: f.ID,
+		RequesterID:  f.RequesterID,
+		AddresseeID:  f.AddresseeID,
+	})
 	return nil
 }
 
-// Block 封鎖用戶
-func (f *Friendship) Block() error {
+// Block 由 blockerID 封鎖關係中的另一位用戶
+func (f *Friendship) Block(blockerID shared.UserID) error {
 	f.Status = StatusBlocked
 	f.UpdatedAt = time.Now()
+	f.Record(UserBlocked{
+		FriendshipID: f.ID,
+		BlockerID:    blockerID,
+		BlockedID:    f.GetOtherUserID(blockerID),
+	})
 	return nil
 }
 
-// Unblock 解除封鎖
-func (f *Friendship) Unblock() error {
+// Unblock 由 unblockerID 解除封鎖
+func (f *Friendship) Unblock(unblockerID shared.UserID) error {
 	if f.Status != StatusBlocked {
-		return errors.New("friendship is not blocked")
+		return ErrInvalidFriendshipStatus.WithMessage("friendship is not blocked")
 	}
 
 	// 解除封鎖後回到已接受狀態（如果之前是朋友）
 	// 或者刪除關係（讓用戶可以重新邀請）
 	f.Status = StatusDeclined // 設為已拒絕，允許重新邀請
 	f.UpdatedAt = time.Now()
+	f.Record(UserUnblocked{
+		FriendshipID: f.ID,
+		UnblockerID:  unblockerID,
+		UnblockedID:  f.GetOtherUserID(unblockerID),
+	})
 	return nil
 }
 
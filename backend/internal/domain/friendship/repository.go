@@ -29,6 +29,9 @@ type FriendshipRepository interface {
 	// FindSentRequestsByUserID 獲取用戶發送的好友邀請（作為邀請者）
 	FindSentRequestsByUserID(ctx context.Context, userID shared.UserID, limit, offset int) ([]*Friendship, error)
 
+	// FindBlockedByUserID 獲取用戶所有的封鎖關係（不論是封鎖者或被封鎖者）
+	FindBlockedByUserID(ctx context.Context, userID shared.UserID) ([]*Friendship, error)
+
 	// CountFriendsByUserID 計算用戶的朋友數量
 	CountFriendsByUserID(ctx context.Context, userID shared.UserID) (int, error)
 
@@ -2,7 +2,6 @@ package friendship
 
 import (
 	"context"
-	"errors"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -33,11 +32,11 @@ func (s *FriendshipService) SendFriendRequest(ctx context.Context, requesterID,
 			return nil, shared.ErrFriendshipExists
 		case StatusPending:
 			if existingFriendship.IsRequester(requesterID) {
-				return nil, errors.New("friend request already sent")
+				return nil, ErrFriendRequestPending.WithMessage("friend request already sent")
 			}
-			return nil, errors.New("friend request already received from this user")
+			return nil, ErrFriendRequestPending.WithMessage("friend request already received from this user")
 		case StatusBlocked:
-			return nil, errors.New("cannot send friend request to blocked user")
+			return nil, ErrFriendshipBlocked.WithMessage("cannot send friend request to blocked user")
 		case StatusDeclined:
 			// 允許重新發送邀請，但先刪除舊記錄
 			if err := s.friendshipRepo.Delete(ctx, existingFriendship.ID); err != nil {
@@ -68,7 +67,7 @@ func (s *FriendshipService) AcceptFriendRequest(ctx context.Context, addresseeID
 
 	// 驗證只有被邀請者可以接受邀請
 	if friendship.AddresseeID != addresseeID {
-		return errors.New("only the addressee can accept the friend request")
+		return ErrNotAddressee.WithMessage("only the addressee can accept the friend request")
 	}
 
 	if err := friendship.Accept(); err != nil {
@@ -87,7 +86,7 @@ func (s *FriendshipService) DeclineFriendRequest(ctx context.Context, addresseeI
 
 	// 驗證只有被邀請者可以拒絕邀請
 	if friendship.AddresseeID != addresseeID {
-		return errors.New("only the addressee can decline the friend request")
+		return ErrNotAddressee.WithMessage("only the addressee can decline the friend request")
 	}
 
 	if err := friendship.Decline(); err != nil {
@@ -107,16 +106,15 @@ func (s *FriendshipService) BlockUser(ctx context.Context, blockerID, blockedID
 
 	if friendship == nil {
 		// 如果沒有現有關係，建立一個新的封鎖關係
-		friendship, err = NewFriendshipRequest(blockerID, blockedID, "")
+		friendship, err = NewBlock(blockerID, blockedID)
 		if err != nil {
 			return err
 		}
-		friendship.Status = StatusBlocked
 		return s.friendshipRepo.Save(ctx, friendship)
 	}
 
 	// 更新現有關係為封鎖狀態
-	if err := friendship.Block(); err != nil {
+	if err := friendship.Block(blockerID); err != nil {
 		return err
 	}
 
@@ -130,7 +128,7 @@ func (s *FriendshipService) UnblockUser(ctx context.Context, unblockerID, unbloc
 		return err
 	}
 
-	if err := friendship.Unblock(); err != nil {
+	if err := friendship.Unblock(unblockerID); err != nil {
 		return err
 	}
 
@@ -145,7 +143,7 @@ func (s *FriendshipService) RemoveFriend(ctx context.Context, userID, friendID s
 	}
 
 	if !friendship.IsActive() {
-		return errors.New("users are not friends")
+		return ErrNotFriends
 	}
 
 	return s.friendshipRepo.Delete(ctx, friendship.ID)
@@ -0,0 +1,27 @@
+package aggregates
+
+import "github.com/chun-wei0413/pingnom/internal/domain/shared"
+
+// 多人聚餐計畫的領域錯誤，輸入驗證錯誤使用 shared.ErrInvalidInput
+var (
+	ErrPlanNotFound          = shared.NewDomainError(shared.KindNotFound, "GROUP_DINING_PLAN_NOT_FOUND", "group dining plan not found")
+	ErrPlanFinalized         = shared.NewDomainError(shared.KindConflict, "GROUP_DINING_PLAN_FINALIZED", "plan is already finalized")
+	ErrInvalidPlanStatus     = shared.NewDomainError(shared.KindConflict, "GROUP_DINING_PLAN_INVALID_STATUS", "operation is not allowed in the current plan status")
+	ErrPlanNotReadyForVoting = shared.NewDomainError(shared.KindUnprocessable, "GROUP_DINING_PLAN_NOT_READY_FOR_VOTING", "plan is not ready for voting")
+	ErrVotingNotActive       = shared.NewDomainError(shared.KindConflict, "VOTING_NOT_ACTIVE", "voting is not active for this plan")
+	ErrVotingDeadlinePassed  = shared.NewDomainError(shared.KindConflict, "VOTING_DEADLINE_PASSED", "voting deadline has passed")
+	ErrVotingStarted         = shared.NewDomainError(shared.KindConflict, "VOTING_ALREADY_STARTED", "voting has already started")
+	ErrAlreadyParticipant    = shared.NewDomainError(shared.KindConflict, "ALREADY_PLAN_PARTICIPANT", "user is already a participant")
+	ErrAutoFinalizeDisabled  = shared.NewDomainError(shared.KindConflict, "AUTO_FINALIZE_DISABLED", "auto finalize is not enabled for this plan")
+	ErrVoteNotFound          = shared.NewDomainError(shared.KindNotFound, "VOTE_NOT_FOUND", "vote not found")
+	ErrNotPlanCreator        = shared.NewDomainError(shared.KindForbidden, "PLAN_CREATOR_REQUIRED", "not the plan creator")
+	ErrNotPlanParticipant    = shared.NewDomainError(shared.KindForbidden, "PLAN_PARTICIPANT_REQUIRED", "not a plan participant")
+	ErrAlreadyInvited        = shared.NewDomainError(shared.KindConflict, "PLAN_INVITATION_PENDING", "user already has a pending invitation")
+	ErrInvitationNotFound    = shared.NewDomainError(shared.KindNotFound, "PLAN_INVITATION_NOT_FOUND", "no pending invitation for this plan")
+	ErrInvitationRequired    = shared.NewDomainError(shared.KindForbidden, "PLAN_INVITATION_REQUIRED", "an invitation or invite link is required to join this plan")
+	ErrInvalidInviteLink     = shared.NewDomainError(shared.KindInvalidInput, "INVALID_INVITE_LINK", "invalid invite link")
+	ErrInviteLinkExpired     = shared.NewDomainError(shared.KindGone, "INVITE_LINK_EXPIRED", "invite link has expired")
+	ErrInviteLinkUsed        = shared.NewDomainError(shared.KindGone, "INVITE_LINK_USED", "invite link has already been used")
+	ErrPlanFull              = shared.NewDomainError(shared.KindConflict, "GROUP_DINING_PLAN_FULL", "plan has reached its participant limit")
+	ErrPlanModified          = shared.NewDomainError(shared.KindConflict, "GROUP_DINING_PLAN_MODIFIED", "plan was modified by another request, please retry")
+)
@@ -0,0 +1,116 @@
+package aggregates
+
+import (
+	"time"
+)
+
+// GroupDiningPlan 聚合的領域事件名稱
+const (
+	EventPlanCreated        = "group_dining.plan_created"
+	EventParticipantJoined  = "group_dining.participant_joined"
+	EventParticipantInvited = "group_dining.participant_invited"
+	EventInvitationDeclined = "group_dining.invitation_declined"
+	EventPlanVotingStarted  = "group_dining.voting_started"
+	EventPlanVoteSubmitted  = "group_dining.vote_submitted"
+	EventPlanVotingClosed   = "group_dining.voting_closed"
+	EventPlanConfirmed      = "group_dining.plan_confirmed"
+	EventPlanCancelled      = "group_dining.plan_cancelled"
+)
+
+// PlanCreated 建立了新的聚餐計畫
+type PlanCreated struct {
+	PlanID    string `json:"plan_id"`
+	CreatedBy string `json:"created_by"`
+	Title     string `json:"title"`
+}
+
+func (e PlanCreated) EventName() string   { return EventPlanCreated }
+func (e PlanCreated) AggregateID() string { return e.PlanID }
+
+// ParticipantJoined 有新的參與者加入計畫
+type ParticipantJoined struct {
+	PlanID      string `json:"plan_id"`
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name"`
+}
+
+func (e ParticipantJoined) EventName() string   { return EventParticipantJoined }
+func (e ParticipantJoined) AggregateID() string { return e.PlanID }
+
+// ParticipantInvited 建立者邀請了用戶加入計畫
+type ParticipantInvited struct {
+	PlanID    string `json:"plan_id"`
+	UserID    string `json:"user_id"`
+	InvitedBy string `json:"invited_by"`
+	Title     string `json:"title"`
+}
+
+func (e ParticipantInvited) EventName() string   { return EventParticipantInvited }
+func (e ParticipantInvited) AggregateID() string { return e.PlanID }
+
+// InvitationDeclined 受邀者婉拒了邀請
+type InvitationDeclined struct {
+	PlanID    string `json:"plan_id"`
+	UserID    string `json:"user_id"`
+	CreatedBy string `json:"created_by"`
+}
+
+func (e InvitationDeclined) EventName() string   { return EventInvitationDeclined }
+func (e InvitationDeclined) AggregateID() string { return e.PlanID }
+
+// PlanVotingStarted 計畫開始投票
+type PlanVotingStarted struct {
+	PlanID         string     `json:"plan_id"`
+	CreatedBy      string     `json:"created_by"`
+	Participants   []string   `json:"participants"`
+	VotingDeadline *time.Time `json:"voting_deadline,omitempty"`
+}
+
+func (e PlanVotingStarted) EventName() string   { return EventPlanVotingStarted }
+func (e PlanVotingStarted) AggregateID() string { return e.PlanID }
+
+// PlanVoteSubmitted 參與者送出 (或更新) 了投票
+type PlanVoteSubmitted struct {
+	PlanID       string   `json:"plan_id"`
+	VoterID      string   `json:"voter_id"`
+	CreatedBy    string   `json:"created_by"`
+	Participants []string `json:"participants"`
+}
+
+func (e PlanVoteSubmitted) EventName() string   { return EventPlanVoteSubmitted }
+func (e PlanVoteSubmitted) AggregateID() string { return e.PlanID }
+
+// PlanVotingClosed 投票已截止
+type PlanVotingClosed struct {
+	PlanID       string   `json:"plan_id"`
+	CreatedBy    string   `json:"created_by"`
+	Participants []string `json:"participants"`
+}
+
+func (e PlanVotingClosed) EventName() string   { return EventPlanVotingClosed }
+func (e PlanVotingClosed) AggregateID() string { return e.PlanID }
+
+// PlanConfirmed 計畫確定了時間與餐廳；AutoFinalized 表示由系統依票數自動確認
+type PlanConfirmed struct {
+	PlanID         string    `json:"plan_id"`
+	CreatedBy      string    `json:"created_by"`
+	Participants   []string  `json:"participants"`
+	TimeSlotID     string    `json:"time_slot_id"`
+	StartTime      time.Time `json:"start_time"`
+	RestaurantID   string    `json:"restaurant_id"`
+	RestaurantName string    `json:"restaurant_name"`
+	AutoFinalized  bool      `json:"auto_finalized"`
+}
+
+func (e PlanConfirmed) EventName() string   { return EventPlanConfirmed }
+func (e PlanConfirmed) AggregateID() string { return e.PlanID }
+
+// PlanCancelled 計畫已取消
+type PlanCancelled struct {
+	PlanID       string   `json:"plan_id"`
+	CreatedBy    string   `json:"created_by"`
+	Participants []string `json:"participants"`
+}
+
+func (e PlanCancelled) EventName() string   { return EventPlanCancelled }
+func (e PlanCancelled) AggregateID() string { return e.PlanID }
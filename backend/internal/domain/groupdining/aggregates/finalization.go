@@ -0,0 +1,323 @@
+package aggregates
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// TieBreakRule decides between options that received the same number of votes
+type TieBreakRule string
+
+const (
+	// TieBreakEarliestSlot 平手時選最早的時段；餐廳則選最先提出的選項
+	TieBreakEarliestSlot TieBreakRule = "earliest_slot"
+	// TieBreakHighestRating 平手時選餐廳目錄中評分最高的餐廳；時段則選最早的
+	TieBreakHighestRating TieBreakRule = "highest_rating"
+	// TieBreakCreatorDecides 平手時不自動確認，由建立者手動選擇
+	TieBreakCreatorDecides TieBreakRule = "creator_decides"
+	// TieBreakRandom 以計畫的 seed 隨機選擇，相同的 seed 與票數一定得到相同結果
+	TieBreakRandom TieBreakRule = "random"
+)
+
+// IsValid checks if the tie-break rule is supported
+func (r TieBreakRule) IsValid() bool {
+	switch r {
+	case TieBreakEarliestSlot, TieBreakHighestRating, TieBreakCreatorDecides, TieBreakRandom:
+		return true
+	}
+	return false
+}
+
+// FinalizationTrigger describes what caused the plan to be finalized
+type FinalizationTrigger string
+
+const (
+	FinalizationTriggerAllVoted FinalizationTrigger = "all_voted"
+	FinalizationTriggerDeadline FinalizationTrigger = "deadline"
+	FinalizationTriggerManual   FinalizationTrigger = "manual"
+)
+
+// SelectionMethod describes how the winning option was chosen
+type SelectionMethod string
+
+const (
+	SelectionMostVotes SelectionMethod = "most_votes" // 唯一的最高票
+	SelectionTieBreak  SelectionMethod = "tie_break"  // 最高票平手，依平手規則選出
+	SelectionCreator   SelectionMethod = "creator"    // 建立者手動選擇
+	SelectionPending   SelectionMethod = "pending"    // 無法自動決定，等待建立者選擇
+)
+
+// AutoFinalizeSettings configures automatic confirmation of a plan
+type AutoFinalizeSettings struct {
+	Enabled  bool         `json:"enabled"`
+	TieBreak TieBreakRule `json:"tie_break,omitempty"`
+	Seed     int64        `json:"seed,omitempty"`
+}
+
+// OptionSelection records how one of the plan options (time slot or restaurant) was chosen
+type OptionSelection struct {
+	WinnerID string             `json:"winner_id,omitempty"`
+	TopVotes int                `json:"top_votes"`
+	Tied     []string           `json:"tied,omitempty"`    // 最高票平手的選項，依提出順序
+	Ratings  map[string]float64 `json:"ratings,omitempty"` // highest_rating 規則比較的評分
+	Method   SelectionMethod    `json:"method"`
+}
+
+// FinalizationAudit records how the confirmed time slot and restaurant were chosen
+type FinalizationAudit struct {
+	Trigger    FinalizationTrigger `json:"trigger"`
+	TieBreak   TieBreakRule        `json:"tie_break,omitempty"`
+	Seed       int64               `json:"seed,omitempty"`
+	TimeSlot   OptionSelection     `json:"time_slot"`
+	Restaurant OptionSelection     `json:"restaurant"`
+	DecidedBy  string              `json:"decided_by,omitempty"`
+	DecidedAt  time.Time           `json:"decided_at"`
+}
+
+// IsResolved checks if both a time slot and a restaurant were chosen
+func (a *FinalizationAudit) IsResolved() bool {
+	return a.TimeSlot.WinnerID != "" && a.Restaurant.WinnerID != ""
+}
+
+// ConfigureAutoFinalize enables automatic confirmation with the given tie-break rule
+func (p *GroupDiningPlan) ConfigureAutoFinalize(rule TieBreakRule, seed int64) error {
+	if p.Status != PlanStatusCreated {
+		return ErrVotingStarted.WithMessage("cannot change auto finalize settings after voting has started")
+	}
+
+	if rule == "" {
+		rule = TieBreakEarliestSlot
+	}
+	if !rule.IsValid() {
+		return shared.ErrInvalidInput.WithMessage("invalid tie-break rule")
+	}
+
+	p.AutoFinalize = AutoFinalizeSettings{
+		Enabled:  true,
+		TieBreak: rule,
+		Seed:     seed,
+	}
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// AllParticipantsVoted checks if every participant has a vote among the given stored votes
+// 以儲存的選票判斷，不依賴計畫上可能被同時投票覆蓋的 HasVoted
+func (p *GroupDiningPlan) AllParticipantsVoted(votes []*Vote) bool {
+	voted := make(map[string]bool, len(votes))
+	for _, vote := range votes {
+		if vote.PlanID == p.ID {
+			voted[vote.UserID] = true
+		}
+	}
+
+	accepted := 0
+	for _, participant := range p.Participants {
+		if !participant.IsAccepted() {
+			continue
+		}
+		if !voted[participant.UserID] {
+			return false
+		}
+		accepted++
+	}
+	return accepted > 0
+}
+
+// FinalizeAutomatically closes the voting and confirms the options with the most votes,
+// breaking ties with the plan's rule. votes are the stored votes of the plan and are
+// recounted before choosing. ratings maps restaurant option IDs to catalog ratings and
+// is only consulted by TieBreakHighestRating.
+// 無法決定時 (沒有任何票或 creator_decides 遇到平手) 計畫停在 voting_closed，回傳 false
+func (p *GroupDiningPlan) FinalizeAutomatically(trigger FinalizationTrigger, votes []*Vote, ratings map[string]float64) (bool, error) {
+	if !p.AutoFinalize.Enabled {
+		return false, ErrAutoFinalizeDisabled
+	}
+	if p.Status != PlanStatusVoting && p.Status != PlanStatusVotingClosed {
+		return false, ErrInvalidPlanStatus.WithMessage("can only finalize plans that are in voting status")
+	}
+
+	if p.Status == PlanStatusVoting {
+		if err := p.CloseVoting(); err != nil {
+			return false, err
+		}
+	}
+
+	// 計畫上的票數可能因同時投票而過時，以儲存的選票重新計票
+	p.applyTally(p.Tally(votes))
+
+	rule := p.AutoFinalize.TieBreak
+	rng := rand.New(rand.NewSource(p.AutoFinalize.Seed))
+	audit := &FinalizationAudit{
+		Trigger:    trigger,
+		TieBreak:   rule,
+		Seed:       p.AutoFinalize.Seed,
+		TimeSlot:   p.selectTimeSlot(rule, rng),
+		Restaurant: p.selectRestaurant(rule, ratings, rng),
+		DecidedAt:  time.Now(),
+	}
+	if rule != TieBreakRandom {
+		audit.Seed = 0
+	}
+
+	if !audit.IsResolved() {
+		p.Finalization = audit
+		p.UpdatedAt = time.Now()
+		return false, nil
+	}
+
+	if err := p.confirm(audit.TimeSlot.WinnerID, audit.Restaurant.WinnerID, true); err != nil {
+		return false, err
+	}
+	p.Finalization = audit
+
+	return true, nil
+}
+
+// selectTimeSlot picks the time slot with the most votes; earliest_slot and highest_rating both prefer the earliest slot on ties
+func (p *GroupDiningPlan) selectTimeSlot(rule TieBreakRule, rng *rand.Rand) OptionSelection {
+	votes := make([]int, len(p.TimeSlots))
+	for i, slot := range p.TimeSlots {
+		votes[i] = slot.VoteCount
+	}
+	selection, tied := topVoted(votes)
+	selection.Tied = p.timeSlotIDs(tied)
+
+	switch {
+	case selection.Method != SelectionTieBreak:
+		if selection.Method == SelectionMostVotes {
+			selection.WinnerID = p.TimeSlots[tied[0]].ID
+		}
+	case rule == TieBreakEarliestSlot || rule == TieBreakHighestRating:
+		earliest := tied[0]
+		for _, i := range tied[1:] {
+			if p.TimeSlots[i].StartTime.Before(p.TimeSlots[earliest].StartTime) {
+				earliest = i
+			}
+		}
+		selection.WinnerID = p.TimeSlots[earliest].ID
+	case rule == TieBreakRandom:
+		selection.WinnerID = p.TimeSlots[tied[rng.Intn(len(tied))]].ID
+	default:
+		selection.Method = SelectionPending
+	}
+
+	return selection
+}
+
+// selectRestaurant picks the restaurant with the most votes; earliest_slot prefers the first proposed option on ties
+func (p *GroupDiningPlan) selectRestaurant(rule TieBreakRule, ratings map[string]float64, rng *rand.Rand) OptionSelection {
+	votes := make([]int, len(p.RestaurantOptions))
+	for i, option := range p.RestaurantOptions {
+		votes[i] = option.VoteCount
+	}
+	selection, tied := topVoted(votes)
+	selection.Tied = p.restaurantOptionIDs(tied)
+
+	switch {
+	case selection.Method != SelectionTieBreak:
+		if selection.Method == SelectionMostVotes {
+			selection.WinnerID = p.RestaurantOptions[tied[0]].ID
+		}
+	case rule == TieBreakHighestRating:
+		// 沒有評分的餐廳視為 0 分，評分相同時選最先提出的
+		best := tied[0]
+		selection.Ratings = make(map[string]float64, len(tied))
+		for _, i := range tied {
+			id := p.RestaurantOptions[i].ID
+			selection.Ratings[id] = ratings[id]
+			if ratings[id] > ratings[p.RestaurantOptions[best].ID] {
+				best = i
+			}
+		}
+		selection.WinnerID = p.RestaurantOptions[best].ID
+	case rule == TieBreakEarliestSlot:
+		selection.WinnerID = p.RestaurantOptions[tied[0]].ID
+	case rule == TieBreakRandom:
+		selection.WinnerID = p.RestaurantOptions[tied[rng.Intn(len(tied))]].ID
+	default:
+		selection.Method = SelectionPending
+	}
+
+	return selection
+}
+
+// topVoted returns the indexes of the options sharing the highest vote count in
+// proposal order. Without any votes there is nothing to decide, so the selection is pending.
+func topVoted(votes []int) (OptionSelection, []int) {
+	var selection OptionSelection
+	var tied []int
+	for i, count := range votes {
+		switch {
+		case count > selection.TopVotes:
+			selection.TopVotes = count
+			tied = []int{i}
+		case count == selection.TopVotes && count > 0:
+			tied = append(tied, i)
+		}
+	}
+
+	switch {
+	case len(tied) == 0:
+		selection.Method = SelectionPending
+	case len(tied) == 1:
+		selection.Method = SelectionMostVotes
+	default:
+		selection.Method = SelectionTieBreak
+	}
+	return selection, tied
+}
+
+// manualFinalization records the creator's own choice together with the vote standings at that time
+func (p *GroupDiningPlan) manualFinalization(timeSlotID, restaurantID string) *FinalizationAudit {
+	slotVotes := make([]int, len(p.TimeSlots))
+	for i, slot := range p.TimeSlots {
+		slotVotes[i] = slot.VoteCount
+	}
+	timeSlot, tiedSlots := topVoted(slotVotes)
+
+	restaurantVotes := make([]int, len(p.RestaurantOptions))
+	for i, option := range p.RestaurantOptions {
+		restaurantVotes[i] = option.VoteCount
+	}
+	restaurant, tiedRestaurants := topVoted(restaurantVotes)
+
+	timeSlot.Tied, timeSlot.WinnerID, timeSlot.Method = p.timeSlotIDs(tiedSlots), timeSlotID, SelectionCreator
+	restaurant.Tied, restaurant.WinnerID, restaurant.Method = p.restaurantOptionIDs(tiedRestaurants), restaurantID, SelectionCreator
+
+	return &FinalizationAudit{
+		Trigger:    FinalizationTriggerManual,
+		TimeSlot:   timeSlot,
+		Restaurant: restaurant,
+		// 目前只有建立者能手動確認計畫
+		DecidedBy: p.CreatedBy,
+		DecidedAt: time.Now(),
+	}
+}
+
+// timeSlotIDs returns the IDs of the time slots at the given indexes; a single winner is not reported as a tie
+func (p *GroupDiningPlan) timeSlotIDs(indexes []int) []string {
+	if len(indexes) < 2 {
+		return nil
+	}
+	ids := make([]string, len(indexes))
+	for i, index := range indexes {
+		ids[i] = p.TimeSlots[index].ID
+	}
+	return ids
+}
+
+// restaurantOptionIDs returns the IDs of the restaurant options at the given indexes; a single winner is not reported as a tie
+func (p *GroupDiningPlan) restaurantOptionIDs(indexes []int) []string {
+	if len(indexes) < 2 {
+		return nil
+	}
+	ids := make([]string, len(indexes))
+	for i, index := range indexes {
+		ids[i] = p.RestaurantOptions[index].ID
+	}
+	return ids
+}
@@ -0,0 +1,219 @@
+package aggregates
+
+import (
+	"testing"
+	"time"
+)
+
+// newTiedPlan 建立兩個時段與兩間餐廳各得一票的投票中計畫，第二個時段比第一個早
+func newTiedPlan(t *testing.T, rule TieBreakRule, seed int64) (*GroupDiningPlan, []*Vote) {
+	t.Helper()
+
+	plan, err := NewGroupDiningPlan("creator", "Creator", "Team dinner", "")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+	start := time.Now().Add(48 * time.Hour)
+	if err := plan.AddTimeSlot(start.Add(24*time.Hour), start.Add(26*time.Hour), "Saturday"); err != nil {
+		t.Fatalf("AddTimeSlot() error = %v", err)
+	}
+	if err := plan.AddTimeSlot(start, start.Add(2*time.Hour), "Friday"); err != nil {
+		t.Fatalf("AddTimeSlot() error = %v", err)
+	}
+	for _, name := range []string{"Din Tai Fung", "Shin Yeh"} {
+		if err := plan.AddRestaurantOption(name, "", 25.03, 121.56, "taiwanese"); err != nil {
+			t.Fatalf("AddRestaurantOption() error = %v", err)
+		}
+	}
+	if err := plan.AddParticipant("guest", "Guest"); err != nil {
+		t.Fatalf("AddParticipant() error = %v", err)
+	}
+	if err := plan.ConfigureAutoFinalize(rule, seed); err != nil {
+		t.Fatalf("ConfigureAutoFinalize() error = %v", err)
+	}
+	if err := plan.StartVoting(nil); err != nil {
+		t.Fatalf("StartVoting() error = %v", err)
+	}
+
+	votes := castVotes(t, plan,
+		testBallot{userID: "creator", timeSlots: []int{0}, restaurants: []int{0}},
+		testBallot{userID: "guest", timeSlots: []int{1}, restaurants: []int{1}},
+	)
+	return plan, votes
+}
+
+func TestFinalizeAutomaticallyTieBreakRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		rule           TieBreakRule
+		ratings        []float64 // 依餐廳提出順序
+		wantConfirmed  bool
+		wantSlot       int
+		wantRestaurant int
+	}{
+		{
+			name:           "earliest slot and first proposed restaurant",
+			rule:           TieBreakEarliestSlot,
+			wantConfirmed:  true,
+			wantSlot:       1,
+			wantRestaurant: 0,
+		},
+		{
+			name:           "highest rated restaurant",
+			rule:           TieBreakHighestRating,
+			ratings:        []float64{4.1, 4.6},
+			wantConfirmed:  true,
+			wantSlot:       1,
+			wantRestaurant: 1,
+		},
+		{
+			name:          "creator decides ties",
+			rule:          TieBreakCreatorDecides,
+			wantConfirmed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, votes := newTiedPlan(t, tt.rule, 0)
+
+			ratings := make(map[string]float64)
+			for i, rating := range tt.ratings {
+				ratings[plan.RestaurantOptions[i].ID] = rating
+			}
+
+			confirmed, err := plan.FinalizeAutomatically(FinalizationTriggerDeadline, votes, ratings)
+			if err != nil {
+				t.Fatalf("FinalizeAutomatically() error = %v", err)
+			}
+			if confirmed != tt.wantConfirmed {
+				t.Fatalf("FinalizeAutomatically() = %v, want %v", confirmed, tt.wantConfirmed)
+			}
+
+			audit := plan.Finalization
+			if audit == nil || audit.Trigger != FinalizationTriggerDeadline || audit.TieBreak != tt.rule {
+				t.Fatalf("Finalization = %+v, want deadline audit with rule %s", audit, tt.rule)
+			}
+			if len(audit.TimeSlot.Tied) != 2 || len(audit.Restaurant.Tied) != 2 {
+				t.Errorf("Finalization tied = %v / %v, want both options", audit.TimeSlot.Tied, audit.Restaurant.Tied)
+			}
+
+			if !tt.wantConfirmed {
+				if plan.Status != PlanStatusVotingClosed || audit.TimeSlot.Method != SelectionPending {
+					t.Errorf("Status = %s, TimeSlot.Method = %s, want voting_closed and pending", plan.Status, audit.TimeSlot.Method)
+				}
+				return
+			}
+
+			if plan.Status != PlanStatusConfirmed {
+				t.Fatalf("Status = %s, want confirmed", plan.Status)
+			}
+			if plan.ConfirmedTimeSlot.ID != plan.TimeSlots[tt.wantSlot].ID {
+				t.Errorf("ConfirmedTimeSlot = %s, want %s", plan.ConfirmedTimeSlot.Description, plan.TimeSlots[tt.wantSlot].Description)
+			}
+			if plan.ConfirmedRestaurant.ID != plan.RestaurantOptions[tt.wantRestaurant].ID {
+				t.Errorf("ConfirmedRestaurant = %s, want %s", plan.ConfirmedRestaurant.Name, plan.RestaurantOptions[tt.wantRestaurant].Name)
+			}
+			if audit.TimeSlot.Method != SelectionTieBreak || audit.Restaurant.Method != SelectionTieBreak {
+				t.Errorf("Finalization methods = %s / %s, want tie_break", audit.TimeSlot.Method, audit.Restaurant.Method)
+			}
+		})
+	}
+}
+
+func TestFinalizeAutomaticallyRandomIsReproducible(t *testing.T) {
+	first, firstVotes := newTiedPlan(t, TieBreakRandom, 7)
+	second, secondVotes := newTiedPlan(t, TieBreakRandom, 7)
+	for plan, votes := range map[*GroupDiningPlan][]*Vote{first: firstVotes, second: secondVotes} {
+		if confirmed, err := plan.FinalizeAutomatically(FinalizationTriggerAllVoted, votes, nil); err != nil || !confirmed {
+			t.Fatalf("FinalizeAutomatically() = %v, %v, want true", confirmed, err)
+		}
+	}
+
+	// 兩個計畫的選項 ID 不同，以提出順序比較結果
+	position := func(plan *GroupDiningPlan) (int, int) {
+		slot, restaurant := -1, -1
+		for i := range plan.TimeSlots {
+			if plan.TimeSlots[i].ID == plan.ConfirmedTimeSlot.ID {
+				slot = i
+			}
+		}
+		for i := range plan.RestaurantOptions {
+			if plan.RestaurantOptions[i].ID == plan.ConfirmedRestaurant.ID {
+				restaurant = i
+			}
+		}
+		return slot, restaurant
+	}
+	firstSlot, firstRestaurant := position(first)
+	secondSlot, secondRestaurant := position(second)
+	if firstSlot != secondSlot || firstRestaurant != secondRestaurant {
+		t.Errorf("same seed chose (%d, %d) and (%d, %d), want identical picks", firstSlot, firstRestaurant, secondSlot, secondRestaurant)
+	}
+	if first.Finalization.Seed != 7 {
+		t.Errorf("Finalization.Seed = %d, want 7", first.Finalization.Seed)
+	}
+}
+
+func TestFinalizeAutomaticallyWithoutVotesWaitsForCreator(t *testing.T) {
+	plan, err := NewGroupDiningPlan("creator", "Creator", "Team dinner", "")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+	start := time.Now().Add(48 * time.Hour)
+	if err := plan.AddTimeSlot(start, start.Add(2*time.Hour), "Friday"); err != nil {
+		t.Fatalf("AddTimeSlot() error = %v", err)
+	}
+	if err := plan.AddRestaurantOption("Din Tai Fung", "", 0, 0, ""); err != nil {
+		t.Fatalf("AddRestaurantOption() error = %v", err)
+	}
+	if err := plan.AddParticipant("guest", "Guest"); err != nil {
+		t.Fatalf("AddParticipant() error = %v", err)
+	}
+	if err := plan.ConfigureAutoFinalize("", 0); err != nil {
+		t.Fatalf("ConfigureAutoFinalize() error = %v", err)
+	}
+	if err := plan.StartVoting(nil); err != nil {
+		t.Fatalf("StartVoting() error = %v", err)
+	}
+
+	confirmed, err := plan.FinalizeAutomatically(FinalizationTriggerDeadline, nil, nil)
+	if err != nil || confirmed {
+		t.Fatalf("FinalizeAutomatically() = %v, %v, want false", confirmed, err)
+	}
+	if plan.Status != PlanStatusVotingClosed || plan.Finalization.TimeSlot.Method != SelectionPending {
+		t.Errorf("Status = %s, Finalization = %+v, want voting_closed with pending selection", plan.Status, plan.Finalization)
+	}
+
+	// 建立者手動確認後改記錄手動選擇
+	if err := plan.ConfirmPlan(plan.TimeSlots[0].ID, plan.RestaurantOptions[0].ID); err != nil {
+		t.Fatalf("ConfirmPlan() error = %v", err)
+	}
+	if audit := plan.Finalization; audit.Trigger != FinalizationTriggerManual || audit.TimeSlot.Method != SelectionCreator || audit.DecidedBy != "creator" {
+		t.Errorf("Finalization = %+v, want manual decision by creator", audit)
+	}
+}
+
+func TestFinalizeAutomaticallyRecountsStoredVotes(t *testing.T) {
+	plan, votes := newTiedPlan(t, TieBreakCreatorDecides, 0)
+
+	// 同時投票時計畫上的票數與 HasVoted 少了 guest 的票，儲存的選票才是完整的
+	plan.TimeSlots[1].VoteCount = 0
+	plan.RestaurantOptions[1].VoteCount = 0
+	plan.Participants[plan.participantIndex("guest")].HasVoted = false
+
+	if !plan.AllParticipantsVoted(votes) {
+		t.Fatal("AllParticipantsVoted() = false with a stored vote from every participant, want true")
+	}
+	if plan.AllParticipantsVoted(votes[:1]) {
+		t.Error("AllParticipantsVoted() = true without the guest's vote, want false")
+	}
+
+	confirmed, err := plan.FinalizeAutomatically(FinalizationTriggerAllVoted, votes, nil)
+	if err != nil || confirmed {
+		t.Fatalf("FinalizeAutomatically() = %v, %v, want false for the recounted tie", confirmed, err)
+	}
+	if len(plan.Finalization.TimeSlot.Tied) != 2 || len(plan.Finalization.Restaurant.Tied) != 2 {
+		t.Errorf("Finalization tied = %v / %v, want both options after recounting", plan.Finalization.TimeSlot.Tied, plan.Finalization.Restaurant.Tied)
+	}
+}
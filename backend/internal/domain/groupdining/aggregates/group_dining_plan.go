@@ -1,9 +1,9 @@
 package aggregates
 
 import (
-	"errors"
 	"time"
 
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/google/uuid"
 )
 
@@ -11,10 +11,11 @@ import (
 type PlanStatus string
 
 const (
-	PlanStatusCreated   PlanStatus = "created"
-	PlanStatusVoting    PlanStatus = "voting" 
-	PlanStatusConfirmed PlanStatus = "confirmed"
-	PlanStatusCancelled PlanStatus = "cancelled"
+	PlanStatusCreated      PlanStatus = "created"
+	PlanStatusVoting       PlanStatus = "voting" 
+	PlanStatusVotingClosed PlanStatus = "voting_closed" // 投票截止，等待建立者確認
+	PlanStatusConfirmed    PlanStatus = "confirmed"
+	PlanStatusCancelled    PlanStatus = "cancelled"
 )
 
 // TimeSlot represents a proposed time for the group dining
@@ -38,15 +39,26 @@ type RestaurantOption struct {
 }
 
 // Participant represents a participant in the group dining plan
+// 受邀但尚未接受 (pending) 或已婉拒 (declined) 的用戶也會列在 Participants 中，只有 accepted 算是參與者
 type Participant struct {
-	UserID      string    `json:"user_id"`
-	DisplayName string    `json:"display_name"`
-	JoinedAt    time.Time `json:"joined_at"`
-	HasVoted    bool      `json:"has_voted"`
+	UserID      string            `json:"user_id"`
+	DisplayName string            `json:"display_name"`
+	Status      ParticipantStatus `json:"status"`
+	InvitedBy   string            `json:"invited_by,omitempty"`
+	JoinedAt    time.Time         `json:"joined_at"`
+	RespondedAt *time.Time        `json:"responded_at,omitempty"`
+	HasVoted    bool              `json:"has_voted"`
+}
+
+// IsAccepted checks if the participant has joined the plan
+func (p Participant) IsAccepted() bool {
+	return p.Status.OrDefault() == ParticipantStatusAccepted
 }
 
 // GroupDiningPlan is the root aggregate for group dining planning
 type GroupDiningPlan struct {
+	shared.EventRecorder
+
 	ID                string              `json:"id"`
 	CreatedBy         string              `json:"created_by"`
 	Title             string              `json:"title"`
@@ -60,15 +72,21 @@ type GroupDiningPlan struct {
 	CreatedAt         time.Time           `json:"created_at"`
 	UpdatedAt         time.Time           `json:"updated_at"`
 	VotingDeadline    *time.Time         `json:"voting_deadline,omitempty"`
+	VotingMethod      VotingMethod        `json:"voting_method"`
+	AutoFinalize      AutoFinalizeSettings `json:"auto_finalize"`
+	Finalization      *FinalizationAudit  `json:"finalization,omitempty"`
+	InviteLinks       []InviteLink        `json:"invite_links"`
+	// Version 樂觀鎖版本，儲存庫每次寫入後遞增，讀取後被其他請求改過的計畫無法覆蓋寫入
+	Version           int64               `json:"-"`
 }
 
-// NewGroupDiningPlan creates a new group dining plan
-func NewGroupDiningPlan(createdBy, title, description string) (*GroupDiningPlan, error) {
+// NewGroupDiningPlan creates a new group dining plan; creatorName is the creator's profile display name
+func NewGroupDiningPlan(createdBy, creatorName, title, description string) (*GroupDiningPlan, error) {
 	if createdBy == "" {
-		return nil, errors.New("creator ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("creator ID cannot be empty")
 	}
 	if title == "" {
-		return nil, errors.New("title cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("title cannot be empty")
 	}
 
 	now := time.Now()
@@ -81,12 +99,22 @@ func NewGroupDiningPlan(createdBy, title, description string) (*GroupDiningPlan,
 		TimeSlots:         make([]TimeSlot, 0),
 		RestaurantOptions: make([]RestaurantOption, 0),
 		Participants:      make([]Participant, 0),
+		InviteLinks:       make([]InviteLink, 0),
+		VotingMethod:      VotingMethodApproval,
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
 
 	// Add creator as first participant
-	plan.AddParticipant(createdBy, "Creator")
+	plan.AddParticipant(createdBy, creatorName)
+
+	// 建立者加入不另外發出 ParticipantJoined，只記錄 PlanCreated
+	plan.ClearEvents()
+	plan.Record(PlanCreated{
+		PlanID:    plan.ID,
+		CreatedBy: createdBy,
+		Title:     title,
+	})
 
 	return plan, nil
 }
@@ -94,11 +122,11 @@ func NewGroupDiningPlan(createdBy, title, description string) (*GroupDiningPlan,
 // AddTimeSlot adds a time slot option to the plan
 func (p *GroupDiningPlan) AddTimeSlot(startTime, endTime time.Time, description string) error {
 	if p.Status != PlanStatusCreated {
-		return errors.New("cannot add time slots after plan is finalized")
+		return ErrPlanFinalized.WithMessage("cannot add time slots after plan is finalized")
 	}
 
 	if startTime.After(endTime) {
-		return errors.New("start time cannot be after end time")
+		return shared.ErrInvalidInput.WithMessage("start time cannot be after end time")
 	}
 
 	timeSlot := TimeSlot{
@@ -118,11 +146,11 @@ func (p *GroupDiningPlan) AddTimeSlot(startTime, endTime time.Time, description
 // AddRestaurantOption adds a restaurant option to the plan
 func (p *GroupDiningPlan) AddRestaurantOption(name, address string, lat, lng float64, cuisineType string) error {
 	if p.Status != PlanStatusCreated {
-		return errors.New("cannot add restaurant options after plan is finalized")
+		return ErrPlanFinalized.WithMessage("cannot add restaurant options after plan is finalized")
 	}
 
 	if name == "" {
-		return errors.New("restaurant name cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("restaurant name cannot be empty")
 	}
 
 	restaurant := RestaurantOption{
@@ -142,27 +170,39 @@ func (p *GroupDiningPlan) AddRestaurantOption(name, address string, lat, lng flo
 }
 
 // AddParticipant adds a participant to the group dining plan
+// 已受邀 (pending 或 declined) 的用戶加入時視為接受邀請
 func (p *GroupDiningPlan) AddParticipant(userID, displayName string) error {
 	if userID == "" {
-		return errors.New("user ID cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+	if !p.acceptsParticipants() {
+		return ErrInvalidPlanStatus.WithMessage("cannot join a plan that is no longer open")
 	}
 
-	// Check if user is already a participant
-	for _, participant := range p.Participants {
-		if participant.UserID == userID {
-			return errors.New("user is already a participant")
+	now := time.Now()
+	if i := p.participantIndex(userID); i >= 0 {
+		if p.Participants[i].IsAccepted() {
+			return ErrAlreadyParticipant
 		}
+		p.accept(i, displayName, now)
+		return nil
 	}
 
 	participant := Participant{
 		UserID:      userID,
 		DisplayName: displayName,
-		JoinedAt:    time.Now(),
+		Status:      ParticipantStatusAccepted,
+		JoinedAt:    now,
 		HasVoted:    false,
 	}
 
 	p.Participants = append(p.Participants, participant)
-	p.UpdatedAt = time.Now()
+	p.UpdatedAt = now
+	p.Record(ParticipantJoined{
+		PlanID:      p.ID,
+		UserID:      userID,
+		DisplayName: displayName,
+	})
 
 	return nil
 }
@@ -170,110 +210,177 @@ func (p *GroupDiningPlan) AddParticipant(userID, displayName string) error {
 // StartVoting starts the voting process for the plan
 func (p *GroupDiningPlan) StartVoting(deadline *time.Time) error {
 	if p.Status != PlanStatusCreated {
-		return errors.New("can only start voting for created plans")
+		return ErrInvalidPlanStatus.WithMessage("can only start voting for created plans")
 	}
 
 	if len(p.TimeSlots) == 0 {
-		return errors.New("cannot start voting without time slots")
+		return ErrPlanNotReadyForVoting.WithMessage("cannot start voting without time slots")
 	}
 
 	if len(p.RestaurantOptions) == 0 {
-		return errors.New("cannot start voting without restaurant options")
+		return ErrPlanNotReadyForVoting.WithMessage("cannot start voting without restaurant options")
 	}
 
-	if len(p.Participants) <= 1 {
-		return errors.New("cannot start voting with less than 2 participants")
+	if len(p.participantIDs()) <= 1 {
+		return ErrPlanNotReadyForVoting.WithMessage("cannot start voting with less than 2 participants")
 	}
 
 	p.Status = PlanStatusVoting
 	p.VotingDeadline = deadline
 	p.UpdatedAt = time.Now()
+	p.Record(PlanVotingStarted{
+		PlanID:         p.ID,
+		CreatedBy:      p.CreatedBy,
+		Participants:   p.participantIDs(),
+		VotingDeadline: deadline,
+	})
 
 	return nil
 }
 
-// RecordVote records a participant's vote
-func (p *GroupDiningPlan) RecordVote(userID string, timeSlotIDs, restaurantIDs []string) error {
+// RecordVote records a participant's vote and recounts the option vote counts.
+// previous are the votes already stored for the plan; an earlier vote of the same
+// participant is replaced, so changed votes are counted correctly.
+func (p *GroupDiningPlan) RecordVote(vote *Vote, previous []*Vote) error {
+	if vote == nil {
+		return shared.ErrInvalidInput.WithMessage("vote cannot be nil")
+	}
+
 	if p.Status != PlanStatusVoting {
-		return errors.New("voting is not active for this plan")
+		return ErrVotingNotActive
+	}
+
+	// 排程器關閉投票前仍可能收到投票，以截止時間為準
+	if p.IsVotingOverdue(time.Now()) {
+		return ErrVotingDeadlinePassed
+	}
+
+	if vote.PlanID != p.ID {
+		return shared.ErrInvalidInput.WithMessage("vote does not belong to this plan")
 	}
 
 	// Check if user is a participant
-	participantIndex := -1
-	for i, participant := range p.Participants {
-		if participant.UserID == userID {
-			participantIndex = i
-			break
-		}
+	participantIndex := p.participantIndex(vote.UserID)
+	if participantIndex == -1 || !p.Participants[participantIndex].IsAccepted() {
+		return ErrNotPlanParticipant.WithMessage("user is not a participant in this plan")
 	}
 
-	if participantIndex == -1 {
-		return errors.New("user is not a participant in this plan")
+	if err := vote.IsValid(); err != nil {
+		return err
 	}
 
-	// Update vote counts for time slots
-	for _, timeSlotID := range timeSlotIDs {
-		for i, timeSlot := range p.TimeSlots {
-			if timeSlot.ID == timeSlotID {
-				if !p.Participants[participantIndex].HasVoted {
-					p.TimeSlots[i].VoteCount++
-				}
-				break
-			}
+	for _, choice := range vote.Choices {
+		if choice.Type == VoteTypeTime && !p.hasTimeSlot(choice.OptionID) {
+			return shared.ErrInvalidInput.WithMessage("invalid time slot ID")
+		}
+		if choice.Type == VoteTypeRestaurant && !p.hasRestaurantOption(choice.OptionID) {
+			return shared.ErrInvalidInput.WithMessage("invalid restaurant ID")
 		}
 	}
 
-	// Update vote counts for restaurants
-	for _, restaurantID := range restaurantIDs {
-		for i, restaurant := range p.RestaurantOptions {
-			if restaurant.ID == restaurantID {
-				if !p.Participants[participantIndex].HasVoted {
-					p.RestaurantOptions[i].VoteCount++
-				}
-				break
-			}
+	if err := p.votingMethod().validateVote(vote); err != nil {
+		return err
+	}
+
+	votes := []*Vote{vote}
+	for _, other := range previous {
+		if other.UserID != vote.UserID {
+			votes = append(votes, other)
 		}
 	}
+	p.applyTally(p.Tally(votes))
 
 	// Mark participant as voted
 	p.Participants[participantIndex].HasVoted = true
 	p.UpdatedAt = time.Now()
+	p.Record(PlanVoteSubmitted{
+		PlanID:       p.ID,
+		VoterID:      vote.UserID,
+		CreatedBy:    p.CreatedBy,
+		Participants: p.participantIDs(),
+	})
 
 	return nil
 }
 
+// CloseVoting ends the voting when the deadline has passed; the creator can still confirm the plan afterwards
+func (p *GroupDiningPlan) CloseVoting() error {
+	if p.Status != PlanStatusVoting {
+		return ErrVotingNotActive
+	}
+
+	p.Status = PlanStatusVotingClosed
+	p.UpdatedAt = time.Now()
+	p.Record(PlanVotingClosed{
+		PlanID:       p.ID,
+		CreatedBy:    p.CreatedBy,
+		Participants: p.participantIDs(),
+	})
+
+	return nil
+}
+
+// IsVotingOverdue checks if the plan is still voting after its deadline
+func (p *GroupDiningPlan) IsVotingOverdue(now time.Time) bool {
+	return p.Status == PlanStatusVoting && p.VotingDeadline != nil && !now.Before(*p.VotingDeadline)
+}
+
 // ConfirmPlan confirms the final arrangements for the group dining
 func (p *GroupDiningPlan) ConfirmPlan(timeSlotID, restaurantID string) error {
-	if p.Status != PlanStatusVoting {
-		return errors.New("can only confirm plans that are in voting status")
+	if err := p.confirm(timeSlotID, restaurantID, false); err != nil {
+		return err
 	}
 
-	// Find and set confirmed time slot
+	p.Finalization = p.manualFinalization(timeSlotID, restaurantID)
+	return nil
+}
+
+// confirm sets the confirmed time slot and restaurant; autoFinalized marks confirmations made by FinalizeAutomatically
+func (p *GroupDiningPlan) confirm(timeSlotID, restaurantID string, autoFinalized bool) error {
+	if p.Status != PlanStatusVoting && p.Status != PlanStatusVotingClosed {
+		return ErrInvalidPlanStatus.WithMessage("can only confirm plans that are in voting status")
+	}
+
+	// Find confirmed time slot
+	var confirmedTimeSlot *TimeSlot
 	for _, timeSlot := range p.TimeSlots {
 		if timeSlot.ID == timeSlotID {
-			p.ConfirmedTimeSlot = &timeSlot
+			confirmedTimeSlot = &timeSlot
 			break
 		}
 	}
 
-	if p.ConfirmedTimeSlot == nil {
-		return errors.New("invalid time slot ID")
+	if confirmedTimeSlot == nil {
+		return shared.ErrInvalidInput.WithMessage("invalid time slot ID")
 	}
 
-	// Find and set confirmed restaurant
+	// Find confirmed restaurant
+	var confirmedRestaurant *RestaurantOption
 	for _, restaurant := range p.RestaurantOptions {
 		if restaurant.ID == restaurantID {
-			p.ConfirmedRestaurant = &restaurant
+			confirmedRestaurant = &restaurant
 			break
 		}
 	}
 
-	if p.ConfirmedRestaurant == nil {
-		return errors.New("invalid restaurant ID")
+	if confirmedRestaurant == nil {
+		return shared.ErrInvalidInput.WithMessage("invalid restaurant ID")
 	}
 
+	p.ConfirmedTimeSlot = confirmedTimeSlot
+	p.ConfirmedRestaurant = confirmedRestaurant
 	p.Status = PlanStatusConfirmed
 	p.UpdatedAt = time.Now()
+	p.Record(PlanConfirmed{
+		PlanID:         p.ID,
+		CreatedBy:      p.CreatedBy,
+		Participants:   p.participantIDs(),
+		TimeSlotID:     p.ConfirmedTimeSlot.ID,
+		StartTime:      p.ConfirmedTimeSlot.StartTime,
+		RestaurantID:   p.ConfirmedRestaurant.ID,
+		RestaurantName: p.ConfirmedRestaurant.Name,
+		AutoFinalized:  autoFinalized,
+	})
 
 	return nil
 }
@@ -281,21 +388,30 @@ func (p *GroupDiningPlan) ConfirmPlan(timeSlotID, restaurantID string) error {
 // CancelPlan cancels the group dining plan
 func (p *GroupDiningPlan) CancelPlan() error {
 	if p.Status == PlanStatusConfirmed {
-		return errors.New("cannot cancel confirmed plans")
+		return ErrPlanFinalized.WithMessage("cannot cancel confirmed plans")
 	}
 
 	p.Status = PlanStatusCancelled
 	p.UpdatedAt = time.Now()
+	p.Record(PlanCancelled{
+		PlanID:       p.ID,
+		CreatedBy:    p.CreatedBy,
+		Participants: p.participantIDs(),
+	})
 
 	return nil
 }
 
 // GetVotingResults returns the voting results summary
 func (p *GroupDiningPlan) GetVotingResults() map[string]interface{} {
-	totalParticipants := len(p.Participants)
+	totalParticipants := 0
 	votedParticipants := 0
 
 	for _, participant := range p.Participants {
+		if !participant.IsAccepted() {
+			continue
+		}
+		totalParticipants++
 		if participant.HasVoted {
 			votedParticipants++
 		}
@@ -315,12 +431,57 @@ func (p *GroupDiningPlan) IsCreator(userID string) bool {
 	return p.CreatedBy == userID
 }
 
-// IsParticipant checks if the given user is a participant in the plan
+// IsParticipant checks if the given user has joined the plan; pending and declined invitees are not participants
 func (p *GroupDiningPlan) IsParticipant(userID string) bool {
+	i := p.participantIndex(userID)
+	return i >= 0 && p.Participants[i].IsAccepted()
+}
+
+// AcceptedParticipants returns the participants who have joined the plan in join order
+func (p *GroupDiningPlan) AcceptedParticipants() []Participant {
+	accepted := make([]Participant, 0, len(p.Participants))
 	for _, participant := range p.Participants {
+		if participant.IsAccepted() {
+			accepted = append(accepted, participant)
+		}
+	}
+	return accepted
+}
+
+// participantIndex returns the index of the user's participant entry regardless of status, or -1
+func (p *GroupDiningPlan) participantIndex(userID string) int {
+	for i, participant := range p.Participants {
 		if participant.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *GroupDiningPlan) hasTimeSlot(id string) bool {
+	for _, slot := range p.TimeSlots {
+		if slot.ID == id {
 			return true
 		}
 	}
 	return false
+}
+
+func (p *GroupDiningPlan) hasRestaurantOption(id string) bool {
+	for _, option := range p.RestaurantOptions {
+		if option.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// participantIDs returns the user IDs of all accepted participants in join order
+func (p *GroupDiningPlan) participantIDs() []string {
+	accepted := p.AcceptedParticipants()
+	ids := make([]string, len(accepted))
+	for i, participant := range accepted {
+		ids[i] = participant.UserID
+	}
+	return ids
 }
\ No newline at end of file
@@ -0,0 +1,208 @@
+package aggregates
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/google/uuid"
+)
+
+// ParticipantStatus 參與者的邀請狀態
+type ParticipantStatus string
+
+const (
+	ParticipantStatusPending  ParticipantStatus = "pending"
+	ParticipantStatusAccepted ParticipantStatus = "accepted"
+	ParticipantStatusDeclined ParticipantStatus = "declined"
+)
+
+// OrDefault 加入邀請功能前的參與者沒有狀態，視為已接受
+func (s ParticipantStatus) OrDefault() ParticipantStatus {
+	if s == "" {
+		return ParticipantStatusAccepted
+	}
+	return s
+}
+
+// InviteLink 可分享的邀請連結；分享出去的 token 由 InviteLinkSigner 簽署，
+// 計畫只記錄連結的設定與使用次數
+type InviteLink struct {
+	ID        string    `json:"id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SingleUse bool      `json:"single_use"`
+	// MaxParticipants 透過此連結加入時計畫最多可有的參與者人數 (含建立者)，0 表示不限
+	MaxParticipants int `json:"max_participants"`
+	UseCount        int `json:"use_count"`
+}
+
+// IsExpired checks if the link can no longer be used at the given time
+func (l InviteLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// InviteParticipant invites a user to the plan; the invitee joins after accepting
+// 婉拒過的用戶可以再次邀請
+func (p *GroupDiningPlan) InviteParticipant(invitedBy, userID, displayName string, now time.Time) error {
+	if userID == "" {
+		return shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+	if userID == invitedBy {
+		return shared.ErrInvalidInput.WithMessage("cannot invite yourself")
+	}
+	if !p.acceptsParticipants() {
+		return ErrInvalidPlanStatus.WithMessage("cannot invite users to a plan that is no longer open")
+	}
+
+	invitation := Participant{
+		UserID:      userID,
+		DisplayName: displayName,
+		Status:      ParticipantStatusPending,
+		InvitedBy:   invitedBy,
+		JoinedAt:    now, // 尚未接受時記錄受邀時間
+	}
+
+	if i := p.participantIndex(userID); i >= 0 {
+		switch p.Participants[i].Status.OrDefault() {
+		case ParticipantStatusAccepted:
+			return ErrAlreadyParticipant
+		case ParticipantStatusPending:
+			return ErrAlreadyInvited
+		}
+		p.Participants[i] = invitation
+	} else {
+		p.Participants = append(p.Participants, invitation)
+	}
+
+	p.UpdatedAt = now
+	p.Record(ParticipantInvited{
+		PlanID:    p.ID,
+		UserID:    userID,
+		InvitedBy: invitedBy,
+		Title:     p.Title,
+	})
+
+	return nil
+}
+
+// RespondToInvitation accepts or declines the user's pending invitation
+func (p *GroupDiningPlan) RespondToInvitation(userID string, accept bool, now time.Time) error {
+	i := p.participantIndex(userID)
+	if i < 0 || p.Participants[i].Status != ParticipantStatusPending {
+		return ErrInvitationNotFound
+	}
+
+	if accept {
+		if !p.acceptsParticipants() {
+			return ErrInvalidPlanStatus.WithMessage("cannot join a plan that is no longer open")
+		}
+		p.accept(i, "", now)
+		return nil
+	}
+
+	p.Participants[i].Status = ParticipantStatusDeclined
+	p.Participants[i].RespondedAt = &now
+	p.UpdatedAt = now
+	p.Record(InvitationDeclined{
+		PlanID:    p.ID,
+		UserID:    userID,
+		CreatedBy: p.CreatedBy,
+	})
+
+	return nil
+}
+
+// HasPendingInvitation checks if the user has been invited but has not responded yet
+func (p *GroupDiningPlan) HasPendingInvitation(userID string) bool {
+	i := p.participantIndex(userID)
+	return i >= 0 && p.Participants[i].Status == ParticipantStatusPending
+}
+
+// CreateInviteLink adds a shareable invite link valid for ttl
+func (p *GroupDiningPlan) CreateInviteLink(createdBy string, ttl time.Duration, singleUse bool, maxParticipants int, now time.Time) (InviteLink, error) {
+	if !p.acceptsParticipants() {
+		return InviteLink{}, ErrInvalidPlanStatus.WithMessage("cannot create invite links for a plan that is no longer open")
+	}
+	if ttl <= 0 {
+		return InviteLink{}, shared.ErrInvalidInput.WithMessage("invite link lifetime must be positive")
+	}
+	if maxParticipants < 0 {
+		return InviteLink{}, shared.ErrInvalidInput.WithMessage("max participants cannot be negative")
+	}
+
+	link := InviteLink{
+		ID:              uuid.New().String(),
+		CreatedBy:       createdBy,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(ttl),
+		SingleUse:       singleUse,
+		MaxParticipants: maxParticipants,
+	}
+	p.InviteLinks = append(p.InviteLinks, link)
+	p.UpdatedAt = now
+
+	return link, nil
+}
+
+// JoinWithInviteLink adds the user to the plan through one of its invite links
+// 已是參與者時不會消耗連結的使用次數
+func (p *GroupDiningPlan) JoinWithInviteLink(linkID, userID, displayName string, now time.Time) error {
+	i := p.inviteLinkIndex(linkID)
+	if i < 0 {
+		return ErrInvalidInviteLink
+	}
+	link := &p.InviteLinks[i]
+
+	if link.IsExpired(now) {
+		return ErrInviteLinkExpired
+	}
+	if link.SingleUse && link.UseCount > 0 {
+		return ErrInviteLinkUsed
+	}
+	if p.IsParticipant(userID) {
+		return ErrAlreadyParticipant
+	}
+	if link.MaxParticipants > 0 && len(p.participantIDs()) >= link.MaxParticipants {
+		return ErrPlanFull
+	}
+
+	if err := p.AddParticipant(userID, displayName); err != nil {
+		return err
+	}
+	link.UseCount++
+
+	return nil
+}
+
+// accept marks the participant entry at index i as joined
+func (p *GroupDiningPlan) accept(i int, displayName string, now time.Time) {
+	participant := &p.Participants[i]
+	if displayName != "" {
+		participant.DisplayName = displayName
+	}
+	participant.Status = ParticipantStatusAccepted
+	participant.JoinedAt = now
+	participant.RespondedAt = &now
+
+	p.UpdatedAt = now
+	p.Record(ParticipantJoined{
+		PlanID:      p.ID,
+		UserID:      participant.UserID,
+		DisplayName: participant.DisplayName,
+	})
+}
+
+// acceptsParticipants checks if users can still be invited to or join the plan
+func (p *GroupDiningPlan) acceptsParticipants() bool {
+	return p.Status == PlanStatusCreated || p.Status == PlanStatusVoting
+}
+
+func (p *GroupDiningPlan) inviteLinkIndex(id string) int {
+	for i, link := range p.InviteLinks {
+		if link.ID == id {
+			return i
+		}
+	}
+	return -1
+}
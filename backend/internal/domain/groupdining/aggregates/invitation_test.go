@@ -0,0 +1,197 @@
+package aggregates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newInvitationTestPlan(t *testing.T) *GroupDiningPlan {
+	t.Helper()
+
+	plan, err := NewGroupDiningPlan("creator", "Creator", "週五晚餐", "")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+	plan.ClearEvents()
+	return plan
+}
+
+func TestInviteAndRespond(t *testing.T) {
+	now := time.Now()
+	plan := newInvitationTestPlan(t)
+
+	if err := plan.InviteParticipant("creator", "alice", "Alice", now); err != nil {
+		t.Fatalf("InviteParticipant() error = %v", err)
+	}
+	if plan.IsParticipant("alice") || !plan.HasPendingInvitation("alice") {
+		t.Fatalf("invitee should be pending, not a participant")
+	}
+	if err := plan.InviteParticipant("creator", "alice", "Alice", now); !errors.Is(err, ErrAlreadyInvited) {
+		t.Errorf("InviteParticipant() twice error = %v, want %v", err, ErrAlreadyInvited)
+	}
+	if err := plan.InviteParticipant("creator", "creator", "Creator", now); err == nil {
+		t.Errorf("InviteParticipant() self error = nil, want error")
+	}
+
+	if err := plan.RespondToInvitation("alice", true, now); err != nil {
+		t.Fatalf("RespondToInvitation() error = %v", err)
+	}
+	if !plan.IsParticipant("alice") || plan.HasPendingInvitation("alice") {
+		t.Errorf("accepted invitee should be a participant")
+	}
+	if err := plan.RespondToInvitation("alice", false, now); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("RespondToInvitation() after accept error = %v, want %v", err, ErrInvitationNotFound)
+	}
+	if err := plan.InviteParticipant("creator", "alice", "Alice", now); !errors.Is(err, ErrAlreadyParticipant) {
+		t.Errorf("InviteParticipant() participant error = %v, want %v", err, ErrAlreadyParticipant)
+	}
+
+	events := plan.PendingEvents()
+	if len(events) != 2 || events[0].Event.EventName() != EventParticipantInvited || events[1].Event.EventName() != EventParticipantJoined {
+		t.Errorf("events = %v, want invited then joined", events)
+	}
+}
+
+func TestDeclinedInvitation(t *testing.T) {
+	now := time.Now()
+	plan := newInvitationTestPlan(t)
+
+	if err := plan.InviteParticipant("creator", "bob", "Bob", now); err != nil {
+		t.Fatalf("InviteParticipant() error = %v", err)
+	}
+	if err := plan.RespondToInvitation("bob", false, now); err != nil {
+		t.Fatalf("RespondToInvitation() error = %v", err)
+	}
+	if plan.IsParticipant("bob") || plan.HasPendingInvitation("bob") {
+		t.Errorf("declined invitee should be neither participant nor pending")
+	}
+	if got := len(plan.AcceptedParticipants()); got != 1 {
+		t.Errorf("AcceptedParticipants() = %d entries, want 1", got)
+	}
+
+	// 婉拒後可以再次邀請
+	if err := plan.InviteParticipant("creator", "bob", "Bob", now); err != nil {
+		t.Fatalf("InviteParticipant() again error = %v", err)
+	}
+	if !plan.HasPendingInvitation("bob") || len(plan.Participants) != 2 {
+		t.Errorf("re-invited user should be pending without a duplicate entry, participants = %+v", plan.Participants)
+	}
+}
+
+func TestPendingInviteesDoNotCountForVoting(t *testing.T) {
+	plan := newInvitationTestPlan(t)
+	start := time.Now().Add(24 * time.Hour)
+	if err := plan.AddTimeSlot(start, start.Add(time.Hour), ""); err != nil {
+		t.Fatalf("AddTimeSlot() error = %v", err)
+	}
+	if err := plan.AddRestaurantOption("鼎泰豐", "", 0, 0, ""); err != nil {
+		t.Fatalf("AddRestaurantOption() error = %v", err)
+	}
+	if err := plan.InviteParticipant("creator", "alice", "Alice", time.Now()); err != nil {
+		t.Fatalf("InviteParticipant() error = %v", err)
+	}
+
+	if err := plan.StartVoting(nil); !errors.Is(err, ErrPlanNotReadyForVoting) {
+		t.Errorf("StartVoting() with only a pending invitee error = %v, want %v", err, ErrPlanNotReadyForVoting)
+	}
+}
+
+func TestJoinWithInviteLink(t *testing.T) {
+	now := time.Now()
+
+	t.Run("single use", func(t *testing.T) {
+		plan := newInvitationTestPlan(t)
+		link, err := plan.CreateInviteLink("creator", time.Hour, true, 0, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+
+		if err := plan.JoinWithInviteLink(link.ID, "alice", "Alice", now); err != nil {
+			t.Fatalf("JoinWithInviteLink() error = %v", err)
+		}
+		if err := plan.JoinWithInviteLink(link.ID, "bob", "Bob", now); !errors.Is(err, ErrInviteLinkUsed) {
+			t.Errorf("JoinWithInviteLink() second use error = %v, want %v", err, ErrInviteLinkUsed)
+		}
+	})
+
+	t.Run("participant cap", func(t *testing.T) {
+		plan := newInvitationTestPlan(t)
+		link, err := plan.CreateInviteLink("creator", time.Hour, false, 2, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+
+		if err := plan.JoinWithInviteLink(link.ID, "alice", "Alice", now); err != nil {
+			t.Fatalf("JoinWithInviteLink() error = %v", err)
+		}
+		if err := plan.JoinWithInviteLink(link.ID, "alice", "Alice", now); !errors.Is(err, ErrAlreadyParticipant) {
+			t.Errorf("JoinWithInviteLink() rejoin error = %v, want %v", err, ErrAlreadyParticipant)
+		}
+		if err := plan.JoinWithInviteLink(link.ID, "bob", "Bob", now); !errors.Is(err, ErrPlanFull) {
+			t.Errorf("JoinWithInviteLink() over cap error = %v, want %v", err, ErrPlanFull)
+		}
+		if plan.InviteLinks[0].UseCount != 1 {
+			t.Errorf("UseCount = %d, want 1", plan.InviteLinks[0].UseCount)
+		}
+	})
+
+	t.Run("expired and unknown links", func(t *testing.T) {
+		plan := newInvitationTestPlan(t)
+		link, err := plan.CreateInviteLink("creator", time.Hour, false, 0, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+
+		if err := plan.JoinWithInviteLink(link.ID, "alice", "Alice", now.Add(time.Hour)); !errors.Is(err, ErrInviteLinkExpired) {
+			t.Errorf("JoinWithInviteLink() expired error = %v, want %v", err, ErrInviteLinkExpired)
+		}
+		if err := plan.JoinWithInviteLink("missing", "alice", "Alice", now); !errors.Is(err, ErrInvalidInviteLink) {
+			t.Errorf("JoinWithInviteLink() unknown error = %v, want %v", err, ErrInvalidInviteLink)
+		}
+	})
+
+	t.Run("closed plan", func(t *testing.T) {
+		plan := newInvitationTestPlan(t)
+		link, err := plan.CreateInviteLink("creator", time.Hour, false, 0, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+		if err := plan.CancelPlan(); err != nil {
+			t.Fatalf("CancelPlan() error = %v", err)
+		}
+
+		if err := plan.JoinWithInviteLink(link.ID, "alice", "Alice", now); !errors.Is(err, ErrInvalidPlanStatus) {
+			t.Errorf("JoinWithInviteLink() cancelled plan error = %v, want %v", err, ErrInvalidPlanStatus)
+		}
+	})
+}
+
+func TestInviteLinkSigner(t *testing.T) {
+	now := time.Now()
+	signer := NewInviteLinkSigner("secret")
+	link := InviteLink{ID: "link-1", ExpiresAt: now.Add(time.Hour)}
+
+	token, err := signer.Sign("plan-1", link)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	claims, err := signer.Verify(token, now)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if claims.PlanID != "plan-1" || claims.LinkID != "link-1" {
+		t.Errorf("Verify() = %+v, want plan-1/link-1", claims)
+	}
+
+	if _, err := signer.Verify(token, now.Add(2*time.Hour)); !errors.Is(err, ErrInviteLinkExpired) {
+		t.Errorf("Verify() expired error = %v, want %v", err, ErrInviteLinkExpired)
+	}
+	if _, err := NewInviteLinkSigner("other").Verify(token, now); !errors.Is(err, ErrInvalidInviteLink) {
+		t.Errorf("Verify() other secret error = %v, want %v", err, ErrInvalidInviteLink)
+	}
+	if _, err := signer.Verify(token+"x", now); !errors.Is(err, ErrInvalidInviteLink) {
+		t.Errorf("Verify() tampered error = %v, want %v", err, ErrInvalidInviteLink)
+	}
+}
@@ -0,0 +1,73 @@
+package aggregates
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// InviteLinkClaims 邀請連結 token 攜帶的內容
+type InviteLinkClaims struct {
+	PlanID    string `json:"pid"`
+	LinkID    string `json:"lid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// InviteLinkSigner 以 HMAC-SHA256 簽署與驗證邀請連結的 token
+// token 無法被竄改成其他計畫或連結；單次使用與人數上限以計畫中的 InviteLink 為準
+type InviteLinkSigner struct {
+	secret []byte
+}
+
+func NewInviteLinkSigner(secret string) *InviteLinkSigner {
+	return &InviteLinkSigner{secret: []byte(secret)}
+}
+
+// Sign returns the token to share for the plan's invite link
+func (s *InviteLinkSigner) Sign(planID string, link InviteLink) (string, error) {
+	payload, err := json.Marshal(InviteLinkClaims{
+		PlanID:    planID,
+		LinkID:    link.ID,
+		ExpiresAt: link.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(encoded), nil
+}
+
+// Verify checks the token signature and expiry and returns its claims
+func (s *InviteLinkSigner) Verify(raw string, now time.Time) (InviteLinkClaims, error) {
+	encoded, signature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return InviteLinkClaims{}, ErrInvalidInviteLink
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(encoded))) {
+		return InviteLinkClaims{}, ErrInvalidInviteLink
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return InviteLinkClaims{}, ErrInvalidInviteLink
+	}
+
+	var claims InviteLinkClaims
+	if err := json.Unmarshal(data, &claims); err != nil || claims.PlanID == "" || claims.LinkID == "" {
+		return InviteLinkClaims{}, ErrInvalidInviteLink
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return InviteLinkClaims{}, ErrInviteLinkExpired
+	}
+	return claims, nil
+}
+
+func (s *InviteLinkSigner) signature(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,98 @@
+package aggregates
+
+import (
+	"fmt"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// PlanAction 需要授權的計畫操作
+type PlanAction string
+
+const (
+	PlanActionAddTimeSlot         PlanAction = "add_time_slot"
+	PlanActionAddRestaurantOption PlanAction = "add_restaurant_option"
+	PlanActionApplySuggestions    PlanAction = "apply_time_slot_suggestions"
+	PlanActionStartVoting         PlanAction = "start_voting"
+	PlanActionFinalize            PlanAction = "finalize"
+	PlanActionCancel              PlanAction = "cancel"
+	PlanActionInvite              PlanAction = "invite"
+	PlanActionCreateInviteLink    PlanAction = "create_invite_link"
+	PlanActionSuggestTimeSlots    PlanAction = "suggest_time_slots"
+	PlanActionVote                PlanAction = "vote"
+	PlanActionViewResults         PlanAction = "view_results"
+	PlanActionView                PlanAction = "view"
+)
+
+// PlanRole 用戶在計畫中的身分
+type PlanRole string
+
+const (
+	PlanRoleCreator     PlanRole = "creator"
+	PlanRoleParticipant PlanRole = "participant"
+	// PlanRoleInvitee 參與者或尚未回覆邀請的受邀者
+	PlanRoleInvitee PlanRole = "participant or invitee"
+)
+
+// planActionRoles 每個操作需要的身分；建立者同時也是參與者
+var planActionRoles = map[PlanAction]PlanRole{
+	PlanActionAddTimeSlot:         PlanRoleCreator,
+	PlanActionAddRestaurantOption: PlanRoleCreator,
+	PlanActionApplySuggestions:    PlanRoleCreator,
+	PlanActionStartVoting:         PlanRoleCreator,
+	PlanActionFinalize:            PlanRoleCreator,
+	PlanActionCancel:              PlanRoleCreator,
+	PlanActionInvite:              PlanRoleCreator,
+	PlanActionCreateInviteLink:    PlanRoleCreator,
+	PlanActionSuggestTimeSlots:    PlanRoleParticipant,
+	PlanActionVote:                PlanRoleParticipant,
+	PlanActionViewResults:         PlanRoleParticipant,
+	PlanActionView:                PlanRoleInvitee,
+}
+
+// PlanPermissionError 用戶沒有執行計畫操作的身分
+// 可用 errors.Is 比對 shared.ErrPermissionDenied 以及 ErrNotPlanCreator 或 ErrNotPlanParticipant
+type PlanPermissionError struct {
+	PlanID   string
+	UserID   string
+	Action   PlanAction
+	Required PlanRole
+}
+
+func (e *PlanPermissionError) Error() string {
+	return fmt.Sprintf("%v: only the plan %s can %s", shared.ErrPermissionDenied, e.Required, e.Action)
+}
+
+func (e *PlanPermissionError) Unwrap() []error {
+	if e.Required == PlanRoleCreator {
+		return []error{ErrNotPlanCreator, shared.ErrPermissionDenied}
+	}
+	return []error{ErrNotPlanParticipant, shared.ErrPermissionDenied}
+}
+
+// Authorize 檢查用戶是否可以對計畫執行 action，沒有權限時回傳 *PlanPermissionError
+func (p *GroupDiningPlan) Authorize(userID string, action PlanAction) error {
+	required, ok := planActionRoles[action]
+	if !ok {
+		return fmt.Errorf("unknown plan action %q", action)
+	}
+
+	allowed := false
+	switch required {
+	case PlanRoleCreator:
+		allowed = userID != "" && p.IsCreator(userID)
+	case PlanRoleParticipant:
+		allowed = userID != "" && p.IsParticipant(userID)
+	case PlanRoleInvitee:
+		allowed = userID != "" && (p.IsParticipant(userID) || p.HasPendingInvitation(userID))
+	}
+	if !allowed {
+		return &PlanPermissionError{
+			PlanID:   p.ID,
+			UserID:   userID,
+			Action:   action,
+			Required: required,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package aggregates
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func TestAuthorize(t *testing.T) {
+	plan, err := NewGroupDiningPlan("creator", "Creator", "週五晚餐", "")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+	if err := plan.AddParticipant("participant", "Alice"); err != nil {
+		t.Fatalf("AddParticipant() error = %v", err)
+	}
+	if err := plan.InviteParticipant("creator", "invitee", "Bob", time.Now()); err != nil {
+		t.Fatalf("InviteParticipant() error = %v", err)
+	}
+
+	tests := []struct {
+		userID  string
+		action  PlanAction
+		wantErr error
+	}{
+		{"creator", PlanActionFinalize, nil},
+		{"creator", PlanActionVote, nil},
+		{"participant", PlanActionVote, nil},
+		{"participant", PlanActionViewResults, nil},
+		{"participant", PlanActionAddRestaurantOption, ErrNotPlanCreator},
+		{"participant", PlanActionStartVoting, ErrNotPlanCreator},
+		{"participant", PlanActionFinalize, ErrNotPlanCreator},
+		{"participant", PlanActionCancel, ErrNotPlanCreator},
+		{"participant", PlanActionInvite, ErrNotPlanCreator},
+		{"participant", PlanActionCreateInviteLink, ErrNotPlanCreator},
+		{"invitee", PlanActionVote, ErrNotPlanParticipant},
+		{"stranger", PlanActionVote, ErrNotPlanParticipant},
+		{"stranger", PlanActionViewResults, ErrNotPlanParticipant},
+		{"", PlanActionViewResults, ErrNotPlanParticipant},
+		{"creator", PlanActionView, nil},
+		{"participant", PlanActionView, nil},
+		{"invitee", PlanActionView, nil},
+		{"stranger", PlanActionView, ErrNotPlanParticipant},
+		{"", PlanActionView, ErrNotPlanParticipant},
+	}
+
+	for _, tt := range tests {
+		err := plan.Authorize(tt.userID, tt.action)
+		if tt.wantErr == nil {
+			if err != nil {
+				t.Errorf("Authorize(%q, %s) error = %v, want nil", tt.userID, tt.action, err)
+			}
+			continue
+		}
+		if !errors.Is(err, tt.wantErr) || !errors.Is(err, shared.ErrPermissionDenied) {
+			t.Errorf("Authorize(%q, %s) error = %v, want %v", tt.userID, tt.action, err, tt.wantErr)
+		}
+		var permissionErr *PlanPermissionError
+		if !errors.As(err, &permissionErr) || permissionErr.Action != tt.action || permissionErr.PlanID != plan.ID {
+			t.Errorf("Authorize(%q, %s) error = %#v, want *PlanPermissionError", tt.userID, tt.action, err)
+		}
+	}
+}
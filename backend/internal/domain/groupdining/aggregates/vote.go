@@ -1,9 +1,9 @@
 package aggregates
 
 import (
-	"errors"
 	"time"
 
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/google/uuid"
 )
 
@@ -15,11 +15,13 @@ const (
 	VoteTypeRestaurant VoteType = "restaurant"
 )
 
-// VoteChoice represents a single vote choice
+// VoteChoice represents a single vote choice. Choices are kept in preference order,
+// which ranked voting methods use as the ranking.
 type VoteChoice struct {
 	ID       string   `json:"id"`
 	Type     VoteType `json:"type"`
 	OptionID string   `json:"option_id"`
+	Score    int      `json:"score,omitempty"` // 只有評分投票使用，1-5
 }
 
 // Vote represents a participant's vote for a group dining plan
@@ -35,10 +37,10 @@ type Vote struct {
 // NewVote creates a new vote for a group dining plan
 func NewVote(planID, userID string) (*Vote, error) {
 	if planID == "" {
-		return nil, errors.New("plan ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
 	}
 	if userID == "" {
-		return nil, errors.New("user ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
 	}
 
 	vote := &Vote{
@@ -55,13 +57,13 @@ func NewVote(planID, userID string) (*Vote, error) {
 // AddTimeChoice adds a time slot choice to the vote
 func (v *Vote) AddTimeChoice(timeSlotID string) error {
 	if timeSlotID == "" {
-		return errors.New("time slot ID cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("time slot ID cannot be empty")
 	}
 
 	// Check if this time slot is already voted for
 	for _, choice := range v.Choices {
 		if choice.Type == VoteTypeTime && choice.OptionID == timeSlotID {
-			return errors.New("time slot already voted for")
+			return shared.ErrInvalidInput.WithMessage("time slot already voted for")
 		}
 	}
 
@@ -78,13 +80,13 @@ func (v *Vote) AddTimeChoice(timeSlotID string) error {
 // AddRestaurantChoice adds a restaurant choice to the vote
 func (v *Vote) AddRestaurantChoice(restaurantID string) error {
 	if restaurantID == "" {
-		return errors.New("restaurant ID cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("restaurant ID cannot be empty")
 	}
 
 	// Check if this restaurant is already voted for
 	for _, choice := range v.Choices {
 		if choice.Type == VoteTypeRestaurant && choice.OptionID == restaurantID {
-			return errors.New("restaurant already voted for")
+			return shared.ErrInvalidInput.WithMessage("restaurant already voted for")
 		}
 	}
 
@@ -98,6 +100,21 @@ func (v *Vote) AddRestaurantChoice(restaurantID string) error {
 	return nil
 }
 
+// SetScore sets the score of a choice for score voting
+func (v *Vote) SetScore(optionID string, score int) error {
+	if score < MinVoteScore || score > MaxVoteScore {
+		return shared.ErrInvalidInput.WithMessage("score must be between 1 and 5")
+	}
+
+	for i, choice := range v.Choices {
+		if choice.OptionID == optionID {
+			v.Choices[i].Score = score
+			return nil
+		}
+	}
+	return shared.ErrInvalidInput.WithMessage("option is not part of this vote")
+}
+
 // SetComment sets a comment for the vote
 func (v *Vote) SetComment(comment string) {
 	v.Comment = comment
@@ -125,10 +142,22 @@ func (v *Vote) GetRestaurantChoices() []string {
 	return restaurantChoices
 }
 
+// ballot returns the preferences of one kind of option in ranked order
+func (v *Vote) ballot(voteType VoteType) Ballot {
+	ballot := Ballot{Scores: make(map[string]int)}
+	for _, choice := range v.Choices {
+		if choice.Type == voteType {
+			ballot.Ranking = append(ballot.Ranking, choice.OptionID)
+			ballot.Scores[choice.OptionID] = choice.Score
+		}
+	}
+	return ballot
+}
+
 // IsValid validates the vote has at least one choice
 func (v *Vote) IsValid() error {
 	if len(v.Choices) == 0 {
-		return errors.New("vote must have at least one choice")
+		return shared.ErrInvalidInput.WithMessage("vote must have at least one choice")
 	}
 
 	hasTimeChoice := false
@@ -144,11 +173,11 @@ func (v *Vote) IsValid() error {
 	}
 
 	if !hasTimeChoice {
-		return errors.New("vote must include at least one time choice")
+		return shared.ErrInvalidInput.WithMessage("vote must include at least one time choice")
 	}
 
 	if !hasRestaurantChoice {
-		return errors.New("vote must include at least one restaurant choice")
+		return shared.ErrInvalidInput.WithMessage("vote must include at least one restaurant choice")
 	}
 
 	return nil
@@ -0,0 +1,281 @@
+package aggregates
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// VotingMethod decides how ballots are turned into option scores
+type VotingMethod string
+
+const (
+	// VotingMethodApproval 每個勾選的選項得 1 分
+	VotingMethodApproval VotingMethod = "approval"
+	// VotingMethodRankedChoice 排序投票 (instant-runoff)：逐輪淘汰最低票，直到有選項過半
+	VotingMethodRankedChoice VotingMethod = "ranked_choice"
+	// VotingMethodBorda 排序投票：n 個選項中排第 r 名得 n-r+1 分，未排序的選項 0 分
+	VotingMethodBorda VotingMethod = "borda"
+	// VotingMethodScore 評分投票：每個選項給 1-5 分，加總得分
+	VotingMethodScore VotingMethod = "score"
+)
+
+const (
+	MinVoteScore = 1
+	MaxVoteScore = 5
+)
+
+// Ballot is one participant's preferences for a single kind of option (time slots or restaurants)
+type Ballot struct {
+	Ranking []string       // 依偏好排序的選項 ID
+	Scores  map[string]int // 只有評分投票使用
+}
+
+// RunoffRound records the first-preference counts of one instant-runoff round
+type RunoffRound struct {
+	Counts     map[string]int `json:"counts"`
+	Eliminated []string       `json:"eliminated,omitempty"`
+}
+
+// Tally is the outcome of counting ballots for one kind of option
+type Tally struct {
+	Counts map[string]int `json:"counts"`           // 每個選項依投票方式計算的分數
+	Rounds []RunoffRound  `json:"rounds,omitempty"` // 只有 ranked_choice 會有
+}
+
+// Tallier counts ballots for a voting method; options are given in proposal order
+type Tallier interface {
+	Tally(options []string, ballots []Ballot) Tally
+}
+
+var talliers = map[VotingMethod]Tallier{
+	VotingMethodApproval:     approvalTallier{},
+	VotingMethodRankedChoice: instantRunoffTallier{},
+	VotingMethodBorda:        bordaTallier{},
+	VotingMethodScore:        scoreTallier{},
+}
+
+// IsValid checks if the voting method is supported
+func (m VotingMethod) IsValid() bool {
+	_, ok := talliers[m]
+	return ok
+}
+
+// IsRanked checks if the order of the choices in a vote matters
+func (m VotingMethod) IsRanked() bool {
+	return m == VotingMethodRankedChoice || m == VotingMethodBorda
+}
+
+// validateVote checks the method specific rules of a vote
+func (m VotingMethod) validateVote(vote *Vote) error {
+	for _, choice := range vote.Choices {
+		switch {
+		case m == VotingMethodScore && (choice.Score < MinVoteScore || choice.Score > MaxVoteScore):
+			return shared.ErrInvalidInput.WithMessage("score voting requires a score between 1 and 5 for every choice")
+		case m != VotingMethodScore && choice.Score != 0:
+			return shared.ErrInvalidInput.WithMessage("scores are only accepted for score voting")
+		}
+	}
+	return nil
+}
+
+type approvalTallier struct{}
+
+func (approvalTallier) Tally(options []string, ballots []Ballot) Tally {
+	counts := zeroCounts(options)
+	for _, ballot := range ballots {
+		for _, optionID := range ballot.Ranking {
+			if _, ok := counts[optionID]; ok {
+				counts[optionID]++
+			}
+		}
+	}
+	return Tally{Counts: counts}
+}
+
+type bordaTallier struct{}
+
+func (bordaTallier) Tally(options []string, ballots []Ballot) Tally {
+	counts := zeroCounts(options)
+	for _, ballot := range ballots {
+		for rank, optionID := range ballot.Ranking {
+			if _, ok := counts[optionID]; ok {
+				counts[optionID] += len(options) - rank
+			}
+		}
+	}
+	return Tally{Counts: counts}
+}
+
+type scoreTallier struct{}
+
+func (scoreTallier) Tally(options []string, ballots []Ballot) Tally {
+	counts := zeroCounts(options)
+	for _, ballot := range ballots {
+		for _, optionID := range ballot.Ranking {
+			if _, ok := counts[optionID]; ok {
+				counts[optionID] += ballot.Scores[optionID]
+			}
+		}
+	}
+	return Tally{Counts: counts}
+}
+
+type instantRunoffTallier struct{}
+
+// Tally 每輪以各選票最前面且尚未淘汰的選項計票；有選項超過有效票的一半、或剩下的選項同票時結束。
+// 最終分數為最後一輪的票數，淘汰的選項為 0，因此同票時交由平手規則決定
+func (instantRunoffTallier) Tally(options []string, ballots []Ballot) Tally {
+	remaining := make(map[string]bool, len(options))
+	for _, optionID := range options {
+		remaining[optionID] = true
+	}
+
+	var rounds []RunoffRound
+	for {
+		round := RunoffRound{Counts: make(map[string]int, len(remaining))}
+		for _, optionID := range options {
+			if remaining[optionID] {
+				round.Counts[optionID] = 0
+			}
+		}
+
+		active := 0
+		for _, ballot := range ballots {
+			for _, optionID := range ballot.Ranking {
+				if remaining[optionID] {
+					round.Counts[optionID]++
+					active++
+					break
+				}
+			}
+		}
+
+		lowest, highest := -1, 0
+		for _, count := range round.Counts {
+			if lowest == -1 || count < lowest {
+				lowest = count
+			}
+			if count > highest {
+				highest = count
+			}
+		}
+
+		if active == 0 || highest*2 > active || lowest == highest {
+			rounds = append(rounds, round)
+			break
+		}
+
+		// 淘汰最低票的選項；多個同為最低票時全部淘汰，但至少保留一個最高票選項
+		for _, optionID := range options {
+			if remaining[optionID] && round.Counts[optionID] == lowest {
+				round.Eliminated = append(round.Eliminated, optionID)
+				delete(remaining, optionID)
+			}
+		}
+		rounds = append(rounds, round)
+	}
+
+	counts := zeroCounts(options)
+	for optionID, count := range rounds[len(rounds)-1].Counts {
+		counts[optionID] = count
+	}
+	return Tally{Counts: counts, Rounds: rounds}
+}
+
+func zeroCounts(options []string) map[string]int {
+	counts := make(map[string]int, len(options))
+	for _, optionID := range options {
+		counts[optionID] = 0
+	}
+	return counts
+}
+
+// VotingTally is the result of counting all votes of a plan
+type VotingTally struct {
+	Method      VotingMethod `json:"method"`
+	Ballots     int          `json:"ballots"`
+	TimeSlots   Tally        `json:"time_slots"`
+	Restaurants Tally        `json:"restaurants"`
+}
+
+// Tally counts the given votes with the plan's voting method. Only the latest vote of
+// each current participant is counted, and choices of options no longer in the plan are ignored.
+func (p *GroupDiningPlan) Tally(votes []*Vote) VotingTally {
+	latest := make(map[string]*Vote, len(votes))
+	for _, vote := range votes {
+		if vote.PlanID != p.ID || !p.IsParticipant(vote.UserID) {
+			continue
+		}
+		if current, ok := latest[vote.UserID]; !ok || vote.VotedAt.After(current.VotedAt) {
+			latest[vote.UserID] = vote
+		}
+	}
+
+	// 依參與者加入順序整理選票，讓結果與 map 走訪順序無關
+	var timeBallots, restaurantBallots []Ballot
+	for _, participant := range p.Participants {
+		vote, ok := latest[participant.UserID]
+		if !ok {
+			continue
+		}
+		timeBallots = append(timeBallots, vote.ballot(VoteTypeTime))
+		restaurantBallots = append(restaurantBallots, vote.ballot(VoteTypeRestaurant))
+	}
+
+	method := p.votingMethod()
+	tallier := talliers[method]
+
+	timeSlotIDs := make([]string, len(p.TimeSlots))
+	for i, slot := range p.TimeSlots {
+		timeSlotIDs[i] = slot.ID
+	}
+	restaurantIDs := make([]string, len(p.RestaurantOptions))
+	for i, option := range p.RestaurantOptions {
+		restaurantIDs[i] = option.ID
+	}
+
+	return VotingTally{
+		Method:      method,
+		Ballots:     len(latest),
+		TimeSlots:   tallier.Tally(timeSlotIDs, timeBallots),
+		Restaurants: tallier.Tally(restaurantIDs, restaurantBallots),
+	}
+}
+
+// applyTally stores the tallied scores in the option vote counts
+func (p *GroupDiningPlan) applyTally(tally VotingTally) {
+	for i := range p.TimeSlots {
+		p.TimeSlots[i].VoteCount = tally.TimeSlots.Counts[p.TimeSlots[i].ID]
+	}
+	for i := range p.RestaurantOptions {
+		p.RestaurantOptions[i].VoteCount = tally.Restaurants.Counts[p.RestaurantOptions[i].ID]
+	}
+}
+
+// votingMethod returns the plan's voting method; plans created before voting methods existed use approval
+func (p *GroupDiningPlan) votingMethod() VotingMethod {
+	if p.VotingMethod == "" {
+		return VotingMethodApproval
+	}
+	return p.VotingMethod
+}
+
+// ConfigureVotingMethod sets how the votes of the plan are counted
+func (p *GroupDiningPlan) ConfigureVotingMethod(method VotingMethod) error {
+	if p.Status != PlanStatusCreated {
+		return ErrVotingStarted.WithMessage("cannot change voting method after voting has started")
+	}
+
+	if method == "" {
+		method = VotingMethodApproval
+	}
+	if !method.IsValid() {
+		return shared.ErrInvalidInput.WithMessage("invalid voting method")
+	}
+
+	p.VotingMethod = method
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
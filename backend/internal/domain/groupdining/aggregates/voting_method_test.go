@@ -0,0 +1,222 @@
+package aggregates
+
+import (
+	"testing"
+	"time"
+)
+
+// testBallot 以提出順序的位置代稱選項，依偏好排序
+type testBallot struct {
+	userID      string
+	timeSlots   []int
+	restaurants []int
+	scores      []int // 評分投票時依 timeSlots、restaurants 的順序給分
+}
+
+// castVotes 依序送出投票，回傳所有已記錄的投票
+func castVotes(t *testing.T, plan *GroupDiningPlan, ballots ...testBallot) []*Vote {
+	t.Helper()
+
+	var votes []*Vote
+	for _, ballot := range ballots {
+		vote, err := NewVote(plan.ID, ballot.userID)
+		if err != nil {
+			t.Fatalf("NewVote() error = %v", err)
+		}
+		for _, i := range ballot.timeSlots {
+			if err := vote.AddTimeChoice(plan.TimeSlots[i].ID); err != nil {
+				t.Fatalf("AddTimeChoice() error = %v", err)
+			}
+		}
+		for _, i := range ballot.restaurants {
+			if err := vote.AddRestaurantChoice(plan.RestaurantOptions[i].ID); err != nil {
+				t.Fatalf("AddRestaurantChoice() error = %v", err)
+			}
+		}
+		for i, score := range ballot.scores {
+			if err := vote.SetScore(vote.Choices[i].OptionID, score); err != nil {
+				t.Fatalf("SetScore() error = %v", err)
+			}
+		}
+		if err := plan.RecordVote(vote, votes); err != nil {
+			t.Fatalf("RecordVote() error = %v", err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+// newVotingPlan 建立一個時段、三間餐廳與五位參與者 (creator, a, b, c, d) 的投票中計畫
+func newVotingPlan(t *testing.T, method VotingMethod) *GroupDiningPlan {
+	t.Helper()
+
+	plan, err := NewGroupDiningPlan("creator", "Creator", "Team dinner", "")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+	start := time.Now().Add(48 * time.Hour)
+	if err := plan.AddTimeSlot(start, start.Add(2*time.Hour), "Friday"); err != nil {
+		t.Fatalf("AddTimeSlot() error = %v", err)
+	}
+	for _, name := range []string{"Ramen", "Sushi", "Hot pot"} {
+		if err := plan.AddRestaurantOption(name, "", 0, 0, ""); err != nil {
+			t.Fatalf("AddRestaurantOption() error = %v", err)
+		}
+	}
+	for _, userID := range []string{"a", "b", "c", "d"} {
+		if err := plan.AddParticipant(userID, userID); err != nil {
+			t.Fatalf("AddParticipant() error = %v", err)
+		}
+	}
+	if err := plan.ConfigureVotingMethod(method); err != nil {
+		t.Fatalf("ConfigureVotingMethod() error = %v", err)
+	}
+	if err := plan.StartVoting(nil); err != nil {
+		t.Fatalf("StartVoting() error = %v", err)
+	}
+	return plan
+}
+
+func restaurantCounts(plan *GroupDiningPlan) []int {
+	counts := make([]int, len(plan.RestaurantOptions))
+	for i, option := range plan.RestaurantOptions {
+		counts[i] = option.VoteCount
+	}
+	return counts
+}
+
+func TestVotingMethodsTallyRestaurants(t *testing.T) {
+	// 兩人最愛 Ramen，兩人最愛 Sushi，一人最愛 Hot pot 但次選 Sushi
+	ranked := []testBallot{
+		{userID: "creator", timeSlots: []int{0}, restaurants: []int{0, 2, 1}},
+		{userID: "a", timeSlots: []int{0}, restaurants: []int{0, 2}},
+		{userID: "b", timeSlots: []int{0}, restaurants: []int{1, 2}},
+		{userID: "c", timeSlots: []int{0}, restaurants: []int{1, 0}},
+		{userID: "d", timeSlots: []int{0}, restaurants: []int{2, 1}},
+	}
+
+	tests := []struct {
+		name    string
+		method  VotingMethod
+		ballots []testBallot
+		want    []int
+	}{
+		{
+			name:    "approval counts every listed option",
+			method:  VotingMethodApproval,
+			ballots: ranked,
+			want:    []int{3, 4, 4},
+		},
+		{
+			name:    "instant runoff transfers eliminated preferences",
+			method:  VotingMethodRankedChoice,
+			ballots: ranked,
+			want:    []int{2, 3, 0},
+		},
+		{
+			name:    "borda gives n-r+1 points",
+			method:  VotingMethodBorda,
+			ballots: ranked,
+			want:    []int{3 + 3 + 2, 1 + 3 + 3 + 2, 2 + 2 + 2 + 3},
+		},
+		{
+			name:   "score sums the ratings",
+			method: VotingMethodScore,
+			ballots: []testBallot{
+				{userID: "creator", timeSlots: []int{0}, restaurants: []int{0, 1}, scores: []int{3, 5, 2}},
+				{userID: "a", timeSlots: []int{0}, restaurants: []int{2}, scores: []int{4, 4}},
+			},
+			want: []int{5, 2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := newVotingPlan(t, tt.method)
+			votes := castVotes(t, plan, tt.ballots...)
+
+			got := restaurantCounts(plan)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("restaurant vote counts = %v, want %v", got, tt.want)
+				}
+			}
+
+			// 由已儲存的投票重新計票須得到相同結果
+			tally := plan.Tally(votes)
+			if tally.Method != tt.method || tally.Ballots != len(tt.ballots) {
+				t.Errorf("Tally() method, ballots = %s, %d, want %s, %d", tally.Method, tally.Ballots, tt.method, len(tt.ballots))
+			}
+			for i, option := range plan.RestaurantOptions {
+				if tally.Restaurants.Counts[option.ID] != tt.want[i] {
+					t.Errorf("Tally() %s = %d, want %d", option.Name, tally.Restaurants.Counts[option.ID], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInstantRunoffRecordsRounds(t *testing.T) {
+	plan := newVotingPlan(t, VotingMethodRankedChoice)
+	votes := castVotes(t, plan,
+		testBallot{userID: "creator", timeSlots: []int{0}, restaurants: []int{0, 2, 1}},
+		testBallot{userID: "a", timeSlots: []int{0}, restaurants: []int{0, 2}},
+		testBallot{userID: "b", timeSlots: []int{0}, restaurants: []int{1, 2}},
+		testBallot{userID: "c", timeSlots: []int{0}, restaurants: []int{1, 0}},
+		testBallot{userID: "d", timeSlots: []int{0}, restaurants: []int{2, 1}},
+	)
+
+	rounds := plan.Tally(votes).Restaurants.Rounds
+	if len(rounds) != 2 {
+		t.Fatalf("rounds = %+v, want 2 rounds", rounds)
+	}
+	hotPot := plan.RestaurantOptions[2].ID
+	if len(rounds[0].Eliminated) != 1 || rounds[0].Eliminated[0] != hotPot {
+		t.Errorf("round 1 eliminated %v, want Hot pot", rounds[0].Eliminated)
+	}
+	if _, ok := rounds[1].Counts[hotPot]; ok {
+		t.Errorf("round 2 counts = %v, want Hot pot excluded", rounds[1].Counts)
+	}
+}
+
+func TestRecordVoteRecountsChangedVotes(t *testing.T) {
+	plan := newVotingPlan(t, VotingMethodApproval)
+	votes := castVotes(t, plan,
+		testBallot{userID: "creator", timeSlots: []int{0}, restaurants: []int{0}},
+		testBallot{userID: "a", timeSlots: []int{0}, restaurants: []int{0}},
+	)
+
+	// a 改投 Sushi，原本的票不再計入
+	changed, _ := NewVote(plan.ID, "a")
+	changed.ID = votes[1].ID
+	_ = changed.AddTimeChoice(plan.TimeSlots[0].ID)
+	_ = changed.AddRestaurantChoice(plan.RestaurantOptions[1].ID)
+	if err := plan.RecordVote(changed, votes); err != nil {
+		t.Fatalf("RecordVote() error = %v", err)
+	}
+
+	if got := restaurantCounts(plan); got[0] != 1 || got[1] != 1 {
+		t.Errorf("restaurant vote counts = %v, want [1 1 0]", got)
+	}
+	if plan.TimeSlots[0].VoteCount != 2 {
+		t.Errorf("time slot vote count = %d, want 2", plan.TimeSlots[0].VoteCount)
+	}
+}
+
+func TestRecordVoteValidatesMethodRules(t *testing.T) {
+	scorePlan := newVotingPlan(t, VotingMethodScore)
+	vote, _ := NewVote(scorePlan.ID, "a")
+	_ = vote.AddTimeChoice(scorePlan.TimeSlots[0].ID)
+	_ = vote.AddRestaurantChoice(scorePlan.RestaurantOptions[0].ID)
+	if err := scorePlan.RecordVote(vote, nil); err == nil {
+		t.Error("RecordVote() without scores error = nil, want error")
+	}
+
+	approvalPlan := newVotingPlan(t, VotingMethodApproval)
+	vote, _ = NewVote(approvalPlan.ID, "a")
+	_ = vote.AddTimeChoice(approvalPlan.TimeSlots[0].ID)
+	_ = vote.AddRestaurantChoice("unknown-restaurant")
+	if err := approvalPlan.RecordVote(vote, nil); err == nil || err.Error() != "invalid restaurant ID" {
+		t.Errorf("RecordVote() unknown option error = %v, want invalid restaurant ID", err)
+	}
+}
@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// ChannelName 通知的送達管道
+type ChannelName string
+
+const (
+	ChannelInApp ChannelName = "in_app"
+	ChannelEmail ChannelName = "email"
+	ChannelPush  ChannelName = "push"
+)
+
+// Channel 將已存入收件匣的通知送到用戶的某個管道
+type Channel interface {
+	Name() ChannelName
+	Deliver(ctx context.Context, recipient *user.User, notification *Notification) error
+}
+
+// InAppChannel 透過即時事件通知在線的用戶有新通知
+type InAppChannel struct {
+	publisher communication.Publisher
+}
+
+func NewInAppChannel(publisher communication.Publisher) *InAppChannel {
+	return &InAppChannel{publisher: publisher}
+}
+
+func (c *InAppChannel) Name() ChannelName { return ChannelInApp }
+
+func (c *InAppChannel) Deliver(ctx context.Context, recipient *user.User, notification *Notification) error {
+	c.publisher.Publish(ctx, communication.NewEvent(communication.EventNotificationCreated, []shared.UserID{recipient.ID}, notification))
+	return nil
+}
+
+// EmailChannel 將通知寄到用戶的 Email
+type EmailChannel struct {
+	mailer communication.Mailer
+}
+
+func NewEmailChannel(mailer communication.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Name() ChannelName { return ChannelEmail }
+
+func (c *EmailChannel) Deliver(ctx context.Context, recipient *user.User, notification *Notification) error {
+	if recipient.Email == "" {
+		return shared.ErrRecipientNoEmail
+	}
+
+	var body strings.Builder
+	body.WriteString("Hi " + recipient.Profile.DisplayName + ",\n\n")
+	body.WriteString(notification.Title + "\n")
+	if notification.Body != "" {
+		body.WriteString("\n" + notification.Body + "\n")
+	}
+	body.WriteString("\nYou can turn off these emails in your notification settings.\n")
+
+	return c.mailer.Send(ctx, communication.Message{
+		To:       recipient.Email,
+		Subject:  "[Pingnom] " + notification.Title,
+		TextBody: body.String(),
+	})
+}
+
+// PushChannel 將通知推播到用戶的手機
+type PushChannel struct {
+	provider communication.PushProvider
+}
+
+func NewPushChannel(provider communication.PushProvider) *PushChannel {
+	return &PushChannel{provider: provider}
+}
+
+func (c *PushChannel) Name() ChannelName { return ChannelPush }
+
+func (c *PushChannel) Deliver(ctx context.Context, recipient *user.User, notification *Notification) error {
+	data := make(map[string]string, len(notification.Data)+2)
+	for key, value := range notification.Data {
+		data[key] = value
+	}
+	data["notificationId"] = notification.ID.String()
+	data["type"] = string(notification.Type)
+
+	return c.provider.Send(ctx, recipient.ID, communication.PushMessage{
+		Title: notification.Title,
+		Body:  notification.Body,
+		Data:  data,
+	})
+}
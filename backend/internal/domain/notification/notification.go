@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"strings"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Type 通知類型，用戶可在偏好設定中關閉特定類型
+type Type string
+
+const (
+	TypePingInvite    Type = "ping_invite"
+	TypeFriendRequest Type = "friend_request"
+	TypeMealReminder  Type = "meal_reminder" // 用餐開始前的提醒
+	TypePlanInvite    Type = "plan_invite"   // 受邀加入聚餐計畫
+)
+
+// Notification 是用戶收件匣中的一則通知
+type Notification struct {
+	ID        shared.ID         `json:"id"`
+	UserID    shared.UserID     `json:"userId"`
+	Type      Type              `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body,omitempty"`
+	Data      map[string]string `json:"data,omitempty"` // 客戶端導頁用的關聯 ID，例如 pingId
+	DedupKey  string            `json:"-"`              // 同一用戶相同 DedupKey 的通知只會送出一次
+	ReadAt    *time.Time        `json:"readAt,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// NewNotification 建立一則尚未讀取的通知
+// dedupKey 通常是觸發通知的事件 ID，讓重複派送的事件不會產生重複通知
+func NewNotification(userID shared.UserID, notificationType Type, title, body string, data map[string]string, dedupKey string) (*Notification, error) {
+	if userID.IsEmpty() {
+		return nil, shared.ErrInvalidInput.WithMessage("notification recipient cannot be empty")
+	}
+	if strings.TrimSpace(title) == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("notification title cannot be empty")
+	}
+
+	return &Notification{
+		ID:        shared.NewID(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     strings.TrimSpace(title),
+		Body:      strings.TrimSpace(body),
+		Data:      data,
+		DedupKey:  dedupKey,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRead 檢查通知是否已讀
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// MarkRead 將通知標記為已讀，已讀的通知保留原本的讀取時間
+func (n *Notification) MarkRead(at time.Time) {
+	if n.IsRead() {
+		return
+	}
+	n.ReadAt = &at
+}
@@ -0,0 +1,26 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Repository 定義通知收件匣的儲存介面
+type Repository interface {
+	// Save 儲存新通知；同一用戶已有相同 DedupKey 的通知時回傳 shared.ErrDuplicateNotification
+	Save(ctx context.Context, notification *Notification) error
+
+	// FindByUser 依建立時間由新到舊列出用戶的通知
+	FindByUser(ctx context.Context, userID shared.UserID, unreadOnly bool, limit, offset int) ([]*Notification, error)
+
+	// CountUnread 計算用戶的未讀通知數
+	CountUnread(ctx context.Context, userID shared.UserID) (int64, error)
+
+	// MarkRead 將用戶的一則通知標記為已讀，通知不存在或不屬於該用戶時回傳 shared.ErrNotificationNotFound
+	MarkRead(ctx context.Context, userID shared.UserID, id shared.ID, at time.Time) error
+
+	// MarkAllRead 將用戶所有未讀通知標記為已讀，回傳更新筆數
+	MarkAllRead(ctx context.Context, userID shared.UserID, at time.Time) (int64, error)
+}
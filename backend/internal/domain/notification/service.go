@@ -0,0 +1,109 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// Service 處理通知的送出與收件匣操作
+type Service struct {
+	repo     Repository
+	userRepo user.UserRepository
+	channels []Channel
+}
+
+// NewService 建立通知服務，channels 為可用的送達管道
+func NewService(repo Repository, userRepo user.UserRepository, channels ...Channel) *Service {
+	return &Service{
+		repo:     repo,
+		userRepo: userRepo,
+		channels: channels,
+	}
+}
+
+// Notify 依收件者的偏好將通知存入收件匣，再經由啟用的管道送出
+// 管道送達為盡力而為，失敗只記錄 log；重複的通知 (相同 DedupKey) 會被忽略
+func (s *Service) Notify(ctx context.Context, notification *Notification) error {
+	recipient, err := s.userRepo.FindByID(ctx, notification.UserID)
+	if err != nil {
+		if errors.Is(err, shared.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	preferences := recipient.NotificationPreferences
+	if !recipient.IsActive || !wantsType(preferences, notification.Type) {
+		return nil
+	}
+
+	if err := s.repo.Save(ctx, notification); err != nil {
+		if errors.Is(err, shared.ErrDuplicateNotification) {
+			return nil
+		}
+		return err
+	}
+
+	for _, channel := range s.channels {
+		if !channelEnabled(preferences, channel.Name(), notification.CreatedAt) {
+			continue
+		}
+		if err := channel.Deliver(ctx, recipient, notification); err != nil {
+			log.Printf("Failed to deliver notification %s via %s: %v", notification.ID, channel.Name(), err)
+		}
+	}
+	return nil
+}
+
+// ListNotifications 列出用戶的通知 (新到舊)
+func (s *Service) ListNotifications(ctx context.Context, userID shared.UserID, unreadOnly bool, limit, offset int) ([]*Notification, error) {
+	return s.repo.FindByUser(ctx, userID, unreadOnly, limit, offset)
+}
+
+// CountUnread 取得用戶的未讀通知數
+func (s *Service) CountUnread(ctx context.Context, userID shared.UserID) (int64, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead 將用戶的一則通知標記為已讀
+func (s *Service) MarkRead(ctx context.Context, userID shared.UserID, id shared.ID) error {
+	return s.repo.MarkRead(ctx, userID, id, time.Now())
+}
+
+// MarkAllRead 將用戶所有未讀通知標記為已讀
+func (s *Service) MarkAllRead(ctx context.Context, userID shared.UserID) (int64, error) {
+	return s.repo.MarkAllRead(ctx, userID, time.Now())
+}
+
+// wantsType 檢查用戶是否接收此類型的通知
+func wantsType(preferences user.NotificationPreferences, notificationType Type) bool {
+	switch notificationType {
+	case TypePingInvite, TypePlanInvite:
+		return preferences.PingInvites
+	case TypeFriendRequest:
+		return preferences.FriendRequests
+	case TypeMealReminder:
+		return preferences.MealReminders
+	default:
+		return true
+	}
+}
+
+// channelEnabled 檢查管道是否啟用；勿擾時段內只保留站內通知
+func channelEnabled(preferences user.NotificationPreferences, channel ChannelName, at time.Time) bool {
+	switch channel {
+	case ChannelInApp:
+		return preferences.InApp
+	case ChannelEmail:
+		return preferences.Email && !preferences.QuietHours.Contains(at)
+	case ChannelPush:
+		return preferences.Push && !preferences.QuietHours.Contains(at)
+	default:
+		return false
+	}
+}
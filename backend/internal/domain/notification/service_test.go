@@ -0,0 +1,143 @@
+package notification_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+	persistenceInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+// recordingChannel 記錄送到此管道的通知
+type recordingChannel struct {
+	name      notification.ChannelName
+	delivered []*notification.Notification
+}
+
+func (c *recordingChannel) Name() notification.ChannelName { return c.name }
+
+func (c *recordingChannel) Deliver(ctx context.Context, recipient *user.User, n *notification.Notification) error {
+	c.delivered = append(c.delivered, n)
+	return nil
+}
+
+type testFixture struct {
+	service  *notification.Service
+	repo     *persistenceInmemory.NotificationRepository
+	userRepo user.UserRepository
+	inApp    *recordingChannel
+	email    *recordingChannel
+	push     *recordingChannel
+}
+
+func newTestFixture() *testFixture {
+	f := &testFixture{
+		repo:     persistenceInmemory.NewNotificationRepository(),
+		userRepo: inmemory.NewInMemoryUserRepository(nil),
+		inApp:    &recordingChannel{name: notification.ChannelInApp},
+		email:    &recordingChannel{name: notification.ChannelEmail},
+		push:     &recordingChannel{name: notification.ChannelPush},
+	}
+	f.service = notification.NewService(f.repo, f.userRepo, f.inApp, f.email, f.push)
+	return f
+}
+
+func (f *testFixture) registerUser(t *testing.T, preferences user.NotificationPreferences) *user.User {
+	t.Helper()
+
+	u, err := user.NewUser(shared.NewID().String()+"@example.com", "", "Password123!", "Test User")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	u.NotificationPreferences = preferences
+	if err := f.userRepo.Save(context.Background(), u); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return u
+}
+
+func newPingInvite(t *testing.T, recipient shared.UserID, dedupKey string) *notification.Notification {
+	t.Helper()
+
+	n, err := notification.NewNotification(recipient, notification.TypePingInvite, "Alice invited you to lunch", "Ramen?", nil, dedupKey)
+	if err != nil {
+		t.Fatalf("NewNotification() error = %v", err)
+	}
+	return n
+}
+
+func TestNotifyDeliversThroughEnabledChannels(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture()
+
+	preferences := user.DefaultNotificationPreferences()
+	preferences.Email = false
+	recipient := f.registerUser(t, preferences)
+
+	if err := f.service.Notify(ctx, newPingInvite(t, recipient.ID, "event-1")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(f.inApp.delivered) != 1 || len(f.push.delivered) != 1 || len(f.email.delivered) != 0 {
+		t.Errorf("delivered in_app=%d push=%d email=%d, want 1 1 0", len(f.inApp.delivered), len(f.push.delivered), len(f.email.delivered))
+	}
+
+	// 同一事件重複派送時不會重複通知
+	if err := f.service.Notify(ctx, newPingInvite(t, recipient.ID, "event-1")); err != nil {
+		t.Fatalf("Notify() duplicate error = %v", err)
+	}
+	if unread, _ := f.service.CountUnread(ctx, recipient.ID); unread != 1 {
+		t.Errorf("CountUnread() = %d, want 1", unread)
+	}
+	if len(f.inApp.delivered) != 1 {
+		t.Errorf("duplicate notification was delivered again")
+	}
+}
+
+func TestNotifySkipsDisabledTypes(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture()
+
+	preferences := user.DefaultNotificationPreferences()
+	preferences.PingInvites = false
+	recipient := f.registerUser(t, preferences)
+
+	if err := f.service.Notify(ctx, newPingInvite(t, recipient.ID, "event-1")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if unread, _ := f.service.CountUnread(ctx, recipient.ID); unread != 0 {
+		t.Errorf("CountUnread() = %d, want 0", unread)
+	}
+	if len(f.inApp.delivered)+len(f.email.delivered)+len(f.push.delivered) != 0 {
+		t.Error("disabled notification type was delivered")
+	}
+}
+
+func TestNotifyHoldsEmailAndPushDuringQuietHours(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture()
+
+	// 以目前時間前後一小時作為勿擾時段，確保通知落在時段內
+	now := time.Now().UTC()
+	preferences := user.DefaultNotificationPreferences()
+	preferences.QuietHours = user.QuietHours{
+		Enabled:  true,
+		Start:    now.Add(-time.Hour).Format("15:04"),
+		End:      now.Add(time.Hour).Format("15:04"),
+		TimeZone: "UTC",
+	}
+	recipient := f.registerUser(t, preferences)
+
+	if err := f.service.Notify(ctx, newPingInvite(t, recipient.ID, "event-1")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if unread, _ := f.service.CountUnread(ctx, recipient.ID); unread != 1 {
+		t.Errorf("CountUnread() = %d, want 1 (inbox is not affected by quiet hours)", unread)
+	}
+	if len(f.inApp.delivered) != 1 || len(f.email.delivered) != 0 || len(f.push.delivered) != 0 {
+		t.Errorf("delivered in_app=%d email=%d push=%d, want 1 0 0", len(f.inApp.delivered), len(f.email.delivered), len(f.push.delivered))
+	}
+}
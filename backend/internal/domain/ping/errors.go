@@ -0,0 +1,17 @@
+package ping
+
+import "github.com/chun-wei0413/pingnom/internal/domain/shared"
+
+// 約飯邀請專屬的錯誤，其餘共用錯誤 (例如 shared.ErrPingNotFound) 定義在 shared
+var (
+	ErrSelfInvite     = shared.NewDomainError(shared.KindInvalidInput, "PING_SELF_INVITE", "cannot invite yourself to a ping")
+	ErrNotInvited     = shared.NewDomainError(shared.KindForbidden, "PING_INVITEE_REQUIRED", "user is not invited to this ping")
+	ErrNotPingCreator = shared.ErrPermissionDenied.WithMessage("only the ping creator can perform this action")
+	ErrPingNotActive  = shared.NewDomainError(shared.KindConflict, "PING_NOT_ACTIVE", "ping is not active")
+
+	// 重複 ping 系列
+	ErrInvalidRecurrence = shared.NewDomainError(shared.KindInvalidInput, "INVALID_RECURRENCE_RULE", "invalid recurrence rule")
+	ErrSeriesNotFound    = shared.NewDomainError(shared.KindNotFound, "PING_SERIES_NOT_FOUND", "ping series not found")
+	ErrSeriesNotActive   = shared.NewDomainError(shared.KindConflict, "PING_SERIES_NOT_ACTIVE", "ping series is not active")
+	ErrNotOccurrence     = shared.NewDomainError(shared.KindConflict, "PING_NOT_RECURRING", "ping is not an occurrence of a recurring series")
+)
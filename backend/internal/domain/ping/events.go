@@ -0,0 +1,66 @@
+package ping
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Ping 聚合的領域事件名稱
+const (
+	EventPingCreated   = "ping.created"
+	EventPingResponded = "ping.responded"
+	EventPingCancelled = "ping.cancelled"
+	EventPingCompleted = "ping.completed"
+	EventPingExpired   = "ping.expired"
+)
+
+// PingCreated 發起人建立了新的 ping
+type PingCreated struct {
+	PingID      shared.ID       `json:"pingId"`
+	CreatedBy   shared.UserID   `json:"createdBy"`
+	Title       string          `json:"title"`
+	PingType    PingType        `json:"pingType"`
+	ScheduledAt time.Time       `json:"scheduledAt"`
+	Invitees    []shared.UserID `json:"invitees"`
+	SeriesID    *shared.ID      `json:"seriesId,omitempty"` // 由重複系列建立時為系列 ID
+}
+
+func (e PingCreated) EventName() string   { return EventPingCreated }
+func (e PingCreated) AggregateID() string { return e.PingID.String() }
+
+// PingResponded 受邀者回覆了 ping，附上回覆後的統計
+type PingResponded struct {
+	PingID        shared.ID       `json:"pingId"`
+	CreatedBy     shared.UserID   `json:"createdBy"`
+	Invitees      []shared.UserID `json:"invitees"`
+	UserID        shared.UserID   `json:"userId"`
+	Status        ResponseStatus  `json:"status"`
+	Message       string          `json:"message,omitempty"`
+	AcceptedCount int             `json:"acceptedCount"`
+	PendingCount  int             `json:"pendingCount"`
+}
+
+func (e PingResponded) EventName() string   { return EventPingResponded }
+func (e PingResponded) AggregateID() string { return e.PingID.String() }
+
+// PingClosed 是 ping 結束 (取消、完成或過期) 時的事件內容
+type PingClosed struct {
+	PingID    shared.ID       `json:"pingId"`
+	CreatedBy shared.UserID   `json:"createdBy"`
+	Invitees  []shared.UserID `json:"invitees"`
+	Status    PingStatus      `json:"status"`
+}
+
+func (e PingClosed) EventName() string {
+	switch e.Status {
+	case PingStatusCancelled:
+		return EventPingCancelled
+	case PingStatusCompleted:
+		return EventPingCompleted
+	default:
+		return EventPingExpired
+	}
+}
+
+func (e PingClosed) AggregateID() string { return e.PingID.String() }
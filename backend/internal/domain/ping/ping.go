@@ -47,6 +47,8 @@ type PingResponse struct {
 
 // Ping represents a meal invitation aggregate root
 type Ping struct {
+	shared.EventRecorder
+
 	id          shared.ID
 	createdBy   shared.UserID
 	title       string
@@ -57,6 +59,8 @@ type Ping struct {
 	location    *shared.Location
 	responses   []PingResponse
 	invitees    []shared.UserID
+	sequence    int // 時間、地點變更或取消時遞增，對應 iCalendar 的 SEQUENCE
+	occurrence  *Occurrence // 重複系列的其中一次，一次性的 ping 為 nil
 	createdAt   time.Time
 	updatedAt   time.Time
 }
@@ -69,14 +73,30 @@ func NewPing(
 	pingType PingType,
 	scheduledAt time.Time,
 	invitees []shared.UserID,
+) (*Ping, error) {
+	p, err := newPing(createdBy, title, description, pingType, scheduledAt, invitees)
+	if err != nil {
+		return nil, err
+	}
+	p.recordCreated()
+	return p, nil
+}
+
+func newPing(
+	createdBy shared.UserID,
+	title string,
+	description string,
+	pingType PingType,
+	scheduledAt time.Time,
+	invitees []shared.UserID,
 ) (*Ping, error) {
 	// Validation
 	if createdBy.IsEmpty() {
-		return nil, shared.ErrInvalidInput
+		return nil, shared.ErrInvalidInput.WithMessage("creator cannot be empty")
 	}
 	
 	if title == "" {
-		return nil, shared.ErrInvalidInput
+		return nil, shared.ErrInvalidInput.WithMessage("title cannot be empty")
 	}
 	
 	if scheduledAt.Before(time.Now()) {
@@ -84,13 +104,13 @@ func NewPing(
 	}
 	
 	if len(invitees) == 0 {
-		return nil, shared.ErrInvalidInput
+		return nil, shared.ErrInvalidInput.WithMessage("at least one invitee is required")
 	}
 
 	// Check for self-invitation
 	for _, invitee := range invitees {
 		if invitee == createdBy {
-			return nil, shared.ErrSelfFriendRequest // Reuse similar error
+			return nil, ErrSelfInvite
 		}
 	}
 
@@ -124,6 +144,58 @@ func NewPing(
 	}, nil
 }
 
+func (p *Ping) recordCreated() {
+	event := PingCreated{
+		PingID:      p.id,
+		CreatedBy:   p.createdBy,
+		Title:       p.title,
+		PingType:    p.pingType,
+		ScheduledAt: p.scheduledAt,
+		Invitees:    p.invitees,
+	}
+	if p.occurrence != nil {
+		seriesID := p.occurrence.SeriesID
+		event.SeriesID = &seriesID
+	}
+	p.Record(event)
+}
+
+// ReconstructPing rebuilds a ping from persisted state without re-running
+// creation invariants (e.g. scheduledAt being in the future)
+func ReconstructPing(
+	id shared.ID,
+	createdBy shared.UserID,
+	title string,
+	description string,
+	pingType PingType,
+	status PingStatus,
+	scheduledAt time.Time,
+	location *shared.Location,
+	responses []PingResponse,
+	invitees []shared.UserID,
+	sequence int,
+	occurrence *Occurrence,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Ping {
+	return &Ping{
+		id:          id,
+		createdBy:   createdBy,
+		title:       title,
+		description: description,
+		pingType:    pingType,
+		status:      status,
+		scheduledAt: scheduledAt,
+		location:    location,
+		responses:   responses,
+		invitees:    invitees,
+		sequence:    sequence,
+		occurrence:  occurrence,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
 // Getters
 func (p *Ping) ID() shared.ID { return p.id }
 func (p *Ping) CreatedBy() shared.UserID { return p.createdBy }
@@ -135,6 +207,8 @@ func (p *Ping) ScheduledAt() time.Time { return p.scheduledAt }
 func (p *Ping) Location() *shared.Location { return p.location }
 func (p *Ping) Responses() []PingResponse { return p.responses }
 func (p *Ping) Invitees() []shared.UserID { return p.invitees }
+func (p *Ping) Sequence() int { return p.sequence }
+func (p *Ping) Occurrence() *Occurrence { return p.occurrence }
 func (p *Ping) CreatedAt() time.Time { return p.createdAt }
 func (p *Ping) UpdatedAt() time.Time { return p.updatedAt }
 
@@ -162,11 +236,21 @@ func (p *Ping) RespondToPing(userID shared.UserID, status ResponseStatus, messag
 			p.responses[i].RespondedAt = &now
 			p.updatedAt = now
 			
+			p.Record(PingResponded{
+				PingID:        p.id,
+				CreatedBy:     p.createdBy,
+				Invitees:      p.invitees,
+				UserID:        userID,
+				Status:        status,
+				Message:       message,
+				AcceptedCount: p.GetAcceptedCount(),
+				PendingCount:  p.GetPendingCount(),
+			})
 			return nil
 		}
 	}
 
-	return shared.ErrEntityNotFound
+	return ErrNotInvited
 }
 
 // Cancel cancels the ping
@@ -175,25 +259,99 @@ func (p *Ping) Cancel() error {
 		return shared.ErrPingCancelled
 	}
 	
-	p.status = PingStatusCancelled
-	p.updatedAt = time.Now()
+	p.sequence++
+	p.close(PingStatusCancelled)
 	return nil
 }
 
 // Complete marks the ping as completed
 func (p *Ping) Complete() error {
 	if p.status != PingStatusActive {
-		return shared.ErrInvalidInput
+		return ErrPingNotActive
 	}
 	
-	p.status = PingStatusCompleted
+	p.close(PingStatusCompleted)
+	return nil
+}
+
+// Expire marks an active ping whose scheduled time has passed as expired
+func (p *Ping) Expire() error {
+	if !p.IsExpired() {
+		return ErrPingNotActive.WithMessage("ping has not passed its scheduled time")
+	}
+	
+	p.close(PingStatusExpired)
+	return nil
+}
+
+// close moves the ping into a terminal status and records the matching event
+func (p *Ping) close(status PingStatus) {
+	p.status = status
+	p.updatedAt = time.Now()
+	p.Record(PingClosed{
+		PingID:    p.id,
+		CreatedBy: p.createdBy,
+		Invitees:  p.invitees,
+		Status:    status,
+	})
+}
+
+// Skip cancels a single occurrence of a recurring series; the rest of the series is unaffected
+func (p *Ping) Skip() error {
+	if p.occurrence == nil {
+		return ErrNotOccurrence
+	}
+	return p.Cancel()
+}
+
+// EditOccurrence changes a single occurrence of a recurring series
+// 修改過的發生之後不會再被系列的變更覆寫
+func (p *Ping) EditOccurrence(title, description string, scheduledAt time.Time, location *shared.Location) error {
+	if p.occurrence == nil {
+		return ErrNotOccurrence
+	}
+	if p.status != PingStatusActive {
+		return ErrPingNotActive
+	}
+	if title == "" {
+		return shared.ErrInvalidInput.WithMessage("title cannot be empty")
+	}
+	if !scheduledAt.Equal(p.scheduledAt) && scheduledAt.Before(time.Now()) {
+		return shared.ErrInvalidPingTime
+	}
+	
+	if !scheduledAt.Equal(p.scheduledAt) || !sameLocation(location, p.location) {
+		p.sequence++
+	}
+	p.title = title
+	p.description = description
+	p.scheduledAt = scheduledAt.In(p.occurrence.OccurrenceAt.Location())
+	p.location = location
+	p.occurrence.Modified = true
 	p.updatedAt = time.Now()
 	return nil
 }
 
+// syncWithSeries applies whole-series changes to an occurrence that has not been edited on its own
+func (p *Ping) syncWithSeries(s *Series) {
+	if p.occurrence == nil || p.occurrence.Modified || p.status != PingStatusActive {
+		return
+	}
+	
+	if !sameLocation(s.location, p.location) {
+		p.sequence++
+	}
+	p.title = s.title
+	p.description = s.description
+	p.pingType = s.pingType
+	p.location = s.location
+	p.updatedAt = time.Now()
+}
+
 // SetLocation updates the ping location
 func (p *Ping) SetLocation(location *shared.Location) {
 	p.location = location
+	p.sequence++
 	p.updatedAt = time.Now()
 }
 
@@ -219,6 +377,19 @@ func (p *Ping) GetPendingCount() int {
 	return count
 }
 
+// IsParticipant checks if the user created or was invited to the ping
+func (p *Ping) IsParticipant(userID shared.UserID) bool {
+	if p.createdBy.Equals(userID) {
+		return true
+	}
+	for _, invitee := range p.invitees {
+		if invitee.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsExpired checks if the ping has expired
 func (p *Ping) IsExpired() bool {
 	return time.Now().After(p.scheduledAt) && p.status == PingStatusActive
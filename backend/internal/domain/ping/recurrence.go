@@ -0,0 +1,369 @@
+package ping
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency 重複規則的週期 (對應 RRULE 的 FREQ)
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+const (
+	MaxRecurrenceInterval = 99
+	MaxRecurrenceCount    = 500
+	// maxRecurrencePeriods 展開時最多檢查的週期數，避免 BYDAY 永遠不會符合時無限迴圈
+	maxRecurrencePeriods = 10000
+)
+
+// WeekdayRule 是 BYDAY 的一個項目，例如 MO、1MO (第一個週一) 或 -1FR (最後一個週五)
+// Ordinal 只用於 MONTHLY，0 代表該月每個符合的星期
+type WeekdayRule struct {
+	Ordinal int
+	Day     time.Weekday
+}
+
+// RecurrenceRule 是 RFC 5545 RRULE 的子集：FREQ (DAILY/WEEKLY/MONTHLY)、INTERVAL、BYDAY、COUNT、UNTIL
+// 展開時以系列的起始時間為基準，每次發生都沿用起始時間在系列時區的時刻
+type RecurrenceRule struct {
+	Frequency Frequency
+	Interval  int
+	ByDay     []WeekdayRule
+	Count     int        // 0 代表不限次數
+	Until     *time.Time // 最後一次發生的時間上限 (含)，nil 代表不限
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRecurrenceRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=TU;COUNT=10"
+func ParseRecurrenceRule(value string) (RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage("recurrence rule cannot be empty")
+	}
+
+	rule := RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" {
+			return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("invalid recurrence rule part %q", part))
+		}
+		if seen[key] {
+			return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("duplicate recurrence rule part %s", key))
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			rule.Frequency = Frequency(val)
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil {
+				return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage("INTERVAL must be a number")
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, err := parseWeekdayRule(code)
+				if err != nil {
+					return RecurrenceRule{}, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("COUNT must be between 1 and %d", MaxRecurrenceCount))
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return RecurrenceRule{}, err
+			}
+			rule.Until = &until
+		default:
+			return RecurrenceRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("unsupported recurrence rule part %s", key))
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return rule, nil
+}
+
+func parseWeekdayRule(code string) (WeekdayRule, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return WeekdayRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("invalid BYDAY value %q", code))
+	}
+
+	day, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return WeekdayRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("invalid BYDAY value %q", code))
+	}
+
+	ordinal := 0
+	if prefix := code[:len(code)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayRule{}, ErrInvalidRecurrence.WithMessage(fmt.Sprintf("invalid BYDAY value %q", code))
+		}
+		ordinal = n
+	}
+	return WeekdayRule{Ordinal: ordinal, Day: day}, nil
+}
+
+// parseUntil 接受 UTC 日期時間 (20261231T235959Z) 或日期 (20261231，視為當天結束)
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, ErrInvalidRecurrence.WithMessage("UNTIL must be a UTC date-time (e.g. 20261231T235959Z) or a date (e.g. 20261231)")
+}
+
+// Validate checks the rule stays within the supported RRULE subset
+func (r RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	case "":
+		return ErrInvalidRecurrence.WithMessage("FREQ is required")
+	default:
+		return ErrInvalidRecurrence.WithMessage(fmt.Sprintf("unsupported FREQ %s, use DAILY, WEEKLY or MONTHLY", r.Frequency))
+	}
+
+	if r.Interval < 1 || r.Interval > MaxRecurrenceInterval {
+		return ErrInvalidRecurrence.WithMessage(fmt.Sprintf("INTERVAL must be between 1 and %d", MaxRecurrenceInterval))
+	}
+	if r.Count < 0 || r.Count > MaxRecurrenceCount {
+		return ErrInvalidRecurrence.WithMessage(fmt.Sprintf("COUNT must be between 1 and %d", MaxRecurrenceCount))
+	}
+	if r.Count > 0 && r.Until != nil {
+		return ErrInvalidRecurrence.WithMessage("COUNT and UNTIL cannot be used together")
+	}
+
+	seen := make(map[WeekdayRule]bool)
+	for _, day := range r.ByDay {
+		if day.Ordinal != 0 && r.Frequency != FrequencyMonthly {
+			return ErrInvalidRecurrence.WithMessage("BYDAY ordinals such as 1MO are only supported with FREQ=MONTHLY")
+		}
+		if seen[day] {
+			return ErrInvalidRecurrence.WithMessage("BYDAY contains duplicate days")
+		}
+		seen[day] = true
+	}
+	return nil
+}
+
+// String formats the rule as an RRULE value
+func (r RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (d WeekdayRule) String() string {
+	code := strings.ToUpper(d.Day.String()[:2])
+	if d.Ordinal != 0 {
+		return strconv.Itoa(d.Ordinal) + code
+	}
+	return code
+}
+
+// Between returns the occurrences of the rule starting at start that fall in [from, to)
+func (r RecurrenceRule) Between(start, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	r.expand(start, func(t time.Time) bool {
+		if !t.Before(to) {
+			return false
+		}
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// Next returns the first occurrence strictly after the given time
+func (r RecurrenceRule) Next(start, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.expand(start, func(t time.Time) bool {
+		if t.After(after) {
+			next, found = t, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Includes checks if t is one of the rule's occurrences
+func (r RecurrenceRule) Includes(start, t time.Time) bool {
+	next, ok := r.Next(start, t.Add(-time.Nanosecond))
+	return ok && next.Equal(t)
+}
+
+// expand 依時間順序產生每次發生的時間，直到 yield 回傳 false、達到 COUNT 或超過 UNTIL
+// 每次發生都在 start 所在時區的同一時刻，日光節約時間轉換時由 time.Date 正規化
+// 依 RFC 5545，start (DTSTART) 一定是第一次發生並計入 COUNT，即使不符合 BYDAY
+func (r RecurrenceRule) expand(start time.Time, yield func(time.Time) bool) {
+	if r.Until != nil && start.After(*r.Until) {
+		return
+	}
+	if !yield(start) {
+		return
+	}
+	count := 1
+	if r.Count > 0 && count >= r.Count {
+		return
+	}
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, t := range r.periodOccurrences(start, period) {
+			if !t.After(start) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+			count++
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// periodOccurrences 回傳第 period 個週期 (日、週或月) 內依時間排序的發生時間
+func (r RecurrenceRule) periodOccurrences(start time.Time, period int) []time.Time {
+	hour, minute, second := start.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, start.Location())
+	}
+	step := period * r.Interval
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		t := at(start.Year(), start.Month(), start.Day()+step)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(t.Weekday()) {
+			return nil
+		}
+		return []time.Time{t}
+
+	case FrequencyWeekly:
+		// 週一為一週的開始 (RRULE 預設的 WKST=MO)
+		offset := (int(start.Weekday()) + 6) % 7
+		monday := at(start.Year(), start.Month(), start.Day()-offset+7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(monday.Year(), monday.Month(), monday.Day()+offset)}
+		}
+		var occurrences []time.Time
+		for i := 0; i < 7; i++ {
+			t := at(monday.Year(), monday.Month(), monday.Day()+i)
+			if r.matchesWeekday(t.Weekday()) {
+				occurrences = append(occurrences, t)
+			}
+		}
+		return occurrences
+
+	case FrequencyMonthly:
+		first := at(start.Year(), start.Month()+time.Month(step), 1)
+		if len(r.ByDay) == 0 {
+			// 該月沒有這一天時 (例如 31 日) 跳過該月
+			t := at(first.Year(), first.Month(), start.Day())
+			if t.Month() != first.Month() {
+				return nil
+			}
+			return []time.Time{t}
+		}
+		return r.monthlyByDay(first, at)
+	}
+	return nil
+}
+
+func (r RecurrenceRule) monthlyByDay(first time.Time, at func(int, time.Month, int) time.Time) []time.Time {
+	daysInMonth := at(first.Year(), first.Month()+1, 0).Day()
+	seen := make(map[int]bool)
+	var occurrences []time.Time
+	for _, rule := range r.ByDay {
+		var days []int
+		for day := 1; day <= daysInMonth; day++ {
+			if at(first.Year(), first.Month(), day).Weekday() == rule.Day {
+				days = append(days, day)
+			}
+		}
+
+		switch {
+		case rule.Ordinal == 0:
+		case rule.Ordinal > 0 && rule.Ordinal <= len(days):
+			days = days[rule.Ordinal-1 : rule.Ordinal]
+		case rule.Ordinal < 0 && -rule.Ordinal <= len(days):
+			days = days[len(days)+rule.Ordinal : len(days)+rule.Ordinal+1]
+		default:
+			days = nil
+		}
+
+		for _, day := range days {
+			if !seen[day] {
+				seen[day] = true
+				occurrences = append(occurrences, at(first.Year(), first.Month(), day))
+			}
+		}
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].Before(occurrences[j])
+	})
+	return occurrences
+}
+
+func (r RecurrenceRule) matchesWeekday(day time.Weekday) bool {
+	for _, rule := range r.ByDay {
+		if rule.Day == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package ping
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q) error = %v", name, err)
+	}
+	return location
+}
+
+func mustRule(t *testing.T, value string) RecurrenceRule {
+	t.Helper()
+	rule, err := ParseRecurrenceRule(value)
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule(%q) error = %v", value, err)
+	}
+	return rule
+}
+
+func formatTimes(times []time.Time) []string {
+	formatted := make([]string, len(times))
+	for i, t := range times {
+		formatted[i] = t.Format("2006-01-02 Mon 15:04 MST")
+	}
+	return formatted
+}
+
+func assertTimes(t *testing.T, got []time.Time, want ...string) {
+	t.Helper()
+	formatted := formatTimes(got)
+	if len(formatted) != len(want) {
+		t.Fatalf("occurrences = %v, want %v", formatted, want)
+	}
+	for i := range want {
+		if formatted[i] != want[i] {
+			t.Fatalf("occurrences = %v, want %v", formatted, want)
+		}
+	}
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "FREQ=WEEKLY", want: "FREQ=WEEKLY"},
+		{value: "RRULE:freq=weekly;byday=tu,th;count=10", want: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10"},
+		{value: "FREQ=DAILY;INTERVAL=2;UNTIL=20301231T100000Z", want: "FREQ=DAILY;INTERVAL=2;UNTIL=20301231T100000Z"},
+		{value: "FREQ=MONTHLY;BYDAY=1MO,-1FR", want: "FREQ=MONTHLY;BYDAY=1MO,-1FR"},
+		{value: "FREQ=MONTHLY;UNTIL=20301231", want: "FREQ=MONTHLY;UNTIL=20301231T235959Z"},
+		{value: "", wantErr: true},
+		{value: "BYDAY=MO", wantErr: true},
+		{value: "FREQ=YEARLY", wantErr: true},
+		{value: "FREQ=WEEKLY;BYHOUR=12", wantErr: true},
+		{value: "FREQ=WEEKLY;INTERVAL=0", wantErr: true},
+		{value: "FREQ=WEEKLY;COUNT=0", wantErr: true},
+		{value: "FREQ=WEEKLY;COUNT=3;UNTIL=20301231", wantErr: true},
+		{value: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{value: "FREQ=WEEKLY;BYDAY=MO,MO", wantErr: true},
+		{value: "FREQ=WEEKLY;FREQ=DAILY", wantErr: true},
+		{value: "FREQ=WEEKLY;UNTIL=tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRecurrence) {
+					t.Fatalf("ParseRecurrenceRule() error = %v, want %v", err, ErrInvalidRecurrence)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule() error = %v", err)
+			}
+			if rule.String() != tt.want {
+				t.Errorf("String() = %q, want %q", rule.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleExpansion(t *testing.T) {
+	taipei := mustLocation(t, "Asia/Taipei")
+	// 2030-01-07 是週一
+	monday := time.Date(2030, time.January, 7, 12, 0, 0, 0, taipei)
+	until := monday.AddDate(1, 0, 0)
+
+	t.Run("weekly by day with count", func(t *testing.T) {
+		rule := mustRule(t, "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=5")
+		assertTimes(t, rule.Between(monday, monday, until),
+			"2030-01-07 Mon 12:00 CST",
+			"2030-01-10 Thu 12:00 CST",
+			"2030-01-14 Mon 12:00 CST",
+			"2030-01-17 Thu 12:00 CST",
+			"2030-01-21 Mon 12:00 CST",
+		)
+	})
+
+	t.Run("every other week skips days before start", func(t *testing.T) {
+		// 起點在週三，同一週的週一不算
+		wednesday := monday.AddDate(0, 0, 2)
+		rule := mustRule(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=4")
+		assertTimes(t, rule.Between(wednesday, wednesday, until),
+			"2030-01-09 Wed 12:00 CST",
+			"2030-01-21 Mon 12:00 CST",
+			"2030-01-23 Wed 12:00 CST",
+			"2030-02-04 Mon 12:00 CST",
+		)
+	})
+
+	t.Run("start not matching by day counts toward count", func(t *testing.T) {
+		// 起點在週三但規則只有週一，起點仍是第一次發生
+		wednesday := monday.AddDate(0, 0, 2)
+		rule := mustRule(t, "FREQ=WEEKLY;BYDAY=MO;COUNT=3")
+		assertTimes(t, rule.Between(wednesday, wednesday, until),
+			"2030-01-09 Wed 12:00 CST",
+			"2030-01-14 Mon 12:00 CST",
+			"2030-01-21 Mon 12:00 CST",
+		)
+		if !rule.Includes(wednesday, wednesday) {
+			t.Error("Includes() should report the start as an occurrence")
+		}
+	})
+
+	t.Run("daily on weekdays until", func(t *testing.T) {
+		rule := mustRule(t, "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20300114T040000Z")
+		got := rule.Between(monday.AddDate(0, 0, 3), time.Time{}, until)
+		assertTimes(t, got,
+			"2030-01-10 Thu 12:00 CST",
+			"2030-01-11 Fri 12:00 CST",
+			"2030-01-14 Mon 12:00 CST",
+		)
+	})
+
+	t.Run("monthly on day skips short months", func(t *testing.T) {
+		start := time.Date(2030, time.January, 31, 19, 0, 0, 0, taipei)
+		rule := mustRule(t, "FREQ=MONTHLY;COUNT=3")
+		assertTimes(t, rule.Between(start, start, until),
+			"2030-01-31 Thu 19:00 CST",
+			"2030-03-31 Sun 19:00 CST",
+			"2030-05-31 Fri 19:00 CST",
+		)
+	})
+
+	t.Run("monthly first monday and last friday", func(t *testing.T) {
+		rule := mustRule(t, "FREQ=MONTHLY;BYDAY=1MO,-1FR;COUNT=4")
+		assertTimes(t, rule.Between(monday, monday, until),
+			"2030-01-07 Mon 12:00 CST",
+			"2030-01-25 Fri 12:00 CST",
+			"2030-02-04 Mon 12:00 CST",
+			"2030-02-22 Fri 12:00 CST",
+		)
+	})
+
+	t.Run("keeps local time across daylight saving", func(t *testing.T) {
+		newYork := mustLocation(t, "America/New_York")
+		start := time.Date(2030, time.March, 4, 12, 0, 0, 0, newYork)
+		rule := mustRule(t, "FREQ=WEEKLY;COUNT=3")
+		assertTimes(t, rule.Between(start, start, start.AddDate(0, 1, 0)),
+			"2030-03-04 Mon 12:00 EST",
+			"2030-03-11 Mon 12:00 EDT",
+			"2030-03-18 Mon 12:00 EDT",
+		)
+	})
+
+	t.Run("window and next", func(t *testing.T) {
+		rule := mustRule(t, "FREQ=WEEKLY")
+		assertTimes(t, rule.Between(monday, monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 21)),
+			"2030-01-14 Mon 12:00 CST",
+			"2030-01-21 Mon 12:00 CST",
+		)
+
+		next, ok := rule.Next(monday, monday)
+		if !ok || !next.Equal(monday.AddDate(0, 0, 7)) {
+			t.Errorf("Next() = %v, %v, want %v", next, ok, monday.AddDate(0, 0, 7))
+		}
+		if !rule.Includes(monday, monday.AddDate(0, 0, 14)) || rule.Includes(monday, monday.AddDate(0, 0, 15)) {
+			t.Error("Includes() did not match the weekly occurrences")
+		}
+
+		limited := mustRule(t, "FREQ=WEEKLY;COUNT=2")
+		if _, ok := limited.Next(monday, monday.AddDate(0, 0, 7)); ok {
+			t.Error("Next() after the last occurrence should report none")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package ping
 
 import (
 	"context"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -26,9 +27,36 @@ type Repository interface {
 	// GetByInvitee retrieves pings where a user is invited
 	GetByInvitee(ctx context.Context, inviteeID shared.UserID, limit, offset int) ([]*Ping, error)
 	
-	// GetActivePings retrieves all active pings for a user (created by or invited to)
-	GetActivePings(ctx context.Context, userID shared.UserID, limit, offset int) ([]*Ping, error)
+	// GetActivePings retrieves all active pings for a user (created by or invited to), excluding pings created by excludeCreators before paging
+	GetActivePings(ctx context.Context, userID shared.UserID, excludeCreators []shared.UserID, limit, offset int) ([]*Ping, error)
 	
 	// GetPingsByStatus retrieves pings by status
 	GetPingsByStatus(ctx context.Context, status PingStatus, limit, offset int) ([]*Ping, error)
-}
\ No newline at end of file
+	
+	// GetScheduledBetween retrieves pings with the given status scheduled in [from, to), ordered by scheduled time
+	GetScheduledBetween(ctx context.Context, status PingStatus, from, to time.Time) ([]*Ping, error)
+	
+	// GetUpcomingForUser retrieves pings of any status created by or inviting a user scheduled at or after from, ordered by scheduled time
+	GetUpcomingForUser(ctx context.Context, userID shared.UserID, from time.Time) ([]*Ping, error)
+	
+	// GetBySeries retrieves the occurrences of a recurring series whose original time is at or after from, ordered by that time
+	GetBySeries(ctx context.Context, seriesID shared.ID, from time.Time) ([]*Ping, error)
+}
+
+// SeriesRepository defines the interface for recurring ping series persistence
+type SeriesRepository interface {
+	// Create stores a new series
+	Create(ctx context.Context, series *Series) error
+	
+	// GetByID retrieves a series by ID
+	GetByID(ctx context.Context, id shared.ID) (*Series, error)
+	
+	// Update updates an existing series
+	Update(ctx context.Context, series *Series) error
+	
+	// GetForUser retrieves series that have not been cancelled created by or inviting a user, ordered by start time
+	GetForUser(ctx context.Context, userID shared.UserID) ([]*Series, error)
+	
+	// GetDueForMaterialization retrieves active series whose occurrences have only been materialized up to before until
+	GetDueForMaterialization(ctx context.Context, until time.Time) ([]*Series, error)
+}
@@ -0,0 +1,303 @@
+package ping
+
+import (
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// DefaultSeriesTimeZone 未指定時區時，重複規則以此時區展開
+const DefaultSeriesTimeZone = "Asia/Taipei"
+
+// SeriesStatus represents the status of a recurring ping series
+type SeriesStatus string
+
+const (
+	SeriesStatusActive    SeriesStatus = "active"
+	SeriesStatusEnded     SeriesStatus = "ended" // 依 COUNT / UNTIL 已沒有之後的發生
+	SeriesStatusCancelled SeriesStatus = "cancelled"
+)
+
+// Occurrence 記錄 ping 屬於哪個重複系列的哪一次發生
+type Occurrence struct {
+	SeriesID shared.ID `json:"seriesId"`
+	// OccurrenceAt 依規則原本的時間，單次改期後不變，對應 iCalendar 的 RECURRENCE-ID
+	OccurrenceAt time.Time `json:"occurrenceAt"`
+	// Modified 單獨修改過的發生不會再被系列的變更覆寫
+	Modified bool `json:"modified"`
+}
+
+// Series 是重複的 ping (例如每週的團隊午餐)
+// 系列只保存規則與內容；排程器把即將到來的每次發生建立成獨立的 Ping，
+// 受邀者對每次發生分別回覆，也可以單獨略過或修改某一次
+type Series struct {
+	id          shared.ID
+	createdBy   shared.UserID
+	title       string
+	description string
+	pingType    PingType
+	location    *shared.Location
+	invitees    []shared.UserID
+	startAt     time.Time // 規則展開的起點 (DTSTART)，每次發生沿用其在系列時區的時刻
+	timeZone    string
+	rule        RecurrenceRule
+	status      SeriesStatus
+	// materializedUntil 此時間之前的發生都已建立成 Ping
+	materializedUntil time.Time
+	sequence          int
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// NewSeries creates a recurring ping series
+func NewSeries(
+	createdBy shared.UserID,
+	title string,
+	description string,
+	pingType PingType,
+	startAt time.Time,
+	timeZone string,
+	rule RecurrenceRule,
+	invitees []shared.UserID,
+) (*Series, error) {
+	if createdBy.IsEmpty() {
+		return nil, shared.ErrInvalidInput.WithMessage("creator cannot be empty")
+	}
+	if title == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("title cannot be empty")
+	}
+	if startAt.Before(time.Now()) {
+		return nil, shared.ErrInvalidPingTime
+	}
+	if len(invitees) == 0 {
+		return nil, shared.ErrInvalidInput.WithMessage("at least one invitee is required")
+	}
+	for _, invitee := range invitees {
+		if invitee == createdBy {
+			return nil, ErrSelfInvite
+		}
+	}
+
+	if timeZone == "" {
+		timeZone = DefaultSeriesTimeZone
+	}
+	startAt, err := localStart(startAt, timeZone, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Series{
+		id:          shared.NewID(),
+		createdBy:   createdBy,
+		title:       title,
+		description: description,
+		pingType:    pingType,
+		invitees:    invitees,
+		startAt:     startAt,
+		timeZone:    timeZone,
+		rule:        rule,
+		status:      SeriesStatusActive,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructSeries rebuilds a series from persisted state
+func ReconstructSeries(
+	id shared.ID,
+	createdBy shared.UserID,
+	title string,
+	description string,
+	pingType PingType,
+	location *shared.Location,
+	invitees []shared.UserID,
+	startAt time.Time,
+	timeZone string,
+	rule RecurrenceRule,
+	status SeriesStatus,
+	materializedUntil time.Time,
+	sequence int,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Series {
+	// 資料庫取回的時間為 UTC，換回系列時區才能依當地時刻展開
+	if loc, err := time.LoadLocation(timeZone); err == nil {
+		startAt = startAt.In(loc)
+	}
+
+	return &Series{
+		id:                id,
+		createdBy:         createdBy,
+		title:             title,
+		description:       description,
+		pingType:          pingType,
+		location:          location,
+		invitees:          invitees,
+		startAt:           startAt,
+		timeZone:          timeZone,
+		rule:              rule,
+		status:            status,
+		materializedUntil: materializedUntil,
+		sequence:          sequence,
+		createdAt:         createdAt,
+		updatedAt:         updatedAt,
+	}
+}
+
+// Getters
+func (s *Series) ID() shared.ID                { return s.id }
+func (s *Series) CreatedBy() shared.UserID     { return s.createdBy }
+func (s *Series) Title() string                { return s.title }
+func (s *Series) Description() string          { return s.description }
+func (s *Series) PingType() PingType           { return s.pingType }
+func (s *Series) Location() *shared.Location   { return s.location }
+func (s *Series) Invitees() []shared.UserID    { return s.invitees }
+func (s *Series) StartAt() time.Time           { return s.startAt }
+func (s *Series) TimeZone() string             { return s.timeZone }
+func (s *Series) Rule() RecurrenceRule         { return s.rule }
+func (s *Series) Status() SeriesStatus         { return s.status }
+func (s *Series) MaterializedUntil() time.Time { return s.materializedUntil }
+func (s *Series) Sequence() int                { return s.sequence }
+func (s *Series) CreatedAt() time.Time         { return s.createdAt }
+func (s *Series) UpdatedAt() time.Time         { return s.updatedAt }
+
+// IsParticipant checks if the user created or is invited to the series
+func (s *Series) IsParticipant(userID shared.UserID) bool {
+	if s.createdBy.Equals(userID) {
+		return true
+	}
+	for _, invitee := range s.invitees {
+		if invitee.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOccurrence returns the first occurrence at or after the given time
+func (s *Series) NextOccurrence(from time.Time) (time.Time, bool) {
+	return s.rule.Next(s.startAt, from.Add(-time.Nanosecond))
+}
+
+// PendingOccurrences returns the occurrences before until that have not been materialized yet
+// 已經過去的發生不會補建
+func (s *Series) PendingOccurrences(now, until time.Time) []time.Time {
+	if s.status != SeriesStatusActive {
+		return nil
+	}
+
+	from := s.materializedUntil
+	if from.Before(now) {
+		from = now
+	}
+	return s.rule.Between(s.startAt, from, until)
+}
+
+// MarkMaterialized records that every occurrence before until has been created
+// 規則在 until 之後沒有發生時，系列結束
+func (s *Series) MarkMaterialized(until time.Time) {
+	if until.After(s.materializedUntil) {
+		s.materializedUntil = until
+	}
+	if _, ok := s.NextOccurrence(s.materializedUntil); !ok && s.status == SeriesStatusActive {
+		s.status = SeriesStatusEnded
+	}
+	s.updatedAt = time.Now()
+}
+
+// Update changes the whole series and reports whether its schedule changed
+// 時間或規則變更時，尚未發生的部分需要依新規則重新建立
+func (s *Series) Update(title, description string, location *shared.Location, startAt time.Time, timeZone string, rule RecurrenceRule) (bool, error) {
+	if s.status == SeriesStatusCancelled {
+		return false, ErrSeriesNotActive
+	}
+	if title == "" {
+		return false, shared.ErrInvalidInput.WithMessage("title cannot be empty")
+	}
+	if timeZone == "" {
+		timeZone = s.timeZone
+	}
+
+	startAt, err := localStart(startAt, timeZone, rule)
+	if err != nil {
+		return false, err
+	}
+	if !startAt.Equal(s.startAt) && startAt.Before(time.Now()) {
+		return false, shared.ErrInvalidPingTime
+	}
+
+	now := time.Now()
+	rescheduled := !startAt.Equal(s.startAt) || timeZone != s.timeZone || rule.String() != s.rule.String()
+	if rescheduled || !sameLocation(location, s.location) {
+		s.sequence++
+	}
+
+	s.title = title
+	s.description = description
+	s.location = location
+	if rescheduled {
+		s.startAt = startAt
+		s.timeZone = timeZone
+		s.rule = rule
+		s.materializedUntil = now
+		s.status = SeriesStatusActive
+	}
+	s.updatedAt = now
+
+	return rescheduled, nil
+}
+
+// Cancel stops the series; upcoming occurrences are cancelled by the service
+func (s *Series) Cancel() error {
+	if s.status == SeriesStatusCancelled {
+		return ErrSeriesNotActive
+	}
+
+	s.status = SeriesStatusCancelled
+	s.sequence++
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// NewOccurrence creates the ping for one occurrence of the series
+func NewOccurrence(s *Series, occurrenceAt time.Time, invitees []shared.UserID) (*Ping, error) {
+	p, err := newPing(s.createdBy, s.title, s.description, s.pingType, occurrenceAt, invitees)
+	if err != nil {
+		return nil, err
+	}
+
+	p.location = s.location
+	p.occurrence = &Occurrence{
+		SeriesID:     s.id,
+		OccurrenceAt: occurrenceAt,
+	}
+	p.recordCreated()
+	return p, nil
+}
+
+// localStart 驗證規則與時區，並把起點換到系列時區
+func localStart(startAt time.Time, timeZone string, rule RecurrenceRule) (time.Time, error) {
+	if err := rule.Validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, shared.ErrInvalidInput.WithMessage("invalid time zone")
+	}
+	// 每次發生只保留到秒，起點也一樣才不會漏掉第一次
+	startAt = startAt.In(loc).Truncate(time.Second)
+
+	if _, ok := rule.Next(startAt, startAt.Add(-time.Nanosecond)); !ok {
+		return time.Time{}, ErrInvalidRecurrence.WithMessage("recurrence rule has no occurrences")
+	}
+	return startAt, nil
+}
+
+func sameLocation(a, b *shared.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,283 @@
+package ping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// SeriesService manages recurring ping series and materializes their upcoming occurrences
+type SeriesService struct {
+	series  SeriesRepository
+	pings   Repository
+	invites InvitePolicy
+	horizon time.Duration
+}
+
+// NewSeriesService creates a series service; occurrences starting within horizon are created ahead of time
+func NewSeriesService(series SeriesRepository, pings Repository, invites InvitePolicy, horizon time.Duration) *SeriesService {
+	return &SeriesService{
+		series:  series,
+		pings:   pings,
+		invites: invites,
+		horizon: horizon,
+	}
+}
+
+// CreateSeries creates a recurring series and the occurrences within the horizon
+func (s *SeriesService) CreateSeries(ctx context.Context, createdBy shared.UserID, title, description string, pingType PingType, startAt time.Time, timeZone string, rule RecurrenceRule, invitees []shared.UserID) (*Series, []*Ping, error) {
+	series, err := NewSeries(createdBy, title, description, pingType, startAt, timeZone, rule, invitees)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, invitee := range series.Invitees() {
+		if err := s.invites.CheckInvite(ctx, createdBy, invitee); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := s.series.Create(ctx, series); err != nil {
+		return nil, nil, err
+	}
+
+	occurrences, err := s.materialize(ctx, series, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
+
+// GetSeries retrieves a series and its upcoming occurrences for one of its participants
+func (s *SeriesService) GetSeries(ctx context.Context, seriesID shared.ID, userID shared.UserID) (*Series, []*Ping, error) {
+	series, err := s.series.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !series.IsParticipant(userID) {
+		return nil, nil, ErrNotInvited.WithMessage("user is not invited to this ping series")
+	}
+
+	occurrences, err := s.pings.GetBySeries(ctx, seriesID, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
+
+// GetUserSeries retrieves the series a user created or is invited to
+func (s *SeriesService) GetUserSeries(ctx context.Context, userID shared.UserID) ([]*Series, error) {
+	return s.series.GetForUser(ctx, userID)
+}
+
+// UpdateSeries changes the whole series (only by creator)
+// 未單獨修改過的發生會套用新內容；時間或規則變更時，新規則中已沒有的發生會被取消，
+// 新規則多出的發生則依預先建立的範圍補上
+func (s *SeriesService) UpdateSeries(ctx context.Context, seriesID shared.ID, userID shared.UserID, title, description string, location *shared.Location, startAt time.Time, timeZone string, rule RecurrenceRule) (*Series, []*Ping, error) {
+	series, err := s.series.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !series.CreatedBy().Equals(userID) {
+		return nil, nil, ErrNotPingCreator
+	}
+
+	now := time.Now()
+	rescheduled, err := series.Update(title, description, location, startAt, timeZone, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	upcoming, err := s.pings.GetBySeries(ctx, seriesID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range upcoming {
+		if p.Status() != PingStatusActive || p.Occurrence().Modified {
+			continue
+		}
+		if rescheduled && !series.Rule().Includes(series.StartAt(), p.Occurrence().OccurrenceAt) {
+			if err := p.Cancel(); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			p.syncWithSeries(series)
+		}
+		if err := s.pings.Update(ctx, p); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if _, err := s.materialize(ctx, series, now); err != nil {
+		return nil, nil, err
+	}
+
+	occurrences, err := s.pings.GetBySeries(ctx, seriesID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return series, occurrences, nil
+}
+
+// CancelSeries stops the series and cancels its upcoming occurrences (only by creator)
+func (s *SeriesService) CancelSeries(ctx context.Context, seriesID shared.ID, userID shared.UserID) (*Series, error) {
+	series, err := s.series.GetByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if !series.CreatedBy().Equals(userID) {
+		return nil, ErrNotPingCreator
+	}
+
+	if err := series.Cancel(); err != nil {
+		return nil, err
+	}
+
+	upcoming, err := s.pings.GetBySeries(ctx, seriesID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range upcoming {
+		if p.Status() != PingStatusActive {
+			continue
+		}
+		if err := p.Cancel(); err != nil {
+			return nil, err
+		}
+		if err := s.pings.Update(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.series.Update(ctx, series); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// SkipOccurrence cancels a single occurrence of a series (only by creator)
+func (s *SeriesService) SkipOccurrence(ctx context.Context, pingID shared.ID, userID shared.UserID) (*Ping, error) {
+	p, err := s.pings.GetByID(ctx, pingID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.CreatedBy().Equals(userID) {
+		return nil, ErrNotPingCreator
+	}
+
+	if err := p.Skip(); err != nil {
+		return nil, err
+	}
+	if err := s.pings.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// EditOccurrence changes a single occurrence of a series without affecting the rest (only by creator)
+func (s *SeriesService) EditOccurrence(ctx context.Context, pingID shared.ID, userID shared.UserID, title, description string, scheduledAt time.Time, location *shared.Location) (*Ping, error) {
+	p, err := s.pings.GetByID(ctx, pingID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.CreatedBy().Equals(userID) {
+		return nil, ErrNotPingCreator
+	}
+
+	if err := p.EditOccurrence(title, description, scheduledAt, location); err != nil {
+		return nil, err
+	}
+	if err := s.pings.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// MaterializeDue creates the occurrences of every active series that start within the horizon and returns how many were created
+func (s *SeriesService) MaterializeDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := s.series.GetDueForMaterialization(ctx, now.Add(s.horizon))
+	if err != nil {
+		return 0, err
+	}
+
+	// 單一系列失敗時繼續處理其他系列，最後一併回報讓排程紀錄為失敗
+	created := 0
+	var failures []error
+	for _, series := range due {
+		occurrences, err := s.materialize(ctx, series, now)
+		created += len(occurrences)
+		if err != nil {
+			failures = append(failures, fmt.Errorf("materialize ping series %s: %w", series.ID(), err))
+		}
+	}
+	return created, errors.Join(failures...)
+}
+
+// materialize 建立尚未建立且在預先建立範圍內的發生
+// 已存在的發生 (包含被略過或單獨改期的) 以原本的時間辨識，不會重複建立
+func (s *SeriesService) materialize(ctx context.Context, series *Series, now time.Time) ([]*Ping, error) {
+	until := now.Add(s.horizon)
+	slots := series.PendingOccurrences(now, until)
+
+	var created []*Ping
+	if len(slots) > 0 {
+		existing, err := s.pings.GetBySeries(ctx, series.ID(), slots[0])
+		if err != nil {
+			return nil, err
+		}
+		materialized := make(map[int64]bool, len(existing))
+		for _, p := range existing {
+			if occurrence := p.Occurrence(); occurrence != nil {
+				materialized[occurrence.OccurrenceAt.UnixNano()] = true
+			}
+		}
+
+		invitees, err := s.allowedInvitees(ctx, series)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, slot := range slots {
+			if materialized[slot.UnixNano()] || len(invitees) == 0 {
+				continue
+			}
+			p, err := NewOccurrence(series, slot, invitees)
+			if err != nil {
+				return created, err
+			}
+			if err := s.pings.Create(ctx, p); err != nil {
+				return created, err
+			}
+			created = append(created, p)
+		}
+	}
+
+	series.MarkMaterialized(until)
+	if err := s.series.Update(ctx, series); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// allowedInvitees 重新確認邀請權限，之後封鎖或不再接受邀請的受邀者不會收到新的發生
+func (s *SeriesService) allowedInvitees(ctx context.Context, series *Series) ([]shared.UserID, error) {
+	allowed := make([]shared.UserID, 0, len(series.Invitees()))
+	for _, invitee := range series.Invitees() {
+		err := s.invites.CheckInvite(ctx, series.CreatedBy(), invitee)
+		if err == nil {
+			allowed = append(allowed, invitee)
+			continue
+		}
+
+		var domainErr *shared.DomainError
+		if errors.As(err, &domainErr) && (domainErr.Kind == shared.KindForbidden || domainErr.Kind == shared.KindNotFound) {
+			continue
+		}
+		return nil, err
+	}
+	return allowed, nil
+}
@@ -0,0 +1,201 @@
+package ping
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func newWeeklySeries(t *testing.T, rule string) (*Series, shared.UserID) {
+	t.Helper()
+	invitee := shared.NewUserID()
+	series, err := NewSeries(shared.NewUserID(), "Team lunch", "Weekly", PingTypeLunch,
+		time.Now().Add(time.Hour), "Asia/Taipei", mustRule(t, rule), []shared.UserID{invitee})
+	if err != nil {
+		t.Fatalf("NewSeries() error = %v", err)
+	}
+	return series, invitee
+}
+
+func TestNewSeriesValidation(t *testing.T) {
+	creator := shared.NewUserID()
+	invitees := []shared.UserID{shared.NewUserID()}
+	weekly := mustRule(t, "FREQ=WEEKLY")
+	start := time.Now().Add(time.Hour)
+
+	if _, err := NewSeries(creator, "Lunch", "", PingTypeLunch, time.Now().Add(-time.Hour), "", weekly, invitees); !errors.Is(err, shared.ErrInvalidPingTime) {
+		t.Errorf("past start error = %v, want %v", err, shared.ErrInvalidPingTime)
+	}
+	if _, err := NewSeries(creator, "Lunch", "", PingTypeLunch, start, "", weekly, []shared.UserID{creator}); !errors.Is(err, ErrSelfInvite) {
+		t.Errorf("self invite error = %v, want %v", err, ErrSelfInvite)
+	}
+	if _, err := NewSeries(creator, "Lunch", "", PingTypeLunch, start, "Mars/Olympus", weekly, invitees); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("invalid time zone error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+	expired := mustRule(t, "FREQ=WEEKLY;UNTIL=20200101")
+	if _, err := NewSeries(creator, "Lunch", "", PingTypeLunch, start, "", expired, invitees); !errors.Is(err, ErrInvalidRecurrence) {
+		t.Errorf("rule without occurrences error = %v, want %v", err, ErrInvalidRecurrence)
+	}
+
+	series, err := NewSeries(creator, "Lunch", "", PingTypeLunch, start, "", weekly, invitees)
+	if err != nil {
+		t.Fatalf("NewSeries() error = %v", err)
+	}
+	if series.TimeZone() != DefaultSeriesTimeZone || series.StartAt().Location().String() != DefaultSeriesTimeZone {
+		t.Errorf("time zone = %s (%s), want %s", series.TimeZone(), series.StartAt().Location(), DefaultSeriesTimeZone)
+	}
+}
+
+func TestSeriesMaterialization(t *testing.T) {
+	series, invitee := newWeeklySeries(t, "FREQ=WEEKLY;COUNT=3")
+	now := time.Now()
+
+	first := series.PendingOccurrences(now, now.Add(10*24*time.Hour))
+	if len(first) != 2 {
+		t.Fatalf("PendingOccurrences() = %d, want 2", len(first))
+	}
+	series.MarkMaterialized(now.Add(10 * 24 * time.Hour))
+	if series.Status() != SeriesStatusActive {
+		t.Fatalf("Status = %v, want %v", series.Status(), SeriesStatusActive)
+	}
+
+	// 已建立的範圍不會再回傳
+	if again := series.PendingOccurrences(now, now.Add(10*24*time.Hour)); len(again) != 0 {
+		t.Errorf("PendingOccurrences() again = %d, want 0", len(again))
+	}
+
+	rest := series.PendingOccurrences(now, now.Add(30*24*time.Hour))
+	if len(rest) != 1 || !rest[0].Equal(first[0].AddDate(0, 0, 14)) {
+		t.Fatalf("PendingOccurrences() rest = %v, want the third week", rest)
+	}
+	series.MarkMaterialized(now.Add(30 * 24 * time.Hour))
+	if series.Status() != SeriesStatusEnded {
+		t.Errorf("Status after last occurrence = %v, want %v", series.Status(), SeriesStatusEnded)
+	}
+
+	p, err := NewOccurrence(series, first[0], series.Invitees())
+	if err != nil {
+		t.Fatalf("NewOccurrence() error = %v", err)
+	}
+	if p.Occurrence() == nil || p.Occurrence().SeriesID != series.ID() || !p.Occurrence().OccurrenceAt.Equal(first[0]) {
+		t.Fatalf("Occurrence = %+v, want series %s at %v", p.Occurrence(), series.ID(), first[0])
+	}
+	events := p.PendingEvents()
+	if len(events) != 1 {
+		t.Fatalf("PendingEvents() = %d, want 1", len(events))
+	}
+	created, ok := events[0].Event.(PingCreated)
+	if !ok || created.SeriesID == nil || *created.SeriesID != series.ID() {
+		t.Errorf("PingCreated = %+v, want series %s", events[0].Event, series.ID())
+	}
+
+	// 每次發生分別回覆
+	if err := p.RespondToPing(invitee, ResponseStatusAccepted, ""); err != nil {
+		t.Errorf("RespondToPing() error = %v", err)
+	}
+}
+
+func TestSeriesUpdate(t *testing.T) {
+	series, _ := newWeeklySeries(t, "FREQ=WEEKLY")
+	series.MarkMaterialized(time.Now().Add(14 * 24 * time.Hour))
+	location := &shared.Location{Latitude: 25.03, Longitude: 121.56, Address: "Taipei"}
+
+	rescheduled, err := series.Update("Team lunch", "At the usual place", location, series.StartAt(), "", series.Rule())
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if rescheduled || series.Sequence() != 1 {
+		t.Errorf("details only: rescheduled = %v, sequence = %d, want false, 1", rescheduled, series.Sequence())
+	}
+
+	rescheduled, err = series.Update("Team lunch", "", location, series.StartAt(), "", mustRule(t, "FREQ=WEEKLY;INTERVAL=2"))
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !rescheduled || series.Sequence() != 2 {
+		t.Errorf("new rule: rescheduled = %v, sequence = %d, want true, 2", rescheduled, series.Sequence())
+	}
+	if series.MaterializedUntil().After(time.Now()) {
+		t.Errorf("MaterializedUntil = %v, want reset so occurrences are rebuilt", series.MaterializedUntil())
+	}
+
+	if _, err := series.Update("", "", nil, series.StartAt(), "", series.Rule()); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("empty title error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+	if _, err := series.Update("Lunch", "", nil, time.Now().Add(-time.Hour), "", series.Rule()); !errors.Is(err, shared.ErrInvalidPingTime) {
+		t.Errorf("past start error = %v, want %v", err, shared.ErrInvalidPingTime)
+	}
+
+	if err := series.Cancel(); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if _, err := series.Update("Lunch", "", nil, series.StartAt(), "", series.Rule()); !errors.Is(err, ErrSeriesNotActive) {
+		t.Errorf("update cancelled error = %v, want %v", err, ErrSeriesNotActive)
+	}
+	if pending := series.PendingOccurrences(time.Now(), time.Now().Add(30*24*time.Hour)); len(pending) != 0 {
+		t.Errorf("PendingOccurrences() after cancel = %d, want 0", len(pending))
+	}
+}
+
+func TestOccurrenceSkipAndEdit(t *testing.T) {
+	series, _ := newWeeklySeries(t, "FREQ=WEEKLY")
+	slots := series.PendingOccurrences(time.Now(), time.Now().Add(15*24*time.Hour))
+	if len(slots) != 3 {
+		t.Fatalf("PendingOccurrences() = %d, want 3", len(slots))
+	}
+
+	occurrences := make([]*Ping, len(slots))
+	for i, slot := range slots {
+		p, err := NewOccurrence(series, slot, series.Invitees())
+		if err != nil {
+			t.Fatalf("NewOccurrence() error = %v", err)
+		}
+		occurrences[i] = p
+	}
+
+	skipped, edited, untouched := occurrences[0], occurrences[1], occurrences[2]
+	if err := skipped.Skip(); err != nil {
+		t.Fatalf("Skip() error = %v", err)
+	}
+	if skipped.Status() != PingStatusCancelled {
+		t.Errorf("skipped Status = %v, want %v", skipped.Status(), PingStatusCancelled)
+	}
+
+	moved := slots[1].Add(30 * time.Minute)
+	if err := edited.EditOccurrence("Team lunch (moved)", "", moved, nil); err != nil {
+		t.Fatalf("EditOccurrence() error = %v", err)
+	}
+	if !edited.ScheduledAt().Equal(moved) || !edited.Occurrence().OccurrenceAt.Equal(slots[1]) || !edited.Occurrence().Modified {
+		t.Errorf("edited = %v (occurrence %+v), want moved to %v and marked modified", edited.ScheduledAt(), *edited.Occurrence(), moved)
+	}
+	if edited.Sequence() != 1 {
+		t.Errorf("edited Sequence = %d, want 1", edited.Sequence())
+	}
+
+	// 系列變更只套用到沒有單獨修改過的發生
+	if _, err := series.Update("Team lunch v2", "", nil, series.StartAt(), "", series.Rule()); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	for _, p := range occurrences {
+		p.syncWithSeries(series)
+	}
+	if untouched.Title() != "Team lunch v2" {
+		t.Errorf("untouched Title = %q, want the series title", untouched.Title())
+	}
+	if edited.Title() != "Team lunch (moved)" || skipped.Title() != "Team lunch" {
+		t.Errorf("edited/skipped Title = %q/%q, want their own titles kept", edited.Title(), skipped.Title())
+	}
+
+	oneOff, err := NewPing(shared.NewUserID(), "Lunch", "", PingTypeLunch, time.Now().Add(time.Hour), []shared.UserID{shared.NewUserID()})
+	if err != nil {
+		t.Fatalf("NewPing() error = %v", err)
+	}
+	if err := oneOff.Skip(); !errors.Is(err, ErrNotOccurrence) {
+		t.Errorf("Skip() one-off error = %v, want %v", err, ErrNotOccurrence)
+	}
+	if err := oneOff.EditOccurrence("Lunch", "", oneOff.ScheduledAt(), nil); !errors.Is(err, ErrNotOccurrence) {
+		t.Errorf("EditOccurrence() one-off error = %v, want %v", err, ErrNotOccurrence)
+	}
+}
@@ -2,20 +2,28 @@ package ping
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
+// InvitePolicy 確認發起人可以邀請受邀者 (好友關係、封鎖與隱私設定)，實作為 social.InteractionPolicy
+type InvitePolicy interface {
+	CheckInvite(ctx context.Context, inviterID, inviteeID shared.UserID) error
+}
+
 // Service provides business logic for ping operations
 type Service struct {
-	repo Repository
+	repo    Repository
+	invites InvitePolicy
 }
 
 // NewService creates a new ping service
-func NewService(repo Repository) *Service {
+func NewService(repo Repository, invites InvitePolicy) *Service {
 	return &Service{
-		repo: repo,
+		repo:    repo,
+		invites: invites,
 	}
 }
 
@@ -25,6 +33,12 @@ func (s *Service) CreatePing(ctx context.Context, createdBy shared.UserID, title
 	if err != nil {
 		return nil, err
 	}
+
+	for _, invitee := range ping.Invitees() {
+		if err := s.invites.CheckInvite(ctx, createdBy, invitee); err != nil {
+			return nil, err
+		}
+	}
 	
 	err = s.repo.Create(ctx, ping)
 	if err != nil {
@@ -63,7 +77,7 @@ func (s *Service) CancelPing(ctx context.Context, pingID shared.ID, userID share
 	
 	// Only creator can cancel
 	if ping.CreatedBy() != userID {
-		return nil, shared.ErrPermissionDenied
+		return nil, ErrNotPingCreator
 	}
 	
 	err = ping.Cancel()
@@ -88,7 +102,7 @@ func (s *Service) CompletePing(ctx context.Context, pingID shared.ID, userID sha
 	
 	// Only creator can complete
 	if ping.CreatedBy() != userID {
-		return nil, shared.ErrPermissionDenied
+		return nil, ErrNotPingCreator
 	}
 	
 	err = ping.Complete()
@@ -113,7 +127,7 @@ func (s *Service) SetPingLocation(ctx context.Context, pingID shared.ID, userID
 	
 	// Only creator can set location
 	if ping.CreatedBy() != userID {
-		return nil, shared.ErrPermissionDenied
+		return nil, ErrNotPingCreator
 	}
 	
 	ping.SetLocation(location)
@@ -126,9 +140,9 @@ func (s *Service) SetPingLocation(ctx context.Context, pingID shared.ID, userID
 	return ping, nil
 }
 
-// GetUserPings retrieves pings for a user (created or invited to)
-func (s *Service) GetUserPings(ctx context.Context, userID shared.UserID, limit, offset int) ([]*Ping, error) {
-	return s.repo.GetActivePings(ctx, userID, limit, offset)
+// GetUserPings retrieves pings for a user (created or invited to), leaving out pings created by excludeCreators
+func (s *Service) GetUserPings(ctx context.Context, userID shared.UserID, excludeCreators []shared.UserID, limit, offset int) ([]*Ping, error) {
+	return s.repo.GetActivePings(ctx, userID, excludeCreators, limit, offset)
 }
 
 // GetPingByID retrieves a specific ping
@@ -136,26 +150,26 @@ func (s *Service) GetPingByID(ctx context.Context, pingID shared.ID) (*Ping, err
 	return s.repo.GetByID(ctx, pingID)
 }
 
-// ExpirePings marks expired pings as expired
-func (s *Service) ExpirePings(ctx context.Context) error {
-	// Get all active pings
-	activePings, err := s.repo.GetPingsByStatus(ctx, PingStatusActive, 1000, 0)
+// ExpirePings marks active pings whose scheduled time has passed as expired and returns how many were expired
+func (s *Service) ExpirePings(ctx context.Context) (int, error) {
+	// Get active pings scheduled before now
+	duePings, err := s.repo.GetScheduledBetween(ctx, PingStatusActive, time.Time{}, time.Now())
 	if err != nil {
-		return err
-	}
-	
-	// Check and expire outdated pings
-	for _, ping := range activePings {
-		if ping.IsExpired() {
-			ping.status = PingStatusExpired
-			ping.updatedAt = time.Now()
-			err := s.repo.Update(ctx, ping)
-			if err != nil {
-				// Log error but continue processing other pings
-				continue
-			}
+		return 0, err
+	}
+	
+	expired := 0
+	for _, ping := range duePings {
+		if err := ping.Expire(); err != nil {
+			continue
+		}
+		if err := s.repo.Update(ctx, ping); err != nil {
+			// Log error but continue processing other pings
+			log.Printf("Failed to expire ping %s: %v", ping.ID(), err)
+			continue
 		}
+		expired++
 	}
 	
-	return nil
+	return expired, nil
 }
\ No newline at end of file
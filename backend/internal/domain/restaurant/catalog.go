@@ -0,0 +1,318 @@
+package restaurant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RestaurantDetails 餐廳目錄中可由管理者維護的資料
+// 建立、編輯與批次匯入都經過 NewRestaurant 驗證，評分與評論數只能由評論重新計算
+type RestaurantDetails struct {
+	Name                  string               `json:"name"`
+	Description           string               `json:"description"`
+	Location              Location             `json:"location"`
+	CuisineTypes          []CuisineType        `json:"cuisineTypes"`
+	PriceLevel            PriceLevel           `json:"priceLevel"`
+	PhoneNumber           string               `json:"phoneNumber"`
+	Website               string               `json:"website,omitempty"`
+	ImageURLs             []string             `json:"imageUrls,omitempty"`
+	OpeningHours          OpeningHours         `json:"openingHours"`
+	SupportedRestrictions []DietaryRestriction `json:"supportedRestrictions,omitempty"`
+	AverageWaitTime       int                  `json:"averageWaitTime"`
+	AcceptsReservations   bool                 `json:"acceptsReservations"`
+}
+
+// NewRestaurant 驗證資料並建立新餐廳
+func (d RestaurantDetails) NewRestaurant() (*Restaurant, error) {
+	d = d.trimmed()
+	if err := d.validate(); err != nil {
+		return nil, err
+	}
+
+	rest, err := NewRestaurant(d.Name, d.Description, d.Location, d.CuisineTypes, d.PriceLevel, d.PhoneNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", shared.ErrInvalidInput, err)
+	}
+	rest.Website = d.Website
+	rest.ImageURLs = d.ImageURLs
+	rest.OpeningHours = d.OpeningHours
+	if d.SupportedRestrictions != nil {
+		rest.SupportedRestrictions = d.SupportedRestrictions
+	}
+	rest.AverageWaitTime = d.AverageWaitTime
+	rest.AcceptsReservations = d.AcceptsReservations
+	return rest, nil
+}
+
+// validate 檢查 NewRestaurant 不檢查的欄位
+func (d RestaurantDetails) validate() error {
+	loc := d.Location
+	if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+		return fmt.Errorf("%w: %v", shared.ErrInvalidInput, shared.ErrInvalidLocation)
+	}
+	// 匯入檔案缺少座標時解析為 (0, 0)，不會是真實的餐廳位置
+	if loc.Latitude == 0 && loc.Longitude == 0 {
+		return fmt.Errorf("%w: restaurant coordinates are required", shared.ErrInvalidInput)
+	}
+	for _, cuisine := range d.CuisineTypes {
+		if !cuisine.IsValid() {
+			return fmt.Errorf("%w: unsupported cuisine type %q", shared.ErrInvalidInput, cuisine)
+		}
+	}
+	for _, restriction := range d.SupportedRestrictions {
+		if !restriction.IsValid() {
+			return fmt.Errorf("%w: unsupported dietary restriction %q", shared.ErrInvalidInput, restriction)
+		}
+	}
+	if d.PriceLevel < PriceLevelUnknown || d.PriceLevel > PriceLevelVeryExpensive {
+		return fmt.Errorf("%w: price level must be between 0 and 4", shared.ErrInvalidInput)
+	}
+	if d.AverageWaitTime < 0 {
+		return fmt.Errorf("%w: average wait time cannot be negative", shared.ErrInvalidInput)
+	}
+	return d.OpeningHours.Validate()
+}
+
+func (d RestaurantDetails) trimmed() RestaurantDetails {
+	d.Name = strings.TrimSpace(d.Name)
+	d.Description = strings.TrimSpace(d.Description)
+	d.Location.Address = strings.TrimSpace(d.Location.Address)
+	d.PhoneNumber = strings.TrimSpace(d.PhoneNumber)
+	d.Website = strings.TrimSpace(d.Website)
+	return d
+}
+
+// UpdateDetails 以新的目錄資料取代餐廳資訊，保留 ID、評分、評論數與啟用狀態
+func (r *Restaurant) UpdateDetails(details RestaurantDetails) error {
+	updated, err := details.NewRestaurant()
+	if err != nil {
+		return err
+	}
+
+	updated.ID = r.ID
+	updated.Rating = r.Rating
+	updated.TotalReviews = r.TotalReviews
+	updated.IsActive = r.IsActive
+	updated.CreatedAt = r.CreatedAt
+	*r = *updated
+	return nil
+}
+
+// SetActive 啟用或停用餐廳，停用的餐廳不會出現在搜尋與推薦中
+func (r *Restaurant) SetActive(active bool) {
+	r.IsActive = active
+	r.UpdatedAt = time.Now()
+}
+
+// ImportStatus 批次匯入單筆資料的結果
+type ImportStatus string
+
+const (
+	ImportStatusCreated   ImportStatus = "created"   // 已建立
+	ImportStatusValid     ImportStatus = "valid"     // dry run 時通過驗證，實際匯入會建立
+	ImportStatusDuplicate ImportStatus = "duplicate" // 目錄或同一檔案中已有同名且位置相近的餐廳
+	ImportStatusInvalid   ImportStatus = "invalid"   // 無法解析或未通過驗證
+)
+
+// ImportRecord 匯入檔案中的一筆資料，Err 為解析錯誤
+type ImportRecord struct {
+	Row     int // CSV 為行號 (含標題列)，GeoJSON 為 feature 的序號 (從 1 開始)
+	Details RestaurantDetails
+	Err     error
+}
+
+// ImportRowResult 單筆資料的匯入結果
+type ImportRowResult struct {
+	Row          int                  `json:"row"`
+	Name         string               `json:"name,omitempty"`
+	Status       ImportStatus         `json:"status"`
+	RestaurantID *shared.RestaurantID `json:"restaurantId,omitempty"` // 建立的餐廳
+	DuplicateOf  *shared.RestaurantID `json:"duplicateOf,omitempty"`  // 重複的目錄餐廳，同一檔案中重複時為空
+	DuplicateRow int                  `json:"duplicateRow,omitempty"` // 同一檔案中重複的資料列
+	Error        string               `json:"error,omitempty"`
+}
+
+// ImportReport 批次匯入報告
+type ImportReport struct {
+	DryRun     bool              `json:"dryRun"`
+	Total      int               `json:"total"`
+	Created    int               `json:"created"`
+	Valid      int               `json:"valid"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Rows       []ImportRowResult `json:"rows"`
+}
+
+func (r *ImportReport) add(result ImportRowResult) {
+	r.Total++
+	switch result.Status {
+	case ImportStatusCreated:
+		r.Created++
+	case ImportStatusValid:
+		r.Valid++
+	case ImportStatusDuplicate:
+		r.Duplicates++
+	case ImportStatusInvalid:
+		r.Invalid++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// CatalogService 維護餐廳目錄：建立、編輯、刪除與批次匯入
+// 同名且距離在 PlaceMatchRadiusKm 內的餐廳視為重複 (包含已停用的餐廳)
+type CatalogService struct {
+	repo Repository
+}
+
+func NewCatalogService(repo Repository) *CatalogService {
+	return &CatalogService{
+		repo: repo,
+	}
+}
+
+// GetRestaurant 取得餐廳，包含已停用的餐廳
+func (s *CatalogService) GetRestaurant(ctx context.Context, id shared.RestaurantID) (*Restaurant, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+// CreateRestaurant 新增餐廳到目錄
+func (s *CatalogService) CreateRestaurant(ctx context.Context, details RestaurantDetails) (*Restaurant, error) {
+	rest, err := details.NewRestaurant()
+	if err != nil {
+		return nil, err
+	}
+
+	duplicate, err := s.findDuplicate(ctx, rest)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return nil, fmt.Errorf("%w: %s", shared.ErrRestaurantExists, duplicate.ID)
+	}
+
+	if err := s.repo.Create(ctx, rest); err != nil {
+		return nil, err
+	}
+	return rest, nil
+}
+
+// UpdateRestaurant 更新餐廳資料，isActive 不為 nil 時同時啟用或停用
+func (s *CatalogService) UpdateRestaurant(ctx context.Context, id shared.RestaurantID, details RestaurantDetails, isActive *bool) (*Restaurant, error) {
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 在副本上修改，未通過檢查時不影響儲存庫中的餐廳
+	rest := *existing
+	if err := rest.UpdateDetails(details); err != nil {
+		return nil, err
+	}
+	if isActive != nil {
+		rest.SetActive(*isActive)
+	}
+
+	duplicate, err := s.findDuplicate(ctx, &rest)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		return nil, fmt.Errorf("%w: %s", shared.ErrRestaurantExists, duplicate.ID)
+	}
+
+	if err := s.repo.Update(ctx, &rest); err != nil {
+		return nil, err
+	}
+	return &rest, nil
+}
+
+// DeleteRestaurant 從目錄移除餐廳
+func (s *CatalogService) DeleteRestaurant(ctx context.Context, id shared.RestaurantID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// Import 批次匯入餐廳，每筆資料個別驗證與比對重複，單筆失敗不影響其他資料
+// dryRun 為 true 時只產生報告，不寫入目錄
+func (s *CatalogService) Import(ctx context.Context, records []ImportRecord, dryRun bool) (*ImportReport, error) {
+	report := &ImportReport{DryRun: dryRun, Rows: []ImportRowResult{}}
+
+	// 同一檔案中已接受的資料與其資料列，先比對檔案內的重複，dry run 與實際匯入的報告才會一致
+	var batch []*Restaurant
+	batchRows := make(map[shared.RestaurantID]int)
+
+	for _, record := range records {
+		result := ImportRowResult{Row: record.Row, Name: strings.TrimSpace(record.Details.Name)}
+		if record.Err != nil {
+			result.Status = ImportStatusInvalid
+			result.Error = record.Err.Error()
+			report.add(result)
+			continue
+		}
+
+		rest, err := record.Details.NewRestaurant()
+		if err != nil {
+			result.Status = ImportStatusInvalid
+			result.Error = err.Error()
+			report.add(result)
+			continue
+		}
+
+		if earlier := findPlace(batch, rest); earlier != nil {
+			result.Status = ImportStatusDuplicate
+			result.DuplicateRow = batchRows[earlier.ID]
+			report.add(result)
+			continue
+		}
+		duplicate, err := s.findDuplicate(ctx, rest)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate != nil {
+			result.Status = ImportStatusDuplicate
+			result.DuplicateOf = &duplicate.ID
+			report.add(result)
+			continue
+		}
+
+		if dryRun {
+			result.Status = ImportStatusValid
+		} else {
+			if err := s.repo.Create(ctx, rest); err != nil {
+				return nil, err
+			}
+			result.Status = ImportStatusCreated
+			result.RestaurantID = &rest.ID
+		}
+		batch = append(batch, rest)
+		batchRows[rest.ID] = record.Row
+		report.add(result)
+	}
+
+	return report, nil
+}
+
+// findDuplicate 找出目錄中與 rest 同名且位置相近的其他餐廳
+func (s *CatalogService) findDuplicate(ctx context.Context, rest *Restaurant) (*Restaurant, error) {
+	nearby, err := s.repo.Search(ctx, SearchCriteria{
+		CenterLocation: &rest.Location,
+		RadiusKm:       PlaceMatchRadiusKm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findPlace(nearby, rest), nil
+}
+
+// findPlace 找出第一間與 rest 同名且位置相近的餐廳 (不含 rest 本身)
+func findPlace(candidates []*Restaurant, rest *Restaurant) *Restaurant {
+	for _, candidate := range candidates {
+		if candidate.ID != rest.ID && candidate.MatchesPlace(rest.Name, rest.Location) {
+			return candidate
+		}
+	}
+	return nil
+}
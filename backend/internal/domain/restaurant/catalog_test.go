@@ -0,0 +1,217 @@
+package restaurant_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+const catalogCSV = `name,address,latitude,longitude,cuisine_types,price_level,phone_number,supported_restrictions,opening_hours
+鼎泰豐 信義店,台北市信義區松高路19號,25.0408,121.5678,taiwanese|chinese,$$$,02-2345-6789,vegetarian,"{""monday"": ""11:00-21:00""}"
+一蘭 台北本店,台北市信義區松壽路11號,25.0360,121.5670,Japanese,2,02-2758-2228,,
+鼎泰豐 信義店,台北市信義區松高路19號,25.0409,121.5679,taiwanese,3,02-2345-6789,,
+沒有電話,台北市大安區,25.03,121.54,taiwanese,1,,,
+座標錯誤,台北市大安區,north,121.54,taiwanese,1,02-1234-5678,,
+`
+
+const catalogGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [121.5654, 25.0330]},
+     "properties": {"name": "欣葉", "address": "台北市中山區雙城街34號", "cuisineTypes": ["taiwanese"], "priceLevel": "$$", "phoneNumber": "02-2596-3255"}},
+    {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[121.5, 25.0], [121.6, 25.1]]},
+     "properties": {"name": "不是點", "address": "台北市", "cuisineTypes": ["taiwanese"], "phoneNumber": "02-0000-0000"}},
+    {"type": "Feature", "geometry": {"type": "Point", "coordinates": [121.5654, 25.0330]},
+     "properties": {"name": "未知料理", "address": "台北市", "cuisineTypes": ["martian"], "phoneNumber": "02-0000-0000"}}
+  ]
+}`
+
+func TestParseCSV(t *testing.T) {
+	records, err := restaurant.ParseCSV(strings.NewReader(catalogCSV))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("ParseCSV() returned %d records, want 5", len(records))
+	}
+
+	first := records[0]
+	if first.Err != nil || first.Row != 2 {
+		t.Fatalf("records[0] = row %d, error %v", first.Row, first.Err)
+	}
+	if got := first.Details.CuisineTypes; len(got) != 2 || got[1] != restaurant.CuisineTypeChinese {
+		t.Errorf("CuisineTypes = %v, want [taiwanese chinese]", got)
+	}
+	if first.Details.PriceLevel != restaurant.PriceLevelExpensive || first.Details.OpeningHours.IsZero() {
+		t.Errorf("records[0] details = %+v", first.Details)
+	}
+	if got := records[1].Details.CuisineTypes; len(got) != 1 || got[0] != restaurant.CuisineTypeJapanese {
+		t.Errorf("cuisine types are not lower-cased: %v", got)
+	}
+	if records[4].Err == nil || records[4].Row != 6 {
+		t.Errorf("records[4] = row %d, error %v, want an error on row 6", records[4].Row, records[4].Err)
+	}
+
+	t.Run("missing required column", func(t *testing.T) {
+		_, err := restaurant.ParseCSV(strings.NewReader("name,address\n鼎泰豐,台北市\n"))
+		if !errors.Is(err, shared.ErrInvalidInput) {
+			t.Errorf("ParseCSV() error = %v, want %v", err, shared.ErrInvalidInput)
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, err := restaurant.ParseCSV(strings.NewReader("name,address,latitude,longitude,cuisine_types,phone_number,rating\n"))
+		if err == nil || !strings.Contains(err.Error(), "rating") {
+			t.Errorf("ParseCSV() error = %v, want unknown column rating", err)
+		}
+	})
+}
+
+func TestParseGeoJSON(t *testing.T) {
+	records, err := restaurant.ParseGeoJSON(strings.NewReader(catalogGeoJSON))
+	if err != nil {
+		t.Fatalf("ParseGeoJSON() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("ParseGeoJSON() returned %d records, want 3", len(records))
+	}
+
+	details := records[0].Details
+	if records[0].Err != nil || details.Location.Latitude != 25.0330 || details.Location.Longitude != 121.5654 {
+		t.Errorf("records[0] = %+v, error %v, want [lon, lat] coordinates", details.Location, records[0].Err)
+	}
+	if details.PriceLevel != restaurant.PriceLevelMidRange {
+		t.Errorf("PriceLevel = %v, want $$", details.PriceLevel)
+	}
+	if records[1].Err == nil {
+		t.Error("records[1] with a LineString geometry parsed without error")
+	}
+
+	if _, err := restaurant.ParseGeoJSON(strings.NewReader(`{"type": "Feature"}`)); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("ParseGeoJSON(Feature) error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+}
+
+func TestCatalogImport(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewRestaurantRepository()
+	service := restaurant.NewCatalogService(repo)
+
+	existing, err := service.CreateRestaurant(ctx, restaurant.RestaurantDetails{
+		Name:         "一蘭 台北本店",
+		Location:     restaurant.Location{Latitude: 25.0361, Longitude: 121.5671, Address: "台北市信義區松壽路11號"},
+		CuisineTypes: []restaurant.CuisineType{restaurant.CuisineTypeJapanese},
+		PhoneNumber:  "02-2758-2228",
+	})
+	if err != nil {
+		t.Fatalf("CreateRestaurant() error = %v", err)
+	}
+
+	records, err := restaurant.ParseCSV(strings.NewReader(catalogCSV))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	dryRun, err := service.Import(ctx, records, true)
+	if err != nil {
+		t.Fatalf("Import(dry run) error = %v", err)
+	}
+	if !dryRun.DryRun || dryRun.Total != 5 || dryRun.Valid != 1 || dryRun.Duplicates != 2 || dryRun.Invalid != 2 || dryRun.Created != 0 {
+		t.Errorf("dry run report = %+v", dryRun)
+	}
+	if all, _ := repo.Search(ctx, restaurant.SearchCriteria{}); len(all) != 1 {
+		t.Errorf("dry run wrote %d restaurants, want only the existing one", len(all))
+	}
+
+	report, err := service.Import(ctx, records, false)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if report.Created != 1 || report.Duplicates != 2 || report.Invalid != 2 {
+		t.Errorf("report = %+v", report)
+	}
+
+	rows := report.Rows
+	if rows[0].Status != restaurant.ImportStatusCreated || rows[0].RestaurantID == nil {
+		t.Errorf("rows[0] = %+v, want created", rows[0])
+	}
+	if rows[1].Status != restaurant.ImportStatusDuplicate || rows[1].DuplicateOf == nil || *rows[1].DuplicateOf != existing.ID {
+		t.Errorf("rows[1] = %+v, want duplicate of %s", rows[1], existing.ID)
+	}
+	if rows[2].Status != restaurant.ImportStatusDuplicate || rows[2].DuplicateRow != 2 {
+		t.Errorf("rows[2] = %+v, want duplicate of row 2 in the same file", rows[2])
+	}
+	if rows[3].Status != restaurant.ImportStatusInvalid || !strings.Contains(rows[3].Error, "phone number") {
+		t.Errorf("rows[3] = %+v, want invalid without phone number", rows[3])
+	}
+
+	// 再次匯入同一個檔案不會建立重複的餐廳
+	again, err := service.Import(ctx, records, false)
+	if err != nil {
+		t.Fatalf("Import(again) error = %v", err)
+	}
+	if again.Created != 0 || again.Duplicates != 3 {
+		t.Errorf("second import report = %+v, want every valid row reported as duplicate", again)
+	}
+}
+
+func TestCatalogUpdateRestaurant(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewRestaurantRepository()
+	service := restaurant.NewCatalogService(repo)
+
+	details := restaurant.RestaurantDetails{
+		Name:         "欣葉",
+		Location:     restaurant.Location{Latitude: 25.0330, Longitude: 121.5654, Address: "台北市中山區雙城街34號"},
+		CuisineTypes: []restaurant.CuisineType{restaurant.CuisineTypeTaiwanese},
+		PhoneNumber:  "02-2596-3255",
+	}
+	xinYe, err := service.CreateRestaurant(ctx, details)
+	if err != nil {
+		t.Fatalf("CreateRestaurant() error = %v", err)
+	}
+	if _, err := service.CreateRestaurant(ctx, details); !errors.Is(err, shared.ErrRestaurantExists) {
+		t.Errorf("CreateRestaurant(duplicate) error = %v, want %v", err, shared.ErrRestaurantExists)
+	}
+
+	other := details
+	other.Name = "欣葉 台菜"
+	second, err := service.CreateRestaurant(ctx, other)
+	if err != nil {
+		t.Fatalf("CreateRestaurant(other) error = %v", err)
+	}
+
+	// 改成另一間餐廳的名稱會與其重複，儲存庫中的資料不變
+	if _, err := service.UpdateRestaurant(ctx, second.ID, details, nil); !errors.Is(err, shared.ErrRestaurantExists) {
+		t.Errorf("UpdateRestaurant(duplicate) error = %v, want %v", err, shared.ErrRestaurantExists)
+	}
+	if stored, _ := repo.FindByID(ctx, second.ID); stored.Name != other.Name {
+		t.Errorf("rejected update changed the stored name to %q", stored.Name)
+	}
+
+	inactive := false
+	details.Description = "台菜老店"
+	updated, err := service.UpdateRestaurant(ctx, xinYe.ID, details, &inactive)
+	if err != nil {
+		t.Fatalf("UpdateRestaurant() error = %v", err)
+	}
+	if updated.ID != xinYe.ID || updated.Description != "台菜老店" || updated.IsActive {
+		t.Errorf("UpdateRestaurant() = %+v", updated)
+	}
+
+	// 停用的餐廳仍會被視為重複
+	if _, err := service.CreateRestaurant(ctx, details); !errors.Is(err, shared.ErrRestaurantExists) {
+		t.Errorf("CreateRestaurant(duplicate of inactive) error = %v, want %v", err, shared.ErrRestaurantExists)
+	}
+
+	invalid := details
+	invalid.CuisineTypes = nil
+	if _, err := service.UpdateRestaurant(ctx, xinYe.ID, invalid, nil); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("UpdateRestaurant(no cuisine) error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+}
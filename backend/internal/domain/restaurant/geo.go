@@ -0,0 +1,190 @@
+package restaurant
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	earthRadiusKm = 6371.0
+
+	// kmPerDegreeLat 緯度每度的距離（公里）
+	kmPerDegreeLat = earthRadiusKm * math.Pi / 180
+
+	// MaxGeohashPrecision 支援的最大 geohash 長度
+	MaxGeohashPrecision = 12
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// DistanceTo 計算與另一個位置的距離（公里）
+func (l Location) DistanceTo(other Location) float64 {
+	return calculateHaversineDistance(l.Latitude, l.Longitude, other.Latitude, other.Longitude)
+}
+
+// BoundingBox 經緯度矩形範圍，用於距離計算前的預先篩選
+// MinLongitude 大於 MaxLongitude 時代表範圍跨越 180 度經線
+type BoundingBox struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// NewBoundingBox 建立涵蓋以 center 為圓心、radiusKm 為半徑之圓的最小經緯度範圍
+// 圓涵蓋極點時經度範圍為全部經度
+func NewBoundingBox(center Location, radiusKm float64) BoundingBox {
+	deltaLat := radiusKm / kmPerDegreeLat
+	box := BoundingBox{
+		MinLatitude:  center.Latitude - deltaLat,
+		MaxLatitude:  center.Latitude + deltaLat,
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	if box.MinLatitude <= -90 || box.MaxLatitude >= 90 {
+		box.MinLatitude = math.Max(box.MinLatitude, -90)
+		box.MaxLatitude = math.Min(box.MaxLatitude, 90)
+		return box
+	}
+
+	// 圓在經度方向最寬處的半角，見 http://janmatuschek.de/LatitudeLongitudeBoundingCoordinates
+	angular := radiusKm / earthRadiusKm
+	sinRatio := math.Sin(angular) / math.Cos(center.Latitude*math.Pi/180)
+	if sinRatio >= 1 {
+		return box
+	}
+	deltaLon := math.Asin(sinRatio) * 180 / math.Pi
+
+	box.MinLongitude = normalizeLongitude(center.Longitude - deltaLon)
+	box.MaxLongitude = normalizeLongitude(center.Longitude + deltaLon)
+	return box
+}
+
+// CrossesAntimeridian 範圍是否跨越 180 度經線
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// Contains 檢查座標是否在範圍內
+func (b BoundingBox) Contains(latitude, longitude float64) bool {
+	if latitude < b.MinLatitude || latitude > b.MaxLatitude {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return longitude >= b.MinLongitude || longitude <= b.MaxLongitude
+	}
+	return longitude >= b.MinLongitude && longitude <= b.MaxLongitude
+}
+
+// EncodeGeohash 將座標編碼為指定長度的 geohash
+func EncodeGeohash(latitude, longitude float64, precision int) string {
+	latBits, lonBits := geohashBits(precision)
+	return encodeGeohashCell(
+		geohashIndex(latitude, -90, 180, latBits),
+		geohashIndex(longitude, -180, 360, lonBits),
+		precision,
+	)
+}
+
+// GeohashCellSize 回傳指定長度 geohash 網格的高與寬（度）
+func GeohashCellSize(precision int) (latDegrees, lonDegrees float64) {
+	latBits, lonBits := geohashBits(precision)
+	return 180 / float64(uint64(1)<<latBits), 360 / float64(uint64(1)<<lonBits)
+}
+
+// GeohashCellCount 回傳涵蓋範圍所需的指定長度 geohash 網格數量
+func GeohashCellCount(box BoundingBox, precision int) int {
+	latBits, lonBits := geohashBits(precision)
+	latFrom, latTo := geohashIndexRange(box.MinLatitude, box.MaxLatitude, -90, 180, latBits)
+	lonCount := 0
+	for _, span := range box.longitudeSpans() {
+		lonFrom, lonTo := geohashIndexRange(span[0], span[1], -180, 360, lonBits)
+		lonCount += int(lonTo - lonFrom + 1)
+	}
+	return int(latTo-latFrom+1) * lonCount
+}
+
+// GeohashCells 列出涵蓋範圍的所有指定長度 geohash 網格
+// 呼叫端應先以 GeohashCellCount 確認數量合理
+func GeohashCells(box BoundingBox, precision int) []string {
+	latBits, lonBits := geohashBits(precision)
+	latFrom, latTo := geohashIndexRange(box.MinLatitude, box.MaxLatitude, -90, 180, latBits)
+
+	cells := make([]string, 0, GeohashCellCount(box, precision))
+	for _, span := range box.longitudeSpans() {
+		lonFrom, lonTo := geohashIndexRange(span[0], span[1], -180, 360, lonBits)
+		for latIdx := latFrom; latIdx <= latTo; latIdx++ {
+			for lonIdx := lonFrom; lonIdx <= lonTo; lonIdx++ {
+				cells = append(cells, encodeGeohashCell(latIdx, lonIdx, precision))
+			}
+		}
+	}
+	return cells
+}
+
+// longitudeSpans 將經度範圍拆成不跨越 180 度經線的區段
+func (b BoundingBox) longitudeSpans() [][2]float64 {
+	if b.CrossesAntimeridian() {
+		return [][2]float64{{b.MinLongitude, 180}, {-180, b.MaxLongitude}}
+	}
+	return [][2]float64{{b.MinLongitude, b.MaxLongitude}}
+}
+
+// geohashBits geohash 以經度、緯度交錯編碼，經度先取位元
+func geohashBits(precision int) (latBits, lonBits uint) {
+	bits := uint(precision) * 5
+	return bits / 2, bits - bits/2
+}
+
+func geohashIndex(value, min, span float64, bits uint) uint64 {
+	cells := uint64(1) << bits
+	idx := math.Floor((value - min) / span * float64(cells))
+	if idx < 0 {
+		return 0
+	}
+	if idx >= float64(cells) {
+		return cells - 1
+	}
+	return uint64(idx)
+}
+
+func geohashIndexRange(from, to, min, span float64, bits uint) (uint64, uint64) {
+	return geohashIndex(from, min, span, bits), geohashIndex(to, min, span, bits)
+}
+
+func encodeGeohashCell(latIdx, lonIdx uint64, precision int) string {
+	latBits, lonBits := geohashBits(precision)
+
+	var sb strings.Builder
+	sb.Grow(precision)
+	var ch, n int
+	latPos, lonPos := latBits, lonBits
+	for i := 0; i < precision*5; i++ {
+		var bit uint64
+		if i%2 == 0 {
+			lonPos--
+			bit = (lonIdx >> lonPos) & 1
+		} else {
+			latPos--
+			bit = (latIdx >> latPos) & 1
+		}
+		ch = ch<<1 | int(bit)
+		n++
+		if n == 5 {
+			sb.WriteByte(geohashAlphabet[ch])
+			ch, n = 0, 0
+		}
+	}
+	return sb.String()
+}
+
+func normalizeLongitude(longitude float64) float64 {
+	for longitude > 180 {
+		longitude -= 360
+	}
+	for longitude < -180 {
+		longitude += 360
+	}
+	return longitude
+}
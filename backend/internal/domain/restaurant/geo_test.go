@@ -0,0 +1,101 @@
+package restaurant
+
+import (
+	"testing"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		precision int
+		want      string
+	}{
+		{"jutland", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"taipei 101", 25.0330, 121.5654, 7, "wsqqqm1"},
+		{"southwest corner", -90, -180, 4, "0000"},
+		{"northeast corner", 90, 180, 4, "zzzz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EncodeGeohash(tt.lat, tt.lon, tt.precision); got != tt.want {
+				t.Errorf("EncodeGeohash(%v, %v, %d) = %q, want %q", tt.lat, tt.lon, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBoundingBox(t *testing.T) {
+	t.Run("contains every point within the radius", func(t *testing.T) {
+		center := Location{Latitude: 25.0478, Longitude: 121.5170}
+		box := NewBoundingBox(center, 5)
+		for _, bearing := range []struct{ dLat, dLon float64 }{
+			{0.0449, 0}, {-0.0449, 0}, {0, 0.0495}, {0, -0.0495}, {0.03, 0.035},
+		} {
+			lat, lon := center.Latitude+bearing.dLat, center.Longitude+bearing.dLon
+			if calculateHaversineDistance(center.Latitude, center.Longitude, lat, lon) > 5 {
+				continue
+			}
+			if !box.Contains(lat, lon) {
+				t.Errorf("box %+v does not contain (%v, %v)", box, lat, lon)
+			}
+		}
+		if box.Contains(25.2, 121.517) {
+			t.Errorf("box %+v contains a point 17 km away", box)
+		}
+	})
+
+	t.Run("crosses the antimeridian", func(t *testing.T) {
+		box := NewBoundingBox(Location{Latitude: -17.7, Longitude: 179.9}, 50)
+		if !box.CrossesAntimeridian() {
+			t.Fatalf("box %+v should cross the antimeridian", box)
+		}
+		if !box.Contains(-17.7, -179.8) || !box.Contains(-17.7, 179.7) {
+			t.Errorf("box %+v misses points on either side of the antimeridian", box)
+		}
+		if box.Contains(-17.7, 0) {
+			t.Errorf("box %+v contains the prime meridian", box)
+		}
+	})
+
+	t.Run("covers all longitudes near a pole", func(t *testing.T) {
+		box := NewBoundingBox(Location{Latitude: 89.9, Longitude: 0}, 50)
+		if box.MinLongitude != -180 || box.MaxLongitude != 180 || box.MaxLatitude != 90 {
+			t.Errorf("box = %+v, want full longitude range up to the pole", box)
+		}
+	})
+}
+
+func TestGeohashCells(t *testing.T) {
+	center := Location{Latitude: 25.0478, Longitude: 121.5170}
+	box := NewBoundingBox(center, 3)
+	cells := GeohashCells(box, 5)
+	if len(cells) != GeohashCellCount(box, 5) {
+		t.Fatalf("len(GeohashCells()) = %d, GeohashCellCount() = %d", len(cells), GeohashCellCount(box, 5))
+	}
+
+	covered := make(map[string]bool, len(cells))
+	for _, cell := range cells {
+		covered[cell] = true
+	}
+	latStep, lonStep := GeohashCellSize(6)
+	for lat := box.MinLatitude; lat <= box.MaxLatitude; lat += latStep {
+		for lon := box.MinLongitude; lon <= box.MaxLongitude; lon += lonStep {
+			if hash := EncodeGeohash(lat, lon, 5); !covered[hash] {
+				t.Fatalf("cell %s for (%v, %v) is not covered", hash, lat, lon)
+			}
+		}
+	}
+
+	antimeridian := NewBoundingBox(Location{Latitude: 0, Longitude: 180}, 10)
+	for _, lon := range []float64{179.95, -179.95} {
+		hash := EncodeGeohash(0, lon, 4)
+		found := false
+		for _, cell := range GeohashCells(antimeridian, 4) {
+			found = found || cell == hash
+		}
+		if !found {
+			t.Errorf("cell %s for longitude %v is not covered across the antimeridian", hash, lon)
+		}
+	}
+}
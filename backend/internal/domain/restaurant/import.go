@@ -0,0 +1,279 @@
+package restaurant
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// ErrUnsupportedImportFormat 不支援的匯入檔案格式
+var ErrUnsupportedImportFormat = shared.NewDomainError(shared.KindInvalidInput, "UNSUPPORTED_IMPORT_FORMAT", "unsupported import format")
+
+// ImportFormat 批次匯入的檔案格式
+type ImportFormat string
+
+const (
+	ImportFormatCSV     ImportFormat = "csv"
+	ImportFormatGeoJSON ImportFormat = "geojson"
+)
+
+// csvListSeparator CSV 欄位中多個值 (料理類型、飲食限制、圖片) 的分隔符號
+const csvListSeparator = "|"
+
+// csvColumns 支援的 CSV 欄位
+var csvColumns = map[string]bool{
+	"name": true, "description": true, "address": true, "latitude": true, "longitude": true,
+	"cuisine_types": true, "price_level": true, "phone_number": true, "website": true, "image_urls": true,
+	"supported_restrictions": true, "average_wait_time": true, "accepts_reservations": true, "opening_hours": true,
+}
+
+// csvRequiredColumns NewRestaurant 必填的欄位，缺少時整個檔案無法匯入
+var csvRequiredColumns = []string{"name", "address", "latitude", "longitude", "cuisine_types", "phone_number"}
+
+// ImportFormatFromFilename 依副檔名判斷檔案格式 (.csv、.geojson、.json)，無法判斷時回傳空字串
+func ImportFormatFromFilename(name string) ImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".geojson", ".json":
+		return ImportFormatGeoJSON
+	default:
+		return ""
+	}
+}
+
+// ParseImportFile 依格式解析匯入檔案
+// 檔案結構錯誤時回傳錯誤；單筆資料的錯誤記錄在 ImportRecord.Err，由匯入報告列出
+func ParseImportFile(format ImportFormat, r io.Reader) ([]ImportRecord, error) {
+	switch format {
+	case ImportFormatCSV:
+		return ParseCSV(r)
+	case ImportFormatGeoJSON:
+		return ParseGeoJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedImportFormat, format)
+	}
+}
+
+// ParseCSV 解析以標題列指定欄位的 CSV
+// 多個值以 | 分隔，price_level 為 1-4 或 $-$$$$，opening_hours 為營業時間 JSON
+func ParseCSV(r io.Reader) ([]ImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: CSV file is empty", shared.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", shared.ErrInvalidInput, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !csvColumns[name] {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", shared.ErrInvalidInput, name)
+		}
+		if _, exists := columns[name]; exists {
+			return nil, fmt.Errorf("%w: CSV column %q is listed more than once", shared.ErrInvalidInput, name)
+		}
+		columns[name] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, exists := columns[name]; !exists {
+			return nil, fmt.Errorf("%w: CSV column %q is required", shared.ErrInvalidInput, name)
+		}
+	}
+
+	var records []ImportRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			records = append(records, ImportRecord{Row: parseErr.StartLine, Err: fmt.Errorf("%w: %v", shared.ErrInvalidInput, parseErr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", shared.ErrInvalidInput, err)
+		}
+
+		row, _ := reader.FieldPos(0)
+		if len(fields) != len(header) {
+			records = append(records, ImportRecord{Row: row, Err: fmt.Errorf("%w: expected %d fields, got %d", shared.ErrInvalidInput, len(header), len(fields))})
+			continue
+		}
+		value := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		details, err := csvDetails(value)
+		records = append(records, ImportRecord{Row: row, Details: details, Err: err})
+	}
+	return records, nil
+}
+
+func csvDetails(value func(string) string) (RestaurantDetails, error) {
+	details := RestaurantDetails{
+		Name:        value("name"),
+		Description: value("description"),
+		Location:    Location{Address: value("address")},
+		PhoneNumber: value("phone_number"),
+		Website:     value("website"),
+		ImageURLs:   splitCSVList(value("image_urls")),
+	}
+
+	var err error
+	if details.Location.Latitude, err = parseCSVFloat("latitude", value("latitude")); err != nil {
+		return details, err
+	}
+	if details.Location.Longitude, err = parseCSVFloat("longitude", value("longitude")); err != nil {
+		return details, err
+	}
+	for _, cuisine := range splitCSVList(value("cuisine_types")) {
+		details.CuisineTypes = append(details.CuisineTypes, CuisineType(strings.ToLower(cuisine)))
+	}
+	for _, restriction := range splitCSVList(value("supported_restrictions")) {
+		details.SupportedRestrictions = append(details.SupportedRestrictions, DietaryRestriction(strings.ToLower(restriction)))
+	}
+	if details.PriceLevel, err = ParsePriceLevel(value("price_level")); err != nil {
+		return details, err
+	}
+	if raw := value("average_wait_time"); raw != "" {
+		if details.AverageWaitTime, err = strconv.Atoi(raw); err != nil {
+			return details, fmt.Errorf("%w: average_wait_time %q must be a whole number of minutes", shared.ErrInvalidInput, raw)
+		}
+	}
+	if raw := value("accepts_reservations"); raw != "" {
+		if details.AcceptsReservations, err = strconv.ParseBool(raw); err != nil {
+			return details, fmt.Errorf("%w: accepts_reservations %q must be true or false", shared.ErrInvalidInput, raw)
+		}
+	}
+	if raw := value("opening_hours"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &details.OpeningHours); err != nil {
+			return details, fmt.Errorf("%w: opening_hours: %v", shared.ErrInvalidInput, err)
+		}
+	}
+	return details, nil
+}
+
+func parseCSVFloat(column, raw string) (float64, error) {
+	if raw == "" {
+		return 0, fmt.Errorf("%w: %s is required", shared.ErrInvalidInput, column)
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %q is not a number", shared.ErrInvalidInput, column, raw)
+	}
+	return value, nil
+}
+
+func splitCSVList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, csvListSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// geoJSONFeature GeoJSON 的 Point feature，屬性名稱與管理 API 的餐廳欄位相同
+type geoJSONFeature struct {
+	Type     string `json:"type"`
+	Geometry *struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"` // [經度, 緯度]
+	} `json:"geometry"`
+	Properties struct {
+		Name                  string               `json:"name"`
+		Description           string               `json:"description"`
+		Address               string               `json:"address"`
+		CuisineTypes          []CuisineType        `json:"cuisineTypes"`
+		PriceLevel            json.RawMessage      `json:"priceLevel"` // 1-4 或 "$"-"$$$$"
+		PhoneNumber           string               `json:"phoneNumber"`
+		Website               string               `json:"website"`
+		ImageURLs             []string             `json:"imageUrls"`
+		OpeningHours          OpeningHours         `json:"openingHours"`
+		SupportedRestrictions []DietaryRestriction `json:"supportedRestrictions"`
+		AverageWaitTime       int                  `json:"averageWaitTime"`
+		AcceptsReservations   bool                 `json:"acceptsReservations"`
+	} `json:"properties"`
+}
+
+// ParseGeoJSON 解析 FeatureCollection，每個 Point feature 為一間餐廳
+func ParseGeoJSON(r io.Reader) ([]ImportRecord, error) {
+	var collection struct {
+		Type     string            `json:"type"`
+		Features []json.RawMessage `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("%w: invalid GeoJSON: %v", shared.ErrInvalidInput, err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("%w: GeoJSON must be a FeatureCollection", shared.ErrInvalidInput)
+	}
+
+	records := make([]ImportRecord, 0, len(collection.Features))
+	for i, raw := range collection.Features {
+		details, err := geoJSONDetails(raw)
+		records = append(records, ImportRecord{Row: i + 1, Details: details, Err: err})
+	}
+	return records, nil
+}
+
+func geoJSONDetails(raw json.RawMessage) (RestaurantDetails, error) {
+	var feature geoJSONFeature
+	if err := json.Unmarshal(raw, &feature); err != nil {
+		return RestaurantDetails{}, fmt.Errorf("%w: %v", shared.ErrInvalidInput, err)
+	}
+
+	props := feature.Properties
+	details := RestaurantDetails{
+		Name:                  props.Name,
+		Description:           props.Description,
+		Location:              Location{Address: props.Address},
+		CuisineTypes:          props.CuisineTypes,
+		PhoneNumber:           props.PhoneNumber,
+		Website:               props.Website,
+		ImageURLs:             props.ImageURLs,
+		OpeningHours:          props.OpeningHours,
+		SupportedRestrictions: props.SupportedRestrictions,
+		AverageWaitTime:       props.AverageWaitTime,
+		AcceptsReservations:   props.AcceptsReservations,
+	}
+	if feature.Type != "Feature" {
+		return details, fmt.Errorf("%w: GeoJSON feature type must be Feature", shared.ErrInvalidInput)
+	}
+	if feature.Geometry == nil || feature.Geometry.Type != "Point" || len(feature.Geometry.Coordinates) < 2 {
+		return details, fmt.Errorf("%w: GeoJSON geometry must be a Point", shared.ErrInvalidInput)
+	}
+	details.Location.Longitude = feature.Geometry.Coordinates[0]
+	details.Location.Latitude = feature.Geometry.Coordinates[1]
+
+	if len(props.PriceLevel) > 0 && string(props.PriceLevel) != "null" {
+		var value interface{}
+		if err := json.Unmarshal(props.PriceLevel, &value); err != nil {
+			return details, fmt.Errorf("%w: priceLevel: %v", shared.ErrInvalidInput, err)
+		}
+		level, err := ParsePriceLevel(fmt.Sprint(value))
+		if err != nil {
+			return details, err
+		}
+		details.PriceLevel = level
+	}
+	return details, nil
+}
@@ -0,0 +1,325 @@
+package restaurant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// MeetingPointStrategy 會面地點的計算方式
+type MeetingPointStrategy string
+
+const (
+	// MeetingPointGeometricMedian 使所有參與者的直線距離總和最小
+	MeetingPointGeometricMedian MeetingPointStrategy = "geometric_median"
+	// MeetingPointMinimax 使最遠參與者的直線距離最小
+	MeetingPointMinimax MeetingPointStrategy = "minimax"
+	// MeetingPointTravelTime 使所有參與者的交通時間總和最小，交通時間由 RoutingProvider 提供
+	MeetingPointTravelTime MeetingPointStrategy = "travel_time"
+	// MeetingPointCentroid 球面上的重心
+	MeetingPointCentroid MeetingPointStrategy = "centroid"
+)
+
+// DefaultMeetingPointStrategy 未指定時使用的計算方式
+const DefaultMeetingPointStrategy = MeetingPointGeometricMedian
+
+// ErrUnsupportedMeetingPointStrategy 不支援的會面地點計算方式
+var ErrUnsupportedMeetingPointStrategy = shared.NewDomainError(shared.KindInvalidInput, "UNSUPPORTED_MEETING_POINT_STRATEGY", "unsupported meeting point strategy")
+
+// IsValid 檢查計算方式是否支援
+func (s MeetingPointStrategy) IsValid() bool {
+	switch s {
+	case MeetingPointGeometricMedian, MeetingPointMinimax, MeetingPointTravelTime, MeetingPointCentroid:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoutingProvider 定義兩點間交通時間的查詢介面，實作在 Infrastructure Layer (例如地圖服務或直線距離估算)
+type RoutingProvider interface {
+	TravelTime(ctx context.Context, from, to Location) (time.Duration, error)
+}
+
+const (
+	// medianIterations Weiszfeld 演算法的最大迭代次數
+	medianIterations = 200
+	// medianToleranceKm 位置變化小於此距離即視為收斂
+	medianToleranceKm = 1e-6
+	// travelTimeGridSize 交通時間策略在參與者範圍內取樣的格點數 (每邊)
+	travelTimeGridSize = 5
+)
+
+// MeetingPointCalculator 依策略計算會面地點
+type MeetingPointCalculator struct {
+	routing RoutingProvider
+}
+
+// NewMeetingPointCalculator 建立會面地點計算器，routing 為 nil 時不支援交通時間策略
+func NewMeetingPointCalculator(routing RoutingProvider) *MeetingPointCalculator {
+	return &MeetingPointCalculator{routing: routing}
+}
+
+// Calculate 依策略計算會面地點
+func (c *MeetingPointCalculator) Calculate(ctx context.Context, strategy MeetingPointStrategy, locations []Location) (Location, error) {
+	if strategy == "" {
+		strategy = DefaultMeetingPointStrategy
+	}
+	if !strategy.IsValid() {
+		return Location{}, fmt.Errorf("%w: %q", ErrUnsupportedMeetingPointStrategy, strategy)
+	}
+	if strategy == MeetingPointTravelTime && c.routing == nil {
+		return Location{}, fmt.Errorf("%w: no routing provider configured", ErrUnsupportedMeetingPointStrategy)
+	}
+
+	if len(locations) == 0 {
+		return Location{}, nil
+	}
+	if len(locations) == 1 {
+		return locations[0], nil
+	}
+
+	plane := newTangentPlane(locations)
+	points := plane.projectAll(locations)
+
+	var center planePoint
+	switch strategy {
+	case MeetingPointCentroid:
+		center = planePoint{}
+	case MeetingPointMinimax:
+		center = minimaxCenter(points)
+	case MeetingPointTravelTime:
+		best, err := c.minTotalTravelTime(ctx, plane, points, locations)
+		if err != nil {
+			return Location{}, err
+		}
+		center = best
+	default:
+		center = geometricMedian(points)
+	}
+
+	meetingPoint := plane.unproject(center)
+	meetingPoint.Address = "會面中心點"
+	return meetingPoint, nil
+}
+
+// TravelTimes 查詢每位參與者到目的地的交通時間
+func (c *MeetingPointCalculator) TravelTimes(ctx context.Context, locations []Location, destination Location) ([]time.Duration, error) {
+	if c.routing == nil {
+		return nil, fmt.Errorf("%w: no routing provider configured", ErrUnsupportedMeetingPointStrategy)
+	}
+
+	times := make([]time.Duration, len(locations))
+	for i, location := range locations {
+		travelTime, err := c.routing.TravelTime(ctx, location, destination)
+		if err != nil {
+			return nil, err
+		}
+		times[i] = travelTime
+	}
+	return times, nil
+}
+
+// minTotalTravelTime 交通時間無法微分，在候選點中挑選交通時間總和最小者
+// 候選點為其他策略的結果、每位參與者的位置與參與者範圍內的格點，每個候選點需查詢每位參與者一次
+func (c *MeetingPointCalculator) minTotalTravelTime(ctx context.Context, plane tangentPlane, points []planePoint, locations []Location) (planePoint, error) {
+	candidates := []planePoint{{}, geometricMedian(points), minimaxCenter(points)}
+	candidates = append(candidates, points...)
+
+	minX, maxX, minY, maxY := points[0].x, points[0].x, points[0].y, points[0].y
+	for _, p := range points[1:] {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	for i := 0; i < travelTimeGridSize; i++ {
+		for j := 0; j < travelTimeGridSize; j++ {
+			candidates = append(candidates, planePoint{
+				x: minX + (maxX-minX)*float64(i)/float64(travelTimeGridSize-1),
+				y: minY + (maxY-minY)*float64(j)/float64(travelTimeGridSize-1),
+			})
+		}
+	}
+
+	best := candidates[0]
+	bestTotal := time.Duration(math.MaxInt64)
+	for _, candidate := range candidates {
+		times, err := c.TravelTimes(ctx, locations, plane.unproject(candidate))
+		if err != nil {
+			return planePoint{}, err
+		}
+
+		var total time.Duration
+		for _, t := range times {
+			total += t
+		}
+		if total < bestTotal {
+			best, bestTotal = candidate, total
+		}
+	}
+	return best, nil
+}
+
+// planePoint 切平面上的座標（公里）
+type planePoint struct {
+	x, y float64
+}
+
+func (p planePoint) distance(q planePoint) float64 {
+	return math.Hypot(p.x-q.x, p.y-q.y)
+}
+
+// tangentPlane 以參與者的球面重心為原點的等距投影
+// 經度差先正規化到 ±180 度，跨越 180 度經線的參與者仍會被投影到相鄰位置
+type tangentPlane struct {
+	origin Location
+	cosLat float64
+}
+
+func newTangentPlane(locations []Location) tangentPlane {
+	origin := sphericalCentroid(locations)
+	return tangentPlane{
+		origin: origin,
+		cosLat: math.Cos(origin.Latitude * math.Pi / 180),
+	}
+}
+
+func (t tangentPlane) project(location Location) planePoint {
+	return planePoint{
+		x: normalizeLongitude(location.Longitude-t.origin.Longitude) * t.cosLat * kmPerDegreeLat,
+		y: (location.Latitude - t.origin.Latitude) * kmPerDegreeLat,
+	}
+}
+
+func (t tangentPlane) projectAll(locations []Location) []planePoint {
+	points := make([]planePoint, len(locations))
+	for i, location := range locations {
+		points[i] = t.project(location)
+	}
+	return points
+}
+
+func (t tangentPlane) unproject(p planePoint) Location {
+	latitude := math.Max(-90, math.Min(90, t.origin.Latitude+p.y/kmPerDegreeLat))
+	longitude := t.origin.Longitude
+	if t.cosLat > 1e-9 {
+		longitude = normalizeLongitude(t.origin.Longitude + p.x/(t.cosLat*kmPerDegreeLat))
+	}
+	return Location{Latitude: latitude, Longitude: longitude}
+}
+
+// sphericalCentroid 以單位向量平均計算球面重心，避免直接平均經度在 180 度經線附近出錯
+// 參與者分布在地球兩端使平均向量趨近零時，退回第一位參與者的位置
+func sphericalCentroid(locations []Location) Location {
+	var x, y, z float64
+	for _, location := range locations {
+		lat := location.Latitude * math.Pi / 180
+		lon := location.Longitude * math.Pi / 180
+		x += math.Cos(lat) * math.Cos(lon)
+		y += math.Cos(lat) * math.Sin(lon)
+		z += math.Sin(lat)
+	}
+
+	norm := math.Sqrt(x*x + y*y + z*z)
+	if norm < 1e-9 {
+		return Location{Latitude: locations[0].Latitude, Longitude: locations[0].Longitude}
+	}
+
+	return Location{
+		Latitude:  math.Asin(z/norm) * 180 / math.Pi,
+		Longitude: math.Atan2(y, x) * 180 / math.Pi,
+	}
+}
+
+// geometricMedian 以 Weiszfeld 演算法計算距離總和最小的點
+func geometricMedian(points []planePoint) planePoint {
+	var current planePoint
+	for _, p := range points {
+		current.x += p.x
+		current.y += p.y
+	}
+	current.x /= float64(len(points))
+	current.y /= float64(len(points))
+
+	for i := 0; i < medianIterations; i++ {
+		var numX, numY, denom float64
+		for _, p := range points {
+			d := current.distance(p)
+			if d < medianToleranceKm {
+				// 目前位置與參與者重合，該項權重無限大，略過以避免除以零
+				continue
+			}
+			numX += p.x / d
+			numY += p.y / d
+			denom += 1 / d
+		}
+		if denom == 0 {
+			return current
+		}
+
+		next := planePoint{x: numX / denom, y: numY / denom}
+		moved := next.distance(current)
+		current = next
+		if moved < medianToleranceKm {
+			break
+		}
+	}
+	return current
+}
+
+// minimaxCenter 計算涵蓋所有參與者的最小圓心，即最遠距離最小的點
+func minimaxCenter(points []planePoint) planePoint {
+	center, radius := points[0], 0.0
+	inside := func(p planePoint) bool {
+		return p.distance(center) <= radius+1e-9
+	}
+
+	for i := 1; i < len(points); i++ {
+		if inside(points[i]) {
+			continue
+		}
+		center, radius = points[i], 0
+		for j := 0; j < i; j++ {
+			if inside(points[j]) {
+				continue
+			}
+			center, radius = circleFromTwo(points[i], points[j])
+			for k := 0; k < j; k++ {
+				if !inside(points[k]) {
+					center, radius = circleFromThree(points[i], points[j], points[k])
+				}
+			}
+		}
+	}
+	return center
+}
+
+func circleFromTwo(a, b planePoint) (planePoint, float64) {
+	center := planePoint{x: (a.x + b.x) / 2, y: (a.y + b.y) / 2}
+	return center, center.distance(a)
+}
+
+// circleFromThree 三點的外接圓，三點共線時取最遠兩點為直徑
+func circleFromThree(a, b, c planePoint) (planePoint, float64) {
+	d := 2 * (a.x*(b.y-c.y) + b.x*(c.y-a.y) + c.x*(a.y-b.y))
+	if math.Abs(d) < 1e-12 {
+		center, radius := circleFromTwo(a, b)
+		for _, pair := range [][2]planePoint{{a, c}, {b, c}} {
+			if cc, r := circleFromTwo(pair[0], pair[1]); r > radius {
+				center, radius = cc, r
+			}
+		}
+		return center, radius
+	}
+
+	a2 := a.x*a.x + a.y*a.y
+	b2 := b.x*b.x + b.y*b.y
+	c2 := c.x*c.x + c.y*c.y
+	center := planePoint{
+		x: (a2*(b.y-c.y) + b2*(c.y-a.y) + c2*(a.y-b.y)) / d,
+		y: (a2*(c.x-b.x) + b2*(a.x-c.x) + c2*(b.x-a.x)) / d,
+	}
+	return center, center.distance(a)
+}
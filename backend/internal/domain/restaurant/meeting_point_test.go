@@ -0,0 +1,124 @@
+package restaurant
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// straightLineRouting 以固定速度估算交通時間，speedFor 可讓特定起點移動較慢
+type straightLineRouting struct {
+	speedFor func(from Location) float64
+}
+
+func (r straightLineRouting) TravelTime(ctx context.Context, from, to Location) (time.Duration, error) {
+	speed := 30.0
+	if r.speedFor != nil {
+		speed = r.speedFor(from)
+	}
+	return time.Duration(from.DistanceTo(to) / speed * float64(time.Hour)), nil
+}
+
+func TestMeetingPointCalculator(t *testing.T) {
+	ctx := context.Background()
+	calculator := NewMeetingPointCalculator(straightLineRouting{})
+
+	t.Run("antimeridian", func(t *testing.T) {
+		// 直接平均經度會得到 0 度，落在地球另一端
+		locations := []Location{
+			{Latitude: -17.7, Longitude: 179.9},
+			{Latitude: -17.7, Longitude: -179.9},
+		}
+		for _, strategy := range []MeetingPointStrategy{MeetingPointCentroid, MeetingPointGeometricMedian, MeetingPointMinimax} {
+			got, err := calculator.Calculate(ctx, strategy, locations)
+			if err != nil {
+				t.Fatalf("Calculate(%s) error = %v", strategy, err)
+			}
+			if math.Abs(math.Abs(got.Longitude)-180) > 0.01 || math.Abs(got.Latitude+17.7) > 0.01 {
+				t.Errorf("Calculate(%s) = %+v, want near (-17.7, 180)", strategy, got)
+			}
+		}
+	})
+
+	t.Run("geometric median is pulled towards the cluster", func(t *testing.T) {
+		// 三人在台北車站、一人在淡水：中位數應留在車站附近，最遠距離最小化則落在兩者中間
+		station := Location{Latitude: 25.0478, Longitude: 121.5170}
+		tamsui := Location{Latitude: 25.1677, Longitude: 121.4456}
+		locations := []Location{station, station, station, tamsui}
+
+		median, err := calculator.Calculate(ctx, MeetingPointGeometricMedian, locations)
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		if d := median.DistanceTo(station); d > 0.1 {
+			t.Errorf("geometric median is %.2f km from the cluster, want < 0.1 km", d)
+		}
+
+		minimax, err := calculator.Calculate(ctx, MeetingPointMinimax, locations)
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		half := station.DistanceTo(tamsui) / 2
+		if d1, d2 := minimax.DistanceTo(station), minimax.DistanceTo(tamsui); math.Abs(d1-half) > 0.05 || math.Abs(d2-half) > 0.05 {
+			t.Errorf("minimax center distances = %.2f, %.2f km, want both %.2f km", d1, d2, half)
+		}
+	})
+
+	t.Run("minimax center of a triangle", func(t *testing.T) {
+		locations := []Location{
+			{Latitude: 25.00, Longitude: 121.50},
+			{Latitude: 25.00, Longitude: 121.60},
+			{Latitude: 25.08, Longitude: 121.55},
+			{Latitude: 25.02, Longitude: 121.55}, // 在圓內，不影響結果
+		}
+		center, err := calculator.Calculate(ctx, MeetingPointMinimax, locations)
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+
+		worst := 0.0
+		for _, location := range locations {
+			worst = math.Max(worst, center.DistanceTo(location))
+		}
+		for _, location := range locations[:3] {
+			if d := center.DistanceTo(location); math.Abs(d-worst) > 0.05 {
+				t.Errorf("distance to %+v = %.3f km, want equal to the worst %.3f km", location, d, worst)
+			}
+		}
+	})
+
+	t.Run("travel time favours slow participants", func(t *testing.T) {
+		walker := Location{Latitude: 25.0330, Longitude: 121.5654}
+		driver := Location{Latitude: 25.0478, Longitude: 121.5170}
+		slowWalker := NewMeetingPointCalculator(straightLineRouting{speedFor: func(from Location) float64 {
+			if from == walker {
+				return 5
+			}
+			return 40
+		}})
+
+		got, err := slowWalker.Calculate(ctx, MeetingPointTravelTime, []Location{walker, driver})
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		if got.DistanceTo(walker) >= got.DistanceTo(driver) {
+			t.Errorf("travel time meeting point %+v is not closer to the slow participant", got)
+		}
+	})
+
+	t.Run("travel time without routing provider", func(t *testing.T) {
+		_, err := NewMeetingPointCalculator(nil).Calculate(ctx, MeetingPointTravelTime, []Location{{}, {}})
+		if !errors.Is(err, ErrUnsupportedMeetingPointStrategy) {
+			t.Errorf("Calculate() error = %v, want %v", err, ErrUnsupportedMeetingPointStrategy)
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := calculator.Calculate(ctx, "closest_to_boss", []Location{{}, {}})
+		if !errors.Is(err, ErrUnsupportedMeetingPointStrategy) {
+			t.Errorf("Calculate() error = %v, want %v", err, ErrUnsupportedMeetingPointStrategy)
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	// DefaultTimeZone 未指定時區的營業時間以台北時間解讀
+	DefaultTimeZone = "Asia/Taipei"
+
+	// MaxRangesPerDay 每天最多的營業時段數
+	MaxRangesPerDay = 4
+	// MaxExceptions 最多的特殊日期數
+	MaxExceptions = 100
+
+	dateLayout     = "2006-01-02"
+	minutesPerDay  = 24 * 60
+	closedKeyword  = "closed"
+	allDayKeyword  = "24h"
+	rangeSeparator = ","
+)
+
+// weekdays 營業時間 Weekly 可用的 key
+var weekdays = map[string]bool{
+	"sunday": true, "monday": true, "tuesday": true, "wednesday": true,
+	"thursday": true, "friday": true, "saturday": true,
+}
+
+// TimeRange 一段營業時間，以當天 00:00 起算的分鐘數表示
+// Close 小於 Open 代表跨夜營業，例如 18:00-02:00 營業到隔天凌晨兩點
+type TimeRange struct {
+	Open  int
+	Close int
+}
+
+// ParseTimeRange 解析 "11:30-14:30" 格式；結束時間可以是 24:00
+func ParseTimeRange(value string) (TimeRange, error) {
+	open, close, ok := strings.Cut(strings.TrimSpace(value), "-")
+	if !ok {
+		return TimeRange{}, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("opening hours %q must be HH:MM-HH:MM", value))
+	}
+
+	openMinute, err := parseClock(open)
+	if err != nil || openMinute == minutesPerDay {
+		return TimeRange{}, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("opening hours %q has an invalid opening time", value))
+	}
+	closeMinute, err := parseClock(close)
+	if err != nil {
+		return TimeRange{}, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("opening hours %q has an invalid closing time", value))
+	}
+	if openMinute == closeMinute {
+		return TimeRange{}, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("opening hours %q must not open and close at the same time", value))
+	}
+
+	return TimeRange{Open: openMinute, Close: closeMinute}, nil
+}
+
+func (r TimeRange) String() string {
+	return formatClock(r.Open) + "-" + formatClock(r.Close)
+}
+
+// Overnight 是否營業到隔天
+func (r TimeRange) Overnight() bool {
+	return r.Close < r.Open
+}
+
+func (r TimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *TimeRange) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := ParseTimeRange(value)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// DateException 特定日期 (例如國定假日) 的營業時間，取代當天的每週營業時間
+// Hours 為空代表當天公休
+type DateException struct {
+	Date  string      `json:"date"` // 2006-01-02，以餐廳時區的日期計算
+	Hours []TimeRange `json:"hours"`
+	Note  string      `json:"note,omitempty"`
+}
+
+// OpeningHours 餐廳的營業時間
+// 時段都以餐廳所在時區的當地時間表示，跨夜時段算在開始營業的那一天
+type OpeningHours struct {
+	TimeZone   string                 `json:"timeZone,omitempty"`   // IANA 時區，空白代表 DefaultTimeZone
+	Weekly     map[string][]TimeRange `json:"weekly,omitempty"`     // key 為 monday ... sunday，沒有列出的星期為公休
+	Exceptions []DateException        `json:"exceptions,omitempty"` // 特殊日期，優先於 Weekly
+}
+
+// ParseWeeklyHours 解析 {"monday": "11:30-14:30,17:30-21:00"} 格式
+// 值可以用逗號分隔多個時段，"closed" 或空字串代表公休，"24h" 代表全天營業
+func ParseWeeklyHours(hours map[string]string) (map[string][]TimeRange, error) {
+	weekly := make(map[string][]TimeRange, len(hours))
+	for day, value := range hours {
+		day = strings.ToLower(strings.TrimSpace(day))
+		if !weekdays[day] {
+			return nil, shared.ErrInvalidInput.WithMessage(fmt.Sprintf("%q is not a day of the week", day))
+		}
+
+		value = strings.ToLower(strings.TrimSpace(value))
+		switch value {
+		case "", closedKeyword:
+			continue
+		case allDayKeyword:
+			weekly[day] = []TimeRange{{Open: 0, Close: minutesPerDay}}
+			continue
+		}
+
+		for _, part := range strings.Split(value, rangeSeparator) {
+			r, err := ParseTimeRange(part)
+			if err != nil {
+				return nil, err
+			}
+			weekly[day] = append(weekly[day], r)
+		}
+	}
+	return weekly, nil
+}
+
+// UnmarshalJSON 同時接受目前的格式與舊的 {"monday": "09:00-21:00"} 格式
+func (h *OpeningHours) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		*h = OpeningHours{}
+		return nil
+	}
+
+	_, hasTimeZone := fields["timeZone"]
+	_, hasWeekly := fields["weekly"]
+	_, hasExceptions := fields["exceptions"]
+	if hasTimeZone || hasWeekly || hasExceptions || len(fields) == 0 {
+		type plain OpeningHours
+		var decoded plain
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return err
+		}
+		*h = OpeningHours(decoded)
+		return nil
+	}
+
+	var legacy map[string]string
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+	weekly, err := ParseWeeklyHours(legacy)
+	if err != nil {
+		return err
+	}
+	*h = OpeningHours{Weekly: weekly}
+	return nil
+}
+
+// IsZero 是否沒有提供任何營業時間
+func (h OpeningHours) IsZero() bool {
+	return len(h.Weekly) == 0 && len(h.Exceptions) == 0
+}
+
+// Validate 檢查時區、星期與特殊日期是否正確
+func (h OpeningHours) Validate() error {
+	if h.TimeZone != "" {
+		if _, err := time.LoadLocation(h.TimeZone); err != nil {
+			return shared.ErrInvalidInput.WithMessage("time zone is invalid")
+		}
+	}
+
+	for day, ranges := range h.Weekly {
+		if !weekdays[day] {
+			return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("%q is not a day of the week", day))
+		}
+		if len(ranges) > MaxRangesPerDay {
+			return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("cannot have more than %d opening ranges per day", MaxRangesPerDay))
+		}
+	}
+
+	if len(h.Exceptions) > MaxExceptions {
+		return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("cannot have more than %d exceptions", MaxExceptions))
+	}
+	seen := make(map[string]bool, len(h.Exceptions))
+	for _, exception := range h.Exceptions {
+		if _, err := time.Parse(dateLayout, exception.Date); err != nil {
+			return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("exception date %q must be YYYY-MM-DD", exception.Date))
+		}
+		if seen[exception.Date] {
+			return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("exception date %s is listed more than once", exception.Date))
+		}
+		seen[exception.Date] = true
+		if len(exception.Hours) > MaxRangesPerDay {
+			return shared.ErrInvalidInput.WithMessage(fmt.Sprintf("cannot have more than %d opening ranges per day", MaxRangesPerDay))
+		}
+	}
+
+	return nil
+}
+
+// IsOpenAt 檢查指定時間是否在營業時間內
+// 除了當天的時段，也會檢查前一天跨夜營業到當天凌晨的時段
+func (h OpeningHours) IsOpenAt(t time.Time) bool {
+	local := t.In(h.Location())
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, r := range h.hoursOn(local) {
+		if minute >= r.Open && (r.Overnight() || minute < r.Close) {
+			return true
+		}
+	}
+	for _, r := range h.hoursOn(local.AddDate(0, 0, -1)) {
+		if r.Overnight() && minute < r.Close {
+			return true
+		}
+	}
+	return false
+}
+
+// Location 回傳營業時間的時區，無法載入時退回 UTC
+func (h OpeningHours) Location() *time.Location {
+	name := h.TimeZone
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// hoursOn 回傳指定日期的營業時段，特殊日期優先於每週營業時間
+func (h OpeningHours) hoursOn(date time.Time) []TimeRange {
+	key := date.Format(dateLayout)
+	for _, exception := range h.Exceptions {
+		if exception.Date == key {
+			return exception.Hours
+		}
+	}
+	return h.Weekly[strings.ToLower(date.Weekday().String())]
+}
+
+// parseClock 解析 HH:MM，回傳當天的分鐘數；允許 24:00
+func parseClock(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "24:00" {
+		return minutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
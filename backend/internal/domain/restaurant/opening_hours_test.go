@@ -0,0 +1,150 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func TestOpeningHoursIsOpenAt(t *testing.T) {
+	hours := OpeningHours{
+		TimeZone: "Asia/Taipei",
+		Weekly: map[string][]TimeRange{
+			"monday": {mustRange(t, "11:30-14:30"), mustRange(t, "17:30-21:00")},
+			"friday": {mustRange(t, "18:00-02:00")},
+			"sunday": {mustRange(t, "00:00-24:00")},
+		},
+		Exceptions: []DateException{
+			{Date: "2024-03-11", Note: "店休"},
+			{Date: "2024-03-15", Hours: []TimeRange{mustRange(t, "18:00-23:00")}},
+		},
+	}
+
+	taipei := time.FixedZone("Asia/Taipei", 8*60*60)
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"monday lunch", time.Date(2024, 3, 4, 12, 0, 0, 0, taipei), true},
+		{"monday between ranges", time.Date(2024, 3, 4, 15, 0, 0, 0, taipei), false},
+		{"monday closing time is exclusive", time.Date(2024, 3, 4, 21, 0, 0, 0, taipei), false},
+		{"monday dinner given in UTC", time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC), true},
+		{"tuesday is not listed", time.Date(2024, 3, 5, 12, 0, 0, 0, taipei), false},
+		{"friday night", time.Date(2024, 3, 8, 23, 30, 0, 0, taipei), true},
+		{"saturday after friday overnight", time.Date(2024, 3, 9, 1, 30, 0, 0, taipei), true},
+		{"sunday all day", time.Date(2024, 3, 10, 23, 59, 0, 0, taipei), true},
+		{"monday after sunday closes at midnight", time.Date(2024, 3, 11, 0, 30, 0, 0, taipei), false},
+		{"holiday closure", time.Date(2024, 3, 11, 12, 0, 0, 0, taipei), false},
+		{"exception replaces overnight range", time.Date(2024, 3, 16, 1, 0, 0, 0, taipei), false},
+		{"exception hours", time.Date(2024, 3, 15, 22, 0, 0, 0, taipei), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hours.IsOpenAt(tt.at); got != tt.want {
+				t.Errorf("IsOpenAt(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpeningHoursJSON(t *testing.T) {
+	t.Run("legacy format", func(t *testing.T) {
+		var hours OpeningHours
+		if err := json.Unmarshal([]byte(`{"monday": "09:00-21:00", "friday": "11:00-14:00, 17:00-01:00", "sunday": "closed", "saturday": "24h"}`), &hours); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		want := map[string][]TimeRange{
+			"monday":   {{Open: 9 * 60, Close: 21 * 60}},
+			"friday":   {{Open: 11 * 60, Close: 14 * 60}, {Open: 17 * 60, Close: 60}},
+			"saturday": {{Open: 0, Close: 24 * 60}},
+		}
+		if !reflect.DeepEqual(hours.Weekly, want) {
+			t.Errorf("Weekly = %v, want %v", hours.Weekly, want)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		hours := OpeningHours{
+			TimeZone:   "Asia/Tokyo",
+			Weekly:     map[string][]TimeRange{"monday": {mustRange(t, "22:00-03:00")}},
+			Exceptions: []DateException{{Date: "2024-01-01", Note: "元旦"}},
+		}
+		data, err := json.Marshal(hours)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := `{"timeZone":"Asia/Tokyo","weekly":{"monday":["22:00-03:00"]},"exceptions":[{"date":"2024-01-01","hours":null,"note":"元旦"}]}`; string(data) != want {
+			t.Errorf("Marshal() = %s, want %s", data, want)
+		}
+
+		var got OpeningHours
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, hours) {
+			t.Errorf("Unmarshal() = %+v, want %+v", got, hours)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		var hours OpeningHours
+		err := json.Unmarshal([]byte(`{"monday": "21:00"}`), &hours)
+		if !errors.Is(err, shared.ErrInvalidInput) {
+			t.Errorf("Unmarshal() error = %v, want %v", err, shared.ErrInvalidInput)
+		}
+	})
+}
+
+func TestOpeningHoursValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours OpeningHours
+	}{
+		{"unknown time zone", OpeningHours{TimeZone: "Mars/Olympus"}},
+		{"unknown day", OpeningHours{Weekly: map[string][]TimeRange{"mon": {{Open: 0, Close: 60}}}}},
+		{"bad exception date", OpeningHours{Exceptions: []DateException{{Date: "2024/01/01"}}}},
+		{"duplicate exception", OpeningHours{Exceptions: []DateException{{Date: "2024-01-01"}, {Date: "2024-01-01"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.hours.Validate(); !errors.Is(err, shared.ErrInvalidInput) {
+				t.Errorf("Validate() error = %v, want %v", err, shared.ErrInvalidInput)
+			}
+		})
+	}
+}
+
+func TestRestaurantIsOpenAt(t *testing.T) {
+	r := &Restaurant{IsActive: true}
+	at := time.Date(2024, 3, 5, 3, 0, 0, 0, time.UTC)
+
+	if !r.IsOpenAt(at) {
+		t.Error("IsOpenAt() = false, want true when opening hours are unknown")
+	}
+
+	r.OpeningHours = OpeningHours{Weekly: map[string][]TimeRange{"monday": {{Open: 9 * 60, Close: 10 * 60}}}}
+	if r.IsOpenAt(at) {
+		t.Error("IsOpenAt() = true, want false outside opening hours")
+	}
+
+	r.OpeningHours = OpeningHours{}
+	r.IsActive = false
+	if r.IsOpenAt(at) {
+		t.Error("IsOpenAt() = true, want false for an inactive restaurant")
+	}
+}
+
+func mustRange(t *testing.T, value string) TimeRange {
+	t.Helper()
+
+	r, err := ParseTimeRange(value)
+	if err != nil {
+		t.Fatalf("ParseTimeRange(%q) error = %v", value, err)
+	}
+	return r
+}
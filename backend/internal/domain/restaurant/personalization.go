@@ -0,0 +1,290 @@
+package restaurant
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	// TasteHalfLife 用餐紀錄的影響力每經過此時間減半
+	TasteHalfLife = 180 * 24 * time.Hour
+
+	// maxFavourites 口味檔案保留的最喜歡餐廳數
+	maxFavourites = 10
+
+	// cuisineAffinityShare 喜好分數中料理所佔的比例，其餘為價位
+	cuisineAffinityShare = 0.7
+)
+
+// DiningSource 用餐紀錄的來源
+type DiningSource string
+
+const (
+	DiningSourcePing            DiningSource = "ping"              // 已完成的 ping
+	DiningSourceGroupDiningPlan DiningSource = "group_dining_plan" // 已確認且已開始的聚餐
+	DiningSourceReview          DiningSource = "review"            // 餐廳評論
+)
+
+// DiningSignal 一筆用餐紀錄，Restaurant 為已對應到目錄中的餐廳
+type DiningSignal struct {
+	Restaurant *Restaurant
+	Source     DiningSource
+	Stars      int // 只有評論有星數
+	OccurredAt time.Time
+}
+
+// weight 用過餐算 1 分，評論依星數加減 (3 星不加減)，再依經過時間衰減
+func (s DiningSignal) weight(now time.Time) float64 {
+	weight := 1.0
+	if s.Source == DiningSourceReview {
+		weight = float64(s.Stars - 3)
+	}
+	if age := now.Sub(s.OccurredAt); age > 0 {
+		weight *= math.Pow(0.5, float64(age)/float64(TasteHalfLife))
+	}
+	return weight
+}
+
+// DiningHistory 提供用戶的用餐紀錄，實作在 Application Layer (查詢 ping、聚餐計畫與評論)
+type DiningHistory interface {
+	DiningSignals(ctx context.Context, userID shared.UserID) ([]DiningSignal, error)
+}
+
+// FavouriteRestaurant 口味檔案中喜歡的餐廳
+type FavouriteRestaurant struct {
+	RestaurantID shared.RestaurantID `json:"restaurantId"`
+	Name         string              `json:"name"`
+	CuisineTypes []CuisineType       `json:"cuisineTypes"`
+	Score        float64             `json:"score"`   // 0-1，群組為各成員分數的平均
+	LikedBy      int                 `json:"likedBy"` // 喜歡此餐廳的成員數
+}
+
+// TasteProfile 由用餐紀錄計算出的料理與價位喜好，數值範圍 -1 到 1，0 為沒有偏好
+type TasteProfile struct {
+	Members     int                     `json:"members"` // 有用餐紀錄的成員數
+	Cuisines    map[CuisineType]float64 `json:"cuisines"`
+	PriceLevels map[PriceLevel]float64  `json:"priceLevels"`
+	Favourites  []FavouriteRestaurant   `json:"favourites"`
+}
+
+// IsEmpty 沒有任何用餐紀錄
+func (p *TasteProfile) IsEmpty() bool {
+	return p == nil || p.Members == 0
+}
+
+// BuildTasteProfile 由單一用戶的用餐紀錄計算口味檔案
+func BuildTasteProfile(signals []DiningSignal, now time.Time) *TasteProfile {
+	profile := newTasteProfile()
+
+	scores := make(map[shared.RestaurantID]float64)
+	restaurants := make(map[shared.RestaurantID]*Restaurant)
+	for _, signal := range signals {
+		if signal.Restaurant == nil {
+			continue
+		}
+		scores[signal.Restaurant.ID] += signal.weight(now)
+		restaurants[signal.Restaurant.ID] = signal.Restaurant
+	}
+	if len(restaurants) == 0 {
+		return profile
+	}
+	profile.Members = 1
+
+	maxScore := 0.0
+	for _, score := range scores {
+		maxScore = math.Max(maxScore, math.Abs(score))
+	}
+	if maxScore == 0 {
+		return profile
+	}
+
+	for id, rest := range restaurants {
+		score := scores[id] / maxScore
+		if len(rest.CuisineTypes) > 0 {
+			share := score / float64(len(rest.CuisineTypes))
+			for _, cuisine := range rest.CuisineTypes {
+				profile.Cuisines[cuisine] += share
+			}
+		}
+		profile.PriceLevels[rest.PriceLevel] += score
+
+		if score > 0 {
+			profile.Favourites = append(profile.Favourites, FavouriteRestaurant{
+				RestaurantID: id,
+				Name:         rest.Name,
+				CuisineTypes: rest.CuisineTypes,
+				Score:        score,
+				LikedBy:      1,
+			})
+		}
+	}
+
+	normalizeAffinities(profile.Cuisines)
+	normalizeAffinities(profile.PriceLevels)
+	profile.sortFavourites()
+	return profile
+}
+
+// MergeTasteProfiles 合併成員的口味檔案為群組口味檔案，喜好取有紀錄成員的平均
+func MergeTasteProfiles(profiles ...*TasteProfile) *TasteProfile {
+	merged := newTasteProfile()
+	favourites := make(map[shared.RestaurantID]*FavouriteRestaurant)
+
+	for _, profile := range profiles {
+		if profile.IsEmpty() {
+			continue
+		}
+		merged.Members += profile.Members
+		for cuisine, affinity := range profile.Cuisines {
+			merged.Cuisines[cuisine] += affinity * float64(profile.Members)
+		}
+		for level, affinity := range profile.PriceLevels {
+			merged.PriceLevels[level] += affinity * float64(profile.Members)
+		}
+		for _, favourite := range profile.Favourites {
+			existing, ok := favourites[favourite.RestaurantID]
+			if !ok {
+				copied := favourite
+				copied.Score *= float64(profile.Members)
+				favourites[favourite.RestaurantID] = &copied
+				continue
+			}
+			existing.Score += favourite.Score * float64(profile.Members)
+			existing.LikedBy += favourite.LikedBy
+		}
+	}
+	if merged.Members == 0 {
+		return merged
+	}
+
+	for cuisine := range merged.Cuisines {
+		merged.Cuisines[cuisine] /= float64(merged.Members)
+	}
+	for level := range merged.PriceLevels {
+		merged.PriceLevels[level] /= float64(merged.Members)
+	}
+	for _, favourite := range favourites {
+		favourite.Score /= float64(merged.Members)
+		merged.Favourites = append(merged.Favourites, *favourite)
+	}
+	merged.sortFavourites()
+	return merged
+}
+
+// RecommendationExplanation 推薦原因，例如「因為你喜歡鼎泰豐」
+type RecommendationExplanation struct {
+	LikedRestaurantID   shared.RestaurantID `json:"likedRestaurantId"`
+	LikedRestaurantName string              `json:"likedRestaurantName"`
+	SharedCuisines      []CuisineType       `json:"sharedCuisines,omitempty"`
+	Message             string              `json:"message"`
+}
+
+// Affinity 計算餐廳與口味檔案的相符分數 (0-100，50 為沒有偏好)
+// 分數高於 50 且能找到相似的喜歡餐廳時附上推薦原因
+func (p *TasteProfile) Affinity(rest *Restaurant) (float64, *RecommendationExplanation) {
+	if p.IsEmpty() {
+		return 50, nil
+	}
+
+	cuisine := 0.0
+	for i, c := range rest.CuisineTypes {
+		if affinity := p.Cuisines[c]; i == 0 || affinity > cuisine {
+			cuisine = affinity
+		}
+	}
+	raw := cuisineAffinityShare*cuisine + (1-cuisineAffinityShare)*p.PriceLevels[rest.PriceLevel]
+	score := (raw + 1) * 50
+	if raw <= 0 {
+		return score, nil
+	}
+	return score, p.explain(rest)
+}
+
+// explain 優先說明曾經喜歡同一間餐廳，否則找料理類型相同的喜歡餐廳
+func (p *TasteProfile) explain(rest *Restaurant) *RecommendationExplanation {
+	subject := "you"
+	if p.Members > 1 {
+		subject = "your group"
+	}
+
+	for _, favourite := range p.Favourites {
+		if favourite.RestaurantID == rest.ID {
+			return &RecommendationExplanation{
+				LikedRestaurantID:   favourite.RestaurantID,
+				LikedRestaurantName: favourite.Name,
+				Message:             fmt.Sprintf("Because %s liked %s before", subject, favourite.Name),
+			}
+		}
+	}
+
+	for _, favourite := range p.Favourites {
+		common := sharedCuisines(favourite.CuisineTypes, rest.CuisineTypes)
+		if len(common) == 0 {
+			continue
+		}
+		return &RecommendationExplanation{
+			LikedRestaurantID:   favourite.RestaurantID,
+			LikedRestaurantName: favourite.Name,
+			SharedCuisines:      common,
+			Message:             fmt.Sprintf("Because %s liked %s", subject, favourite.Name),
+		}
+	}
+	return nil
+}
+
+func newTasteProfile() *TasteProfile {
+	return &TasteProfile{
+		Cuisines:    make(map[CuisineType]float64),
+		PriceLevels: make(map[PriceLevel]float64),
+		Favourites:  []FavouriteRestaurant{},
+	}
+}
+
+// sortFavourites 依喜歡的成員數、分數排序，只保留前 maxFavourites 間
+func (p *TasteProfile) sortFavourites() {
+	sort.Slice(p.Favourites, func(i, j int) bool {
+		a, b := p.Favourites[i], p.Favourites[j]
+		if a.LikedBy != b.LikedBy {
+			return a.LikedBy > b.LikedBy
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.RestaurantID.String() < b.RestaurantID.String()
+	})
+	if len(p.Favourites) > maxFavourites {
+		p.Favourites = p.Favourites[:maxFavourites]
+	}
+}
+
+// normalizeAffinities 以絕對值最大者為基準縮放到 -1 到 1
+func normalizeAffinities[K comparable](affinities map[K]float64) {
+	maxAbs := 0.0
+	for _, affinity := range affinities {
+		maxAbs = math.Max(maxAbs, math.Abs(affinity))
+	}
+	if maxAbs == 0 {
+		return
+	}
+	for key := range affinities {
+		affinities[key] /= maxAbs
+	}
+}
+
+func sharedCuisines(a, b []CuisineType) []CuisineType {
+	var common []CuisineType
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				common = append(common, x)
+				break
+			}
+		}
+	}
+	return common
+}
@@ -0,0 +1,177 @@
+package restaurant
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func testRestaurant(name string, price PriceLevel, cuisines ...CuisineType) *Restaurant {
+	return &Restaurant{
+		ID:           shared.NewRestaurantID(),
+		Name:         name,
+		CuisineTypes: cuisines,
+		PriceLevel:   price,
+		Rating:       4,
+		IsActive:     true,
+	}
+}
+
+func TestBuildTasteProfile(t *testing.T) {
+	now := time.Now()
+	ramen := testRestaurant("一蘭", PriceLevelBudget, CuisineTypeJapanese)
+	steak := testRestaurant("王品", PriceLevelVeryExpensive, CuisineTypeWestern)
+
+	signals := []DiningSignal{
+		{Restaurant: ramen, Source: DiningSourcePing, OccurredAt: now.Add(-24 * time.Hour)},
+		{Restaurant: ramen, Source: DiningSourceGroupDiningPlan, OccurredAt: now.Add(-48 * time.Hour)},
+		{Restaurant: ramen, Source: DiningSourceReview, Stars: 5, OccurredAt: now.Add(-24 * time.Hour)},
+		{Restaurant: steak, Source: DiningSourcePing, OccurredAt: now.Add(-24 * time.Hour)},
+		{Restaurant: steak, Source: DiningSourceReview, Stars: 1, OccurredAt: now.Add(-24 * time.Hour)},
+	}
+	profile := BuildTasteProfile(signals, now)
+
+	if profile.Members != 1 {
+		t.Errorf("Members = %d, want 1", profile.Members)
+	}
+	if got := profile.Cuisines[CuisineTypeJapanese]; math.Abs(got-1) > 1e-9 {
+		t.Errorf("Cuisines[japanese] = %.3f, want 1", got)
+	}
+	if got := profile.Cuisines[CuisineTypeWestern]; got >= 0 {
+		t.Errorf("Cuisines[western] = %.3f, want negative after a 1 star review", got)
+	}
+	if got := profile.PriceLevels[PriceLevelVeryExpensive]; got >= 0 {
+		t.Errorf("PriceLevels[very expensive] = %.3f, want negative", got)
+	}
+	if len(profile.Favourites) != 1 || profile.Favourites[0].RestaurantID != ramen.ID {
+		t.Errorf("Favourites = %+v, want only %s", profile.Favourites, ramen.Name)
+	}
+
+	t.Run("old visits count less", func(t *testing.T) {
+		recent := testRestaurant("鼎泰豐", PriceLevelMidRange, CuisineTypeChinese)
+		old := testRestaurant("欣葉", PriceLevelMidRange, CuisineTypeTaiwanese)
+		profile := BuildTasteProfile([]DiningSignal{
+			{Restaurant: recent, Source: DiningSourcePing, OccurredAt: now},
+			{Restaurant: old, Source: DiningSourcePing, OccurredAt: now.Add(-TasteHalfLife)},
+		}, now)
+		if got := profile.Cuisines[CuisineTypeTaiwanese]; math.Abs(got-0.5) > 1e-9 {
+			t.Errorf("Cuisines[taiwanese] one half-life ago = %.3f, want 0.5", got)
+		}
+	})
+
+	t.Run("no history", func(t *testing.T) {
+		if profile := BuildTasteProfile(nil, now); !profile.IsEmpty() {
+			t.Errorf("BuildTasteProfile(nil) = %+v, want empty", profile)
+		}
+	})
+}
+
+func TestTasteProfileAffinityAndExplanation(t *testing.T) {
+	now := time.Now()
+	liked := testRestaurant("一蘭", PriceLevelBudget, CuisineTypeJapanese)
+	alice := BuildTasteProfile([]DiningSignal{
+		{Restaurant: liked, Source: DiningSourceReview, Stars: 5, OccurredAt: now},
+	}, now)
+
+	similar := testRestaurant("屯京", PriceLevelBudget, CuisineTypeJapanese)
+	score, explanation := alice.Affinity(similar)
+	if score != 100 {
+		t.Errorf("Affinity(similar) = %.1f, want 100", score)
+	}
+	if explanation == nil || explanation.LikedRestaurantID != liked.ID || explanation.Message != "Because you liked 一蘭" {
+		t.Fatalf("Affinity(similar) explanation = %+v", explanation)
+	}
+	if len(explanation.SharedCuisines) != 1 || explanation.SharedCuisines[0] != CuisineTypeJapanese {
+		t.Errorf("SharedCuisines = %v, want [japanese]", explanation.SharedCuisines)
+	}
+
+	if _, explanation := alice.Affinity(liked); explanation == nil || explanation.Message != "Because you liked 一蘭 before" {
+		t.Errorf("Affinity(liked) explanation = %+v", explanation)
+	}
+
+	unrelated := testRestaurant("王品", PriceLevelVeryExpensive, CuisineTypeWestern)
+	if score, explanation := alice.Affinity(unrelated); score != 50 || explanation != nil {
+		t.Errorf("Affinity(unrelated) = %.1f, %+v, want neutral 50 without explanation", score, explanation)
+	}
+
+	t.Run("group", func(t *testing.T) {
+		bob := BuildTasteProfile([]DiningSignal{
+			{Restaurant: unrelated, Source: DiningSourcePing, OccurredAt: now},
+		}, now)
+		group := MergeTasteProfiles(alice, bob, BuildTasteProfile(nil, now))
+
+		if group.Members != 2 {
+			t.Errorf("Members = %d, want 2", group.Members)
+		}
+		if got := group.Cuisines[CuisineTypeJapanese]; math.Abs(got-0.5) > 1e-9 {
+			t.Errorf("Cuisines[japanese] = %.3f, want 0.5", got)
+		}
+		_, explanation := group.Affinity(similar)
+		if explanation == nil || !strings.HasPrefix(explanation.Message, "Because your group liked") {
+			t.Errorf("group explanation = %+v", explanation)
+		}
+	})
+}
+
+type fakeDiningHistory map[shared.UserID][]DiningSignal
+
+func (h fakeDiningHistory) DiningSignals(ctx context.Context, userID shared.UserID) ([]DiningSignal, error) {
+	return h[userID], nil
+}
+
+func TestScoringWeightProfiles(t *testing.T) {
+	for name, weights := range DefaultWeightProfiles() {
+		if err := weights.Validate(); err != nil {
+			t.Errorf("profile %s: Validate() error = %v", name, err)
+		}
+	}
+
+	if _, err := DefaultWeightProfiles().Resolve("cheapest"); !errors.Is(err, ErrUnsupportedWeightProfile) {
+		t.Errorf("Resolve(unknown) error = %v, want %v", err, ErrUnsupportedWeightProfile)
+	}
+	if err := (ScoringWeights{Affinity: 1}).Validate(); err == nil {
+		t.Error("Validate() with only affinity weight succeeded, want error")
+	}
+
+	// 沒有用餐紀錄時 balanced 與原本的固定權重相同
+	balanced, _ := DefaultWeightProfiles().Resolve("")
+	got := balanced.normalized(false)
+	want := ScoringWeights{Distance: 0.40, Rating: 0.25, Cuisine: 0.20, Price: 0.10, Restrictions: 0.05}
+	if math.Abs(got.Distance-want.Distance) > 1e-9 || math.Abs(got.Rating-want.Rating) > 1e-9 || got.Affinity != 0 {
+		t.Errorf("balanced without history = %+v, want %+v", got, want)
+	}
+}
+
+func TestPersonalizedScoring(t *testing.T) {
+	now := time.Now()
+	user := shared.NewUserID()
+	liked := testRestaurant("一蘭", PriceLevelBudget, CuisineTypeJapanese)
+	history := fakeDiningHistory{user: {{Restaurant: liked, Source: DiningSourceReview, Stars: 5, OccurredAt: now}}}
+
+	japanese := testRestaurant("屯京", PriceLevelBudget, CuisineTypeJapanese)
+	western := testRestaurant("王品", PriceLevelBudget, CuisineTypeWestern)
+	center := Location{Latitude: 25.0330, Longitude: 121.5654}
+	req := RecommendationRequest{ParticipantLocations: []Location{center}, MaxDistance: 10, ParticipantIDs: []shared.UserID{user}}
+
+	service := NewRecommendationService(nil, nil, history, nil)
+	taste, err := service.TasteProfile(context.Background(), req.ParticipantIDs)
+	if err != nil {
+		t.Fatalf("TasteProfile() error = %v", err)
+	}
+	weights, _ := service.profiles.Resolve("personalized")
+	weights = weights.normalized(!taste.IsEmpty())
+
+	japaneseResult := service.calculateRecommendationScore(japanese, req, center, weights, taste)
+	westernResult := service.calculateRecommendationScore(western, req, center, weights, taste)
+	if japaneseResult.Score <= westernResult.Score {
+		t.Errorf("score(japanese) = %.1f, score(western) = %.1f, want japanese ranked higher", japaneseResult.Score, westernResult.Score)
+	}
+	if japaneseResult.Explanation == nil || westernResult.Explanation != nil {
+		t.Errorf("explanations = %+v, %+v, want only the japanese restaurant explained", japaneseResult.Explanation, westernResult.Explanation)
+	}
+}
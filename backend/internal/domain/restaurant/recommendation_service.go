@@ -3,17 +3,30 @@ package restaurant
 import (
 	"context"
 	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 // RecommendationService 餐廳推薦服務
 type RecommendationService struct {
-	repository Repository
+	repository   Repository
+	meetingPoint *MeetingPointCalculator
+	history      DiningHistory
+	profiles     WeightProfiles
 }
 
 // NewRecommendationService 建立餐廳推薦服務
-func NewRecommendationService(repository Repository) *RecommendationService {
+// routing 為 nil 時不支援交通時間策略；history 為 nil 時不依用餐紀錄個人化；profiles 為 nil 時使用內建權重設定
+func NewRecommendationService(repository Repository, routing RoutingProvider, history DiningHistory, profiles WeightProfiles) *RecommendationService {
+	if profiles == nil {
+		profiles = DefaultWeightProfiles()
+	}
 	return &RecommendationService{
-		repository: repository,
+		repository:   repository,
+		meetingPoint: NewMeetingPointCalculator(routing),
+		history:      history,
+		profiles:     profiles,
 	}
 }
 
@@ -25,6 +38,10 @@ type RecommendationRequest struct {
 	DietaryRestrictions  []DietaryRestriction `json:"dietaryRestrictions,omitempty"`
 	MaxDistance          float64              `json:"maxDistance,omitempty"` // 公里
 	MaxResults           int                  `json:"maxResults,omitempty"`
+	MealTime             *time.Time           `json:"mealTime,omitempty"` // 用餐時間，排除當時未營業的餐廳
+	MeetingPointStrategy MeetingPointStrategy `json:"meetingPointStrategy,omitempty"`
+	WeightProfile        string               `json:"weightProfile,omitempty"` // 空字串使用 DefaultWeightProfile
+	ParticipantIDs       []shared.UserID      `json:"-"`                       // 依這些用戶的用餐紀錄個人化
 }
 
 // RecommendationResult 推薦結果
@@ -34,6 +51,20 @@ type RecommendationResult struct {
 	DistanceFromCenter float64     `json:"distanceFromCenter"` // 距離中心點的距離 (公里)
 	AverageDistance    float64     `json:"averageDistance"`    // 平均距離 (公里)
 	MaxDistance        float64     `json:"maxDistance"`        // 最遠距離 (公里)
+
+	ParticipantDistances []ParticipantDistance `json:"participantDistances"`
+	FarthestParticipant  int                   `json:"farthestParticipant"` // 最遠參與者在 ParticipantLocations 中的索引
+
+	AffinityScore float64                    `json:"affinityScore,omitempty"` // 與參與者用餐喜好的相符分數 (0-100)，沒有用餐紀錄時省略
+	Explanation   *RecommendationExplanation `json:"explanation,omitempty"`
+}
+
+// ParticipantDistance 參與者到餐廳的距離
+type ParticipantDistance struct {
+	ParticipantIndex int     `json:"participantIndex"` // 在 ParticipantLocations 中的索引
+	Address          string  `json:"address,omitempty"`
+	DistanceKm       float64 `json:"distanceKm"`
+	TravelMinutes    float64 `json:"travelMinutes,omitempty"` // 只有交通時間策略會查詢
 }
 
 // GetRecommendations 獲取餐廳推薦
@@ -46,8 +77,26 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, req Reco
 		req.MaxResults = 10 // 預設 10 個結果
 	}
 
-	// 1. 計算最佳會面地點（所有參與者位置的中心點）
-	centerLocation := calculateOptimalMeetingPoint(req.ParticipantLocations)
+	if req.MeetingPointStrategy == "" {
+		req.MeetingPointStrategy = DefaultMeetingPointStrategy
+	}
+
+	weights, err := s.profiles.Resolve(req.WeightProfile)
+	if err != nil {
+		return nil, err
+	}
+	taste, err := s.TasteProfile(ctx, req.ParticipantIDs)
+	if err != nil {
+		return nil, err
+	}
+	// 沒有用餐紀錄時忽略喜好權重，其餘項目依比例分配
+	weights = weights.normalized(!taste.IsEmpty())
+
+	// 1. 依策略計算會面地點
+	centerLocation, err := s.meetingPoint.Calculate(ctx, req.MeetingPointStrategy, req.ParticipantLocations)
+	if err != nil {
+		return nil, err
+	}
 
 	// 2. 搜尋附近的餐廳
 	criteria := SearchCriteria{
@@ -60,6 +109,7 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, req Reco
 		SortOrder:           OrderAsc,
 		Limit:               req.MaxResults * 2, // 取更多結果以便計算分數後篩選
 		IsActive:            boolPtr(true),
+		OpenAt:              req.MealTime,
 	}
 
 	restaurants, err := s.repository.Search(ctx, criteria)
@@ -70,10 +120,16 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, req Reco
 	// 3. 計算每個餐廳的推薦分數
 	results := make([]*RecommendationResult, 0, len(restaurants))
 	for _, restaurant := range restaurants {
-		result := s.calculateRecommendationScore(restaurant, req, centerLocation)
+		result := s.calculateRecommendationScore(restaurant, req, centerLocation, weights, taste)
 		results = append(results, result)
 	}
 
+	if req.MeetingPointStrategy == MeetingPointTravelTime {
+		if err := s.addTravelTimes(ctx, results, req.ParticipantLocations); err != nil {
+			return nil, err
+		}
+	}
+
 	// 4. 根據分數排序
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Score > results[j].Score
@@ -87,35 +143,13 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, req Reco
 	return results, nil
 }
 
-// calculateOptimalMeetingPoint 計算最佳會面地點
-func calculateOptimalMeetingPoint(locations []Location) Location {
-	if len(locations) == 0 {
-		return Location{}
-	}
-
-	if len(locations) == 1 {
-		return locations[0]
-	}
-
-	// 簡單的重心計算（算術平均）
-	var totalLat, totalLon float64
-	for _, loc := range locations {
-		totalLat += loc.Latitude
-		totalLon += loc.Longitude
-	}
-
-	return Location{
-		Latitude:  totalLat / float64(len(locations)),
-		Longitude: totalLon / float64(len(locations)),
-		Address:   "會面中心點",
-	}
-}
-
 // calculateRecommendationScore 計算推薦分數
 func (s *RecommendationService) calculateRecommendationScore(
 	restaurant *Restaurant,
 	req RecommendationRequest,
 	centerLocation Location,
+	weights ScoringWeights,
+	taste *TasteProfile,
 ) *RecommendationResult {
 	result := &RecommendationResult{
 		Restaurant: restaurant,
@@ -124,49 +158,109 @@ func (s *RecommendationService) calculateRecommendationScore(
 	// 計算距離資訊
 	result.DistanceFromCenter = restaurant.CalculateDistance(centerLocation)
 	
-	distances := make([]float64, len(req.ParticipantLocations))
+	result.ParticipantDistances = make([]ParticipantDistance, len(req.ParticipantLocations))
 	totalDistance := 0.0
 	maxDistance := 0.0
 	
 	for i, location := range req.ParticipantLocations {
 		distance := restaurant.CalculateDistance(location)
-		distances[i] = distance
+		result.ParticipantDistances[i] = ParticipantDistance{
+			ParticipantIndex: i,
+			Address:          location.Address,
+			DistanceKm:       distance,
+		}
 		totalDistance += distance
 		if distance > maxDistance {
 			maxDistance = distance
+			result.FarthestParticipant = i
 		}
 	}
 	
 	result.AverageDistance = totalDistance / float64(len(req.ParticipantLocations))
 	result.MaxDistance = maxDistance
 
-	// 計算推薦分數 (0-100)
+	// 計算推薦分數 (0-100)，各項目分數皆為 0-100，依權重加總
 	score := 0.0
 
-	// 1. 距離分數 (40% 權重) - 距離越近分數越高
-	distanceScore := calculateDistanceScore(result.AverageDistance, req.MaxDistance)
-	score += distanceScore * 0.4
+	// 1. 距離分數 - 距離越近分數越高，minimax 策略以最遠參與者的距離計算
+	fairDistance := result.AverageDistance
+	if req.MeetingPointStrategy == MeetingPointMinimax {
+		fairDistance = result.MaxDistance
+	}
+	distanceScore := calculateDistanceScore(fairDistance, req.MaxDistance)
+	score += distanceScore * weights.Distance
 
-	// 2. 評分分數 (25% 權重)
+	// 2. 評分分數
 	ratingScore := (restaurant.Rating / 5.0) * 100
-	score += ratingScore * 0.25
+	score += ratingScore * weights.Rating
 
-	// 3. 料理偏好分數 (20% 權重)
+	// 3. 料理偏好分數
 	cuisineScore := calculateCuisineScore(restaurant.CuisineTypes, req.CuisinePreferences)
-	score += cuisineScore * 0.20
+	score += cuisineScore * weights.Cuisine
 
-	// 4. 價位適配分數 (10% 權重)
+	// 4. 價位適配分數
 	priceScore := calculatePriceScore(restaurant.PriceLevel, req.PriceRange)
-	score += priceScore * 0.10
+	score += priceScore * weights.Price
 
-	// 5. 飲食限制適配分數 (5% 權重)
+	// 5. 飲食限制適配分數
 	restrictionScore := calculateRestrictionScore(restaurant.SupportedRestrictions, req.DietaryRestrictions)
-	score += restrictionScore * 0.05
+	score += restrictionScore * weights.Restrictions
+
+	// 6. 用餐喜好分數 - 沒有用餐紀錄時權重為 0
+	if !taste.IsEmpty() {
+		result.AffinityScore, result.Explanation = taste.Affinity(restaurant)
+		score += result.AffinityScore * weights.Affinity
+	}
 
 	result.Score = score
 	return result
 }
 
+// TasteProfile 以用戶的用餐紀錄計算口味檔案，多位用戶時合併為群組口味檔案
+// 沒有設定用餐紀錄來源或沒有用戶時回傳空的口味檔案
+func (s *RecommendationService) TasteProfile(ctx context.Context, userIDs []shared.UserID) (*TasteProfile, error) {
+	if s.history == nil || len(userIDs) == 0 {
+		return newTasteProfile(), nil
+	}
+
+	now := time.Now()
+	profiles := make([]*TasteProfile, 0, len(userIDs))
+	seen := make(map[shared.UserID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		signals, err := s.history.DiningSignals(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, BuildTasteProfile(signals, now))
+	}
+	return MergeTasteProfiles(profiles...), nil
+}
+
+// addTravelTimes 查詢每位參與者到餐廳的交通時間，最遠參與者改以交通時間判斷
+func (s *RecommendationService) addTravelTimes(ctx context.Context, results []*RecommendationResult, locations []Location) error {
+	for _, result := range results {
+		times, err := s.meetingPoint.TravelTimes(ctx, locations, result.Restaurant.Location)
+		if err != nil {
+			return err
+		}
+
+		farthest := 0
+		for i, travelTime := range times {
+			result.ParticipantDistances[i].TravelMinutes = travelTime.Minutes()
+			if travelTime > times[farthest] {
+				farthest = i
+			}
+		}
+		result.FarthestParticipant = farthest
+	}
+	return nil
+}
+
 // calculateDistanceScore 計算距離分數
 func calculateDistanceScore(averageDistance, maxDistance float64) float64 {
 	if averageDistance >= maxDistance {
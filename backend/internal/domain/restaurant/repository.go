@@ -2,6 +2,7 @@ package restaurant
 
 import (
 	"context"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -54,6 +55,7 @@ type SearchCriteria struct {
 	MinRating           float64 `json:"minRating,omitempty"`
 	AcceptsReservations *bool   `json:"acceptsReservations,omitempty"`
 	IsActive            *bool   `json:"isActive,omitempty"`
+	OpenAt              *time.Time `json:"openAt,omitempty"` // 只保留此時間營業中的餐廳
 }
 
 // PriceRange 價位範圍
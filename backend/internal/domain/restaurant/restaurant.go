@@ -1,8 +1,9 @@
 package restaurant
 
 import (
-	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
@@ -41,6 +42,25 @@ func (p PriceLevel) String() string {
 	}
 }
 
+// ParsePriceLevel 解析價位等級，接受 1-4 或 $-$$$$，空字串為 PriceLevelUnknown
+func ParsePriceLevel(value string) (PriceLevel, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", "0":
+		return PriceLevelUnknown, nil
+	case "1", "$":
+		return PriceLevelBudget, nil
+	case "2", "$$":
+		return PriceLevelMidRange, nil
+	case "3", "$$$":
+		return PriceLevelExpensive, nil
+	case "4", "$$$$":
+		return PriceLevelVeryExpensive, nil
+	default:
+		return PriceLevelUnknown, fmt.Errorf("%w: price level %q must be 1-4 or $-$$$$", shared.ErrInvalidInput, value)
+	}
+}
+
 func (p PriceLevel) ToRange() (int, int) {
 	switch p {
 	case PriceLevelBudget:
@@ -105,6 +125,18 @@ func (c CuisineType) DisplayName() string {
 	}
 }
 
+// IsValid 檢查是否為支援的料理類型
+func (c CuisineType) IsValid() bool {
+	switch c {
+	case CuisineTypeTaiwanese, CuisineTypeChinese, CuisineTypeJapanese, CuisineTypeKorean,
+		CuisineTypeWestern, CuisineTypeItalian, CuisineTypeThai, CuisineTypeVietnamese,
+		CuisineTypeVegetarian, CuisineTypeSeafood, CuisineTypeBarbecue, CuisineTypeHotpot:
+		return true
+	default:
+		return false
+	}
+}
+
 // DietaryRestriction 代表飲食限制
 type DietaryRestriction string
 
@@ -118,6 +150,17 @@ const (
 	DietaryRestrictionNutFree    DietaryRestriction = "nut_free"
 )
 
+// IsValid 檢查是否為支援的飲食限制
+func (d DietaryRestriction) IsValid() bool {
+	switch d {
+	case DietaryRestrictionVegetarian, DietaryRestrictionVegan, DietaryRestrictionHalal, DietaryRestrictionKosher,
+		DietaryRestrictionGlutenFree, DietaryRestrictionDairyFree, DietaryRestrictionNutFree:
+		return true
+	default:
+		return false
+	}
+}
+
 // Restaurant 代表餐廳實體
 type Restaurant struct {
 	ID                   shared.RestaurantID  `json:"id"`
@@ -131,7 +174,7 @@ type Restaurant struct {
 	PhoneNumber          string               `json:"phoneNumber"`
 	Website              string               `json:"website,omitempty"`
 	ImageURLs            []string             `json:"imageUrls,omitempty"`
-	OpeningHours         map[string]string    `json:"openingHours"`         // "monday": "09:00-21:00"
+	OpeningHours         OpeningHours         `json:"openingHours"`
 	SupportedRestrictions []DietaryRestriction `json:"supportedRestrictions"` // 支援的飲食限制
 	AverageWaitTime      int                  `json:"averageWaitTime"`      // 平均等候時間（分鐘）
 	AcceptsReservations  bool                 `json:"acceptsReservations"`  // 是否接受預訂
@@ -149,19 +192,19 @@ func NewRestaurant(
 	phoneNumber string,
 ) (*Restaurant, error) {
 	if name == "" {
-		return nil, errors.New("restaurant name is required")
+		return nil, shared.ErrInvalidInput.WithMessage("restaurant name is required")
 	}
 
 	if location.Address == "" {
-		return nil, errors.New("restaurant address is required")
+		return nil, shared.ErrInvalidInput.WithMessage("restaurant address is required")
 	}
 
 	if len(cuisineTypes) == 0 {
-		return nil, errors.New("at least one cuisine type is required")
+		return nil, shared.ErrInvalidInput.WithMessage("at least one cuisine type is required")
 	}
 
 	if phoneNumber == "" {
-		return nil, errors.New("phone number is required")
+		return nil, shared.ErrInvalidInput.WithMessage("phone number is required")
 	}
 
 	now := time.Now()
@@ -175,7 +218,7 @@ func NewRestaurant(
 		Rating:               0.0,
 		TotalReviews:         0,
 		PhoneNumber:          phoneNumber,
-		OpeningHours:         make(map[string]string),
+		OpeningHours:         OpeningHours{},
 		SupportedRestrictions: make([]DietaryRestriction, 0),
 		AverageWaitTime:      0,
 		AcceptsReservations:  false,
@@ -193,10 +236,45 @@ func (r *Restaurant) CalculateDistance(targetLocation Location) float64 {
 	)
 }
 
+// PlaceMatchRadiusKm 外部記錄的地點（例如聚餐的餐廳選項）只有名稱與座標，在此距離內同名即視為同一間餐廳
+const PlaceMatchRadiusKm = 0.2
+
+// IsNear 檢查位置是否在 PlaceMatchRadiusKm 內
+func (r *Restaurant) IsNear(location Location) bool {
+	return r.CalculateDistance(location) <= PlaceMatchRadiusKm
+}
+
+// MatchesPlace 檢查名稱與座標是否指向這間餐廳，名稱比對不分大小寫
+func (r *Restaurant) MatchesPlace(name string, location Location) bool {
+	return r.IsNear(location) && strings.EqualFold(strings.TrimSpace(r.Name), strings.TrimSpace(name))
+}
+
 // IsOpenNow 檢查餐廳現在是否營業
 func (r *Restaurant) IsOpenNow() bool {
-	// 簡化版本，實際應該解析 OpeningHours 並檢查當前時間
-	return r.IsActive && len(r.OpeningHours) > 0
+	return r.IsOpenAt(time.Now())
+}
+
+// IsOpenAt 檢查餐廳在指定時間是否營業
+// 未提供營業時間的餐廳無法判斷，視為營業，避免資料不完整的餐廳被排除
+func (r *Restaurant) IsOpenAt(t time.Time) bool {
+	if !r.IsActive {
+		return false
+	}
+	if r.OpeningHours.IsZero() {
+		return true
+	}
+	return r.OpeningHours.IsOpenAt(t)
+}
+
+// SetOpeningHours 驗證並更新營業時間
+func (r *Restaurant) SetOpeningHours(hours OpeningHours) error {
+	if err := hours.Validate(); err != nil {
+		return err
+	}
+
+	r.OpeningHours = hours
+	r.UpdatedAt = time.Now()
+	return nil
 }
 
 // MatchesCuisinePreferences 檢查是否符合料理偏好
@@ -244,29 +322,26 @@ func (r *Restaurant) SupportsDietaryRestrictions(restrictions []DietaryRestricti
 	return true
 }
 
-// UpdateRating 更新餐廳評分
-func (r *Restaurant) UpdateRating(newRating float64, incrementReviews bool) error {
-	if newRating < 1.0 || newRating > 5.0 {
-		return errors.New("rating must be between 1.0 and 5.0")
+// ApplyRatingSummary 以評論統計更新餐廳評分
+// 評分只能由儲存的評論重新計算，重複套用相同統計不會改變結果
+func (r *Restaurant) ApplyRatingSummary(average float64, count int) error {
+	if count < 0 {
+		return shared.ErrInvalidInput.WithMessage("review count cannot be negative")
 	}
-
-	if incrementReviews {
-		// 計算新的平均評分
-		totalRating := r.Rating * float64(r.TotalReviews)
-		r.TotalReviews++
-		r.Rating = (totalRating + newRating) / float64(r.TotalReviews)
-	} else {
-		r.Rating = newRating
+	if count == 0 {
+		average = 0
+	} else if average < 1.0 || average > 5.0 {
+		return shared.ErrInvalidInput.WithMessage("rating must be between 1.0 and 5.0")
 	}
 
+	r.Rating = average
+	r.TotalReviews = count
 	r.UpdatedAt = time.Now()
 	return nil
 }
 
 // calculateHaversineDistance 使用 Haversine 公式計算兩點間距離（公里）
 func calculateHaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
-	const earthRadiusKm = 6371.0
-
 	// 轉換為弧度
 	lat1Rad := lat1 * math.Pi / 180
 	lon1Rad := lon1 * math.Pi / 180
@@ -0,0 +1,82 @@
+package restaurant
+
+import (
+	"fmt"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// ErrUnsupportedWeightProfile 指定的推薦權重設定不存在
+var ErrUnsupportedWeightProfile = shared.NewDomainError(shared.KindInvalidInput, "UNSUPPORTED_WEIGHT_PROFILE", "unsupported recommendation weight profile")
+
+// DefaultWeightProfile 未指定時使用的權重設定
+const DefaultWeightProfile = "balanced"
+
+// ScoringWeights 推薦分數各項目的權重，計算時會正規化為總和 1
+// 沒有用餐紀錄可以參考時忽略 Affinity，其餘權重重新正規化
+type ScoringWeights struct {
+	Distance     float64 `json:"distance"`
+	Rating       float64 `json:"rating"`
+	Cuisine      float64 `json:"cuisine"`      // 與請求指定的料理偏好相符
+	Price        float64 `json:"price"`        // 與請求指定的價位相符
+	Restrictions float64 `json:"restrictions"` // 支援請求指定的飲食限制
+	Affinity     float64 `json:"affinity"`     // 與參與者過去的用餐喜好相符
+}
+
+// Validate 權重不能為負數，且除了 Affinity 之外至少要有一項大於 0
+func (w ScoringWeights) Validate() error {
+	for _, weight := range []float64{w.Distance, w.Rating, w.Cuisine, w.Price, w.Restrictions, w.Affinity} {
+		if weight < 0 {
+			return shared.ErrInvalidInput.WithMessage("scoring weights cannot be negative")
+		}
+	}
+	if w.Distance+w.Rating+w.Cuisine+w.Price+w.Restrictions <= 0 {
+		return shared.ErrInvalidInput.WithMessage("at least one non-affinity scoring weight must be positive")
+	}
+	return nil
+}
+
+// normalized 回傳總和為 1 的權重，withAffinity 為 false 時 Affinity 設為 0
+func (w ScoringWeights) normalized(withAffinity bool) ScoringWeights {
+	if !withAffinity {
+		w.Affinity = 0
+	}
+	total := w.Distance + w.Rating + w.Cuisine + w.Price + w.Restrictions + w.Affinity
+	return ScoringWeights{
+		Distance:     w.Distance / total,
+		Rating:       w.Rating / total,
+		Cuisine:      w.Cuisine / total,
+		Price:        w.Price / total,
+		Restrictions: w.Restrictions / total,
+		Affinity:     w.Affinity / total,
+	}
+}
+
+// WeightProfiles 以名稱對應的權重設定
+type WeightProfiles map[string]ScoringWeights
+
+// DefaultWeightProfiles 內建的權重設定
+//   - balanced：沒有用餐紀錄時與原本的固定權重相同 (距離 40%、評分 25%、料理 20%、價位 10%、飲食限制 5%)
+//   - nearby：以距離為主
+//   - top_rated：以評分為主
+//   - personalized：以過去的用餐喜好為主
+func DefaultWeightProfiles() WeightProfiles {
+	return WeightProfiles{
+		"balanced":     {Distance: 40, Rating: 25, Cuisine: 20, Price: 10, Restrictions: 5, Affinity: 25},
+		"nearby":       {Distance: 70, Rating: 10, Cuisine: 10, Price: 5, Restrictions: 5, Affinity: 10},
+		"top_rated":    {Distance: 25, Rating: 50, Cuisine: 10, Price: 10, Restrictions: 5, Affinity: 10},
+		"personalized": {Distance: 25, Rating: 15, Cuisine: 10, Price: 5, Restrictions: 5, Affinity: 60},
+	}
+}
+
+// Resolve 取得權重設定，name 為空時使用 DefaultWeightProfile
+func (p WeightProfiles) Resolve(name string) (ScoringWeights, error) {
+	if name == "" {
+		name = DefaultWeightProfile
+	}
+	weights, ok := p[name]
+	if !ok {
+		return ScoringWeights{}, fmt.Errorf("%w: %q", ErrUnsupportedWeightProfile, name)
+	}
+	return weights, nil
+}
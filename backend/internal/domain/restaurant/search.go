@@ -0,0 +1,114 @@
+package restaurant
+
+import (
+	"sort"
+)
+
+// Matches 檢查餐廳是否符合搜尋條件（不含分頁）
+func (c SearchCriteria) Matches(r *Restaurant) bool {
+	// 檢查活躍狀態
+	if c.IsActive != nil && r.IsActive != *c.IsActive {
+		return false
+	}
+
+	// 檢查位置範圍
+	if c.CenterLocation != nil && c.RadiusKm > 0 {
+		if r.CalculateDistance(*c.CenterLocation) > c.RadiusKm {
+			return false
+		}
+	}
+
+	// 檢查料理類型
+	if len(c.CuisineTypes) > 0 && !r.MatchesCuisinePreferences(c.CuisineTypes) {
+		return false
+	}
+
+	// 檢查價位範圍
+	if c.PriceRange != nil && !r.MatchesPriceRange(c.PriceRange.MinPrice, c.PriceRange.MaxPrice) {
+		return false
+	}
+
+	// 檢查飲食限制
+	if len(c.DietaryRestrictions) > 0 && !r.SupportsDietaryRestrictions(c.DietaryRestrictions) {
+		return false
+	}
+
+	// 檢查最低評分
+	if c.MinRating > 0 && r.Rating < c.MinRating {
+		return false
+	}
+
+	// 檢查預約支援
+	if c.AcceptsReservations != nil && r.AcceptsReservations != *c.AcceptsReservations {
+		return false
+	}
+
+	// 檢查營業時間
+	if c.OpenAt != nil && !r.IsOpenAt(*c.OpenAt) {
+		return false
+	}
+
+	return true
+}
+
+// SortRestaurants 依照搜尋條件排序餐廳，centerLocation 用於距離排序
+func SortRestaurants(results []*Restaurant, criteria SearchCriteria, centerLocation Location) {
+	desc := criteria.SortOrder == OrderDesc
+
+	switch criteria.SortBy {
+	case SortByDistance:
+		if criteria.CenterLocation != nil {
+			sort.Slice(results, func(i, j int) bool {
+				distanceI := results[i].CalculateDistance(centerLocation)
+				distanceJ := results[j].CalculateDistance(centerLocation)
+				if desc {
+					return distanceI > distanceJ
+				}
+				return distanceI < distanceJ
+			})
+		}
+	case SortByRating:
+		sort.Slice(results, func(i, j int) bool {
+			if desc {
+				return results[i].Rating > results[j].Rating
+			}
+			return results[i].Rating < results[j].Rating
+		})
+	case SortByPrice:
+		sort.Slice(results, func(i, j int) bool {
+			priceI, _ := results[i].PriceLevel.ToRange()
+			priceJ, _ := results[j].PriceLevel.ToRange()
+			if desc {
+				return priceI > priceJ
+			}
+			return priceI < priceJ
+		})
+	case SortByName:
+		fallthrough
+	default:
+		sort.Slice(results, func(i, j int) bool {
+			if desc {
+				return results[i].Name > results[j].Name
+			}
+			return results[i].Name < results[j].Name
+		})
+	}
+}
+
+// Paginate 依照 Limit/Offset 截取結果，Limit 為 0 時回傳全部
+func (c SearchCriteria) Paginate(results []*Restaurant) []*Restaurant {
+	if c.Limit <= 0 {
+		return results
+	}
+
+	start := c.Offset
+	if start >= len(results) {
+		return []*Restaurant{}
+	}
+
+	end := start + c.Limit
+	if end > len(results) {
+		end = len(results)
+	}
+	return results[start:end]
+}
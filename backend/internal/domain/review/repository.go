@@ -0,0 +1,39 @@
+package review
+
+import (
+	"context"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// SortOrder 餐廳評論列表的排序方式
+type SortOrder string
+
+const (
+	SortNewest      SortOrder = "newest"       // 依建立時間由新到舊
+	SortMostHelpful SortOrder = "most_helpful" // 依有幫助人數由多到少，同數時由新到舊
+)
+
+// Repository 定義餐廳評論的儲存介面
+type Repository interface {
+	// Create 儲存新評論；同一用戶對同一次用餐已有評論時回傳 shared.ErrReviewExists
+	Create(ctx context.Context, review *Review) error
+
+	// FindByID 取得評論，不存在時回傳 shared.ErrReviewNotFound
+	FindByID(ctx context.Context, id shared.ID) (*Review, error)
+
+	// Update 更新評論內容與有幫助標記，不存在時回傳 shared.ErrReviewNotFound
+	Update(ctx context.Context, review *Review) error
+
+	// Delete 刪除評論與其有幫助標記，不存在時回傳 shared.ErrReviewNotFound
+	Delete(ctx context.Context, id shared.ID) error
+
+	// FindByRestaurant 列出餐廳的評論，不含 excludeAuthors 寫的評論 (在分頁前排除)
+	FindByRestaurant(ctx context.Context, restaurantID shared.RestaurantID, order SortOrder, excludeAuthors []shared.UserID, limit, offset int) ([]*Review, error)
+
+	// FindByUser 依建立時間由新到舊列出用戶寫的評論
+	FindByUser(ctx context.Context, userID shared.UserID, limit, offset int) ([]*Review, error)
+
+	// Summarize 由儲存的評論計算餐廳的評分統計
+	Summarize(ctx context.Context, restaurantID shared.RestaurantID) (RatingSummary, error)
+}
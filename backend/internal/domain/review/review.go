@@ -0,0 +1,184 @@
+package review
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+const (
+	MinStars      = 1
+	MaxStars      = 5
+	MaxTextLength = 2000
+	MaxPhotos     = 10
+)
+
+// VisitType 評論對應的用餐來源
+type VisitType string
+
+const (
+	VisitTypePing            VisitType = "ping"
+	VisitTypeGroupDiningPlan VisitType = "group_dining_plan"
+)
+
+// Visit 評論對應的一次用餐，每位用戶每次用餐只能評論一次
+// VisitedAt 由 ping 的預定時間或聚餐確認的時段決定，不由用戶填寫
+type Visit struct {
+	Type      VisitType `json:"type"`
+	ID        string    `json:"id"`
+	VisitedAt time.Time `json:"visitedAt"`
+}
+
+// Review 用戶對一次用餐的餐廳評論
+type Review struct {
+	ID           shared.ID           `json:"id"`
+	RestaurantID shared.RestaurantID `json:"restaurantId"`
+	UserID       shared.UserID       `json:"userId"`
+	Visit        Visit               `json:"visit"`
+	Stars        int                 `json:"stars"`
+	Text         string              `json:"text,omitempty"`
+	PhotoURLs    []string            `json:"photoUrls"`
+	HelpfulBy    []shared.UserID     `json:"-"` // 標記為有幫助的用戶
+	CreatedAt    time.Time           `json:"createdAt"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+}
+
+// NewReview 建立並驗證評論，呼叫端需先確認用戶確實參加了該次用餐
+func NewReview(restaurantID shared.RestaurantID, userID shared.UserID, visit Visit, stars int, text string, photoURLs []string) (*Review, error) {
+	if restaurantID.IsEmpty() || userID.IsEmpty() {
+		return nil, shared.ErrInvalidInput
+	}
+	if visit.ID == "" || visit.VisitedAt.IsZero() {
+		return nil, fmt.Errorf("%w: visit is required", shared.ErrInvalidInput)
+	}
+	switch visit.Type {
+	case VisitTypePing, VisitTypeGroupDiningPlan:
+	default:
+		return nil, fmt.Errorf("%w: unsupported visit type %q", shared.ErrInvalidInput, visit.Type)
+	}
+
+	now := time.Now()
+	r := &Review{
+		ID:           shared.NewID(),
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Visit:        visit,
+		HelpfulBy:    []shared.UserID{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := r.setContent(stars, text, photoURLs); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Edit 更新評分、內容與照片，只有作者可以編輯
+func (r *Review) Edit(editor shared.UserID, stars int, text string, photoURLs []string) error {
+	if !r.IsAuthor(editor) {
+		return shared.ErrPermissionDenied
+	}
+	if err := r.setContent(stars, text, photoURLs); err != nil {
+		return err
+	}
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsAuthor 檢查用戶是否為評論作者
+func (r *Review) IsAuthor(userID shared.UserID) bool {
+	return r.UserID.Equals(userID)
+}
+
+// MarkHelpful 將評論標記為有幫助，作者不能標記自己的評論，重複標記不會重複計算
+func (r *Review) MarkHelpful(userID shared.UserID) error {
+	if r.IsAuthor(userID) {
+		return shared.ErrPermissionDenied
+	}
+	if r.IsHelpfulTo(userID) {
+		return nil
+	}
+	r.HelpfulBy = append(r.HelpfulBy, userID)
+	return nil
+}
+
+// UnmarkHelpful 取消有幫助的標記
+func (r *Review) UnmarkHelpful(userID shared.UserID) {
+	for i, voter := range r.HelpfulBy {
+		if voter.Equals(userID) {
+			r.HelpfulBy = append(r.HelpfulBy[:i], r.HelpfulBy[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsHelpfulTo 檢查用戶是否已標記為有幫助
+func (r *Review) IsHelpfulTo(userID shared.UserID) bool {
+	for _, voter := range r.HelpfulBy {
+		if voter.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// HelpfulCount 標記為有幫助的人數
+func (r *Review) HelpfulCount() int {
+	return len(r.HelpfulBy)
+}
+
+func (r *Review) setContent(stars int, text string, photoURLs []string) error {
+	if stars < MinStars || stars > MaxStars {
+		return fmt.Errorf("%w: stars must be between %d and %d", shared.ErrInvalidInput, MinStars, MaxStars)
+	}
+
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return fmt.Errorf("%w: text cannot exceed %d characters", shared.ErrInvalidInput, MaxTextLength)
+	}
+
+	if len(photoURLs) > MaxPhotos {
+		return fmt.Errorf("%w: at most %d photos", shared.ErrInvalidInput, MaxPhotos)
+	}
+	photos := make([]string, 0, len(photoURLs))
+	for _, raw := range photoURLs {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: invalid photo URL %q", shared.ErrInvalidInput, raw)
+		}
+		photos = append(photos, u.String())
+	}
+
+	r.Stars = stars
+	r.Text = text
+	r.PhotoURLs = photos
+	return nil
+}
+
+// RatingSummary 由儲存的評論計算出的餐廳評分
+type RatingSummary struct {
+	RestaurantID shared.RestaurantID `json:"restaurantId"`
+	Count        int                 `json:"count"`
+	Average      float64             `json:"average"` // 沒有評論時為 0
+	// Distribution[i] 為 i+1 星的評論數
+	Distribution [MaxStars]int `json:"distribution"`
+}
+
+// Summarize 計算評論的評分統計
+func Summarize(restaurantID shared.RestaurantID, reviews []*Review) RatingSummary {
+	summary := RatingSummary{RestaurantID: restaurantID}
+	total := 0
+	for _, r := range reviews {
+		summary.Count++
+		summary.Distribution[r.Stars-MinStars]++
+		total += r.Stars
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+	return summary
+}
@@ -0,0 +1,163 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// VisitVerifier 確認用戶確實在該餐廳用過餐，實作在 Application Layer (查詢 ping 與聚餐計畫)
+type VisitVerifier interface {
+	// VerifyVisit 回傳用餐時間；用戶未參加、用餐尚未完成或地點不是該餐廳時回傳 shared.ErrVisitNotEligible
+	VerifyVisit(ctx context.Context, userID shared.UserID, rest *restaurant.Restaurant, visitType VisitType, visitID string) (time.Time, error)
+}
+
+// Service 處理餐廳評論，評論變更後由儲存的評論重新計算餐廳評分
+type Service struct {
+	repo           Repository
+	restaurantRepo restaurant.Repository
+	visits         VisitVerifier
+}
+
+func NewService(repo Repository, restaurantRepo restaurant.Repository, visits VisitVerifier) *Service {
+	return &Service{
+		repo:           repo,
+		restaurantRepo: restaurantRepo,
+		visits:         visits,
+	}
+}
+
+// CreateReview 為一次已完成的用餐撰寫評論
+func (s *Service) CreateReview(ctx context.Context, userID shared.UserID, restaurantID shared.RestaurantID, visitType VisitType, visitID string, stars int, text string, photoURLs []string) (*Review, error) {
+	rest, err := s.restaurantRepo.FindByID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	visitedAt, err := s.visits.VerifyVisit(ctx, userID, rest, visitType, visitID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewReview(restaurantID, userID, Visit{Type: visitType, ID: visitID, VisitedAt: visitedAt}, stars, text, photoURLs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := s.refreshRating(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// EditReview 更新評論，只有作者可以編輯
+func (s *Service) EditReview(ctx context.Context, userID shared.UserID, reviewID shared.ID, stars int, text string, photoURLs []string) (*Review, error) {
+	r, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Edit(userID, stars, text, photoURLs); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := s.refreshRating(ctx, r.RestaurantID); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteReview 刪除評論，只有作者可以刪除
+func (s *Service) DeleteReview(ctx context.Context, userID shared.UserID, reviewID shared.ID) error {
+	r, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if !r.IsAuthor(userID) {
+		return shared.ErrPermissionDenied
+	}
+	if err := s.repo.Delete(ctx, reviewID); err != nil {
+		return err
+	}
+	return s.refreshRating(ctx, r.RestaurantID)
+}
+
+// RemoveReview 版主移除違反規範的評論，不限作者
+func (s *Service) RemoveReview(ctx context.Context, reviewID shared.ID) error {
+	r, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, reviewID); err != nil {
+		return err
+	}
+	return s.refreshRating(ctx, r.RestaurantID)
+}
+
+// SetHelpful 標記或取消標記評論為有幫助
+func (s *Service) SetHelpful(ctx context.Context, userID shared.UserID, reviewID shared.ID, helpful bool) (*Review, error) {
+	r, err := s.repo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if helpful {
+		if err := r.MarkHelpful(userID); err != nil {
+			return nil, err
+		}
+	} else {
+		r.UnmarkHelpful(userID)
+	}
+
+	if err := s.repo.Update(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ListForRestaurant 列出餐廳的評論與評分統計；excludeAuthors 寫的評論不列出，但仍計入評分統計
+func (s *Service) ListForRestaurant(ctx context.Context, restaurantID shared.RestaurantID, order SortOrder, excludeAuthors []shared.UserID, limit, offset int) ([]*Review, RatingSummary, error) {
+	if _, err := s.restaurantRepo.FindByID(ctx, restaurantID); err != nil {
+		return nil, RatingSummary{}, err
+	}
+	if order != SortMostHelpful {
+		order = SortNewest
+	}
+
+	reviews, err := s.repo.FindByRestaurant(ctx, restaurantID, order, excludeAuthors, limit, offset)
+	if err != nil {
+		return nil, RatingSummary{}, err
+	}
+	summary, err := s.repo.Summarize(ctx, restaurantID)
+	if err != nil {
+		return nil, RatingSummary{}, err
+	}
+	return reviews, summary, nil
+}
+
+// ListForUser 列出用戶寫的評論
+func (s *Service) ListForUser(ctx context.Context, userID shared.UserID, limit, offset int) ([]*Review, error) {
+	return s.repo.FindByUser(ctx, userID, limit, offset)
+}
+
+// refreshRating 以儲存的評論重新計算餐廳評分，重複呼叫結果相同
+func (s *Service) refreshRating(ctx context.Context, restaurantID shared.RestaurantID) error {
+	summary, err := s.repo.Summarize(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	rest, err := s.restaurantRepo.FindByID(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	if err := rest.ApplyRatingSummary(summary.Average, summary.Count); err != nil {
+		return err
+	}
+	return s.restaurantRepo.Update(ctx, rest)
+}
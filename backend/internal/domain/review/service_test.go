@@ -0,0 +1,187 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+// attendedVisits 只接受事先登記的用戶與用餐
+type attendedVisits map[string]shared.UserID
+
+func (v attendedVisits) VerifyVisit(ctx context.Context, userID shared.UserID, rest *restaurant.Restaurant, visitType review.VisitType, visitID string) (time.Time, error) {
+	if attendee, ok := v[visitID]; !ok || !attendee.Equals(userID) {
+		return time.Time{}, shared.ErrVisitNotEligible
+	}
+	return time.Now().Add(-time.Hour), nil
+}
+
+type testFixture struct {
+	service        *review.Service
+	restaurantRepo *inmemory.RestaurantRepository
+	visits         attendedVisits
+	restaurant     *restaurant.Restaurant
+}
+
+func newTestFixture(t *testing.T) *testFixture {
+	t.Helper()
+
+	f := &testFixture{
+		restaurantRepo: inmemory.NewRestaurantRepository(),
+		visits:         attendedVisits{},
+	}
+	f.service = review.NewService(inmemory.NewReviewRepository(), f.restaurantRepo, f.visits)
+
+	rest, err := restaurant.NewRestaurant("鼎泰豐", "", restaurant.Location{Latitude: 25.0330, Longitude: 121.5654, Address: "台北市信義區"}, []restaurant.CuisineType{restaurant.CuisineTypeChinese}, restaurant.PriceLevelMidRange, "02-2345-6789")
+	if err != nil {
+		t.Fatalf("NewRestaurant() error = %v", err)
+	}
+	if err := f.restaurantRepo.Create(context.Background(), rest); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	f.restaurant = rest
+	return f
+}
+
+// attend 登記用戶參加了一次用餐並回傳用餐 ID
+func (f *testFixture) attend(userID shared.UserID) string {
+	visitID := shared.NewID().String()
+	f.visits[visitID] = userID
+	return visitID
+}
+
+func (f *testFixture) write(t *testing.T, userID shared.UserID, stars int) *review.Review {
+	t.Helper()
+
+	r, err := f.service.CreateReview(context.Background(), userID, f.restaurant.ID, review.VisitTypePing, f.attend(userID), stars, "好吃", nil)
+	if err != nil {
+		t.Fatalf("CreateReview() error = %v", err)
+	}
+	return r
+}
+
+func (f *testFixture) assertRating(t *testing.T, wantAverage float64, wantCount int) {
+	t.Helper()
+
+	rest, err := f.restaurantRepo.FindByID(context.Background(), f.restaurant.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if rest.Rating != wantAverage || rest.TotalReviews != wantCount {
+		t.Errorf("restaurant rating = %.2f (%d reviews), want %.2f (%d reviews)", rest.Rating, rest.TotalReviews, wantAverage, wantCount)
+	}
+}
+
+func TestRatingIsRecomputedFromStoredReviews(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	alice, bob := shared.NewUserID(), shared.NewUserID()
+
+	first := f.write(t, alice, 5)
+	f.assertRating(t, 5, 1)
+
+	f.write(t, bob, 2)
+	f.assertRating(t, 3.5, 2)
+
+	if _, err := f.service.EditReview(ctx, alice, first.ID, 4, "第二次普通", nil); err != nil {
+		t.Fatalf("EditReview() error = %v", err)
+	}
+	f.assertRating(t, 3, 2)
+
+	if err := f.service.DeleteReview(ctx, alice, first.ID); err != nil {
+		t.Fatalf("DeleteReview() error = %v", err)
+	}
+	f.assertRating(t, 2, 1)
+}
+
+func TestCreateReviewRequiresAttendedVisit(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	alice, bob := shared.NewUserID(), shared.NewUserID()
+
+	// bob 沒有參加 alice 的用餐
+	visitID := f.attend(alice)
+	if _, err := f.service.CreateReview(ctx, bob, f.restaurant.ID, review.VisitTypePing, visitID, 4, "", nil); !errors.Is(err, shared.ErrVisitNotEligible) {
+		t.Errorf("CreateReview() by non-attendee error = %v, want %v", err, shared.ErrVisitNotEligible)
+	}
+
+	if _, err := f.service.CreateReview(ctx, alice, f.restaurant.ID, review.VisitTypePing, visitID, 4, "", nil); err != nil {
+		t.Fatalf("CreateReview() error = %v", err)
+	}
+	if _, err := f.service.CreateReview(ctx, alice, f.restaurant.ID, review.VisitTypePing, visitID, 1, "", nil); !errors.Is(err, shared.ErrReviewExists) {
+		t.Errorf("CreateReview() second review error = %v, want %v", err, shared.ErrReviewExists)
+	}
+	f.assertRating(t, 4, 1)
+
+	if _, err := f.service.CreateReview(ctx, alice, f.restaurant.ID, review.VisitTypePing, f.attend(alice), 6, "", nil); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("CreateReview() with 6 stars error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+}
+
+func TestOnlyAuthorCanEditOrDelete(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	author, other := shared.NewUserID(), shared.NewUserID()
+	r := f.write(t, author, 4)
+
+	if _, err := f.service.EditReview(ctx, other, r.ID, 1, "", nil); !errors.Is(err, shared.ErrPermissionDenied) {
+		t.Errorf("EditReview() by other user error = %v, want %v", err, shared.ErrPermissionDenied)
+	}
+	if err := f.service.DeleteReview(ctx, other, r.ID); !errors.Is(err, shared.ErrPermissionDenied) {
+		t.Errorf("DeleteReview() by other user error = %v, want %v", err, shared.ErrPermissionDenied)
+	}
+	f.assertRating(t, 4, 1)
+
+	// 版主可以移除任何人的評論
+	if err := f.service.RemoveReview(ctx, r.ID); err != nil {
+		t.Fatalf("RemoveReview() error = %v", err)
+	}
+	f.assertRating(t, 0, 0)
+}
+
+func TestHelpfulVotes(t *testing.T) {
+	ctx := context.Background()
+	f := newTestFixture(t)
+	author, voter := shared.NewUserID(), shared.NewUserID()
+	older := f.write(t, author, 3)
+	newer := f.write(t, shared.NewUserID(), 5)
+
+	if _, err := f.service.SetHelpful(ctx, author, older.ID, true); !errors.Is(err, shared.ErrPermissionDenied) {
+		t.Errorf("SetHelpful() by author error = %v, want %v", err, shared.ErrPermissionDenied)
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := f.service.SetHelpful(ctx, voter, older.ID, true)
+		if err != nil {
+			t.Fatalf("SetHelpful() error = %v", err)
+		}
+		if got.HelpfulCount() != 1 {
+			t.Errorf("HelpfulCount() after %d marks = %d, want 1", i+1, got.HelpfulCount())
+		}
+	}
+
+	reviews, summary, err := f.service.ListForRestaurant(ctx, f.restaurant.ID, review.SortMostHelpful, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("ListForRestaurant() error = %v", err)
+	}
+	if len(reviews) != 2 || reviews[0].ID != older.ID || reviews[1].ID != newer.ID {
+		t.Errorf("ListForRestaurant(most helpful) = %v, want [%s %s]", reviews, older.ID, newer.ID)
+	}
+	if summary.Count != 2 || summary.Average != 4 {
+		t.Errorf("ListForRestaurant() summary = %+v, want 2 reviews averaging 4", summary)
+	}
+
+	got, err := f.service.SetHelpful(ctx, voter, older.ID, false)
+	if err != nil {
+		t.Fatalf("SetHelpful(false) error = %v", err)
+	}
+	if got.HelpfulCount() != 0 {
+		t.Errorf("HelpfulCount() after unmark = %d, want 0", got.HelpfulCount())
+	}
+}
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// refreshTokenBytes 決定 opaque refresh token 的亂數長度
+const refreshTokenBytes = 32
+
+// RefreshToken 代表一個已發出的 refresh token
+// 原始 token 只在發出時回傳給客戶端，資料庫僅保存其雜湊值
+//
+// 同一次登入所產生的 token 共用 FamilyID，每次 rotation 都會在同一個 family
+// 中產生新 token。FamilyID 同時作為 access token 的 session ID (sid)。
+type RefreshToken struct {
+	ID         shared.ID     `json:"id"`
+	FamilyID   shared.ID     `json:"familyId"`
+	UserID     shared.UserID `json:"userId"`
+	DeviceID   string        `json:"deviceId,omitempty"`
+	TokenHash  string        `json:"-"`
+	CreatedAt  time.Time     `json:"createdAt"`
+	ExpiresAt  time.Time     `json:"expiresAt"`
+	RotatedAt  *time.Time    `json:"rotatedAt,omitempty"`  // 已被換發的時間，再次使用即視為重複使用
+	ReplacedBy *shared.ID    `json:"replacedBy,omitempty"` // 換發後的新 token
+	RevokedAt  *time.Time    `json:"revokedAt,omitempty"`
+}
+
+// newRefreshToken 建立新的 refresh token，回傳實體與原始 token 字串
+func newRefreshToken(familyID shared.ID, userID shared.UserID, deviceID string, now time.Time, ttl time.Duration) (*RefreshToken, string, error) {
+	raw, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &RefreshToken{
+		ID:        shared.NewID(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		DeviceID:  deviceID,
+		TokenHash: HashToken(raw),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, raw, nil
+}
+
+// IsExpired 檢查 token 是否已過期
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsRotated 檢查 token 是否已被換發
+func (t *RefreshToken) IsRotated() bool {
+	return t.RotatedAt != nil
+}
+
+// IsRevoked 檢查 token 是否已被撤銷
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// HashToken 計算原始 token 的雜湊值，用於儲存與查詢
+func HashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,38 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RefreshTokenRepository 定義 refresh token 的儲存介面
+type RefreshTokenRepository interface {
+	// Save 儲存新的 refresh token
+	Save(ctx context.Context, token *RefreshToken) error
+
+	// FindByHash 根據 token 雜湊值查找，找不到時回傳 shared.ErrEntityNotFound
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// Rotate 將 current 標記為已換發並儲存 next，兩者須在同一交易中完成
+	// 若 current 已被換發或撤銷，回傳 shared.ErrRefreshTokenReused
+	Rotate(ctx context.Context, current, next *RefreshToken) error
+
+	// RevokeFamily 撤銷同一 family 中所有尚未撤銷的 token
+	RevokeFamily(ctx context.Context, familyID shared.ID, at time.Time) error
+
+	// RevokeByUser 撤銷用戶所有尚未撤銷的 token，回傳受影響的 family
+	// deviceID 非空時只撤銷該裝置的 token
+	RevokeByUser(ctx context.Context, userID shared.UserID, deviceID string, at time.Time) ([]shared.ID, error)
+}
+
+// RevocationList 記錄已撤銷的 access token ID (jti) 或 session ID (sid)
+// 項目只需保留到對應 access token 過期為止
+type RevocationList interface {
+	// Revoke 將 id 加入撤銷清單直到 until
+	Revoke(ctx context.Context, id string, until time.Time) error
+
+	// IsRevoked 檢查 id 是否仍在撤銷清單中
+	IsRevoked(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,177 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Service 管理登入 session 的 refresh token 生命週期
+// 每次使用 refresh token 都會換發新 token (rotation)，舊 token 若被再次使用
+// 即視為外洩，整個 family 會被撤銷 (reuse detection)
+type Service struct {
+	tokens      RefreshTokenRepository
+	revocations RevocationList
+	refreshTTL  time.Duration
+	accessTTL   time.Duration
+}
+
+// NewService 建立 session service
+// accessTTL 用來決定撤銷 session 時，sid 需在撤銷清單中保留多久
+func NewService(tokens RefreshTokenRepository, revocations RevocationList, refreshTTL, accessTTL time.Duration) *Service {
+	return &Service{
+		tokens:      tokens,
+		revocations: revocations,
+		refreshTTL:  refreshTTL,
+		accessTTL:   accessTTL,
+	}
+}
+
+// RefreshTTL 回傳 refresh token 的有效期限
+func (s *Service) RefreshTTL() time.Duration {
+	return s.refreshTTL
+}
+
+// Start 為登入建立新的 session (token family)，回傳 token 與原始 token 字串
+// 同一裝置重新登入時，該裝置先前的 session 會被撤銷
+func (s *Service) Start(ctx context.Context, userID shared.UserID, deviceID string) (*RefreshToken, string, error) {
+	now := time.Now()
+
+	if deviceID != "" {
+		families, err := s.tokens.RevokeByUser(ctx, userID, deviceID, now)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := s.revokeSessions(ctx, families, now); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token, raw, err := newRefreshToken(shared.NewID(), userID, deviceID, now, s.refreshTTL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.tokens.Save(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	return token, raw, nil
+}
+
+// Rotate 以原始 refresh token 換發同一 family 的新 token
+func (s *Service) Rotate(ctx context.Context, raw string) (*RefreshToken, string, error) {
+	now := time.Now()
+
+	current, err := s.find(ctx, raw)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if current.IsRevoked() {
+		return nil, "", shared.ErrSessionRevoked
+	}
+
+	// 已換發過的 token 再次出現，代表 token 可能外洩，撤銷整個 family
+	if current.IsRotated() {
+		return nil, "", s.handleReuse(ctx, current.FamilyID, now)
+	}
+
+	if current.IsExpired(now) {
+		return nil, "", shared.ErrRefreshTokenExpired
+	}
+
+	next, nextRaw, err := newRefreshToken(current.FamilyID, current.UserID, current.DeviceID, now, s.refreshTTL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	current.RotatedAt = &now
+	current.ReplacedBy = &next.ID
+
+	if err := s.tokens.Rotate(ctx, current, next); err != nil {
+		// 併發請求搶先換發了同一個 token
+		if errors.Is(err, shared.ErrRefreshTokenReused) {
+			return nil, "", s.handleReuse(ctx, current.FamilyID, now)
+		}
+		return nil, "", err
+	}
+
+	return next, nextRaw, nil
+}
+
+// RevokeSession 撤銷整個 session，包含其 refresh token 與已發出的 access token
+func (s *Service) RevokeSession(ctx context.Context, familyID shared.ID) error {
+	now := time.Now()
+	if err := s.tokens.RevokeFamily(ctx, familyID, now); err != nil {
+		return err
+	}
+	return s.revokeSessions(ctx, []shared.ID{familyID}, now)
+}
+
+// RevokeToken 撤銷原始 refresh token 所屬的 session，僅限 token 擁有者
+func (s *Service) RevokeToken(ctx context.Context, userID shared.UserID, raw string) error {
+	token, err := s.find(ctx, raw)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return shared.ErrPermissionDenied
+	}
+	return s.RevokeSession(ctx, token.FamilyID)
+}
+
+// RevokeAll 撤銷用戶在所有裝置上的 session
+func (s *Service) RevokeAll(ctx context.Context, userID shared.UserID) error {
+	now := time.Now()
+	families, err := s.tokens.RevokeByUser(ctx, userID, "", now)
+	if err != nil {
+		return err
+	}
+	return s.revokeSessions(ctx, families, now)
+}
+
+// RevokeAccessToken 將單一 access token (jti) 加入撤銷清單直到其過期
+func (s *Service) RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return nil
+	}
+	return s.revocations.Revoke(ctx, tokenID, expiresAt)
+}
+
+func (s *Service) find(ctx context.Context, raw string) (*RefreshToken, error) {
+	if raw == "" {
+		return nil, shared.ErrInvalidRefreshToken
+	}
+
+	token, err := s.tokens.FindByHash(ctx, HashToken(raw))
+	if err != nil {
+		if errors.Is(err, shared.ErrEntityNotFound) {
+			return nil, shared.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+func (s *Service) handleReuse(ctx context.Context, familyID shared.ID, now time.Time) error {
+	if err := s.tokens.RevokeFamily(ctx, familyID, now); err != nil {
+		return err
+	}
+	if err := s.revokeSessions(ctx, []shared.ID{familyID}, now); err != nil {
+		return err
+	}
+	return shared.ErrRefreshTokenReused
+}
+
+// revokeSessions 將 sid 加入撤銷清單，讓該 session 已發出的 access token 立即失效
+func (s *Service) revokeSessions(ctx context.Context, families []shared.ID, now time.Time) error {
+	for _, familyID := range families {
+		if err := s.revocations.Revoke(ctx, familyID.String(), now.Add(s.accessTTL)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+func newTestService() (*session.Service, *inmemory.RevocationList) {
+	revocations := inmemory.NewRevocationList()
+	return session.NewService(inmemory.NewRefreshTokenRepository(), revocations, time.Hour, time.Minute), revocations
+}
+
+func TestRotateIssuesNewTokenInSameFamily(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+	userID := shared.NewUserID()
+
+	first, raw, err := service.Start(ctx, userID, "phone")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	second, nextRaw, err := service.Rotate(ctx, raw)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if nextRaw == raw {
+		t.Error("Rotate() returned the same raw token")
+	}
+	if second.FamilyID != first.FamilyID || second.UserID != userID || second.DeviceID != "phone" {
+		t.Errorf("Rotate() token = %+v, want family %v for user %v", second, first.FamilyID, userID)
+	}
+
+	if _, _, err := service.Rotate(ctx, nextRaw); err != nil {
+		t.Errorf("Rotate() with new token error = %v", err)
+	}
+}
+
+func TestRotateReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	service, revocations := newTestService()
+
+	first, raw, err := service.Start(ctx, shared.NewUserID(), "")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	_, nextRaw, err := service.Rotate(ctx, raw)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	if _, _, err := service.Rotate(ctx, raw); !errors.Is(err, shared.ErrRefreshTokenReused) {
+		t.Fatalf("Rotate() reused token error = %v, want %v", err, shared.ErrRefreshTokenReused)
+	}
+
+	// 合法持有者手上的新 token 也一併失效
+	if _, _, err := service.Rotate(ctx, nextRaw); !errors.Is(err, shared.ErrSessionRevoked) {
+		t.Errorf("Rotate() after reuse error = %v, want %v", err, shared.ErrSessionRevoked)
+	}
+
+	revoked, err := revocations.IsRevoked(ctx, first.FamilyID.String())
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+	if !revoked {
+		t.Error("session ID not added to revocation list after reuse")
+	}
+}
+
+func TestRotateRejectsUnknownAndExpiredTokens(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+
+	if _, _, err := service.Rotate(ctx, "not-a-token"); !errors.Is(err, shared.ErrInvalidRefreshToken) {
+		t.Errorf("Rotate() unknown token error = %v, want %v", err, shared.ErrInvalidRefreshToken)
+	}
+
+	expiring := session.NewService(inmemory.NewRefreshTokenRepository(), inmemory.NewRevocationList(), -time.Second, time.Minute)
+	_, raw, err := expiring.Start(ctx, shared.NewUserID(), "")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if _, _, err := expiring.Rotate(ctx, raw); !errors.Is(err, shared.ErrRefreshTokenExpired) {
+		t.Errorf("Rotate() expired token error = %v, want %v", err, shared.ErrRefreshTokenExpired)
+	}
+}
+
+func TestStartReplacesSessionOnSameDevice(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+	userID := shared.NewUserID()
+
+	_, phoneRaw, err := service.Start(ctx, userID, "phone")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	_, laptopRaw, err := service.Start(ctx, userID, "laptop")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if _, _, err := service.Start(ctx, userID, "phone"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if _, _, err := service.Rotate(ctx, phoneRaw); !errors.Is(err, shared.ErrSessionRevoked) {
+		t.Errorf("Rotate() replaced session error = %v, want %v", err, shared.ErrSessionRevoked)
+	}
+	if _, _, err := service.Rotate(ctx, laptopRaw); err != nil {
+		t.Errorf("Rotate() other device error = %v", err)
+	}
+}
+
+func TestRevokeTokenRequiresOwner(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService()
+	owner := shared.NewUserID()
+
+	_, raw, err := service.Start(ctx, owner, "")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if err := service.RevokeToken(ctx, shared.NewUserID(), raw); !errors.Is(err, shared.ErrPermissionDenied) {
+		t.Fatalf("RevokeToken() by stranger error = %v, want %v", err, shared.ErrPermissionDenied)
+	}
+	if err := service.RevokeToken(ctx, owner, raw); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	if _, _, err := service.Rotate(ctx, raw); !errors.Is(err, shared.ErrSessionRevoked) {
+		t.Errorf("Rotate() after logout error = %v, want %v", err, shared.ErrSessionRevoked)
+	}
+}
@@ -0,0 +1,54 @@
+package shared
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DomainEvent 是聚合狀態改變時記錄的領域事件
+// 事件內容會序列化為 JSON 寫入 outbox，因此欄位須可被 encoding/json 還原
+type DomainEvent interface {
+	// EventName 事件名稱，例如 "ping.responded"，訂閱者依此過濾
+	EventName() string
+	// AggregateID 產生事件的聚合 ID
+	AggregateID() string
+}
+
+// RecordedEvent 是聚合記錄下來、尚未寫入 outbox 的事件
+type RecordedEvent struct {
+	ID         string
+	Event      DomainEvent
+	OccurredAt time.Time
+}
+
+// EventSource 由會記錄領域事件的聚合實作，repository 儲存時據此寫入 outbox
+type EventSource interface {
+	PendingEvents() []RecordedEvent
+	ClearEvents()
+}
+
+// EventRecorder 嵌入聚合以記錄領域事件
+// 事件只在聚合成功儲存時與狀態一起寫入 outbox，儲存後由 repository 清除
+type EventRecorder struct {
+	pending []RecordedEvent
+}
+
+// Record 記錄一個領域事件
+func (r *EventRecorder) Record(event DomainEvent) {
+	r.pending = append(r.pending, RecordedEvent{
+		ID:         uuid.NewString(),
+		Event:      event,
+		OccurredAt: time.Now(),
+	})
+}
+
+// PendingEvents 回傳尚未寫入 outbox 的事件 (依記錄順序)
+func (r *EventRecorder) PendingEvents() []RecordedEvent {
+	return r.pending
+}
+
+// ClearEvents 清除已寫入 outbox 的事件
+func (r *EventRecorder) ClearEvents() {
+	r.pending = nil
+}
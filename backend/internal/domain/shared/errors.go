@@ -1,66 +1,129 @@
 package shared
 
-import "errors"
+// ErrorKind 錯誤的類別，介面層依類別決定回應的狀態碼
+type ErrorKind string
+
+const (
+	KindInvalidInput  ErrorKind = "invalid_input" // 輸入不合法
+	KindUnauthorized  ErrorKind = "unauthorized"  // 未登入或憑證無效
+	KindForbidden     ErrorKind = "forbidden"     // 沒有執行操作的權限
+	KindNotFound      ErrorKind = "not_found"     // 資源不存在
+	KindConflict      ErrorKind = "conflict"      // 與資源目前的狀態衝突
+	KindGone          ErrorKind = "gone"          // 資源已失效且不會再恢復
+	KindUnprocessable ErrorKind = "unprocessable" // 輸入格式正確但不符合業務規則
+	KindInternal      ErrorKind = "internal"      // 非預期的錯誤
+)
 
 var (
 	// Domain Errors
-	ErrEntityNotFound     = errors.New("entity not found")
-	ErrInvalidInput       = errors.New("invalid input")
-	ErrUnauthorized      = errors.New("unauthorized access")
-	ErrPermissionDenied  = errors.New("permission denied")
-	ErrResourceConflict  = errors.New("resource conflict")
-	
+	ErrEntityNotFound   = NewDomainError(KindNotFound, "ENTITY_NOT_FOUND", "entity not found")
+	ErrInvalidInput     = NewDomainError(KindInvalidInput, "INVALID_INPUT", "invalid input")
+	ErrUnauthorized     = NewDomainError(KindUnauthorized, "UNAUTHORIZED", "unauthorized access")
+	ErrPermissionDenied = NewDomainError(KindForbidden, "PERMISSION_DENIED", "permission denied")
+	ErrResourceConflict = NewDomainError(KindConflict, "RESOURCE_CONFLICT", "resource conflict")
+
 	// User Domain Errors
-	ErrUserNotFound       = errors.New("user not found")
-	ErrUserAlreadyExists  = errors.New("user already exists")
-	ErrInvalidEmail       = errors.New("invalid email format")
-	ErrInvalidPhone       = errors.New("invalid phone number")
-	ErrWeakPassword       = errors.New("password too weak")
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrUserInactive       = errors.New("user account is inactive")
-	ErrInvalidDisplayName = errors.New("display name must contain only English letters, numbers, and spaces")
-	
+	ErrUserNotFound       = NewDomainError(KindNotFound, "USER_NOT_FOUND", "user not found")
+	ErrUserAlreadyExists  = NewDomainError(KindConflict, "USER_ALREADY_EXISTS", "user already exists")
+	ErrInvalidEmail       = NewDomainError(KindInvalidInput, "INVALID_EMAIL", "invalid email format")
+	ErrInvalidPhone       = NewDomainError(KindInvalidInput, "INVALID_PHONE", "invalid phone number")
+	ErrWeakPassword       = NewDomainError(KindInvalidInput, "WEAK_PASSWORD", "password too weak")
+	ErrInvalidCredentials = NewDomainError(KindUnauthorized, "INVALID_CREDENTIALS", "invalid email or password")
+	ErrUserInactive       = NewDomainError(KindForbidden, "USER_INACTIVE", "user account is inactive")
+	ErrInvalidDisplayName = NewDomainError(KindInvalidInput, "INVALID_DISPLAY_NAME", "display name must contain only English letters, numbers, and spaces")
+
+	// Session Domain Errors
+	ErrInvalidRefreshToken = NewDomainError(KindUnauthorized, "INVALID_REFRESH_TOKEN", "invalid refresh token")
+	ErrRefreshTokenExpired = NewDomainError(KindUnauthorized, "REFRESH_TOKEN_EXPIRED", "refresh token has expired")
+	ErrRefreshTokenReused  = NewDomainError(KindUnauthorized, "REFRESH_TOKEN_REUSED", "refresh token has already been used")
+	ErrSessionRevoked      = NewDomainError(KindUnauthorized, "SESSION_REVOKED", "session has been revoked")
+
+	// Account Domain Errors
+	ErrInvalidActionToken = NewDomainError(KindInvalidInput, "INVALID_ACTION_TOKEN", "invalid token")
+	ErrActionTokenExpired = NewDomainError(KindInvalidInput, "ACTION_TOKEN_EXPIRED", "token has expired")
+	ErrActionTokenUsed    = NewDomainError(KindGone, "ACTION_TOKEN_USED", "token has already been used")
+	ErrAlreadyVerified    = NewDomainError(KindConflict, "EMAIL_ALREADY_VERIFIED", "email is already verified")
+
 	// Ping Domain Errors
-	ErrPingNotFound      = errors.New("ping not found")
-	ErrPingExpired       = errors.New("ping has expired")
-	ErrPingCancelled     = errors.New("ping has been cancelled")
-	ErrInvalidPingTime   = errors.New("ping time must be in the future")
-	ErrAlreadyResponded  = errors.New("already responded to this ping")
-	
+	ErrPingNotFound     = NewDomainError(KindNotFound, "PING_NOT_FOUND", "ping not found")
+	ErrPingExpired      = NewDomainError(KindConflict, "PING_EXPIRED", "ping has expired")
+	ErrPingCancelled    = NewDomainError(KindConflict, "PING_CANCELLED", "ping has been cancelled")
+	ErrInvalidPingTime  = NewDomainError(KindInvalidInput, "INVALID_PING_TIME", "ping time must be in the future")
+	ErrAlreadyResponded = NewDomainError(KindConflict, "PING_ALREADY_RESPONDED", "already responded to this ping")
+
 	// Social Domain Errors
-	ErrFriendshipNotFound    = errors.New("friendship not found")
-	ErrFriendshipExists      = errors.New("friendship already exists")
-	ErrSelfFriendRequest     = errors.New("cannot send friend request to yourself")
-	ErrGroupNotFound         = errors.New("group not found")
-	ErrNotGroupMember        = errors.New("not a group member")
-	ErrNotGroupCreator       = errors.New("not the group creator")
-	
+	ErrFriendshipNotFound = NewDomainError(KindNotFound, "FRIENDSHIP_NOT_FOUND", "friendship not found")
+	ErrFriendshipExists   = NewDomainError(KindConflict, "FRIENDSHIP_EXISTS", "friendship already exists")
+	ErrSelfFriendRequest  = NewDomainError(KindInvalidInput, "SELF_FRIEND_REQUEST", "cannot send friend request to yourself")
+	ErrGroupNotFound      = NewDomainError(KindNotFound, "GROUP_NOT_FOUND", "group not found")
+	ErrNotGroupMember     = NewDomainError(KindForbidden, "NOT_GROUP_MEMBER", "not a group member")
+	ErrNotGroupCreator    = NewDomainError(KindForbidden, "NOT_GROUP_CREATOR", "not the group creator")
+
 	// Restaurant Domain Errors
-	ErrRestaurantNotFound = errors.New("restaurant not found")
-	ErrInvalidLocation    = errors.New("invalid location coordinates")
+	ErrRestaurantNotFound = NewDomainError(KindNotFound, "RESTAURANT_NOT_FOUND", "restaurant not found")
+	ErrInvalidLocation    = NewDomainError(KindInvalidInput, "INVALID_LOCATION", "invalid location coordinates")
+	ErrRestaurantExists   = NewDomainError(KindConflict, "RESTAURANT_EXISTS", "restaurant already exists at this location")
+
+	// Notification Domain Errors
+	ErrNotificationNotFound  = NewDomainError(KindNotFound, "NOTIFICATION_NOT_FOUND", "notification not found")
+	ErrDuplicateNotification = NewDomainError(KindConflict, "NOTIFICATION_DUPLICATE", "notification has already been delivered")
+	ErrRecipientNoEmail      = NewDomainError(KindUnprocessable, "NOTIFICATION_RECIPIENT_NO_EMAIL", "recipient has no email address")
+
+	// Availability Domain Errors
+	ErrAvailabilityNotFound = NewDomainError(KindNotFound, "AVAILABILITY_NOT_FOUND", "availability has not been published")
+
+	// Calendar Domain Errors
+	ErrCalendarFeedNotFound = NewDomainError(KindNotFound, "CALENDAR_FEED_NOT_FOUND", "calendar feed not found")
+
+	// Review Domain Errors
+	ErrReviewNotFound   = NewDomainError(KindNotFound, "REVIEW_NOT_FOUND", "review not found")
+	ErrReviewExists     = NewDomainError(KindConflict, "REVIEW_EXISTS", "visit has already been reviewed")
+	ErrVisitNotEligible = NewDomainError(KindUnprocessable, "VISIT_NOT_ELIGIBLE", "visit is not eligible for review")
 )
 
+// DomainError 帶有穩定錯誤碼的領域錯誤
+// 錯誤碼相同的 DomainError 視為同一種錯誤，因此 WithMessage / Wrap 產生的錯誤仍可用 errors.Is 比對原本的變數
 type DomainError struct {
+	Kind    ErrorKind
 	Code    string
 	Message string
 	Cause   error
 }
 
-func (e DomainError) Error() string {
+func (e *DomainError) Error() string {
 	if e.Cause != nil {
 		return e.Message + ": " + e.Cause.Error()
 	}
 	return e.Message
 }
 
-func (e DomainError) Unwrap() error {
+func (e *DomainError) Unwrap() error {
 	return e.Cause
 }
 
-func NewDomainError(code, message string, cause error) DomainError {
-	return DomainError{
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage 回傳錯誤碼相同但說明較具體的錯誤
+func (e *DomainError) WithMessage(message string) *DomainError {
+	copied := *e
+	copied.Message = message
+	return &copied
+}
+
+// Wrap 回傳錯誤碼相同並帶有底層原因的錯誤
+func (e *DomainError) Wrap(cause error) *DomainError {
+	copied := *e
+	copied.Cause = cause
+	return &copied
+}
+
+func NewDomainError(kind ErrorKind, code, message string) *DomainError {
+	return &DomainError{
+		Kind:    kind,
 		Code:    code,
 		Message: message,
-		Cause:   cause,
 	}
-}
\ No newline at end of file
+}
@@ -1,7 +1,6 @@
 package shared
 
 import (
-	"errors"
 	"math"
 )
 
@@ -13,11 +12,11 @@ type Location struct {
 
 func NewLocation(lat, lng float64, address string) (Location, error) {
 	if !isValidLatitude(lat) {
-		return Location{}, errors.New("latitude must be between -90 and 90")
+		return Location{}, ErrInvalidLocation.WithMessage("latitude must be between -90 and 90")
 	}
 	
 	if !isValidLongitude(lng) {
-		return Location{}, errors.New("longitude must be between -180 and 180")
+		return Location{}, ErrInvalidLocation.WithMessage("longitude must be between -180 and 180")
 	}
 	
 	return Location{
@@ -2,8 +2,6 @@ package shared
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
@@ -22,12 +20,12 @@ func NewID() ID {
 
 func ParseID(id string) (ID, error) {
 	if strings.TrimSpace(id) == "" {
-		return ID{}, errors.New("ID cannot be empty")
+		return ID{}, ErrInvalidInput.WithMessage("ID cannot be empty")
 	}
 	
 	// 驗證是否為有效的 UUID
 	if _, err := uuid.Parse(id); err != nil {
-		return ID{}, fmt.Errorf("invalid ID format: %w", err)
+		return ID{}, ErrInvalidInput.WithMessage("invalid ID format").Wrap(err)
 	}
 	
 	return ID{value: id}, nil
@@ -81,12 +79,12 @@ func ParseUserID(id string) (UserID, error) {
 
 func NewUserIDFromString(id string) (UserID, error) {
 	if strings.TrimSpace(id) == "" {
-		return UserID{}, errors.New("user ID cannot be empty")
+		return UserID{}, ErrInvalidInput.WithMessage("user ID cannot be empty")
 	}
 	
 	// 驗證是否為有效的 UUID
 	if _, err := uuid.Parse(id); err != nil {
-		return UserID{}, fmt.Errorf("invalid user ID format: %w", err)
+		return UserID{}, ErrInvalidInput.WithMessage("invalid user ID format").Wrap(err)
 	}
 	
 	return UserID{value: id}, nil
@@ -116,12 +114,12 @@ func NewFriendshipID() FriendshipID {
 
 func NewFriendshipIDFromString(id string) (FriendshipID, error) {
 	if strings.TrimSpace(id) == "" {
-		return FriendshipID{}, errors.New("friendship ID cannot be empty")
+		return FriendshipID{}, ErrInvalidInput.WithMessage("friendship ID cannot be empty")
 	}
 	
 	// 驗證是否為有效的 UUID
 	if _, err := uuid.Parse(id); err != nil {
-		return FriendshipID{}, fmt.Errorf("invalid friendship ID format: %w", err)
+		return FriendshipID{}, ErrInvalidInput.WithMessage("invalid friendship ID format").Wrap(err)
 	}
 	
 	return FriendshipID{value: id}, nil
@@ -194,12 +192,12 @@ func NewRestaurantID() RestaurantID {
 // NewRestaurantIDFromString 從字串建立餐廳 ID
 func NewRestaurantIDFromString(id string) (RestaurantID, error) {
 	if strings.TrimSpace(id) == "" {
-		return RestaurantID{}, errors.New("restaurant ID cannot be empty")
+		return RestaurantID{}, ErrInvalidInput.WithMessage("restaurant ID cannot be empty")
 	}
 	
 	// 驗證是否為有效的 UUID 格式
 	if _, err := uuid.Parse(id); err != nil {
-		return RestaurantID{}, fmt.Errorf("invalid restaurant ID format: %w", err)
+		return RestaurantID{}, ErrInvalidInput.WithMessage("invalid restaurant ID format").Wrap(err)
 	}
 	
 	return RestaurantID{value: id}, nil
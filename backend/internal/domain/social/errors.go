@@ -0,0 +1,8 @@
+package social
+
+import "github.com/chun-wei0413/pingnom/internal/domain/shared"
+
+// 用戶互動的領域錯誤，封鎖關係使用 friendship.ErrFriendshipBlocked
+var (
+	ErrFriendsOnly = shared.NewDomainError(shared.KindForbidden, "FRIENDS_ONLY_INVITATION", "user only accepts invitations from friends")
+)
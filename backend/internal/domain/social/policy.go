@@ -0,0 +1,101 @@
+package social
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// BlockList 與某位用戶之間有封鎖關係的用戶，不論是誰封鎖誰
+type BlockList map[shared.UserID]struct{}
+
+// Contains 回傳 userID 是否在封鎖名單中
+func (b BlockList) Contains(userID shared.UserID) bool {
+	_, ok := b[userID]
+	return ok
+}
+
+// UserIDs 回傳封鎖名單中的用戶，供查詢在分頁前排除
+func (b BlockList) UserIDs() []shared.UserID {
+	ids := make([]shared.UserID, 0, len(b))
+	for userID := range b {
+		ids = append(ids, userID)
+	}
+	return ids
+}
+
+// InteractionPolicy 跨聚合的用戶互動規則，依好友關係與隱私設定決定用戶之間能否互動
+//   - 有封鎖關係的用戶互相不能邀請、不能一起參加聚餐，也看不到對方的內容
+//   - 受邀者的 PingAudience 為 friends 時只接受已接受好友的邀請
+type InteractionPolicy struct {
+	friendshipRepo friendship.FriendshipRepository
+	userRepo       user.UserRepository
+}
+
+func NewInteractionPolicy(friendshipRepo friendship.FriendshipRepository, userRepo user.UserRepository) *InteractionPolicy {
+	return &InteractionPolicy{
+		friendshipRepo: friendshipRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CheckInvite 確認 inviterID 可以邀請 inviteeID，實作 ping.InvitePolicy
+func (p *InteractionPolicy) CheckInvite(ctx context.Context, inviterID, inviteeID shared.UserID) error {
+	relation, err := p.relation(ctx, inviterID, inviteeID)
+	if err != nil {
+		return err
+	}
+	if relation != nil && relation.IsBlocked() {
+		return friendship.ErrFriendshipBlocked.WithMessage("cannot invite a blocked user")
+	}
+	if relation != nil && relation.IsActive() {
+		return nil
+	}
+
+	invitee, err := p.userRepo.FindByID(ctx, inviteeID)
+	if err != nil {
+		return err
+	}
+	if !invitee.AcceptsPingsFromStrangers() {
+		return ErrFriendsOnly
+	}
+	return nil
+}
+
+// CheckNotBlocked 確認兩位用戶之間沒有封鎖關係
+func (p *InteractionPolicy) CheckNotBlocked(ctx context.Context, userID1, userID2 shared.UserID) error {
+	relation, err := p.relation(ctx, userID1, userID2)
+	if err != nil {
+		return err
+	}
+	if relation != nil && relation.IsBlocked() {
+		return friendship.ErrFriendshipBlocked
+	}
+	return nil
+}
+
+// BlockedUsers 回傳與 userID 有封鎖關係的用戶，供列表查詢過濾對方的內容
+func (p *InteractionPolicy) BlockedUsers(ctx context.Context, userID shared.UserID) (BlockList, error) {
+	blocks, err := p.friendshipRepo.FindBlockedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(BlockList, len(blocks))
+	for _, f := range blocks {
+		blocked[f.GetOtherUserID(userID)] = struct{}{}
+	}
+	return blocked, nil
+}
+
+// relation 回傳兩位用戶之間的好友關係，沒有任何關係時回傳 nil
+func (p *InteractionPolicy) relation(ctx context.Context, userID1, userID2 shared.UserID) (*friendship.Friendship, error) {
+	relation, err := p.friendshipRepo.FindByUsers(ctx, userID1, userID2)
+	if errors.Is(err, shared.ErrEntityNotFound) {
+		return nil, nil
+	}
+	return relation, err
+}
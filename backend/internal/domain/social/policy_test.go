@@ -0,0 +1,125 @@
+package social_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/social"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+	persistenceInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+func TestInteractionPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	friends := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		f, err := friendship.NewFriendshipRequest(inviter, invitee, "")
+		if err != nil {
+			return nil, err
+		}
+		return f, f.Accept()
+	}
+	pending := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		return friendship.NewFriendshipRequest(inviter, invitee, "")
+	}
+	blockedByInviter := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		return friendship.NewBlock(inviter, invitee)
+	}
+	blockedByInvitee := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		return friendship.NewBlock(invitee, inviter)
+	}
+	blockedFriend := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		f, err := friends(inviter, invitee)
+		if err != nil {
+			return nil, err
+		}
+		return f, f.Block(invitee)
+	}
+	unblocked := func(inviter, invitee shared.UserID) (*friendship.Friendship, error) {
+		f, err := friendship.NewBlock(inviter, invitee)
+		if err != nil {
+			return nil, err
+		}
+		return f, f.Unblock(inviter)
+	}
+
+	tests := []struct {
+		name              string
+		audience          user.PingAudience
+		unregistered      bool // 受邀者不存在
+		relation          func(inviter, invitee shared.UserID) (*friendship.Friendship, error)
+		wantInviteErr     error
+		wantNotBlockedErr error
+	}{
+		{name: "friend", audience: user.PingAudienceFriends, relation: friends},
+		{name: "stranger with friends-only audience", audience: user.PingAudienceFriends, wantInviteErr: social.ErrFriendsOnly},
+		{name: "audience not set defaults to friends only", audience: "", wantInviteErr: social.ErrFriendsOnly},
+		{name: "pending request is not a friendship", audience: user.PingAudienceFriends, relation: pending, wantInviteErr: social.ErrFriendsOnly},
+		{name: "stranger with open audience", audience: user.PingAudienceEveryone},
+		{name: "blocked by inviter", audience: user.PingAudienceEveryone, relation: blockedByInviter, wantInviteErr: friendship.ErrFriendshipBlocked, wantNotBlockedErr: friendship.ErrFriendshipBlocked},
+		{name: "blocked by invitee", audience: user.PingAudienceEveryone, relation: blockedByInvitee, wantInviteErr: friendship.ErrFriendshipBlocked, wantNotBlockedErr: friendship.ErrFriendshipBlocked},
+		{name: "blocked friend", audience: user.PingAudienceEveryone, relation: blockedFriend, wantInviteErr: friendship.ErrFriendshipBlocked, wantNotBlockedErr: friendship.ErrFriendshipBlocked},
+		{name: "unblocked stranger with friends-only audience", audience: user.PingAudienceFriends, relation: unblocked, wantInviteErr: social.ErrFriendsOnly},
+		{name: "unknown invitee", unregistered: true, wantInviteErr: shared.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			friendshipRepo := persistenceInmemory.NewInMemoryFriendshipRepository(nil)
+			userRepo := inmemory.NewInMemoryUserRepository(nil)
+			policy := social.NewInteractionPolicy(friendshipRepo, userRepo)
+
+			inviter, invitee := shared.NewUserID(), shared.NewUserID()
+			if !tt.unregistered {
+				u, err := user.NewUser(shared.NewID().String()+"@example.com", "", "Password123!", "Invitee")
+				if err != nil {
+					t.Fatalf("NewUser() error = %v", err)
+				}
+				u.PrivacySettings.PingAudience = tt.audience
+				if err := userRepo.Save(ctx, u); err != nil {
+					t.Fatalf("Save() error = %v", err)
+				}
+				invitee = u.ID
+			}
+			if tt.relation != nil {
+				relation, err := tt.relation(inviter, invitee)
+				if err != nil {
+					t.Fatalf("relation setup error = %v", err)
+				}
+				if err := friendshipRepo.Save(ctx, relation); err != nil {
+					t.Fatalf("Save() relation error = %v", err)
+				}
+			}
+
+			if err := policy.CheckInvite(ctx, inviter, invitee); !matchesErr(err, tt.wantInviteErr) {
+				t.Errorf("CheckInvite() error = %v, want %v", err, tt.wantInviteErr)
+			}
+			if err := policy.CheckNotBlocked(ctx, invitee, inviter); !matchesErr(err, tt.wantNotBlockedErr) {
+				t.Errorf("CheckNotBlocked() error = %v, want %v", err, tt.wantNotBlockedErr)
+			}
+
+			// 封鎖不分方向，雙方的封鎖名單都包含對方
+			wantBlocked := tt.wantNotBlockedErr != nil
+			for _, pair := range [][2]shared.UserID{{inviter, invitee}, {invitee, inviter}} {
+				blocked, err := policy.BlockedUsers(ctx, pair[0])
+				if err != nil {
+					t.Fatalf("BlockedUsers() error = %v", err)
+				}
+				if blocked.Contains(pair[1]) != wantBlocked || len(blocked) != len(blocked.UserIDs()) {
+					t.Errorf("BlockedUsers(%v) = %v, want contains %v = %v", pair[0], blocked.UserIDs(), pair[1], wantBlocked)
+				}
+			}
+		})
+	}
+}
+
+func matchesErr(err, want error) bool {
+	if want == nil {
+		return err == nil
+	}
+	return errors.Is(err, want)
+}
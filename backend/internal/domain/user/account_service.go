@@ -0,0 +1,192 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// AccountService 處理 Email 驗證、密碼重設與未驗證帳號清理
+// token 以 HMAC 簽章並存入 repository，確保只能使用一次
+type AccountService struct {
+	userRepo        UserRepository
+	tokenRepo       ActionTokenRepository
+	signer          actionTokenSigner
+	verificationTTL time.Duration
+	resetTTL        time.Duration
+	now             func() time.Time
+}
+
+func NewAccountService(userRepo UserRepository, tokenRepo ActionTokenRepository, secret string, verificationTTL, resetTTL time.Duration) *AccountService {
+	return &AccountService{
+		userRepo:        userRepo,
+		tokenRepo:       tokenRepo,
+		signer:          actionTokenSigner{secret: []byte(secret)},
+		verificationTTL: verificationTTL,
+		resetTTL:        resetTTL,
+		now:             time.Now,
+	}
+}
+
+// VerificationTTL 回傳 Email 驗證 token 的有效時間
+func (s *AccountService) VerificationTTL() time.Duration {
+	return s.verificationTTL
+}
+
+// PasswordResetTTL 回傳密碼重設 token 的有效時間
+func (s *AccountService) PasswordResetTTL() time.Duration {
+	return s.resetTTL
+}
+
+// IssueVerificationToken 為尚未驗證的用戶簽發 Email 驗證 token，先前簽發的 token 會失效
+func (s *AccountService) IssueVerificationToken(ctx context.Context, userID shared.UserID) (*User, string, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, "", shared.ErrUserNotFound
+	}
+	if user.IsVerified {
+		return nil, "", shared.ErrAlreadyVerified
+	}
+
+	raw, err := s.issue(ctx, user.ID, PurposeEmailVerification, s.verificationTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, raw, nil
+}
+
+// VerifyEmail 使用驗證 token 將帳號標記為已驗證
+func (s *AccountService) VerifyEmail(ctx context.Context, raw string) (*User, error) {
+	token, err := s.consume(ctx, raw, PurposeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, shared.ErrInvalidActionToken
+	}
+	if !user.IsVerified {
+		user.Verify()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// IssuePasswordReset 為有效帳號簽發密碼重設 token
+// 帳號不存在或已停用時回傳 shared.ErrUserNotFound，呼叫端不應將此結果透露給請求者
+func (s *AccountService) IssuePasswordReset(ctx context.Context, email string) (*User, string, error) {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		return nil, "", shared.ErrUserNotFound
+	}
+
+	raw, err := s.issue(ctx, user.ID, PurposePasswordReset, s.resetTTL)
+	if err != nil {
+		return nil, "", err
+	}
+	return user, raw, nil
+}
+
+// ResetPassword 使用重設 token 設定新密碼
+func (s *AccountService) ResetPassword(ctx context.Context, raw, newPassword string) (*User, error) {
+	if !isStrongPassword(newPassword) {
+		return nil, shared.ErrWeakPassword
+	}
+
+	token, err := s.consume(ctx, raw, PurposePasswordReset)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil || !user.IsActive {
+		return nil, shared.ErrInvalidActionToken
+	}
+	if err := user.ResetPassword(newPassword); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeactivateUnverified 停用註冊超過 maxAgeDays 天仍未驗證的帳號，回傳被停用的用戶 ID
+func (s *AccountService) DeactivateUnverified(ctx context.Context, maxAgeDays int) ([]shared.UserID, error) {
+	users, err := s.userRepo.FindUnverifiedUsers(ctx, maxAgeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var deactivated []shared.UserID
+	for _, user := range users {
+		if !user.IsActive {
+			continue
+		}
+		user.Deactivate()
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return deactivated, err
+		}
+		deactivated = append(deactivated, user.ID)
+	}
+	return deactivated, nil
+}
+
+func (s *AccountService) issue(ctx context.Context, userID shared.UserID, purpose TokenPurpose, ttl time.Duration) (string, error) {
+	now := s.now()
+	if err := s.tokenRepo.InvalidateForUser(ctx, userID, purpose, now); err != nil {
+		return "", err
+	}
+
+	token := &ActionToken{
+		ID:        shared.NewID(),
+		UserID:    userID,
+		Purpose:   purpose,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.tokenRepo.Save(ctx, token); err != nil {
+		return "", err
+	}
+	return s.signer.sign(token)
+}
+
+// consume 驗證 token 並標記為已使用
+func (s *AccountService) consume(ctx context.Context, raw string, purpose TokenPurpose) (*ActionToken, error) {
+	payload, err := s.signer.parse(raw, purpose)
+	if err != nil {
+		return nil, err
+	}
+	id, err := shared.ParseID(payload.ID)
+	if err != nil {
+		return nil, shared.ErrInvalidActionToken
+	}
+
+	token, err := s.tokenRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrEntityNotFound) {
+			return nil, shared.ErrInvalidActionToken
+		}
+		return nil, err
+	}
+	if token.Purpose != purpose || token.UserID.String() != payload.UserID {
+		return nil, shared.ErrInvalidActionToken
+	}
+
+	now := s.now()
+	if token.IsUsed() {
+		return nil, shared.ErrActionTokenUsed
+	}
+	if token.IsExpired(now) {
+		return nil, shared.ErrActionTokenExpired
+	}
+	if err := s.tokenRepo.MarkUsed(ctx, token.ID, now); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,185 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+	persistenceInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+const testAccountSecret = "test-account-secret"
+
+func newTestAccountService(t *testing.T, verificationTTL, resetTTL time.Duration) (*user.AccountService, user.UserRepository) {
+	t.Helper()
+	userRepo := inmemory.NewInMemoryUserRepository(nil)
+	service := user.NewAccountService(userRepo, persistenceInmemory.NewActionTokenRepository(), testAccountSecret, verificationTTL, resetTTL)
+	return service, userRepo
+}
+
+func registerTestUser(t *testing.T, repo user.UserRepository, email string) *user.User {
+	t.Helper()
+	u, err := user.NewUser(email, "", "Password123!", "Test User")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if err := repo.Save(context.Background(), u); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return u
+}
+
+func TestVerifyEmailIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestAccountService(t, time.Hour, time.Hour)
+	u := registerTestUser(t, repo, "frank@pingnom.app")
+
+	first, _, err := service.IssueVerificationToken(ctx, u.ID)
+	if err != nil || first.ID != u.ID {
+		t.Fatalf("IssueVerificationToken() = %v, %v", first, err)
+	}
+	_, staleToken, _ := service.IssueVerificationToken(ctx, u.ID)
+	_, token, err := service.IssueVerificationToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("IssueVerificationToken() error = %v", err)
+	}
+
+	// 重新簽發後舊 token 失效
+	if _, err := service.VerifyEmail(ctx, staleToken); !errors.Is(err, shared.ErrActionTokenUsed) {
+		t.Errorf("VerifyEmail() stale token error = %v, want %v", err, shared.ErrActionTokenUsed)
+	}
+
+	verified, err := service.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !verified.IsVerified {
+		t.Error("VerifyEmail() did not mark the user as verified")
+	}
+	if _, err := service.VerifyEmail(ctx, token); !errors.Is(err, shared.ErrActionTokenUsed) {
+		t.Errorf("VerifyEmail() reused token error = %v, want %v", err, shared.ErrActionTokenUsed)
+	}
+	if _, _, err := service.IssueVerificationToken(ctx, u.ID); !errors.Is(err, shared.ErrAlreadyVerified) {
+		t.Errorf("IssueVerificationToken() verified user error = %v, want %v", err, shared.ErrAlreadyVerified)
+	}
+}
+
+func TestActionTokensRejectTamperingAndWrongPurpose(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestAccountService(t, time.Hour, time.Hour)
+	u := registerTestUser(t, repo, "alice@pingnom.app")
+
+	_, verification, err := service.IssueVerificationToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("IssueVerificationToken() error = %v", err)
+	}
+
+	// 驗證 token 不能拿來重設密碼
+	if _, err := service.ResetPassword(ctx, verification, "NewPassword123!"); !errors.Is(err, shared.ErrInvalidActionToken) {
+		t.Errorf("ResetPassword() with verification token error = %v, want %v", err, shared.ErrInvalidActionToken)
+	}
+
+	// 以其他 secret 簽發的 token 無效
+	other := user.NewAccountService(repo, persistenceInmemory.NewActionTokenRepository(), "other-secret", time.Hour, time.Hour)
+	_, forged, err := other.IssueVerificationToken(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("IssueVerificationToken() error = %v", err)
+	}
+	for _, raw := range []string{forged, verification + "x", "garbage"} {
+		if _, err := service.VerifyEmail(ctx, raw); !errors.Is(err, shared.ErrInvalidActionToken) {
+			t.Errorf("VerifyEmail(%q) error = %v, want %v", raw, err, shared.ErrInvalidActionToken)
+		}
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestAccountService(t, time.Hour, time.Hour)
+	u := registerTestUser(t, repo, "frank@pingnom.app")
+
+	if _, _, err := service.IssuePasswordReset(ctx, "nobody@pingnom.app"); !errors.Is(err, shared.ErrUserNotFound) {
+		t.Errorf("IssuePasswordReset() unknown email error = %v, want %v", err, shared.ErrUserNotFound)
+	}
+
+	_, token, err := service.IssuePasswordReset(ctx, u.Email)
+	if err != nil {
+		t.Fatalf("IssuePasswordReset() error = %v", err)
+	}
+	if _, err := service.ResetPassword(ctx, token, "weak"); !errors.Is(err, shared.ErrWeakPassword) {
+		t.Errorf("ResetPassword() weak password error = %v, want %v", err, shared.ErrWeakPassword)
+	}
+	if _, err := service.ResetPassword(ctx, token, "NewPassword123!"); err != nil {
+		t.Fatalf("ResetPassword() error = %v", err)
+	}
+
+	updated, err := repo.FindByID(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !updated.VerifyPassword("NewPassword123!") || updated.VerifyPassword("Password123!") {
+		t.Error("ResetPassword() did not replace the password")
+	}
+	if _, err := service.ResetPassword(ctx, token, "OtherPassword123!"); !errors.Is(err, shared.ErrActionTokenUsed) {
+		t.Errorf("ResetPassword() reused token error = %v, want %v", err, shared.ErrActionTokenUsed)
+	}
+}
+
+func TestResetPasswordRejectsExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestAccountService(t, time.Hour, -time.Second)
+	u := registerTestUser(t, repo, "frank@pingnom.app")
+
+	_, token, err := service.IssuePasswordReset(ctx, u.Email)
+	if err != nil {
+		t.Fatalf("IssuePasswordReset() error = %v", err)
+	}
+	if _, err := service.ResetPassword(ctx, token, "NewPassword123!"); !errors.Is(err, shared.ErrActionTokenExpired) {
+		t.Errorf("ResetPassword() expired token error = %v, want %v", err, shared.ErrActionTokenExpired)
+	}
+}
+
+func TestDeactivateUnverified(t *testing.T) {
+	ctx := context.Background()
+	service, repo := newTestAccountService(t, time.Hour, time.Hour)
+
+	stale := registerTestUser(t, repo, "stale@pingnom.app")
+	stale.CreatedAt = time.Now().AddDate(0, 0, -30)
+	verified := registerTestUser(t, repo, "verified@pingnom.app")
+	verified.CreatedAt = time.Now().AddDate(0, 0, -30)
+	verified.Verify()
+	fresh := registerTestUser(t, repo, "fresh@pingnom.app")
+
+	deactivated, err := service.DeactivateUnverified(ctx, 14)
+	if err != nil {
+		t.Fatalf("DeactivateUnverified() error = %v", err)
+	}
+	if len(deactivated) != 1 || deactivated[0] != stale.ID {
+		t.Fatalf("DeactivateUnverified() = %v, want [%v]", deactivated, stale.ID)
+	}
+
+	for _, tt := range []struct {
+		user   *user.User
+		active bool
+	}{
+		{stale, false},
+		{verified, true},
+		{fresh, true},
+	} {
+		got, err := repo.FindByID(ctx, tt.user.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.IsActive != tt.active {
+			t.Errorf("%s active = %v, want %v", got.Email, got.IsActive, tt.active)
+		}
+	}
+
+	// 已停用的帳號不會重複處理
+	if again, err := service.DeactivateUnverified(ctx, 14); err != nil || len(again) != 0 {
+		t.Errorf("DeactivateUnverified() second run = %v, %v, want none", again, err)
+	}
+}
@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// TokenPurpose 區分一次性 token 的用途，不同用途的 token 不可互用
+type TokenPurpose string
+
+const (
+	PurposeEmailVerification TokenPurpose = "email_verification"
+	PurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// ActionToken 是寄送給使用者的一次性 token (Email 驗證、密碼重設)
+// 交給使用者的字串帶有 HMAC 簽章，資料庫只用來確保 token 只能使用一次
+type ActionToken struct {
+	ID        shared.ID     `json:"id"`
+	UserID    shared.UserID `json:"userId"`
+	Purpose   TokenPurpose  `json:"purpose"`
+	CreatedAt time.Time     `json:"createdAt"`
+	ExpiresAt time.Time     `json:"expiresAt"`
+	UsedAt    *time.Time    `json:"usedAt,omitempty"`
+}
+
+// ActionTokenRepository 定義一次性 token 的儲存介面
+type ActionTokenRepository interface {
+	// Save 儲存新的 token
+	Save(ctx context.Context, token *ActionToken) error
+
+	// FindByID 根據 ID 查找 token，找不到時回傳 shared.ErrEntityNotFound
+	FindByID(ctx context.Context, id shared.ID) (*ActionToken, error)
+
+	// MarkUsed 將 token 標記為已使用，已使用過時回傳 shared.ErrActionTokenUsed
+	MarkUsed(ctx context.Context, id shared.ID, at time.Time) error
+
+	// InvalidateForUser 讓用戶同用途且尚未使用的 token 全部失效
+	InvalidateForUser(ctx context.Context, userID shared.UserID, purpose TokenPurpose, at time.Time) error
+}
+
+// IsUsed 檢查 token 是否已使用
+func (t *ActionToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired 檢查 token 是否已過期
+func (t *ActionToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// actionTokenPayload 是簽章字串中攜帶的內容
+type actionTokenPayload struct {
+	ID        string       `json:"jti"`
+	UserID    string       `json:"sub"`
+	Purpose   TokenPurpose `json:"pur"`
+	ExpiresAt int64        `json:"exp"`
+}
+
+// actionTokenSigner 以 HMAC-SHA256 簽署與驗證 token 字串
+type actionTokenSigner struct {
+	secret []byte
+}
+
+func (s actionTokenSigner) sign(token *ActionToken) (string, error) {
+	payload, err := json.Marshal(actionTokenPayload{
+		ID:        token.ID.String(),
+		UserID:    token.UserID.String(),
+		Purpose:   token.Purpose,
+		ExpiresAt: token.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.signature(encoded), nil
+}
+
+// parse 驗證簽章與用途後回傳 token ID；過期與是否使用過由呼叫端檢查
+func (s actionTokenSigner) parse(raw string, purpose TokenPurpose) (actionTokenPayload, error) {
+	encoded, signature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return actionTokenPayload{}, shared.ErrInvalidActionToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(encoded))) {
+		return actionTokenPayload{}, shared.ErrInvalidActionToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return actionTokenPayload{}, shared.ErrInvalidActionToken
+	}
+
+	var payload actionTokenPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return actionTokenPayload{}, shared.ErrInvalidActionToken
+	}
+	if payload.Purpose != purpose {
+		return actionTokenPayload{}, shared.ErrInvalidActionToken
+	}
+	return payload, nil
+}
+
+func (s actionTokenSigner) signature(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,9 @@
+package user
+
+import "github.com/chun-wei0413/pingnom/internal/domain/shared"
+
+// 用戶領域專屬的錯誤，其餘共用錯誤 (例如 shared.ErrUserNotFound) 定義在 shared
+var (
+	ErrInsufficientRole  = shared.NewDomainError(shared.KindForbidden, "INSUFFICIENT_ROLE", "insufficient role")
+	ErrIncorrectPassword = shared.NewDomainError(shared.KindInvalidInput, "INCORRECT_CURRENT_PASSWORD", "invalid current password")
+)
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// User 聚合的領域事件名稱
+const (
+	EventUserRegistered      = "user.registered"
+	EventUserEmailVerified   = "user.email_verified"
+	EventUserPasswordChanged = "user.password_changed"
+	EventUserDeactivated     = "user.deactivated"
+	EventUserRoleChanged     = "user.role_changed"
+)
+
+// UserRegistered 新用戶完成註冊
+type UserRegistered struct {
+	UserID      shared.UserID `json:"userId"`
+	Email       string        `json:"email"`
+	DisplayName string        `json:"displayName"`
+}
+
+func (e UserRegistered) EventName() string   { return EventUserRegistered }
+func (e UserRegistered) AggregateID() string { return e.UserID.String() }
+
+// UserEmailVerified 用戶完成 Email 驗證
+type UserEmailVerified struct {
+	UserID shared.UserID `json:"userId"`
+	Email  string        `json:"email"`
+}
+
+func (e UserEmailVerified) EventName() string   { return EventUserEmailVerified }
+func (e UserEmailVerified) AggregateID() string { return e.UserID.String() }
+
+// UserPasswordChanged 用戶變更或重設了密碼
+type UserPasswordChanged struct {
+	UserID shared.UserID `json:"userId"`
+	Reset  bool          `json:"reset"` // true 代表透過重設密碼流程
+}
+
+func (e UserPasswordChanged) EventName() string   { return EventUserPasswordChanged }
+func (e UserPasswordChanged) AggregateID() string { return e.UserID.String() }
+
+// UserDeactivated 帳號被停用
+type UserDeactivated struct {
+	UserID shared.UserID `json:"userId"`
+}
+
+func (e UserDeactivated) EventName() string   { return EventUserDeactivated }
+func (e UserDeactivated) AggregateID() string { return e.UserID.String() }
+
+// UserRoleChanged 用戶角色被變更
+type UserRoleChanged struct {
+	UserID shared.UserID `json:"userId"`
+	From   Role          `json:"from"`
+	To     Role          `json:"to"`
+}
+
+func (e UserRoleChanged) EventName() string   { return EventUserRoleChanged }
+func (e UserRoleChanged) AggregateID() string { return e.UserID.String() }
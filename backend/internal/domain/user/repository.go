@@ -18,8 +18,9 @@ type UserRepository interface {
 	Delete(ctx context.Context, id shared.UserID) error
 	
 	// Query operations
-	FindDiscoverableUsers(ctx context.Context, limit, offset int) ([]*User, error)
-	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*User, error)
+	// FindDiscoverableUsers / SearchUsers 不含 excludeIDs 中的用戶 (在分頁前排除)
+	FindDiscoverableUsers(ctx context.Context, excludeIDs []shared.UserID, limit, offset int) ([]*User, error)
+	SearchUsers(ctx context.Context, query string, excludeIDs []shared.UserID, limit, offset int) ([]*User, error)
 	CountUsers(ctx context.Context) (int64, error)
 	
 	// Business-specific queries
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Role 用戶的權限角色，高階角色擁有低階角色的所有權限
+type Role string
+
+const (
+	RoleUser      Role = "user"      // 一般用戶
+	RoleModerator Role = "moderator" // 可管理其他用戶發布的內容 (例如刪除評論)
+	RoleAdmin     Role = "admin"     // 可管理餐廳目錄、用戶帳號與角色
+)
+
+// roleRanks 角色的權限高低
+var roleRanks = map[Role]int{
+	RoleUser:      1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// ParseRole 解析角色名稱 (不分大小寫)
+func ParseRole(raw string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(raw)))
+	if !role.IsValid() {
+		return "", fmt.Errorf("%w: unknown role %q", shared.ErrInvalidInput, raw)
+	}
+	return role, nil
+}
+
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// OrDefault 角色為空時視為一般用戶 (新增角色前建立的帳號與 token 沒有角色)
+func (r Role) OrDefault() Role {
+	if r == "" {
+		return RoleUser
+	}
+	return r
+}
+
+// Includes 回傳角色是否擁有 required 角色的權限
+func (r Role) Includes(required Role) bool {
+	rank, ok := roleRanks[r.OrDefault()]
+	return ok && rank >= roleRanks[required]
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"errors"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -55,6 +54,11 @@ func (s *UserService) RegisterUser(ctx context.Context, email, phoneNumber, pass
 	return user, nil
 }
 
+// GetUserByID retrieves user by ID
+func (s *UserService) GetUserByID(ctx context.Context, userID shared.UserID) (*User, error) {
+	return s.userRepo.FindByID(ctx, userID)
+}
+
 // GetUserByEmail retrieves user by email
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	return s.userRepo.FindByEmail(ctx, email)
@@ -68,11 +72,11 @@ func (s *UserService) AuthenticateUser(ctx context.Context, email, password stri
 	}
 	
 	if !user.IsActive {
-		return nil, errors.New("user account is deactivated")
+		return nil, shared.ErrUserInactive
 	}
 	
 	if !user.VerifyPassword(password) {
-		return nil, errors.New("invalid password")
+		return nil, shared.ErrInvalidCredentials
 	}
 	
 	return user, nil
@@ -114,6 +118,19 @@ func (s *UserService) UpdatePrivacySettings(ctx context.Context, userID shared.U
 	return s.userRepo.Update(ctx, user)
 }
 
+// UpdateNotificationPreferences updates user notification preferences
+func (s *UserService) UpdateNotificationPreferences(ctx context.Context, userID shared.UserID, preferences NotificationPreferences) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return shared.ErrUserNotFound
+	}
+	
+	if err := user.UpdateNotificationPreferences(preferences); err != nil {
+		return err
+	}
+	return s.userRepo.Update(ctx, user)
+}
+
 // VerifyUser marks a user as verified
 func (s *UserService) VerifyUser(ctx context.Context, userID shared.UserID) error {
 	user, err := s.userRepo.FindByID(ctx, userID)
@@ -139,12 +156,12 @@ func (s *UserService) ChangePassword(ctx context.Context, userID shared.UserID,
 	return s.userRepo.Update(ctx, user)
 }
 
-// SearchDiscoverableUsers returns users that can be discovered by others
-func (s *UserService) SearchDiscoverableUsers(ctx context.Context, query string, limit, offset int) ([]*User, error) {
+// SearchDiscoverableUsers returns users that can be discovered by others, leaving out excludeIDs
+func (s *UserService) SearchDiscoverableUsers(ctx context.Context, query string, excludeIDs []shared.UserID, limit, offset int) ([]*User, error) {
 	if query == "" {
-		return s.userRepo.FindDiscoverableUsers(ctx, limit, offset)
+		return s.userRepo.FindDiscoverableUsers(ctx, excludeIDs, limit, offset)
 	}
-	return s.userRepo.SearchUsers(ctx, query, limit, offset)
+	return s.userRepo.SearchUsers(ctx, query, excludeIDs, limit, offset)
 }
 
 // DeactivateUser deactivates a user account
@@ -156,4 +173,20 @@ func (s *UserService) DeactivateUser(ctx context.Context, userID shared.UserID)
 	
 	user.Deactivate()
 	return s.userRepo.Update(ctx, user)
-}
\ No newline at end of file
+}
+
+// ChangeUserRole changes the role of a user account
+func (s *UserService) ChangeUserRole(ctx context.Context, userID shared.UserID, role Role) (*User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, shared.ErrUserNotFound
+	}
+	
+	if err := user.ChangeRole(role); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,46 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/inmemory"
+)
+
+func TestSearchDiscoverableUsersExcludesBeforePaging(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewInMemoryUserRepository(nil)
+	service := user.NewUserService(repo)
+
+	blocked := registerTestUser(t, repo, "blocked@pingnom.app")
+	registerTestUser(t, repo, "alice@pingnom.app")
+	registerTestUser(t, repo, "bob@pingnom.app")
+	excluded := []shared.UserID{blocked.ID}
+
+	for _, query := range []string{"", "pingnom"} {
+		all, err := service.SearchDiscoverableUsers(ctx, query, excluded, 10, 0)
+		if err != nil {
+			t.Fatalf("SearchDiscoverableUsers(%q) error = %v", query, err)
+		}
+		if len(all) != 2 {
+			t.Errorf("SearchDiscoverableUsers(%q) returned %d users, want 2", query, len(all))
+		}
+
+		// 第二頁不應因為排除的用戶而變短
+		page, err := service.SearchDiscoverableUsers(ctx, query, excluded, 1, 1)
+		if err != nil {
+			t.Fatalf("SearchDiscoverableUsers(%q, limit 1, offset 1) error = %v", query, err)
+		}
+		if len(page) != 1 {
+			t.Fatalf("SearchDiscoverableUsers(%q, limit 1, offset 1) returned %d users, want 1", query, len(page))
+		}
+
+		for _, u := range append(all, page...) {
+			if u.ID == blocked.ID {
+				t.Errorf("SearchDiscoverableUsers(%q) returned the excluded user", query)
+			}
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package user
 
 import (
-	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -11,17 +11,21 @@ import (
 )
 
 type User struct {
-	ID              shared.UserID      `json:"id"`
-	Email           string             `json:"email"`
-	PhoneNumber     string             `json:"phoneNumber,omitempty"`
-	PasswordHash    string             `json:"-"`
-	Profile         UserProfile        `json:"profile"`
-	Preferences     DietaryPreferences `json:"preferences"`
-	PrivacySettings PrivacySettings    `json:"privacySettings"`
-	IsActive        bool               `json:"isActive"`
-	IsVerified      bool               `json:"isVerified"`
-	CreatedAt       time.Time          `json:"createdAt"`
-	UpdatedAt       time.Time          `json:"updatedAt"`
+	shared.EventRecorder
+
+	ID                      shared.UserID           `json:"id"`
+	Email                   string                  `json:"email"`
+	PhoneNumber             string                  `json:"phoneNumber,omitempty"`
+	PasswordHash            string                  `json:"-"`
+	Profile                 UserProfile             `json:"profile"`
+	Preferences             DietaryPreferences      `json:"preferences"`
+	PrivacySettings         PrivacySettings         `json:"privacySettings"`
+	NotificationPreferences NotificationPreferences `json:"notificationPreferences"`
+	Role                    Role                    `json:"role"`
+	IsActive                bool                    `json:"isActive"`
+	IsVerified              bool                    `json:"isVerified"`
+	CreatedAt               time.Time               `json:"createdAt"`
+	UpdatedAt               time.Time               `json:"updatedAt"`
 }
 
 var (
@@ -66,24 +70,33 @@ func NewUser(email, phoneNumber, password, displayName string) (*User, error) {
 	
 	now := time.Now()
 	
-	return &User{
-		ID:              userID,
-		Email:           strings.ToLower(strings.TrimSpace(email)),
-		PhoneNumber:     strings.TrimSpace(phoneNumber),
-		PasswordHash:    string(passwordHash),
-		Profile:         profile,
-		Preferences:     DietaryPreferences{},
-		PrivacySettings: DefaultPrivacySettings(),
-		IsActive:        true,
-		IsVerified:      false,
-		CreatedAt:       now,
-		UpdatedAt:       now,
-	}, nil
+	u := &User{
+		ID:                      userID,
+		Email:                   strings.ToLower(strings.TrimSpace(email)),
+		PhoneNumber:             strings.TrimSpace(phoneNumber),
+		PasswordHash:            string(passwordHash),
+		Profile:                 profile,
+		Preferences:             DietaryPreferences{},
+		PrivacySettings:         DefaultPrivacySettings(),
+		NotificationPreferences: DefaultNotificationPreferences(),
+		Role:                    RoleUser,
+		IsActive:                true,
+		IsVerified:              false,
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+	u.Record(UserRegistered{
+		UserID:      u.ID,
+		Email:       u.Email,
+		DisplayName: displayName,
+	})
+	
+	return u, nil
 }
 
 func (u *User) UpdateProfile(profile UserProfile) error {
 	if !profile.IsComplete() {
-		return errors.New("profile is incomplete")
+		return shared.ErrInvalidInput.WithMessage("profile is incomplete")
 	}
 	
 	u.Profile = profile
@@ -101,6 +114,16 @@ func (u *User) UpdatePrivacySettings(settings PrivacySettings) {
 	u.UpdatedAt = time.Now()
 }
 
+func (u *User) UpdateNotificationPreferences(preferences NotificationPreferences) error {
+	if err := preferences.Validate(); err != nil {
+		return err
+	}
+	
+	u.NotificationPreferences = preferences
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
 func (u *User) VerifyPassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
 	return err == nil
@@ -108,7 +131,7 @@ func (u *User) VerifyPassword(password string) bool {
 
 func (u *User) ChangePassword(oldPassword, newPassword string) error {
 	if !u.VerifyPassword(oldPassword) {
-		return errors.New("invalid current password")
+		return ErrIncorrectPassword
 	}
 	
 	if !isStrongPassword(newPassword) {
@@ -122,15 +145,39 @@ func (u *User) ChangePassword(oldPassword, newPassword string) error {
 	
 	u.PasswordHash = string(passwordHash)
 	u.UpdatedAt = time.Now()
+	u.Record(UserPasswordChanged{UserID: u.ID})
+	return nil
+}
+
+// ResetPassword 以重設密碼流程設定新密碼 (不需舊密碼，呼叫端須先驗證重設 token)
+func (u *User) ResetPassword(newPassword string) error {
+	if !isStrongPassword(newPassword) {
+		return shared.ErrWeakPassword
+	}
+	
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	
+	u.PasswordHash = string(passwordHash)
+	u.UpdatedAt = time.Now()
+	u.Record(UserPasswordChanged{UserID: u.ID, Reset: true})
 	return nil
 }
 
 func (u *User) Verify() {
+	if !u.IsVerified {
+		u.Record(UserEmailVerified{UserID: u.ID, Email: u.Email})
+	}
 	u.IsVerified = true
 	u.UpdatedAt = time.Now()
 }
 
 func (u *User) Deactivate() {
+	if u.IsActive {
+		u.Record(UserDeactivated{UserID: u.ID})
+	}
 	u.IsActive = false
 	u.UpdatedAt = time.Now()
 }
@@ -140,6 +187,25 @@ func (u *User) Activate() {
 	u.UpdatedAt = time.Now()
 }
 
+// ChangeRole 變更用戶角色
+func (u *User) ChangeRole(role Role) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: unknown role %q", shared.ErrInvalidInput, role)
+	}
+	
+	if u.Role != role {
+		u.Record(UserRoleChanged{UserID: u.ID, From: u.Role.OrDefault(), To: role})
+	}
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// HasRole 回傳用戶是否擁有 role 的權限
+func (u *User) HasRole(role Role) bool {
+	return u.Role.Includes(role)
+}
+
 func (u *User) CanBeDiscovered() bool {
 	return u.IsActive && u.PrivacySettings.IsDiscoverable
 }
@@ -148,6 +214,11 @@ func (u *User) AllowsFriendRequests() bool {
 	return u.IsActive && u.PrivacySettings.AllowFriendRequest
 }
 
+// AcceptsPingsFromStrangers 回傳非好友的用戶是否可以邀請此用戶參加 ping
+func (u *User) AcceptsPingsFromStrangers() bool {
+	return u.PrivacySettings.PingAudience.OrDefault() == PingAudienceEveryone
+}
+
 func (u *User) SharesLocation() bool {
 	return u.IsActive && u.PrivacySettings.ShowLocation
 }
@@ -1,8 +1,9 @@
 package user
 
 import (
-	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -16,19 +17,19 @@ type UserProfile struct {
 
 func NewUserProfile(displayName, avatar, bio string, locations []shared.Location) (UserProfile, error) {
 	if strings.TrimSpace(displayName) == "" {
-		return UserProfile{}, errors.New("display name cannot be empty")
+		return UserProfile{}, shared.ErrInvalidInput.WithMessage("display name cannot be empty")
 	}
 	
 	if len(displayName) > 100 {
-		return UserProfile{}, errors.New("display name cannot exceed 100 characters")
+		return UserProfile{}, shared.ErrInvalidInput.WithMessage("display name cannot exceed 100 characters")
 	}
 	
 	if len(bio) > 500 {
-		return UserProfile{}, errors.New("bio cannot exceed 500 characters")
+		return UserProfile{}, shared.ErrInvalidInput.WithMessage("bio cannot exceed 500 characters")
 	}
 	
 	if len(locations) > 5 {
-		return UserProfile{}, errors.New("cannot have more than 5 default locations")
+		return UserProfile{}, shared.ErrInvalidInput.WithMessage("cannot have more than 5 default locations")
 	}
 	
 	return UserProfile{
@@ -56,11 +57,11 @@ type PriceRange struct {
 
 func NewDietaryPreferences(cuisines, restrictions []string, minPrice, maxPrice int) (DietaryPreferences, error) {
 	if minPrice < 0 || maxPrice < 0 {
-		return DietaryPreferences{}, errors.New("price cannot be negative")
+		return DietaryPreferences{}, shared.ErrInvalidInput.WithMessage("price cannot be negative")
 	}
 	
 	if minPrice > maxPrice {
-		return DietaryPreferences{}, errors.New("minimum price cannot be greater than maximum price")
+		return DietaryPreferences{}, shared.ErrInvalidInput.WithMessage("minimum price cannot be greater than maximum price")
 	}
 	
 	return DietaryPreferences{
@@ -74,9 +75,10 @@ func NewDietaryPreferences(cuisines, restrictions []string, minPrice, maxPrice i
 }
 
 type PrivacySettings struct {
-	IsDiscoverable     bool `json:"isDiscoverable"`
-	ShowLocation       bool `json:"showLocation"`
-	AllowFriendRequest bool `json:"allowFriendRequest"`
+	IsDiscoverable     bool         `json:"isDiscoverable"`
+	ShowLocation       bool         `json:"showLocation"`
+	AllowFriendRequest bool         `json:"allowFriendRequest"`
+	PingAudience       PingAudience `json:"pingAudience"` // 誰可以邀請此用戶參加 ping
 }
 
 func DefaultPrivacySettings() PrivacySettings {
@@ -84,5 +86,125 @@ func DefaultPrivacySettings() PrivacySettings {
 		IsDiscoverable:     true,
 		ShowLocation:       true,
 		AllowFriendRequest: true,
+		PingAudience:       PingAudienceFriends,
 	}
-}
\ No newline at end of file
+}
+
+// PingAudience 可以邀請用戶參加 ping 的對象，封鎖關係不論設定為何都不能邀請
+type PingAudience string
+
+const (
+	PingAudienceFriends  PingAudience = "friends"  // 只有已接受的好友
+	PingAudienceEveryone PingAudience = "everyone" // 任何用戶
+)
+
+// ParsePingAudience 解析邀請對象設定 (不分大小寫)，空字串視為預設值
+func ParsePingAudience(raw string) (PingAudience, error) {
+	audience := PingAudience(strings.ToLower(strings.TrimSpace(raw))).OrDefault()
+	if audience != PingAudienceFriends && audience != PingAudienceEveryone {
+		return "", fmt.Errorf("%w: unknown ping audience %q", shared.ErrInvalidInput, raw)
+	}
+	return audience, nil
+}
+
+// OrDefault 設定為空時只允許好友邀請 (新增此設定前建立的帳號沒有設定值)
+func (a PingAudience) OrDefault() PingAudience {
+	if a == "" {
+		return PingAudienceFriends
+	}
+	return a
+}
+
+// NotificationPreferences 用戶的通知偏好：接收哪些通知、經由哪些管道，以及勿擾時段
+type NotificationPreferences struct {
+	InApp          bool       `json:"inApp"`          // 站內即時提示
+	Email          bool       `json:"email"`
+	Push           bool       `json:"push"`           // 手機推播
+	PingInvites    bool       `json:"pingInvites"`    // 收到 ping 或聚餐計畫邀請時通知
+	FriendRequests bool       `json:"friendRequests"` // 收到好友邀請時通知
+	MealReminders  bool       `json:"mealReminders"`  // 用餐開始前提醒
+	QuietHours     QuietHours `json:"quietHours"`
+}
+
+// QuietHours 勿擾時段，期間不寄送 Email 與推播，通知仍會進入收件匣
+// Start、End 為 "HH:MM" 格式的當地時間，Start 晚於 End 代表跨越午夜
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone"` // IANA 時區，例如 "Asia/Taipei"
+}
+
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		InApp:          true,
+		Email:          true,
+		Push:           true,
+		PingInvites:    true,
+		FriendRequests: true,
+		MealReminders:  true,
+		QuietHours: QuietHours{
+			Enabled:  false,
+			Start:    "22:00",
+			End:      "08:00",
+			TimeZone: "Asia/Taipei",
+		},
+	}
+}
+
+// Validate 檢查勿擾時段的格式
+func (p NotificationPreferences) Validate() error {
+	q := p.QuietHours
+	if !q.Enabled {
+		return nil
+	}
+	if _, err := parseClock(q.Start); err != nil {
+		return fmt.Errorf("%w: quiet hours start must be in HH:MM format", shared.ErrInvalidInput)
+	}
+	if _, err := parseClock(q.End); err != nil {
+		return fmt.Errorf("%w: quiet hours end must be in HH:MM format", shared.ErrInvalidInput)
+	}
+	if q.Start == q.End {
+		return fmt.Errorf("%w: quiet hours start and end cannot be the same", shared.ErrInvalidInput)
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("%w: quiet hours time zone is invalid", shared.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Contains 檢查 at 是否落在勿擾時段內
+func (q QuietHours) Contains(at time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(q.End)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// 跨越午夜，例如 22:00 - 08:00
+	return minute >= start || minute < end
+}
+
+// parseClock 將 "HH:MM" 轉為當天的第幾分鐘
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -1,7 +1,9 @@
 package user
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
@@ -209,4 +211,109 @@ func TestUser_ChangePassword(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	taipei, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	overnight := QuietHours{Enabled: true, Start: "22:00", End: "08:00", TimeZone: "Asia/Taipei"}
+	daytime := QuietHours{Enabled: true, Start: "13:00", End: "14:00", TimeZone: "Asia/Taipei"}
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		at    time.Time
+		want  bool
+	}{
+		{"before overnight window", overnight, time.Date(2026, 1, 1, 21, 59, 0, 0, taipei), false},
+		{"start of overnight window", overnight, time.Date(2026, 1, 1, 22, 0, 0, 0, taipei), true},
+		{"after midnight", overnight, time.Date(2026, 1, 2, 3, 0, 0, 0, taipei), true},
+		{"end of overnight window", overnight, time.Date(2026, 1, 2, 8, 0, 0, 0, taipei), false},
+		{"converted from UTC", overnight, time.Date(2026, 1, 1, 15, 30, 0, 0, time.UTC), true},
+		{"inside daytime window", daytime, time.Date(2026, 1, 1, 13, 30, 0, 0, taipei), true},
+		{"outside daytime window", daytime, time.Date(2026, 1, 1, 23, 0, 0, 0, taipei), false},
+		{"disabled", QuietHours{Start: "00:00", End: "23:59", TimeZone: "Asia/Taipei"}, time.Date(2026, 1, 1, 12, 0, 0, 0, taipei), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quiet.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_UpdateNotificationPreferences(t *testing.T) {
+	testUser, _ := NewUser("test@example.com", "", "Test123!@#", "Test User")
+
+	invalid := DefaultNotificationPreferences()
+	invalid.QuietHours = QuietHours{Enabled: true, Start: "25:00", End: "08:00", TimeZone: "Asia/Taipei"}
+	if err := testUser.UpdateNotificationPreferences(invalid); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("UpdateNotificationPreferences() error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+	if testUser.NotificationPreferences != DefaultNotificationPreferences() {
+		t.Error("invalid preferences were applied")
+	}
+
+	valid := DefaultNotificationPreferences()
+	valid.Email = false
+	valid.QuietHours.Enabled = true
+	if err := testUser.UpdateNotificationPreferences(valid); err != nil {
+		t.Fatalf("UpdateNotificationPreferences() error = %v", err)
+	}
+	if testUser.NotificationPreferences != valid {
+		t.Errorf("NotificationPreferences = %+v, want %+v", testUser.NotificationPreferences, valid)
+	}
+}
+
+func TestUser_ChangeRole(t *testing.T) {
+	testUser, _ := NewUser("test@example.com", "", "Test123!@#", "Test User")
+	if testUser.Role != RoleUser || testUser.HasRole(RoleModerator) {
+		t.Fatalf("new user role = %q, want %q without moderator privileges", testUser.Role, RoleUser)
+	}
+
+	if err := testUser.ChangeRole("owner"); !errors.Is(err, shared.ErrInvalidInput) {
+		t.Errorf("ChangeRole(owner) error = %v, want %v", err, shared.ErrInvalidInput)
+	}
+
+	if err := testUser.ChangeRole(RoleAdmin); err != nil {
+		t.Fatalf("ChangeRole(admin) error = %v", err)
+	}
+	for _, role := range []Role{RoleUser, RoleModerator, RoleAdmin} {
+		if !testUser.HasRole(role) {
+			t.Errorf("admin HasRole(%s) = false, want true", role)
+		}
+	}
+
+	events := testUser.PendingEvents()
+	last := events[len(events)-1].Event
+	changed, ok := last.(UserRoleChanged)
+	if !ok || changed.From != RoleUser || changed.To != RoleAdmin {
+		t.Errorf("last event = %+v, want UserRoleChanged user -> admin", last)
+	}
+}
+
+func TestRole_Includes(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleModerator, RoleUser, true},
+		{RoleModerator, RoleModerator, true},
+		{RoleModerator, RoleAdmin, false},
+		{"", RoleUser, true},
+		{"", RoleModerator, false},
+		{"owner", RoleUser, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.role.Includes(tt.required); got != tt.want {
+			t.Errorf("Role(%q).Includes(%s) = %v, want %v", tt.role, tt.required, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK 是 RFC 7517 定義的單一公鑰
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// OKP (Ed25519)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+// JWKS 是公開於 /.well-known/jwks.json 的公鑰集合
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWKS 將金鑰轉換為 JWKS，HMAC 金鑰會被略過
+func NewJWKS(keys []*SigningKey) JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range keys {
+		jwk, ok := toJWK(key)
+		if ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
+
+func toJWK(key *SigningKey) (JWK, bool) {
+	jwk := JWK{
+		Use:       "sig",
+		KeyID:     key.ID,
+		Algorithm: key.Method.Alg(),
+	}
+
+	switch public := key.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeSegment(public.N.Bytes())
+		jwk.E = encodeSegment(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = encodeSegment(public)
+	default:
+		return JWK{}, false
+	}
+	return jwk, true
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
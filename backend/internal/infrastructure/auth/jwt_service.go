@@ -5,63 +5,105 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken = shared.NewDomainError(shared.KindUnauthorized, "INVALID_ACCESS_TOKEN", "invalid token")
+	ErrExpiredToken = shared.NewDomainError(shared.KindUnauthorized, "ACCESS_TOKEN_EXPIRED", "token has expired")
 )
 
+// defaultIssuer 未指定 issuer 時使用
+const defaultIssuer = "pingnom-api"
+
 type JWTService struct {
-	secretKey     string
+	keys          *KeySet
 	tokenDuration time.Duration
+	issuer        string
 }
 
 type Claims struct {
 	UserID    string `json:"user_id"`
 	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"` // 用戶角色，沒有角色的舊 token 視為一般用戶
+	SessionID string `json:"sid,omitempty"` // 對應 refresh token family，登出時一併撤銷
 	IssuedAt  int64  `json:"iat"`
 	ExpiresAt int64  `json:"exp"`
 	jwt.RegisteredClaims
 }
 
+// NewJWTService 建立僅使用 HS256 secret 的 JWT service
 func NewJWTService(secretKey string, tokenDuration time.Duration) *JWTService {
+	keys, _ := NewKeySet(NewHMACKey(secretKey))
+	return NewJWTServiceWithKeys(keys, tokenDuration, defaultIssuer)
+}
+
+// NewJWTServiceWithKeys 建立使用金鑰組簽章的 JWT service，支援 kid 輪替
+func NewJWTServiceWithKeys(keys *KeySet, tokenDuration time.Duration, issuer string) *JWTService {
+	if issuer == "" {
+		issuer = defaultIssuer
+	}
 	return &JWTService{
-		secretKey:     secretKey,
+		keys:          keys,
 		tokenDuration: tokenDuration,
+		issuer:        issuer,
 	}
 }
 
-func (j *JWTService) GenerateToken(userID shared.UserID, email string) (string, error) {
+// TokenDuration 回傳 access token 的有效期限
+func (j *JWTService) TokenDuration() time.Duration {
+	return j.tokenDuration
+}
+
+func (j *JWTService) GenerateToken(userID shared.UserID, email, role string) (string, error) {
+	return j.GenerateSessionToken(userID, email, role, "")
+}
+
+// GenerateSessionToken 產生綁定 session 的 access token
+// 每個 token 皆帶有唯一的 jti，供撤銷清單使用；角色變更後需重新發出 token 才會生效
+func (j *JWTService) GenerateSessionToken(userID shared.UserID, email, role, sessionID string) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(j.tokenDuration)
 
+	key, err := j.keys.SigningKey(now)
+	if err != nil {
+		return "", err
+	}
+
 	claims := &Claims{
 		UserID:    userID.String(),
 		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
 		IssuedAt:  now.Unix(),
 		ExpiresAt: expiresAt.Unix(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			Subject:   userID.String(),
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			Issuer:    "pingnom-api",
+			Issuer:    j.issuer,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secretKey))
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signKey)
 }
 
 func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// 確保使用的是 HMAC 簽名方法
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(j.secretKey), nil
-	})
+		// 依 kid 選擇金鑰；沒有 kid 的舊版 HS256 token 對應 ID 為空的 HMAC 金鑰
+		kid, _ := token.Header["kid"].(string)
+		return j.keys.VerificationKey(kid, token.Method, time.Now())
+	}, jwt.WithValidMethods([]string{
+		jwt.SigningMethodHS256.Alg(),
+		jwt.SigningMethodRS256.Alg(),
+		jwt.SigningMethodEdDSA.Alg(),
+	}))
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -83,22 +125,6 @@ func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-func (j *JWTService) RefreshToken(tokenString string) (string, error) {
-	claims, err := j.ValidateToken(tokenString)
-	if err != nil {
-		return "", err
-	}
-
-	// 解析 UserID
-	userID, err := shared.NewUserIDFromString(claims.UserID)
-	if err != nil {
-		return "", ErrInvalidToken
-	}
-
-	// 生成新的 token
-	return j.GenerateToken(userID, claims.Email)
-}
-
 func (j *JWTService) ExtractUserID(tokenString string) (shared.UserID, error) {
 	claims, err := j.ValidateToken(tokenString)
 	if err != nil {
@@ -106,4 +132,9 @@ func (j *JWTService) ExtractUserID(tokenString string) (shared.UserID, error) {
 	}
 
 	return shared.NewUserIDFromString(claims.UserID)
-}
\ No newline at end of file
+}
+
+// JWKS 回傳供其他服務驗證 token 的公鑰集合
+func (j *JWTService) JWKS() JWKS {
+	return NewJWKS(j.keys.PublicKeys(time.Now()))
+}
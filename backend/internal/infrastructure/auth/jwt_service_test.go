@@ -0,0 +1,274 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func newTestRSAKey(t *testing.T, kid string, activeFrom, retireAt time.Time) (*SigningKey, []byte) {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	key, err := NewKeyFromPEM(kid, pemBytes, activeFrom, retireAt)
+	if err != nil {
+		t.Fatalf("NewKeyFromPEM() error = %v", err)
+	}
+	return key, pemBytes
+}
+
+func newTestService(t *testing.T, keys ...*SigningKey) *JWTService {
+	t.Helper()
+	keySet, err := NewKeySet(keys...)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	return NewJWTServiceWithKeys(keySet, time.Hour, "")
+}
+
+func tokenHeader(t *testing.T, tokenString string) map[string]interface{} {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	return token.Header
+}
+
+func TestLegacyHS256RoundTrip(t *testing.T) {
+	service := NewJWTService(testSecret, time.Hour)
+	userID := shared.NewUserID()
+
+	token, err := service.GenerateToken(userID, "frank@pingnom.app", "admin")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if header := tokenHeader(t, token); header["alg"] != "HS256" || header["kid"] != nil {
+		t.Errorf("header = %v, want HS256 without kid", header)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != userID.String() || claims.Role != "admin" {
+		t.Errorf("claims = %s %s, want %s admin", claims.UserID, claims.Role, userID)
+	}
+	if len(service.JWKS().Keys) != 0 {
+		t.Errorf("JWKS() published %d keys for HS256 secret, want 0", len(service.JWKS().Keys))
+	}
+}
+
+func TestAsymmetricSigning(t *testing.T) {
+	rsaKey, _ := newTestRSAKey(t, "rsa-1", time.Time{}, time.Time{})
+	edKey, err := GenerateEd25519Key("ed-1")
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  *SigningKey
+		alg  string
+	}{
+		{"RS256", rsaKey, "RS256"},
+		{"EdDSA", edKey, "EdDSA"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t, tt.key)
+			token, err := service.GenerateSessionToken(shared.NewUserID(), "alice@pingnom.app", "user", "session-1")
+			if err != nil {
+				t.Fatalf("GenerateSessionToken() error = %v", err)
+			}
+			if header := tokenHeader(t, token); header["alg"] != tt.alg || header["kid"] != tt.key.ID {
+				t.Errorf("header = %v, want alg %s kid %s", header, tt.alg, tt.key.ID)
+			}
+
+			claims, err := service.ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if claims.SessionID != "session-1" {
+				t.Errorf("SessionID = %s, want session-1", claims.SessionID)
+			}
+		})
+	}
+}
+
+func TestScheduledKeyRotation(t *testing.T) {
+	now := time.Now()
+	oldKey, _ := newTestRSAKey(t, "2025-01", now.Add(-48*time.Hour), time.Time{})
+	newKey, err := GenerateEd25519Key("2025-02")
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key() error = %v", err)
+	}
+
+	// 新金鑰尚未生效：仍以舊金鑰簽章，但新公鑰已預先公開
+	newKey.ActiveFrom = now.Add(time.Hour)
+	before := newTestService(t, oldKey, newKey)
+	oldToken, err := before.GenerateToken(shared.NewUserID(), "frank@pingnom.app", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if kid := tokenHeader(t, oldToken)["kid"]; kid != "2025-01" {
+		t.Errorf("kid before rotation = %v, want 2025-01", kid)
+	}
+	if got := len(before.JWKS().Keys); got != 2 {
+		t.Errorf("JWKS() before rotation has %d keys, want 2", got)
+	}
+
+	// 新金鑰生效後改用新金鑰，舊 token 仍可驗證
+	newKey.ActiveFrom = now.Add(-time.Minute)
+	after := newTestService(t, oldKey, newKey)
+	newToken, err := after.GenerateToken(shared.NewUserID(), "frank@pingnom.app", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if kid := tokenHeader(t, newToken)["kid"]; kid != "2025-02" {
+		t.Errorf("kid after rotation = %v, want 2025-02", kid)
+	}
+	if _, err := after.ValidateToken(oldToken); err != nil {
+		t.Errorf("ValidateToken() old token after rotation error = %v", err)
+	}
+
+	// 舊金鑰退役後，其簽發的 token 失效且不再公開
+	oldKey.RetireAt = now.Add(-time.Second)
+	retired := newTestService(t, oldKey, newKey)
+	if _, err := retired.ValidateToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateToken() retired key error = %v, want %v", err, ErrInvalidToken)
+	}
+	if _, err := retired.ValidateToken(newToken); err != nil {
+		t.Errorf("ValidateToken() new token error = %v", err)
+	}
+	jwks := retired.JWKS()
+	if len(jwks.Keys) != 1 || jwks.Keys[0].KeyID != "2025-02" {
+		t.Errorf("JWKS() after retirement = %+v, want only 2025-02", jwks.Keys)
+	}
+}
+
+func TestHS256CompatibilityDuringMigration(t *testing.T) {
+	legacyToken, err := NewJWTService(testSecret, time.Hour).GenerateToken(shared.NewUserID(), "frank@pingnom.app", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	rsaKey, _ := newTestRSAKey(t, "rsa-1", time.Time{}, time.Time{})
+
+	migrating := newTestService(t, NewHMACKey(testSecret), rsaKey)
+	if _, err := migrating.ValidateToken(legacyToken); err != nil {
+		t.Errorf("ValidateToken() legacy token while migrating error = %v", err)
+	}
+	token, err := migrating.GenerateToken(shared.NewUserID(), "frank@pingnom.app", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if alg := tokenHeader(t, token)["alg"]; alg != "RS256" {
+		t.Errorf("alg while migrating = %v, want RS256", alg)
+	}
+
+	migrated := newTestService(t, rsaKey)
+	if _, err := migrated.ValidateToken(legacyToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateToken() legacy token after migration error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestRejectsAlgorithmConfusion(t *testing.T) {
+	rsaKey, _ := newTestRSAKey(t, "rsa-1", time.Time{}, time.Time{})
+	service := newTestService(t, rsaKey)
+
+	// 以公鑰內容作為 HMAC secret 偽造 token
+	publicDER, err := x509.MarshalPKIXPublicKey(rsaKey.PublicKey())
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey() error = %v", err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+		UserID:           shared.NewUserID().String(),
+		ExpiresAt:        time.Now().Add(time.Hour).Unix(),
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	forged.Header["kid"] = "rsa-1"
+	tokenString, err := forged.SignedString(publicDER)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := service.ValidateToken(tokenString); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateToken() forged token error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestJWKSPublishesPublicKeys(t *testing.T) {
+	rsaKey, _ := newTestRSAKey(t, "rsa-1", time.Time{}, time.Time{})
+	edKey, err := GenerateEd25519Key("ed-1")
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key() error = %v", err)
+	}
+	service := newTestService(t, NewHMACKey(testSecret), rsaKey, edKey)
+
+	byKid := make(map[string]JWK)
+	for _, key := range service.JWKS().Keys {
+		byKid[key.KeyID] = key
+	}
+	if len(byKid) != 2 {
+		t.Fatalf("JWKS() keys = %v, want rsa-1 and ed-1 only", byKid)
+	}
+	if rsa := byKid["rsa-1"]; rsa.KeyType != "RSA" || rsa.Algorithm != "RS256" || rsa.N == "" || rsa.E != "AQAB" {
+		t.Errorf("RSA JWK = %+v", rsa)
+	}
+	if ed := byKid["ed-1"]; ed.KeyType != "OKP" || ed.Curve != "Ed25519" || ed.Algorithm != "EdDSA" || ed.X == "" {
+		t.Errorf("Ed25519 JWK = %+v", ed)
+	}
+}
+
+func TestNewKeySetFromConfig(t *testing.T) {
+	_, pemBytes := newTestRSAKey(t, "rsa-1", time.Time{}, time.Time{})
+
+	keySet, err := NewKeySetFromConfig(config.JWTConfig{
+		Secret:      testSecret,
+		AcceptHS256: false,
+		Keys: []config.JWTKeyConfig{
+			{KID: "rsa-1", PrivateKey: string(pemBytes)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewKeySetFromConfig() error = %v", err)
+	}
+	key, err := keySet.SigningKey(time.Now())
+	if err != nil {
+		t.Fatalf("SigningKey() error = %v", err)
+	}
+	if key.ID != "rsa-1" {
+		t.Errorf("SigningKey() = %s, want rsa-1", key.ID)
+	}
+	if _, err := keySet.VerificationKey("", jwt.SigningMethodHS256, time.Now()); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("VerificationKey() HS256 without accept_hs256 error = %v, want %v", err, ErrUnknownKey)
+	}
+
+	// 所有金鑰都尚未生效且不接受 HS256 時無法簽章
+	_, err = NewKeySetFromConfig(config.JWTConfig{
+		Keys: []config.JWTKeyConfig{
+			{KID: "rsa-1", PrivateKey: string(pemBytes), ActiveFrom: time.Now().Add(time.Hour)},
+		},
+	})
+	if !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("NewKeySetFromConfig() future-only keys error = %v, want %v", err, ErrNoSigningKey)
+	}
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrNoSigningKey   = errors.New("no active JWT signing key")
+	ErrUnknownKey     = errors.New("unknown JWT signing key")
+	ErrKeyAlgorithm   = errors.New("JWT algorithm does not match signing key")
+	errUnsupportedKey = errors.New("unsupported private key type, expected RSA or Ed25519")
+)
+
+// minRSAKeyBits RS256 金鑰的最小長度
+const minRSAKeyBits = 2048
+
+// SigningKey 是一把用於簽發與驗證 JWT 的金鑰
+// 非對稱金鑰以 kid 區分；ID 為空的 HMAC 金鑰代表舊版 HS256 secret
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	ActiveFrom time.Time // 開始用於簽章的時間，零值代表立即生效
+	RetireAt   time.Time // 停止驗證的時間，零值代表不退役
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// NewHMACKey 建立 HS256 金鑰 (僅為相容舊版 token)
+func NewHMACKey(secret string) *SigningKey {
+	return &SigningKey{
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+}
+
+// NewKeyFromPEM 由 PEM 格式的私鑰建立金鑰，依金鑰型別決定 RS256 或 EdDSA
+func NewKeyFromPEM(kid string, pemBytes []byte, activeFrom, retireAt time.Time) (*SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", kid)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	key, err := newAsymmetricKey(kid, parsed)
+	if err != nil {
+		return nil, err
+	}
+	key.ActiveFrom = activeFrom
+	key.RetireAt = retireAt
+	return key, nil
+}
+
+// GenerateEd25519Key 產生臨時的 EdDSA 金鑰，適用於開發環境
+func GenerateEd25519Key(kid string) (*SigningKey, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newAsymmetricKey(kid, private)
+}
+
+func newAsymmetricKey(kid string, private interface{}) (*SigningKey, error) {
+	if kid == "" {
+		return nil, errors.New("asymmetric keys require a kid")
+	}
+
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("key %s: RSA key must be at least %d bits", kid, minRSAKeyBits)
+		}
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodRS256, signKey: k, verifyKey: &k.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, signKey: k, verifyKey: k.Public()}, nil
+	case *ed25519.PrivateKey:
+		return &SigningKey{ID: kid, Method: jwt.SigningMethodEdDSA, signKey: *k, verifyKey: k.Public()}, nil
+	default:
+		return nil, fmt.Errorf("key %s: %w", kid, errUnsupportedKey)
+	}
+}
+
+// IsSymmetric 檢查是否為 HMAC 金鑰 (不可公開於 JWKS)
+func (k *SigningKey) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// PublicKey 回傳驗證用的公鑰，HMAC 金鑰回傳 nil
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	if k.IsSymmetric() {
+		return nil
+	}
+	return k.verifyKey
+}
+
+func (k *SigningKey) isRetired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+func (k *SigningKey) canSign(now time.Time) bool {
+	return !k.isRetired(now) && !now.Before(k.ActiveFrom)
+}
+
+// KeySet 管理多把同時有效的金鑰
+// 簽章使用 ActiveFrom 最新且已生效的金鑰；驗證依 token header 的 kid 選擇金鑰，
+// 因此輪替後舊金鑰簽發的 token 在退役前仍然有效
+type KeySet struct {
+	keys []*SigningKey // 依 ActiveFrom 排序
+}
+
+// NewKeySet 建立金鑰組，kid 不可重複
+func NewKeySet(keys ...*SigningKey) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key set requires at least one key")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	sorted := make([]*SigningKey, 0, len(keys))
+	for _, key := range keys {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("duplicate JWT key id %q", key.ID)
+		}
+		seen[key.ID] = true
+		sorted = append(sorted, key)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+
+	return &KeySet{keys: sorted}, nil
+}
+
+// NewKeySetFromConfig 依設定建立金鑰組
+// 未設定 keys 時沿用 HS256 secret；設定 keys 後，accept_hs256 決定是否仍接受舊版 token
+func NewKeySetFromConfig(cfg config.JWTConfig) (*KeySet, error) {
+	var keys []*SigningKey
+	if len(cfg.Keys) == 0 || cfg.AcceptHS256 {
+		keys = append(keys, NewHMACKey(cfg.Secret))
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		pemBytes := []byte(keyCfg.PrivateKey)
+		if keyCfg.PrivateKeyFile != "" {
+			data, err := os.ReadFile(keyCfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read JWT key %s: %w", keyCfg.KID, err)
+			}
+			pemBytes = data
+		}
+		if len(pemBytes) == 0 {
+			return nil, fmt.Errorf("JWT key %s has no private key", keyCfg.KID)
+		}
+
+		key, err := NewKeyFromPEM(keyCfg.KID, pemBytes, keyCfg.ActiveFrom, keyCfg.RetireAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	keySet, err := NewKeySet(keys...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := keySet.SigningKey(time.Now()); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
+// SigningKey 回傳目前應使用的簽章金鑰
+func (s *KeySet) SigningKey(now time.Time) (*SigningKey, error) {
+	for i := len(s.keys) - 1; i >= 0; i-- {
+		if s.keys[i].canSign(now) {
+			return s.keys[i], nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey 依 kid 與演算法找出驗證用金鑰
+func (s *KeySet) VerificationKey(kid string, method jwt.SigningMethod, now time.Time) (interface{}, error) {
+	for _, key := range s.keys {
+		if key.ID != kid {
+			continue
+		}
+		if key.isRetired(now) {
+			return nil, ErrUnknownKey
+		}
+		// 防止演算法混淆攻擊 (例如以公鑰當作 HMAC secret)
+		if key.Method.Alg() != method.Alg() {
+			return nil, ErrKeyAlgorithm
+		}
+		return key.verifyKey, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// PublicKeys 回傳所有尚未退役的非對稱金鑰，包含尚未生效的金鑰
+// 讓其他服務能在輪替前預先取得新公鑰
+func (s *KeySet) PublicKeys(now time.Time) []*SigningKey {
+	var keys []*SigningKey
+	for _, key := range s.keys {
+		if key.IsSymmetric() || key.isRetired(now) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"time"
+)
+
+// AccountConfig 帳號驗證與密碼重設設定
+type AccountConfig struct {
+	TokenSecret           string        `mapstructure:"token_secret"`             // 簽署驗證與重設 token 的 HMAC secret
+	VerificationTokenTTL  time.Duration `mapstructure:"verification_token_ttl"`   // Email 驗證連結有效時間
+	PasswordResetTokenTTL time.Duration `mapstructure:"password_reset_token_ttl"` // 密碼重設連結有效時間
+	UnverifiedMaxAgeDays  int           `mapstructure:"unverified_max_age_days"`  // 註冊超過此天數仍未驗證即停用，0 代表不停用
+	SweepInterval         time.Duration `mapstructure:"sweep_interval"`           // 檢查未驗證帳號的間隔
+	AppBaseURL            string        `mapstructure:"app_base_url"`             // Email 中連結的前端網址
+}
+
+// MailConfig 寄信設定
+type MailConfig struct {
+	Driver    string `mapstructure:"driver"` // log: 寫入 log；file: 每封信寫成一個 .eml 檔案
+	From      string `mapstructure:"from"`
+	OutputDir string `mapstructure:"output_dir"` // file driver 的輸出目錄
+}
+
+// NotificationConfig 通知送達設定
+type NotificationConfig struct {
+	PushDriver string `mapstructure:"push_driver"` // fake: 寫入 log (開發用)；none: 不推播
+}
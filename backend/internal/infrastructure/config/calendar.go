@@ -0,0 +1,11 @@
+package config
+
+import (
+	"time"
+)
+
+// CalendarConfig 行事曆匯出與訂閱設定
+type CalendarConfig struct {
+	APIBaseURL      string        `mapstructure:"api_base_url"`     // 訂閱網址使用的 API 網址，行事曆 App 會直接連到此網址
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // 建議行事曆 App 重新抓取訂閱的間隔
+}
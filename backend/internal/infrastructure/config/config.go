@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
@@ -36,7 +38,11 @@ func LoadConfig(configPath string) (*Config, error) {
 	}
 	
 	// 將配置解析到結構體
-	if err := viper.Unmarshal(&config); err != nil {
+	if err := viper.Unmarshal(&config, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+	))); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 	
@@ -64,11 +70,54 @@ func setDefaults(config *Config) {
 	viper.SetDefault("database.password", config.Database.Password)
 	viper.SetDefault("database.dbname", config.Database.DBName)
 	viper.SetDefault("database.sslmode", config.Database.SSLMode)
+	viper.SetDefault("database.auto_migrate", config.Database.AutoMigrate)
 	
 	viper.SetDefault("jwt.secret", config.JWT.Secret)
 	viper.SetDefault("jwt.access_token_ttl", config.JWT.AccessTokenTTL)
 	viper.SetDefault("jwt.refresh_token_ttl", config.JWT.RefreshTokenTTL)
 	viper.SetDefault("jwt.issuer", config.JWT.Issuer)
+	viper.SetDefault("jwt.accept_hs256", config.JWT.AcceptHS256)
+	
+	viper.SetDefault("account.token_secret", config.Account.TokenSecret)
+	viper.SetDefault("account.verification_token_ttl", config.Account.VerificationTokenTTL)
+	viper.SetDefault("account.password_reset_token_ttl", config.Account.PasswordResetTokenTTL)
+	viper.SetDefault("account.unverified_max_age_days", config.Account.UnverifiedMaxAgeDays)
+	viper.SetDefault("account.sweep_interval", config.Account.SweepInterval)
+	viper.SetDefault("account.app_base_url", config.Account.AppBaseURL)
+	
+	viper.SetDefault("mail.driver", config.Mail.Driver)
+	viper.SetDefault("mail.from", config.Mail.From)
+	viper.SetDefault("mail.output_dir", config.Mail.OutputDir)
+	
+	viper.SetDefault("events.poll_interval", config.Events.PollInterval)
+	viper.SetDefault("events.batch_size", config.Events.BatchSize)
+	viper.SetDefault("events.max_attempts", config.Events.MaxAttempts)
+	viper.SetDefault("events.lease", config.Events.Lease)
+	viper.SetDefault("events.retention", config.Events.Retention)
+	
+	viper.SetDefault("notification.push_driver", config.Notification.PushDriver)
+	
+	viper.SetDefault("scheduler.enabled", config.Scheduler.Enabled)
+	viper.SetDefault("scheduler.tick_interval", config.Scheduler.TickInterval)
+	viper.SetDefault("scheduler.lease_ttl", config.Scheduler.LeaseTTL)
+	viper.SetDefault("scheduler.retention", config.Scheduler.Retention)
+	viper.SetDefault("scheduler.ping_expiry_interval", config.Scheduler.PingExpiryInterval)
+	viper.SetDefault("scheduler.voting_deadline_interval", config.Scheduler.VotingDeadlineInterval)
+	viper.SetDefault("scheduler.reminder_interval", config.Scheduler.ReminderInterval)
+	viper.SetDefault("scheduler.reminder_lead_time", config.Scheduler.ReminderLeadTime)
+	viper.SetDefault("scheduler.ping_series_interval", config.Scheduler.PingSeriesInterval)
+	viper.SetDefault("scheduler.ping_series_horizon", config.Scheduler.PingSeriesHorizon)
+	
+	viper.SetDefault("calendar.api_base_url", config.Calendar.APIBaseURL)
+	viper.SetDefault("calendar.refresh_interval", config.Calendar.RefreshInterval)
+	
+	viper.SetDefault("group_dining.invite_secret", config.GroupDining.InviteSecret)
+	viper.SetDefault("group_dining.invite_link_ttl", config.GroupDining.InviteLinkTTL)
+	viper.SetDefault("group_dining.invite_link_max_ttl", config.GroupDining.InviteLinkMaxTTL)
+	
+	viper.SetDefault("routing.driver", config.Routing.Driver)
+	viper.SetDefault("routing.average_speed_kmh", config.Routing.AverageSpeedKmh)
+	viper.SetDefault("routing.detour_factor", config.Routing.DetourFactor)
 }
 
 func validateConfig(config *Config) error {
@@ -88,11 +137,99 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("database name cannot be empty")
 	}
 	
-	if config.JWT.Secret == "" || config.JWT.Secret == "your-secret-key-change-in-production" {
+	// 只有仍使用 HS256 時才需要 secret
+	usesSecret := len(config.JWT.Keys) == 0 || config.JWT.AcceptHS256
+	if usesSecret && (config.JWT.Secret == "" || config.JWT.Secret == "your-secret-key-change-in-production") {
 		if config.Environment == "production" {
 			return fmt.Errorf("JWT secret must be set in production")
 		}
 	}
 	
+	for i, key := range config.JWT.Keys {
+		if key.KID == "" {
+			return fmt.Errorf("jwt.keys[%d]: kid cannot be empty", i)
+		}
+		if key.PrivateKey == "" && key.PrivateKeyFile == "" {
+			return fmt.Errorf("jwt.keys[%d]: private_key or private_key_file is required", i)
+		}
+	}
+	
+	// 預設值與 configs/config.yaml 附帶的開發用值都是公開的，正式環境必須另外設定
+	if config.Account.TokenSecret == "" || config.Account.TokenSecret == "your-account-token-secret-change-in-production" || config.Account.TokenSecret == "your-development-account-token-secret" {
+		if config.Environment == "production" {
+			return fmt.Errorf("account token secret must be set in production")
+		}
+	}
+	
+	if config.Account.UnverifiedMaxAgeDays < 0 {
+		return fmt.Errorf("account.unverified_max_age_days cannot be negative")
+	}
+	
+	if config.Account.UnverifiedMaxAgeDays > 0 && config.Account.SweepInterval <= 0 {
+		return fmt.Errorf("account.sweep_interval must be positive")
+	}
+	
+	switch config.Mail.Driver {
+	case "log", "file":
+	default:
+		return fmt.Errorf("unsupported mail driver: %q", config.Mail.Driver)
+	}
+	
+	if config.Events.PollInterval <= 0 || config.Events.BatchSize <= 0 || config.Events.MaxAttempts <= 0 || config.Events.Lease <= 0 {
+		return fmt.Errorf("events.poll_interval, batch_size, max_attempts and lease must be positive")
+	}
+	
+	if config.Events.Retention < 0 {
+		return fmt.Errorf("events.retention cannot be negative")
+	}
+	
+	switch config.Notification.PushDriver {
+	case "fake", "none":
+	default:
+		return fmt.Errorf("unsupported push driver: %q", config.Notification.PushDriver)
+	}
+	
+	if config.Scheduler.Enabled {
+		if config.Scheduler.TickInterval <= 0 || config.Scheduler.LeaseTTL <= config.Scheduler.TickInterval {
+			return fmt.Errorf("scheduler.tick_interval must be positive and shorter than scheduler.lease_ttl")
+		}
+		if config.Scheduler.Retention < 0 || config.Scheduler.PingExpiryInterval < 0 || config.Scheduler.VotingDeadlineInterval < 0 || config.Scheduler.ReminderInterval < 0 || config.Scheduler.PingSeriesInterval < 0 {
+			return fmt.Errorf("scheduler retention and job intervals cannot be negative")
+		}
+		if config.Scheduler.ReminderInterval > 0 && config.Scheduler.ReminderLeadTime <= 0 {
+			return fmt.Errorf("scheduler.reminder_lead_time must be positive")
+		}
+	}
+	
+	// 建立重複 ping 時也會立即建立範圍內的發生，所以排程停用時仍需要
+	if config.Scheduler.PingSeriesHorizon <= 0 {
+		return fmt.Errorf("scheduler.ping_series_horizon must be positive")
+	}
+	
+	if config.GroupDining.InviteSecret == "" || config.GroupDining.InviteSecret == "your-invite-link-secret-change-in-production" || config.GroupDining.InviteSecret == "your-development-invite-link-secret" {
+		if config.Environment == "production" {
+			return fmt.Errorf("group dining invite secret must be set in production")
+		}
+	}
+	
+	if config.GroupDining.InviteLinkTTL <= 0 || config.GroupDining.InviteLinkMaxTTL < config.GroupDining.InviteLinkTTL {
+		return fmt.Errorf("group_dining.invite_link_ttl must be positive and not longer than group_dining.invite_link_max_ttl")
+	}
+	
+	switch config.Routing.Driver {
+	case "straight_line", "none":
+	default:
+		return fmt.Errorf("unsupported routing driver: %q", config.Routing.Driver)
+	}
+	
+	for name, weights := range config.Recommendation.WeightProfiles {
+		if weights.Distance < 0 || weights.Rating < 0 || weights.Cuisine < 0 || weights.Price < 0 || weights.Restrictions < 0 || weights.Affinity < 0 {
+			return fmt.Errorf("recommendation.weight_profiles.%s: weights cannot be negative", name)
+		}
+		if weights.Distance+weights.Rating+weights.Cuisine+weights.Price+weights.Restrictions <= 0 {
+			return fmt.Errorf("recommendation.weight_profiles.%s: at least one non-affinity weight must be positive", name)
+		}
+	}
+	
 	return nil
 }
\ No newline at end of file
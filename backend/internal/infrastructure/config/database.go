@@ -16,6 +16,9 @@ type DatabaseConfig struct {
 	Password string `mapstructure:"password"`
 	DBName   string `mapstructure:"dbname"`
 	SSLMode  string `mapstructure:"sslmode"`
+	
+	// AutoMigrate 啟動時自動套用尚未執行的 migration
+	AutoMigrate bool `mapstructure:"auto_migrate"`
 }
 
 func (c DatabaseConfig) DSN() string {
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"time"
+)
+
+// EventsConfig 領域事件 outbox 派送設定
+type EventsConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"` // 檢查 outbox 的間隔
+	BatchSize    int           `mapstructure:"batch_size"`    // 每次取出的事件數
+	MaxAttempts  int           `mapstructure:"max_attempts"`  // 派送失敗超過此次數即放棄
+	Lease        time.Duration `mapstructure:"lease"`         // 取出的事件被鎖定的時間
+	Retention    time.Duration `mapstructure:"retention"`     // 已派送事件保留時間，0 代表不刪除
+}
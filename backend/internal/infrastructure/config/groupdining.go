@@ -0,0 +1,12 @@
+package config
+
+import (
+	"time"
+)
+
+// GroupDiningConfig 多人聚餐邀請連結設定
+type GroupDiningConfig struct {
+	InviteSecret     string        `mapstructure:"invite_secret"`       // 簽署邀請連結 token 的 HMAC secret
+	InviteLinkTTL    time.Duration `mapstructure:"invite_link_ttl"`     // 建立連結時未指定有效時間的預設值
+	InviteLinkMaxTTL time.Duration `mapstructure:"invite_link_max_ttl"` // 邀請連結有效時間的上限
+}
@@ -0,0 +1,18 @@
+package config
+
+// RecommendationConfig 餐廳推薦設定
+type RecommendationConfig struct {
+	// WeightProfiles 新增或覆寫內建的權重設定 (balanced、nearby、top_rated、personalized)，
+	// 覆寫 balanced 即改變未指定 weightProfile 時的權重
+	WeightProfiles map[string]WeightProfileConfig `mapstructure:"weight_profiles"`
+}
+
+// WeightProfileConfig 推薦分數各項目的權重，計算時會正規化為總和 1
+type WeightProfileConfig struct {
+	Distance     float64 `mapstructure:"distance"`
+	Rating       float64 `mapstructure:"rating"`
+	Cuisine      float64 `mapstructure:"cuisine"`
+	Price        float64 `mapstructure:"price"`
+	Restrictions float64 `mapstructure:"restrictions"`
+	Affinity     float64 `mapstructure:"affinity"` // 與參與者過去的用餐喜好相符，沒有用餐紀錄時忽略
+}
@@ -0,0 +1,8 @@
+package config
+
+// RoutingConfig 交通時間查詢設定，供餐廳推薦的交通時間會面地點策略使用
+type RoutingConfig struct {
+	Driver          string  `mapstructure:"driver"`            // straight_line: 以直線距離與平均速度估算；none: 停用交通時間策略
+	AverageSpeedKmh float64 `mapstructure:"average_speed_kmh"` // straight_line 使用的平均移動速度
+	DetourFactor    float64 `mapstructure:"detour_factor"`     // 實際路程相對於直線距離的倍數
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+)
+
+// SchedulerConfig 背景排程工作設定；各工作的間隔設為 0 代表停用該工作
+type SchedulerConfig struct {
+	Enabled                bool          `mapstructure:"enabled"`                  // 關閉時此 instance 不參與 leader 選舉，也不執行排程工作
+	TickInterval           time.Duration `mapstructure:"tick_interval"`            // 檢查到期工作並續約 leader 租約的間隔
+	LeaseTTL               time.Duration `mapstructure:"lease_ttl"`                // leader 租約有效時間，leader 停止續約後其他 instance 最久等待此時間接手
+	Retention              time.Duration `mapstructure:"retention"`                // 執行紀錄保留時間，0 代表不刪除
+	PingExpiryInterval     time.Duration `mapstructure:"ping_expiry_interval"`     // 將過了預定時間的 ping 標記為 expired
+	VotingDeadlineInterval time.Duration `mapstructure:"voting_deadline_interval"` // 關閉已過截止時間的聚餐投票
+	ReminderInterval       time.Duration `mapstructure:"reminder_interval"`        // 檢查即將開始的用餐並提醒參加者
+	ReminderLeadTime       time.Duration `mapstructure:"reminder_lead_time"`       // 用餐開始前多久提醒
+	PingSeriesInterval     time.Duration `mapstructure:"ping_series_interval"`     // 建立重複 ping 即將到來的發生
+	PingSeriesHorizon      time.Duration `mapstructure:"ping_series_horizon"`      // 重複 ping 預先建立多久內的發生
+}
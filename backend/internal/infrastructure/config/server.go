@@ -24,18 +24,39 @@ type CORSConfig struct {
 }
 
 type JWTConfig struct {
-	Secret          string        `mapstructure:"secret"`
-	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`
-	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
-	Issuer          string        `mapstructure:"issuer"`
+	Secret          string         `mapstructure:"secret"`
+	AccessTokenTTL  time.Duration  `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL time.Duration  `mapstructure:"refresh_token_ttl"`
+	Issuer          string         `mapstructure:"issuer"`
+	Keys            []JWTKeyConfig `mapstructure:"keys"`         // RS256 / EdDSA 簽章金鑰，未設定時使用 Secret (HS256)
+	AcceptHS256     bool           `mapstructure:"accept_hs256"` // 設定 Keys 後是否仍接受以 Secret 簽發的 HS256 token
+}
+
+// JWTKeyConfig 描述一把非對稱簽章金鑰
+// 以 active_from 排程輪替：最新且已生效的金鑰負責簽章，其餘未退役的金鑰仍可驗證
+type JWTKeyConfig struct {
+	KID            string    `mapstructure:"kid"`
+	PrivateKeyFile string    `mapstructure:"private_key_file"` // PEM 檔案路徑 (PKCS#1 或 PKCS#8)
+	PrivateKey     string    `mapstructure:"private_key"`      // PEM 內容，方便以環境變數注入
+	ActiveFrom     time.Time `mapstructure:"active_from"`      // 開始用於簽章的時間
+	RetireAt       time.Time `mapstructure:"retire_at"`        // 停止驗證的時間，應晚於最後一次簽章加上 access_token_ttl
 }
 
 type Config struct {
-	Environment string         `mapstructure:"environment"`
-	LogLevel    string         `mapstructure:"log_level"`
-	Server      ServerConfig   `mapstructure:"server"`
-	Database    DatabaseConfig `mapstructure:"database"`
-	JWT         JWTConfig      `mapstructure:"jwt"`
+	Environment    string               `mapstructure:"environment"`
+	LogLevel       string               `mapstructure:"log_level"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Database       DatabaseConfig       `mapstructure:"database"`
+	JWT            JWTConfig            `mapstructure:"jwt"`
+	Account        AccountConfig        `mapstructure:"account"`
+	Mail           MailConfig           `mapstructure:"mail"`
+	Events         EventsConfig         `mapstructure:"events"`
+	Notification   NotificationConfig   `mapstructure:"notification"`
+	Scheduler      SchedulerConfig      `mapstructure:"scheduler"`
+	Calendar       CalendarConfig       `mapstructure:"calendar"`
+	GroupDining    GroupDiningConfig    `mapstructure:"group_dining"`
+	Routing        RoutingConfig        `mapstructure:"routing"`
+	Recommendation RecommendationConfig `mapstructure:"recommendation"`
 }
 
 func DefaultConfig() Config {
@@ -70,6 +91,56 @@ func DefaultConfig() Config {
 			AccessTokenTTL:  24 * time.Hour,
 			RefreshTokenTTL: 7 * 24 * time.Hour,
 			Issuer:          "pingnom-api",
+			AcceptHS256:     true,
+		},
+		Account: AccountConfig{
+			TokenSecret:           "your-account-token-secret-change-in-production",
+			VerificationTokenTTL:  24 * time.Hour,
+			PasswordResetTokenTTL: time.Hour,
+			UnverifiedMaxAgeDays:  14,
+			SweepInterval:         time.Hour,
+			AppBaseURL:            "http://localhost:3000",
+		},
+		Mail: MailConfig{
+			Driver:    "log",
+			From:      "Pingnom <no-reply@pingnom.app>",
+			OutputDir: "./tmp/mail",
+		},
+		Events: EventsConfig{
+			PollInterval: 500 * time.Millisecond,
+			BatchSize:    100,
+			MaxAttempts:  10,
+			Lease:        time.Minute,
+			Retention:    7 * 24 * time.Hour,
+		},
+		Notification: NotificationConfig{
+			PushDriver: "fake",
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:                true,
+			TickInterval:           5 * time.Second,
+			LeaseTTL:               30 * time.Second,
+			Retention:              7 * 24 * time.Hour,
+			PingExpiryInterval:     time.Minute,
+			VotingDeadlineInterval: time.Minute,
+			ReminderInterval:       time.Minute,
+			ReminderLeadTime:       30 * time.Minute,
+			PingSeriesInterval:     time.Hour,
+			PingSeriesHorizon:      7 * 24 * time.Hour,
+		},
+		Calendar: CalendarConfig{
+			APIBaseURL:      "http://localhost:8080",
+			RefreshInterval: time.Hour,
+		},
+		GroupDining: GroupDiningConfig{
+			InviteSecret:     "your-invite-link-secret-change-in-production",
+			InviteLinkTTL:    72 * time.Hour,
+			InviteLinkMaxTTL: 30 * 24 * time.Hour,
+		},
+		Routing: RoutingConfig{
+			Driver:          "straight_line",
+			AverageSpeedKmh: 20,
+			DetourFactor:    1.3,
 		},
 	}
 }
\ No newline at end of file
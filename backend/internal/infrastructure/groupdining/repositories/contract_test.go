@@ -0,0 +1,34 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/groupdining/repositories"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/contracttest"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+func TestGroupDiningPlanRepositoryInMemoryContract(t *testing.T) {
+	contracttest.RunGroupDiningPlanRepositoryContract(t, func(t *testing.T) interfaces.GroupDiningPlanRepository {
+		return repositories.NewGroupDiningPlanRepositoryInMemory(inmemory.NewOutbox())
+	})
+}
+
+func TestGroupDiningPlanRepositoryPostgresContract(t *testing.T) {
+	contracttest.RunGroupDiningPlanRepositoryContract(t, func(t *testing.T) interfaces.GroupDiningPlanRepository {
+		return repositories.NewGroupDiningPlanRepositoryPostgres(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestVoteRepositoryInMemoryContract(t *testing.T) {
+	contracttest.RunVoteRepositoryContract(t, func(t *testing.T) interfaces.VoteRepository {
+		return repositories.NewVoteRepositoryInMemory()
+	})
+}
+
+func TestVoteRepositoryPostgresContract(t *testing.T) {
+	contracttest.RunVoteRepositoryContract(t, func(t *testing.T) interfaces.VoteRepository {
+		return repositories.NewVoteRepositoryPostgres(contracttest.OpenTestDB(t))
+	})
+}
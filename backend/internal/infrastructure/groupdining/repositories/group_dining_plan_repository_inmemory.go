@@ -1,21 +1,26 @@
 package repositories
 
 import (
-	"errors"
 	"sync"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
 )
 
 type GroupDiningPlanRepositoryInMemory struct {
-	plans map[string]*aggregates.GroupDiningPlan
-	mutex sync.RWMutex
+	plans  map[string]*aggregates.GroupDiningPlan
+	outbox *inmemory.Outbox
+	mutex  sync.RWMutex
 }
 
-func NewGroupDiningPlanRepositoryInMemory() *GroupDiningPlanRepositoryInMemory {
+// NewGroupDiningPlanRepositoryInMemory 聚合記錄的事件寫入 outbox；outbox 為 nil 時捨棄事件
+func NewGroupDiningPlanRepositoryInMemory(outbox *inmemory.Outbox) *GroupDiningPlanRepositoryInMemory {
 	return &GroupDiningPlanRepositoryInMemory{
-		plans: make(map[string]*aggregates.GroupDiningPlan),
-		mutex: sync.RWMutex{},
+		plans:  make(map[string]*aggregates.GroupDiningPlan),
+		outbox: outbox,
+		mutex:  sync.RWMutex{},
 	}
 }
 
@@ -24,14 +29,18 @@ func (r *GroupDiningPlanRepositoryInMemory) Create(plan *aggregates.GroupDiningP
 	defer r.mutex.Unlock()
 
 	if plan == nil {
-		return errors.New("plan cannot be nil")
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
 	}
 
 	if _, exists := r.plans[plan.ID]; exists {
-		return errors.New("group dining plan already exists")
+		return shared.ErrResourceConflict.WithMessage("group dining plan already exists")
 	}
 
-	r.plans[plan.ID] = plan
+	if err := r.outbox.Append(plan); err != nil {
+		return err
+	}
+	r.plans[plan.ID] = copyPlan(plan)
+	plan.ClearEvents()
 	return nil
 }
 
@@ -40,15 +49,15 @@ func (r *GroupDiningPlanRepositoryInMemory) GetByID(id string) (*aggregates.Grou
 	defer r.mutex.RUnlock()
 
 	if id == "" {
-		return nil, errors.New("id cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("id cannot be empty")
 	}
 
 	plan, exists := r.plans[id]
 	if !exists {
-		return nil, errors.New("group dining plan not found")
+		return nil, aggregates.ErrPlanNotFound
 	}
 
-	return plan, nil
+	return copyPlan(plan), nil
 }
 
 func (r *GroupDiningPlanRepositoryInMemory) GetByCreator(createdBy string) ([]*aggregates.GroupDiningPlan, error) {
@@ -56,31 +65,51 @@ func (r *GroupDiningPlanRepositoryInMemory) GetByCreator(createdBy string) ([]*a
 	defer r.mutex.RUnlock()
 
 	if createdBy == "" {
-		return nil, errors.New("creator ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("creator ID cannot be empty")
 	}
 
 	var result []*aggregates.GroupDiningPlan
 	for _, plan := range r.plans {
 		if plan.CreatedBy == createdBy {
-			result = append(result, plan)
+			result = append(result, copyPlan(plan))
 		}
 	}
 
 	return result, nil
 }
 
-func (r *GroupDiningPlanRepositoryInMemory) GetByParticipant(userID string) ([]*aggregates.GroupDiningPlan, error) {
+func (r *GroupDiningPlanRepositoryInMemory) GetByParticipant(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
 	if userID == "" {
-		return nil, errors.New("user ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
 	}
 
+	excluded := idSet(excludeCreators)
 	var result []*aggregates.GroupDiningPlan
 	for _, plan := range r.plans {
-		if plan.IsParticipant(userID) {
-			result = append(result, plan)
+		if plan.IsParticipant(userID) && !excluded[plan.CreatedBy] {
+			result = append(result, copyPlan(plan))
+		}
+	}
+
+	return result, nil
+}
+
+func (r *GroupDiningPlanRepositoryInMemory) GetByInvitee(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if userID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+
+	excluded := idSet(excludeCreators)
+	var result []*aggregates.GroupDiningPlan
+	for _, plan := range r.plans {
+		if plan.HasPendingInvitation(userID) && !excluded[plan.CreatedBy] {
+			result = append(result, copyPlan(plan))
 		}
 	}
 
@@ -92,14 +121,78 @@ func (r *GroupDiningPlanRepositoryInMemory) Update(plan *aggregates.GroupDiningP
 	defer r.mutex.Unlock()
 
 	if plan == nil {
-		return errors.New("plan cannot be nil")
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
 	}
 
-	if _, exists := r.plans[plan.ID]; !exists {
-		return errors.New("group dining plan not found")
+	stored, exists := r.plans[plan.ID]
+	if !exists {
+		return aggregates.ErrPlanNotFound
+	}
+	if stored.Version != plan.Version {
+		return aggregates.ErrPlanModified
 	}
 
-	r.plans[plan.ID] = plan
+	if err := r.outbox.Append(plan); err != nil {
+		return err
+	}
+	plan.Version++
+	r.plans[plan.ID] = copyPlan(plan)
+	plan.ClearEvents()
+	return nil
+}
+
+func (r *GroupDiningPlanRepositoryInMemory) RedeemInviteLink(plan *aggregates.GroupDiningPlan, linkID, userID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if plan == nil {
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
+	}
+	joined := participantIndex(plan, userID)
+	if joined < 0 {
+		return shared.ErrInvalidInput.WithMessage("user has not joined the plan")
+	}
+
+	stored, exists := r.plans[plan.ID]
+	if !exists {
+		return aggregates.ErrPlanNotFound
+	}
+
+	// 以已儲存的內容檢查，與 postgres 實作在交易中重新讀取的結果一致
+	updated := copyPlan(stored)
+	link := -1
+	for i := range updated.InviteLinks {
+		if updated.InviteLinks[i].ID == linkID {
+			link = i
+			break
+		}
+	}
+	if link < 0 {
+		return aggregates.ErrInvalidInviteLink
+	}
+	if updated.IsParticipant(userID) {
+		return aggregates.ErrAlreadyParticipant
+	}
+	if limit := updated.InviteLinks[link].MaxParticipants; limit > 0 && len(updated.AcceptedParticipants()) >= limit {
+		return aggregates.ErrPlanFull
+	}
+	if updated.InviteLinks[link].SingleUse && updated.InviteLinks[link].UseCount > 0 {
+		return aggregates.ErrInviteLinkUsed
+	}
+
+	if err := r.outbox.Append(plan); err != nil {
+		return err
+	}
+	updated.InviteLinks[link].UseCount++
+	if i := participantIndex(updated, userID); i >= 0 {
+		updated.Participants[i] = plan.Participants[joined]
+	} else {
+		updated.Participants = append(updated.Participants, plan.Participants[joined])
+	}
+	updated.UpdatedAt = plan.UpdatedAt
+	updated.Version++
+	r.plans[plan.ID] = updated
+	plan.ClearEvents()
 	return nil
 }
 
@@ -108,11 +201,11 @@ func (r *GroupDiningPlanRepositoryInMemory) Delete(id string) error {
 	defer r.mutex.Unlock()
 
 	if id == "" {
-		return errors.New("id cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("id cannot be empty")
 	}
 
 	if _, exists := r.plans[id]; !exists {
-		return errors.New("group dining plan not found")
+		return aggregates.ErrPlanNotFound
 	}
 
 	delete(r.plans, id)
@@ -140,9 +233,69 @@ func (r *GroupDiningPlanRepositoryInMemory) List(limit, offset int) ([]*aggregat
 		if len(result) >= limit {
 			break
 		}
-		result = append(result, plan)
+		result = append(result, copyPlan(plan))
 		count++
 	}
 
 	return result, nil
-}
\ No newline at end of file
+}
+
+func (r *GroupDiningPlanRepositoryInMemory) GetVotingPastDeadline(now time.Time) ([]*aggregates.GroupDiningPlan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*aggregates.GroupDiningPlan
+	for _, plan := range r.plans {
+		if plan.IsVotingOverdue(now) {
+			result = append(result, copyPlan(plan))
+		}
+	}
+
+	return result, nil
+}
+
+func (r *GroupDiningPlanRepositoryInMemory) GetConfirmedStartingBetween(from, to time.Time) ([]*aggregates.GroupDiningPlan, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var result []*aggregates.GroupDiningPlan
+	for _, plan := range r.plans {
+		if plan.Status != aggregates.PlanStatusConfirmed || plan.ConfirmedTimeSlot == nil {
+			continue
+		}
+		start := plan.ConfirmedTimeSlot.StartTime
+		if !start.Before(from) && start.Before(to) {
+			result = append(result, copyPlan(plan))
+		}
+	}
+
+	return result, nil
+}
+
+// copyPlan 複製計畫與其中的切片，避免呼叫端修改到儲存的資料；複本不含尚未寫入 outbox 的事件
+func copyPlan(plan *aggregates.GroupDiningPlan) *aggregates.GroupDiningPlan {
+	copied := *plan
+	copied.ClearEvents()
+	copied.TimeSlots = append([]aggregates.TimeSlot{}, plan.TimeSlots...)
+	copied.RestaurantOptions = append([]aggregates.RestaurantOption{}, plan.RestaurantOptions...)
+	copied.Participants = append([]aggregates.Participant{}, plan.Participants...)
+	copied.InviteLinks = append([]aggregates.InviteLink{}, plan.InviteLinks...)
+	return &copied
+}
+
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func participantIndex(plan *aggregates.GroupDiningPlan, userID string) int {
+	for i, participant := range plan.Participants {
+		if participant.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
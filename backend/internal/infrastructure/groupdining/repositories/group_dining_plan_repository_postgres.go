@@ -0,0 +1,718 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GroupDiningPlanModel represents the database model for GroupDiningPlan
+type GroupDiningPlanModel struct {
+	ID                    string `gorm:"type:uuid;primary_key"`
+	CreatedBy             string `gorm:"index;not null"`
+	Title                 string `gorm:"not null"`
+	Description           string
+	Status                string `gorm:"index;not null"`
+	ConfirmedTimeSlotID   *string
+	ConfirmedRestaurantID *string
+	VotingDeadline        *time.Time
+	VotingMethod          string                             `gorm:"not null"`
+	AutoFinalize          bool                               `gorm:"not null"`
+	TieBreak              string                             `gorm:"not null"`
+	TieBreakSeed          int64                              `gorm:"not null"`
+	Finalization          persistence.RawJSON                `gorm:"type:jsonb"`
+	TimeSlots             []GroupDiningTimeSlotModel         `gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE"`
+	RestaurantOptions     []GroupDiningRestaurantOptionModel `gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE"`
+	Participants          []GroupDiningParticipantModel      `gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE"`
+	InviteLinks           []GroupDiningInviteLinkModel       `gorm:"foreignKey:PlanID;constraint:OnDelete:CASCADE"`
+	Version               int64                              `gorm:"not null"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (GroupDiningPlanModel) TableName() string {
+	return "group_dining_plans"
+}
+
+// GroupDiningTimeSlotModel represents the database model for TimeSlot
+type GroupDiningTimeSlotModel struct {
+	ID          string    `gorm:"primary_key"`
+	PlanID      string    `gorm:"type:uuid;primary_key"`
+	Position    int       `gorm:"not null"`
+	StartTime   time.Time `gorm:"not null"`
+	EndTime     time.Time `gorm:"not null"`
+	Description string
+	VoteCount   int `gorm:"not null"`
+}
+
+func (GroupDiningTimeSlotModel) TableName() string {
+	return "group_dining_time_slots"
+}
+
+// GroupDiningRestaurantOptionModel represents the database model for RestaurantOption
+type GroupDiningRestaurantOptionModel struct {
+	ID          string `gorm:"primary_key"`
+	PlanID      string `gorm:"type:uuid;primary_key"`
+	Position    int    `gorm:"not null"`
+	Name        string `gorm:"not null"`
+	Address     string
+	Latitude    float64
+	Longitude   float64
+	CuisineType string
+	VoteCount   int `gorm:"not null"`
+}
+
+func (GroupDiningRestaurantOptionModel) TableName() string {
+	return "group_dining_restaurant_options"
+}
+
+// GroupDiningParticipantModel represents the database model for Participant
+type GroupDiningParticipantModel struct {
+	PlanID      string `gorm:"type:uuid;primary_key"`
+	UserID      string `gorm:"primary_key;index"`
+	Position    int    `gorm:"not null"`
+	DisplayName string
+	Status      string `gorm:"not null"`
+	InvitedBy   *string
+	JoinedAt    time.Time `gorm:"not null"`
+	RespondedAt *time.Time
+	HasVoted    bool `gorm:"not null"`
+}
+
+func (GroupDiningParticipantModel) TableName() string {
+	return "group_dining_participants"
+}
+
+// GroupDiningInviteLinkModel represents the database model for InviteLink
+type GroupDiningInviteLinkModel struct {
+	ID              string    `gorm:"primary_key"`
+	PlanID          string    `gorm:"type:uuid;primary_key"`
+	Position        int       `gorm:"not null"`
+	CreatedBy       string    `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"not null"`
+	ExpiresAt       time.Time `gorm:"not null"`
+	SingleUse       bool      `gorm:"not null"`
+	MaxParticipants int       `gorm:"not null"`
+	UseCount        int       `gorm:"not null"`
+}
+
+func (GroupDiningInviteLinkModel) TableName() string {
+	return "group_dining_invite_links"
+}
+
+// GroupDiningPlanRepositoryPostgres persists group dining plans with GORM
+type GroupDiningPlanRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewGroupDiningPlanRepositoryPostgres(db *gorm.DB) *GroupDiningPlanRepositoryPostgres {
+	return &GroupDiningPlanRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) Create(plan *aggregates.GroupDiningPlan) error {
+	if plan == nil {
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
+	}
+
+	model, err := r.domainToModel(plan)
+	if err != nil {
+		return err
+	}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&GroupDiningPlanModel{}).Where("id = ?", model.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return shared.ErrResourceConflict.WithMessage("group dining plan already exists")
+		}
+
+		if err := tx.Omit("TimeSlots", "RestaurantOptions", "Participants", "InviteLinks").Create(model).Error; err != nil {
+			return err
+		}
+		if err := r.saveChildren(tx, model); err != nil {
+			return err
+		}
+		return persistence.WriteOutbox(tx, plan)
+	})
+	if err != nil {
+		return err
+	}
+	plan.ClearEvents()
+	return nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetByID(id string) (*aggregates.GroupDiningPlan, error) {
+	if id == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("id cannot be empty")
+	}
+
+	var model GroupDiningPlanModel
+	result := r.preloaded().Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, aggregates.ErrPlanNotFound
+		}
+		return nil, result.Error
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetByCreator(createdBy string) ([]*aggregates.GroupDiningPlan, error) {
+	if createdBy == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("creator ID cannot be empty")
+	}
+
+	var models []GroupDiningPlanModel
+	result := r.preloaded().
+		Where("created_by = ?", createdBy).
+		Order("created_at DESC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetByParticipant(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error) {
+	if userID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+
+	participantPlans := r.db.Model(&GroupDiningParticipantModel{}).
+		Select("plan_id").
+		Where("user_id = ? AND status = ?", userID, string(aggregates.ParticipantStatusAccepted))
+
+	var models []GroupDiningPlanModel
+	result := r.excludingCreators(r.preloaded().Where("id IN (?)", participantPlans), excludeCreators).
+		Order("created_at DESC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetByInvitee(userID string, excludeCreators []string) ([]*aggregates.GroupDiningPlan, error) {
+	if userID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+
+	invitedPlans := r.db.Model(&GroupDiningParticipantModel{}).
+		Select("plan_id").
+		Where("user_id = ? AND status = ?", userID, string(aggregates.ParticipantStatusPending))
+
+	var models []GroupDiningPlanModel
+	result := r.excludingCreators(r.preloaded().Where("id IN (?)", invitedPlans), excludeCreators).
+		Order("created_at DESC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) Update(plan *aggregates.GroupDiningPlan) error {
+	if plan == nil {
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
+	}
+
+	model, err := r.domainToModel(plan)
+	if err != nil {
+		return err
+	}
+	// 只有版本與讀取時相同才寫入，子資料整批重寫前先確認沒有其他請求改過計畫
+	model.Version = plan.Version + 1
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&GroupDiningPlanModel{}).
+			Where("id = ? AND version = ?", model.ID, plan.Version).
+			Omit("TimeSlots", "RestaurantOptions", "Participants", "InviteLinks").
+			Select("*").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&GroupDiningPlanModel{}).Where("id = ?", model.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return aggregates.ErrPlanNotFound
+			}
+			return aggregates.ErrPlanModified
+		}
+
+		if err := r.deleteChildren(tx, model.ID); err != nil {
+			return err
+		}
+		if err := r.saveChildren(tx, model); err != nil {
+			return err
+		}
+		return persistence.WriteOutbox(tx, plan)
+	})
+	if err != nil {
+		return err
+	}
+	plan.Version = model.Version
+	plan.ClearEvents()
+	return nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) RedeemInviteLink(plan *aggregates.GroupDiningPlan, linkID, userID string) error {
+	if plan == nil {
+		return shared.ErrInvalidInput.WithMessage("plan cannot be nil")
+	}
+
+	model, err := r.domainToModel(plan)
+	if err != nil {
+		return err
+	}
+	var participant *GroupDiningParticipantModel
+	for i := range model.Participants {
+		if model.Participants[i].UserID == userID {
+			participant = &model.Participants[i]
+			break
+		}
+	}
+	if participant == nil {
+		return shared.ErrInvalidInput.WithMessage("user has not joined the plan")
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		// 鎖住計畫，同一計畫的連結加入依序處理，之後讀到的參與者與使用次數都是最新的
+		var locked GroupDiningPlanModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", model.ID).Take(&locked).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return aggregates.ErrPlanNotFound
+			}
+			return err
+		}
+
+		var link GroupDiningInviteLinkModel
+		if err := tx.Where("plan_id = ? AND id = ?", model.ID, linkID).Take(&link).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return aggregates.ErrInvalidInviteLink
+			}
+			return err
+		}
+
+		var existing []GroupDiningParticipantModel
+		if err := tx.Where("plan_id = ? AND user_id = ?", model.ID, userID).Find(&existing).Error; err != nil {
+			return err
+		}
+		if len(existing) > 0 && existing[0].Status == string(aggregates.ParticipantStatusAccepted) {
+			return aggregates.ErrAlreadyParticipant
+		}
+
+		if link.MaxParticipants > 0 {
+			var joined int64
+			if err := tx.Model(&GroupDiningParticipantModel{}).
+				Where("plan_id = ? AND status = ?", model.ID, string(aggregates.ParticipantStatusAccepted)).
+				Count(&joined).Error; err != nil {
+				return err
+			}
+			if joined >= int64(link.MaxParticipants) {
+				return aggregates.ErrPlanFull
+			}
+		}
+
+		result := tx.Model(&GroupDiningInviteLinkModel{}).
+			Where("plan_id = ? AND id = ? AND (NOT single_use OR use_count = 0)", model.ID, linkID).
+			Update("use_count", gorm.Expr("use_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return aggregates.ErrInviteLinkUsed
+		}
+
+		// 只寫入加入的用戶，不以讀取時的內容覆蓋其他同時加入的參與者
+		if len(existing) > 0 {
+			participant.Position = existing[0].Position
+			if err := tx.Model(&GroupDiningParticipantModel{}).
+				Where("plan_id = ? AND user_id = ?", model.ID, userID).
+				Select("*").
+				Updates(participant).Error; err != nil {
+				return err
+			}
+		} else {
+			var count int64
+			if err := tx.Model(&GroupDiningParticipantModel{}).Where("plan_id = ?", model.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			participant.Position = int(count)
+			if err := tx.Create(participant).Error; err != nil {
+				return err
+			}
+		}
+
+		// 遞增版本，讀取時還沒有這位參與者的計畫不能再覆蓋寫入
+		if err := tx.Model(&GroupDiningPlanModel{}).Where("id = ?", model.ID).Updates(map[string]interface{}{
+			"updated_at": model.UpdatedAt,
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+		return persistence.WriteOutbox(tx, plan)
+	})
+	if err != nil {
+		return err
+	}
+	plan.ClearEvents()
+	return nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) Delete(id string) error {
+	if id == "" {
+		return shared.ErrInvalidInput.WithMessage("id cannot be empty")
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.deleteChildren(tx, id); err != nil {
+			return err
+		}
+		result := tx.Delete(&GroupDiningPlanModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return aggregates.ErrPlanNotFound
+		}
+		return nil
+	})
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) List(limit, offset int) ([]*aggregates.GroupDiningPlan, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var models []GroupDiningPlanModel
+	result := r.preloaded().
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetVotingPastDeadline(now time.Time) ([]*aggregates.GroupDiningPlan, error) {
+	var models []GroupDiningPlanModel
+	result := r.preloaded().
+		Where("status = ? AND voting_deadline <= ?", string(aggregates.PlanStatusVoting), now).
+		Order("voting_deadline ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) GetConfirmedStartingBetween(from, to time.Time) ([]*aggregates.GroupDiningPlan, error) {
+	// 確認的時段存於 time slots 表，以確認時段 ID 對應其開始時間
+	confirmedPlans := r.db.Model(&GroupDiningTimeSlotModel{}).
+		Select("group_dining_time_slots.plan_id").
+		Joins("JOIN group_dining_plans ON group_dining_plans.id = group_dining_time_slots.plan_id AND group_dining_plans.confirmed_time_slot_id = group_dining_time_slots.id").
+		Where("group_dining_plans.status = ? AND group_dining_time_slots.start_time >= ? AND group_dining_time_slots.start_time < ?", string(aggregates.PlanStatusConfirmed), from, to)
+
+	var models []GroupDiningPlanModel
+	result := r.preloaded().
+		Where("id IN (?)", confirmedPlans).
+		Order("created_at DESC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.modelsToDomain(models), nil
+}
+
+// preloaded returns a query that loads all child collections in their original order
+func (r *GroupDiningPlanRepositoryPostgres) preloaded() *gorm.DB {
+	byPosition := func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}
+	return r.db.
+		Preload("TimeSlots", byPosition).
+		Preload("RestaurantOptions", byPosition).
+		Preload("Participants", byPosition).
+		Preload("InviteLinks", byPosition)
+}
+
+// excludingCreators 排除指定用戶建立的計畫；名單為空時不加條件，避免產生 NOT IN (NULL)
+func (r *GroupDiningPlanRepositoryPostgres) excludingCreators(query *gorm.DB, creators []string) *gorm.DB {
+	if len(creators) == 0 {
+		return query
+	}
+	return query.Where("created_by NOT IN ?", creators)
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) deleteChildren(tx *gorm.DB, planID string) error {
+	if err := tx.Where("plan_id = ?", planID).Delete(&GroupDiningTimeSlotModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("plan_id = ?", planID).Delete(&GroupDiningRestaurantOptionModel{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("plan_id = ?", planID).Delete(&GroupDiningParticipantModel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("plan_id = ?", planID).Delete(&GroupDiningInviteLinkModel{}).Error
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) saveChildren(tx *gorm.DB, model *GroupDiningPlanModel) error {
+	if len(model.TimeSlots) > 0 {
+		if err := tx.Create(&model.TimeSlots).Error; err != nil {
+			return err
+		}
+	}
+	if len(model.RestaurantOptions) > 0 {
+		if err := tx.Create(&model.RestaurantOptions).Error; err != nil {
+			return err
+		}
+	}
+	if len(model.Participants) > 0 {
+		if err := tx.Create(&model.Participants).Error; err != nil {
+			return err
+		}
+	}
+	if len(model.InviteLinks) > 0 {
+		if err := tx.Create(&model.InviteLinks).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Helper methods for conversion
+func (r *GroupDiningPlanRepositoryPostgres) domainToModel(plan *aggregates.GroupDiningPlan) (*GroupDiningPlanModel, error) {
+	timeSlots := make([]GroupDiningTimeSlotModel, len(plan.TimeSlots))
+	for i, slot := range plan.TimeSlots {
+		timeSlots[i] = GroupDiningTimeSlotModel{
+			ID:          slot.ID,
+			PlanID:      plan.ID,
+			Position:    i,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			Description: slot.Description,
+			VoteCount:   slot.VoteCount,
+		}
+	}
+
+	restaurantOptions := make([]GroupDiningRestaurantOptionModel, len(plan.RestaurantOptions))
+	for i, option := range plan.RestaurantOptions {
+		restaurantOptions[i] = GroupDiningRestaurantOptionModel{
+			ID:          option.ID,
+			PlanID:      plan.ID,
+			Position:    i,
+			Name:        option.Name,
+			Address:     option.Address,
+			Latitude:    option.Latitude,
+			Longitude:   option.Longitude,
+			CuisineType: option.CuisineType,
+			VoteCount:   option.VoteCount,
+		}
+	}
+
+	participants := make([]GroupDiningParticipantModel, len(plan.Participants))
+	for i, participant := range plan.Participants {
+		participants[i] = GroupDiningParticipantModel{
+			PlanID:      plan.ID,
+			UserID:      participant.UserID,
+			Position:    i,
+			DisplayName: participant.DisplayName,
+			Status:      string(participant.Status.OrDefault()),
+			JoinedAt:    participant.JoinedAt,
+			RespondedAt: participant.RespondedAt,
+			HasVoted:    participant.HasVoted,
+		}
+		if participant.InvitedBy != "" {
+			participants[i].InvitedBy = &participant.InvitedBy
+		}
+	}
+
+	inviteLinks := make([]GroupDiningInviteLinkModel, len(plan.InviteLinks))
+	for i, link := range plan.InviteLinks {
+		inviteLinks[i] = GroupDiningInviteLinkModel{
+			ID:              link.ID,
+			PlanID:          plan.ID,
+			Position:        i,
+			CreatedBy:       link.CreatedBy,
+			CreatedAt:       link.CreatedAt,
+			ExpiresAt:       link.ExpiresAt,
+			SingleUse:       link.SingleUse,
+			MaxParticipants: link.MaxParticipants,
+			UseCount:        link.UseCount,
+		}
+	}
+
+	model := &GroupDiningPlanModel{
+		ID:                plan.ID,
+		CreatedBy:         plan.CreatedBy,
+		Title:             plan.Title,
+		Description:       plan.Description,
+		Status:            string(plan.Status),
+		VotingDeadline:    plan.VotingDeadline,
+		VotingMethod:      string(plan.VotingMethod),
+		AutoFinalize:      plan.AutoFinalize.Enabled,
+		TieBreak:          string(plan.AutoFinalize.TieBreak),
+		TieBreakSeed:      plan.AutoFinalize.Seed,
+		Version:           plan.Version,
+		TimeSlots:         timeSlots,
+		RestaurantOptions: restaurantOptions,
+		Participants:      participants,
+		InviteLinks:       inviteLinks,
+		CreatedAt:         plan.CreatedAt,
+		UpdatedAt:         plan.UpdatedAt,
+	}
+
+	if plan.ConfirmedTimeSlot != nil {
+		model.ConfirmedTimeSlotID = &plan.ConfirmedTimeSlot.ID
+	}
+	if plan.ConfirmedRestaurant != nil {
+		model.ConfirmedRestaurantID = &plan.ConfirmedRestaurant.ID
+	}
+	if plan.Finalization != nil {
+		finalization, err := json.Marshal(plan.Finalization)
+		if err != nil {
+			return nil, err
+		}
+		model.Finalization = finalization
+	}
+
+	return model, nil
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) modelToDomain(m *GroupDiningPlanModel) *aggregates.GroupDiningPlan {
+	timeSlots := make([]aggregates.TimeSlot, len(m.TimeSlots))
+	for i, slot := range m.TimeSlots {
+		timeSlots[i] = aggregates.TimeSlot{
+			ID:          slot.ID,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			Description: slot.Description,
+			VoteCount:   slot.VoteCount,
+		}
+	}
+
+	restaurantOptions := make([]aggregates.RestaurantOption, len(m.RestaurantOptions))
+	for i, option := range m.RestaurantOptions {
+		restaurantOptions[i] = aggregates.RestaurantOption{
+			ID:          option.ID,
+			Name:        option.Name,
+			Address:     option.Address,
+			Latitude:    option.Latitude,
+			Longitude:   option.Longitude,
+			CuisineType: option.CuisineType,
+			VoteCount:   option.VoteCount,
+		}
+	}
+
+	participants := make([]aggregates.Participant, len(m.Participants))
+	for i, participant := range m.Participants {
+		participants[i] = aggregates.Participant{
+			UserID:      participant.UserID,
+			DisplayName: participant.DisplayName,
+			Status:      aggregates.ParticipantStatus(participant.Status),
+			JoinedAt:    participant.JoinedAt,
+			RespondedAt: participant.RespondedAt,
+			HasVoted:    participant.HasVoted,
+		}
+		if participant.InvitedBy != nil {
+			participants[i].InvitedBy = *participant.InvitedBy
+		}
+	}
+
+	inviteLinks := make([]aggregates.InviteLink, len(m.InviteLinks))
+	for i, link := range m.InviteLinks {
+		inviteLinks[i] = aggregates.InviteLink{
+			ID:              link.ID,
+			CreatedBy:       link.CreatedBy,
+			CreatedAt:       link.CreatedAt,
+			ExpiresAt:       link.ExpiresAt,
+			SingleUse:       link.SingleUse,
+			MaxParticipants: link.MaxParticipants,
+			UseCount:        link.UseCount,
+		}
+	}
+
+	plan := &aggregates.GroupDiningPlan{
+		ID:                m.ID,
+		CreatedBy:         m.CreatedBy,
+		Title:             m.Title,
+		Description:       m.Description,
+		Status:            aggregates.PlanStatus(m.Status),
+		TimeSlots:         timeSlots,
+		RestaurantOptions: restaurantOptions,
+		Participants:      participants,
+		InviteLinks:       inviteLinks,
+		Version:           m.Version,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+		VotingDeadline:    m.VotingDeadline,
+		VotingMethod:      aggregates.VotingMethod(m.VotingMethod),
+		AutoFinalize: aggregates.AutoFinalizeSettings{
+			Enabled:  m.AutoFinalize,
+			TieBreak: aggregates.TieBreakRule(m.TieBreak),
+			Seed:     m.TieBreakSeed,
+		},
+	}
+
+	// 稽核紀錄只用於顯示，內容無法解析時不影響讀取計畫；JSON null 解析為 nil
+	if len(m.Finalization) > 0 {
+		if err := json.Unmarshal(m.Finalization, &plan.Finalization); err != nil {
+			plan.Finalization = nil
+		}
+	}
+
+	// 確認的選項以複本形式保存，與 ConfirmPlan 的行為一致
+	if m.ConfirmedTimeSlotID != nil {
+		for _, slot := range timeSlots {
+			if slot.ID == *m.ConfirmedTimeSlotID {
+				confirmed := slot
+				plan.ConfirmedTimeSlot = &confirmed
+				break
+			}
+		}
+	}
+	if m.ConfirmedRestaurantID != nil {
+		for _, option := range restaurantOptions {
+			if option.ID == *m.ConfirmedRestaurantID {
+				confirmed := option
+				plan.ConfirmedRestaurant = &confirmed
+				break
+			}
+		}
+	}
+
+	return plan
+}
+
+func (r *GroupDiningPlanRepositoryPostgres) modelsToDomain(models []GroupDiningPlanModel) []*aggregates.GroupDiningPlan {
+	plans := make([]*aggregates.GroupDiningPlan, len(models))
+	for i := range models {
+		plans[i] = r.modelToDomain(&models[i])
+	}
+	return plans
+}
@@ -1,10 +1,10 @@
 package repositories
 
 import (
-	"errors"
 	"sync"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type VoteRepositoryInMemory struct {
@@ -24,11 +24,11 @@ func (r *VoteRepositoryInMemory) Create(vote *aggregates.Vote) error {
 	defer r.mutex.Unlock()
 
 	if vote == nil {
-		return errors.New("vote cannot be nil")
+		return shared.ErrInvalidInput.WithMessage("vote cannot be nil")
 	}
 
 	if _, exists := r.votes[vote.ID]; exists {
-		return errors.New("vote already exists")
+		return shared.ErrResourceConflict.WithMessage("vote already exists")
 	}
 
 	r.votes[vote.ID] = vote
@@ -40,12 +40,12 @@ func (r *VoteRepositoryInMemory) GetByID(id string) (*aggregates.Vote, error) {
 	defer r.mutex.RUnlock()
 
 	if id == "" {
-		return nil, errors.New("id cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("id cannot be empty")
 	}
 
 	vote, exists := r.votes[id]
 	if !exists {
-		return nil, errors.New("vote not found")
+		return nil, aggregates.ErrVoteNotFound
 	}
 
 	return vote, nil
@@ -56,10 +56,10 @@ func (r *VoteRepositoryInMemory) GetByPlanAndUser(planID, userID string) (*aggre
 	defer r.mutex.RUnlock()
 
 	if planID == "" {
-		return nil, errors.New("plan ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
 	}
 	if userID == "" {
-		return nil, errors.New("user ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
 	}
 
 	for _, vote := range r.votes {
@@ -68,7 +68,7 @@ func (r *VoteRepositoryInMemory) GetByPlanAndUser(planID, userID string) (*aggre
 		}
 	}
 
-	return nil, errors.New("vote not found")
+	return nil, aggregates.ErrVoteNotFound
 }
 
 func (r *VoteRepositoryInMemory) GetByPlan(planID string) ([]*aggregates.Vote, error) {
@@ -76,7 +76,7 @@ func (r *VoteRepositoryInMemory) GetByPlan(planID string) ([]*aggregates.Vote, e
 	defer r.mutex.RUnlock()
 
 	if planID == "" {
-		return nil, errors.New("plan ID cannot be empty")
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
 	}
 
 	var result []*aggregates.Vote
@@ -94,11 +94,11 @@ func (r *VoteRepositoryInMemory) Update(vote *aggregates.Vote) error {
 	defer r.mutex.Unlock()
 
 	if vote == nil {
-		return errors.New("vote cannot be nil")
+		return shared.ErrInvalidInput.WithMessage("vote cannot be nil")
 	}
 
 	if _, exists := r.votes[vote.ID]; !exists {
-		return errors.New("vote not found")
+		return aggregates.ErrVoteNotFound
 	}
 
 	r.votes[vote.ID] = vote
@@ -110,11 +110,11 @@ func (r *VoteRepositoryInMemory) Delete(id string) error {
 	defer r.mutex.Unlock()
 
 	if id == "" {
-		return errors.New("id cannot be empty")
+		return shared.ErrInvalidInput.WithMessage("id cannot be empty")
 	}
 
 	if _, exists := r.votes[id]; !exists {
-		return errors.New("vote not found")
+		return aggregates.ErrVoteNotFound
 	}
 
 	delete(r.votes, id)
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// GroupDiningVoteModel represents the database model for Vote
+type GroupDiningVoteModel struct {
+	ID      string `gorm:"type:uuid;primary_key"`
+	PlanID  string `gorm:"type:uuid;index;not null"`
+	UserID  string `gorm:"index;not null"`
+	Comment string
+	VotedAt time.Time                    `gorm:"not null"`
+	Choices []GroupDiningVoteChoiceModel `gorm:"foreignKey:VoteID;constraint:OnDelete:CASCADE"`
+}
+
+func (GroupDiningVoteModel) TableName() string {
+	return "group_dining_votes"
+}
+
+// GroupDiningVoteChoiceModel represents the database model for VoteChoice
+type GroupDiningVoteChoiceModel struct {
+	ID       string `gorm:"type:uuid;primary_key"`
+	VoteID   string `gorm:"type:uuid;index;not null"`
+	Position int    `gorm:"not null"`
+	Type     string `gorm:"not null"`
+	OptionID string `gorm:"not null"`
+	Score    int    `gorm:"not null"`
+}
+
+func (GroupDiningVoteChoiceModel) TableName() string {
+	return "group_dining_vote_choices"
+}
+
+// VoteRepositoryPostgres persists group dining votes with GORM
+type VoteRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewVoteRepositoryPostgres(db *gorm.DB) *VoteRepositoryPostgres {
+	return &VoteRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *VoteRepositoryPostgres) Create(vote *aggregates.Vote) error {
+	if vote == nil {
+		return shared.ErrInvalidInput.WithMessage("vote cannot be nil")
+	}
+
+	model := r.domainToModel(vote)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&GroupDiningVoteModel{}).Where("id = ?", model.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return shared.ErrResourceConflict.WithMessage("vote already exists")
+		}
+
+		if err := tx.Omit("Choices").Create(model).Error; err != nil {
+			return err
+		}
+		return r.saveChoices(tx, model)
+	})
+}
+
+func (r *VoteRepositoryPostgres) GetByID(id string) (*aggregates.Vote, error) {
+	if id == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("id cannot be empty")
+	}
+
+	var model GroupDiningVoteModel
+	result := r.preloaded().Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, aggregates.ErrVoteNotFound
+		}
+		return nil, result.Error
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *VoteRepositoryPostgres) GetByPlanAndUser(planID, userID string) (*aggregates.Vote, error) {
+	if planID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
+	}
+	if userID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("user ID cannot be empty")
+	}
+
+	var model GroupDiningVoteModel
+	result := r.preloaded().
+		Where("plan_id = ? AND user_id = ?", planID, userID).
+		Order("voted_at DESC").
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, aggregates.ErrVoteNotFound
+		}
+		return nil, result.Error
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *VoteRepositoryPostgres) GetByPlan(planID string) ([]*aggregates.Vote, error) {
+	if planID == "" {
+		return nil, shared.ErrInvalidInput.WithMessage("plan ID cannot be empty")
+	}
+
+	var models []GroupDiningVoteModel
+	result := r.preloaded().
+		Where("plan_id = ?", planID).
+		Order("voted_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	votes := make([]*aggregates.Vote, len(models))
+	for i := range models {
+		votes[i] = r.modelToDomain(&models[i])
+	}
+	return votes, nil
+}
+
+func (r *VoteRepositoryPostgres) Update(vote *aggregates.Vote) error {
+	if vote == nil {
+		return shared.ErrInvalidInput.WithMessage("vote cannot be nil")
+	}
+
+	model := r.domainToModel(vote)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&GroupDiningVoteModel{}).
+			Where("id = ?", model.ID).
+			Omit("Choices").
+			Select("*").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return aggregates.ErrVoteNotFound
+		}
+
+		if err := tx.Where("vote_id = ?", model.ID).Delete(&GroupDiningVoteChoiceModel{}).Error; err != nil {
+			return err
+		}
+		return r.saveChoices(tx, model)
+	})
+}
+
+func (r *VoteRepositoryPostgres) Delete(id string) error {
+	if id == "" {
+		return shared.ErrInvalidInput.WithMessage("id cannot be empty")
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("vote_id = ?", id).Delete(&GroupDiningVoteChoiceModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&GroupDiningVoteModel{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return aggregates.ErrVoteNotFound
+		}
+		return nil
+	})
+}
+
+// preloaded returns a query that loads vote choices in their original order
+func (r *VoteRepositoryPostgres) preloaded() *gorm.DB {
+	return r.db.Preload("Choices", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
+}
+
+func (r *VoteRepositoryPostgres) saveChoices(tx *gorm.DB, model *GroupDiningVoteModel) error {
+	if len(model.Choices) == 0 {
+		return nil
+	}
+	return tx.Create(&model.Choices).Error
+}
+
+// Helper methods for conversion
+func (r *VoteRepositoryPostgres) domainToModel(vote *aggregates.Vote) *GroupDiningVoteModel {
+	choices := make([]GroupDiningVoteChoiceModel, len(vote.Choices))
+	for i, choice := range vote.Choices {
+		choices[i] = GroupDiningVoteChoiceModel{
+			ID:       choice.ID,
+			VoteID:   vote.ID,
+			Position: i,
+			Type:     string(choice.Type),
+			OptionID: choice.OptionID,
+			Score:    choice.Score,
+		}
+	}
+
+	return &GroupDiningVoteModel{
+		ID:      vote.ID,
+		PlanID:  vote.PlanID,
+		UserID:  vote.UserID,
+		Comment: vote.Comment,
+		VotedAt: vote.VotedAt,
+		Choices: choices,
+	}
+}
+
+func (r *VoteRepositoryPostgres) modelToDomain(m *GroupDiningVoteModel) *aggregates.Vote {
+	choices := make([]aggregates.VoteChoice, len(m.Choices))
+	for i, choice := range m.Choices {
+		choices[i] = aggregates.VoteChoice{
+			ID:       choice.ID,
+			Type:     aggregates.VoteType(choice.Type),
+			OptionID: choice.OptionID,
+			Score:    choice.Score,
+		}
+	}
+
+	return &aggregates.Vote{
+		ID:      m.ID,
+		PlanID:  m.PlanID,
+		UserID:  m.UserID,
+		Choices: choices,
+		Comment: m.Comment,
+		VotedAt: m.VotedAt,
+	}
+}
@@ -8,20 +8,24 @@ import (
 
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	persistenceInmemory "github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
 )
 
 // InMemoryUserRepository implements the UserRepository interface using in-memory storage
 // 實作 Infrastructure Layer 的 Repository，遵循 Clean Architecture
 type InMemoryUserRepository struct {
-	users map[string]*user.User // key: userID string
-	mutex sync.RWMutex
+	users  map[string]*user.User // key: userID string
+	outbox *persistenceInmemory.Outbox
+	mutex  sync.RWMutex
 }
 
 // NewInMemoryUserRepository creates a new in-memory user repository
-func NewInMemoryUserRepository() *InMemoryUserRepository {
+// 聚合記錄的事件寫入 outbox；outbox 為 nil 時捨棄事件
+func NewInMemoryUserRepository(outbox *persistenceInmemory.Outbox) *InMemoryUserRepository {
 	return &InMemoryUserRepository{
-		users: make(map[string]*user.User),
-		mutex: sync.RWMutex{},
+		users:  make(map[string]*user.User),
+		outbox: outbox,
+		mutex:  sync.RWMutex{},
 	}
 }
 
@@ -30,7 +34,11 @@ func (r *InMemoryUserRepository) Save(ctx context.Context, u *user.User) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 	
+	if err := r.outbox.Append(u); err != nil {
+		return err
+	}
 	r.users[u.ID.String()] = u
+	u.ClearEvents()
 	return nil
 }
 
@@ -88,8 +96,12 @@ func (r *InMemoryUserRepository) Update(ctx context.Context, u *user.User) error
 		return shared.ErrUserNotFound
 	}
 	
+	if err := r.outbox.Append(u); err != nil {
+		return err
+	}
 	u.UpdatedAt = time.Now()
 	r.users[u.ID.String()] = u
+	u.ClearEvents()
 	return nil
 }
 
@@ -107,15 +119,16 @@ func (r *InMemoryUserRepository) Delete(ctx context.Context, id shared.UserID) e
 }
 
 // FindDiscoverableUsers retrieves users that can be discovered
-func (r *InMemoryUserRepository) FindDiscoverableUsers(ctx context.Context, limit, offset int) ([]*user.User, error) {
+func (r *InMemoryUserRepository) FindDiscoverableUsers(ctx context.Context, excludeIDs []shared.UserID, limit, offset int) ([]*user.User, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 	
+	excluded := userIDSet(excludeIDs)
 	var discoverableUsers []*user.User
 	count := 0
 	
 	for _, u := range r.users {
-		if u.CanBeDiscovered() {
+		if u.CanBeDiscovered() && !excluded[u.ID] {
 			if count >= offset {
 				discoverableUsers = append(discoverableUsers, u)
 				if len(discoverableUsers) >= limit {
@@ -130,16 +143,17 @@ func (r *InMemoryUserRepository) FindDiscoverableUsers(ctx context.Context, limi
 }
 
 // SearchUsers searches for users by query string
-func (r *InMemoryUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*user.User, error) {
+func (r *InMemoryUserRepository) SearchUsers(ctx context.Context, query string, excludeIDs []shared.UserID, limit, offset int) ([]*user.User, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 	
+	excluded := userIDSet(excludeIDs)
 	var foundUsers []*user.User
 	count := 0
 	normalizedQuery := strings.ToLower(strings.TrimSpace(query))
 	
 	for _, u := range r.users {
-		if r.matchesSearchQuery(u, normalizedQuery) {
+		if r.matchesSearchQuery(u, normalizedQuery) && !excluded[u.ID] {
 			if count >= offset {
 				foundUsers = append(foundUsers, u)
 				if len(foundUsers) >= limit {
@@ -233,6 +247,14 @@ func (r *InMemoryUserRepository) FindUnverifiedUsers(ctx context.Context, olderT
 	return unverifiedUsers, nil
 }
 
+func userIDSet(ids []shared.UserID) map[shared.UserID]bool {
+	set := make(map[shared.UserID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
 // Helper function to check if user matches search query
 func (r *InMemoryUserRepository) matchesSearchQuery(u *user.User, query string) bool {
 	// Search in display name, email, and bio
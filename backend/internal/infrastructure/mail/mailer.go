@@ -0,0 +1,81 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+	"github.com/google/uuid"
+)
+
+// NewMailerFromConfig 依設定建立 Mailer
+func NewMailerFromConfig(cfg config.MailConfig) (communication.Mailer, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogMailer(cfg.From), nil
+	case "file":
+		return NewFileMailer(cfg.From, cfg.OutputDir)
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %q", cfg.Driver)
+	}
+}
+
+// LogMailer 將 Email 內容寫入 log，適用於本機開發
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message communication.Message) error {
+	log.Printf("📧 Mail from %s to %s: %s\n%s", m.from, message.To, message.Subject, message.TextBody)
+	return nil
+}
+
+// FileMailer 將每封 Email 寫成一個 .eml 檔案，可直接用郵件軟體開啟檢查
+type FileMailer struct {
+	mu   sync.Mutex
+	from string
+	dir  string
+}
+
+func NewFileMailer(from, dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file mailer requires an output directory")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, message communication.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), uuid.NewString()[:8])
+	return os.WriteFile(filepath.Join(m.dir, name), []byte(formatMessage(m.from, message, now)), 0o644)
+}
+
+func formatMessage(from string, message communication.Message, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", message.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", message.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(message.TextBody, "\n", "\r\n"))
+	return b.String()
+}
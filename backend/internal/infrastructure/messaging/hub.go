@@ -0,0 +1,111 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// DefaultBufferSize 每個訂閱的事件緩衝數量
+const DefaultBufferSize = 32
+
+// Hub 是單一程序內的 pub/sub，實作 communication.Publisher
+// 每個用戶可同時有多個訂閱 (多裝置、多分頁)；跟不上的訂閱會被關閉，
+// 由客戶端重新連線並重新取得最新狀態，避免阻塞發布者或默默遺漏事件
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[shared.UserID]map[*Subscription]struct{}
+	bufferSize  int
+}
+
+// NewHub 建立 Hub，bufferSize <= 0 時使用 DefaultBufferSize
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		subscribers: make(map[shared.UserID]map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+var _ communication.Publisher = (*Hub)(nil)
+
+// Subscription 是單一連線對某用戶事件的訂閱
+type Subscription struct {
+	hub    *Hub
+	userID shared.UserID
+	events chan communication.Event
+}
+
+// Events 回傳事件 channel，訂閱關閉後 channel 會被關閉
+func (s *Subscription) Events() <-chan communication.Event {
+	return s.events
+}
+
+// Close 取消訂閱，可重複呼叫
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Subscribe 訂閱指定用戶的事件
+func (h *Hub) Subscribe(userID shared.UserID) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan communication.Event, h.bufferSize),
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+// Publish 將事件送給所有收件者的訂閱，不會阻塞
+func (h *Hub) Publish(ctx context.Context, event communication.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, recipient := range event.Recipients {
+		for sub := range h.subscribers[recipient] {
+			select {
+			case sub.events <- event:
+			default:
+				// 緩衝已滿：關閉訂閱讓客戶端重新同步
+				h.remove(sub)
+			}
+		}
+	}
+}
+
+// SubscriberCount 回傳用戶目前的訂閱數
+func (h *Hub) SubscriberCount(userID shared.UserID) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userID])
+}
+
+// remove 移除並關閉訂閱，呼叫端須持有 h.mu
+func (h *Hub) remove(sub *Subscription) {
+	subs, exists := h.subscribers[sub.userID]
+	if !exists {
+		return
+	}
+	if _, exists := subs[sub]; !exists {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.userID)
+	}
+}
@@ -0,0 +1,84 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+func TestPublishDeliversOnlyToRecipients(t *testing.T) {
+	hub := NewHub(4)
+	alice, bob, carol := shared.NewUserID(), shared.NewUserID(), shared.NewUserID()
+
+	alicePhone := hub.Subscribe(alice)
+	aliceLaptop := hub.Subscribe(alice)
+	bobSub := hub.Subscribe(bob)
+	carolSub := hub.Subscribe(carol)
+
+	event := communication.NewEvent(communication.EventPingResponded, []shared.UserID{alice, bob, alice}, nil)
+	hub.Publish(context.Background(), event)
+
+	for name, sub := range map[string]*Subscription{"alice phone": alicePhone, "alice laptop": aliceLaptop, "bob": bobSub} {
+		select {
+		case got := <-sub.Events():
+			if got.ID != event.ID {
+				t.Errorf("%s received event %s, want %s", name, got.ID, event.ID)
+			}
+		default:
+			t.Errorf("%s did not receive the event", name)
+		}
+		select {
+		case extra := <-sub.Events():
+			t.Errorf("%s received duplicate event %s", name, extra.ID)
+		default:
+		}
+	}
+
+	select {
+	case got := <-carolSub.Events():
+		t.Errorf("non-recipient received event %s", got.ID)
+	default:
+	}
+}
+
+func TestCloseUnsubscribes(t *testing.T) {
+	hub := NewHub(4)
+	userID := shared.NewUserID()
+
+	sub := hub.Subscribe(userID)
+	sub.Close()
+	sub.Close()
+
+	if _, open := <-sub.Events(); open {
+		t.Error("Events() channel still open after Close()")
+	}
+	if got := hub.SubscriberCount(userID); got != 0 {
+		t.Errorf("SubscriberCount() = %d, want 0", got)
+	}
+
+	// 關閉後發布不應 panic
+	hub.Publish(context.Background(), communication.NewEvent(communication.EventPingResponded, []shared.UserID{userID}, nil))
+}
+
+func TestSlowSubscriberIsDisconnected(t *testing.T) {
+	hub := NewHub(1)
+	userID := shared.NewUserID()
+	slow := hub.Subscribe(userID)
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(context.Background(), communication.NewEvent(communication.EventPlanVoteSubmitted, []shared.UserID{userID}, i))
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	if received != 1 {
+		t.Errorf("slow subscriber received %d events before disconnect, want 1", received)
+	}
+	if got := hub.SubscriberCount(userID); got != 0 {
+		t.Errorf("SubscriberCount() = %d, want 0", got)
+	}
+}
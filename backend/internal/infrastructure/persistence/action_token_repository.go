@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"gorm.io/gorm"
+)
+
+// ActionTokenModel represents the database model for user.ActionToken
+type ActionTokenModel struct {
+	ID        string `gorm:"type:uuid;primary_key"`
+	UserID    string `gorm:"type:uuid;index:idx_action_tokens_user_purpose;not null"`
+	Purpose   string `gorm:"index:idx_action_tokens_user_purpose;not null"`
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (ActionTokenModel) TableName() string {
+	return "action_tokens"
+}
+
+// PostgreSQLActionTokenRepository implements user.ActionTokenRepository
+type PostgreSQLActionTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLActionTokenRepository(db *gorm.DB) *PostgreSQLActionTokenRepository {
+	return &PostgreSQLActionTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLActionTokenRepository) Save(ctx context.Context, token *user.ActionToken) error {
+	return r.db.WithContext(ctx).Create(r.domainToModel(token)).Error
+}
+
+func (r *PostgreSQLActionTokenRepository) FindByID(ctx context.Context, id shared.ID) (*user.ActionToken, error) {
+	var model ActionTokenModel
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrEntityNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLActionTokenRepository) MarkUsed(ctx context.Context, id shared.ID, at time.Time) error {
+	// 條件式更新確保同一個 token 只能使用一次
+	result := r.db.WithContext(ctx).
+		Model(&ActionTokenModel{}).
+		Where("id = ? AND used_at IS NULL", id.String()).
+		Update("used_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&ActionTokenModel{}).Where("id = ?", id.String()).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return shared.ErrEntityNotFound
+	}
+	return shared.ErrActionTokenUsed
+}
+
+func (r *PostgreSQLActionTokenRepository) InvalidateForUser(ctx context.Context, userID shared.UserID, purpose user.TokenPurpose, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&ActionTokenModel{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID.String(), string(purpose)).
+		Update("used_at", at).Error
+}
+
+func (r *PostgreSQLActionTokenRepository) domainToModel(token *user.ActionToken) *ActionTokenModel {
+	return &ActionTokenModel{
+		ID:        token.ID.String(),
+		UserID:    token.UserID.String(),
+		Purpose:   string(token.Purpose),
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    token.UsedAt,
+	}
+}
+
+func (r *PostgreSQLActionTokenRepository) modelToDomain(model *ActionTokenModel) (*user.ActionToken, error) {
+	id, err := shared.ParseID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := shared.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.ActionToken{
+		ID:        id,
+		UserID:    userID,
+		Purpose:   user.TokenPurpose(model.Purpose),
+		CreatedAt: model.CreatedAt,
+		ExpiresAt: model.ExpiresAt,
+		UsedAt:    model.UsedAt,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AvailabilityModel represents the database model for availability.Schedule
+type AvailabilityModel struct {
+	UserID     string            `gorm:"type:uuid;primary_key"`
+	TimeZone   string            `gorm:"not null"`
+	Windows    WeeklyWindowsJSON `gorm:"type:jsonb;not null"`
+	BusyBlocks BusyBlocksJSON    `gorm:"type:jsonb;not null"`
+	UpdatedAt  time.Time         `gorm:"not null"`
+}
+
+func (AvailabilityModel) TableName() string {
+	return "availability_schedules"
+}
+
+// WeeklyWindowsJSON stores weekly availability windows in a jsonb column
+type WeeklyWindowsJSON []availability.WeeklyWindow
+
+func (w WeeklyWindowsJSON) Value() (driver.Value, error) {
+	if w == nil {
+		return json.Marshal([]availability.WeeklyWindow{})
+	}
+	return json.Marshal([]availability.WeeklyWindow(w))
+}
+
+func (w *WeeklyWindowsJSON) Scan(value interface{}) error {
+	return scanJSON(value, w)
+}
+
+// BusyBlocksJSON stores busy blocks in a jsonb column
+type BusyBlocksJSON []availability.BusyBlock
+
+func (b BusyBlocksJSON) Value() (driver.Value, error) {
+	if b == nil {
+		return json.Marshal([]availability.BusyBlock{})
+	}
+	return json.Marshal([]availability.BusyBlock(b))
+}
+
+func (b *BusyBlocksJSON) Scan(value interface{}) error {
+	return scanJSON(value, b)
+}
+
+// PostgreSQLAvailabilityRepository implements availability.Repository
+type PostgreSQLAvailabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLAvailabilityRepository(db *gorm.DB) *PostgreSQLAvailabilityRepository {
+	return &PostgreSQLAvailabilityRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLAvailabilityRepository) Save(ctx context.Context, schedule *availability.Schedule) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"time_zone", "windows", "busy_blocks", "updated_at"}),
+		}).
+		Create(r.domainToModel(schedule)).Error
+}
+
+func (r *PostgreSQLAvailabilityRepository) FindByUser(ctx context.Context, userID shared.UserID) (*availability.Schedule, error) {
+	var model AvailabilityModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrAvailabilityNotFound
+		}
+		return nil, err
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLAvailabilityRepository) FindByUsers(ctx context.Context, userIDs []shared.UserID) ([]*availability.Schedule, error) {
+	if len(userIDs) == 0 {
+		return []*availability.Schedule{}, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	var models []AvailabilityModel
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", ids).Order("user_id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	schedules := make([]*availability.Schedule, 0, len(models))
+	for i := range models {
+		schedule, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+func (r *PostgreSQLAvailabilityRepository) domainToModel(s *availability.Schedule) *AvailabilityModel {
+	return &AvailabilityModel{
+		UserID:     s.UserID.String(),
+		TimeZone:   s.TimeZone,
+		Windows:    WeeklyWindowsJSON(s.Windows),
+		BusyBlocks: BusyBlocksJSON(s.BusyBlocks),
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func (r *PostgreSQLAvailabilityRepository) modelToDomain(m *AvailabilityModel) (*availability.Schedule, error) {
+	userID, err := shared.NewUserIDFromString(m.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []availability.WeeklyWindow(m.Windows)
+	if windows == nil {
+		windows = []availability.WeeklyWindow{}
+	}
+	busyBlocks := []availability.BusyBlock(m.BusyBlocks)
+	if busyBlocks == nil {
+		busyBlocks = []availability.BusyBlock{}
+	}
+
+	return &availability.Schedule{
+		UserID:     userID,
+		TimeZone:   m.TimeZone,
+		Windows:    windows,
+		BusyBlocks: busyBlocks,
+		UpdatedAt:  m.UpdatedAt,
+	}, nil
+}
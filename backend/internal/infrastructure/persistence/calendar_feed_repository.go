@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CalendarFeedModel represents the database model for calendar.Feed
+type CalendarFeedModel struct {
+	UserID    string    `gorm:"type:uuid;primary_key"`
+	TokenHash string    `gorm:"not null;uniqueIndex"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (CalendarFeedModel) TableName() string {
+	return "calendar_feeds"
+}
+
+// PostgreSQLCalendarFeedRepository implements calendar.FeedRepository
+type PostgreSQLCalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLCalendarFeedRepository(db *gorm.DB) *PostgreSQLCalendarFeedRepository {
+	return &PostgreSQLCalendarFeedRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLCalendarFeedRepository) Save(ctx context.Context, feed *calendar.Feed) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+		}).
+		Create(&CalendarFeedModel{
+			UserID:    feed.UserID.String(),
+			TokenHash: feed.TokenHash,
+			CreatedAt: feed.CreatedAt,
+		}).Error
+}
+
+func (r *PostgreSQLCalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*calendar.Feed, error) {
+	var model CalendarFeedModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+
+	userID, err := shared.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &calendar.Feed{
+		UserID:    userID,
+		TokenHash: model.TokenHash,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+func (r *PostgreSQLCalendarFeedRepository) Delete(ctx context.Context, userID shared.UserID) error {
+	return r.db.WithContext(ctx).Delete(&CalendarFeedModel{}, "user_id = ?", userID.String()).Error
+}
@@ -0,0 +1,100 @@
+package persistence_test
+
+import (
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/contracttest"
+)
+
+func TestPostgreSQLPingRepositoryContract(t *testing.T) {
+	contracttest.RunPingRepositoryContract(t, func(t *testing.T) ping.Repository {
+		return persistence.NewPostgreSQLPingRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLPingSeriesRepositoryContract(t *testing.T) {
+	contracttest.RunPingSeriesRepositoryContract(t, func(t *testing.T) (ping.SeriesRepository, ping.Repository) {
+		db := contracttest.OpenTestDB(t)
+		return persistence.NewPostgreSQLPingSeriesRepository(db), persistence.NewPostgreSQLPingRepository(db)
+	})
+}
+
+func TestPostgreSQLFriendshipRepositoryContract(t *testing.T) {
+	contracttest.RunFriendshipRepositoryContract(t, func(t *testing.T) friendship.FriendshipRepository {
+		return persistence.NewPostgreSQLFriendshipRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLRestaurantRepositoryContract(t *testing.T) {
+	contracttest.RunRestaurantRepositoryContract(t, func(t *testing.T) restaurant.Repository {
+		return persistence.NewPostgreSQLRestaurantRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLRefreshTokenRepositoryContract(t *testing.T) {
+	contracttest.RunRefreshTokenRepositoryContract(t, func(t *testing.T) session.RefreshTokenRepository {
+		return persistence.NewPostgreSQLRefreshTokenRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLRevocationListContract(t *testing.T) {
+	contracttest.RunRevocationListContract(t, func(t *testing.T) session.RevocationList {
+		return persistence.NewPostgreSQLRevocationList(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLActionTokenRepositoryContract(t *testing.T) {
+	contracttest.RunActionTokenRepositoryContract(t, func(t *testing.T) user.ActionTokenRepository {
+		return persistence.NewPostgreSQLActionTokenRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLOutboxContract(t *testing.T) {
+	contracttest.RunOutboxContract(t, func(t *testing.T) (ping.Repository, events.OutboxStore) {
+		db := contracttest.OpenTestDB(t)
+		return persistence.NewPostgreSQLPingRepository(db), persistence.NewPostgreSQLOutboxStore(db)
+	})
+}
+
+func TestPostgreSQLNotificationRepositoryContract(t *testing.T) {
+	contracttest.RunNotificationRepositoryContract(t, func(t *testing.T) notification.Repository {
+		return persistence.NewPostgreSQLNotificationRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLSchedulerStoreContract(t *testing.T) {
+	contracttest.RunSchedulerStoreContract(t, func(t *testing.T) (scheduler.LeaseStore, scheduler.RunStore) {
+		db := contracttest.OpenTestDB(t)
+		return persistence.NewPostgreSQLLeaseStore(db), persistence.NewPostgreSQLJobRunStore(db)
+	})
+}
+
+func TestPostgreSQLAvailabilityRepositoryContract(t *testing.T) {
+	contracttest.RunAvailabilityRepositoryContract(t, func(t *testing.T) availability.Repository {
+		return persistence.NewPostgreSQLAvailabilityRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLCalendarFeedRepositoryContract(t *testing.T) {
+	contracttest.RunCalendarFeedRepositoryContract(t, func(t *testing.T) calendar.FeedRepository {
+		return persistence.NewPostgreSQLCalendarFeedRepository(contracttest.OpenTestDB(t))
+	})
+}
+
+func TestPostgreSQLReviewRepositoryContract(t *testing.T) {
+	contracttest.RunReviewRepositoryContract(t, func(t *testing.T) review.Repository {
+		return persistence.NewPostgreSQLReviewRepository(contracttest.OpenTestDB(t))
+	})
+}
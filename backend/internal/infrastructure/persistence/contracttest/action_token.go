@@ -0,0 +1,113 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// RunActionTokenRepositoryContract exercises a user.ActionTokenRepository
+// implementation. newRepo must return an empty repository on every call.
+func RunActionTokenRepositoryContract(t *testing.T, newRepo func(t *testing.T) user.ActionTokenRepository) {
+	ctx := context.Background()
+
+	t.Run("save and find by id", func(t *testing.T) {
+		repo := newRepo(t)
+		token := newTestActionToken(shared.NewUserID(), user.PurposeEmailVerification)
+		if err := repo.Save(ctx, token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, token.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.ID != token.ID || got.UserID != token.UserID || got.Purpose != token.Purpose {
+			t.Errorf("FindByID() = %+v, want %+v", got, token)
+		}
+		assertTimeEqual(t, "CreatedAt", token.CreatedAt, got.CreatedAt)
+		assertTimeEqual(t, "ExpiresAt", token.ExpiresAt, got.ExpiresAt)
+		if got.IsUsed() {
+			t.Error("new token is marked as used")
+		}
+
+		if _, err := repo.FindByID(ctx, shared.NewID()); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByID() missing error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+
+	t.Run("mark used only once", func(t *testing.T) {
+		repo := newRepo(t)
+		token := newTestActionToken(shared.NewUserID(), user.PurposePasswordReset)
+		if err := repo.Save(ctx, token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		usedAt := time.Now().Truncate(time.Microsecond)
+		if err := repo.MarkUsed(ctx, token.ID, usedAt); err != nil {
+			t.Fatalf("MarkUsed() error = %v", err)
+		}
+		if err := repo.MarkUsed(ctx, token.ID, usedAt); !errors.Is(err, shared.ErrActionTokenUsed) {
+			t.Errorf("MarkUsed() twice error = %v, want %v", err, shared.ErrActionTokenUsed)
+		}
+		if err := repo.MarkUsed(ctx, shared.NewID(), usedAt); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("MarkUsed() missing error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+
+		got, err := repo.FindByID(ctx, token.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		assertOptionalTimeEqual(t, "UsedAt", &usedAt, got.UsedAt)
+	})
+
+	t.Run("invalidate for user and purpose", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+		reset := newTestActionToken(userID, user.PurposePasswordReset)
+		verification := newTestActionToken(userID, user.PurposeEmailVerification)
+		otherUser := newTestActionToken(shared.NewUserID(), user.PurposePasswordReset)
+		for _, token := range []*user.ActionToken{reset, verification, otherUser} {
+			if err := repo.Save(ctx, token); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		if err := repo.InvalidateForUser(ctx, userID, user.PurposePasswordReset, time.Now()); err != nil {
+			t.Fatalf("InvalidateForUser() error = %v", err)
+		}
+
+		for _, tt := range []struct {
+			name  string
+			token *user.ActionToken
+			used  bool
+		}{
+			{"same purpose", reset, true},
+			{"other purpose", verification, false},
+			{"other user", otherUser, false},
+		} {
+			got, err := repo.FindByID(ctx, tt.token.ID)
+			if err != nil {
+				t.Fatalf("FindByID() error = %v", err)
+			}
+			if got.IsUsed() != tt.used {
+				t.Errorf("%s: used = %v, want %v", tt.name, got.IsUsed(), tt.used)
+			}
+		}
+	})
+}
+
+func newTestActionToken(userID shared.UserID, purpose user.TokenPurpose) *user.ActionToken {
+	now := time.Now().Truncate(time.Microsecond)
+	return &user.ActionToken{
+		ID:        shared.NewID(),
+		UserID:    userID,
+		Purpose:   purpose,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+}
@@ -0,0 +1,92 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunAvailabilityRepositoryContract exercises an availability.Repository
+// implementation. newRepo must return an empty repository on every call.
+func RunAvailabilityRepositoryContract(t *testing.T, newRepo func(t *testing.T) availability.Repository) {
+	ctx := context.Background()
+
+	t.Run("save replaces the schedule of a user", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+
+		if _, err := repo.FindByUser(ctx, userID); !errors.Is(err, shared.ErrAvailabilityNotFound) {
+			t.Fatalf("FindByUser() before save error = %v, want %v", err, shared.ErrAvailabilityNotFound)
+		}
+
+		first := newTestSchedule(t, userID, "Asia/Taipei", time.Monday)
+		if err := repo.Save(ctx, first); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		second := newTestSchedule(t, userID, "Asia/Tokyo", time.Friday)
+		if err := repo.Save(ctx, second); err != nil {
+			t.Fatalf("Save() replace error = %v", err)
+		}
+
+		got, err := repo.FindByUser(ctx, userID)
+		if err != nil {
+			t.Fatalf("FindByUser() error = %v", err)
+		}
+		if got.UserID != userID || got.TimeZone != "Asia/Tokyo" {
+			t.Errorf("FindByUser() = %s in %s, want %s in Asia/Tokyo", got.UserID, got.TimeZone, userID)
+		}
+		if len(got.Windows) != 1 || got.Windows[0] != second.Windows[0] {
+			t.Errorf("Windows = %+v, want %+v", got.Windows, second.Windows)
+		}
+		if len(got.BusyBlocks) != 1 || got.BusyBlocks[0].Title != "dentist" {
+			t.Fatalf("BusyBlocks = %+v, want one dentist block", got.BusyBlocks)
+		}
+		assertTimeEqual(t, "BusyBlocks[0].Start", second.BusyBlocks[0].Start, got.BusyBlocks[0].Start)
+		assertTimeEqual(t, "BusyBlocks[0].End", second.BusyBlocks[0].End, got.BusyBlocks[0].End)
+		assertTimeEqual(t, "UpdatedAt", second.UpdatedAt, got.UpdatedAt)
+	})
+
+	t.Run("find by users skips users without a schedule", func(t *testing.T) {
+		repo := newRepo(t)
+		alice, bob, carol := shared.NewUserID(), shared.NewUserID(), shared.NewUserID()
+
+		for _, userID := range []shared.UserID{alice, bob} {
+			if err := repo.Save(ctx, newTestSchedule(t, userID, "UTC", time.Tuesday)); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		got, err := repo.FindByUsers(ctx, []shared.UserID{alice, carol, bob})
+		if err != nil {
+			t.Fatalf("FindByUsers() error = %v", err)
+		}
+		ids := make([]string, len(got))
+		for i, schedule := range got {
+			ids[i] = schedule.UserID.String()
+		}
+		assertSameIDs(t, "FindByUsers()", []string{alice.String(), bob.String()}, ids)
+
+		if empty, err := repo.FindByUsers(ctx, nil); err != nil || len(empty) != 0 {
+			t.Errorf("FindByUsers(nil) = %d schedules, %v, want none", len(empty), err)
+		}
+	})
+}
+
+func newTestSchedule(t *testing.T, userID shared.UserID, timeZone string, day time.Weekday) *availability.Schedule {
+	t.Helper()
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	schedule, err := availability.NewSchedule(userID, timeZone,
+		[]availability.WeeklyWindow{{Day: day, Start: "11:30", End: "14:00"}},
+		[]availability.BusyBlock{{Start: start, End: start.Add(time.Hour), Title: "dentist"}},
+	)
+	if err != nil {
+		t.Fatalf("NewSchedule() error = %v", err)
+	}
+	schedule.UpdatedAt = schedule.UpdatedAt.Truncate(time.Microsecond)
+	return schedule
+}
@@ -0,0 +1,76 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunCalendarFeedRepositoryContract exercises a calendar.FeedRepository
+// implementation. newRepo must return an empty repository on every call.
+func RunCalendarFeedRepositoryContract(t *testing.T, newRepo func(t *testing.T) calendar.FeedRepository) {
+	ctx := context.Background()
+
+	t.Run("rotating a feed invalidates the old token", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+
+		first, firstToken := newTestFeed(t, userID)
+		if err := repo.Save(ctx, first); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		got, err := repo.FindByTokenHash(ctx, calendar.HashFeedToken(firstToken))
+		if err != nil {
+			t.Fatalf("FindByTokenHash() error = %v", err)
+		}
+		if got.UserID != userID {
+			t.Errorf("FindByTokenHash().UserID = %s, want %s", got.UserID, userID)
+		}
+		assertTimeEqual(t, "CreatedAt", first.CreatedAt, got.CreatedAt)
+
+		second, secondToken := newTestFeed(t, userID)
+		if err := repo.Save(ctx, second); err != nil {
+			t.Fatalf("Save() rotate error = %v", err)
+		}
+		if _, err := repo.FindByTokenHash(ctx, calendar.HashFeedToken(firstToken)); !errors.Is(err, shared.ErrCalendarFeedNotFound) {
+			t.Errorf("FindByTokenHash(old token) error = %v, want %v", err, shared.ErrCalendarFeedNotFound)
+		}
+		if got, err := repo.FindByTokenHash(ctx, calendar.HashFeedToken(secondToken)); err != nil || got.UserID != userID {
+			t.Errorf("FindByTokenHash(new token) = %v, %v, want feed of %s", got, err, userID)
+		}
+	})
+
+	t.Run("delete revokes the feed", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+
+		feed, token := newTestFeed(t, userID)
+		if err := repo.Save(ctx, feed); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := repo.Delete(ctx, userID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByTokenHash(ctx, calendar.HashFeedToken(token)); !errors.Is(err, shared.ErrCalendarFeedNotFound) {
+			t.Errorf("FindByTokenHash() after delete error = %v, want %v", err, shared.ErrCalendarFeedNotFound)
+		}
+		if err := repo.Delete(ctx, userID); err != nil {
+			t.Errorf("Delete() twice error = %v, want nil", err)
+		}
+	})
+}
+
+func newTestFeed(t *testing.T, userID shared.UserID) (*calendar.Feed, string) {
+	t.Helper()
+
+	feed, token, err := calendar.NewFeed(userID)
+	if err != nil {
+		t.Fatalf("NewFeed() error = %v", err)
+	}
+	feed.CreatedAt = time.Now().Truncate(time.Microsecond)
+	return feed, token
+}
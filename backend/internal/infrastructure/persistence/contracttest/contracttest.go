@@ -0,0 +1,166 @@
+// Package contracttest holds repository contract suites shared by the
+// in-memory and PostgreSQL implementations, so both are held to the same
+// observable behaviour.
+package contracttest
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/migrations"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DatabaseDSNEnv names the environment variable holding the PostgreSQL DSN
+// used by the SQL contract runs. When unset, those runs are skipped.
+const DatabaseDSNEnv = "PINGNOM_TEST_DATABASE_DSN"
+
+// testDBLockKey is the PostgreSQL advisory lock that serializes users of the
+// shared test database. go test runs packages in parallel, and every package
+// truncates the same tables, so callers from different packages take turns.
+const testDBLockKey = 7_402_311
+
+// timeTolerance absorbs precision lost when timestamps round-trip through
+// the database (PostgreSQL stores microseconds, Go keeps nanoseconds)
+const timeTolerance = time.Millisecond
+
+// OpenTestDB connects to the database named by DatabaseDSNEnv, migrates the
+// schema and empties every table so each caller starts from a clean slate.
+// The database is held exclusively until the test finishes.
+func OpenTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(DatabaseDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set, skipping PostgreSQL contract tests", DatabaseDSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get test database handle: %v", err)
+	}
+
+	// 以獨立連線持有 session 層級的 advisory lock，其他套件的測試等到此測試結束才開始
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		sqlDB.Close()
+		t.Fatalf("failed to reserve test database connection: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", testDBLockKey); err != nil {
+		conn.Close()
+		sqlDB.Close()
+		t.Fatalf("failed to lock test database: %v", err)
+	}
+	t.Cleanup(func() {
+		// 關閉連線時 session 結束，鎖也會一併釋放
+		conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", testDBLockKey)
+		conn.Close()
+		sqlDB.Close()
+	})
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("failed to migrate schema: %v", err)
+	}
+
+	tables := []string{
+		"group_dining_vote_choices",
+		"group_dining_votes",
+		"group_dining_participants",
+		"group_dining_restaurant_options",
+		"group_dining_time_slots",
+		"group_dining_plans",
+		"outbox_events",
+		"job_runs",
+		"scheduler_leases",
+		"reviews",
+		"availability_schedules",
+		"calendar_feeds",
+		"notifications",
+		"action_tokens",
+		"refresh_tokens",
+		"revoked_tokens",
+		"ping_responses",
+		"pings",
+		"ping_series",
+		"friendships",
+		"restaurants",
+		"users",
+	}
+	for _, table := range tables {
+		if err := db.Exec("TRUNCATE TABLE " + table + " CASCADE").Error; err != nil {
+			t.Fatalf("failed to truncate %s: %v", table, err)
+		}
+	}
+
+	return db
+}
+
+func assertTimeEqual(t *testing.T, field string, want, got time.Time) {
+	t.Helper()
+	diff := want.Sub(got)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > timeTolerance {
+		t.Errorf("%s = %v, want %v", field, got, want)
+	}
+}
+
+func assertOptionalTimeEqual(t *testing.T, field string, want, got *time.Time) {
+	t.Helper()
+	if want == nil || got == nil {
+		if want != got {
+			t.Errorf("%s = %v, want %v", field, got, want)
+		}
+		return
+	}
+	assertTimeEqual(t, field, *want, *got)
+}
+
+func assertSameIDs(t *testing.T, field string, want, got []string) {
+	t.Helper()
+	want = append([]string(nil), want...)
+	got = append([]string(nil), got...)
+	sort.Strings(want)
+	sort.Strings(got)
+	if len(want) != len(got) {
+		t.Errorf("%s = %v, want %v", field, got, want)
+		return
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("%s = %v, want %v", field, got, want)
+			return
+		}
+	}
+}
+
+func assertOrderedIDs(t *testing.T, field string, want, got []string) {
+	t.Helper()
+	if len(want) != len(got) {
+		t.Errorf("%s = %v, want %v", field, got, want)
+		return
+	}
+	for i := range want {
+		if want[i] != got[i] {
+			t.Errorf("%s = %v, want %v", field, got, want)
+			return
+		}
+	}
+}
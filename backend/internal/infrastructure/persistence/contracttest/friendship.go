@@ -0,0 +1,228 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunFriendshipRepositoryContract exercises a friendship.FriendshipRepository
+// implementation. newRepo must return an empty repository on every call.
+func RunFriendshipRepositoryContract(t *testing.T, newRepo func(t *testing.T) friendship.FriendshipRepository) {
+	ctx := context.Background()
+
+	t.Run("save and find round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		f := newTestFriendship(t, shared.NewUserID(), shared.NewUserID())
+		if err := repo.Save(ctx, f); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, f.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		assertFriendshipEqual(t, f, got)
+	})
+
+	t.Run("find missing friendship", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, shared.NewFriendshipID()); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+		if _, err := repo.FindByUsers(ctx, shared.NewUserID(), shared.NewUserID()); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByUsers() error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+
+	t.Run("find by users in either direction", func(t *testing.T) {
+		repo := newRepo(t)
+		requester, addressee := shared.NewUserID(), shared.NewUserID()
+		f := newTestFriendship(t, requester, addressee)
+		if err := repo.Save(ctx, f); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		for _, pair := range [][2]shared.UserID{{requester, addressee}, {addressee, requester}} {
+			got, err := repo.FindByUsers(ctx, pair[0], pair[1])
+			if err != nil {
+				t.Fatalf("FindByUsers() error = %v", err)
+			}
+			if got.ID != f.ID {
+				t.Errorf("FindByUsers() ID = %v, want %v", got.ID, f.ID)
+			}
+
+			exists, err := repo.ExistsBetweenUsers(ctx, pair[0], pair[1])
+			if err != nil {
+				t.Fatalf("ExistsBetweenUsers() error = %v", err)
+			}
+			if !exists {
+				t.Errorf("ExistsBetweenUsers() = false, want true")
+			}
+		}
+
+		exists, err := repo.ExistsBetweenUsers(ctx, requester, shared.NewUserID())
+		if err != nil {
+			t.Fatalf("ExistsBetweenUsers() error = %v", err)
+		}
+		if exists {
+			t.Errorf("ExistsBetweenUsers() with stranger = true, want false")
+		}
+	})
+
+	t.Run("pending sent and accepted lists", func(t *testing.T) {
+		repo := newRepo(t)
+		user := shared.NewUserID()
+
+		incoming := newTestFriendship(t, shared.NewUserID(), user)
+		outgoing := newTestFriendship(t, user, shared.NewUserID())
+		accepted := newTestFriendship(t, shared.NewUserID(), user)
+		for _, f := range []*friendship.Friendship{incoming, outgoing, accepted} {
+			if err := repo.Save(ctx, f); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		if err := accepted.Accept(); err != nil {
+			t.Fatalf("Accept() error = %v", err)
+		}
+		if err := repo.Update(ctx, accepted); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, accepted.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		assertFriendshipEqual(t, accepted, got)
+
+		pending, err := repo.FindPendingRequestsByUserID(ctx, user, 10, 0)
+		if err != nil {
+			t.Fatalf("FindPendingRequestsByUserID() error = %v", err)
+		}
+		assertSameIDs(t, "FindPendingRequestsByUserID", friendshipIDs(incoming), friendshipIDs(pending...))
+
+		sent, err := repo.FindSentRequestsByUserID(ctx, user, 10, 0)
+		if err != nil {
+			t.Fatalf("FindSentRequestsByUserID() error = %v", err)
+		}
+		assertSameIDs(t, "FindSentRequestsByUserID", friendshipIDs(outgoing), friendshipIDs(sent...))
+
+		friends, err := repo.FindFriendsByUserID(ctx, user, 10, 0)
+		if err != nil {
+			t.Fatalf("FindFriendsByUserID() error = %v", err)
+		}
+		assertSameIDs(t, "FindFriendsByUserID", friendshipIDs(accepted), friendshipIDs(friends...))
+
+		count, err := repo.CountFriendsByUserID(ctx, user)
+		if err != nil {
+			t.Fatalf("CountFriendsByUserID() error = %v", err)
+		}
+		if count != 1 {
+			t.Errorf("CountFriendsByUserID() = %d, want 1", count)
+		}
+	})
+
+	t.Run("blocked list includes both directions", func(t *testing.T) {
+		repo := newRepo(t)
+		user := shared.NewUserID()
+
+		blockedByUser, err := friendship.NewBlock(user, shared.NewUserID())
+		if err != nil {
+			t.Fatalf("NewBlock() error = %v", err)
+		}
+		blockedUser, err := friendship.NewBlock(shared.NewUserID(), user)
+		if err != nil {
+			t.Fatalf("NewBlock() error = %v", err)
+		}
+		pending := newTestFriendship(t, user, shared.NewUserID())
+		unrelated, err := friendship.NewBlock(shared.NewUserID(), shared.NewUserID())
+		if err != nil {
+			t.Fatalf("NewBlock() error = %v", err)
+		}
+		for _, f := range []*friendship.Friendship{blockedByUser, blockedUser, pending, unrelated} {
+			if err := repo.Save(ctx, f); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		blocked, err := repo.FindBlockedByUserID(ctx, user)
+		if err != nil {
+			t.Fatalf("FindBlockedByUserID() error = %v", err)
+		}
+		assertSameIDs(t, "FindBlockedByUserID", friendshipIDs(blockedByUser, blockedUser), friendshipIDs(blocked...))
+	})
+
+	t.Run("update and delete missing friendship", func(t *testing.T) {
+		repo := newRepo(t)
+		f := newTestFriendship(t, shared.NewUserID(), shared.NewUserID())
+		if err := repo.Update(ctx, f); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+		if err := repo.Delete(ctx, f.ID); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("Delete() error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+		requester, addressee := shared.NewUserID(), shared.NewUserID()
+		f := newTestFriendship(t, requester, addressee)
+		if err := repo.Save(ctx, f); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		if err := repo.Delete(ctx, f.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByID(ctx, f.ID); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByID() after delete error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+		exists, err := repo.ExistsBetweenUsers(ctx, requester, addressee)
+		if err != nil {
+			t.Fatalf("ExistsBetweenUsers() error = %v", err)
+		}
+		if exists {
+			t.Errorf("ExistsBetweenUsers() after delete = true, want false")
+		}
+	})
+}
+
+func newTestFriendship(t *testing.T, requester, addressee shared.UserID) *friendship.Friendship {
+	t.Helper()
+
+	f, err := friendship.NewFriendshipRequest(requester, addressee, "一起吃飯吧")
+	if err != nil {
+		t.Fatalf("NewFriendshipRequest() error = %v", err)
+	}
+	return f
+}
+
+func friendshipIDs(friendships ...*friendship.Friendship) []string {
+	ids := make([]string, len(friendships))
+	for i, f := range friendships {
+		ids[i] = f.ID.String()
+	}
+	return ids
+}
+
+func assertFriendshipEqual(t *testing.T, want, got *friendship.Friendship) {
+	t.Helper()
+
+	if got.ID != want.ID || got.RequesterID != want.RequesterID || got.AddresseeID != want.AddresseeID {
+		t.Errorf("identity = %v/%v/%v, want %v/%v/%v",
+			got.ID, got.RequesterID, got.AddresseeID, want.ID, want.RequesterID, want.AddresseeID)
+	}
+	if got.Status != want.Status {
+		t.Errorf("Status = %v, want %v", got.Status, want.Status)
+	}
+	if got.Message != want.Message {
+		t.Errorf("Message = %q, want %q", got.Message, want.Message)
+	}
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt, got.CreatedAt)
+	assertTimeEqual(t, "UpdatedAt", want.UpdatedAt, got.UpdatedAt)
+	assertOptionalTimeEqual(t, "AcceptedAt", want.AcceptedAt, got.AcceptedAt)
+}
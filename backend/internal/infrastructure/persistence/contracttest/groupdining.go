@@ -0,0 +1,690 @@
+package contracttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/groupdining/interfaces"
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/google/uuid"
+)
+
+// RunGroupDiningPlanRepositoryContract exercises a GroupDiningPlanRepository
+// implementation. newRepo must return an empty repository on every call.
+func RunGroupDiningPlanRepositoryContract(t *testing.T, newRepo func(t *testing.T) interfaces.GroupDiningPlanRepository) {
+	t.Run("create and get round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPlanEqual(t, plan, got)
+
+		if err := repo.Create(plan); err == nil {
+			t.Errorf("Create() duplicate error = nil, want error")
+		}
+	})
+
+	t.Run("get missing plan", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetByID(uuid.New().String())
+		if !errors.Is(err, aggregates.ErrPlanNotFound) {
+			t.Errorf("GetByID() error = %v, want group dining plan not found", err)
+		}
+	})
+
+	t.Run("update persists voting and confirmation", func(t *testing.T) {
+		repo := newRepo(t)
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		deadline := time.Now().Add(24 * time.Hour)
+		if err := plan.StartVoting(&deadline); err != nil {
+			t.Fatalf("StartVoting() error = %v", err)
+		}
+		slotID, optionID := plan.TimeSlots[1].ID, plan.RestaurantOptions[0].ID
+		recordVotes(t, plan, slotID, optionID, "guest-1")
+		if err := plan.ConfirmPlan(slotID, optionID); err != nil {
+			t.Fatalf("ConfirmPlan() error = %v", err)
+		}
+		if err := repo.Update(plan); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPlanEqual(t, plan, got)
+	})
+
+	t.Run("voting method, auto finalize settings and audit round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := plan.ConfigureVotingMethod(aggregates.VotingMethodBorda); err != nil {
+			t.Fatalf("ConfigureVotingMethod() error = %v", err)
+		}
+		if err := plan.ConfigureAutoFinalize(aggregates.TieBreakRandom, 42); err != nil {
+			t.Fatalf("ConfigureAutoFinalize() error = %v", err)
+		}
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if err := plan.StartVoting(nil); err != nil {
+			t.Fatalf("StartVoting() error = %v", err)
+		}
+		votes := recordVotes(t, plan, plan.TimeSlots[0].ID, plan.RestaurantOptions[0].ID, "creator-1", "guest-1")
+		if confirmed, err := plan.FinalizeAutomatically(aggregates.FinalizationTriggerAllVoted, votes, nil); err != nil || !confirmed {
+			t.Fatalf("FinalizeAutomatically() = %v, %v, want true", confirmed, err)
+		}
+		if err := repo.Update(plan); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPlanEqual(t, plan, got)
+	})
+
+	t.Run("update and delete missing plan", func(t *testing.T) {
+		repo := newRepo(t)
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := repo.Update(plan); !errors.Is(err, aggregates.ErrPlanNotFound) {
+			t.Errorf("Update() error = %v, want group dining plan not found", err)
+		}
+		if err := repo.Delete(plan.ID); !errors.Is(err, aggregates.ErrPlanNotFound) {
+			t.Errorf("Delete() error = %v, want group dining plan not found", err)
+		}
+	})
+
+	t.Run("update of a stale plan is rejected", func(t *testing.T) {
+		repo := newRepo(t)
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		// 兩個請求都在對方儲存前讀取計畫，後儲存的一方不能覆蓋先儲存的變更
+		first, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		second, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if err := first.AddParticipant("guest-2", "Guest 2"); err != nil {
+			t.Fatalf("AddParticipant() error = %v", err)
+		}
+		if err := second.AddParticipant("guest-3", "Guest 3"); err != nil {
+			t.Fatalf("AddParticipant() error = %v", err)
+		}
+		if err := repo.Update(first); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if err := repo.Update(second); !errors.Is(err, aggregates.ErrPlanModified) {
+			t.Errorf("Update() of stale plan error = %v, want %v", err, aggregates.ErrPlanModified)
+		}
+
+		// 儲存後的計畫可以繼續更新
+		if err := first.AddParticipant("guest-4", "Guest 4"); err != nil {
+			t.Fatalf("AddParticipant() error = %v", err)
+		}
+		if err := repo.Update(first); err != nil {
+			t.Fatalf("Update() after save error = %v", err)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPlanEqual(t, first, got)
+	})
+
+	t.Run("queries by creator and participant", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newTestPlan(t, "creator-1", "guest-1")
+		second := newTestPlan(t, "creator-1", "guest-2")
+		other := newTestPlan(t, "creator-2", "guest-1")
+		for _, plan := range []*aggregates.GroupDiningPlan{first, second, other} {
+			if err := repo.Create(plan); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		byCreator, err := repo.GetByCreator("creator-1")
+		if err != nil {
+			t.Fatalf("GetByCreator() error = %v", err)
+		}
+		assertSameIDs(t, "GetByCreator", planIDs(first, second), planIDs(byCreator...))
+
+		byParticipant, err := repo.GetByParticipant("guest-1", nil)
+		if err != nil {
+			t.Fatalf("GetByParticipant() error = %v", err)
+		}
+		assertSameIDs(t, "GetByParticipant", planIDs(first, other), planIDs(byParticipant...))
+
+		byParticipant, err = repo.GetByParticipant("guest-1", []string{"creator-2"})
+		if err != nil {
+			t.Fatalf("GetByParticipant(excluded) error = %v", err)
+		}
+		assertSameIDs(t, "GetByParticipant(excluded)", planIDs(first), planIDs(byParticipant...))
+
+		listed, err := repo.List(2, 0)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(listed) != 2 {
+			t.Errorf("List(2, 0) returned %d plans, want 2", len(listed))
+		}
+
+		if err := repo.Delete(second.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		byCreator, err = repo.GetByCreator("creator-1")
+		if err != nil {
+			t.Fatalf("GetByCreator() error = %v", err)
+		}
+		assertSameIDs(t, "GetByCreator after delete", planIDs(first), planIDs(byCreator...))
+	})
+
+	t.Run("invitations and invite links round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		if err := plan.InviteParticipant("creator-1", "invitee-1", "Invitee", now); err != nil {
+			t.Fatalf("InviteParticipant() error = %v", err)
+		}
+		if err := plan.InviteParticipant("creator-1", "decliner-1", "Decliner", now); err != nil {
+			t.Fatalf("InviteParticipant() error = %v", err)
+		}
+		if err := plan.RespondToInvitation("decliner-1", false, now); err != nil {
+			t.Fatalf("RespondToInvitation() error = %v", err)
+		}
+		link, err := plan.CreateInviteLink("creator-1", time.Hour, true, 5, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+		if err := plan.JoinWithInviteLink(link.ID, "guest-2", "Guest 2", now); err != nil {
+			t.Fatalf("JoinWithInviteLink() error = %v", err)
+		}
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPlanEqual(t, plan, got)
+
+		invited, err := repo.GetByInvitee("invitee-1", nil)
+		if err != nil {
+			t.Fatalf("GetByInvitee() error = %v", err)
+		}
+		assertSameIDs(t, "GetByInvitee", planIDs(plan), planIDs(invited...))
+		invited, err = repo.GetByInvitee("invitee-1", []string{"creator-1"})
+		if err != nil {
+			t.Fatalf("GetByInvitee(excluded) error = %v", err)
+		}
+		assertSameIDs(t, "GetByInvitee(excluded)", nil, planIDs(invited...))
+		for _, userID := range []string{"invitee-1", "decliner-1"} {
+			joined, err := repo.GetByParticipant(userID, nil)
+			if err != nil {
+				t.Fatalf("GetByParticipant() error = %v", err)
+			}
+			assertSameIDs(t, "GetByParticipant "+userID, nil, planIDs(joined...))
+		}
+		declined, err := repo.GetByInvitee("decliner-1", nil)
+		if err != nil {
+			t.Fatalf("GetByInvitee() error = %v", err)
+		}
+		assertSameIDs(t, "GetByInvitee declined", nil, planIDs(declined...))
+
+		if err := plan.RespondToInvitation("invitee-1", true, now); err != nil {
+			t.Fatalf("RespondToInvitation() error = %v", err)
+		}
+		if err := repo.Update(plan); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		invited, err = repo.GetByInvitee("invitee-1", nil)
+		if err != nil {
+			t.Fatalf("GetByInvitee() error = %v", err)
+		}
+		assertSameIDs(t, "GetByInvitee after accept", nil, planIDs(invited...))
+		joined, err := repo.GetByParticipant("invitee-1", nil)
+		if err != nil {
+			t.Fatalf("GetByParticipant() error = %v", err)
+		}
+		assertSameIDs(t, "GetByParticipant after accept", planIDs(plan), planIDs(joined...))
+	})
+
+	t.Run("concurrent invite link redemptions respect link limits", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+		plan := newTestPlan(t, "creator-1", "guest-1")
+		singleUse, err := plan.CreateInviteLink("creator-1", time.Hour, true, 0, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+		limited, err := plan.CreateInviteLink("creator-1", time.Hour, false, 4, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+		open, err := plan.CreateInviteLink("creator-1", time.Hour, false, 0, now)
+		if err != nil {
+			t.Fatalf("CreateInviteLink() error = %v", err)
+		}
+		if err := repo.Create(plan); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		// 兩個請求都在對方儲存前讀取計畫，各自的檢查都會通過
+		redeemBoth := func(linkID, firstUser, secondUser string) (error, error) {
+			t.Helper()
+			first, err := repo.GetByID(plan.ID)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			second, err := repo.GetByID(plan.ID)
+			if err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if err := first.JoinWithInviteLink(linkID, firstUser, firstUser, now); err != nil {
+				t.Fatalf("JoinWithInviteLink(%s) error = %v", firstUser, err)
+			}
+			if err := second.JoinWithInviteLink(linkID, secondUser, secondUser, now); err != nil {
+				t.Fatalf("JoinWithInviteLink(%s) error = %v", secondUser, err)
+			}
+			return repo.RedeemInviteLink(first, linkID, firstUser), repo.RedeemInviteLink(second, linkID, secondUser)
+		}
+
+		firstErr, secondErr := redeemBoth(singleUse.ID, "guest-2", "guest-3")
+		if firstErr != nil || !errors.Is(secondErr, aggregates.ErrInviteLinkUsed) {
+			t.Errorf("single-use link redeemed twice: errors = %v, %v, want nil, %v", firstErr, secondErr, aggregates.ErrInviteLinkUsed)
+		}
+		firstErr, secondErr = redeemBoth(limited.ID, "guest-4", "guest-5")
+		if firstErr != nil || !errors.Is(secondErr, aggregates.ErrPlanFull) {
+			t.Errorf("limited link over capacity: errors = %v, %v, want nil, %v", firstErr, secondErr, aggregates.ErrPlanFull)
+		}
+		firstErr, secondErr = redeemBoth(open.ID, "guest-6", "guest-7")
+		if firstErr != nil || secondErr != nil {
+			t.Errorf("open link redeemed by two users: errors = %v, %v, want nil", firstErr, secondErr)
+		}
+
+		got, err := repo.GetByID(plan.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		var joined []string
+		for _, participant := range got.AcceptedParticipants() {
+			joined = append(joined, participant.UserID)
+		}
+		assertSameIDs(t, "AcceptedParticipants", []string{"creator-1", "guest-1", "guest-2", "guest-4", "guest-6", "guest-7"}, joined)
+		wantUses := map[string]int{singleUse.ID: 1, limited.ID: 1, open.ID: 2}
+		for _, link := range got.InviteLinks {
+			if link.UseCount != wantUses[link.ID] {
+				t.Errorf("InviteLinks[%s].UseCount = %d, want %d", link.ID, link.UseCount, wantUses[link.ID])
+			}
+		}
+
+		// 以連結加入也會遞增版本，加入前讀取的計畫不能覆蓋新加入的參與者
+		if err := repo.Update(plan); !errors.Is(err, aggregates.ErrPlanModified) {
+			t.Errorf("Update() of plan read before redemptions error = %v, want %v", err, aggregates.ErrPlanModified)
+		}
+	})
+
+	t.Run("scheduler queries by deadline and confirmed start", func(t *testing.T) {
+		repo := newRepo(t)
+		now := time.Now()
+
+		overdue := newTestPlan(t, "creator-1", "guest-1")
+		pastDeadline := now.Add(-time.Minute)
+		if err := overdue.StartVoting(&pastDeadline); err != nil {
+			t.Fatalf("StartVoting() error = %v", err)
+		}
+		stillOpen := newTestPlan(t, "creator-1", "guest-2")
+		futureDeadline := now.Add(time.Hour)
+		if err := stillOpen.StartVoting(&futureDeadline); err != nil {
+			t.Fatalf("StartVoting() error = %v", err)
+		}
+		confirmed := newTestPlan(t, "creator-2", "guest-1")
+		if err := confirmed.StartVoting(nil); err != nil {
+			t.Fatalf("StartVoting() error = %v", err)
+		}
+		if err := confirmed.ConfirmPlan(confirmed.TimeSlots[0].ID, confirmed.RestaurantOptions[0].ID); err != nil {
+			t.Fatalf("ConfirmPlan() error = %v", err)
+		}
+		for _, plan := range []*aggregates.GroupDiningPlan{overdue, stillOpen, confirmed} {
+			if err := repo.Create(plan); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		pastDue, err := repo.GetVotingPastDeadline(now)
+		if err != nil {
+			t.Fatalf("GetVotingPastDeadline() error = %v", err)
+		}
+		assertSameIDs(t, "GetVotingPastDeadline", planIDs(overdue), planIDs(pastDue...))
+
+		start := confirmed.ConfirmedTimeSlot.StartTime
+		starting, err := repo.GetConfirmedStartingBetween(start.Add(-30*time.Minute), start.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("GetConfirmedStartingBetween() error = %v", err)
+		}
+		assertSameIDs(t, "GetConfirmedStartingBetween", planIDs(confirmed), planIDs(starting...))
+
+		// 第二個時段未被確認，不應被選中
+		otherSlot := confirmed.TimeSlots[1].StartTime
+		starting, err = repo.GetConfirmedStartingBetween(otherSlot.Add(-30*time.Minute), otherSlot.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("GetConfirmedStartingBetween() error = %v", err)
+		}
+		assertSameIDs(t, "GetConfirmedStartingBetween other slot", nil, planIDs(starting...))
+	})
+}
+
+// RunVoteRepositoryContract exercises a VoteRepository implementation.
+// newRepo must return an empty repository on every call.
+func RunVoteRepositoryContract(t *testing.T, newRepo func(t *testing.T) interfaces.VoteRepository) {
+	t.Run("create and get round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		vote := newTestVote(t, uuid.New().String(), "guest-1")
+		if err := repo.Create(vote); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(vote.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertVoteEqual(t, vote, got)
+
+		got, err = repo.GetByPlanAndUser(vote.PlanID, vote.UserID)
+		if err != nil {
+			t.Fatalf("GetByPlanAndUser() error = %v", err)
+		}
+		assertVoteEqual(t, vote, got)
+
+		if err := repo.Create(vote); err == nil {
+			t.Errorf("Create() duplicate error = nil, want error")
+		}
+	})
+
+	t.Run("get missing vote", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(uuid.New().String()); !errors.Is(err, aggregates.ErrVoteNotFound) {
+			t.Errorf("GetByID() error = %v, want vote not found", err)
+		}
+		if _, err := repo.GetByPlanAndUser(uuid.New().String(), "guest-1"); !errors.Is(err, aggregates.ErrVoteNotFound) {
+			t.Errorf("GetByPlanAndUser() error = %v, want vote not found", err)
+		}
+	})
+
+	t.Run("update replaces choices", func(t *testing.T) {
+		repo := newRepo(t)
+		vote := newTestVote(t, uuid.New().String(), "guest-1")
+		if err := repo.Create(vote); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		vote.Choices = vote.Choices[:1]
+		if err := vote.AddRestaurantChoice(uuid.New().String()); err != nil {
+			t.Fatalf("AddRestaurantChoice() error = %v", err)
+		}
+		if err := vote.SetScore(vote.Choices[1].OptionID, 4); err != nil {
+			t.Fatalf("SetScore() error = %v", err)
+		}
+		vote.SetComment("改投別家")
+		if err := repo.Update(vote); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(vote.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertVoteEqual(t, vote, got)
+	})
+
+	t.Run("get by plan and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		planID := uuid.New().String()
+		first := newTestVote(t, planID, "guest-1")
+		second := newTestVote(t, planID, "guest-2")
+		other := newTestVote(t, uuid.New().String(), "guest-1")
+		for _, vote := range []*aggregates.Vote{first, second, other} {
+			if err := repo.Create(vote); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		byPlan, err := repo.GetByPlan(planID)
+		if err != nil {
+			t.Fatalf("GetByPlan() error = %v", err)
+		}
+		assertSameIDs(t, "GetByPlan", voteIDs(first, second), voteIDs(byPlan...))
+
+		if err := repo.Delete(first.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(first.ID); !errors.Is(err, aggregates.ErrVoteNotFound) {
+			t.Errorf("GetByID() after delete error = %v, want vote not found", err)
+		}
+		if err := repo.Delete(first.ID); !errors.Is(err, aggregates.ErrVoteNotFound) {
+			t.Errorf("Delete() twice error = %v, want vote not found", err)
+		}
+		if err := repo.Update(first); !errors.Is(err, aggregates.ErrVoteNotFound) {
+			t.Errorf("Update() missing error = %v, want vote not found", err)
+		}
+	})
+}
+
+func newTestPlan(t *testing.T, creator, guest string) *aggregates.GroupDiningPlan {
+	t.Helper()
+
+	plan, err := aggregates.NewGroupDiningPlan(creator, "Creator", "週五聚餐", "同事聚餐")
+	if err != nil {
+		t.Fatalf("NewGroupDiningPlan() error = %v", err)
+	}
+
+	start := time.Now().Add(48 * time.Hour)
+	for i := 0; i < 2; i++ {
+		slotStart := start.Add(time.Duration(i) * 24 * time.Hour)
+		if err := plan.AddTimeSlot(slotStart, slotStart.Add(2*time.Hour), "晚餐"); err != nil {
+			t.Fatalf("AddTimeSlot() error = %v", err)
+		}
+	}
+	if err := plan.AddRestaurantOption("鼎泰豐", "台北市信義區", 25.0330, 121.5654, "taiwanese"); err != nil {
+		t.Fatalf("AddRestaurantOption() error = %v", err)
+	}
+	if err := plan.AddRestaurantOption("欣葉", "台北市中山區", 25.0478, 121.5319, "japanese"); err != nil {
+		t.Fatalf("AddRestaurantOption() error = %v", err)
+	}
+	if err := plan.AddParticipant(guest, "Guest"); err != nil {
+		t.Fatalf("AddParticipant() error = %v", err)
+	}
+	return plan
+}
+
+// recordVotes lets each user vote for the same time slot and restaurant in turn and returns the votes
+func recordVotes(t *testing.T, plan *aggregates.GroupDiningPlan, slotID, optionID string, userIDs ...string) []*aggregates.Vote {
+	t.Helper()
+
+	var votes []*aggregates.Vote
+	for _, userID := range userIDs {
+		vote, err := aggregates.NewVote(plan.ID, userID)
+		if err != nil {
+			t.Fatalf("NewVote() error = %v", err)
+		}
+		if err := vote.AddTimeChoice(slotID); err != nil {
+			t.Fatalf("AddTimeChoice() error = %v", err)
+		}
+		if err := vote.AddRestaurantChoice(optionID); err != nil {
+			t.Fatalf("AddRestaurantChoice() error = %v", err)
+		}
+		if err := plan.RecordVote(vote, votes); err != nil {
+			t.Fatalf("RecordVote() error = %v", err)
+		}
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+func newTestVote(t *testing.T, planID, userID string) *aggregates.Vote {
+	t.Helper()
+
+	vote, err := aggregates.NewVote(planID, userID)
+	if err != nil {
+		t.Fatalf("NewVote() error = %v", err)
+	}
+	if err := vote.AddTimeChoice(uuid.New().String()); err != nil {
+		t.Fatalf("AddTimeChoice() error = %v", err)
+	}
+	if err := vote.AddRestaurantChoice(uuid.New().String()); err != nil {
+		t.Fatalf("AddRestaurantChoice() error = %v", err)
+	}
+	vote.SetComment("都可以")
+	return vote
+}
+
+func planIDs(plans ...*aggregates.GroupDiningPlan) []string {
+	ids := make([]string, len(plans))
+	for i, plan := range plans {
+		ids[i] = plan.ID
+	}
+	return ids
+}
+
+func voteIDs(votes ...*aggregates.Vote) []string {
+	ids := make([]string, len(votes))
+	for i, vote := range votes {
+		ids[i] = vote.ID
+	}
+	return ids
+}
+
+func assertPlanEqual(t *testing.T, want, got *aggregates.GroupDiningPlan) {
+	t.Helper()
+
+	if got.ID != want.ID || got.CreatedBy != want.CreatedBy || got.Title != want.Title || got.Description != want.Description {
+		t.Errorf("plan = %s/%s/%q/%q, want %s/%s/%q/%q",
+			got.ID, got.CreatedBy, got.Title, got.Description, want.ID, want.CreatedBy, want.Title, want.Description)
+	}
+	if got.Status != want.Status || got.VotingMethod != want.VotingMethod {
+		t.Errorf("Status, VotingMethod = %v, %v, want %v, %v", got.Status, got.VotingMethod, want.Status, want.VotingMethod)
+	}
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt, got.CreatedAt)
+	assertTimeEqual(t, "UpdatedAt", want.UpdatedAt, got.UpdatedAt)
+	assertOptionalTimeEqual(t, "VotingDeadline", want.VotingDeadline, got.VotingDeadline)
+
+	if len(got.TimeSlots) != len(want.TimeSlots) {
+		t.Fatalf("TimeSlots = %d entries, want %d", len(got.TimeSlots), len(want.TimeSlots))
+	}
+	for i, w := range want.TimeSlots {
+		g := got.TimeSlots[i]
+		if g.ID != w.ID || g.Description != w.Description || g.VoteCount != w.VoteCount {
+			t.Errorf("TimeSlots[%d] = %+v, want %+v", i, g, w)
+		}
+		assertTimeEqual(t, "TimeSlots.StartTime", w.StartTime, g.StartTime)
+		assertTimeEqual(t, "TimeSlots.EndTime", w.EndTime, g.EndTime)
+	}
+
+	if len(got.RestaurantOptions) != len(want.RestaurantOptions) {
+		t.Fatalf("RestaurantOptions = %d entries, want %d", len(got.RestaurantOptions), len(want.RestaurantOptions))
+	}
+	for i, w := range want.RestaurantOptions {
+		if got.RestaurantOptions[i] != w {
+			t.Errorf("RestaurantOptions[%d] = %+v, want %+v", i, got.RestaurantOptions[i], w)
+		}
+	}
+
+	if len(got.Participants) != len(want.Participants) {
+		t.Fatalf("Participants = %d entries, want %d", len(got.Participants), len(want.Participants))
+	}
+	for i, w := range want.Participants {
+		g := got.Participants[i]
+		if g.UserID != w.UserID || g.DisplayName != w.DisplayName || g.HasVoted != w.HasVoted ||
+			g.Status.OrDefault() != w.Status.OrDefault() || g.InvitedBy != w.InvitedBy {
+			t.Errorf("Participants[%d] = %+v, want %+v", i, g, w)
+		}
+		assertTimeEqual(t, "Participants.JoinedAt", w.JoinedAt, g.JoinedAt)
+		assertOptionalTimeEqual(t, "Participants.RespondedAt", w.RespondedAt, g.RespondedAt)
+	}
+
+	if len(got.InviteLinks) != len(want.InviteLinks) {
+		t.Fatalf("InviteLinks = %d entries, want %d", len(got.InviteLinks), len(want.InviteLinks))
+	}
+	for i, w := range want.InviteLinks {
+		g := got.InviteLinks[i]
+		if g.ID != w.ID || g.CreatedBy != w.CreatedBy || g.SingleUse != w.SingleUse ||
+			g.MaxParticipants != w.MaxParticipants || g.UseCount != w.UseCount {
+			t.Errorf("InviteLinks[%d] = %+v, want %+v", i, g, w)
+		}
+		assertTimeEqual(t, "InviteLinks.CreatedAt", w.CreatedAt, g.CreatedAt)
+		assertTimeEqual(t, "InviteLinks.ExpiresAt", w.ExpiresAt, g.ExpiresAt)
+	}
+
+	switch {
+	case (want.ConfirmedTimeSlot == nil) != (got.ConfirmedTimeSlot == nil):
+		t.Errorf("ConfirmedTimeSlot = %v, want %v", got.ConfirmedTimeSlot, want.ConfirmedTimeSlot)
+	case want.ConfirmedTimeSlot != nil && got.ConfirmedTimeSlot.ID != want.ConfirmedTimeSlot.ID:
+		t.Errorf("ConfirmedTimeSlot.ID = %s, want %s", got.ConfirmedTimeSlot.ID, want.ConfirmedTimeSlot.ID)
+	}
+	switch {
+	case (want.ConfirmedRestaurant == nil) != (got.ConfirmedRestaurant == nil):
+		t.Errorf("ConfirmedRestaurant = %v, want %v", got.ConfirmedRestaurant, want.ConfirmedRestaurant)
+	case want.ConfirmedRestaurant != nil && got.ConfirmedRestaurant.ID != want.ConfirmedRestaurant.ID:
+		t.Errorf("ConfirmedRestaurant.ID = %s, want %s", got.ConfirmedRestaurant.ID, want.ConfirmedRestaurant.ID)
+	}
+
+	if got.AutoFinalize != want.AutoFinalize {
+		t.Errorf("AutoFinalize = %+v, want %+v", got.AutoFinalize, want.AutoFinalize)
+	}
+	switch {
+	case (want.Finalization == nil) != (got.Finalization == nil):
+		t.Errorf("Finalization = %+v, want %+v", got.Finalization, want.Finalization)
+	case want.Finalization != nil:
+		w, g := want.Finalization, got.Finalization
+		if g.Trigger != w.Trigger || g.TieBreak != w.TieBreak || g.Seed != w.Seed || g.DecidedBy != w.DecidedBy {
+			t.Errorf("Finalization = %+v, want %+v", g, w)
+		}
+		if g.TimeSlot.WinnerID != w.TimeSlot.WinnerID || g.TimeSlot.Method != w.TimeSlot.Method || g.TimeSlot.TopVotes != w.TimeSlot.TopVotes {
+			t.Errorf("Finalization.TimeSlot = %+v, want %+v", g.TimeSlot, w.TimeSlot)
+		}
+		if g.Restaurant.WinnerID != w.Restaurant.WinnerID || g.Restaurant.Method != w.Restaurant.Method || g.Restaurant.TopVotes != w.Restaurant.TopVotes {
+			t.Errorf("Finalization.Restaurant = %+v, want %+v", g.Restaurant, w.Restaurant)
+		}
+		assertTimeEqual(t, "Finalization.DecidedAt", w.DecidedAt, g.DecidedAt)
+	}
+}
+
+func assertVoteEqual(t *testing.T, want, got *aggregates.Vote) {
+	t.Helper()
+
+	if got.ID != want.ID || got.PlanID != want.PlanID || got.UserID != want.UserID || got.Comment != want.Comment {
+		t.Errorf("vote = %s/%s/%s/%q, want %s/%s/%s/%q",
+			got.ID, got.PlanID, got.UserID, got.Comment, want.ID, want.PlanID, want.UserID, want.Comment)
+	}
+	assertTimeEqual(t, "VotedAt", want.VotedAt, got.VotedAt)
+
+	if len(got.Choices) != len(want.Choices) {
+		t.Fatalf("Choices = %v, want %v", got.Choices, want.Choices)
+	}
+	for i := range want.Choices {
+		if got.Choices[i] != want.Choices[i] {
+			t.Errorf("Choices[%d] = %+v, want %+v", i, got.Choices[i], want.Choices[i])
+		}
+	}
+}
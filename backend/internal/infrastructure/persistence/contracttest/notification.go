@@ -0,0 +1,140 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunNotificationRepositoryContract exercises a notification.Repository
+// implementation. newRepo must return an empty repository on every call.
+func RunNotificationRepositoryContract(t *testing.T, newRepo func(t *testing.T) notification.Repository) {
+	ctx := context.Background()
+
+	t.Run("lists newest first with unread filter", func(t *testing.T) {
+		repo := newRepo(t)
+		owner, other := shared.NewUserID(), shared.NewUserID()
+
+		older := saveTestNotification(t, repo, owner, "older", time.Now().Add(-time.Hour))
+		newer := saveTestNotification(t, repo, owner, "newer", time.Now())
+		saveTestNotification(t, repo, other, "someone else", time.Now())
+
+		got, err := repo.FindByUser(ctx, owner, false, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByUser() error = %v", err)
+		}
+		if len(got) != 2 || got[0].ID != newer.ID || got[1].ID != older.ID {
+			t.Fatalf("FindByUser() = %v, want [%s %s]", notificationIDs(got), newer.ID, older.ID)
+		}
+		if got[0].Data["pingId"] != "ping-1" || got[0].Type != notification.TypePingInvite || got[0].IsRead() {
+			t.Errorf("FindByUser()[0] = %+v", got[0])
+		}
+
+		if page, err := repo.FindByUser(ctx, owner, false, 1, 1); err != nil || len(page) != 1 || page[0].ID != older.ID {
+			t.Errorf("FindByUser(limit 1, offset 1) = %v, %v, want [%s]", notificationIDs(page), err, older.ID)
+		}
+
+		if err := repo.MarkRead(ctx, owner, newer.ID, time.Now()); err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		unread, err := repo.FindByUser(ctx, owner, true, 10, 0)
+		if err != nil || len(unread) != 1 || unread[0].ID != older.ID {
+			t.Errorf("FindByUser(unread) = %v, %v, want [%s]", notificationIDs(unread), err, older.ID)
+		}
+	})
+
+	t.Run("duplicate dedup key is rejected per user", func(t *testing.T) {
+		repo := newRepo(t)
+		owner := shared.NewUserID()
+
+		first, _ := notification.NewNotification(owner, notification.TypeFriendRequest, "Friend request", "", nil, "event-1")
+		if err := repo.Save(ctx, first); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		again, _ := notification.NewNotification(owner, notification.TypeFriendRequest, "Friend request", "", nil, "event-1")
+		if err := repo.Save(ctx, again); !errors.Is(err, shared.ErrDuplicateNotification) {
+			t.Errorf("Save() duplicate error = %v, want %v", err, shared.ErrDuplicateNotification)
+		}
+
+		// 同一事件通知不同用戶不算重複，沒有 dedup key 的通知也不受限制
+		otherUser, _ := notification.NewNotification(shared.NewUserID(), notification.TypeFriendRequest, "Friend request", "", nil, "event-1")
+		if err := repo.Save(ctx, otherUser); err != nil {
+			t.Errorf("Save() other user error = %v", err)
+		}
+		for i := 0; i < 2; i++ {
+			noKey, _ := notification.NewNotification(owner, notification.TypeFriendRequest, "Friend request", "", nil, "")
+			if err := repo.Save(ctx, noKey); err != nil {
+				t.Errorf("Save() without dedup key error = %v", err)
+			}
+		}
+	})
+
+	t.Run("mark read and unread count", func(t *testing.T) {
+		repo := newRepo(t)
+		owner, other := shared.NewUserID(), shared.NewUserID()
+		first := saveTestNotification(t, repo, owner, "first", time.Now())
+		saveTestNotification(t, repo, owner, "second", time.Now())
+		saveTestNotification(t, repo, owner, "third", time.Now())
+
+		assertUnread(t, repo, owner, 3)
+
+		if err := repo.MarkRead(ctx, other, first.ID, time.Now()); !errors.Is(err, shared.ErrNotificationNotFound) {
+			t.Errorf("MarkRead() by other user error = %v, want %v", err, shared.ErrNotificationNotFound)
+		}
+		if err := repo.MarkRead(ctx, owner, shared.NewID(), time.Now()); !errors.Is(err, shared.ErrNotificationNotFound) {
+			t.Errorf("MarkRead() missing error = %v, want %v", err, shared.ErrNotificationNotFound)
+		}
+
+		if err := repo.MarkRead(ctx, owner, first.ID, time.Now()); err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		// 重複標記已讀不視為錯誤
+		if err := repo.MarkRead(ctx, owner, first.ID, time.Now()); err != nil {
+			t.Errorf("MarkRead() again error = %v", err)
+		}
+		assertUnread(t, repo, owner, 2)
+
+		if updated, err := repo.MarkAllRead(ctx, owner, time.Now()); err != nil || updated != 2 {
+			t.Errorf("MarkAllRead() = %d, %v, want 2", updated, err)
+		}
+		assertUnread(t, repo, owner, 0)
+	})
+}
+
+func saveTestNotification(t *testing.T, repo notification.Repository, userID shared.UserID, title string, createdAt time.Time) *notification.Notification {
+	t.Helper()
+
+	n, err := notification.NewNotification(userID, notification.TypePingInvite, title, "body", map[string]string{"pingId": "ping-1"}, shared.NewID().String())
+	if err != nil {
+		t.Fatalf("NewNotification() error = %v", err)
+	}
+	n.CreatedAt = createdAt.Truncate(time.Microsecond)
+	if err := repo.Save(context.Background(), n); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	return n
+}
+
+func assertUnread(t *testing.T, repo notification.Repository, userID shared.UserID, want int64) {
+	t.Helper()
+
+	got, err := repo.CountUnread(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("CountUnread() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CountUnread() = %d, want %d", got, want)
+	}
+}
+
+func notificationIDs(notifications []*notification.Notification) []shared.ID {
+	ids := make([]shared.ID, len(notifications))
+	for i, n := range notifications {
+		ids[i] = n.ID
+	}
+	return ids
+}
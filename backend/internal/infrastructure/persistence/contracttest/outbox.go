@@ -0,0 +1,157 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunOutboxContract exercises an events.OutboxStore together with a
+// ping.Repository writing to it. newFixture must return an empty outbox and a
+// repository that appends the events of saved aggregates to that outbox.
+func RunOutboxContract(t *testing.T, newFixture func(t *testing.T) (ping.Repository, events.OutboxStore)) {
+	ctx := context.Background()
+
+	t.Run("repository writes recorded events in order", func(t *testing.T) {
+		repo, store := newFixture(t)
+		invitee := shared.NewUserID()
+		p := createEventedPing(t, repo, invitee)
+
+		if err := p.RespondToPing(invitee, ping.ResponseStatusAccepted, "see you"); err != nil {
+			t.Fatalf("RespondToPing() error = %v", err)
+		}
+		if err := repo.Update(ctx, p); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if pending := p.PendingEvents(); len(pending) != 0 {
+			t.Errorf("PendingEvents() after save = %d events, want 0", len(pending))
+		}
+
+		messages, err := store.ClaimPending(ctx, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("ClaimPending() error = %v", err)
+		}
+		if len(messages) != 2 || messages[0].EventType != ping.EventPingCreated || messages[1].EventType != ping.EventPingResponded {
+			t.Fatalf("ClaimPending() = %+v, want [%s %s]", messages, ping.EventPingCreated, ping.EventPingResponded)
+		}
+		for _, message := range messages {
+			if message.AggregateID != p.ID().String() || message.Attempts != 0 {
+				t.Errorf("message %s = %+v, want aggregate %s and no attempts", message.EventType, message, p.ID())
+			}
+		}
+
+		var responded ping.PingResponded
+		if err := messages[1].Decode(&responded); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if responded.UserID != invitee || responded.Status != ping.ResponseStatusAccepted || responded.AcceptedCount != 1 {
+			t.Errorf("decoded payload = %+v", responded)
+		}
+
+		// 已被取出的事件在 lease 期間不會再被取出
+		again, err := store.ClaimPending(ctx, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("ClaimPending() again error = %v", err)
+		}
+		if len(again) != 0 {
+			t.Errorf("ClaimPending() during lease = %d messages, want 0", len(again))
+		}
+	})
+
+	t.Run("claim respects limit", func(t *testing.T) {
+		repo, store := newFixture(t)
+		for i := 0; i < 3; i++ {
+			createEventedPing(t, repo, shared.NewUserID())
+		}
+
+		messages, err := store.ClaimPending(ctx, 2, time.Minute)
+		if err != nil {
+			t.Fatalf("ClaimPending() error = %v", err)
+		}
+		if len(messages) != 2 {
+			t.Errorf("ClaimPending(limit 2) = %d messages, want 2", len(messages))
+		}
+	})
+
+	t.Run("dispatched events are not claimed again and can be purged", func(t *testing.T) {
+		repo, store := newFixture(t)
+		createEventedPing(t, repo, shared.NewUserID())
+
+		// 負的 lease 讓鎖定立即過期，確認是 dispatched 狀態本身阻止重複派送
+		messages, err := store.ClaimPending(ctx, 10, -time.Second)
+		if err != nil || len(messages) != 1 {
+			t.Fatalf("ClaimPending() = %d messages, %v, want 1", len(messages), err)
+		}
+		if err := store.MarkDispatched(ctx, messages[0].ID, time.Now()); err != nil {
+			t.Fatalf("MarkDispatched() error = %v", err)
+		}
+		if err := store.MarkDispatched(ctx, shared.NewID().String(), time.Now()); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("MarkDispatched() missing error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+
+		if again, err := store.ClaimPending(ctx, 10, time.Minute); err != nil || len(again) != 0 {
+			t.Errorf("ClaimPending() after dispatch = %d messages, %v, want none", len(again), err)
+		}
+
+		if purged, err := store.PurgeDispatched(ctx, time.Now().Add(-time.Hour)); err != nil || purged != 0 {
+			t.Errorf("PurgeDispatched(an hour ago) = %d, %v, want 0", purged, err)
+		}
+		if purged, err := store.PurgeDispatched(ctx, time.Now().Add(time.Second)); err != nil || purged != 1 {
+			t.Errorf("PurgeDispatched(now) = %d, %v, want 1", purged, err)
+		}
+	})
+
+	t.Run("failed events are retried until given up", func(t *testing.T) {
+		repo, store := newFixture(t)
+		createEventedPing(t, repo, shared.NewUserID())
+
+		messages, err := store.ClaimPending(ctx, 10, time.Hour)
+		if err != nil || len(messages) != 1 {
+			t.Fatalf("ClaimPending() = %d messages, %v, want 1", len(messages), err)
+		}
+
+		retryAt := time.Now().Add(-time.Second)
+		if err := store.MarkFailed(ctx, messages[0].ID, "subscriber unavailable", &retryAt); err != nil {
+			t.Fatalf("MarkFailed() error = %v", err)
+		}
+		retried, err := store.ClaimPending(ctx, 10, time.Hour)
+		if err != nil || len(retried) != 1 {
+			t.Fatalf("ClaimPending() after failure = %d messages, %v, want 1", len(retried), err)
+		}
+		if retried[0].Attempts != 1 {
+			t.Errorf("Attempts = %d, want 1", retried[0].Attempts)
+		}
+
+		// 放棄後即使已到可重試時間也不會再被取出
+		if err := store.MarkFailed(ctx, retried[0].ID, "gave up", nil); err != nil {
+			t.Fatalf("MarkFailed() give up error = %v", err)
+		}
+		if pending, err := store.ClaimPending(ctx, 10, time.Hour); err != nil || len(pending) != 0 {
+			t.Errorf("ClaimPending() after giving up = %d messages, %v, want none", len(pending), err)
+		}
+
+		if err := store.MarkFailed(ctx, shared.NewID().String(), "missing", nil); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("MarkFailed() missing error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+}
+
+// createEventedPing saves a freshly created ping so its PingCreated event is
+// written to the outbox
+func createEventedPing(t *testing.T, repo ping.Repository, invitee shared.UserID) *ping.Ping {
+	t.Helper()
+
+	p, err := ping.NewPing(shared.NewUserID(), "午餐", "一起吃飯", ping.PingTypeLunch, time.Now().Add(time.Hour), []shared.UserID{invitee})
+	if err != nil {
+		t.Fatalf("NewPing() error = %v", err)
+	}
+	if err := repo.Create(context.Background(), p); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return p
+}
@@ -0,0 +1,306 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunPingRepositoryContract exercises a ping.Repository implementation.
+// newRepo must return an empty repository on every call.
+func RunPingRepositoryContract(t *testing.T, newRepo func(t *testing.T) ping.Repository) {
+	ctx := context.Background()
+
+	t.Run("create and get round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		creator := shared.NewUserID()
+		invitees := []shared.UserID{shared.NewUserID(), shared.NewUserID()}
+		p := newTestPing(t, creator, invitees, time.Now().Add(2*time.Hour), time.Now())
+		p.SetLocation(&shared.Location{Latitude: 25.033, Longitude: 121.5654, Address: "台北市信義區"})
+
+		if err := repo.Create(ctx, p); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, p.ID())
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPingEqual(t, p, got)
+	})
+
+	t.Run("get missing ping", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(ctx, shared.NewID()); !errors.Is(err, shared.ErrPingNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, shared.ErrPingNotFound)
+		}
+	})
+
+	t.Run("update persists responses and status", func(t *testing.T) {
+		repo := newRepo(t)
+		invitee := shared.NewUserID()
+		p := newTestPing(t, shared.NewUserID(), []shared.UserID{invitee}, time.Now().Add(time.Hour), time.Now())
+		if err := repo.Create(ctx, p); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if err := p.RespondToPing(invitee, ping.ResponseStatusAccepted, "see you"); err != nil {
+			t.Fatalf("RespondToPing() error = %v", err)
+		}
+		if err := p.Complete(); err != nil {
+			t.Fatalf("Complete() error = %v", err)
+		}
+		if err := repo.Update(ctx, p); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, p.ID())
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertPingEqual(t, p, got)
+	})
+
+	t.Run("update missing ping", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newTestPing(t, shared.NewUserID(), []shared.UserID{shared.NewUserID()}, time.Now().Add(time.Hour), time.Now())
+		if err := repo.Update(ctx, p); !errors.Is(err, shared.ErrPingNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, shared.ErrPingNotFound)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := newRepo(t)
+		p := newTestPing(t, shared.NewUserID(), []shared.UserID{shared.NewUserID()}, time.Now().Add(time.Hour), time.Now())
+		if err := repo.Create(ctx, p); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if err := repo.Delete(ctx, p.ID()); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.GetByID(ctx, p.ID()); !errors.Is(err, shared.ErrPingNotFound) {
+			t.Errorf("GetByID() after delete error = %v, want %v", err, shared.ErrPingNotFound)
+		}
+		if err := repo.Delete(ctx, p.ID()); !errors.Is(err, shared.ErrPingNotFound) {
+			t.Errorf("Delete() twice error = %v, want %v", err, shared.ErrPingNotFound)
+		}
+	})
+
+	t.Run("queries by creator invitee and status", func(t *testing.T) {
+		repo := newRepo(t)
+		creator := shared.NewUserID()
+		invitee := shared.NewUserID()
+		base := time.Now().Add(-time.Hour)
+
+		// 建立時間遞增、預定時間遞減，用於驗證兩種排序
+		oldest := newTestPing(t, creator, []shared.UserID{invitee}, time.Now().Add(3*time.Hour), base)
+		middle := newTestPing(t, creator, []shared.UserID{invitee}, time.Now().Add(2*time.Hour), base.Add(time.Minute))
+		newest := newTestPing(t, creator, []shared.UserID{shared.NewUserID()}, time.Now().Add(time.Hour), base.Add(2*time.Minute))
+		if err := middle.Cancel(); err != nil {
+			t.Fatalf("Cancel() error = %v", err)
+		}
+		for _, p := range []*ping.Ping{oldest, middle, newest} {
+			if err := repo.Create(ctx, p); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		byCreator, err := repo.GetByCreator(ctx, creator, 10, 0)
+		if err != nil {
+			t.Fatalf("GetByCreator() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetByCreator", pingIDs(newest, middle, oldest), pingIDs(byCreator...))
+
+		paged, err := repo.GetByCreator(ctx, creator, 1, 1)
+		if err != nil {
+			t.Fatalf("GetByCreator() paged error = %v", err)
+		}
+		assertOrderedIDs(t, "GetByCreator paged", pingIDs(middle), pingIDs(paged...))
+
+		byInvitee, err := repo.GetByInvitee(ctx, invitee, 10, 0)
+		if err != nil {
+			t.Fatalf("GetByInvitee() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetByInvitee", pingIDs(middle, oldest), pingIDs(byInvitee...))
+
+		active, err := repo.GetActivePings(ctx, invitee, nil, 10, 0)
+		if err != nil {
+			t.Fatalf("GetActivePings() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetActivePings invitee", pingIDs(oldest), pingIDs(active...))
+
+		active, err = repo.GetActivePings(ctx, creator, nil, 10, 0)
+		if err != nil {
+			t.Fatalf("GetActivePings() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetActivePings creator", pingIDs(newest, oldest), pingIDs(active...))
+
+		cancelled, err := repo.GetPingsByStatus(ctx, ping.PingStatusCancelled, 10, 0)
+		if err != nil {
+			t.Fatalf("GetPingsByStatus() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetPingsByStatus", pingIDs(middle), pingIDs(cancelled...))
+
+		scheduled, err := repo.GetScheduledBetween(ctx, ping.PingStatusActive, time.Now(), time.Now().Add(4*time.Hour))
+		if err != nil {
+			t.Fatalf("GetScheduledBetween() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetScheduledBetween", pingIDs(newest, oldest), pingIDs(scheduled...))
+
+		scheduled, err = repo.GetScheduledBetween(ctx, ping.PingStatusActive, time.Now(), oldest.ScheduledAt())
+		if err != nil {
+			t.Fatalf("GetScheduledBetween() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetScheduledBetween excludes upper bound", pingIDs(newest), pingIDs(scheduled...))
+
+		// 不分狀態，包含已取消的 ping
+		upcoming, err := repo.GetUpcomingForUser(ctx, invitee, time.Now())
+		if err != nil {
+			t.Fatalf("GetUpcomingForUser() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetUpcomingForUser invitee", pingIDs(middle, oldest), pingIDs(upcoming...))
+
+		upcoming, err = repo.GetUpcomingForUser(ctx, creator, middle.ScheduledAt())
+		if err != nil {
+			t.Fatalf("GetUpcomingForUser() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetUpcomingForUser creator", pingIDs(middle, oldest), pingIDs(upcoming...))
+	})
+
+	t.Run("active pings exclude creators before paging", func(t *testing.T) {
+		repo := newRepo(t)
+		invitee := shared.NewUserID()
+		blocked := shared.NewUserID()
+		base := time.Now().Add(-time.Hour)
+
+		earliest := newTestPing(t, shared.NewUserID(), []shared.UserID{invitee}, time.Now().Add(time.Hour), base)
+		fromBlocked := newTestPing(t, blocked, []shared.UserID{invitee}, time.Now().Add(2*time.Hour), base)
+		latest := newTestPing(t, shared.NewUserID(), []shared.UserID{invitee}, time.Now().Add(3*time.Hour), base)
+		for _, p := range []*ping.Ping{earliest, fromBlocked, latest} {
+			if err := repo.Create(ctx, p); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		// 第二頁不應因為排除的 ping 而變短
+		page, err := repo.GetActivePings(ctx, invitee, []shared.UserID{blocked}, 1, 1)
+		if err != nil {
+			t.Fatalf("GetActivePings(excluded, limit 1, offset 1) error = %v", err)
+		}
+		assertOrderedIDs(t, "GetActivePings(excluded, limit 1, offset 1)", pingIDs(latest), pingIDs(page...))
+
+		all, err := repo.GetActivePings(ctx, invitee, []shared.UserID{blocked}, 10, 0)
+		if err != nil {
+			t.Fatalf("GetActivePings(excluded) error = %v", err)
+		}
+		assertOrderedIDs(t, "GetActivePings(excluded)", pingIDs(earliest, latest), pingIDs(all...))
+	})
+
+}
+
+// newTestPing builds a valid ping and then rewrites its creation time so
+// ordering can be asserted deterministically
+func newTestPing(t *testing.T, creator shared.UserID, invitees []shared.UserID, scheduledAt, createdAt time.Time) *ping.Ping {
+	t.Helper()
+
+	p, err := ping.NewPing(creator, "午餐", "一起吃飯", ping.PingTypeLunch, scheduledAt, invitees)
+	if err != nil {
+		t.Fatalf("NewPing() error = %v", err)
+	}
+
+	return ping.ReconstructPing(
+		p.ID(),
+		p.CreatedBy(),
+		p.Title(),
+		p.Description(),
+		p.PingType(),
+		p.Status(),
+		p.ScheduledAt(),
+		p.Location(),
+		p.Responses(),
+		p.Invitees(),
+		p.Sequence(),
+		p.Occurrence(),
+		createdAt,
+		createdAt,
+	)
+}
+
+func pingIDs(pings ...*ping.Ping) []string {
+	ids := make([]string, len(pings))
+	for i, p := range pings {
+		ids[i] = p.ID().String()
+	}
+	return ids
+}
+
+func assertPingEqual(t *testing.T, want, got *ping.Ping) {
+	t.Helper()
+
+	if got.ID() != want.ID() {
+		t.Errorf("ID = %v, want %v", got.ID(), want.ID())
+	}
+	if got.CreatedBy() != want.CreatedBy() {
+		t.Errorf("CreatedBy = %v, want %v", got.CreatedBy(), want.CreatedBy())
+	}
+	if got.Title() != want.Title() || got.Description() != want.Description() {
+		t.Errorf("Title/Description = %q/%q, want %q/%q", got.Title(), got.Description(), want.Title(), want.Description())
+	}
+	if got.PingType() != want.PingType() {
+		t.Errorf("PingType = %v, want %v", got.PingType(), want.PingType())
+	}
+	if got.Status() != want.Status() {
+		t.Errorf("Status = %v, want %v", got.Status(), want.Status())
+	}
+	if got.Sequence() != want.Sequence() {
+		t.Errorf("Sequence = %d, want %d", got.Sequence(), want.Sequence())
+	}
+	switch w, g := want.Occurrence(), got.Occurrence(); {
+	case w == nil && g != nil:
+		t.Errorf("Occurrence = %+v, want nil", *g)
+	case w != nil && g == nil:
+		t.Errorf("Occurrence = nil, want %+v", *w)
+	case w != nil:
+		if g.SeriesID != w.SeriesID || g.Modified != w.Modified {
+			t.Errorf("Occurrence = %+v, want %+v", *g, *w)
+		}
+		assertTimeEqual(t, "Occurrence.OccurrenceAt", w.OccurrenceAt, g.OccurrenceAt)
+	}
+	assertTimeEqual(t, "ScheduledAt", want.ScheduledAt(), got.ScheduledAt())
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt(), got.CreatedAt())
+	assertTimeEqual(t, "UpdatedAt", want.UpdatedAt(), got.UpdatedAt())
+
+	switch {
+	case want.Location() == nil && got.Location() != nil:
+		t.Errorf("Location = %+v, want nil", *got.Location())
+	case want.Location() != nil && got.Location() == nil:
+		t.Errorf("Location = nil, want %+v", *want.Location())
+	case want.Location() != nil && *want.Location() != *got.Location():
+		t.Errorf("Location = %+v, want %+v", *got.Location(), *want.Location())
+	}
+
+	if len(got.Invitees()) != len(want.Invitees()) {
+		t.Fatalf("Invitees = %v, want %v", got.Invitees(), want.Invitees())
+	}
+	for i := range want.Invitees() {
+		if got.Invitees()[i] != want.Invitees()[i] {
+			t.Errorf("Invitees[%d] = %v, want %v", i, got.Invitees()[i], want.Invitees()[i])
+		}
+	}
+
+	if len(got.Responses()) != len(want.Responses()) {
+		t.Fatalf("Responses = %d entries, want %d", len(got.Responses()), len(want.Responses()))
+	}
+	for i, w := range want.Responses() {
+		g := got.Responses()[i]
+		if g.ID != w.ID || g.PingID != w.PingID || g.UserID != w.UserID || g.Status != w.Status || g.Message != w.Message {
+			t.Errorf("Responses[%d] = %+v, want %+v", i, g, w)
+		}
+		assertOptionalTimeEqual(t, "Responses.RespondedAt", w.RespondedAt, g.RespondedAt)
+	}
+}
@@ -0,0 +1,243 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunPingSeriesRepositoryContract exercises a ping.SeriesRepository together
+// with the ping.Repository storing its occurrences. newFixture must return
+// empty repositories sharing the same storage.
+func RunPingSeriesRepositoryContract(t *testing.T, newFixture func(t *testing.T) (ping.SeriesRepository, ping.Repository)) {
+	ctx := context.Background()
+
+	t.Run("create get and update round trip", func(t *testing.T) {
+		repo, _ := newFixture(t)
+		series := newTestSeries(t, shared.NewUserID(), []shared.UserID{shared.NewUserID(), shared.NewUserID()})
+		if err := repo.Create(ctx, series); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.GetByID(ctx, series.ID())
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertSeriesEqual(t, series, got)
+
+		rule, err := ping.ParseRecurrenceRule("FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20991231T000000Z")
+		if err != nil {
+			t.Fatalf("ParseRecurrenceRule() error = %v", err)
+		}
+		location := &shared.Location{Latitude: 25.033, Longitude: 121.5654, Address: "台北市信義區"}
+		if _, err := series.Update("月底聚餐", "最後一個週五", location, series.StartAt(), "Asia/Tokyo", rule); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		series.MarkMaterialized(time.Now().Add(7 * 24 * time.Hour))
+		if err := repo.Update(ctx, series); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err = repo.GetByID(ctx, series.ID())
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		assertSeriesEqual(t, series, got)
+	})
+
+	t.Run("get and update missing series", func(t *testing.T) {
+		repo, _ := newFixture(t)
+		if _, err := repo.GetByID(ctx, shared.NewID()); !errors.Is(err, ping.ErrSeriesNotFound) {
+			t.Errorf("GetByID() error = %v, want %v", err, ping.ErrSeriesNotFound)
+		}
+		series := newTestSeries(t, shared.NewUserID(), []shared.UserID{shared.NewUserID()})
+		if err := repo.Update(ctx, series); !errors.Is(err, ping.ErrSeriesNotFound) {
+			t.Errorf("Update() error = %v, want %v", err, ping.ErrSeriesNotFound)
+		}
+	})
+
+	t.Run("queries for user and materialization", func(t *testing.T) {
+		repo, _ := newFixture(t)
+		creator := shared.NewUserID()
+		invitee := shared.NewUserID()
+
+		later := newTestSeries(t, creator, []shared.UserID{invitee})
+		earlier := newTestSeries(t, creator, []shared.UserID{shared.NewUserID()})
+		cancelled := newTestSeries(t, creator, []shared.UserID{invitee})
+		earlier = reconstructSeriesWith(earlier, earlier.StartAt().Add(-24*time.Hour), time.Now().Add(time.Hour))
+		later = reconstructSeriesWith(later, later.StartAt(), time.Now().Add(30*24*time.Hour))
+		if err := cancelled.Cancel(); err != nil {
+			t.Fatalf("Cancel() error = %v", err)
+		}
+		for _, series := range []*ping.Series{later, earlier, cancelled} {
+			if err := repo.Create(ctx, series); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		forCreator, err := repo.GetForUser(ctx, creator)
+		if err != nil {
+			t.Fatalf("GetForUser() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetForUser creator", seriesIDs(earlier, later), seriesIDs(forCreator...))
+
+		forInvitee, err := repo.GetForUser(ctx, invitee)
+		if err != nil {
+			t.Fatalf("GetForUser() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetForUser invitee", seriesIDs(later), seriesIDs(forInvitee...))
+
+		due, err := repo.GetDueForMaterialization(ctx, time.Now().Add(7*24*time.Hour))
+		if err != nil {
+			t.Fatalf("GetDueForMaterialization() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetDueForMaterialization", seriesIDs(earlier), seriesIDs(due...))
+	})
+
+	t.Run("occurrences by series", func(t *testing.T) {
+		repo, pings := newFixture(t)
+		series := newTestSeries(t, shared.NewUserID(), []shared.UserID{shared.NewUserID()})
+		if err := repo.Create(ctx, series); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		slots := series.PendingOccurrences(time.Now(), time.Now().Add(20*24*time.Hour))
+		if len(slots) != 3 {
+			t.Fatalf("PendingOccurrences() = %d slots, want 3", len(slots))
+		}
+		var occurrences []*ping.Ping
+		for _, slot := range slots {
+			p, err := ping.NewOccurrence(series, slot, series.Invitees())
+			if err != nil {
+				t.Fatalf("NewOccurrence() error = %v", err)
+			}
+			if err := pings.Create(ctx, p); err != nil {
+				t.Fatalf("Create() ping error = %v", err)
+			}
+			occurrences = append(occurrences, p)
+		}
+		oneOff := newTestPing(t, series.CreatedBy(), series.Invitees(), time.Now().Add(time.Hour), time.Now())
+		if err := pings.Create(ctx, oneOff); err != nil {
+			t.Fatalf("Create() ping error = %v", err)
+		}
+
+		// 單獨改期的發生仍以原本的時間排序與查詢
+		moved := occurrences[0]
+		if err := moved.EditOccurrence("延後的午餐", "", slots[2].Add(time.Hour), nil); err != nil {
+			t.Fatalf("EditOccurrence() error = %v", err)
+		}
+		if err := pings.Update(ctx, moved); err != nil {
+			t.Fatalf("Update() ping error = %v", err)
+		}
+
+		got, err := pings.GetBySeries(ctx, series.ID(), time.Now())
+		if err != nil {
+			t.Fatalf("GetBySeries() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetBySeries", pingIDs(occurrences...), pingIDs(got...))
+		assertPingEqual(t, moved, got[0])
+
+		got, err = pings.GetBySeries(ctx, series.ID(), slots[1])
+		if err != nil {
+			t.Fatalf("GetBySeries() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetBySeries from", pingIDs(occurrences[1:]...), pingIDs(got...))
+
+		got, err = pings.GetBySeries(ctx, shared.NewID(), time.Now())
+		if err != nil {
+			t.Fatalf("GetBySeries() error = %v", err)
+		}
+		assertOrderedIDs(t, "GetBySeries other series", nil, pingIDs(got...))
+	})
+}
+
+// newTestSeries builds a weekly series starting tomorrow
+func newTestSeries(t *testing.T, creator shared.UserID, invitees []shared.UserID) *ping.Series {
+	t.Helper()
+
+	rule, err := ping.ParseRecurrenceRule("FREQ=WEEKLY;COUNT=10")
+	if err != nil {
+		t.Fatalf("ParseRecurrenceRule() error = %v", err)
+	}
+	series, err := ping.NewSeries(creator, "團隊午餐", "每週一起吃飯", ping.PingTypeLunch, time.Now().Add(24*time.Hour), "Asia/Taipei", rule, invitees)
+	if err != nil {
+		t.Fatalf("NewSeries() error = %v", err)
+	}
+	return series
+}
+
+// reconstructSeriesWith rewrites the fields the queries order and filter on
+func reconstructSeriesWith(s *ping.Series, startAt, materializedUntil time.Time) *ping.Series {
+	return ping.ReconstructSeries(
+		s.ID(),
+		s.CreatedBy(),
+		s.Title(),
+		s.Description(),
+		s.PingType(),
+		s.Location(),
+		s.Invitees(),
+		startAt,
+		s.TimeZone(),
+		s.Rule(),
+		s.Status(),
+		materializedUntil,
+		s.Sequence(),
+		s.CreatedAt(),
+		s.UpdatedAt(),
+	)
+}
+
+func seriesIDs(series ...*ping.Series) []string {
+	ids := make([]string, len(series))
+	for i, s := range series {
+		ids[i] = s.ID().String()
+	}
+	return ids
+}
+
+func assertSeriesEqual(t *testing.T, want, got *ping.Series) {
+	t.Helper()
+
+	if got.ID() != want.ID() || got.CreatedBy() != want.CreatedBy() {
+		t.Errorf("ID/CreatedBy = %v/%v, want %v/%v", got.ID(), got.CreatedBy(), want.ID(), want.CreatedBy())
+	}
+	if got.Title() != want.Title() || got.Description() != want.Description() || got.PingType() != want.PingType() {
+		t.Errorf("Title/Description/PingType = %q/%q/%v, want %q/%q/%v", got.Title(), got.Description(), got.PingType(), want.Title(), want.Description(), want.PingType())
+	}
+	if got.TimeZone() != want.TimeZone() || got.Rule().String() != want.Rule().String() {
+		t.Errorf("TimeZone/Rule = %s/%s, want %s/%s", got.TimeZone(), got.Rule(), want.TimeZone(), want.Rule())
+	}
+	if got.Status() != want.Status() || got.Sequence() != want.Sequence() {
+		t.Errorf("Status/Sequence = %v/%d, want %v/%d", got.Status(), got.Sequence(), want.Status(), want.Sequence())
+	}
+	assertTimeEqual(t, "StartAt", want.StartAt(), got.StartAt())
+	if got.StartAt().Location().String() != want.TimeZone() {
+		t.Errorf("StartAt location = %s, want %s", got.StartAt().Location(), want.TimeZone())
+	}
+	assertTimeEqual(t, "MaterializedUntil", want.MaterializedUntil(), got.MaterializedUntil())
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt(), got.CreatedAt())
+	assertTimeEqual(t, "UpdatedAt", want.UpdatedAt(), got.UpdatedAt())
+
+	switch {
+	case want.Location() == nil && got.Location() != nil:
+		t.Errorf("Location = %+v, want nil", *got.Location())
+	case want.Location() != nil && got.Location() == nil:
+		t.Errorf("Location = nil, want %+v", *want.Location())
+	case want.Location() != nil && *want.Location() != *got.Location():
+		t.Errorf("Location = %+v, want %+v", *got.Location(), *want.Location())
+	}
+
+	assertOrderedIDs(t, "Invitees", userIDStrings(want.Invitees()), userIDStrings(got.Invitees()))
+}
+
+func userIDStrings(ids []shared.UserID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
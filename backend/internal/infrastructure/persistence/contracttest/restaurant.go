@@ -0,0 +1,273 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// 台北車站附近的測試座標
+var taipeiMainStation = restaurant.Location{Latitude: 25.0478, Longitude: 121.5170}
+
+// RunRestaurantRepositoryContract exercises a restaurant.Repository
+// implementation. newRepo must return an empty repository on every call.
+func RunRestaurantRepositoryContract(t *testing.T, newRepo func(t *testing.T) restaurant.Repository) {
+	ctx := context.Background()
+
+	t.Run("create and find round trip", func(t *testing.T) {
+		repo := newRepo(t)
+		r := newTestRestaurant(t, "鼎泰豐", 25.0330, 121.5654, restaurant.CuisineTypeTaiwanese, restaurant.CuisineTypeChinese)
+		r.Rating = 4.3
+		r.TotalReviews = 2856
+		r.Website = "https://example.com"
+		r.ImageURLs = []string{"https://example.com/1.jpg"}
+		r.OpeningHours = restaurant.OpeningHours{
+			TimeZone: "Asia/Taipei",
+			Weekly: map[string][]restaurant.TimeRange{
+				"monday": {{Open: 11 * 60, Close: 14 * 60}, {Open: 17 * 60, Close: 21 * 60}},
+				"friday": {{Open: 18 * 60, Close: 2 * 60}},
+			},
+			Exceptions: []restaurant.DateException{
+				{Date: "2025-01-28", Note: "除夕"},
+				{Date: "2025-01-29", Hours: []restaurant.TimeRange{{Open: 12 * 60, Close: 20 * 60}}},
+			},
+		}
+		r.SupportedRestrictions = []restaurant.DietaryRestriction{restaurant.DietaryRestrictionVegetarian}
+		r.AverageWaitTime = 30
+		r.AcceptsReservations = true
+
+		if err := repo.Create(ctx, r); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		assertRestaurantEqual(t, r, got)
+	})
+
+	t.Run("find missing restaurant", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, shared.NewRestaurantID()); !errors.Is(err, shared.ErrRestaurantNotFound) {
+			t.Errorf("FindByID() error = %v, want %v", err, shared.ErrRestaurantNotFound)
+		}
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		repo := newRepo(t)
+		r := newTestRestaurant(t, "欣葉", 25.0478, 121.5319, restaurant.CuisineTypeJapanese)
+		if err := repo.Create(ctx, r); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if err := r.ApplyRatingSummary(4.5, 2); err != nil {
+			t.Fatalf("ApplyRatingSummary() error = %v", err)
+		}
+		r.IsActive = false
+		if err := repo.Update(ctx, r); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, r.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		assertRestaurantEqual(t, r, got)
+
+		if err := repo.Delete(ctx, r.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByID(ctx, r.ID); !errors.Is(err, shared.ErrRestaurantNotFound) {
+			t.Errorf("FindByID() after delete error = %v, want %v", err, shared.ErrRestaurantNotFound)
+		}
+		if err := repo.Update(ctx, r); !errors.Is(err, shared.ErrRestaurantNotFound) {
+			t.Errorf("Update() missing error = %v, want %v", err, shared.ErrRestaurantNotFound)
+		}
+		if err := repo.Delete(ctx, r.ID); !errors.Is(err, shared.ErrRestaurantNotFound) {
+			t.Errorf("Delete() missing error = %v, want %v", err, shared.ErrRestaurantNotFound)
+		}
+	})
+
+	t.Run("location queries", func(t *testing.T) {
+		repo := newRepo(t)
+		near := newTestRestaurant(t, "春川炸雞", 25.0478, 121.5170, restaurant.CuisineTypeKorean)
+		mid := newTestRestaurant(t, "大三元", 25.0453, 121.5097, restaurant.CuisineTypeChinese)
+		far := newTestRestaurant(t, "高雄餐廳", 22.6273, 120.3014, restaurant.CuisineTypeChinese)
+		closed := newTestRestaurant(t, "歇業餐廳", 25.0470, 121.5160, restaurant.CuisineTypeChinese)
+		closed.IsActive = false
+		for _, r := range []*restaurant.Restaurant{far, closed, mid, near} {
+			if err := repo.Create(ctx, r); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		byLocation, err := repo.FindByLocation(ctx, taipeiMainStation.Latitude, taipeiMainStation.Longitude, 5)
+		if err != nil {
+			t.Fatalf("FindByLocation() error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByLocation", restaurantIDs(near, mid), restaurantIDs(byLocation...))
+
+		nearby, err := repo.FindNearby(ctx, taipeiMainStation, 5, restaurant.SearchCriteria{
+			CuisineTypes: []restaurant.CuisineType{restaurant.CuisineTypeChinese},
+		})
+		if err != nil {
+			t.Fatalf("FindNearby() error = %v", err)
+		}
+		assertOrderedIDs(t, "FindNearby", restaurantIDs(mid), restaurantIDs(nearby...))
+	})
+
+	t.Run("find all and search", func(t *testing.T) {
+		repo := newRepo(t)
+		a := newTestRestaurant(t, "A 餐廳", 25.04, 121.51, restaurant.CuisineTypeThai)
+		a.Rating = 3.5
+		b := newTestRestaurant(t, "B 餐廳", 25.04, 121.52, restaurant.CuisineTypeThai)
+		b.Rating = 4.6
+		b.AcceptsReservations = true
+		c := newTestRestaurant(t, "C 餐廳", 25.04, 121.53, restaurant.CuisineTypeItalian)
+		c.Rating = 4.1
+		d := newTestRestaurant(t, "D 餐廳", 25.04, 121.54, restaurant.CuisineTypeThai)
+		d.Rating = 4.9
+		d.IsActive = false
+		for _, r := range []*restaurant.Restaurant{c, d, a, b} {
+			if err := repo.Create(ctx, r); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		all, err := repo.FindAll(ctx, 10, 0)
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		assertOrderedIDs(t, "FindAll", restaurantIDs(a, b, c), restaurantIDs(all...))
+
+		page, err := repo.FindAll(ctx, 1, 1)
+		if err != nil {
+			t.Fatalf("FindAll() paged error = %v", err)
+		}
+		assertOrderedIDs(t, "FindAll paged", restaurantIDs(b), restaurantIDs(page...))
+
+		active := true
+		byRating, err := repo.Search(ctx, restaurant.SearchCriteria{
+			MinRating: 4.0,
+			IsActive:  &active,
+			SortBy:    restaurant.SortByRating,
+			SortOrder: restaurant.OrderDesc,
+		})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		assertOrderedIDs(t, "Search by rating", restaurantIDs(b, c), restaurantIDs(byRating...))
+
+		reservations := true
+		thai, err := repo.Search(ctx, restaurant.SearchCriteria{
+			CuisineTypes:        []restaurant.CuisineType{restaurant.CuisineTypeThai},
+			AcceptsReservations: &reservations,
+		})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		assertOrderedIDs(t, "Search by cuisine", restaurantIDs(b), restaurantIDs(thai...))
+
+		limited, err := repo.Search(ctx, restaurant.SearchCriteria{
+			SortBy: restaurant.SortByName,
+			Limit:  2,
+			Offset: 1,
+		})
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		assertOrderedIDs(t, "Search paged", restaurantIDs(b, c), restaurantIDs(limited...))
+	})
+
+	t.Run("search open at a time", func(t *testing.T) {
+		repo := newRepo(t)
+		lunch := newTestRestaurant(t, "午餐店", 25.04, 121.51, restaurant.CuisineTypeTaiwanese)
+		lunch.OpeningHours = restaurant.OpeningHours{Weekly: map[string][]restaurant.TimeRange{
+			"monday": {{Open: 11 * 60, Close: 14 * 60}},
+		}}
+		bar := newTestRestaurant(t, "宵夜店", 25.04, 121.52, restaurant.CuisineTypeTaiwanese)
+		bar.OpeningHours = restaurant.OpeningHours{Weekly: map[string][]restaurant.TimeRange{
+			"sunday": {{Open: 18 * 60, Close: 2 * 60}},
+		}}
+		unknown := newTestRestaurant(t, "未提供時間", 25.04, 121.53, restaurant.CuisineTypeTaiwanese)
+		for _, r := range []*restaurant.Restaurant{lunch, bar, unknown} {
+			if err := repo.Create(ctx, r); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		taipei := time.FixedZone("Asia/Taipei", 8*60*60)
+		for _, tt := range []struct {
+			name string
+			at   time.Time
+			want []*restaurant.Restaurant
+		}{
+			{"monday noon", time.Date(2024, 3, 4, 12, 0, 0, 0, taipei), []*restaurant.Restaurant{lunch, unknown}},
+			{"monday 1am after sunday night", time.Date(2024, 3, 4, 1, 0, 0, 0, taipei), []*restaurant.Restaurant{bar, unknown}},
+			{"monday evening", time.Date(2024, 3, 4, 19, 0, 0, 0, taipei), []*restaurant.Restaurant{unknown}},
+		} {
+			at := tt.at
+			open, err := repo.Search(ctx, restaurant.SearchCriteria{OpenAt: &at, SortBy: restaurant.SortByName})
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			assertSameIDs(t, "Search open at "+tt.name, restaurantIDs(tt.want...), restaurantIDs(open...))
+		}
+	})
+}
+
+func newTestRestaurant(t *testing.T, name string, lat, lon float64, cuisines ...restaurant.CuisineType) *restaurant.Restaurant {
+	t.Helper()
+
+	r, err := restaurant.NewRestaurant(
+		name,
+		"測試餐廳",
+		restaurant.Location{Latitude: lat, Longitude: lon, Address: "台北市"},
+		cuisines,
+		restaurant.PriceLevelMidRange,
+		"+886-2-1234-5678",
+	)
+	if err != nil {
+		t.Fatalf("NewRestaurant() error = %v", err)
+	}
+	return r
+}
+
+func restaurantIDs(restaurants ...*restaurant.Restaurant) []string {
+	ids := make([]string, len(restaurants))
+	for i, r := range restaurants {
+		ids[i] = r.ID.String()
+	}
+	return ids
+}
+
+func assertRestaurantEqual(t *testing.T, want, got *restaurant.Restaurant) {
+	t.Helper()
+
+	wantCopy, gotCopy := *want, *got
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt, got.CreatedAt)
+	assertTimeEqual(t, "UpdatedAt", want.UpdatedAt, got.UpdatedAt)
+	wantCopy.CreatedAt, gotCopy.CreatedAt = got.CreatedAt, got.CreatedAt
+	wantCopy.UpdatedAt, gotCopy.UpdatedAt = got.UpdatedAt, got.UpdatedAt
+
+	// 空集合與 nil 在持久化後視為相同
+	if len(wantCopy.ImageURLs) == 0 && len(gotCopy.ImageURLs) == 0 {
+		wantCopy.ImageURLs, gotCopy.ImageURLs = nil, nil
+	}
+	if len(wantCopy.SupportedRestrictions) == 0 && len(gotCopy.SupportedRestrictions) == 0 {
+		wantCopy.SupportedRestrictions, gotCopy.SupportedRestrictions = nil, nil
+	}
+	if wantCopy.OpeningHours.IsZero() && gotCopy.OpeningHours.IsZero() {
+		wantCopy.OpeningHours, gotCopy.OpeningHours = restaurant.OpeningHours{}, restaurant.OpeningHours{}
+	}
+
+	if !reflect.DeepEqual(wantCopy, gotCopy) {
+		t.Errorf("restaurant = %+v, want %+v", gotCopy, wantCopy)
+	}
+}
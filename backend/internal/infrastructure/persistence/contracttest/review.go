@@ -0,0 +1,230 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunReviewRepositoryContract exercises a review.Repository implementation.
+// newRepo must return an empty repository on every call.
+func RunReviewRepositoryContract(t *testing.T, newRepo func(t *testing.T) review.Repository) {
+	ctx := context.Background()
+
+	t.Run("round trip and one review per visit", func(t *testing.T) {
+		repo := newRepo(t)
+		restaurantID, author := shared.NewRestaurantID(), shared.NewUserID()
+
+		created := createTestReview(t, repo, restaurantID, author, 4, time.Now())
+		got, err := repo.FindByID(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.RestaurantID != restaurantID || got.UserID != author || got.Stars != 4 || got.Text != "Great noodles" {
+			t.Errorf("FindByID() = %+v", got)
+		}
+		if got.Visit.Type != review.VisitTypePing || got.Visit.ID != created.Visit.ID {
+			t.Errorf("FindByID().Visit = %+v, want %+v", got.Visit, created.Visit)
+		}
+		assertTimeEqual(t, "Visit.VisitedAt", created.Visit.VisitedAt, got.Visit.VisitedAt)
+		if len(got.PhotoURLs) != 1 || got.PhotoURLs[0] != "https://example.com/noodles.jpg" {
+			t.Errorf("FindByID().PhotoURLs = %v", got.PhotoURLs)
+		}
+
+		again, err := review.NewReview(restaurantID, author, created.Visit, 2, "", nil)
+		if err != nil {
+			t.Fatalf("NewReview() error = %v", err)
+		}
+		if err := repo.Create(ctx, again); !errors.Is(err, shared.ErrReviewExists) {
+			t.Errorf("Create() same visit error = %v, want %v", err, shared.ErrReviewExists)
+		}
+
+		// 同一次用餐的其他參與者可以各自評論
+		other, _ := review.NewReview(restaurantID, shared.NewUserID(), created.Visit, 3, "", nil)
+		if err := repo.Create(ctx, other); err != nil {
+			t.Errorf("Create() other participant error = %v", err)
+		}
+
+		if _, err := repo.FindByID(ctx, shared.NewID()); !errors.Is(err, shared.ErrReviewNotFound) {
+			t.Errorf("FindByID() missing error = %v, want %v", err, shared.ErrReviewNotFound)
+		}
+	})
+
+	t.Run("update keeps helpful marks and delete removes", func(t *testing.T) {
+		repo := newRepo(t)
+		created := createTestReview(t, repo, shared.NewRestaurantID(), shared.NewUserID(), 3, time.Now())
+
+		voter := shared.NewUserID()
+		if err := created.Edit(created.UserID, 5, "Even better the second time", nil); err != nil {
+			t.Fatalf("Edit() error = %v", err)
+		}
+		if err := created.MarkHelpful(voter); err != nil {
+			t.Fatalf("MarkHelpful() error = %v", err)
+		}
+		if err := repo.Update(ctx, created); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.Stars != 5 || got.Text != "Even better the second time" || len(got.PhotoURLs) != 0 {
+			t.Errorf("FindByID() after update = %+v", got)
+		}
+		if !got.IsHelpfulTo(voter) || got.HelpfulCount() != 1 {
+			t.Errorf("FindByID().HelpfulBy = %v, want [%s]", got.HelpfulBy, voter)
+		}
+
+		if err := repo.Delete(ctx, created.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByID(ctx, created.ID); !errors.Is(err, shared.ErrReviewNotFound) {
+			t.Errorf("FindByID() after delete error = %v, want %v", err, shared.ErrReviewNotFound)
+		}
+		if err := repo.Delete(ctx, created.ID); !errors.Is(err, shared.ErrReviewNotFound) {
+			t.Errorf("Delete() again error = %v, want %v", err, shared.ErrReviewNotFound)
+		}
+		if err := repo.Update(ctx, created); !errors.Is(err, shared.ErrReviewNotFound) {
+			t.Errorf("Update() deleted error = %v, want %v", err, shared.ErrReviewNotFound)
+		}
+	})
+
+	t.Run("restaurant listing order", func(t *testing.T) {
+		repo := newRepo(t)
+		restaurantID := shared.NewRestaurantID()
+		now := time.Now()
+
+		oldest := createTestReview(t, repo, restaurantID, shared.NewUserID(), 5, now.Add(-2*time.Hour))
+		middle := createTestReview(t, repo, restaurantID, shared.NewUserID(), 2, now.Add(-time.Hour))
+		newest := createTestReview(t, repo, restaurantID, shared.NewUserID(), 4, now)
+		createTestReview(t, repo, shared.NewRestaurantID(), shared.NewUserID(), 1, now)
+
+		for _, voter := range []shared.UserID{shared.NewUserID(), shared.NewUserID()} {
+			oldest.MarkHelpful(voter)
+		}
+		middle.MarkHelpful(shared.NewUserID())
+		for _, rv := range []*review.Review{oldest, middle} {
+			if err := repo.Update(ctx, rv); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		}
+
+		byNewest, err := repo.FindByRestaurant(ctx, restaurantID, review.SortNewest, nil, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByRestaurant(newest) error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByRestaurant(newest)", reviewIDs(newest, middle, oldest), reviewIDs(byNewest...))
+
+		byHelpful, err := repo.FindByRestaurant(ctx, restaurantID, review.SortMostHelpful, nil, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByRestaurant(most helpful) error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByRestaurant(most helpful)", reviewIDs(oldest, middle, newest), reviewIDs(byHelpful...))
+
+		page, err := repo.FindByRestaurant(ctx, restaurantID, review.SortNewest, nil, 1, 1)
+		if err != nil {
+			t.Fatalf("FindByRestaurant(limit 1, offset 1) error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByRestaurant(limit 1, offset 1)", reviewIDs(middle), reviewIDs(page...))
+	})
+
+	t.Run("restaurant listing excludes authors before paging", func(t *testing.T) {
+		repo := newRepo(t)
+		restaurantID := shared.NewRestaurantID()
+		blocked := shared.NewUserID()
+		now := time.Now()
+
+		oldest := createTestReview(t, repo, restaurantID, shared.NewUserID(), 5, now.Add(-3*time.Hour))
+		createTestReview(t, repo, restaurantID, blocked, 1, now.Add(-2*time.Hour))
+		middle := createTestReview(t, repo, restaurantID, shared.NewUserID(), 3, now.Add(-time.Hour))
+		createTestReview(t, repo, shared.NewRestaurantID(), blocked, 1, now)
+
+		// 第二頁不應因為排除的評論而變短
+		page, err := repo.FindByRestaurant(ctx, restaurantID, review.SortNewest, []shared.UserID{blocked}, 1, 1)
+		if err != nil {
+			t.Fatalf("FindByRestaurant(excluded, limit 1, offset 1) error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByRestaurant(excluded, limit 1, offset 1)", reviewIDs(oldest), reviewIDs(page...))
+
+		all, err := repo.FindByRestaurant(ctx, restaurantID, review.SortNewest, []shared.UserID{blocked}, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByRestaurant(excluded) error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByRestaurant(excluded)", reviewIDs(middle, oldest), reviewIDs(all...))
+	})
+
+	t.Run("user listing newest first", func(t *testing.T) {
+		repo := newRepo(t)
+		author := shared.NewUserID()
+		now := time.Now()
+
+		older := createTestReview(t, repo, shared.NewRestaurantID(), author, 3, now.Add(-time.Hour))
+		newer := createTestReview(t, repo, shared.NewRestaurantID(), author, 4, now)
+		createTestReview(t, repo, shared.NewRestaurantID(), shared.NewUserID(), 5, now)
+
+		got, err := repo.FindByUser(ctx, author, 10, 0)
+		if err != nil {
+			t.Fatalf("FindByUser() error = %v", err)
+		}
+		assertOrderedIDs(t, "FindByUser()", reviewIDs(newer, older), reviewIDs(got...))
+	})
+
+	t.Run("summarize from stored reviews", func(t *testing.T) {
+		repo := newRepo(t)
+		restaurantID := shared.NewRestaurantID()
+
+		empty, err := repo.Summarize(ctx, restaurantID)
+		if err != nil {
+			t.Fatalf("Summarize() error = %v", err)
+		}
+		if empty.Count != 0 || empty.Average != 0 {
+			t.Errorf("Summarize() without reviews = %+v", empty)
+		}
+
+		for _, stars := range []int{5, 4, 4, 1} {
+			createTestReview(t, repo, restaurantID, shared.NewUserID(), stars, time.Now())
+		}
+		createTestReview(t, repo, shared.NewRestaurantID(), shared.NewUserID(), 1, time.Now())
+
+		got, err := repo.Summarize(ctx, restaurantID)
+		if err != nil {
+			t.Fatalf("Summarize() error = %v", err)
+		}
+		if got.Count != 4 || got.Average != 3.5 || got.Distribution != [review.MaxStars]int{1, 0, 0, 2, 1} {
+			t.Errorf("Summarize() = %+v, want count 4, average 3.5, distribution [1 0 0 2 1]", got)
+		}
+	})
+}
+
+func createTestReview(t *testing.T, repo review.Repository, restaurantID shared.RestaurantID, userID shared.UserID, stars int, createdAt time.Time) *review.Review {
+	t.Helper()
+
+	visit := review.Visit{
+		Type:      review.VisitTypePing,
+		ID:        shared.NewID().String(),
+		VisitedAt: createdAt.Add(-time.Hour).Truncate(time.Microsecond),
+	}
+	rv, err := review.NewReview(restaurantID, userID, visit, stars, "Great noodles", []string{"https://example.com/noodles.jpg"})
+	if err != nil {
+		t.Fatalf("NewReview() error = %v", err)
+	}
+	rv.CreatedAt = createdAt.Truncate(time.Microsecond)
+	rv.UpdatedAt = rv.CreatedAt
+	if err := repo.Create(context.Background(), rv); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return rv
+}
+
+func reviewIDs(reviews ...*review.Review) []string {
+	ids := make([]string, len(reviews))
+	for i, rv := range reviews {
+		ids[i] = rv.ID.String()
+	}
+	return ids
+}
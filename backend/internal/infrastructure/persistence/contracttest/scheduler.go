@@ -0,0 +1,122 @@
+package contracttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunSchedulerStoreContract exercises a scheduler.LeaseStore together with a
+// scheduler.RunStore. newFixture must return empty stores on every call.
+func RunSchedulerStoreContract(t *testing.T, newFixture func(t *testing.T) (scheduler.LeaseStore, scheduler.RunStore)) {
+	ctx := context.Background()
+
+	t.Run("lease is exclusive until released or expired", func(t *testing.T) {
+		leases, _ := newFixture(t)
+
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-a", time.Minute); err != nil || !ok {
+			t.Fatalf("TryAcquire() instance-a = %v, %v, want true", ok, err)
+		}
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-b", time.Minute); err != nil || ok {
+			t.Fatalf("TryAcquire() instance-b while held = %v, %v, want false", ok, err)
+		}
+		// 持有者可續約
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-a", time.Minute); err != nil || !ok {
+			t.Fatalf("TryAcquire() renew = %v, %v, want true", ok, err)
+		}
+		// 不同名稱的租約互不影響
+		if ok, err := leases.TryAcquire(ctx, "other", "instance-b", time.Minute); err != nil || !ok {
+			t.Fatalf("TryAcquire() other lease = %v, %v, want true", ok, err)
+		}
+
+		// 非持有者釋放不影響租約
+		if err := leases.Release(ctx, "jobs", "instance-b"); err != nil {
+			t.Fatalf("Release() non-holder error = %v", err)
+		}
+		if ok, _ := leases.TryAcquire(ctx, "jobs", "instance-b", time.Minute); ok {
+			t.Fatal("TryAcquire() instance-b after non-holder release = true, want false")
+		}
+
+		if err := leases.Release(ctx, "jobs", "instance-a"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-b", time.Minute); err != nil || !ok {
+			t.Fatalf("TryAcquire() instance-b after release = %v, %v, want true", ok, err)
+		}
+	})
+
+	t.Run("expired lease can be taken over", func(t *testing.T) {
+		leases, _ := newFixture(t)
+
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-a", -time.Second); err != nil || !ok {
+			t.Fatalf("TryAcquire() instance-a = %v, %v, want true", ok, err)
+		}
+		if ok, err := leases.TryAcquire(ctx, "jobs", "instance-b", time.Minute); err != nil || !ok {
+			t.Fatalf("TryAcquire() instance-b after expiry = %v, %v, want true", ok, err)
+		}
+		if ok, _ := leases.TryAcquire(ctx, "jobs", "instance-a", time.Minute); ok {
+			t.Fatal("TryAcquire() previous holder after takeover = true, want false")
+		}
+	})
+
+	t.Run("records runs and returns the latest", func(t *testing.T) {
+		_, runs := newFixture(t)
+
+		if last, err := runs.LastRun(ctx, "expire_pings"); err != nil || last != nil {
+			t.Fatalf("LastRun() without runs = %+v, %v, want nil", last, err)
+		}
+
+		base := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+		old := newTestJobRun("expire_pings", base, scheduler.RunStatusSucceeded, "")
+		latest := newTestJobRun("expire_pings", base.Add(30*time.Minute), scheduler.RunStatusFailed, "database unavailable")
+		other := newTestJobRun("meal_reminders", base.Add(45*time.Minute), scheduler.RunStatusSucceeded, "")
+		for _, run := range []scheduler.JobRun{latest, old, other} {
+			if err := runs.RecordRun(ctx, run); err != nil {
+				t.Fatalf("RecordRun() error = %v", err)
+			}
+		}
+
+		last, err := runs.LastRun(ctx, "expire_pings")
+		if err != nil {
+			t.Fatalf("LastRun() error = %v", err)
+		}
+		if last == nil || last.ID != latest.ID {
+			t.Fatalf("LastRun() = %+v, want %s", last, latest.ID)
+		}
+		if last.Status != scheduler.RunStatusFailed || last.Error != latest.Error || last.Processed != latest.Processed || last.InstanceID != latest.InstanceID {
+			t.Errorf("LastRun() = %+v, want %+v", last, latest)
+		}
+		assertTimeEqual(t, "StartedAt", latest.StartedAt, last.StartedAt)
+		assertTimeEqual(t, "FinishedAt", latest.FinishedAt, last.FinishedAt)
+
+		purged, err := runs.PurgeRuns(ctx, base.Add(40*time.Minute))
+		if err != nil {
+			t.Fatalf("PurgeRuns() error = %v", err)
+		}
+		if purged != 2 {
+			t.Errorf("PurgeRuns() = %d, want 2", purged)
+		}
+		if last, _ := runs.LastRun(ctx, "expire_pings"); last != nil {
+			t.Errorf("LastRun() after purge = %+v, want nil", last)
+		}
+		if last, _ := runs.LastRun(ctx, "meal_reminders"); last == nil || last.ID != other.ID {
+			t.Errorf("LastRun() meal_reminders after purge = %+v, want %s", last, other.ID)
+		}
+	})
+}
+
+func newTestJobRun(jobName string, startedAt time.Time, status scheduler.RunStatus, errMessage string) scheduler.JobRun {
+	return scheduler.JobRun{
+		ID:         shared.NewID().String(),
+		JobName:    jobName,
+		InstanceID: "instance-a",
+		Status:     status,
+		Processed:  3,
+		Error:      errMessage,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(time.Second),
+	}
+}
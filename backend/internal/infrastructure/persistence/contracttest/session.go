@@ -0,0 +1,235 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RunRefreshTokenRepositoryContract exercises a session.RefreshTokenRepository
+// implementation. newRepo must return an empty repository on every call.
+func RunRefreshTokenRepositoryContract(t *testing.T, newRepo func(t *testing.T) session.RefreshTokenRepository) {
+	ctx := context.Background()
+
+	t.Run("save and find by hash", func(t *testing.T) {
+		repo := newRepo(t)
+		token := newTestRefreshToken(shared.NewID(), shared.NewUserID(), "phone")
+		if err := repo.Save(ctx, token); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := repo.FindByHash(ctx, token.TokenHash)
+		if err != nil {
+			t.Fatalf("FindByHash() error = %v", err)
+		}
+		assertRefreshTokenEqual(t, token, got)
+
+		if _, err := repo.FindByHash(ctx, session.HashToken("missing")); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByHash() missing error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+
+	t.Run("rotate only once", func(t *testing.T) {
+		repo := newRepo(t)
+		current := newTestRefreshToken(shared.NewID(), shared.NewUserID(), "phone")
+		if err := repo.Save(ctx, current); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		next := newTestRefreshToken(current.FamilyID, current.UserID, current.DeviceID)
+		rotatedAt := time.Now().Truncate(time.Microsecond)
+		current.RotatedAt = &rotatedAt
+		current.ReplacedBy = &next.ID
+		if err := repo.Rotate(ctx, current, next); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		got, err := repo.FindByHash(ctx, current.TokenHash)
+		if err != nil {
+			t.Fatalf("FindByHash() error = %v", err)
+		}
+		assertRefreshTokenEqual(t, current, got)
+
+		gotNext, err := repo.FindByHash(ctx, next.TokenHash)
+		if err != nil {
+			t.Fatalf("FindByHash() next error = %v", err)
+		}
+		assertRefreshTokenEqual(t, next, gotNext)
+
+		// 已換發的 token 不能再換發一次
+		again := newTestRefreshToken(current.FamilyID, current.UserID, current.DeviceID)
+		if err := repo.Rotate(ctx, current, again); !errors.Is(err, shared.ErrRefreshTokenReused) {
+			t.Errorf("Rotate() twice error = %v, want %v", err, shared.ErrRefreshTokenReused)
+		}
+		if _, err := repo.FindByHash(ctx, again.TokenHash); !errors.Is(err, shared.ErrEntityNotFound) {
+			t.Errorf("FindByHash() after failed rotate error = %v, want %v", err, shared.ErrEntityNotFound)
+		}
+	})
+
+	t.Run("revoke family", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+		familyID := shared.NewID()
+		first := newTestRefreshToken(familyID, userID, "phone")
+		second := newTestRefreshToken(familyID, userID, "phone")
+		other := newTestRefreshToken(shared.NewID(), userID, "laptop")
+		for _, token := range []*session.RefreshToken{first, second, other} {
+			if err := repo.Save(ctx, token); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		if err := repo.RevokeFamily(ctx, familyID, time.Now()); err != nil {
+			t.Fatalf("RevokeFamily() error = %v", err)
+		}
+
+		assertRevoked(t, ctx, repo, first, true)
+		assertRevoked(t, ctx, repo, second, true)
+		assertRevoked(t, ctx, repo, other, false)
+
+		// 已撤銷的 token 不能再換發
+		next := newTestRefreshToken(familyID, userID, "phone")
+		rotatedAt := time.Now()
+		second.RotatedAt = &rotatedAt
+		if err := repo.Rotate(ctx, second, next); !errors.Is(err, shared.ErrRefreshTokenReused) {
+			t.Errorf("Rotate() revoked error = %v, want %v", err, shared.ErrRefreshTokenReused)
+		}
+	})
+
+	t.Run("revoke by user and device", func(t *testing.T) {
+		repo := newRepo(t)
+		userID := shared.NewUserID()
+		phone := newTestRefreshToken(shared.NewID(), userID, "phone")
+		laptop := newTestRefreshToken(shared.NewID(), userID, "laptop")
+		stranger := newTestRefreshToken(shared.NewID(), shared.NewUserID(), "phone")
+		for _, token := range []*session.RefreshToken{phone, laptop, stranger} {
+			if err := repo.Save(ctx, token); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+
+		families, err := repo.RevokeByUser(ctx, userID, "phone", time.Now())
+		if err != nil {
+			t.Fatalf("RevokeByUser() error = %v", err)
+		}
+		assertSameIDs(t, "RevokeByUser(phone)", []string{phone.FamilyID.String()}, idStrings(families))
+		assertRevoked(t, ctx, repo, phone, true)
+		assertRevoked(t, ctx, repo, laptop, false)
+
+		families, err = repo.RevokeByUser(ctx, userID, "", time.Now())
+		if err != nil {
+			t.Fatalf("RevokeByUser() error = %v", err)
+		}
+		assertSameIDs(t, "RevokeByUser(all)", []string{laptop.FamilyID.String()}, idStrings(families))
+		assertRevoked(t, ctx, repo, laptop, true)
+		assertRevoked(t, ctx, repo, stranger, false)
+	})
+}
+
+// RunRevocationListContract exercises a session.RevocationList
+// implementation. newList must return an empty list on every call.
+func RunRevocationListContract(t *testing.T, newList func(t *testing.T) session.RevocationList) {
+	ctx := context.Background()
+
+	t.Run("revoke until expiry", func(t *testing.T) {
+		list := newList(t)
+		id := shared.NewID().String()
+
+		assertIsRevoked(t, ctx, list, id, false)
+		if err := list.Revoke(ctx, id, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		assertIsRevoked(t, ctx, list, id, true)
+		assertIsRevoked(t, ctx, list, shared.NewID().String(), false)
+	})
+
+	t.Run("expired entries are ignored", func(t *testing.T) {
+		list := newList(t)
+		id := shared.NewID().String()
+
+		if err := list.Revoke(ctx, id, time.Now().Add(-time.Minute)); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		assertIsRevoked(t, ctx, list, id, false)
+	})
+
+	t.Run("revoking again keeps the later expiry", func(t *testing.T) {
+		list := newList(t)
+		id := shared.NewID().String()
+
+		if err := list.Revoke(ctx, id, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		if err := list.Revoke(ctx, id, time.Now().Add(-time.Minute)); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		assertIsRevoked(t, ctx, list, id, true)
+	})
+}
+
+func newTestRefreshToken(familyID shared.ID, userID shared.UserID, deviceID string) *session.RefreshToken {
+	now := time.Now().Truncate(time.Microsecond)
+	return &session.RefreshToken{
+		ID:        shared.NewID(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		DeviceID:  deviceID,
+		TokenHash: session.HashToken(shared.NewID().String()),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+}
+
+func assertRefreshTokenEqual(t *testing.T, want, got *session.RefreshToken) {
+	t.Helper()
+	if got.ID != want.ID || got.FamilyID != want.FamilyID || got.UserID != want.UserID {
+		t.Errorf("token IDs = (%v, %v, %v), want (%v, %v, %v)", got.ID, got.FamilyID, got.UserID, want.ID, want.FamilyID, want.UserID)
+	}
+	if got.DeviceID != want.DeviceID {
+		t.Errorf("DeviceID = %q, want %q", got.DeviceID, want.DeviceID)
+	}
+	if got.TokenHash != want.TokenHash {
+		t.Errorf("TokenHash = %q, want %q", got.TokenHash, want.TokenHash)
+	}
+	assertTimeEqual(t, "CreatedAt", want.CreatedAt, got.CreatedAt)
+	assertTimeEqual(t, "ExpiresAt", want.ExpiresAt, got.ExpiresAt)
+	assertOptionalTimeEqual(t, "RotatedAt", want.RotatedAt, got.RotatedAt)
+	assertOptionalTimeEqual(t, "RevokedAt", want.RevokedAt, got.RevokedAt)
+	if (want.ReplacedBy == nil) != (got.ReplacedBy == nil) || (want.ReplacedBy != nil && *want.ReplacedBy != *got.ReplacedBy) {
+		t.Errorf("ReplacedBy = %v, want %v", got.ReplacedBy, want.ReplacedBy)
+	}
+}
+
+func assertRevoked(t *testing.T, ctx context.Context, repo session.RefreshTokenRepository, token *session.RefreshToken, want bool) {
+	t.Helper()
+	got, err := repo.FindByHash(ctx, token.TokenHash)
+	if err != nil {
+		t.Fatalf("FindByHash() error = %v", err)
+	}
+	if got.IsRevoked() != want {
+		t.Errorf("token %v revoked = %v, want %v", token.ID, got.IsRevoked(), want)
+	}
+}
+
+func assertIsRevoked(t *testing.T, ctx context.Context, list session.RevocationList, id string, want bool) {
+	t.Helper()
+	got, err := list.IsRevoked(ctx, id)
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("IsRevoked(%s) = %v, want %v", id, got, want)
+	}
+}
+
+func idStrings(ids []shared.ID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
@@ -0,0 +1,244 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// FriendshipModel represents the database model for Friendship
+type FriendshipModel struct {
+	ID          string `gorm:"type:uuid;primary_key"`
+	RequesterID string `gorm:"type:uuid;index;not null"`
+	AddresseeID string `gorm:"type:uuid;index;not null"`
+	Status      int    `gorm:"index;not null"`
+	Message     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time `gorm:"autoUpdateTime:false"`
+	AcceptedAt  *time.Time
+}
+
+func (FriendshipModel) TableName() string {
+	return "friendships"
+}
+
+// PostgreSQLFriendshipRepository implements friendship.FriendshipRepository
+type PostgreSQLFriendshipRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLFriendshipRepository(db *gorm.DB) *PostgreSQLFriendshipRepository {
+	return &PostgreSQLFriendshipRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLFriendshipRepository) Save(ctx context.Context, f *friendship.Friendship) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(r.domainToModel(f)).Error; err != nil {
+			return err
+		}
+		return WriteOutbox(tx, f)
+	})
+	if err != nil {
+		return err
+	}
+	f.ClearEvents()
+	return nil
+}
+
+func (r *PostgreSQLFriendshipRepository) Update(ctx context.Context, f *friendship.Friendship) error {
+	model := r.domainToModel(f)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&FriendshipModel{}).
+			Where("id = ?", model.ID).
+			Select("*").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return shared.ErrEntityNotFound
+		}
+		return WriteOutbox(tx, f)
+	})
+	if err != nil {
+		return err
+	}
+	f.ClearEvents()
+	return nil
+}
+
+func (r *PostgreSQLFriendshipRepository) FindByID(ctx context.Context, id shared.FriendshipID) (*friendship.Friendship, error) {
+	var model FriendshipModel
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrEntityNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLFriendshipRepository) FindByUsers(ctx context.Context, userID1, userID2 shared.UserID) (*friendship.Friendship, error) {
+	var model FriendshipModel
+	result := r.db.WithContext(ctx).
+		Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
+			userID1.String(), userID2.String(), userID2.String(), userID1.String()).
+		Order("created_at ASC").
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrEntityNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLFriendshipRepository) FindFriendsByUserID(ctx context.Context, userID shared.UserID, limit, offset int) ([]*friendship.Friendship, error) {
+	var models []FriendshipModel
+	result := r.db.WithContext(ctx).
+		Where("status = ?", int(friendship.StatusAccepted)).
+		Where("requester_id = ? OR addressee_id = ?", userID.String(), userID.String()).
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLFriendshipRepository) FindPendingRequestsByUserID(ctx context.Context, userID shared.UserID, limit, offset int) ([]*friendship.Friendship, error) {
+	var models []FriendshipModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND addressee_id = ?", int(friendship.StatusPending), userID.String()).
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLFriendshipRepository) FindSentRequestsByUserID(ctx context.Context, userID shared.UserID, limit, offset int) ([]*friendship.Friendship, error) {
+	var models []FriendshipModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND requester_id = ?", int(friendship.StatusPending), userID.String()).
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLFriendshipRepository) FindBlockedByUserID(ctx context.Context, userID shared.UserID) ([]*friendship.Friendship, error) {
+	var models []FriendshipModel
+	result := r.db.WithContext(ctx).
+		Where("status = ?", int(friendship.StatusBlocked)).
+		Where("requester_id = ? OR addressee_id = ?", userID.String(), userID.String()).
+		Order("created_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLFriendshipRepository) CountFriendsByUserID(ctx context.Context, userID shared.UserID) (int, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&FriendshipModel{}).
+		Where("status = ?", int(friendship.StatusAccepted)).
+		Where("requester_id = ? OR addressee_id = ?", userID.String(), userID.String()).
+		Count(&count)
+	return int(count), result.Error
+}
+
+func (r *PostgreSQLFriendshipRepository) ExistsBetweenUsers(ctx context.Context, userID1, userID2 shared.UserID) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&FriendshipModel{}).
+		Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
+			userID1.String(), userID2.String(), userID2.String(), userID1.String()).
+		Count(&count)
+	return count > 0, result.Error
+}
+
+func (r *PostgreSQLFriendshipRepository) Delete(ctx context.Context, id shared.FriendshipID) error {
+	result := r.db.WithContext(ctx).Delete(&FriendshipModel{}, "id = ?", id.String())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrEntityNotFound
+	}
+	return nil
+}
+
+// Helper methods for conversion
+func (r *PostgreSQLFriendshipRepository) domainToModel(f *friendship.Friendship) *FriendshipModel {
+	return &FriendshipModel{
+		ID:          f.ID.String(),
+		RequesterID: f.RequesterID.String(),
+		AddresseeID: f.AddresseeID.String(),
+		Status:      int(f.Status),
+		Message:     f.Message,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+		AcceptedAt:  f.AcceptedAt,
+	}
+}
+
+func (r *PostgreSQLFriendshipRepository) modelToDomain(m *FriendshipModel) (*friendship.Friendship, error) {
+	id, err := shared.NewFriendshipIDFromString(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	requesterID, err := shared.NewUserIDFromString(m.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresseeID, err := shared.NewUserIDFromString(m.AddresseeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &friendship.Friendship{
+		ID:          id,
+		RequesterID: requesterID,
+		AddresseeID: addresseeID,
+		Status:      friendship.FriendshipStatus(m.Status),
+		Message:     m.Message,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		AcceptedAt:  m.AcceptedAt,
+	}, nil
+}
+
+func (r *PostgreSQLFriendshipRepository) modelsToDomain(models []FriendshipModel) ([]*friendship.Friendship, error) {
+	friendships := make([]*friendship.Friendship, len(models))
+	for i := range models {
+		f, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		friendships[i] = f
+	}
+	return friendships, nil
+}
@@ -0,0 +1,79 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+)
+
+// ActionTokenRepository implements user.ActionTokenRepository using in-memory storage
+type ActionTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[shared.ID]user.ActionToken
+}
+
+// NewActionTokenRepository creates a new in-memory action token repository
+func NewActionTokenRepository() *ActionTokenRepository {
+	return &ActionTokenRepository{
+		tokens: make(map[shared.ID]user.ActionToken),
+	}
+}
+
+// Save 儲存新的 token
+func (r *ActionTokenRepository) Save(ctx context.Context, token *user.ActionToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.ID] = *token
+	return nil
+}
+
+// FindByID 根據 ID 查找 token
+func (r *ActionTokenRepository) FindByID(ctx context.Context, id shared.ID) (*user.ActionToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return nil, shared.ErrEntityNotFound
+	}
+	return &token, nil
+}
+
+// MarkUsed 將 token 標記為已使用
+func (r *ActionTokenRepository) MarkUsed(ctx context.Context, id shared.ID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return shared.ErrEntityNotFound
+	}
+	if token.IsUsed() {
+		return shared.ErrActionTokenUsed
+	}
+
+	usedAt := at
+	token.UsedAt = &usedAt
+	r.tokens[id] = token
+	return nil
+}
+
+// InvalidateForUser 讓用戶同用途且尚未使用的 token 全部失效
+func (r *ActionTokenRepository) InvalidateForUser(ctx context.Context, userID shared.UserID, purpose user.TokenPurpose, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserID != userID || token.Purpose != purpose || token.IsUsed() {
+			continue
+		}
+		usedAt := at
+		token.UsedAt = &usedAt
+		r.tokens[id] = token
+	}
+	return nil
+}
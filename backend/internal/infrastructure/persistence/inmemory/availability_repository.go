@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// AvailabilityRepository implements availability.Repository using in-memory storage
+type AvailabilityRepository struct {
+	mu        sync.RWMutex
+	schedules map[shared.UserID]availability.Schedule
+}
+
+// NewAvailabilityRepository creates a new in-memory availability repository
+func NewAvailabilityRepository() *AvailabilityRepository {
+	return &AvailabilityRepository{
+		schedules: make(map[shared.UserID]availability.Schedule),
+	}
+}
+
+// Save 新增或取代用戶的可用時間
+func (r *AvailabilityRepository) Save(ctx context.Context, schedule *availability.Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schedules[schedule.UserID] = copySchedule(schedule)
+	return nil
+}
+
+// FindByUser 取得用戶的可用時間
+func (r *AvailabilityRepository) FindByUser(ctx context.Context, userID shared.UserID) (*availability.Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, exists := r.schedules[userID]
+	if !exists {
+		return nil, shared.ErrAvailabilityNotFound
+	}
+	copied := copySchedule(&schedule)
+	return &copied, nil
+}
+
+// FindByUsers 取得多個用戶的可用時間，依用戶 ID 排序
+func (r *AvailabilityRepository) FindByUsers(ctx context.Context, userIDs []shared.UserID) ([]*availability.Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedules := make([]*availability.Schedule, 0, len(userIDs))
+	seen := make(map[shared.UserID]bool, len(userIDs))
+	for _, userID := range userIDs {
+		schedule, exists := r.schedules[userID]
+		if !exists || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		copied := copySchedule(&schedule)
+		schedules = append(schedules, &copied)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].UserID.String() < schedules[j].UserID.String()
+	})
+	return schedules, nil
+}
+
+// copySchedule 複製 slice，避免呼叫端修改到儲存的資料
+func copySchedule(schedule *availability.Schedule) availability.Schedule {
+	copied := *schedule
+	copied.Windows = append([]availability.WeeklyWindow{}, schedule.Windows...)
+	copied.BusyBlocks = append([]availability.BusyBlock{}, schedule.BusyBlocks...)
+	return copied
+}
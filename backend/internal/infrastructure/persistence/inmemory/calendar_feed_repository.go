@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// CalendarFeedRepository implements calendar.FeedRepository using in-memory storage
+type CalendarFeedRepository struct {
+	mu    sync.RWMutex
+	feeds map[shared.UserID]calendar.Feed
+}
+
+// NewCalendarFeedRepository creates a new in-memory calendar feed repository
+func NewCalendarFeedRepository() *CalendarFeedRepository {
+	return &CalendarFeedRepository{
+		feeds: make(map[shared.UserID]calendar.Feed),
+	}
+}
+
+// Save 新增或取代用戶的訂閱
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed *calendar.Feed) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.feeds[feed.UserID] = *feed
+	return nil
+}
+
+// FindByTokenHash 以 token 的雜湊查找訂閱
+func (r *CalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*calendar.Feed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, feed := range r.feeds {
+		if feed.TokenHash == tokenHash {
+			copied := feed
+			return &copied, nil
+		}
+	}
+	return nil, shared.ErrCalendarFeedNotFound
+}
+
+// Delete 刪除用戶的訂閱
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID shared.UserID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.feeds, userID)
+	return nil
+}
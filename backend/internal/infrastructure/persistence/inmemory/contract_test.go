@@ -0,0 +1,98 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+	"github.com/chun-wei0413/pingnom/internal/domain/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/friendship"
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/contracttest"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+func TestPingRepositoryContract(t *testing.T) {
+	contracttest.RunPingRepositoryContract(t, func(t *testing.T) ping.Repository {
+		return inmemory.NewPingRepository(inmemory.NewOutbox())
+	})
+}
+
+func TestPingSeriesRepositoryContract(t *testing.T) {
+	contracttest.RunPingSeriesRepositoryContract(t, func(t *testing.T) (ping.SeriesRepository, ping.Repository) {
+		return inmemory.NewPingSeriesRepository(), inmemory.NewPingRepository(inmemory.NewOutbox())
+	})
+}
+
+func TestFriendshipRepositoryContract(t *testing.T) {
+	contracttest.RunFriendshipRepositoryContract(t, func(t *testing.T) friendship.FriendshipRepository {
+		return inmemory.NewInMemoryFriendshipRepository(inmemory.NewOutbox())
+	})
+}
+
+func TestRestaurantRepositoryContract(t *testing.T) {
+	contracttest.RunRestaurantRepositoryContract(t, func(t *testing.T) restaurant.Repository {
+		return inmemory.NewRestaurantRepository()
+	})
+}
+
+func TestRefreshTokenRepositoryContract(t *testing.T) {
+	contracttest.RunRefreshTokenRepositoryContract(t, func(t *testing.T) session.RefreshTokenRepository {
+		return inmemory.NewRefreshTokenRepository()
+	})
+}
+
+func TestRevocationListContract(t *testing.T) {
+	contracttest.RunRevocationListContract(t, func(t *testing.T) session.RevocationList {
+		return inmemory.NewRevocationList()
+	})
+}
+
+func TestActionTokenRepositoryContract(t *testing.T) {
+	contracttest.RunActionTokenRepositoryContract(t, func(t *testing.T) user.ActionTokenRepository {
+		return inmemory.NewActionTokenRepository()
+	})
+}
+
+func TestOutboxContract(t *testing.T) {
+	contracttest.RunOutboxContract(t, func(t *testing.T) (ping.Repository, events.OutboxStore) {
+		outbox := inmemory.NewOutbox()
+		return inmemory.NewPingRepository(outbox), outbox
+	})
+}
+
+func TestNotificationRepositoryContract(t *testing.T) {
+	contracttest.RunNotificationRepositoryContract(t, func(t *testing.T) notification.Repository {
+		return inmemory.NewNotificationRepository()
+	})
+}
+
+func TestSchedulerStoreContract(t *testing.T) {
+	contracttest.RunSchedulerStoreContract(t, func(t *testing.T) (scheduler.LeaseStore, scheduler.RunStore) {
+		return inmemory.NewLeaseStore(), inmemory.NewJobRunStore()
+	})
+}
+
+func TestAvailabilityRepositoryContract(t *testing.T) {
+	contracttest.RunAvailabilityRepositoryContract(t, func(t *testing.T) availability.Repository {
+		return inmemory.NewAvailabilityRepository()
+	})
+}
+
+func TestCalendarFeedRepositoryContract(t *testing.T) {
+	contracttest.RunCalendarFeedRepositoryContract(t, func(t *testing.T) calendar.FeedRepository {
+		return inmemory.NewCalendarFeedRepository()
+	})
+}
+
+func TestReviewRepositoryContract(t *testing.T) {
+	contracttest.RunReviewRepositoryContract(t, func(t *testing.T) review.Repository {
+		return inmemory.NewReviewRepository()
+	})
+}
@@ -13,13 +13,16 @@ type InMemoryFriendshipRepository struct {
 	mu          sync.RWMutex
 	friendships map[string]*friendship.Friendship // key: FriendshipID
 	userIndex   map[string][]string               // key: UserID, value: []FriendshipID
+	outbox      *Outbox
 }
 
 // NewInMemoryFriendshipRepository 建立新的 InMemory 好友關係儲存庫
-func NewInMemoryFriendshipRepository() *InMemoryFriendshipRepository {
+// 聚合記錄的事件寫入 outbox；outbox 為 nil 時捨棄事件
+func NewInMemoryFriendshipRepository(outbox *Outbox) *InMemoryFriendshipRepository {
 	return &InMemoryFriendshipRepository{
 		friendships: make(map[string]*friendship.Friendship),
 		userIndex:   make(map[string][]string),
+		outbox:      outbox,
 	}
 }
 
@@ -28,8 +31,12 @@ func (r *InMemoryFriendshipRepository) Save(ctx context.Context, f *friendship.F
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if err := r.outbox.Append(f); err != nil {
+		return err
+	}
 	friendshipID := f.ID.String()
 	r.friendships[friendshipID] = f
+	f.ClearEvents()
 
 	// 更新用戶索引
 	r.addToUserIndex(f.RequesterID.String(), friendshipID)
@@ -48,7 +55,11 @@ func (r *InMemoryFriendshipRepository) Update(ctx context.Context, f *friendship
 		return shared.ErrEntityNotFound
 	}
 
+	if err := r.outbox.Append(f); err != nil {
+		return err
+	}
 	r.friendships[friendshipID] = f
+	f.ClearEvents()
 	return nil
 }
 
@@ -142,6 +153,22 @@ func (r *InMemoryFriendshipRepository) FindSentRequestsByUserID(ctx context.Cont
 	return r.applyPagination(sentRequests, limit, offset), nil
 }
 
+// FindBlockedByUserID 獲取用戶所有的封鎖關係（不論是封鎖者或被封鎖者）
+func (r *InMemoryFriendshipRepository) FindBlockedByUserID(ctx context.Context, userID shared.UserID) ([]*friendship.Friendship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var blocked []*friendship.Friendship
+	for _, fID := range r.userIndex[userID.String()] {
+		f := r.friendships[fID]
+		if f != nil && f.IsBlocked() {
+			blocked = append(blocked, f)
+		}
+	}
+
+	return blocked, nil
+}
+
 // CountFriendsByUserID 計算用戶的朋友數量
 func (r *InMemoryFriendshipRepository) CountFriendsByUserID(ctx context.Context, userID shared.UserID) (int, error) {
 	r.mu.RLock()
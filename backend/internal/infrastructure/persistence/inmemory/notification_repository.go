@@ -0,0 +1,116 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// NotificationRepository implements notification.Repository using in-memory storage
+type NotificationRepository struct {
+	mu            sync.RWMutex
+	notifications map[shared.ID]notification.Notification
+}
+
+// NewNotificationRepository creates a new in-memory notification repository
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: make(map[shared.ID]notification.Notification),
+	}
+}
+
+// Save 儲存新通知，同一用戶相同 DedupKey 的通知只保留第一則
+func (r *NotificationRepository) Save(ctx context.Context, n *notification.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n.DedupKey != "" {
+		for _, existing := range r.notifications {
+			if existing.UserID == n.UserID && existing.DedupKey == n.DedupKey {
+				return shared.ErrDuplicateNotification
+			}
+		}
+	}
+
+	r.notifications[n.ID] = *n
+	return nil
+}
+
+// FindByUser 依建立時間由新到舊列出用戶的通知
+func (r *NotificationRepository) FindByUser(ctx context.Context, userID shared.UserID, unreadOnly bool, limit, offset int) ([]*notification.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*notification.Notification
+	for _, n := range r.notifications {
+		if n.UserID != userID || (unreadOnly && n.IsRead()) {
+			continue
+		}
+		copied := n
+		matched = append(matched, &copied)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID.String() < matched[j].ID.String()
+		}
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	if offset >= len(matched) {
+		return []*notification.Notification{}, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// CountUnread 計算用戶的未讀通知數
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID shared.UserID) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, n := range r.notifications {
+		if n.UserID == userID && !n.IsRead() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MarkRead 將用戶的一則通知標記為已讀
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID shared.UserID, id shared.ID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, exists := r.notifications[id]
+	if !exists || n.UserID != userID {
+		return shared.ErrNotificationNotFound
+	}
+	n.MarkRead(at)
+	r.notifications[id] = n
+	return nil
+}
+
+// MarkAllRead 將用戶所有未讀通知標記為已讀
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID shared.UserID, at time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var updated int64
+	for id, n := range r.notifications {
+		if n.UserID != userID || n.IsRead() {
+			continue
+		}
+		n.MarkRead(at)
+		r.notifications[id] = n
+		updated++
+	}
+	return updated, nil
+}
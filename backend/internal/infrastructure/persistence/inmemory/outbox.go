@@ -0,0 +1,143 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// Outbox implements events.OutboxStore in memory. The in-memory repositories
+// append to it while holding their own lock, which stands in for the
+// transaction the PostgreSQL repositories use.
+type Outbox struct {
+	mu      sync.Mutex
+	entries []*outboxEntry
+	index   map[string]*outboxEntry
+}
+
+type outboxEntry struct {
+	message      events.Message
+	availableAt  time.Time
+	lockedUntil  *time.Time
+	dispatchedAt *time.Time
+	failedAt     *time.Time
+	lastError    string
+}
+
+// NewOutbox creates an empty in-memory outbox
+func NewOutbox() *Outbox {
+	return &Outbox{
+		index: make(map[string]*outboxEntry),
+	}
+}
+
+// Append stores the pending events of source. A nil outbox discards them,
+// which lets tests use the repositories without wiring a dispatcher.
+func (o *Outbox) Append(source shared.EventSource) error {
+	if o == nil {
+		return nil
+	}
+
+	messages, err := events.NewMessages(source.PendingEvents())
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, message := range messages {
+		entry := &outboxEntry{
+			message:     message,
+			availableAt: message.OccurredAt,
+		}
+		o.entries = append(o.entries, entry)
+		o.index[message.ID] = entry
+	}
+	return nil
+}
+
+func (o *Outbox) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.Message, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	var claimable []*outboxEntry
+	for _, entry := range o.entries {
+		if entry.dispatchedAt != nil || entry.failedAt != nil || entry.availableAt.After(now) {
+			continue
+		}
+		if entry.lockedUntil != nil && entry.lockedUntil.After(now) {
+			continue
+		}
+		claimable = append(claimable, entry)
+	}
+	sort.SliceStable(claimable, func(i, j int) bool {
+		return claimable[i].message.OccurredAt.Before(claimable[j].message.OccurredAt)
+	})
+	if limit > 0 && len(claimable) > limit {
+		claimable = claimable[:limit]
+	}
+
+	lockedUntil := now.Add(lease)
+	messages := make([]events.Message, len(claimable))
+	for i, entry := range claimable {
+		entry.lockedUntil = &lockedUntil
+		messages[i] = entry.message
+	}
+	return messages, nil
+}
+
+func (o *Outbox) MarkDispatched(ctx context.Context, id string, dispatchedAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, exists := o.index[id]
+	if !exists {
+		return shared.ErrEntityNotFound
+	}
+	entry.dispatchedAt = &dispatchedAt
+	entry.lockedUntil = nil
+	return nil
+}
+
+func (o *Outbox) MarkFailed(ctx context.Context, id string, reason string, retryAt *time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, exists := o.index[id]
+	if !exists {
+		return shared.ErrEntityNotFound
+	}
+	entry.message.Attempts++
+	entry.lastError = reason
+	entry.lockedUntil = nil
+	if retryAt != nil {
+		entry.availableAt = *retryAt
+	} else {
+		now := time.Now()
+		entry.failedAt = &now
+	}
+	return nil
+}
+
+func (o *Outbox) PurgeDispatched(ctx context.Context, before time.Time) (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var purged int64
+	kept := o.entries[:0]
+	for _, entry := range o.entries {
+		if entry.dispatchedAt != nil && entry.dispatchedAt.Before(before) {
+			delete(o.index, entry.message.ID)
+			purged++
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	o.entries = kept
+	return purged, nil
+}
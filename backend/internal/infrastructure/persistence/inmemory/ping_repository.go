@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/chun-wei0413/pingnom/internal/domain/ping"
 	"github.com/chun-wei0413/pingnom/internal/domain/shared"
@@ -11,14 +12,17 @@ import (
 
 // PingRepository implements ping.Repository using in-memory storage
 type PingRepository struct {
-	pings map[string]*ping.Ping
-	mu    sync.RWMutex
+	pings  map[string]*ping.Ping
+	outbox *Outbox
+	mu     sync.RWMutex
 }
 
 // NewPingRepository creates a new in-memory ping repository
-func NewPingRepository() *PingRepository {
+// 聚合記錄的事件寫入 outbox；outbox 為 nil 時捨棄事件
+func NewPingRepository(outbox *Outbox) *PingRepository {
 	return &PingRepository{
-		pings: make(map[string]*ping.Ping),
+		pings:  make(map[string]*ping.Ping),
+		outbox: outbox,
 	}
 }
 
@@ -27,7 +31,11 @@ func (r *PingRepository) Create(ctx context.Context, p *ping.Ping) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
+	if err := r.outbox.Append(p); err != nil {
+		return err
+	}
 	r.pings[p.ID().String()] = p
+	p.ClearEvents()
 	return nil
 }
 
@@ -54,7 +62,11 @@ func (r *PingRepository) Update(ctx context.Context, p *ping.Ping) error {
 		return shared.ErrPingNotFound
 	}
 	
+	if err := r.outbox.Append(p); err != nil {
+		return err
+	}
 	r.pings[p.ID().String()] = p
+	p.ClearEvents()
 	return nil
 }
 
@@ -116,14 +128,19 @@ func (r *PingRepository) GetByInvitee(ctx context.Context, inviteeID shared.User
 }
 
 // GetActivePings retrieves all active pings for a user (created by or invited to)
-func (r *PingRepository) GetActivePings(ctx context.Context, userID shared.UserID, limit, offset int) ([]*ping.Ping, error) {
+func (r *PingRepository) GetActivePings(ctx context.Context, userID shared.UserID, excludeCreators []shared.UserID, limit, offset int) ([]*ping.Ping, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	
+	excluded := make(map[shared.UserID]bool, len(excludeCreators))
+	for _, creator := range excludeCreators {
+		excluded[creator] = true
+	}
+	
 	var result []*ping.Ping
 	for _, p := range r.pings {
 		// Include if user is creator or invitee and ping is active
-		if p.Status() == ping.PingStatusActive {
+		if p.Status() == ping.PingStatusActive && !excluded[p.CreatedBy()] {
 			if p.CreatedBy() == userID {
 				result = append(result, p)
 				continue
@@ -167,6 +184,77 @@ func (r *PingRepository) GetPingsByStatus(ctx context.Context, status ping.PingS
 	return paginateSlice(result, limit, offset), nil
 }
 
+// GetScheduledBetween retrieves pings with the given status scheduled in [from, to)
+func (r *PingRepository) GetScheduledBetween(ctx context.Context, status ping.PingStatus, from, to time.Time) ([]*ping.Ping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	var result []*ping.Ping
+	for _, p := range r.pings {
+		if p.Status() == status && !p.ScheduledAt().Before(from) && p.ScheduledAt().Before(to) {
+			result = append(result, p)
+		}
+	}
+	
+	// Sort by scheduled time (earliest first)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ScheduledAt().Before(result[j].ScheduledAt())
+	})
+	
+	return result, nil
+}
+
+// GetUpcomingForUser retrieves pings of any status created by or inviting a user scheduled at or after from
+func (r *PingRepository) GetUpcomingForUser(ctx context.Context, userID shared.UserID, from time.Time) ([]*ping.Ping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	var result []*ping.Ping
+	for _, p := range r.pings {
+		if p.ScheduledAt().Before(from) {
+			continue
+		}
+		if p.CreatedBy() == userID {
+			result = append(result, p)
+			continue
+		}
+		for _, invitee := range p.Invitees() {
+			if invitee == userID {
+				result = append(result, p)
+				break
+			}
+		}
+	}
+	
+	// Sort by scheduled time (earliest first)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ScheduledAt().Before(result[j].ScheduledAt())
+	})
+	
+	return result, nil
+}
+
+// GetBySeries retrieves the occurrences of a recurring series whose original time is at or after from
+func (r *PingRepository) GetBySeries(ctx context.Context, seriesID shared.ID, from time.Time) ([]*ping.Ping, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	var result []*ping.Ping
+	for _, p := range r.pings {
+		occurrence := p.Occurrence()
+		if occurrence != nil && occurrence.SeriesID == seriesID && !occurrence.OccurrenceAt.Before(from) {
+			result = append(result, p)
+		}
+	}
+	
+	// Sort by original occurrence time
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Occurrence().OccurrenceAt.Before(result[j].Occurrence().OccurrenceAt)
+	})
+	
+	return result, nil
+}
+
 // Helper function to paginate slice
 func paginateSlice[T any](slice []T, limit, offset int) []T {
 	if offset >= len(slice) {
@@ -0,0 +1,93 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// PingSeriesRepository implements ping.SeriesRepository using in-memory storage
+type PingSeriesRepository struct {
+	series map[string]*ping.Series
+	mu     sync.RWMutex
+}
+
+// NewPingSeriesRepository creates a new in-memory ping series repository
+func NewPingSeriesRepository() *PingSeriesRepository {
+	return &PingSeriesRepository{
+		series: make(map[string]*ping.Series),
+	}
+}
+
+// Create stores a new series
+func (r *PingSeriesRepository) Create(ctx context.Context, s *ping.Series) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.series[s.ID().String()] = s
+	return nil
+}
+
+// GetByID retrieves a series by ID
+func (r *PingSeriesRepository) GetByID(ctx context.Context, id shared.ID) (*ping.Series, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, exists := r.series[id.String()]
+	if !exists {
+		return nil, ping.ErrSeriesNotFound
+	}
+	return s, nil
+}
+
+// Update updates an existing series
+func (r *PingSeriesRepository) Update(ctx context.Context, s *ping.Series) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.series[s.ID().String()]; !exists {
+		return ping.ErrSeriesNotFound
+	}
+	r.series[s.ID().String()] = s
+	return nil
+}
+
+// GetForUser retrieves series that have not been cancelled created by or inviting a user, ordered by start time
+func (r *PingSeriesRepository) GetForUser(ctx context.Context, userID shared.UserID) ([]*ping.Series, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*ping.Series
+	for _, s := range r.series {
+		if s.Status() != ping.SeriesStatusCancelled && s.IsParticipant(userID) {
+			result = append(result, s)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartAt().Before(result[j].StartAt())
+	})
+	return result, nil
+}
+
+// GetDueForMaterialization retrieves active series materialized only up to before until
+func (r *PingSeriesRepository) GetDueForMaterialization(ctx context.Context, until time.Time) ([]*ping.Series, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*ping.Series
+	for _, s := range r.series {
+		if s.Status() == ping.SeriesStatusActive && s.MaterializedUntil().Before(until) {
+			result = append(result, s)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MaterializedUntil().Before(result[j].MaterializedUntil())
+	})
+	return result, nil
+}
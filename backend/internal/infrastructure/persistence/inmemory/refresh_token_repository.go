@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// RefreshTokenRepository implements session.RefreshTokenRepository using in-memory storage
+// 儲存複本而非指標，避免呼叫端修改實體時繞過 Rotate 的檢查
+type RefreshTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]session.RefreshToken // key: TokenHash
+}
+
+// NewRefreshTokenRepository creates a new in-memory refresh token repository
+func NewRefreshTokenRepository() *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		tokens: make(map[string]session.RefreshToken),
+	}
+}
+
+// Save 儲存新的 refresh token
+func (r *RefreshTokenRepository) Save(ctx context.Context, token *session.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tokens[token.TokenHash] = *token
+	return nil
+}
+
+// FindByHash 根據 token 雜湊值查找
+func (r *RefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.tokens[tokenHash]
+	if !exists {
+		return nil, shared.ErrEntityNotFound
+	}
+	return &token, nil
+}
+
+// Rotate 將 current 標記為已換發並儲存 next
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, current, next *session.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.tokens[current.TokenHash]
+	if !exists {
+		return shared.ErrEntityNotFound
+	}
+	if stored.IsRotated() || stored.IsRevoked() {
+		return shared.ErrRefreshTokenReused
+	}
+
+	r.tokens[current.TokenHash] = *current
+	r.tokens[next.TokenHash] = *next
+	return nil
+}
+
+// RevokeFamily 撤銷同一 family 中所有尚未撤銷的 token
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID shared.ID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, token := range r.tokens {
+		if token.FamilyID == familyID && !token.IsRevoked() {
+			revokedAt := at
+			token.RevokedAt = &revokedAt
+			r.tokens[hash] = token
+		}
+	}
+	return nil
+}
+
+// RevokeByUser 撤銷用戶 (或指定裝置) 所有尚未撤銷的 token
+func (r *RefreshTokenRepository) RevokeByUser(ctx context.Context, userID shared.UserID, deviceID string, at time.Time) ([]shared.ID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[shared.ID]bool)
+	var families []shared.ID
+	for hash, token := range r.tokens {
+		if token.UserID != userID || token.IsRevoked() {
+			continue
+		}
+		if deviceID != "" && token.DeviceID != deviceID {
+			continue
+		}
+
+		revokedAt := at
+		token.RevokedAt = &revokedAt
+		r.tokens[hash] = token
+
+		if !seen[token.FamilyID] {
+			seen[token.FamilyID] = true
+			families = append(families, token.FamilyID)
+		}
+	}
+	return families, nil
+}
@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+)
+
+// geoIndexPrecisions 同時維護多種長度的 geohash 網格，查詢時依半徑挑選合適的一層
+// 長度 2 約 1250x625 公里，長度 6 約 1.2x0.6 公里
+var geoIndexPrecisions = []int{2, 3, 4, 5, 6}
+
+// geoIndexMaxCells 單次查詢最多展開的網格數，超過時退回全表掃描
+const geoIndexMaxCells = 128
+
+// restaurantGeoIndex 以 geohash 網格索引餐廳位置，查詢只需檢查涵蓋範圍內的網格
+// 非並行安全，由 RestaurantRepository 的鎖保護
+type restaurantGeoIndex struct {
+	// cells[i] 對應 geoIndexPrecisions[i]：geohash -> 餐廳 ID 集合
+	cells []map[string]map[string]struct{}
+	// entries 記錄每間餐廳被索引的網格，位置變更時據此移除舊紀錄
+	entries map[string][]string
+}
+
+func newRestaurantGeoIndex() *restaurantGeoIndex {
+	cells := make([]map[string]map[string]struct{}, len(geoIndexPrecisions))
+	for i := range cells {
+		cells[i] = make(map[string]map[string]struct{})
+	}
+	return &restaurantGeoIndex{
+		cells:   cells,
+		entries: make(map[string][]string),
+	}
+}
+
+// put 新增或更新餐廳位置
+func (g *restaurantGeoIndex) put(id string, location restaurant.Location) {
+	g.remove(id)
+
+	hashes := make([]string, len(geoIndexPrecisions))
+	for i, precision := range geoIndexPrecisions {
+		hash := restaurant.EncodeGeohash(location.Latitude, location.Longitude, precision)
+		ids, exists := g.cells[i][hash]
+		if !exists {
+			ids = make(map[string]struct{})
+			g.cells[i][hash] = ids
+		}
+		ids[id] = struct{}{}
+		hashes[i] = hash
+	}
+	g.entries[id] = hashes
+}
+
+// remove 移除餐廳
+func (g *restaurantGeoIndex) remove(id string) {
+	hashes, exists := g.entries[id]
+	if !exists {
+		return
+	}
+
+	for i, hash := range hashes {
+		ids := g.cells[i][hash]
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(g.cells[i], hash)
+		}
+	}
+	delete(g.entries, id)
+}
+
+// candidates 回傳位於範圍所涵蓋網格內的餐廳 ID，結果仍需以實際距離篩選
+// 範圍過大無法以索引縮小時 ok 為 false，呼叫端應改為全表掃描
+func (g *restaurantGeoIndex) candidates(box restaurant.BoundingBox) (ids []string, ok bool) {
+	for i := len(geoIndexPrecisions) - 1; i >= 0; i-- {
+		precision := geoIndexPrecisions[i]
+		if restaurant.GeohashCellCount(box, precision) > geoIndexMaxCells {
+			continue
+		}
+
+		for _, hash := range restaurant.GeohashCells(box, precision) {
+			for id := range g.cells[i][hash] {
+				ids = append(ids, id)
+			}
+		}
+		return ids, true
+	}
+	return nil, false
+}
@@ -10,8 +10,10 @@ import (
 )
 
 // RestaurantRepository InMemory 餐廳儲存庫實作
+// 位置查詢經由 geohash 索引與經緯度範圍預先篩選，只對候選餐廳計算距離
 type RestaurantRepository struct {
 	restaurants map[string]*restaurant.Restaurant
+	geoIndex    *restaurantGeoIndex
 	mu          sync.RWMutex
 }
 
@@ -19,6 +21,7 @@ type RestaurantRepository struct {
 func NewRestaurantRepository() *RestaurantRepository {
 	return &RestaurantRepository{
 		restaurants: make(map[string]*restaurant.Restaurant),
+		geoIndex:    newRestaurantGeoIndex(),
 	}
 }
 
@@ -28,6 +31,7 @@ func (r *RestaurantRepository) Create(ctx context.Context, rest *restaurant.Rest
 	defer r.mu.Unlock()
 
 	r.restaurants[rest.ID.String()] = rest
+	r.geoIndex.put(rest.ID.String(), rest.Location)
 	return nil
 }
 
@@ -55,13 +59,8 @@ func (r *RestaurantRepository) FindByLocation(ctx context.Context, centerLat, ce
 	}
 
 	var results []*restaurant.Restaurant
-	for _, rest := range r.restaurants {
-		if !rest.IsActive {
-			continue
-		}
-
-		distance := rest.CalculateDistance(centerLocation)
-		if distance <= radiusKm {
+	for _, rest := range r.findWithinRadius(centerLocation, radiusKm) {
+		if rest.IsActive {
 			results = append(results, rest)
 		}
 	}
@@ -82,38 +81,18 @@ func (r *RestaurantRepository) FindNearby(ctx context.Context, location restaura
 	defer r.mu.RUnlock()
 
 	var results []*restaurant.Restaurant
-	for _, rest := range r.restaurants {
-		if !rest.IsActive {
-			continue
-		}
-
-		distance := rest.CalculateDistance(location)
-		if distance <= radiusKm {
-			// 檢查其他篩選條件
-			if r.matchesCriteria(rest, criteria) {
-				results = append(results, rest)
-			}
+	for _, rest := range r.findWithinRadius(location, radiusKm) {
+		// 檢查其他篩選條件
+		if rest.IsActive && criteria.Matches(rest) {
+			results = append(results, rest)
 		}
 	}
 
 	// 排序
-	r.sortResults(results, criteria, location)
+	restaurant.SortRestaurants(results, criteria, location)
 
 	// 分頁
-	if criteria.Limit > 0 {
-		start := criteria.Offset
-		end := start + criteria.Limit
-		if start < len(results) {
-			if end > len(results) {
-				end = len(results)
-			}
-			results = results[start:end]
-		} else {
-			results = []*restaurant.Restaurant{}
-		}
-	}
-
-	return results, nil
+	return criteria.Paginate(results), nil
 }
 
 // Update 更新餐廳資訊
@@ -126,6 +105,7 @@ func (r *RestaurantRepository) Update(ctx context.Context, rest *restaurant.Rest
 	}
 
 	r.restaurants[rest.ID.String()] = rest
+	r.geoIndex.put(rest.ID.String(), rest.Location)
 	return nil
 }
 
@@ -139,6 +119,7 @@ func (r *RestaurantRepository) Delete(ctx context.Context, id shared.RestaurantI
 	}
 
 	delete(r.restaurants, id.String())
+	r.geoIndex.remove(id.String())
 	return nil
 }
 
@@ -177,9 +158,14 @@ func (r *RestaurantRepository) Search(ctx context.Context, criteria restaurant.S
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	candidates := r.restaurants
+	if criteria.CenterLocation != nil && criteria.RadiusKm > 0 {
+		candidates = r.findWithinRadius(*criteria.CenterLocation, criteria.RadiusKm)
+	}
+
 	var results []*restaurant.Restaurant
-	for _, rest := range r.restaurants {
-		if r.matchesCriteria(rest, criteria) {
+	for _, rest := range candidates {
+		if criteria.Matches(rest) {
 			results = append(results, rest)
 		}
 	}
@@ -189,112 +175,40 @@ func (r *RestaurantRepository) Search(ctx context.Context, criteria restaurant.S
 	if criteria.CenterLocation != nil {
 		centerLocation = *criteria.CenterLocation
 	}
-	r.sortResults(results, criteria, centerLocation)
+	restaurant.SortRestaurants(results, criteria, centerLocation)
 
 	// 分頁
-	if criteria.Limit > 0 {
-		start := criteria.Offset
-		end := start + criteria.Limit
-		if start < len(results) {
-			if end > len(results) {
-				end = len(results)
-			}
-			results = results[start:end]
-		} else {
-			results = []*restaurant.Restaurant{}
-		}
-	}
-
-	return results, nil
+	return criteria.Paginate(results), nil
 }
 
-// matchesCriteria 檢查餐廳是否符合篩選條件
-func (r *RestaurantRepository) matchesCriteria(rest *restaurant.Restaurant, criteria restaurant.SearchCriteria) bool {
-	// 檢查活躍狀態
-	if criteria.IsActive != nil && rest.IsActive != *criteria.IsActive {
-		return false
-	}
+// findWithinRadius 回傳距離 center 不超過 radiusKm 的餐廳（含非營業中）
+// 呼叫端需持有讀鎖
+func (r *RestaurantRepository) findWithinRadius(center restaurant.Location, radiusKm float64) map[string]*restaurant.Restaurant {
+	box := restaurant.NewBoundingBox(center, radiusKm)
 
-	// 檢查位置範圍
-	if criteria.CenterLocation != nil && criteria.RadiusKm > 0 {
-		distance := rest.CalculateDistance(*criteria.CenterLocation)
-		if distance > criteria.RadiusKm {
-			return false
+	results := make(map[string]*restaurant.Restaurant)
+	check := func(rest *restaurant.Restaurant) {
+		// 先以經緯度範圍排除，再計算實際距離
+		if !box.Contains(rest.Location.Latitude, rest.Location.Longitude) {
+			return
 		}
-	}
-
-	// 檢查料理類型
-	if len(criteria.CuisineTypes) > 0 {
-		if !rest.MatchesCuisinePreferences(criteria.CuisineTypes) {
-			return false
+		if rest.CalculateDistance(center) <= radiusKm {
+			results[rest.ID.String()] = rest
 		}
 	}
 
-	// 檢查價位範圍
-	if criteria.PriceRange != nil {
-		if !rest.MatchesPriceRange(criteria.PriceRange.MinPrice, criteria.PriceRange.MaxPrice) {
-			return false
+	ids, ok := r.geoIndex.candidates(box)
+	if !ok {
+		for _, rest := range r.restaurants {
+			check(rest)
 		}
+		return results
 	}
 
-	// 檢查飲食限制
-	if len(criteria.DietaryRestrictions) > 0 {
-		if !rest.SupportsDietaryRestrictions(criteria.DietaryRestrictions) {
-			return false
+	for _, id := range ids {
+		if rest, exists := r.restaurants[id]; exists {
+			check(rest)
 		}
 	}
-
-	// 檢查最低評分
-	if criteria.MinRating > 0 && rest.Rating < criteria.MinRating {
-		return false
-	}
-
-	// 檢查預約支援
-	if criteria.AcceptsReservations != nil && rest.AcceptsReservations != *criteria.AcceptsReservations {
-		return false
-	}
-
-	return true
+	return results
 }
-
-// sortResults 對結果進行排序
-func (r *RestaurantRepository) sortResults(results []*restaurant.Restaurant, criteria restaurant.SearchCriteria, centerLocation restaurant.Location) {
-	switch criteria.SortBy {
-	case restaurant.SortByDistance:
-		if criteria.CenterLocation != nil {
-			sort.Slice(results, func(i, j int) bool {
-				distanceI := results[i].CalculateDistance(centerLocation)
-				distanceJ := results[j].CalculateDistance(centerLocation)
-				if criteria.SortOrder == restaurant.OrderDesc {
-					return distanceI > distanceJ
-				}
-				return distanceI < distanceJ
-			})
-		}
-	case restaurant.SortByRating:
-		sort.Slice(results, func(i, j int) bool {
-			if criteria.SortOrder == restaurant.OrderDesc {
-				return results[i].Rating > results[j].Rating
-			}
-			return results[i].Rating < results[j].Rating
-		})
-	case restaurant.SortByPrice:
-		sort.Slice(results, func(i, j int) bool {
-			priceI, _ := results[i].PriceLevel.ToRange()
-			priceJ, _ := results[j].PriceLevel.ToRange()
-			if criteria.SortOrder == restaurant.OrderDesc {
-				return priceI > priceJ
-			}
-			return priceI < priceJ
-		})
-	case restaurant.SortByName:
-		fallthrough
-	default:
-		sort.Slice(results, func(i, j int) bool {
-			if criteria.SortOrder == restaurant.OrderDesc {
-				return results[i].Name > results[j].Name
-			}
-			return results[i].Name < results[j].Name
-		})
-	}
-}
\ No newline at end of file
@@ -0,0 +1,170 @@
+package inmemory_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/persistence/inmemory"
+)
+
+// 台灣本島的經緯度範圍，測試資料隨機分布其中
+const (
+	taiwanMinLat = 21.9
+	taiwanMaxLat = 25.3
+	taiwanMinLon = 120.0
+	taiwanMaxLon = 122.0
+)
+
+func TestRestaurantRepositoryGeoIndexMatchesFullScan(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+	repo, all := seedRestaurants(t, rng, 5000)
+
+	// 移動部分餐廳並刪除部分餐廳，確認索引隨 Update/Delete 更新
+	for _, rest := range all[:500] {
+		rest.Location.Latitude = taiwanMinLat + rng.Float64()*(taiwanMaxLat-taiwanMinLat)
+		rest.Location.Longitude = taiwanMinLon + rng.Float64()*(taiwanMaxLon-taiwanMinLon)
+		if err := repo.Update(ctx, rest); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+	for _, rest := range all[500:1000] {
+		if err := repo.Delete(ctx, rest.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	remaining := append(append([]*restaurant.Restaurant(nil), all[:500]...), all[1000:]...)
+
+	for _, radiusKm := range []float64{0.5, 2, 10, 80, 1000} {
+		center := restaurant.Location{
+			Latitude:  taiwanMinLat + rng.Float64()*(taiwanMaxLat-taiwanMinLat),
+			Longitude: taiwanMinLon + rng.Float64()*(taiwanMaxLon-taiwanMinLon),
+		}
+
+		var want []string
+		for _, rest := range remaining {
+			if rest.CalculateDistance(center) <= radiusKm {
+				want = append(want, rest.ID.String())
+			}
+		}
+
+		got, err := repo.FindByLocation(ctx, center.Latitude, center.Longitude, radiusKm)
+		if err != nil {
+			t.Fatalf("FindByLocation() error = %v", err)
+		}
+		gotIDs := make([]string, len(got))
+		for i, rest := range got {
+			gotIDs[i] = rest.ID.String()
+		}
+
+		sort.Strings(want)
+		sort.Strings(gotIDs)
+		if fmt.Sprint(want) != fmt.Sprint(gotIDs) {
+			t.Errorf("radius %v km: FindByLocation() returned %d restaurants, full scan found %d", radiusKm, len(gotIDs), len(want))
+		}
+	}
+}
+
+func TestRestaurantRepositoryFindNearbyAcrossAntimeridian(t *testing.T) {
+	ctx := context.Background()
+	repo := inmemory.NewRestaurantRepository()
+
+	east := newBenchRestaurant(t, "Suva", -17.70, 179.95)
+	west := newBenchRestaurant(t, "Taveuni", -17.70, -179.95)
+	for _, rest := range []*restaurant.Restaurant{east, west} {
+		if err := repo.Create(ctx, rest); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.FindNearby(ctx, restaurant.Location{Latitude: -17.70, Longitude: 179.99}, 20, restaurant.SearchCriteria{})
+	if err != nil {
+		t.Fatalf("FindNearby() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("FindNearby() returned %d restaurants, want both sides of the antimeridian", len(got))
+	}
+}
+
+// BenchmarkRestaurantRepositoryFindNearby 比較不同資料量下的查詢時間
+// 以索引查詢時耗時取決於範圍內的餐廳數，而非總數
+func BenchmarkRestaurantRepositoryFindNearby(b *testing.B) {
+	ctx := context.Background()
+	center := restaurant.Location{Latitude: 25.0478, Longitude: 121.5170}
+
+	for _, size := range []int{1000, 10000, 100000} {
+		repo, _ := seedRestaurants(b, rand.New(rand.NewSource(1)), size)
+		b.Run(fmt.Sprintf("restaurants=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.FindNearby(ctx, center, 2, restaurant.SearchCriteria{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRestaurantRepositorySearchWithRadius(b *testing.B) {
+	ctx := context.Background()
+	center := restaurant.Location{Latitude: 25.0478, Longitude: 121.5170}
+	repo, _ := seedRestaurants(b, rand.New(rand.NewSource(1)), 100000)
+
+	for _, radiusKm := range []float64{1, 5, 20} {
+		criteria := restaurant.SearchCriteria{
+			CenterLocation: &center,
+			RadiusKm:       radiusKm,
+			SortBy:         restaurant.SortByDistance,
+			Limit:          20,
+		}
+		b.Run(fmt.Sprintf("radius=%vkm", radiusKm), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.Search(ctx, criteria); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// seedRestaurants 以 size 間餐廳填滿儲存庫
+func seedRestaurants(tb testing.TB, rng *rand.Rand, size int) (*inmemory.RestaurantRepository, []*restaurant.Restaurant) {
+	tb.Helper()
+
+	ctx := context.Background()
+	repo := inmemory.NewRestaurantRepository()
+	all := make([]*restaurant.Restaurant, size)
+	for i := range all {
+		rest := newBenchRestaurant(tb, fmt.Sprintf("餐廳 %d", i),
+			taiwanMinLat+rng.Float64()*(taiwanMaxLat-taiwanMinLat),
+			taiwanMinLon+rng.Float64()*(taiwanMaxLon-taiwanMinLon),
+		)
+		if err := repo.Create(ctx, rest); err != nil {
+			tb.Fatalf("Create() error = %v", err)
+		}
+		all[i] = rest
+	}
+	return repo, all
+}
+
+func newBenchRestaurant(tb testing.TB, name string, lat, lon float64) *restaurant.Restaurant {
+	tb.Helper()
+
+	rest, err := restaurant.NewRestaurant(
+		name,
+		"",
+		restaurant.Location{Latitude: lat, Longitude: lon, Address: "台灣"},
+		[]restaurant.CuisineType{restaurant.CuisineTypeTaiwanese},
+		restaurant.PriceLevelBudget,
+		"+886-2-1234-5678",
+	)
+	if err != nil {
+		tb.Fatalf("NewRestaurant() error = %v", err)
+	}
+	return rest
+}
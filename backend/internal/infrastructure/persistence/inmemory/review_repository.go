@@ -0,0 +1,151 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+)
+
+// ReviewRepository implements review.Repository using in-memory storage
+type ReviewRepository struct {
+	mu      sync.RWMutex
+	reviews map[shared.ID]review.Review
+}
+
+// NewReviewRepository creates a new in-memory review repository
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		reviews: make(map[shared.ID]review.Review),
+	}
+}
+
+// Create 儲存新評論，同一用戶對同一次用餐只能有一則評論
+func (r *ReviewRepository) Create(ctx context.Context, rv *review.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.reviews {
+		if existing.UserID == rv.UserID && existing.Visit.Type == rv.Visit.Type && existing.Visit.ID == rv.Visit.ID {
+			return shared.ErrReviewExists
+		}
+	}
+
+	r.reviews[rv.ID] = copyReview(rv)
+	return nil
+}
+
+// FindByID 取得評論
+func (r *ReviewRepository) FindByID(ctx context.Context, id shared.ID) (*review.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rv, ok := r.reviews[id]
+	if !ok {
+		return nil, shared.ErrReviewNotFound
+	}
+	copied := copyReview(&rv)
+	return &copied, nil
+}
+
+// Update 更新評論
+func (r *ReviewRepository) Update(ctx context.Context, rv *review.Review) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reviews[rv.ID]; !ok {
+		return shared.ErrReviewNotFound
+	}
+	r.reviews[rv.ID] = copyReview(rv)
+	return nil
+}
+
+// Delete 刪除評論
+func (r *ReviewRepository) Delete(ctx context.Context, id shared.ID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reviews[id]; !ok {
+		return shared.ErrReviewNotFound
+	}
+	delete(r.reviews, id)
+	return nil
+}
+
+// FindByRestaurant 依指定排序列出餐廳的評論
+func (r *ReviewRepository) FindByRestaurant(ctx context.Context, restaurantID shared.RestaurantID, order review.SortOrder, excludeAuthors []shared.UserID, limit, offset int) ([]*review.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	excluded := make(map[shared.UserID]bool, len(excludeAuthors))
+	for _, author := range excludeAuthors {
+		excluded[author] = true
+	}
+	matched := r.filter(func(rv *review.Review) bool { return rv.RestaurantID == restaurantID && !excluded[rv.UserID] })
+	sort.Slice(matched, func(i, j int) bool {
+		if order == review.SortMostHelpful && matched[i].HelpfulCount() != matched[j].HelpfulCount() {
+			return matched[i].HelpfulCount() > matched[j].HelpfulCount()
+		}
+		return newerReview(matched[i], matched[j])
+	})
+	return paginateReviews(matched, limit, offset), nil
+}
+
+// FindByUser 依建立時間由新到舊列出用戶的評論
+func (r *ReviewRepository) FindByUser(ctx context.Context, userID shared.UserID, limit, offset int) ([]*review.Review, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.filter(func(rv *review.Review) bool { return rv.UserID == userID })
+	sort.Slice(matched, func(i, j int) bool { return newerReview(matched[i], matched[j]) })
+	return paginateReviews(matched, limit, offset), nil
+}
+
+// Summarize 計算餐廳的評分統計
+func (r *ReviewRepository) Summarize(ctx context.Context, restaurantID shared.RestaurantID) (review.RatingSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := r.filter(func(rv *review.Review) bool { return rv.RestaurantID == restaurantID })
+	return review.Summarize(restaurantID, matched), nil
+}
+
+func (r *ReviewRepository) filter(keep func(rv *review.Review) bool) []*review.Review {
+	var matched []*review.Review
+	for _, rv := range r.reviews {
+		if !keep(&rv) {
+			continue
+		}
+		copied := copyReview(&rv)
+		matched = append(matched, &copied)
+	}
+	return matched
+}
+
+func newerReview(a, b *review.Review) bool {
+	if a.CreatedAt.Equal(b.CreatedAt) {
+		return a.ID.String() < b.ID.String()
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+func paginateReviews(reviews []*review.Review, limit, offset int) []*review.Review {
+	if offset >= len(reviews) {
+		return []*review.Review{}
+	}
+	reviews = reviews[offset:]
+	if limit > 0 && len(reviews) > limit {
+		reviews = reviews[:limit]
+	}
+	return reviews
+}
+
+// copyReview 複製評論，避免呼叫端修改切片時影響已儲存的資料
+func copyReview(rv *review.Review) review.Review {
+	copied := *rv
+	copied.PhotoURLs = append([]string{}, rv.PhotoURLs...)
+	copied.HelpfulBy = append([]shared.UserID{}, rv.HelpfulBy...)
+	return copied
+}
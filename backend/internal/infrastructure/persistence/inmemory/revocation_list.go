@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevocationList implements session.RevocationList using in-memory storage
+type RevocationList struct {
+	mu      sync.RWMutex
+	revoked map[string]time.Time // key: jti 或 sid, value: 保留期限
+}
+
+// NewRevocationList creates a new in-memory revocation list
+func NewRevocationList() *RevocationList {
+	return &RevocationList{
+		revoked: make(map[string]time.Time),
+	}
+}
+
+// Revoke 將 id 加入撤銷清單直到 until
+func (l *RevocationList) Revoke(ctx context.Context, id string, until time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 順便清除已過期的項目，避免清單無限成長
+	now := time.Now()
+	for key, expiresAt := range l.revoked {
+		if !now.Before(expiresAt) {
+			delete(l.revoked, key)
+		}
+	}
+
+	if current, exists := l.revoked[id]; !exists || until.After(current) {
+		l.revoked[id] = until
+	}
+	return nil
+}
+
+// IsRevoked 檢查 id 是否仍在撤銷清單中
+func (l *RevocationList) IsRevoked(ctx context.Context, id string) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	until, exists := l.revoked[id]
+	return exists && time.Now().Before(until), nil
+}
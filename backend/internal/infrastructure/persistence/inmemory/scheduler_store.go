@@ -0,0 +1,97 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+)
+
+// LeaseStore implements scheduler.LeaseStore in memory. It only coordinates
+// schedulers inside one process, which is all the in-memory build runs.
+type LeaseStore struct {
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// NewLeaseStore creates an empty in-memory lease store
+func NewLeaseStore() *LeaseStore {
+	return &LeaseStore{
+		leases: make(map[string]lease),
+	}
+}
+
+func (s *LeaseStore) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := s.leases[name]; ok && current.holder != holder && now.Before(current.expiresAt) {
+		return false, nil
+	}
+	s.leases[name] = lease{holder: holder, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (s *LeaseStore) Release(ctx context.Context, name, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.leases[name]; ok && current.holder == holder {
+		delete(s.leases, name)
+	}
+	return nil
+}
+
+// JobRunStore implements scheduler.RunStore in memory
+type JobRunStore struct {
+	mu   sync.RWMutex
+	runs []scheduler.JobRun
+}
+
+// NewJobRunStore creates an empty in-memory job run store
+func NewJobRunStore() *JobRunStore {
+	return &JobRunStore{}
+}
+
+func (s *JobRunStore) RecordRun(ctx context.Context, run scheduler.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append(s.runs, run)
+	return nil
+}
+
+func (s *JobRunStore) LastRun(ctx context.Context, jobName string) (*scheduler.JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var last *scheduler.JobRun
+	for i := range s.runs {
+		run := s.runs[i]
+		if run.JobName == jobName && (last == nil || run.StartedAt.After(last.StartedAt)) {
+			last = &run
+		}
+	}
+	return last, nil
+}
+
+func (s *JobRunStore) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.runs[:0]
+	for _, run := range s.runs {
+		if !run.StartedAt.Before(before) {
+			kept = append(kept, run)
+		}
+	}
+	purged := int64(len(s.runs) - len(kept))
+	s.runs = kept
+	return purged, nil
+}
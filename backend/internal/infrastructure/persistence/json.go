@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// scanJSON decodes a jsonb column into dest, accepting both the []byte and
+// string representations drivers may hand back
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON column", value)
+	}
+}
+
+// StringListJSON stores a string slice in a jsonb column
+type StringListJSON []string
+
+func (l StringListJSON) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(l))
+}
+
+func (l *StringListJSON) Scan(value interface{}) error {
+	return scanJSON(value, l)
+}
+
+// StringMapJSON stores a string map in a jsonb column
+type StringMapJSON map[string]string
+
+func (m StringMapJSON) Value() (driver.Value, error) {
+	if m == nil {
+		return json.Marshal(map[string]string{})
+	}
+	return json.Marshal(map[string]string(m))
+}
+
+func (m *StringMapJSON) Scan(value interface{}) error {
+	return scanJSON(value, m)
+}
+
+// RawJSON stores an already encoded JSON document in a jsonb column
+type RawJSON []byte
+
+func (r RawJSON) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return []byte("null"), nil
+	}
+	return []byte(r), nil
+}
+
+func (r *RawJSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = nil
+	case []byte:
+		*r = append(RawJSON(nil), v...)
+	case string:
+		*r = RawJSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON column", value)
+	}
+	return nil
+}
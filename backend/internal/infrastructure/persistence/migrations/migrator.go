@@ -0,0 +1,362 @@
+// Package migrations applies the versioned SQL schema embedded in this
+// package. Files are named NNNN_description.up.sql / NNNN_description.down.sql
+// and are applied in version order, each inside its own transaction.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+// ErrLocked is returned when another process still holds the migration lock after LockTimeout
+var ErrLocked = errors.New("migrations are locked by another process")
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// lockID is the single row used in the lock table
+const lockID = 1
+
+// DefaultLockTimeout is how long Up and Down wait for another process to release the lock
+const DefaultLockTimeout = 2 * time.Minute
+
+// lockRetryInterval 等待鎖時重試的間隔
+const lockRetryInterval = time.Second
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// schemaMigration records an applied migration
+type schemaMigration struct {
+	Version   int64  `gorm:"primary_key;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// migrationLock is a single-row table guarding against concurrent runs
+type migrationLock struct {
+	ID       int    `gorm:"primary_key;autoIncrement:false"`
+	Holder   string `gorm:"not null"`
+	LockedAt time.Time
+}
+
+func (migrationLock) TableName() string {
+	return "schema_migrations_lock"
+}
+
+// Migrator runs migrations against a database
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	// LockTimeout 等待其他程序 (例如同時啟動的其他實例) 釋放鎖的上限，逾時回傳 ErrLocked
+	LockTimeout time.Duration
+}
+
+// NewMigrator creates a migrator for the embedded SQL migrations
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	sqlFiles, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return NewMigratorFromFS(db, sqlFiles)
+}
+
+// NewMigratorFromFS creates a migrator for the migrations found in fsys
+func NewMigratorFromFS(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:          db,
+		migrations:  migrations,
+		LockTimeout: DefaultLockTimeout,
+	}, nil
+}
+
+// Load reads and validates migration files from fsys, sorted by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		} else if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrations returns the known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies every pending migration and returns those applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		done, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the most recently applied migrations, up to steps of them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		done, err := m.appliedVersions(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			err := db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("rollback of %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			rolledBack = append(rolledBack, migration)
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status lists every known migration and when it was applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return nil, err
+	}
+
+	done, err := m.appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if appliedAt, ok := done[migration.Version]; ok {
+			appliedAt := appliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// Unlock forcibly releases the migration lock, e.g. after a crashed run
+func (m *Migrator) Unlock(ctx context.Context) error {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return err
+	}
+	return db.Delete(&migrationLock{}, "id = ?", lockID).Error
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	db := m.db.WithContext(ctx)
+	if err := m.ensureTables(db); err != nil {
+		return err
+	}
+
+	holder := lockHolder()
+	// 多個實例同時啟動時，後到的等待先到的套用完畢，之後就沒有待套用的 migration
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		acquired, err := m.tryLock(db, holder)
+		if err != nil {
+			return err
+		}
+		if acquired {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			var current migrationLock
+			if err := db.First(&current, "id = ?", lockID).Error; err == nil {
+				return fmt.Errorf("%w (held by %s since %s)", ErrLocked, current.Holder, current.LockedAt.Format(time.RFC3339))
+			}
+			return ErrLocked
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	defer func() {
+		if err := db.Delete(&migrationLock{}, "id = ? AND holder = ?", lockID, holder).Error; err != nil {
+			fmt.Fprintf(os.Stderr, "failed to release migration lock: %v\n", err)
+		}
+	}()
+
+	return fn(db)
+}
+
+// tryLock 嘗試取得鎖，主鍵衝突代表已有其他程序持有鎖
+func (m *Migrator) tryLock(db *gorm.DB, holder string) (bool, error) {
+	result := db.Exec(
+		"INSERT INTO schema_migrations_lock (id, holder, locked_at) VALUES (?, ?, ?) ON CONFLICT (id) DO NOTHING",
+		lockID, holder, time.Now(),
+	)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire migration lock: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (m *Migrator) ensureTables(db *gorm.DB) error {
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    BIGINT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL
+)`).Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+    id        INTEGER PRIMARY KEY,
+    holder    TEXT NOT NULL,
+    locked_at TIMESTAMPTZ NOT NULL
+)`).Error; err != nil {
+		return fmt.Errorf("failed to create schema_migrations_lock: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) appliedVersions(db *gorm.DB) (map[int64]time.Time, error) {
+	var rows []schemaMigration
+	if err := db.Order("version ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	done := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row.AppliedAt
+	}
+	return done, nil
+}
+
+func lockHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
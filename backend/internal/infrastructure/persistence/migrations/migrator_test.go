@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	sqlFiles, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		t.Fatalf("fs.Sub() error = %v", err)
+	}
+
+	migrations, err := Load(sqlFiles)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Load() returned no migrations")
+	}
+
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			t.Errorf("migrations not strictly ordered: %d after %d", migrations[i].Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		wantErr  bool
+		versions []int64
+	}{
+		{
+			name: "sorted by version",
+			files: fstest.MapFS{
+				"0010_second.up.sql":   {Data: []byte("SELECT 2;")},
+				"0010_second.down.sql": {Data: []byte("SELECT -2;")},
+				"0002_first.up.sql":    {Data: []byte("SELECT 1;")},
+				"0002_first.down.sql":  {Data: []byte("SELECT -1;")},
+			},
+			versions: []int64{2, 10},
+		},
+		{
+			name: "missing down file",
+			files: fstest.MapFS{
+				"0001_only_up.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid file name",
+			files: fstest.MapFS{
+				"create_users.sql": {Data: []byte("SELECT 1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "conflicting names for one version",
+			files: fstest.MapFS{
+				"0001_users.up.sql":    {Data: []byte("SELECT 1;")},
+				"0001_people.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("Load() returned %d migrations, want %d", len(migrations), len(tt.versions))
+			}
+			for i, version := range tt.versions {
+				if migrations[i].Version != version {
+					t.Errorf("migrations[%d].Version = %d, want %d", i, migrations[i].Version, version)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS users (
+    id               UUID PRIMARY KEY,
+    email            TEXT NOT NULL,
+    phone_number     TEXT,
+    password_hash    TEXT NOT NULL,
+    profile          JSONB,
+    preferences      JSONB,
+    privacy_settings JSONB,
+    is_active        BOOLEAN NOT NULL DEFAULT TRUE,
+    is_verified      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMPTZ NOT NULL,
+    updated_at       TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_phone_number ON users (phone_number);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS friendships (
+    id           UUID PRIMARY KEY,
+    requester_id UUID NOT NULL,
+    addressee_id UUID NOT NULL,
+    status       BIGINT NOT NULL,
+    message      TEXT,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    accepted_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_friendships_requester_id ON friendships (requester_id);
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee_id ON friendships (addressee_id);
+CREATE INDEX IF NOT EXISTS idx_friendships_status ON friendships (status);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS ping_responses;
+DROP TABLE IF EXISTS pings;
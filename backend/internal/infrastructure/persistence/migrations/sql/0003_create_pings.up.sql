@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS pings (
+    id                 UUID PRIMARY KEY,
+    created_by         UUID NOT NULL,
+    title              TEXT NOT NULL,
+    description        TEXT,
+    ping_type          TEXT NOT NULL,
+    status             TEXT NOT NULL,
+    scheduled_at       TIMESTAMPTZ NOT NULL,
+    location_latitude  DOUBLE PRECISION,
+    location_longitude DOUBLE PRECISION,
+    location_address   TEXT,
+    invitees           JSONB,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pings_created_by ON pings (created_by);
+CREATE INDEX IF NOT EXISTS idx_pings_status ON pings (status);
+CREATE INDEX IF NOT EXISTS idx_pings_scheduled_at ON pings (scheduled_at);
+
+CREATE TABLE IF NOT EXISTS ping_responses (
+    id           UUID PRIMARY KEY,
+    ping_id      UUID NOT NULL REFERENCES pings (id) ON DELETE CASCADE,
+    user_id      UUID NOT NULL,
+    position     BIGINT NOT NULL,
+    status       TEXT NOT NULL,
+    message      TEXT,
+    responded_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ping_responses_ping_id ON ping_responses (ping_id);
+CREATE INDEX IF NOT EXISTS idx_ping_responses_user_id ON ping_responses (user_id);
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS restaurants (
+    id                     UUID PRIMARY KEY,
+    name                   TEXT NOT NULL,
+    description            TEXT,
+    latitude               DOUBLE PRECISION NOT NULL,
+    longitude              DOUBLE PRECISION NOT NULL,
+    address                TEXT NOT NULL,
+    cuisine_types          JSONB,
+    price_level            BIGINT NOT NULL,
+    rating                 DOUBLE PRECISION,
+    total_reviews          BIGINT,
+    phone_number           TEXT,
+    website                TEXT,
+    image_urls             JSONB,
+    opening_hours          JSONB,
+    supported_restrictions JSONB,
+    average_wait_time      BIGINT,
+    accepts_reservations   BOOLEAN NOT NULL,
+    is_active              BOOLEAN NOT NULL,
+    created_at             TIMESTAMPTZ NOT NULL,
+    updated_at             TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_name ON restaurants (name);
+CREATE INDEX IF NOT EXISTS idx_restaurants_rating ON restaurants (rating);
+CREATE INDEX IF NOT EXISTS idx_restaurants_is_active ON restaurants (is_active);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS group_dining_vote_choices;
+DROP TABLE IF EXISTS group_dining_votes;
+DROP TABLE IF EXISTS group_dining_participants;
+DROP TABLE IF EXISTS group_dining_restaurant_options;
+DROP TABLE IF EXISTS group_dining_time_slots;
+DROP TABLE IF EXISTS group_dining_plans;
@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS group_dining_plans (
+    id                      UUID PRIMARY KEY,
+    created_by              TEXT NOT NULL,
+    title                   TEXT NOT NULL,
+    description             TEXT,
+    status                  TEXT NOT NULL,
+    confirmed_time_slot_id  TEXT,
+    confirmed_restaurant_id TEXT,
+    voting_deadline         TIMESTAMPTZ,
+    created_at              TIMESTAMPTZ NOT NULL,
+    updated_at              TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_dining_plans_created_by ON group_dining_plans (created_by);
+CREATE INDEX IF NOT EXISTS idx_group_dining_plans_status ON group_dining_plans (status);
+
+CREATE TABLE IF NOT EXISTS group_dining_time_slots (
+    id          TEXT NOT NULL,
+    plan_id     UUID NOT NULL REFERENCES group_dining_plans (id) ON DELETE CASCADE,
+    position    BIGINT NOT NULL,
+    start_time  TIMESTAMPTZ NOT NULL,
+    end_time    TIMESTAMPTZ NOT NULL,
+    description TEXT,
+    vote_count  BIGINT NOT NULL,
+    PRIMARY KEY (id, plan_id)
+);
+
+CREATE TABLE IF NOT EXISTS group_dining_restaurant_options (
+    id           TEXT NOT NULL,
+    plan_id      UUID NOT NULL REFERENCES group_dining_plans (id) ON DELETE CASCADE,
+    position     BIGINT NOT NULL,
+    name         TEXT NOT NULL,
+    address      TEXT,
+    latitude     DOUBLE PRECISION,
+    longitude    DOUBLE PRECISION,
+    cuisine_type TEXT,
+    vote_count   BIGINT NOT NULL,
+    PRIMARY KEY (id, plan_id)
+);
+
+CREATE TABLE IF NOT EXISTS group_dining_participants (
+    plan_id      UUID NOT NULL REFERENCES group_dining_plans (id) ON DELETE CASCADE,
+    user_id      TEXT NOT NULL,
+    position     BIGINT NOT NULL,
+    display_name TEXT,
+    joined_at    TIMESTAMPTZ NOT NULL,
+    has_voted    BOOLEAN NOT NULL,
+    PRIMARY KEY (plan_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_dining_participants_user_id ON group_dining_participants (user_id);
+
+-- 投票可能早於方案寫入（或方案已刪除），因此 plan_id 不設外鍵
+CREATE TABLE IF NOT EXISTS group_dining_votes (
+    id       UUID PRIMARY KEY,
+    plan_id  UUID NOT NULL,
+    user_id  TEXT NOT NULL,
+    comment  TEXT,
+    voted_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_dining_votes_plan_id ON group_dining_votes (plan_id);
+CREATE INDEX IF NOT EXISTS idx_group_dining_votes_user_id ON group_dining_votes (user_id);
+
+CREATE TABLE IF NOT EXISTS group_dining_vote_choices (
+    id        UUID PRIMARY KEY,
+    vote_id   UUID NOT NULL REFERENCES group_dining_votes (id) ON DELETE CASCADE,
+    position  BIGINT NOT NULL,
+    type      TEXT NOT NULL,
+    option_id TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_dining_vote_choices_vote_id ON group_dining_vote_choices (vote_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS action_tokens (
+    id         UUID PRIMARY KEY,
+    user_id    UUID NOT NULL,
+    purpose    TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_action_tokens_user_purpose ON action_tokens (user_id, purpose);
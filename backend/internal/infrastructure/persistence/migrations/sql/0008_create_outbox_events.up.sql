@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id            UUID PRIMARY KEY,
+    event_type    TEXT NOT NULL,
+    aggregate_id  TEXT NOT NULL,
+    payload       JSONB NOT NULL,
+    occurred_at   TIMESTAMPTZ NOT NULL,
+    attempts      INTEGER NOT NULL DEFAULT 0,
+    last_error    TEXT,
+    available_at  TIMESTAMPTZ NOT NULL,
+    locked_until  TIMESTAMPTZ,
+    dispatched_at TIMESTAMPTZ,
+    failed_at     TIMESTAMPTZ
+);
+
+-- 只索引尚未處理的事件，已派送的事件累積不影響輪詢
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (available_at, occurred_at) WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON outbox_events (dispatched_at) WHERE dispatched_at IS NOT NULL;
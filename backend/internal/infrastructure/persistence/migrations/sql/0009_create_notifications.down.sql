@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN notification_preferences;
+
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id         UUID PRIMARY KEY,
+    user_id    UUID NOT NULL,
+    type       TEXT NOT NULL,
+    title      TEXT NOT NULL,
+    body       TEXT,
+    data       JSONB,
+    dedup_key  TEXT,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created_at ON notifications (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+-- 同一個事件重複派送時不會產生重複的通知
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_user_dedup_key ON notifications (user_id, dedup_key) WHERE dedup_key IS NOT NULL;
+
+-- 通知偏好與 privacy_settings 一樣存在 users，NULL 代表使用預設值
+ALTER TABLE users ADD COLUMN notification_preferences JSONB;
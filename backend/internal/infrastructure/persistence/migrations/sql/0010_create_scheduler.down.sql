@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_group_dining_plans_voting_deadline;
+
+DROP TABLE IF EXISTS job_runs;
+
+DROP TABLE IF EXISTS scheduler_leases;
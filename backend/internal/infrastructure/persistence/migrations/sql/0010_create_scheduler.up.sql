@@ -0,0 +1,24 @@
+-- leader 租約：同一時間只有持有租約的 instance 執行排程工作
+CREATE TABLE IF NOT EXISTS scheduler_leases (
+    name        TEXT PRIMARY KEY,
+    holder      TEXT NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id          UUID PRIMARY KEY,
+    job_name    TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    processed   INTEGER NOT NULL DEFAULT 0,
+    error       TEXT,
+    started_at  TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_name_started_at ON job_runs (job_name, started_at);
+CREATE INDEX IF NOT EXISTS idx_job_runs_started_at ON job_runs (started_at);
+
+-- 排程器定期查詢已過投票截止時間的計畫
+CREATE INDEX IF NOT EXISTS idx_group_dining_plans_voting_deadline ON group_dining_plans (voting_deadline) WHERE status = 'voting';
@@ -0,0 +1,4 @@
+ALTER TABLE group_dining_plans DROP COLUMN finalization;
+ALTER TABLE group_dining_plans DROP COLUMN tie_break_seed;
+ALTER TABLE group_dining_plans DROP COLUMN tie_break;
+ALTER TABLE group_dining_plans DROP COLUMN auto_finalize;
@@ -0,0 +1,6 @@
+-- 自動確認設定：全員投票或投票截止時依票數與平手規則確認計畫
+ALTER TABLE group_dining_plans ADD COLUMN auto_finalize BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE group_dining_plans ADD COLUMN tie_break TEXT NOT NULL DEFAULT '';
+ALTER TABLE group_dining_plans ADD COLUMN tie_break_seed BIGINT NOT NULL DEFAULT 0;
+-- 記錄最後選出時段與餐廳的方式 (票數、平手候選與使用的規則)
+ALTER TABLE group_dining_plans ADD COLUMN finalization JSONB;
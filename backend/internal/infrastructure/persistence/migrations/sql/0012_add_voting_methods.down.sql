@@ -0,0 +1,2 @@
+ALTER TABLE group_dining_vote_choices DROP COLUMN score;
+ALTER TABLE group_dining_plans DROP COLUMN voting_method;
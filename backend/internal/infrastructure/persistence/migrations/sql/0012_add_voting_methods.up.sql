@@ -0,0 +1,4 @@
+-- 每個計畫可選擇投票方式，既有計畫維持認可投票
+ALTER TABLE group_dining_plans ADD COLUMN voting_method TEXT NOT NULL DEFAULT 'approval';
+-- 評分投票的分數 (1-5)，其他投票方式為 0；排序投票沿用 position 作為偏好順序
+ALTER TABLE group_dining_vote_choices ADD COLUMN score INTEGER NOT NULL DEFAULT 0;
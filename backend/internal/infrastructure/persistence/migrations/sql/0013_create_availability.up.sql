@@ -0,0 +1,8 @@
+-- 每個用戶一筆可用時間，每週時段與行程以 JSON 儲存
+CREATE TABLE IF NOT EXISTS availability_schedules (
+    user_id     UUID PRIMARY KEY,
+    time_zone   TEXT NOT NULL,
+    windows     JSONB NOT NULL,
+    busy_blocks JSONB NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
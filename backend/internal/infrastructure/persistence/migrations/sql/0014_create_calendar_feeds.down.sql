@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_calendar_feeds_token_hash;
+
+DROP TABLE IF EXISTS calendar_feeds;
+
+ALTER TABLE pings DROP COLUMN sequence;
@@ -0,0 +1,11 @@
+-- 行事曆匯出的 SEQUENCE，ping 的時間、地點變更或取消時遞增
+ALTER TABLE pings ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+
+-- 每個用戶最多一個行事曆訂閱，只保存 token 的 SHA-256
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id    UUID PRIMARY KEY,
+    token_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token_hash ON calendar_feeds (token_hash);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_restaurants_geog;
+
+ALTER TABLE restaurants DROP COLUMN IF EXISTS geog;
+
+DROP INDEX IF EXISTS idx_restaurants_latitude_longitude;
@@ -0,0 +1,14 @@
+-- 經緯度範圍預先篩選用的索引，無 PostGIS 時位置查詢依此縮小範圍
+CREATE INDEX IF NOT EXISTS idx_restaurants_latitude_longitude ON restaurants (latitude, longitude);
+
+-- 資料庫可安裝 PostGIS 時，加上 geography 欄位與 GiST 索引，位置查詢改用 ST_DWithin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'postgis') THEN
+        EXECUTE 'CREATE EXTENSION IF NOT EXISTS postgis';
+        EXECUTE 'ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS geog geography(Point, 4326) '
+             || 'GENERATED ALWAYS AS (ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography) STORED';
+        EXECUTE 'CREATE INDEX IF NOT EXISTS idx_restaurants_geog ON restaurants USING GIST (geog)';
+    END IF;
+END
+$$;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reviews_user_created_at;
+DROP INDEX IF EXISTS idx_reviews_restaurant_created_at;
+DROP INDEX IF EXISTS idx_reviews_user_visit;
+
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS reviews (
+    id            UUID PRIMARY KEY,
+    restaurant_id UUID NOT NULL,
+    user_id       UUID NOT NULL,
+    visit_type    TEXT NOT NULL,
+    visit_id      TEXT NOT NULL,
+    visited_at    TIMESTAMPTZ NOT NULL,
+    stars         INTEGER NOT NULL CHECK (stars BETWEEN 1 AND 5),
+    text          TEXT,
+    photo_urls    JSONB,
+    helpful_by    JSONB,
+    helpful_count INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL
+);
+
+-- 每位用戶每次用餐只能評論一次
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_user_visit ON reviews (user_id, visit_type, visit_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_restaurant_created_at ON reviews (restaurant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_reviews_user_created_at ON reviews (user_id, created_at);
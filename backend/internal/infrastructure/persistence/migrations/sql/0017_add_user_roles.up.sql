@@ -0,0 +1,2 @@
+-- 既有帳號皆為一般用戶，第一位管理者以 cmd/set-role 指定
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'user';
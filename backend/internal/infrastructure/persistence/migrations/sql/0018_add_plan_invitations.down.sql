@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS group_dining_invite_links;
+
+DROP INDEX IF EXISTS idx_group_dining_participants_user_status;
+-- 尚未接受的邀請不是參與者
+DELETE FROM group_dining_participants WHERE status <> 'accepted';
+ALTER TABLE group_dining_participants DROP COLUMN responded_at;
+ALTER TABLE group_dining_participants DROP COLUMN invited_by;
+ALTER TABLE group_dining_participants DROP COLUMN status;
@@ -0,0 +1,20 @@
+-- 既有參與者都是自行加入的，視為已接受
+ALTER TABLE group_dining_participants ADD COLUMN status TEXT NOT NULL DEFAULT 'accepted';
+ALTER TABLE group_dining_participants ADD COLUMN invited_by TEXT;
+ALTER TABLE group_dining_participants ADD COLUMN responded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_group_dining_participants_user_status ON group_dining_participants (user_id, status);
+
+-- 分享出去的 token 不落地，只記錄連結設定與使用次數
+CREATE TABLE IF NOT EXISTS group_dining_invite_links (
+    id               TEXT NOT NULL,
+    plan_id          UUID NOT NULL REFERENCES group_dining_plans (id) ON DELETE CASCADE,
+    position         BIGINT NOT NULL,
+    created_by       TEXT NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    single_use       BOOLEAN NOT NULL,
+    max_participants BIGINT NOT NULL,
+    use_count        BIGINT NOT NULL,
+    PRIMARY KEY (id, plan_id)
+);
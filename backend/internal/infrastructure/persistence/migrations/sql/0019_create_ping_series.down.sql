@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pings_series_occurrence;
+-- 系列建立的發生保留為一次性的 ping
+ALTER TABLE pings DROP COLUMN occurrence_modified;
+ALTER TABLE pings DROP COLUMN occurrence_at;
+ALTER TABLE pings DROP COLUMN series_id;
+
+DROP TABLE IF EXISTS ping_series;
@@ -0,0 +1,30 @@
+-- 重複的 ping 系列，即將到來的每次發生由排程器建立成 pings
+CREATE TABLE IF NOT EXISTS ping_series (
+    id                 UUID PRIMARY KEY,
+    created_by         UUID NOT NULL,
+    title              TEXT NOT NULL,
+    description        TEXT,
+    ping_type          TEXT NOT NULL,
+    location_latitude  DOUBLE PRECISION,
+    location_longitude DOUBLE PRECISION,
+    location_address   TEXT,
+    invitees           JSONB,
+    start_at           TIMESTAMPTZ NOT NULL,
+    time_zone          TEXT NOT NULL,
+    rrule              TEXT NOT NULL,
+    status             TEXT NOT NULL,
+    materialized_until TIMESTAMPTZ NOT NULL,
+    sequence           BIGINT NOT NULL DEFAULT 0,
+    created_at         TIMESTAMPTZ NOT NULL,
+    updated_at         TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ping_series_created_by ON ping_series (created_by);
+CREATE INDEX IF NOT EXISTS idx_ping_series_status_materialized_until ON ping_series (status, materialized_until);
+
+ALTER TABLE pings ADD COLUMN series_id UUID REFERENCES ping_series (id) ON DELETE CASCADE;
+ALTER TABLE pings ADD COLUMN occurrence_at TIMESTAMPTZ;
+ALTER TABLE pings ADD COLUMN occurrence_modified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 每個系列的每次發生只建立一次
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pings_series_occurrence ON pings (series_id, occurrence_at) WHERE series_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE group_dining_plans DROP COLUMN version;
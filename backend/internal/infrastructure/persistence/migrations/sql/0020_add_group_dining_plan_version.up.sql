@@ -0,0 +1,2 @@
+-- 樂觀鎖版本，更新計畫時比對讀取時的版本，避免覆蓋同時寫入的變更
+ALTER TABLE group_dining_plans ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,167 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationModel represents the database model for notification.Notification
+type NotificationModel struct {
+	ID        string `gorm:"type:uuid;primary_key"`
+	UserID    string `gorm:"type:uuid;not null"`
+	Type      string `gorm:"not null"`
+	Title     string `gorm:"not null"`
+	Body      string
+	Data      StringMapJSON `gorm:"type:jsonb"`
+	DedupKey  *string
+	ReadAt    *time.Time
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (NotificationModel) TableName() string {
+	return "notifications"
+}
+
+// PostgreSQLNotificationRepository implements notification.Repository
+type PostgreSQLNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLNotificationRepository(db *gorm.DB) *PostgreSQLNotificationRepository {
+	return &PostgreSQLNotificationRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLNotificationRepository) Save(ctx context.Context, n *notification.Notification) error {
+	// 依 (user_id, dedup_key) 唯一索引忽略重複的通知
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(r.domainToModel(n))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrDuplicateNotification
+	}
+	return nil
+}
+
+func (r *PostgreSQLNotificationRepository) FindByUser(ctx context.Context, userID shared.UserID, unreadOnly bool, limit, offset int) ([]*notification.Notification, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID.String())
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var models []NotificationModel
+	if err := query.Order("created_at DESC, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*notification.Notification, 0, len(models))
+	for i := range models {
+		n, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+func (r *PostgreSQLNotificationRepository) CountUnread(ctx context.Context, userID shared.UserID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&NotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID.String()).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *PostgreSQLNotificationRepository) MarkRead(ctx context.Context, userID shared.UserID, id shared.ID, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&NotificationModel{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id.String(), userID.String()).
+		Update("read_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// 已讀過的通知視為成功，只有不存在或不屬於該用戶時回傳錯誤
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&NotificationModel{}).Where("id = ? AND user_id = ?", id.String(), userID.String()).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return shared.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLNotificationRepository) MarkAllRead(ctx context.Context, userID shared.UserID, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&NotificationModel{}).
+		Where("user_id = ? AND read_at IS NULL", userID.String()).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *PostgreSQLNotificationRepository) domainToModel(n *notification.Notification) *NotificationModel {
+	var dedupKey *string
+	if n.DedupKey != "" {
+		key := n.DedupKey
+		dedupKey = &key
+	}
+
+	return &NotificationModel{
+		ID:        n.ID.String(),
+		UserID:    n.UserID.String(),
+		Type:      string(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      StringMapJSON(n.Data),
+		DedupKey:  dedupKey,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func (r *PostgreSQLNotificationRepository) modelToDomain(model *NotificationModel) (*notification.Notification, error) {
+	id, err := shared.ParseID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := shared.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dedupKey string
+	if model.DedupKey != nil {
+		dedupKey = *model.DedupKey
+	}
+	var data map[string]string
+	if len(model.Data) > 0 {
+		data = map[string]string(model.Data)
+	}
+
+	return &notification.Notification{
+		ID:        id,
+		UserID:    userID,
+		Type:      notification.Type(model.Type),
+		Title:     model.Title,
+		Body:      model.Body,
+		Data:      data,
+		DedupKey:  dedupKey,
+		ReadAt:    model.ReadAt,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
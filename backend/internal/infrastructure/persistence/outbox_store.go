@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/events"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxEventModel represents the database model for events.Message
+type OutboxEventModel struct {
+	ID           string    `gorm:"type:uuid;primary_key"`
+	EventType    string    `gorm:"not null"`
+	AggregateID  string    `gorm:"not null"`
+	Payload      RawJSON   `gorm:"type:jsonb;not null"`
+	OccurredAt   time.Time `gorm:"not null"`
+	Attempts     int       `gorm:"not null"`
+	LastError    string
+	AvailableAt  time.Time `gorm:"not null"`
+	LockedUntil  *time.Time
+	DispatchedAt *time.Time
+	FailedAt     *time.Time
+}
+
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+// WriteOutbox 將聚合記錄的事件寫入 outbox
+// 必須在儲存聚合的同一個交易 (tx) 內呼叫，交易提交後再由呼叫端清除聚合上的事件
+func WriteOutbox(tx *gorm.DB, source shared.EventSource) error {
+	messages, err := events.NewMessages(source.PendingEvents())
+	if err != nil || len(messages) == 0 {
+		return err
+	}
+
+	models := make([]OutboxEventModel, len(messages))
+	for i, message := range messages {
+		models[i] = OutboxEventModel{
+			ID:          message.ID,
+			EventType:   message.EventType,
+			AggregateID: message.AggregateID,
+			Payload:     RawJSON(message.Payload),
+			OccurredAt:  message.OccurredAt,
+			AvailableAt: message.OccurredAt,
+		}
+	}
+	return tx.Create(&models).Error
+}
+
+// PostgreSQLOutboxStore implements events.OutboxStore
+type PostgreSQLOutboxStore struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLOutboxStore(db *gorm.DB) *PostgreSQLOutboxStore {
+	return &PostgreSQLOutboxStore{
+		db: db,
+	}
+}
+
+func (s *PostgreSQLOutboxStore) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]events.Message, error) {
+	var models []OutboxEventModel
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// SKIP LOCKED 讓多個 instance 同時輪詢時各自取得不同的事件
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dispatched_at IS NULL AND failed_at IS NULL AND available_at <= ?", now).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Order("occurred_at, id").
+			Limit(limit).
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(models))
+		for i, model := range models {
+			ids[i] = model.ID
+		}
+		return tx.Model(&OutboxEventModel{}).
+			Where("id IN ?", ids).
+			Update("locked_until", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]events.Message, len(models))
+	for i, model := range models {
+		messages[i] = events.Message{
+			ID:          model.ID,
+			EventType:   model.EventType,
+			AggregateID: model.AggregateID,
+			Payload:     json.RawMessage(model.Payload),
+			OccurredAt:  model.OccurredAt,
+			Attempts:    model.Attempts,
+		}
+	}
+	return messages, nil
+}
+
+func (s *PostgreSQLOutboxStore) MarkDispatched(ctx context.Context, id string, dispatchedAt time.Time) error {
+	return s.update(ctx, id, map[string]interface{}{
+		"dispatched_at": dispatchedAt,
+		"locked_until":  nil,
+	})
+}
+
+func (s *PostgreSQLOutboxStore) MarkFailed(ctx context.Context, id string, reason string, retryAt *time.Time) error {
+	updates := map[string]interface{}{
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   reason,
+		"locked_until": nil,
+	}
+	if retryAt != nil {
+		updates["available_at"] = *retryAt
+	} else {
+		updates["failed_at"] = time.Now()
+	}
+	return s.update(ctx, id, updates)
+}
+
+func (s *PostgreSQLOutboxStore) PurgeDispatched(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("dispatched_at IS NOT NULL AND dispatched_at < ?", before).
+		Delete(&OutboxEventModel{})
+	return result.RowsAffected, result.Error
+}
+
+func (s *PostgreSQLOutboxStore) update(ctx context.Context, id string, updates map[string]interface{}) error {
+	result := s.db.WithContext(ctx).
+		Model(&OutboxEventModel{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrEntityNotFound
+	}
+	return nil
+}
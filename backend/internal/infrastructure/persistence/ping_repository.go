@@ -0,0 +1,405 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// PingModel represents the database model for Ping
+type PingModel struct {
+	ID                 string `gorm:"type:uuid;primary_key"`
+	CreatedBy          string `gorm:"type:uuid;index;not null"`
+	Title              string `gorm:"not null"`
+	Description        string
+	PingType           string    `gorm:"not null"`
+	Status             string    `gorm:"index;not null"`
+	ScheduledAt        time.Time `gorm:"index;not null"`
+	LocationLatitude   *float64
+	LocationLongitude  *float64
+	LocationAddress    string
+	Invitees           StringListJSON `gorm:"type:jsonb"`
+	Sequence           int            `gorm:"not null;default:0"`
+	SeriesID           *string        `gorm:"type:uuid;index"`
+	OccurrenceAt       *time.Time
+	OccurrenceModified bool                `gorm:"not null;default:false"`
+	Responses          []PingResponseModel `gorm:"foreignKey:PingID;constraint:OnDelete:CASCADE"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (PingModel) TableName() string {
+	return "pings"
+}
+
+// PingResponseModel represents the database model for PingResponse
+type PingResponseModel struct {
+	ID          string `gorm:"type:uuid;primary_key"`
+	PingID      string `gorm:"type:uuid;index;not null"`
+	UserID      string `gorm:"type:uuid;index;not null"`
+	Position    int    `gorm:"not null"`
+	Status      string `gorm:"not null"`
+	Message     string
+	RespondedAt *time.Time
+}
+
+func (PingResponseModel) TableName() string {
+	return "ping_responses"
+}
+
+// PostgreSQLPingRepository implements ping.Repository
+type PostgreSQLPingRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLPingRepository(db *gorm.DB) *PostgreSQLPingRepository {
+	return &PostgreSQLPingRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLPingRepository) Create(ctx context.Context, p *ping.Ping) error {
+	model := r.domainToModel(p)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Responses").Create(model).Error; err != nil {
+			return err
+		}
+		if err := r.saveResponses(tx, model); err != nil {
+			return err
+		}
+		return WriteOutbox(tx, p)
+	})
+	if err != nil {
+		return err
+	}
+	p.ClearEvents()
+	return nil
+}
+
+func (r *PostgreSQLPingRepository) GetByID(ctx context.Context, id shared.ID) (*ping.Ping, error) {
+	var model PingModel
+	result := r.preloaded(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrPingNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLPingRepository) Update(ctx context.Context, p *ping.Ping) error {
+	model := r.domainToModel(p)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&PingModel{}).Where("id = ?", model.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return shared.ErrPingNotFound
+		}
+
+		if err := tx.Omit("Responses").Save(model).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ping_id = ?", model.ID).Delete(&PingResponseModel{}).Error; err != nil {
+			return err
+		}
+		if err := r.saveResponses(tx, model); err != nil {
+			return err
+		}
+		return WriteOutbox(tx, p)
+	})
+	if err != nil {
+		return err
+	}
+	p.ClearEvents()
+	return nil
+}
+
+func (r *PostgreSQLPingRepository) Delete(ctx context.Context, id shared.ID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ping_id = ?", id.String()).Delete(&PingResponseModel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&PingModel{}, "id = ?", id.String())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return shared.ErrPingNotFound
+		}
+		return nil
+	})
+}
+
+func (r *PostgreSQLPingRepository) GetByCreator(ctx context.Context, creatorID shared.UserID, limit, offset int) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("created_by = ?", creatorID.String()).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetByInvitee(ctx context.Context, inviteeID shared.UserID, limit, offset int) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("id IN (?)", r.inviteeSubquery(ctx, inviteeID)).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetActivePings(ctx context.Context, userID shared.UserID, excludeCreators []shared.UserID, limit, offset int) ([]*ping.Ping, error) {
+	query := r.preloaded(ctx).
+		Where("status = ?", string(ping.PingStatusActive)).
+		Where("created_by = ? OR id IN (?)", userID.String(), r.inviteeSubquery(ctx, userID))
+	if len(excludeCreators) > 0 {
+		creators := make([]string, len(excludeCreators))
+		for i, creator := range excludeCreators {
+			creators[i] = creator.String()
+		}
+		query = query.Where("created_by NOT IN ?", creators)
+	}
+
+	var models []PingModel
+	result := query.
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetPingsByStatus(ctx context.Context, status ping.PingStatus, limit, offset int) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("status = ?", string(status)).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetScheduledBetween(ctx context.Context, status ping.PingStatus, from, to time.Time) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("status = ? AND scheduled_at >= ? AND scheduled_at < ?", string(status), from, to).
+		Order("scheduled_at ASC, id").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetUpcomingForUser(ctx context.Context, userID shared.UserID, from time.Time) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("scheduled_at >= ?", from).
+		Where("created_by = ? OR id IN (?)", userID.String(), r.inviteeSubquery(ctx, userID)).
+		Order("scheduled_at ASC, id").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingRepository) GetBySeries(ctx context.Context, seriesID shared.ID, from time.Time) ([]*ping.Ping, error) {
+	var models []PingModel
+	result := r.preloaded(ctx).
+		Where("series_id = ? AND occurrence_at >= ?", seriesID.String(), from).
+		Order("occurrence_at ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+// preloaded returns a query that loads responses in their original order
+func (r *PostgreSQLPingRepository) preloaded(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Responses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
+}
+
+func (r *PostgreSQLPingRepository) inviteeSubquery(ctx context.Context, userID shared.UserID) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Model(&PingResponseModel{}).
+		Select("ping_id").
+		Where("user_id = ?", userID.String())
+}
+
+func (r *PostgreSQLPingRepository) saveResponses(tx *gorm.DB, model *PingModel) error {
+	if len(model.Responses) == 0 {
+		return nil
+	}
+	return tx.Create(&model.Responses).Error
+}
+
+// Helper methods for conversion
+func (r *PostgreSQLPingRepository) domainToModel(p *ping.Ping) *PingModel {
+	invitees := make(StringListJSON, len(p.Invitees()))
+	for i, invitee := range p.Invitees() {
+		invitees[i] = invitee.String()
+	}
+
+	responses := make([]PingResponseModel, len(p.Responses()))
+	for i, response := range p.Responses() {
+		responses[i] = PingResponseModel{
+			ID:          response.ID.String(),
+			PingID:      p.ID().String(),
+			UserID:      response.UserID.String(),
+			Position:    i,
+			Status:      string(response.Status),
+			Message:     response.Message,
+			RespondedAt: response.RespondedAt,
+		}
+	}
+
+	model := &PingModel{
+		ID:          p.ID().String(),
+		CreatedBy:   p.CreatedBy().String(),
+		Title:       p.Title(),
+		Description: p.Description(),
+		PingType:    string(p.PingType()),
+		Status:      string(p.Status()),
+		ScheduledAt: p.ScheduledAt(),
+		Invitees:    invitees,
+		Sequence:    p.Sequence(),
+		Responses:   responses,
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
+	}
+
+	if location := p.Location(); location != nil {
+		model.LocationLatitude = &location.Latitude
+		model.LocationLongitude = &location.Longitude
+		model.LocationAddress = location.Address
+	}
+
+	if occurrence := p.Occurrence(); occurrence != nil {
+		seriesID := occurrence.SeriesID.String()
+		occurrenceAt := occurrence.OccurrenceAt
+		model.SeriesID = &seriesID
+		model.OccurrenceAt = &occurrenceAt
+		model.OccurrenceModified = occurrence.Modified
+	}
+
+	return model
+}
+
+func (r *PostgreSQLPingRepository) modelToDomain(m *PingModel) (*ping.Ping, error) {
+	id, err := shared.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := shared.ParseUserID(m.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	invitees := make([]shared.UserID, len(m.Invitees))
+	for i, invitee := range m.Invitees {
+		invitees[i], err = shared.ParseUserID(invitee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responses := make([]ping.PingResponse, len(m.Responses))
+	for i, response := range m.Responses {
+		responseID, err := shared.ParseID(response.ID)
+		if err != nil {
+			return nil, err
+		}
+		userID, err := shared.ParseUserID(response.UserID)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = ping.PingResponse{
+			ID:          responseID,
+			PingID:      id,
+			UserID:      userID,
+			Status:      ping.ResponseStatus(response.Status),
+			Message:     response.Message,
+			RespondedAt: response.RespondedAt,
+		}
+	}
+
+	var location *shared.Location
+	if m.LocationLatitude != nil && m.LocationLongitude != nil {
+		location = &shared.Location{
+			Latitude:  *m.LocationLatitude,
+			Longitude: *m.LocationLongitude,
+			Address:   m.LocationAddress,
+		}
+	}
+
+	var occurrence *ping.Occurrence
+	if m.SeriesID != nil && m.OccurrenceAt != nil {
+		seriesID, err := shared.ParseID(*m.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		occurrence = &ping.Occurrence{
+			SeriesID:     seriesID,
+			OccurrenceAt: *m.OccurrenceAt,
+			Modified:     m.OccurrenceModified,
+		}
+	}
+
+	return ping.ReconstructPing(
+		id,
+		createdBy,
+		m.Title,
+		m.Description,
+		ping.PingType(m.PingType),
+		ping.PingStatus(m.Status),
+		m.ScheduledAt,
+		location,
+		responses,
+		invitees,
+		m.Sequence,
+		occurrence,
+		m.CreatedAt,
+		m.UpdatedAt,
+	), nil
+}
+
+func (r *PostgreSQLPingRepository) modelsToDomain(models []PingModel) ([]*ping.Ping, error) {
+	pings := make([]*ping.Ping, len(models))
+	for i := range models {
+		p, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		pings[i] = p
+	}
+	return pings, nil
+}
@@ -0,0 +1,201 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// PingSeriesModel represents the database model for ping.Series
+type PingSeriesModel struct {
+	ID                string `gorm:"type:uuid;primary_key"`
+	CreatedBy         string `gorm:"type:uuid;index;not null"`
+	Title             string `gorm:"not null"`
+	Description       string
+	PingType          string `gorm:"not null"`
+	LocationLatitude  *float64
+	LocationLongitude *float64
+	LocationAddress   string
+	Invitees          StringListJSON `gorm:"type:jsonb"`
+	StartAt           time.Time      `gorm:"not null"`
+	TimeZone          string         `gorm:"not null"`
+	RRule             string         `gorm:"column:rrule;not null"`
+	Status            string         `gorm:"not null"`
+	MaterializedUntil time.Time      `gorm:"not null"`
+	Sequence          int            `gorm:"not null;default:0"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (PingSeriesModel) TableName() string {
+	return "ping_series"
+}
+
+// PostgreSQLPingSeriesRepository implements ping.SeriesRepository
+type PostgreSQLPingSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLPingSeriesRepository(db *gorm.DB) *PostgreSQLPingSeriesRepository {
+	return &PostgreSQLPingSeriesRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLPingSeriesRepository) Create(ctx context.Context, s *ping.Series) error {
+	return r.db.WithContext(ctx).Create(r.domainToModel(s)).Error
+}
+
+func (r *PostgreSQLPingSeriesRepository) GetByID(ctx context.Context, id shared.ID) (*ping.Series, error) {
+	var model PingSeriesModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ping.ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLPingSeriesRepository) Update(ctx context.Context, s *ping.Series) error {
+	model := r.domainToModel(s)
+	result := r.db.WithContext(ctx).Model(&PingSeriesModel{}).Where("id = ?", model.ID).Select("*").Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ping.ErrSeriesNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLPingSeriesRepository) GetForUser(ctx context.Context, userID shared.UserID) ([]*ping.Series, error) {
+	invitee, err := json.Marshal([]string{userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var models []PingSeriesModel
+	result := r.db.WithContext(ctx).
+		Where("status <> ?", string(ping.SeriesStatusCancelled)).
+		Where("created_by = ? OR invitees @> ?", userID.String(), string(invitee)).
+		Order("start_at ASC, id").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingSeriesRepository) GetDueForMaterialization(ctx context.Context, until time.Time) ([]*ping.Series, error) {
+	var models []PingSeriesModel
+	result := r.db.WithContext(ctx).
+		Where("status = ? AND materialized_until < ?", string(ping.SeriesStatusActive), until).
+		Order("materialized_until ASC, id").
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLPingSeriesRepository) domainToModel(s *ping.Series) *PingSeriesModel {
+	invitees := make(StringListJSON, len(s.Invitees()))
+	for i, invitee := range s.Invitees() {
+		invitees[i] = invitee.String()
+	}
+
+	model := &PingSeriesModel{
+		ID:                s.ID().String(),
+		CreatedBy:         s.CreatedBy().String(),
+		Title:             s.Title(),
+		Description:       s.Description(),
+		PingType:          string(s.PingType()),
+		Invitees:          invitees,
+		StartAt:           s.StartAt(),
+		TimeZone:          s.TimeZone(),
+		RRule:             s.Rule().String(),
+		Status:            string(s.Status()),
+		MaterializedUntil: s.MaterializedUntil(),
+		Sequence:          s.Sequence(),
+		CreatedAt:         s.CreatedAt(),
+		UpdatedAt:         s.UpdatedAt(),
+	}
+
+	if location := s.Location(); location != nil {
+		model.LocationLatitude = &location.Latitude
+		model.LocationLongitude = &location.Longitude
+		model.LocationAddress = location.Address
+	}
+
+	return model
+}
+
+func (r *PostgreSQLPingSeriesRepository) modelToDomain(m *PingSeriesModel) (*ping.Series, error) {
+	id, err := shared.ParseID(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := shared.ParseUserID(m.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	invitees := make([]shared.UserID, len(m.Invitees))
+	for i, invitee := range m.Invitees {
+		invitees[i], err = shared.ParseUserID(invitee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := ping.ParseRecurrenceRule(m.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	var location *shared.Location
+	if m.LocationLatitude != nil && m.LocationLongitude != nil {
+		location = &shared.Location{
+			Latitude:  *m.LocationLatitude,
+			Longitude: *m.LocationLongitude,
+			Address:   m.LocationAddress,
+		}
+	}
+
+	return ping.ReconstructSeries(
+		id,
+		createdBy,
+		m.Title,
+		m.Description,
+		ping.PingType(m.PingType),
+		location,
+		invitees,
+		m.StartAt,
+		m.TimeZone,
+		rule,
+		ping.SeriesStatus(m.Status),
+		m.MaterializedUntil,
+		m.Sequence,
+		m.CreatedAt,
+		m.UpdatedAt,
+	), nil
+}
+
+func (r *PostgreSQLPingSeriesRepository) modelsToDomain(models []PingSeriesModel) ([]*ping.Series, error) {
+	series := make([]*ping.Series, len(models))
+	for i := range models {
+		s, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		series[i] = s
+	}
+	return series, nil
+}
@@ -0,0 +1,173 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenModel represents the database model for session.RefreshToken
+type RefreshTokenModel struct {
+	ID         string `gorm:"type:uuid;primary_key"`
+	FamilyID   string `gorm:"type:uuid;index;not null"`
+	UserID     string `gorm:"type:uuid;index;not null"`
+	DeviceID   string `gorm:"not null"`
+	TokenHash  string `gorm:"uniqueIndex;not null"`
+	CreatedAt  time.Time
+	ExpiresAt  time.Time `gorm:"not null"`
+	RotatedAt  *time.Time
+	ReplacedBy *string `gorm:"type:uuid"`
+	RevokedAt  *time.Time
+}
+
+func (RefreshTokenModel) TableName() string {
+	return "refresh_tokens"
+}
+
+// PostgreSQLRefreshTokenRepository implements session.RefreshTokenRepository
+type PostgreSQLRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLRefreshTokenRepository(db *gorm.DB) *PostgreSQLRefreshTokenRepository {
+	return &PostgreSQLRefreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLRefreshTokenRepository) Save(ctx context.Context, token *session.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(r.domainToModel(token)).Error
+}
+
+func (r *PostgreSQLRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	var model RefreshTokenModel
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrEntityNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLRefreshTokenRepository) Rotate(ctx context.Context, current, next *session.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 條件式更新確保同一個 token 只能被換發一次
+		result := tx.Model(&RefreshTokenModel{}).
+			Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", current.ID.String()).
+			Updates(map[string]interface{}{
+				"rotated_at":  current.RotatedAt,
+				"replaced_by": optionalIDString(current.ReplacedBy),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return shared.ErrRefreshTokenReused
+		}
+
+		return tx.Create(r.domainToModel(next)).Error
+	})
+}
+
+func (r *PostgreSQLRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID shared.ID, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&RefreshTokenModel{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID.String()).
+		Update("revoked_at", at).Error
+}
+
+func (r *PostgreSQLRefreshTokenRepository) RevokeByUser(ctx context.Context, userID shared.UserID, deviceID string, at time.Time) ([]shared.ID, error) {
+	var familyIDs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&RefreshTokenModel{}).Where("user_id = ? AND revoked_at IS NULL", userID.String())
+		if deviceID != "" {
+			query = query.Where("device_id = ?", deviceID)
+		}
+
+		if err := query.Session(&gorm.Session{}).Distinct().Pluck("family_id", &familyIDs).Error; err != nil {
+			return err
+		}
+		if len(familyIDs) == 0 {
+			return nil
+		}
+
+		return query.Update("revoked_at", at).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	families := make([]shared.ID, 0, len(familyIDs))
+	for _, id := range familyIDs {
+		familyID, err := shared.ParseID(id)
+		if err != nil {
+			return nil, err
+		}
+		families = append(families, familyID)
+	}
+	return families, nil
+}
+
+func (r *PostgreSQLRefreshTokenRepository) domainToModel(token *session.RefreshToken) *RefreshTokenModel {
+	return &RefreshTokenModel{
+		ID:         token.ID.String(),
+		FamilyID:   token.FamilyID.String(),
+		UserID:     token.UserID.String(),
+		DeviceID:   token.DeviceID,
+		TokenHash:  token.TokenHash,
+		CreatedAt:  token.CreatedAt,
+		ExpiresAt:  token.ExpiresAt,
+		RotatedAt:  token.RotatedAt,
+		ReplacedBy: optionalIDString(token.ReplacedBy),
+		RevokedAt:  token.RevokedAt,
+	}
+}
+
+func (r *PostgreSQLRefreshTokenRepository) modelToDomain(model *RefreshTokenModel) (*session.RefreshToken, error) {
+	id, err := shared.ParseID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	familyID, err := shared.ParseID(model.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := shared.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &session.RefreshToken{
+		ID:        id,
+		FamilyID:  familyID,
+		UserID:    userID,
+		DeviceID:  model.DeviceID,
+		TokenHash: model.TokenHash,
+		CreatedAt: model.CreatedAt,
+		ExpiresAt: model.ExpiresAt,
+		RotatedAt: model.RotatedAt,
+		RevokedAt: model.RevokedAt,
+	}
+	if model.ReplacedBy != nil {
+		replacedBy, err := shared.ParseID(*model.ReplacedBy)
+		if err != nil {
+			return nil, err
+		}
+		token.ReplacedBy = &replacedBy
+	}
+	return token, nil
+}
+
+func optionalIDString(id *shared.ID) *string {
+	if id == nil {
+		return nil
+	}
+	value := id.String()
+	return &value
+}
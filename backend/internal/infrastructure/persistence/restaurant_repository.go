@@ -0,0 +1,361 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+)
+
+// RestaurantModel represents the database model for Restaurant
+type RestaurantModel struct {
+	ID                    string `gorm:"type:uuid;primary_key"`
+	Name                  string `gorm:"index;not null"`
+	Description           string
+	Latitude              float64        `gorm:"not null"`
+	Longitude             float64        `gorm:"not null"`
+	Address               string         `gorm:"not null"`
+	CuisineTypes          StringListJSON `gorm:"type:jsonb"`
+	PriceLevel            int            `gorm:"not null"`
+	Rating                float64        `gorm:"index"`
+	TotalReviews          int
+	PhoneNumber           string
+	Website               string
+	ImageURLs             StringListJSON   `gorm:"type:jsonb"`
+	OpeningHours          OpeningHoursJSON `gorm:"type:jsonb"`
+	SupportedRestrictions StringListJSON   `gorm:"type:jsonb"`
+	AverageWaitTime       int
+	AcceptsReservations   bool `gorm:"not null"`
+	IsActive              bool `gorm:"index;not null"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (RestaurantModel) TableName() string {
+	return "restaurants"
+}
+
+// OpeningHoursJSON stores restaurant.OpeningHours in a jsonb column
+// 舊資料的 {"monday": "09:00-21:00"} 格式在讀取時由 restaurant.OpeningHours 轉換
+type OpeningHoursJSON restaurant.OpeningHours
+
+func (o OpeningHoursJSON) Value() (driver.Value, error) {
+	return json.Marshal(restaurant.OpeningHours(o))
+}
+
+func (o *OpeningHoursJSON) Scan(value interface{}) error {
+	var hours restaurant.OpeningHours
+	if err := scanJSON(value, &hours); err != nil {
+		return err
+	}
+	*o = OpeningHoursJSON(hours)
+	return nil
+}
+
+// PostgreSQLRestaurantRepository implements restaurant.Repository
+// 位置查詢先在資料庫以經緯度範圍（或 PostGIS 的 ST_DWithin）縮小候選，再以 Haversine 精確比對
+type PostgreSQLRestaurantRepository struct {
+	db *gorm.DB
+
+	postGISMu      sync.Mutex
+	postGISChecked bool
+	postGIS        bool
+}
+
+func NewPostgreSQLRestaurantRepository(db *gorm.DB) *PostgreSQLRestaurantRepository {
+	return &PostgreSQLRestaurantRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLRestaurantRepository) Create(ctx context.Context, rest *restaurant.Restaurant) error {
+	return r.db.WithContext(ctx).Create(r.domainToModel(rest)).Error
+}
+
+func (r *PostgreSQLRestaurantRepository) FindByID(ctx context.Context, id shared.RestaurantID) (*restaurant.Restaurant, error) {
+	var model RestaurantModel
+	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrRestaurantNotFound
+		}
+		return nil, result.Error
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLRestaurantRepository) FindByLocation(ctx context.Context, centerLat, centerLon, radiusKm float64) ([]*restaurant.Restaurant, error) {
+	centerLocation := restaurant.Location{
+		Latitude:  centerLat,
+		Longitude: centerLon,
+	}
+
+	candidates, err := r.findActiveWithin(ctx, centerLocation, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*restaurant.Restaurant
+	for _, rest := range candidates {
+		if rest.CalculateDistance(centerLocation) <= radiusKm {
+			results = append(results, rest)
+		}
+	}
+
+	// 按距離排序
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CalculateDistance(centerLocation) < results[j].CalculateDistance(centerLocation)
+	})
+
+	return results, nil
+}
+
+func (r *PostgreSQLRestaurantRepository) FindNearby(ctx context.Context, location restaurant.Location, radiusKm float64, criteria restaurant.SearchCriteria) ([]*restaurant.Restaurant, error) {
+	candidates, err := r.findActiveWithin(ctx, location, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*restaurant.Restaurant
+	for _, rest := range candidates {
+		if rest.CalculateDistance(location) <= radiusKm && criteria.Matches(rest) {
+			results = append(results, rest)
+		}
+	}
+
+	restaurant.SortRestaurants(results, criteria, location)
+	return criteria.Paginate(results), nil
+}
+
+func (r *PostgreSQLRestaurantRepository) Update(ctx context.Context, rest *restaurant.Restaurant) error {
+	model := r.domainToModel(rest)
+	result := r.db.WithContext(ctx).
+		Model(&RestaurantModel{}).
+		Where("id = ?", model.ID).
+		Select("*").
+		Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrRestaurantNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLRestaurantRepository) Delete(ctx context.Context, id shared.RestaurantID) error {
+	result := r.db.WithContext(ctx).Delete(&RestaurantModel{}, "id = ?", id.String())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrRestaurantNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLRestaurantRepository) FindAll(ctx context.Context, limit, offset int) ([]*restaurant.Restaurant, error) {
+	var models []RestaurantModel
+	result := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLRestaurantRepository) Search(ctx context.Context, criteria restaurant.SearchCriteria) ([]*restaurant.Restaurant, error) {
+	// 可直接以欄位過濾的條件交給資料庫，其餘條件在記憶體中比對
+	query := r.db.WithContext(ctx).Model(&RestaurantModel{})
+	if criteria.IsActive != nil {
+		query = query.Where("is_active = ?", *criteria.IsActive)
+	}
+	if criteria.MinRating > 0 {
+		query = query.Where("rating >= ?", criteria.MinRating)
+	}
+	if criteria.AcceptsReservations != nil {
+		query = query.Where("accepts_reservations = ?", *criteria.AcceptsReservations)
+	}
+	if criteria.CenterLocation != nil && criteria.RadiusKm > 0 {
+		query = r.withinRadius(ctx, query, *criteria.CenterLocation, criteria.RadiusKm)
+	}
+
+	var models []RestaurantModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	candidates, err := r.modelsToDomain(models)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*restaurant.Restaurant
+	for _, rest := range candidates {
+		if criteria.Matches(rest) {
+			results = append(results, rest)
+		}
+	}
+
+	var centerLocation restaurant.Location
+	if criteria.CenterLocation != nil {
+		centerLocation = *criteria.CenterLocation
+	}
+	restaurant.SortRestaurants(results, criteria, centerLocation)
+
+	return criteria.Paginate(results), nil
+}
+
+func (r *PostgreSQLRestaurantRepository) findActiveWithin(ctx context.Context, center restaurant.Location, radiusKm float64) ([]*restaurant.Restaurant, error) {
+	query := r.db.WithContext(ctx).Where("is_active = ?", true)
+	query = r.withinRadius(ctx, query, center, radiusKm)
+
+	var models []RestaurantModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return r.modelsToDomain(models)
+}
+
+// withinRadius 加上位置預先篩選條件，結果仍需以 CalculateDistance 精確比對
+func (r *PostgreSQLRestaurantRepository) withinRadius(ctx context.Context, query *gorm.DB, center restaurant.Location, radiusKm float64) *gorm.DB {
+	if r.hasPostGIS(ctx) {
+		return query.Where(
+			"ST_DWithin(geog, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			center.Longitude, center.Latitude, radiusKm*1000,
+		)
+	}
+
+	box := restaurant.NewBoundingBox(center, radiusKm)
+	query = query.Where("latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude)
+	if box.CrossesAntimeridian() {
+		return query.Where("(longitude >= ? OR longitude <= ?)", box.MinLongitude, box.MaxLongitude)
+	}
+	return query.Where("longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude)
+}
+
+// hasPostGIS 檢查 migration 是否已建立 geog 欄位，結果在第一次成功查詢後快取
+// 查詢失敗 (例如 ctx 已取消) 時這次改用經緯度範圍，下次查詢再重新檢查
+func (r *PostgreSQLRestaurantRepository) hasPostGIS(ctx context.Context) bool {
+	r.postGISMu.Lock()
+	defer r.postGISMu.Unlock()
+
+	if r.postGISChecked {
+		return r.postGIS
+	}
+
+	var exists bool
+	err := r.db.WithContext(ctx).Raw(
+		`SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'restaurants' AND column_name = 'geog'
+		)`,
+	).Scan(&exists).Error
+	if err != nil {
+		return false
+	}
+	r.postGISChecked = true
+	r.postGIS = exists
+	return exists
+}
+
+// Helper methods for conversion
+func (r *PostgreSQLRestaurantRepository) domainToModel(rest *restaurant.Restaurant) *RestaurantModel {
+	cuisineTypes := make(StringListJSON, len(rest.CuisineTypes))
+	for i, cuisine := range rest.CuisineTypes {
+		cuisineTypes[i] = string(cuisine)
+	}
+
+	restrictions := make(StringListJSON, len(rest.SupportedRestrictions))
+	for i, restriction := range rest.SupportedRestrictions {
+		restrictions[i] = string(restriction)
+	}
+
+	return &RestaurantModel{
+		ID:                    rest.ID.String(),
+		Name:                  rest.Name,
+		Description:           rest.Description,
+		Latitude:              rest.Location.Latitude,
+		Longitude:             rest.Location.Longitude,
+		Address:               rest.Location.Address,
+		CuisineTypes:          cuisineTypes,
+		PriceLevel:            int(rest.PriceLevel),
+		Rating:                rest.Rating,
+		TotalReviews:          rest.TotalReviews,
+		PhoneNumber:           rest.PhoneNumber,
+		Website:               rest.Website,
+		ImageURLs:             StringListJSON(rest.ImageURLs),
+		OpeningHours:          OpeningHoursJSON(rest.OpeningHours),
+		SupportedRestrictions: restrictions,
+		AverageWaitTime:       rest.AverageWaitTime,
+		AcceptsReservations:   rest.AcceptsReservations,
+		IsActive:              rest.IsActive,
+		CreatedAt:             rest.CreatedAt,
+		UpdatedAt:             rest.UpdatedAt,
+	}
+}
+
+func (r *PostgreSQLRestaurantRepository) modelToDomain(m *RestaurantModel) (*restaurant.Restaurant, error) {
+	id, err := shared.NewRestaurantIDFromString(m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cuisineTypes := make([]restaurant.CuisineType, len(m.CuisineTypes))
+	for i, cuisine := range m.CuisineTypes {
+		cuisineTypes[i] = restaurant.CuisineType(cuisine)
+	}
+
+	restrictions := make([]restaurant.DietaryRestriction, len(m.SupportedRestrictions))
+	for i, restriction := range m.SupportedRestrictions {
+		restrictions[i] = restaurant.DietaryRestriction(restriction)
+	}
+
+	return &restaurant.Restaurant{
+		ID:          id,
+		Name:        m.Name,
+		Description: m.Description,
+		Location: restaurant.Location{
+			Latitude:  m.Latitude,
+			Longitude: m.Longitude,
+			Address:   m.Address,
+		},
+		CuisineTypes:          cuisineTypes,
+		PriceLevel:            restaurant.PriceLevel(m.PriceLevel),
+		Rating:                m.Rating,
+		TotalReviews:          m.TotalReviews,
+		PhoneNumber:           m.PhoneNumber,
+		Website:               m.Website,
+		ImageURLs:             []string(m.ImageURLs),
+		OpeningHours:          restaurant.OpeningHours(m.OpeningHours),
+		SupportedRestrictions: restrictions,
+		AverageWaitTime:       m.AverageWaitTime,
+		AcceptsReservations:   m.AcceptsReservations,
+		IsActive:              m.IsActive,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+	}, nil
+}
+
+func (r *PostgreSQLRestaurantRepository) modelsToDomain(models []RestaurantModel) ([]*restaurant.Restaurant, error) {
+	restaurants := make([]*restaurant.Restaurant, len(models))
+	for i := range models {
+		rest, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		restaurants[i] = rest
+	}
+	return restaurants, nil
+}
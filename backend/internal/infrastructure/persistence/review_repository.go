@@ -0,0 +1,241 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewModel represents the database model for review.Review
+type ReviewModel struct {
+	ID           string    `gorm:"type:uuid;primary_key"`
+	RestaurantID string    `gorm:"type:uuid;not null"`
+	UserID       string    `gorm:"type:uuid;not null"`
+	VisitType    string    `gorm:"not null"`
+	VisitID      string    `gorm:"not null"`
+	VisitedAt    time.Time `gorm:"not null"`
+	Stars        int       `gorm:"not null"`
+	Text         string
+	PhotoURLs    StringListJSON `gorm:"column:photo_urls;type:jsonb"`
+	HelpfulBy    StringListJSON `gorm:"type:jsonb"`
+	HelpfulCount int            `gorm:"not null"` // 冗餘存放 HelpfulBy 的長度供排序使用
+	CreatedAt    time.Time      `gorm:"not null"`
+	UpdatedAt    time.Time      `gorm:"not null;autoUpdateTime:false"`
+}
+
+func (ReviewModel) TableName() string {
+	return "reviews"
+}
+
+// PostgreSQLReviewRepository implements review.Repository
+type PostgreSQLReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLReviewRepository(db *gorm.DB) *PostgreSQLReviewRepository {
+	return &PostgreSQLReviewRepository{
+		db: db,
+	}
+}
+
+func (r *PostgreSQLReviewRepository) Create(ctx context.Context, rv *review.Review) error {
+	// 依 (user_id, visit_type, visit_id) 唯一索引拒絕同一次用餐的第二則評論
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(r.domainToModel(rv))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrReviewExists
+	}
+	return nil
+}
+
+func (r *PostgreSQLReviewRepository) FindByID(ctx context.Context, id shared.ID) (*review.Review, error) {
+	var model ReviewModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrReviewNotFound
+		}
+		return nil, err
+	}
+	return r.modelToDomain(&model)
+}
+
+func (r *PostgreSQLReviewRepository) Update(ctx context.Context, rv *review.Review) error {
+	model := r.domainToModel(rv)
+	result := r.db.WithContext(ctx).
+		Model(&ReviewModel{}).
+		Where("id = ?", model.ID).
+		Select("stars", "text", "photo_urls", "helpful_by", "helpful_count", "updated_at").
+		Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrReviewNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLReviewRepository) Delete(ctx context.Context, id shared.ID) error {
+	result := r.db.WithContext(ctx).Delete(&ReviewModel{}, "id = ?", id.String())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.ErrReviewNotFound
+	}
+	return nil
+}
+
+func (r *PostgreSQLReviewRepository) FindByRestaurant(ctx context.Context, restaurantID shared.RestaurantID, order review.SortOrder, excludeAuthors []shared.UserID, limit, offset int) ([]*review.Review, error) {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID.String())
+	if len(excludeAuthors) > 0 {
+		authors := make([]string, len(excludeAuthors))
+		for i, author := range excludeAuthors {
+			authors[i] = author.String()
+		}
+		query = query.Where("user_id NOT IN ?", authors)
+	}
+	if order == review.SortMostHelpful {
+		query = query.Order("helpful_count DESC")
+	}
+
+	var models []ReviewModel
+	if err := query.Order("created_at DESC, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLReviewRepository) FindByUser(ctx context.Context, userID shared.UserID, limit, offset int) ([]*review.Review, error) {
+	var models []ReviewModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID.String()).
+		Order("created_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.modelsToDomain(models)
+}
+
+func (r *PostgreSQLReviewRepository) Summarize(ctx context.Context, restaurantID shared.RestaurantID) (review.RatingSummary, error) {
+	var rows []struct {
+		Stars int
+		Count int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&ReviewModel{}).
+		Select("stars, COUNT(*) AS count").
+		Where("restaurant_id = ?", restaurantID.String()).
+		Group("stars").
+		Scan(&rows).Error
+	if err != nil {
+		return review.RatingSummary{}, err
+	}
+
+	summary := review.RatingSummary{RestaurantID: restaurantID}
+	total := 0
+	for _, row := range rows {
+		if row.Stars < review.MinStars || row.Stars > review.MaxStars {
+			continue
+		}
+		summary.Distribution[row.Stars-review.MinStars] = row.Count
+		summary.Count += row.Count
+		total += row.Stars * row.Count
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+	return summary, nil
+}
+
+func (r *PostgreSQLReviewRepository) domainToModel(rv *review.Review) *ReviewModel {
+	helpfulBy := make(StringListJSON, len(rv.HelpfulBy))
+	for i, userID := range rv.HelpfulBy {
+		helpfulBy[i] = userID.String()
+	}
+
+	return &ReviewModel{
+		ID:           rv.ID.String(),
+		RestaurantID: rv.RestaurantID.String(),
+		UserID:       rv.UserID.String(),
+		VisitType:    string(rv.Visit.Type),
+		VisitID:      rv.Visit.ID,
+		VisitedAt:    rv.Visit.VisitedAt,
+		Stars:        rv.Stars,
+		Text:         rv.Text,
+		PhotoURLs:    StringListJSON(rv.PhotoURLs),
+		HelpfulBy:    helpfulBy,
+		HelpfulCount: len(helpfulBy),
+		CreatedAt:    rv.CreatedAt,
+		UpdatedAt:    rv.UpdatedAt,
+	}
+}
+
+func (r *PostgreSQLReviewRepository) modelToDomain(model *ReviewModel) (*review.Review, error) {
+	id, err := shared.ParseID(model.ID)
+	if err != nil {
+		return nil, err
+	}
+	restaurantID, err := shared.NewRestaurantIDFromString(model.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := shared.NewUserIDFromString(model.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	helpfulBy := make([]shared.UserID, len(model.HelpfulBy))
+	for i, raw := range model.HelpfulBy {
+		voter, err := shared.NewUserIDFromString(raw)
+		if err != nil {
+			return nil, err
+		}
+		helpfulBy[i] = voter
+	}
+	photoURLs := []string(model.PhotoURLs)
+	if photoURLs == nil {
+		photoURLs = []string{}
+	}
+
+	return &review.Review{
+		ID:           id,
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Visit: review.Visit{
+			Type:      review.VisitType(model.VisitType),
+			ID:        model.VisitID,
+			VisitedAt: model.VisitedAt,
+		},
+		Stars:     model.Stars,
+		Text:      model.Text,
+		PhotoURLs: photoURLs,
+		HelpfulBy: helpfulBy,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
+
+func (r *PostgreSQLReviewRepository) modelsToDomain(models []ReviewModel) ([]*review.Review, error) {
+	reviews := make([]*review.Review, 0, len(models))
+	for i := range models {
+		rv, err := r.modelToDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, rv)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RevokedTokenModel represents a revoked access token ID (jti) or session ID (sid)
+type RevokedTokenModel struct {
+	ID        string    `gorm:"primary_key"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+func (RevokedTokenModel) TableName() string {
+	return "revoked_tokens"
+}
+
+// PostgreSQLRevocationList implements session.RevocationList
+type PostgreSQLRevocationList struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLRevocationList(db *gorm.DB) *PostgreSQLRevocationList {
+	return &PostgreSQLRevocationList{
+		db: db,
+	}
+}
+
+func (l *PostgreSQLRevocationList) Revoke(ctx context.Context, id string, until time.Time) error {
+	db := l.db.WithContext(ctx)
+
+	// 順便清除已過期的項目，避免資料表無限成長
+	if err := db.Where("expires_at <= ?", time.Now()).Delete(&RevokedTokenModel{}).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(
+		`INSERT INTO revoked_tokens (id, expires_at) VALUES (?, ?)
+ON CONFLICT (id) DO UPDATE SET expires_at = excluded.expires_at
+WHERE revoked_tokens.expires_at < excluded.expires_at`,
+		id, until,
+	).Error
+}
+
+func (l *PostgreSQLRevocationList) IsRevoked(ctx context.Context, id string) (bool, error) {
+	var count int64
+	err := l.db.WithContext(ctx).
+		Model(&RevokedTokenModel{}).
+		Where("id = ? AND expires_at > ?", id, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/application/scheduler"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SchedulerLeaseModel represents the database model for a scheduler leader lease
+type SchedulerLeaseModel struct {
+	Name       string    `gorm:"primary_key"`
+	Holder     string    `gorm:"not null"`
+	AcquiredAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+}
+
+func (SchedulerLeaseModel) TableName() string {
+	return "scheduler_leases"
+}
+
+// JobRunModel represents the database model for scheduler.JobRun
+type JobRunModel struct {
+	ID         string `gorm:"type:uuid;primary_key"`
+	JobName    string `gorm:"not null"`
+	InstanceID string `gorm:"not null"`
+	Status     string `gorm:"not null"`
+	Processed  int    `gorm:"not null"`
+	Error      string
+	StartedAt  time.Time `gorm:"not null"`
+	FinishedAt time.Time `gorm:"not null"`
+}
+
+func (JobRunModel) TableName() string {
+	return "job_runs"
+}
+
+// PostgreSQLLeaseStore implements scheduler.LeaseStore
+type PostgreSQLLeaseStore struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLLeaseStore(db *gorm.DB) *PostgreSQLLeaseStore {
+	return &PostgreSQLLeaseStore{
+		db: db,
+	}
+}
+
+func (s *PostgreSQLLeaseStore) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	// 只有租約由自己持有或已過期時才會覆寫，單一 upsert 避免兩個 instance 同時取得租約
+	result := s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"holder":      holder,
+				"expires_at":  now.Add(ttl),
+				"acquired_at": gorm.Expr("CASE WHEN scheduler_leases.holder = ? THEN scheduler_leases.acquired_at ELSE ? END", holder, now),
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				gorm.Expr("scheduler_leases.holder = ? OR scheduler_leases.expires_at <= ?", holder, now),
+			}},
+		}).
+		Create(&SchedulerLeaseModel{
+			Name:       name,
+			Holder:     holder,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(ttl),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (s *PostgreSQLLeaseStore) Release(ctx context.Context, name, holder string) error {
+	return s.db.WithContext(ctx).
+		Where("name = ? AND holder = ?", name, holder).
+		Delete(&SchedulerLeaseModel{}).Error
+}
+
+// PostgreSQLJobRunStore implements scheduler.RunStore
+type PostgreSQLJobRunStore struct {
+	db *gorm.DB
+}
+
+func NewPostgreSQLJobRunStore(db *gorm.DB) *PostgreSQLJobRunStore {
+	return &PostgreSQLJobRunStore{
+		db: db,
+	}
+}
+
+func (s *PostgreSQLJobRunStore) RecordRun(ctx context.Context, run scheduler.JobRun) error {
+	return s.db.WithContext(ctx).Create(&JobRunModel{
+		ID:         run.ID,
+		JobName:    run.JobName,
+		InstanceID: run.InstanceID,
+		Status:     string(run.Status),
+		Processed:  run.Processed,
+		Error:      run.Error,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+	}).Error
+}
+
+func (s *PostgreSQLJobRunStore) LastRun(ctx context.Context, jobName string) (*scheduler.JobRun, error) {
+	var model JobRunModel
+	err := s.db.WithContext(ctx).
+		Where("job_name = ?", jobName).
+		Order("started_at DESC").
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &scheduler.JobRun{
+		ID:         model.ID,
+		JobName:    model.JobName,
+		InstanceID: model.InstanceID,
+		Status:     scheduler.RunStatus(model.Status),
+		Processed:  model.Processed,
+		Error:      model.Error,
+		StartedAt:  model.StartedAt,
+		FinishedAt: model.FinishedAt,
+	}, nil
+}
+
+func (s *PostgreSQLJobRunStore) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("started_at < ?", before).
+		Delete(&JobRunModel{})
+	return result.RowsAffected, result.Error
+}
@@ -16,17 +16,19 @@ import (
 
 // UserModel represents the database model for User
 type UserModel struct {
-	ID              string                 `gorm:"type:uuid;primary_key" json:"id"`
-	Email           string                 `gorm:"uniqueIndex;not null" json:"email"`
-	PhoneNumber     string                 `gorm:"index" json:"phone_number"`
-	PasswordHash    string                 `gorm:"not null" json:"-"`
-	Profile         ProfileJSON            `gorm:"type:jsonb" json:"profile"`
-	Preferences     PreferencesJSON        `gorm:"type:jsonb" json:"preferences"`
-	PrivacySettings PrivacySettingsJSON    `gorm:"type:jsonb" json:"privacy_settings"`
-	IsActive        bool                   `gorm:"default:true" json:"is_active"`
-	IsVerified      bool                   `gorm:"default:false" json:"is_verified"`
-	CreatedAt       time.Time              `json:"created_at"`
-	UpdatedAt       time.Time              `json:"updated_at"`
+	ID                      string                       `gorm:"type:uuid;primary_key" json:"id"`
+	Email                   string                       `gorm:"uniqueIndex;not null" json:"email"`
+	PhoneNumber             string                       `gorm:"index" json:"phone_number"`
+	PasswordHash            string                       `gorm:"not null" json:"-"`
+	Profile                 ProfileJSON                  `gorm:"type:jsonb" json:"profile"`
+	Preferences             PreferencesJSON              `gorm:"type:jsonb" json:"preferences"`
+	PrivacySettings         PrivacySettingsJSON          `gorm:"type:jsonb" json:"privacy_settings"`
+	NotificationPreferences *NotificationPreferencesJSON `gorm:"type:jsonb" json:"notification_preferences"`
+	Role                    string                       `gorm:"not null;default:user" json:"role"`
+	IsActive                bool                         `gorm:"default:true" json:"is_active"`
+	IsVerified              bool                         `gorm:"default:false" json:"is_verified"`
+	CreatedAt               time.Time                    `json:"created_at"`
+	UpdatedAt               time.Time                    `json:"updated_at"`
 }
 
 func (UserModel) TableName() string {
@@ -37,6 +39,7 @@ func (UserModel) TableName() string {
 type ProfileJSON user.UserProfile
 type PreferencesJSON user.DietaryPreferences
 type PrivacySettingsJSON user.PrivacySettings
+type NotificationPreferencesJSON user.NotificationPreferences
 
 func (p ProfileJSON) Value() (driver.Value, error) {
 	return json.Marshal(p)
@@ -74,6 +77,16 @@ func (p *PrivacySettingsJSON) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, p)
 }
 
+func (p NotificationPreferencesJSON) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *NotificationPreferencesJSON) Scan(value interface{}) error {
+	// 先填入預設值，之後新增的偏好欄位在舊資料中維持預設
+	*p = NotificationPreferencesJSON(user.DefaultNotificationPreferences())
+	return scanJSON(value, p)
+}
+
 // PostgreSQLUserRepository implements the UserRepository interface
 type PostgreSQLUserRepository struct {
 	db *gorm.DB
@@ -87,8 +100,17 @@ func NewPostgreSQLUserRepository(db *gorm.DB) *PostgreSQLUserRepository {
 
 func (r *PostgreSQLUserRepository) Save(ctx context.Context, user *user.User) error {
 	model := r.domainToModel(user)
-	result := r.db.WithContext(ctx).Create(model)
-	return result.Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return WriteOutbox(tx, user)
+	})
+	if err != nil {
+		return err
+	}
+	user.ClearEvents()
+	return nil
 }
 
 func (r *PostgreSQLUserRepository) FindByID(ctx context.Context, id shared.UserID) (*user.User, error) {
@@ -129,8 +151,17 @@ func (r *PostgreSQLUserRepository) FindByPhoneNumber(ctx context.Context, phoneN
 
 func (r *PostgreSQLUserRepository) Update(ctx context.Context, user *user.User) error {
 	model := r.domainToModel(user)
-	result := r.db.WithContext(ctx).Save(model)
-	return result.Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(model).Error; err != nil {
+			return err
+		}
+		return WriteOutbox(tx, user)
+	})
+	if err != nil {
+		return err
+	}
+	user.ClearEvents()
+	return nil
 }
 
 func (r *PostgreSQLUserRepository) Delete(ctx context.Context, id shared.UserID) error {
@@ -138,9 +169,9 @@ func (r *PostgreSQLUserRepository) Delete(ctx context.Context, id shared.UserID)
 	return result.Error
 }
 
-func (r *PostgreSQLUserRepository) FindDiscoverableUsers(ctx context.Context, limit, offset int) ([]*user.User, error) {
+func (r *PostgreSQLUserRepository) FindDiscoverableUsers(ctx context.Context, excludeIDs []shared.UserID, limit, offset int) ([]*user.User, error) {
 	var models []UserModel
-	result := r.db.WithContext(ctx).
+	result := excludingUsers(r.db.WithContext(ctx), excludeIDs).
 		Where("is_active = ? AND privacy_settings->>'isDiscoverable' = 'true'", true).
 		Limit(limit).
 		Offset(offset).
@@ -162,11 +193,11 @@ func (r *PostgreSQLUserRepository) FindDiscoverableUsers(ctx context.Context, li
 	return users, nil
 }
 
-func (r *PostgreSQLUserRepository) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*user.User, error) {
+func (r *PostgreSQLUserRepository) SearchUsers(ctx context.Context, query string, excludeIDs []shared.UserID, limit, offset int) ([]*user.User, error) {
 	var models []UserModel
 	searchPattern := fmt.Sprintf("%%%s%%", strings.ToLower(query))
 	
-	result := r.db.WithContext(ctx).
+	result := excludingUsers(r.db.WithContext(ctx), excludeIDs).
 		Where("is_active = ? AND privacy_settings->>'isDiscoverable' = 'true'", true).
 		Where("LOWER(email) LIKE ? OR LOWER(profile->>'displayName') LIKE ?", searchPattern, searchPattern).
 		Limit(limit).
@@ -189,6 +220,18 @@ func (r *PostgreSQLUserRepository) SearchUsers(ctx context.Context, query string
 	return users, nil
 }
 
+// excludingUsers 排除指定的用戶；名單為空時不加條件
+func excludingUsers(query *gorm.DB, ids []shared.UserID) *gorm.DB {
+	if len(ids) == 0 {
+		return query
+	}
+	excluded := make([]string, len(ids))
+	for i, id := range ids {
+		excluded[i] = id.String()
+	}
+	return query.Where("id NOT IN ?", excluded)
+}
+
 func (r *PostgreSQLUserRepository) CountUsers(ctx context.Context) (int64, error) {
 	var count int64
 	result := r.db.WithContext(ctx).Model(&UserModel{}).Count(&count)
@@ -258,17 +301,19 @@ func (r *PostgreSQLUserRepository) FindUnverifiedUsers(ctx context.Context, olde
 // Helper methods for conversion
 func (r *PostgreSQLUserRepository) domainToModel(u *user.User) *UserModel {
 	return &UserModel{
-		ID:              u.ID.String(),
-		Email:           u.Email,
-		PhoneNumber:     u.PhoneNumber,
-		PasswordHash:    u.PasswordHash,
-		Profile:         ProfileJSON(u.Profile),
-		Preferences:     PreferencesJSON(u.Preferences),
-		PrivacySettings: PrivacySettingsJSON(u.PrivacySettings),
-		IsActive:        u.IsActive,
-		IsVerified:      u.IsVerified,
-		CreatedAt:       u.CreatedAt,
-		UpdatedAt:       u.UpdatedAt,
+		ID:                      u.ID.String(),
+		Email:                   u.Email,
+		PhoneNumber:             u.PhoneNumber,
+		PasswordHash:            u.PasswordHash,
+		Profile:                 ProfileJSON(u.Profile),
+		Preferences:             PreferencesJSON(u.Preferences),
+		PrivacySettings:         PrivacySettingsJSON(u.PrivacySettings),
+		NotificationPreferences: (*NotificationPreferencesJSON)(&u.NotificationPreferences),
+		Role:                    string(u.Role.OrDefault()),
+		IsActive:                u.IsActive,
+		IsVerified:              u.IsVerified,
+		CreatedAt:               u.CreatedAt,
+		UpdatedAt:               u.UpdatedAt,
 	}
 }
 
@@ -278,17 +323,25 @@ func (r *PostgreSQLUserRepository) modelToDomain(m *UserModel) (*user.User, erro
 		return nil, err
 	}
 	
+	// 在新增通知偏好前建立的用戶沒有設定，使用預設值
+	notificationPreferences := user.DefaultNotificationPreferences()
+	if m.NotificationPreferences != nil {
+		notificationPreferences = user.NotificationPreferences(*m.NotificationPreferences)
+	}
+	
 	return &user.User{
-		ID:              userID,
-		Email:           m.Email,
-		PhoneNumber:     m.PhoneNumber,
-		PasswordHash:    m.PasswordHash,
-		Profile:         user.UserProfile(m.Profile),
-		Preferences:     user.DietaryPreferences(m.Preferences),
-		PrivacySettings: user.PrivacySettings(m.PrivacySettings),
-		IsActive:        m.IsActive,
-		IsVerified:      m.IsVerified,
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
+		ID:                      userID,
+		Email:                   m.Email,
+		PhoneNumber:             m.PhoneNumber,
+		PasswordHash:            m.PasswordHash,
+		Profile:                 user.UserProfile(m.Profile),
+		Preferences:             user.DietaryPreferences(m.Preferences),
+		PrivacySettings:         user.PrivacySettings(m.PrivacySettings),
+		NotificationPreferences: notificationPreferences,
+		Role:                    user.Role(m.Role).OrDefault(),
+		IsActive:                m.IsActive,
+		IsVerified:              m.IsVerified,
+		CreatedAt:               m.CreatedAt,
+		UpdatedAt:               m.UpdatedAt,
 	}, nil
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/communication"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+)
+
+// NewPushProviderFromConfig 依設定建立推播 provider，driver 為 "none" 時回傳 nil (不推播)
+func NewPushProviderFromConfig(cfg config.NotificationConfig) (communication.PushProvider, error) {
+	switch cfg.PushDriver {
+	case "", "fake":
+		return NewFakeProvider(), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported push driver: %q", cfg.PushDriver)
+	}
+}
+
+// FakeProvider 不實際推播，只把推播寫入 log 並保留在記憶體，供本機開發與測試使用
+type FakeProvider struct {
+	mu   sync.Mutex
+	sent map[shared.UserID][]communication.PushMessage
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		sent: make(map[shared.UserID][]communication.PushMessage),
+	}
+}
+
+func (p *FakeProvider) Send(ctx context.Context, userID shared.UserID, message communication.PushMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sent[userID] = append(p.sent[userID], message)
+	log.Printf("📱 Push to %s: %s - %s %v", userID, message.Title, message.Body, message.Data)
+	return nil
+}
+
+// Sent 回傳送給用戶的所有推播 (依送出順序)
+func (p *FakeProvider) Sent(userID shared.UserID) []communication.PushMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]communication.PushMessage(nil), p.sent[userID]...)
+}
@@ -0,0 +1,47 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/config"
+)
+
+// NewRoutingProviderFromConfig 依設定建立交通時間 provider，driver 為 "none" 時回傳 nil (不支援交通時間策略)
+func NewRoutingProviderFromConfig(cfg config.RoutingConfig) (restaurant.RoutingProvider, error) {
+	switch cfg.Driver {
+	case "", "straight_line":
+		return NewStraightLineProvider(cfg.AverageSpeedKmh, cfg.DetourFactor), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported routing driver: %q", cfg.Driver)
+	}
+}
+
+// StraightLineProvider 不呼叫地圖服務，以直線距離乘上繞路倍數再除以平均速度估算交通時間
+type StraightLineProvider struct {
+	averageSpeedKmh float64
+	detourFactor    float64
+}
+
+// NewStraightLineProvider 建立直線距離估算 provider，參數不為正數時使用市區步行加大眾運輸的預設值
+func NewStraightLineProvider(averageSpeedKmh, detourFactor float64) *StraightLineProvider {
+	if averageSpeedKmh <= 0 {
+		averageSpeedKmh = 20
+	}
+	if detourFactor <= 0 {
+		detourFactor = 1.3
+	}
+	return &StraightLineProvider{
+		averageSpeedKmh: averageSpeedKmh,
+		detourFactor:    detourFactor,
+	}
+}
+
+func (p *StraightLineProvider) TravelTime(ctx context.Context, from, to restaurant.Location) (time.Duration, error) {
+	distanceKm := from.DistanceTo(to) * p.detourFactor
+	return time.Duration(distanceKm / p.averageSpeedKmh * float64(time.Hour)), nil
+}
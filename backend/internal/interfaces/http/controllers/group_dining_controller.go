@@ -8,6 +8,7 @@ import (
 
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/dtos"
 	"github.com/chun-wei0413/pingnom/internal/application/groupdining/services"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 )
 
 type GroupDiningController struct {
@@ -21,15 +22,21 @@ func NewGroupDiningController(groupDiningService *services.GroupDiningService) *
 }
 
 func (c *GroupDiningController) CreateGroupDiningPlan(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
 	var req dtos.CreateGroupDiningPlanRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
+	req.CreatedBy = userID
 
 	response, err := c.groupDiningService.CreateGroupDiningPlan(&req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -39,49 +46,50 @@ func (c *GroupDiningController) CreateGroupDiningPlan(ctx *gin.Context) {
 func (c *GroupDiningController) GetGroupDiningPlan(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
-	response, err := c.groupDiningService.GetGroupDiningPlanByID(planID)
+	response, err := c.groupDiningService.GetGroupDiningPlanByID(planID, userID)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
+// GetGroupDiningPlansByCreator 列出目前用戶建立的計畫
 func (c *GroupDiningController) GetGroupDiningPlansByCreator(ctx *gin.Context) {
-	createdBy := ctx.Query("created_by")
-	if createdBy == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "created_by parameter is required"})
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
-	responses, err := c.groupDiningService.GetGroupDiningPlansByCreator(createdBy)
+	responses, err := c.groupDiningService.GetGroupDiningPlansByCreator(userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"plans": responses})
 }
 
+// GetGroupDiningPlansByParticipant 列出目前用戶參與的計畫
 func (c *GroupDiningController) GetGroupDiningPlansByParticipant(ctx *gin.Context) {
-	userID := ctx.Query("user_id")
-	if userID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user_id parameter is required"})
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
 	responses, err := c.groupDiningService.GetGroupDiningPlansByParticipant(userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -91,7 +99,12 @@ func (c *GroupDiningController) GetGroupDiningPlansByParticipant(ctx *gin.Contex
 func (c *GroupDiningController) AddTimeSlot(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
@@ -102,12 +115,13 @@ func (c *GroupDiningController) AddTimeSlot(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	req := &dtos.AddTimeSlotRequest{
 		PlanID:      planID,
+		ActorID:     userID,
 		StartTime:   reqBody.StartTime,
 		EndTime:     reqBody.EndTime,
 		Description: reqBody.Description,
@@ -115,11 +129,37 @@ func (c *GroupDiningController) AddTimeSlot(ctx *gin.Context) {
 
 	response, err := c.groupDiningService.AddTimeSlot(req)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SuggestTimeSlots 依參與者的可用時間建議時段，apply 為 true 時直接加入計畫
+func (c *GroupDiningController) SuggestTimeSlots(ctx *gin.Context) {
+	planID := ctx.Param("id")
+	if planID == "" {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dtos.SuggestTimeSlotsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	req.PlanID = planID
+	req.ActorID = userID
+
+	response, err := c.groupDiningService.SuggestTimeSlots(&req)
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
@@ -129,7 +169,12 @@ func (c *GroupDiningController) AddTimeSlot(ctx *gin.Context) {
 func (c *GroupDiningController) AddRestaurantOption(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
@@ -142,12 +187,13 @@ func (c *GroupDiningController) AddRestaurantOption(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	req := &dtos.AddRestaurantOptionRequest{
 		PlanID:      planID,
+		ActorID:     userID,
 		Name:        reqBody.Name,
 		Address:     reqBody.Address,
 		Latitude:    reqBody.Latitude,
@@ -157,57 +203,204 @@ func (c *GroupDiningController) AddRestaurantOption(ctx *gin.Context) {
 
 	response, err := c.groupDiningService.AddRestaurantOption(req)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// JoinGroupDiningPlan 受邀者加入計畫，或帶著邀請連結的 invite_token 加入
+func (c *GroupDiningController) JoinGroupDiningPlan(ctx *gin.Context) {
+	planID := ctx.Param("id")
+	if planID == "" {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	// 受邀者加入時不需要請求內容
+	var reqBody struct {
+		InviteToken string `json:"invite_token"`
+	}
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+			ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 			return
 		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+
+	req := &dtos.JoinGroupDiningPlanRequest{
+		PlanID:      planID,
+		UserID:      userID,
+		InviteToken: reqBody.InviteToken,
+	}
+
+	response, err := c.groupDiningService.JoinGroupDiningPlan(req)
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
-func (c *GroupDiningController) JoinGroupDiningPlan(ctx *gin.Context) {
+// JoinWithInviteLink 以邀請連結的 token 加入計畫，計畫由 token 決定
+func (c *GroupDiningController) JoinWithInviteLink(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	response, err := c.groupDiningService.JoinGroupDiningPlan(&dtos.JoinGroupDiningPlanRequest{
+		UserID:      userID,
+		InviteToken: reqBody.Token,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// InviteParticipants 建立者邀請用戶加入計畫
+func (c *GroupDiningController) InviteParticipants(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
 	var reqBody struct {
-		UserID      string `json:"user_id" binding:"required"`
-		DisplayName string `json:"display_name" binding:"required"`
+		UserIDs []string `json:"user_ids" binding:"required,min=1,max=20,dive,uuid"`
+	}
+	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
 	}
 
+	response, err := c.groupDiningService.InviteParticipants(&dtos.InviteParticipantsRequest{
+		PlanID:  planID,
+		ActorID: userID,
+		UserIDs: reqBody.UserIDs,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// RespondToInvitation 受邀者接受或婉拒邀請
+func (c *GroupDiningController) RespondToInvitation(ctx *gin.Context) {
+	planID := ctx.Param("id")
+	if planID == "" {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		Accept *bool `json:"accept" binding:"required"`
+	}
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
-	req := &dtos.JoinGroupDiningPlanRequest{
-		PlanID:      planID,
-		UserID:      reqBody.UserID,
-		DisplayName: reqBody.DisplayName,
+	response, err := c.groupDiningService.RespondToInvitation(&dtos.RespondToInvitationRequest{
+		PlanID: planID,
+		UserID: userID,
+		Accept: *reqBody.Accept,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
 	}
 
-	response, err := c.groupDiningService.JoinGroupDiningPlan(req)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetPendingInvitations 列出目前用戶尚未回覆的邀請
+func (c *GroupDiningController) GetPendingInvitations(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	responses, err := c.groupDiningService.GetPendingInvitations(userID)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"invitations": responses})
+}
+
+// CreateInviteLink 建立者建立可分享的邀請連結
+func (c *GroupDiningController) CreateInviteLink(ctx *gin.Context) {
+	planID := ctx.Param("id")
+	if planID == "" {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req dtos.CreateInviteLinkRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 			return
 		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+	req.PlanID = planID
+	req.ActorID = userID
+
+	response, err := c.groupDiningService.CreateInviteLink(&req)
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusCreated, response)
 }
 
 func (c *GroupDiningController) StartVoting(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
@@ -216,22 +409,19 @@ func (c *GroupDiningController) StartVoting(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	req := &dtos.StartVotingRequest{
 		PlanID:         planID,
+		ActorID:        userID,
 		VotingDeadline: reqBody.VotingDeadline,
 	}
 
 	response, err := c.groupDiningService.StartVoting(req)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -241,37 +431,39 @@ func (c *GroupDiningController) StartVoting(ctx *gin.Context) {
 func (c *GroupDiningController) SubmitVote(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
 	var reqBody struct {
-		UserID        string   `json:"user_id" binding:"required"`
-		TimeSlotIDs   []string `json:"time_slot_ids" binding:"required"`
-		RestaurantIDs []string `json:"restaurant_ids" binding:"required"`
-		Comment       string   `json:"comment"`
+		TimeSlotIDs   []string       `json:"time_slot_ids" binding:"required"`
+		RestaurantIDs []string       `json:"restaurant_ids" binding:"required"`
+		Comment       string         `json:"comment"`
+		Scores        map[string]int `json:"scores"`
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	req := &dtos.SubmitVoteRequest{
 		PlanID:        planID,
-		UserID:        reqBody.UserID,
+		UserID:        userID,
 		TimeSlotIDs:   reqBody.TimeSlotIDs,
 		RestaurantIDs: reqBody.RestaurantIDs,
 		Comment:       reqBody.Comment,
+		Scores:        reqBody.Scores,
 	}
 
 	response, err := c.groupDiningService.SubmitVote(req)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -281,7 +473,12 @@ func (c *GroupDiningController) SubmitVote(ctx *gin.Context) {
 func (c *GroupDiningController) FinalizeGroupDiningPlan(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
 		return
 	}
 
@@ -291,23 +488,20 @@ func (c *GroupDiningController) FinalizeGroupDiningPlan(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&reqBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	req := &dtos.FinalizeGroupDiningPlanRequest{
 		PlanID:       planID,
+		ActorID:      userID,
 		TimeSlotID:   reqBody.TimeSlotID,
 		RestaurantID: reqBody.RestaurantID,
 	}
 
 	response, err := c.groupDiningService.FinalizeGroupDiningPlan(req)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
@@ -317,19 +511,55 @@ func (c *GroupDiningController) FinalizeGroupDiningPlan(ctx *gin.Context) {
 func (c *GroupDiningController) GetVotingResults(ctx *gin.Context) {
 	planID := ctx.Param("id")
 	if planID == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "plan ID is required"})
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
 		return
 	}
 
-	response, err := c.groupDiningService.GetVotingResults(planID)
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.groupDiningService.GetVotingResults(planID, userID)
 	if err != nil {
-		if err.Error() == "group dining plan not found" {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// CancelGroupDiningPlan 取消尚未確認的計畫，只限建立者
+func (c *GroupDiningController) CancelGroupDiningPlan(ctx *gin.Context) {
+	planID := ctx.Param("id")
+	if planID == "" {
+		ctx.Error(shared.ErrInvalidInput.WithMessage("plan ID is required"))
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.groupDiningService.CancelGroupDiningPlan(&dtos.CancelGroupDiningPlanRequest{
+		PlanID:  planID,
+		ActorID: userID,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// currentUserID 取得 access token 中的用戶，計畫的建立者、參與者與投票者一律以此為準
+func currentUserID(ctx *gin.Context) (string, bool) {
+	userID, err := shared.NewUserIDFromString(ctx.GetString("userID"))
+	if err != nil {
+		ctx.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return "", false
+	}
+	return userID.String(), true
+}
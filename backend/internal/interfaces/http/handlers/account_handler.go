@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	authcommands "github.com/chun-wei0413/pingnom/internal/application/commands/auth"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// AccountHandler 處理 Email 驗證與密碼重設
+type AccountHandler struct {
+	verifyEmailHandler        *authcommands.VerifyEmailHandler
+	resendVerificationHandler *authcommands.ResendVerificationHandler
+	forgotPasswordHandler     *authcommands.ForgotPasswordHandler
+	resetPasswordHandler      *authcommands.ResetPasswordHandler
+}
+
+func NewAccountHandler(
+	verifyEmailHandler *authcommands.VerifyEmailHandler,
+	resendVerificationHandler *authcommands.ResendVerificationHandler,
+	forgotPasswordHandler *authcommands.ForgotPasswordHandler,
+	resetPasswordHandler *authcommands.ResetPasswordHandler,
+) *AccountHandler {
+	return &AccountHandler{
+		verifyEmailHandler:        verifyEmailHandler,
+		resendVerificationHandler: resendVerificationHandler,
+		forgotPasswordHandler:     forgotPasswordHandler,
+		resetPasswordHandler:      resetPasswordHandler,
+	}
+}
+
+// POST /api/v1/auth/verify-email
+func (h *AccountHandler) VerifyEmail(c *gin.Context) {
+	var cmd authcommands.VerifyEmailCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	result, err := h.verifyEmailHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email verified",
+		"data":    result,
+	})
+}
+
+// POST /api/v1/auth/verify-email/resend
+func (h *AccountHandler) ResendVerification(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	err = h.resendVerificationHandler.Handle(c.Request.Context(), authcommands.ResendVerificationCommand{UserID: userID})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Verification email sent",
+	})
+}
+
+// POST /api/v1/auth/password/forgot
+func (h *AccountHandler) ForgotPassword(c *gin.Context) {
+	var cmd authcommands.ForgotPasswordCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	if err := h.forgotPasswordHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.Error(fmt.Errorf("failed to send password reset email: %w", err))
+		return
+	}
+
+	// 不論帳號是否存在都回傳相同結果
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+// POST /api/v1/auth/password/reset
+func (h *AccountHandler) ResetPassword(c *gin.Context) {
+	var cmd authcommands.ResetPasswordCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	if err := h.resetPasswordHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password has been reset",
+	})
+}
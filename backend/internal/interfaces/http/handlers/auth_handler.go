@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -9,12 +10,16 @@ import (
 )
 
 type AuthHandler struct {
-	loginHandler *authcommands.LoginHandler
+	loginHandler   *authcommands.LoginHandler
+	refreshHandler *authcommands.RefreshTokenHandler
+	logoutHandler  *authcommands.LogoutHandler
 }
 
-func NewAuthHandler(loginHandler *authcommands.LoginHandler) *AuthHandler {
+func NewAuthHandler(loginHandler *authcommands.LoginHandler, refreshHandler *authcommands.RefreshTokenHandler, logoutHandler *authcommands.LogoutHandler) *AuthHandler {
 	return &AuthHandler{
-		loginHandler: loginHandler,
+		loginHandler:   loginHandler,
+		refreshHandler: refreshHandler,
+		logoutHandler:  logoutHandler,
 	}
 }
 
@@ -22,30 +27,17 @@ func NewAuthHandler(loginHandler *authcommands.LoginHandler) *AuthHandler {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var cmd authcommands.LoginCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	result, err := h.loginHandler.Handle(c.Request.Context(), cmd)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		switch err {
-		case shared.ErrInvalidCredentials:
-			statusCode = http.StatusUnauthorized
-		case shared.ErrUserNotFound:
-			statusCode = http.StatusUnauthorized // 不要透露用戶不存在的資訊
-		case shared.ErrUserInactive:
-			statusCode = http.StatusForbidden
-		default:
-			statusCode = http.StatusInternalServerError
+		if errors.Is(err, shared.ErrUserNotFound) {
+			// 不要透露用戶不存在的資訊
+			err = shared.ErrInvalidCredentials
 		}
-
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -57,9 +49,30 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // POST /api/auth/logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// 由於使用 JWT，logout 主要在前端處理（刪除 token）
-	// 這裡可以記錄 logout 事件或將 token 加入黑名單（如果需要）
-	
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	// request body 為選填 (refreshToken / allDevices)
+	var cmd authcommands.LogoutCommand
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&cmd); err != nil {
+			c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+			return
+		}
+	}
+	cmd.UserID = userID
+	cmd.SessionID = c.GetString("sessionID")
+	cmd.TokenID = c.GetString("tokenID")
+	cmd.TokenExpiresAt = c.GetTime("tokenExpiresAt")
+
+	if err := h.logoutHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.Error(err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logout successful",
 	})
@@ -67,18 +80,23 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 // POST /api/auth/refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	// 從 Authorization header 獲取當前 token
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Authorization header required",
-		})
+	var cmd authcommands.RefreshTokenCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	result, err := h.refreshHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		if errors.Is(err, shared.ErrUserNotFound) {
+			err = shared.ErrInvalidRefreshToken
+		}
+		c.Error(err)
 		return
 	}
 
-	// 這裡需要實作 refresh token 邏輯
-	// 暫時回傳錯誤，因為 refresh 功能較複雜
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "Token refresh not implemented yet",
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Token refreshed",
+		"data":    result,
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	availabilitycommands "github.com/chun-wei0413/pingnom/internal/application/commands/availability"
+	availabilityqueries "github.com/chun-wei0413/pingnom/internal/application/queries/availability"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// AvailabilityHandler 處理用戶的每週可用時段與行程
+type AvailabilityHandler struct {
+	getAvailabilityHandler    *availabilityqueries.GetAvailabilityHandler
+	updateAvailabilityHandler *availabilitycommands.UpdateAvailabilityHandler
+}
+
+func NewAvailabilityHandler(
+	getAvailabilityHandler *availabilityqueries.GetAvailabilityHandler,
+	updateAvailabilityHandler *availabilitycommands.UpdateAvailabilityHandler,
+) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		getAvailabilityHandler:    getAvailabilityHandler,
+		updateAvailabilityHandler: updateAvailabilityHandler,
+	}
+}
+
+// GET /api/v1/users/availability
+func (h *AvailabilityHandler) GetAvailability(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	schedule, err := h.getAvailabilityHandler.Handle(c.Request.Context(), availabilityqueries.GetAvailabilityQuery{UserID: userID})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": schedule,
+	})
+}
+
+// PUT /api/v1/users/availability
+func (h *AvailabilityHandler) UpdateAvailability(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	var cmd availabilitycommands.UpdateAvailabilityCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.UserID = userID
+
+	schedule, err := h.updateAvailabilityHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Availability updated successfully",
+		"data":    schedule,
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	appservices "github.com/chun-wei0413/pingnom/internal/application/services"
+	"github.com/chun-wei0413/pingnom/internal/domain/calendar"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler 處理 .ics 匯出與行事曆訂閱
+type CalendarHandler struct {
+	calendarService *appservices.CalendarService
+	apiBaseURL      string
+}
+
+func NewCalendarHandler(calendarService *appservices.CalendarService, apiBaseURL string) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		apiBaseURL:      strings.TrimRight(apiBaseURL, "/"),
+	}
+}
+
+// GET /api/v1/pings/:id/calendar.ics
+func (h *CalendarHandler) GetPingCalendar(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	pingID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping ID"))
+		return
+	}
+
+	cal, err := h.calendarService.PingCalendar(c.Request.Context(), userID, pingID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondCalendar(c, cal, "ping-"+pingID.String()+".ics", true)
+}
+
+// GET /api/v1/group-dining/plans/:id/calendar.ics
+func (h *CalendarHandler) GetPlanCalendar(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	planID := c.Param("id")
+	cal, err := h.calendarService.PlanCalendar(c.Request.Context(), userID, planID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondCalendar(c, cal, "plan-"+planID+".ics", true)
+}
+
+// POST /api/v1/calendar/feed
+// 建立訂閱；已有訂閱時輪替 token，舊的訂閱網址隨即失效
+func (h *CalendarHandler) CreateFeed(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	feed, token, err := h.calendarService.CreateFeed(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Calendar feed created successfully",
+		"data": gin.H{
+			"url":       h.apiBaseURL + "/api/v1/calendar/feeds/" + token + "/meals.ics",
+			"createdAt": feed.CreatedAt,
+		},
+	})
+}
+
+// DELETE /api/v1/calendar/feed
+func (h *CalendarHandler) DeleteFeed(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	if err := h.calendarService.DeleteFeed(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// GET /api/v1/calendar/feeds/:token/meals.ics
+// 行事曆 App 無法帶 Authorization header，以網址中的 token 驗證
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	cal, err := h.calendarService.FeedCalendar(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.respondCalendar(c, cal, "meals.ics", false)
+}
+
+func (h *CalendarHandler) respondCalendar(c *gin.Context, cal calendar.Calendar, filename string, attachment bool) {
+	disposition := "inline"
+	if attachment {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", disposition+`; filename="`+filename+`"`)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, calendar.ContentType, cal.Encode(time.Now()))
+}
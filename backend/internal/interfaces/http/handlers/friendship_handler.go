@@ -53,20 +53,20 @@ func (h *FriendshipHandler) SendFriendRequest(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body"))
 		return
 	}
 
 	// 從 JWT token 獲取當前用戶 ID
 	requesterID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
 	addresseeID, err := shared.NewUserIDFromString(req.AddresseeID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid addressee ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid addressee ID"))
 		return
 	}
 
@@ -78,7 +78,7 @@ func (h *FriendshipHandler) SendFriendRequest(c *gin.Context) {
 
 	friendship, err := h.sendRequestHandler.Handle(c.Request.Context(), cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -89,20 +89,20 @@ func (h *FriendshipHandler) SendFriendRequest(c *gin.Context) {
 func (h *FriendshipHandler) AcceptFriendRequest(c *gin.Context) {
 	friendshipID := c.Param("id")
 	if friendshipID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Friendship ID is required"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Friendship ID is required"))
 		return
 	}
 
 	// 從 JWT token 獲取當前用戶 ID
 	addresseeID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
 	fID, err := shared.NewFriendshipIDFromString(friendshipID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid friendship ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid friendship ID"))
 		return
 	}
 
@@ -112,7 +112,7 @@ func (h *FriendshipHandler) AcceptFriendRequest(c *gin.Context) {
 	}
 
 	if err := h.acceptRequestHandler.Handle(c.Request.Context(), cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -123,20 +123,20 @@ func (h *FriendshipHandler) AcceptFriendRequest(c *gin.Context) {
 func (h *FriendshipHandler) DeclineFriendRequest(c *gin.Context) {
 	friendshipID := c.Param("id")
 	if friendshipID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Friendship ID is required"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Friendship ID is required"))
 		return
 	}
 
 	// 從 JWT token 獲取當前用戶 ID
 	addresseeID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
 	fID, err := shared.NewFriendshipIDFromString(friendshipID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid friendship ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid friendship ID"))
 		return
 	}
 
@@ -146,7 +146,7 @@ func (h *FriendshipHandler) DeclineFriendRequest(c *gin.Context) {
 	}
 
 	if err := h.declineRequestHandler.Handle(c.Request.Context(), cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -160,20 +160,20 @@ func (h *FriendshipHandler) BlockUser(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body"))
 		return
 	}
 
 	// 從 JWT token 獲取當前用戶 ID
 	blockerID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
 	blockedID, err := shared.NewUserIDFromString(req.BlockedID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocked user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid blocked user ID"))
 		return
 	}
 
@@ -183,7 +183,7 @@ func (h *FriendshipHandler) BlockUser(c *gin.Context) {
 	}
 
 	if err := h.blockUserHandler.Handle(c.Request.Context(), cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -194,20 +194,20 @@ func (h *FriendshipHandler) BlockUser(c *gin.Context) {
 func (h *FriendshipHandler) RemoveFriend(c *gin.Context) {
 	friendID := c.Param("friendId")
 	if friendID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Friend ID is required"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Friend ID is required"))
 		return
 	}
 
 	// 從 JWT token 獲取當前用戶 ID
 	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
 	fID, err := shared.NewUserIDFromString(friendID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid friend ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid friend ID"))
 		return
 	}
 
@@ -217,7 +217,7 @@ func (h *FriendshipHandler) RemoveFriend(c *gin.Context) {
 	}
 
 	if err := h.removeFriendHandler.Handle(c.Request.Context(), cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -229,7 +229,7 @@ func (h *FriendshipHandler) GetFriends(c *gin.Context) {
 	// 從 JWT token 獲取當前用戶 ID
 	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
@@ -245,7 +245,7 @@ func (h *FriendshipHandler) GetFriends(c *gin.Context) {
 
 	friends, err := h.getFriendsHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -257,7 +257,7 @@ func (h *FriendshipHandler) GetPendingRequests(c *gin.Context) {
 	// 從 JWT token 獲取當前用戶 ID
 	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
@@ -273,7 +273,7 @@ func (h *FriendshipHandler) GetPendingRequests(c *gin.Context) {
 
 	requests, err := h.getPendingHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -285,7 +285,7 @@ func (h *FriendshipHandler) GetSentRequests(c *gin.Context) {
 	// 從 JWT token 獲取當前用戶 ID
 	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
 		return
 	}
 
@@ -301,7 +301,7 @@ func (h *FriendshipHandler) GetSentRequests(c *gin.Context) {
 
 	requests, err := h.getSentHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
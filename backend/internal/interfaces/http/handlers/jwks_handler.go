@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAge 讓其他服務快取公鑰的秒數，應遠小於金鑰預告 (active_from) 的提前時間
+const jwksMaxAge = "public, max-age=300"
+
+type JWKSHandler struct {
+	jwtService *auth.JWTService
+}
+
+func NewJWKSHandler(jwtService *auth.JWTService) *JWKSHandler {
+	return &JWKSHandler{
+		jwtService: jwtService,
+	}
+}
+
+// GET /.well-known/jwks.json
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksMaxAge)
+	c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
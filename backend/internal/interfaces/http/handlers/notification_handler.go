@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	notificationcommands "github.com/chun-wei0413/pingnom/internal/application/commands/notification"
+	notificationqueries "github.com/chun-wei0413/pingnom/internal/application/queries/notification"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler 處理通知收件匣
+type NotificationHandler struct {
+	getNotificationsHandler *notificationqueries.GetNotificationsHandler
+	getUnreadCountHandler   *notificationqueries.GetUnreadCountHandler
+	markReadHandler         *notificationcommands.MarkReadHandler
+	markAllReadHandler      *notificationcommands.MarkAllReadHandler
+}
+
+func NewNotificationHandler(
+	getNotificationsHandler *notificationqueries.GetNotificationsHandler,
+	getUnreadCountHandler *notificationqueries.GetUnreadCountHandler,
+	markReadHandler *notificationcommands.MarkReadHandler,
+	markAllReadHandler *notificationcommands.MarkAllReadHandler,
+) *NotificationHandler {
+	return &NotificationHandler{
+		getNotificationsHandler: getNotificationsHandler,
+		getUnreadCountHandler:   getUnreadCountHandler,
+		markReadHandler:         markReadHandler,
+		markAllReadHandler:      markAllReadHandler,
+	}
+}
+
+// GET /api/v1/notifications?unread=true&limit=20&offset=0
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	result, err := h.getNotificationsHandler.Handle(c.Request.Context(), notificationqueries.GetNotificationsQuery{
+		UserID:     userID,
+		UnreadOnly: c.Query("unread") == "true",
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// GET /api/v1/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	count, err := h.getUnreadCountHandler.Handle(c.Request.Context(), notificationqueries.GetUnreadCountQuery{UserID: userID})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"unreadCount": count,
+		},
+	})
+}
+
+// PUT /api/v1/notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	notificationID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid notification ID").Wrap(err))
+		return
+	}
+
+	err = h.markReadHandler.Handle(c.Request.Context(), notificationcommands.MarkReadCommand{
+		UserID:         userID,
+		NotificationID: notificationID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification marked as read",
+	})
+}
+
+// PUT /api/v1/notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	updated, err := h.markAllReadHandler.Handle(c.Request.Context(), notificationcommands.MarkAllReadCommand{UserID: userID})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All notifications marked as read",
+		"data": gin.H{
+			"updated": updated,
+		},
+	})
+}
@@ -35,9 +35,7 @@ func (h *PingHandler) CreatePing(c *gin.Context) {
 	// Get current user from context (set by auth middleware)
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		c.Error(shared.ErrUnauthorized.WithMessage("User not authenticated"))
 		return
 	}
 
@@ -51,20 +49,14 @@ func (h *PingHandler) CreatePing(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	// Parse scheduled time
 	scheduledAt, err := time.Parse(time.RFC3339, request.ScheduledAt)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid scheduledAt format",
-			"details": "Please use ISO 8601 format (e.g., 2023-12-25T18:00:00Z)",
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid scheduledAt format, please use ISO 8601 format (e.g., 2023-12-25T18:00:00Z)"))
 		return
 	}
 
@@ -73,10 +65,7 @@ func (h *PingHandler) CreatePing(c *gin.Context) {
 	for i, inviteeStr := range request.Invitees {
 		inviteeID, err := shared.ParseUserID(inviteeStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid invitee ID format",
-				"details": err.Error(),
-			})
+			c.Error(shared.ErrInvalidInput.WithMessage("Invalid invitee ID format").Wrap(err))
 			return
 		}
 		inviteeIDs[i] = inviteeID
@@ -86,10 +75,7 @@ func (h *PingHandler) CreatePing(c *gin.Context) {
 	userIDStr := userID.(string)
 	createdBy, err := shared.ParseUserID(userIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID").Wrap(err))
 		return
 	}
 
@@ -105,9 +91,7 @@ func (h *PingHandler) CreatePing(c *gin.Context) {
 	// Execute command
 	result, err := h.createPingHandler.Handle(c.Request.Context(), cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -122,9 +106,7 @@ func (h *PingHandler) RespondToPing(c *gin.Context) {
 	// Get current user from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		c.Error(shared.ErrUnauthorized.WithMessage("User not authenticated"))
 		return
 	}
 
@@ -132,10 +114,7 @@ func (h *PingHandler) RespondToPing(c *gin.Context) {
 	pingIDStr := c.Param("id")
 	pingID, err := shared.ParseID(pingIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid ping ID",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping ID").Wrap(err))
 		return
 	}
 
@@ -146,10 +125,7 @@ func (h *PingHandler) RespondToPing(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
@@ -157,10 +133,7 @@ func (h *PingHandler) RespondToPing(c *gin.Context) {
 	userIDStr := userID.(string)
 	respondingUserID, err := shared.ParseUserID(userIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID").Wrap(err))
 		return
 	}
 
@@ -174,9 +147,7 @@ func (h *PingHandler) RespondToPing(c *gin.Context) {
 	// Execute command
 	result, err := h.respondToPingHandler.Handle(c.Request.Context(), cmd)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -191,9 +162,7 @@ func (h *PingHandler) GetUserPings(c *gin.Context) {
 	// Get current user from context
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		c.Error(shared.ErrUnauthorized.WithMessage("User not authenticated"))
 		return
 	}
 
@@ -215,10 +184,7 @@ func (h *PingHandler) GetUserPings(c *gin.Context) {
 	userIDStr := userID.(string)
 	queryUserID, err := shared.ParseUserID(userIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID").Wrap(err))
 		return
 	}
 
@@ -231,9 +197,7 @@ func (h *PingHandler) GetUserPings(c *gin.Context) {
 	// Execute query
 	result, err := h.getUserPingsHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"net/http"
+
+	pingcommands "github.com/chun-wei0413/pingnom/internal/application/commands/ping"
+	pingqueries "github.com/chun-wei0413/pingnom/internal/application/queries/ping"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// PingSeriesHandler 處理重複的 ping (例如每週團隊午餐) 與單一發生的跳過/修改
+type PingSeriesHandler struct {
+	createPingSeriesHandler  *pingcommands.CreatePingSeriesHandler
+	updatePingSeriesHandler  *pingcommands.UpdatePingSeriesHandler
+	cancelPingSeriesHandler  *pingcommands.CancelPingSeriesHandler
+	skipOccurrenceHandler    *pingcommands.SkipOccurrenceHandler
+	editOccurrenceHandler    *pingcommands.EditOccurrenceHandler
+	getPingSeriesHandler     *pingqueries.GetPingSeriesHandler
+	getUserPingSeriesHandler *pingqueries.GetUserPingSeriesHandler
+}
+
+func NewPingSeriesHandler(
+	createPingSeriesHandler *pingcommands.CreatePingSeriesHandler,
+	updatePingSeriesHandler *pingcommands.UpdatePingSeriesHandler,
+	cancelPingSeriesHandler *pingcommands.CancelPingSeriesHandler,
+	skipOccurrenceHandler *pingcommands.SkipOccurrenceHandler,
+	editOccurrenceHandler *pingcommands.EditOccurrenceHandler,
+	getPingSeriesHandler *pingqueries.GetPingSeriesHandler,
+	getUserPingSeriesHandler *pingqueries.GetUserPingSeriesHandler,
+) *PingSeriesHandler {
+	return &PingSeriesHandler{
+		createPingSeriesHandler:  createPingSeriesHandler,
+		updatePingSeriesHandler:  updatePingSeriesHandler,
+		cancelPingSeriesHandler:  cancelPingSeriesHandler,
+		skipOccurrenceHandler:    skipOccurrenceHandler,
+		editOccurrenceHandler:    editOccurrenceHandler,
+		getPingSeriesHandler:     getPingSeriesHandler,
+		getUserPingSeriesHandler: getUserPingSeriesHandler,
+	}
+}
+
+// POST /api/v1/ping-series
+func (h *PingSeriesHandler) CreateSeries(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	var cmd pingcommands.CreatePingSeriesCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.CreatedBy = userID
+
+	series, occurrences, err := h.createPingSeriesHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Ping series created successfully",
+		"data":    pingqueries.NewPingSeriesDTO(series, occurrences),
+	})
+}
+
+// GET /api/v1/ping-series
+func (h *PingSeriesHandler) GetMySeries(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	result, err := h.getUserPingSeriesHandler.Handle(c.Request.Context(), pingqueries.GetUserPingSeriesQuery{
+		UserID: userID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// GET /api/v1/ping-series/:id
+func (h *PingSeriesHandler) GetSeries(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	seriesID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping series ID"))
+		return
+	}
+
+	series, err := h.getPingSeriesHandler.Handle(c.Request.Context(), pingqueries.GetPingSeriesQuery{
+		UserID:   userID,
+		SeriesID: seriesID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": series,
+	})
+}
+
+// PUT /api/v1/ping-series/:id
+func (h *PingSeriesHandler) UpdateSeries(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	seriesID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping series ID"))
+		return
+	}
+
+	var cmd pingcommands.UpdatePingSeriesCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.UserID = userID
+	cmd.SeriesID = seriesID
+
+	series, occurrences, err := h.updatePingSeriesHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Ping series updated successfully",
+		"data":    pingqueries.NewPingSeriesDTO(series, occurrences),
+	})
+}
+
+// POST /api/v1/ping-series/:id/cancel
+func (h *PingSeriesHandler) CancelSeries(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	seriesID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping series ID"))
+		return
+	}
+
+	series, err := h.cancelPingSeriesHandler.Handle(c.Request.Context(), pingcommands.CancelPingSeriesCommand{
+		UserID:   userID,
+		SeriesID: seriesID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Ping series cancelled successfully",
+		"data":    pingqueries.NewPingSeriesDTO(series, nil),
+	})
+}
+
+// POST /api/v1/pings/:id/skip
+func (h *PingSeriesHandler) SkipOccurrence(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	pingID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping ID"))
+		return
+	}
+
+	skipped, err := h.skipOccurrenceHandler.Handle(c.Request.Context(), pingcommands.SkipOccurrenceCommand{
+		UserID: userID,
+		PingID: pingID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Occurrence skipped successfully",
+		"data":    pingqueries.NewPingDTO(skipped),
+	})
+}
+
+// PUT /api/v1/pings/:id
+func (h *PingSeriesHandler) EditOccurrence(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	pingID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid ping ID"))
+		return
+	}
+
+	var cmd pingcommands.EditOccurrenceCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.UserID = userID
+	cmd.PingID = pingID
+
+	edited, err := h.editOccurrenceHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Occurrence updated successfully",
+		"data":    pingqueries.NewPingDTO(edited),
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/infrastructure/messaging"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// streamHeartbeatInterval 定期送出 heartbeat，避免 proxy 關閉閒置連線
+	streamHeartbeatInterval = 25 * time.Second
+	// streamWriteTimeout 單次寫入的逾時，寫不出去代表客戶端已斷線
+	streamWriteTimeout = 10 * time.Second
+	// streamRevocationCheckInterval 定期確認連線使用的 token 或 session 是否已被撤銷 (登出、撤銷裝置)
+	streamRevocationCheckInterval = 30 * time.Second
+)
+
+// RealtimeHandler 以 WebSocket 或 SSE 推送即時事件
+type RealtimeHandler struct {
+	hub         *messaging.Hub
+	revocations session.RevocationList
+}
+
+func NewRealtimeHandler(hub *messaging.Hub, revocations session.RevocationList) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub:         hub,
+		revocations: revocations,
+	}
+}
+
+// streamMessage 是 heartbeat 等非業務訊息
+type streamMessage struct {
+	Type string `json:"type"`
+}
+
+// GET /api/v1/events/ws
+func (h *RealtimeHandler) WebSocket(c *gin.Context) {
+	stream, ok := newStreamSession(c)
+	if !ok {
+		return
+	}
+
+	server := websocket.Server{
+		// 身分已由 JWT 驗證 (token 不會隨 cookie 自動帶出)，因此不限制 Origin
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			h.serveWebSocket(ws, stream)
+		},
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+func (h *RealtimeHandler) serveWebSocket(ws *websocket.Conn, stream streamSession) {
+	defer ws.Close()
+
+	// 清除 http.Server 的讀取逾時，連線會長時間保持
+	_ = ws.SetReadDeadline(time.Time{})
+
+	sub := h.hub.Subscribe(stream.userID)
+	defer sub.Close()
+
+	// 讀取迴圈只用來偵測客戶端關閉連線
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var message string
+		for {
+			if err := websocket.Message.Receive(ws, &message); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	revocationCheck := time.NewTicker(streamRevocationCheckInterval)
+	defer revocationCheck.Stop()
+	expired := time.NewTimer(time.Until(stream.expiresAt))
+	defer expired.Stop()
+
+	send := func(v interface{}) bool {
+		_ = ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		return websocket.JSON.Send(ws, v) == nil
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok || !send(event) {
+				return
+			}
+		case <-heartbeat.C:
+			if !send(streamMessage{Type: "heartbeat"}) {
+				return
+			}
+		case <-revocationCheck.C:
+			if h.isRevoked(ws.Request().Context(), stream) {
+				send(streamMessage{Type: "session.revoked"})
+				return
+			}
+		case <-expired.C:
+			// access token 過期後關閉連線，客戶端需以新 token 重新連線
+			send(streamMessage{Type: "token.expired"})
+			return
+		case <-closed:
+			return
+		}
+	}
+}
+
+// GET /api/v1/events/stream
+func (h *RealtimeHandler) Stream(c *gin.Context) {
+	stream, ok := newStreamSession(c)
+	if !ok {
+		return
+	}
+
+	// 解除 http.Server 的 WriteTimeout，改為每次寫入各自設定逾時
+	controller := http.NewResponseController(c.Writer)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	sub := h.hub.Subscribe(stream.userID)
+	defer sub.Close()
+
+	write := func(format string, args ...interface{}) bool {
+		_ = controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := fmt.Fprintf(c.Writer, format, args...); err != nil {
+			return false
+		}
+		return controller.Flush() == nil
+	}
+
+	// 斷線後 3 秒重連
+	if !write("retry: 3000\n\n") {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	revocationCheck := time.NewTicker(streamRevocationCheckInterval)
+	defer revocationCheck.Stop()
+	expired := time.NewTimer(time.Until(stream.expiresAt))
+	defer expired.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if !write("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data) {
+				return
+			}
+		case <-heartbeat.C:
+			if !write(": heartbeat\n\n") {
+				return
+			}
+		case <-revocationCheck.C:
+			if h.isRevoked(c.Request.Context(), stream) {
+				write("event: %s\ndata: {}\n\n", "session.revoked")
+				return
+			}
+		case <-expired.C:
+			write("event: %s\ndata: {}\n\n", "token.expired")
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// streamSession 連線所使用的 access token
+type streamSession struct {
+	userID    shared.UserID
+	tokenID   string
+	sessionID string
+	expiresAt time.Time
+}
+
+func newStreamSession(c *gin.Context) (streamSession, bool) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return streamSession{}, false
+	}
+	return streamSession{
+		userID:    userID,
+		tokenID:   c.GetString("tokenID"),
+		sessionID: c.GetString("sessionID"),
+		expiresAt: c.GetTime("tokenExpiresAt"),
+	}, true
+}
+
+// isRevoked 與 AuthMiddleware 相同，token 或其 session 任一被撤銷即視為撤銷
+// 查詢失敗時保留連線，下次檢查再確認
+func (h *RealtimeHandler) isRevoked(ctx context.Context, stream streamSession) bool {
+	for _, id := range []string{stream.tokenID, stream.sessionID} {
+		if id == "" {
+			continue
+		}
+		if revoked, err := h.revocations.IsRevoked(ctx, id); err == nil && revoked {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	restaurantcommands "github.com/chun-wei0413/pingnom/internal/application/commands/restaurant"
+	restaurantqueries "github.com/chun-wei0413/pingnom/internal/application/queries/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/restaurant"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize 批次匯入檔案的大小上限
+const maxImportFileSize = 10 << 20
+
+// RestaurantAdminHandler 處理餐廳目錄的管理 API
+type RestaurantAdminHandler struct {
+	getRestaurantHandler     *restaurantqueries.GetRestaurantHandler
+	createRestaurantHandler  *restaurantcommands.CreateRestaurantHandler
+	updateRestaurantHandler  *restaurantcommands.UpdateRestaurantHandler
+	deleteRestaurantHandler  *restaurantcommands.DeleteRestaurantHandler
+	importRestaurantsHandler *restaurantcommands.ImportRestaurantsHandler
+}
+
+func NewRestaurantAdminHandler(
+	getRestaurantHandler *restaurantqueries.GetRestaurantHandler,
+	createRestaurantHandler *restaurantcommands.CreateRestaurantHandler,
+	updateRestaurantHandler *restaurantcommands.UpdateRestaurantHandler,
+	deleteRestaurantHandler *restaurantcommands.DeleteRestaurantHandler,
+	importRestaurantsHandler *restaurantcommands.ImportRestaurantsHandler,
+) *RestaurantAdminHandler {
+	return &RestaurantAdminHandler{
+		getRestaurantHandler:     getRestaurantHandler,
+		createRestaurantHandler:  createRestaurantHandler,
+		updateRestaurantHandler:  updateRestaurantHandler,
+		deleteRestaurantHandler:  deleteRestaurantHandler,
+		importRestaurantsHandler: importRestaurantsHandler,
+	}
+}
+
+// GET /api/v1/admin/restaurants/:id
+func (h *RestaurantAdminHandler) GetRestaurant(c *gin.Context) {
+	restaurantID, err := shared.NewRestaurantIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
+		return
+	}
+
+	rest, err := h.getRestaurantHandler.Handle(c.Request.Context(), restaurantqueries.GetRestaurantQuery{
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": rest,
+	})
+}
+
+// POST /api/v1/admin/restaurants
+func (h *RestaurantAdminHandler) CreateRestaurant(c *gin.Context) {
+	var cmd restaurantcommands.CreateRestaurantCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+
+	created, err := h.createRestaurantHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Restaurant created successfully",
+		"data":    created,
+	})
+}
+
+// PUT /api/v1/admin/restaurants/:id
+func (h *RestaurantAdminHandler) UpdateRestaurant(c *gin.Context) {
+	restaurantID, err := shared.NewRestaurantIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
+		return
+	}
+
+	var cmd restaurantcommands.UpdateRestaurantCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.RestaurantID = restaurantID
+
+	updated, err := h.updateRestaurantHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Restaurant updated successfully",
+		"data":    updated,
+	})
+}
+
+// DELETE /api/v1/admin/restaurants/:id
+func (h *RestaurantAdminHandler) DeleteRestaurant(c *gin.Context) {
+	restaurantID, err := shared.NewRestaurantIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
+		return
+	}
+
+	err = h.deleteRestaurantHandler.Handle(c.Request.Context(), restaurantcommands.DeleteRestaurantCommand{
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Restaurant deleted successfully",
+	})
+}
+
+// POST /api/v1/admin/restaurants/import?format=csv|geojson&dryRun=true
+// 檔案可直接作為 request body，或以 multipart/form-data 的 file 欄位上傳
+// 未指定 format 時依 Content-Type 或上傳的檔名判斷
+func (h *RestaurantAdminHandler) ImportRestaurants(c *gin.Context) {
+	dryRun := false
+	if raw := c.Query("dryRun"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.Error(shared.ErrInvalidInput.WithMessage("dryRun must be true or false"))
+			return
+		}
+		dryRun = parsed
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	format := restaurant.ImportFormat(c.Query("format"))
+	var file io.Reader
+	if mediaType, _, _ := mime.ParseMediaType(c.ContentType()); mediaType == "multipart/form-data" {
+		upload, err := c.FormFile("file")
+		if err != nil {
+			c.Error(importBodyError(err))
+			return
+		}
+		if format == "" {
+			format = restaurant.ImportFormatFromFilename(upload.Filename)
+		}
+		opened, err := upload.Open()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		defer opened.Close()
+		file = opened
+	} else {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(importBodyError(err))
+			return
+		}
+		if format == "" {
+			format = importFormatFromMediaType(mediaType)
+		}
+		file = bytes.NewReader(body)
+	}
+
+	if format == "" {
+		c.Error(shared.ErrInvalidInput.WithMessage("Cannot detect the import format, set ?format=csv or ?format=geojson"))
+		return
+	}
+
+	report, err := h.importRestaurantsHandler.Handle(c.Request.Context(), restaurantcommands.ImportRestaurantsCommand{
+		Format: format,
+		File:   file,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+func importFormatFromMediaType(mediaType string) restaurant.ImportFormat {
+	switch mediaType {
+	case "text/csv":
+		return restaurant.ImportFormatCSV
+	case "application/geo+json", "application/json":
+		return restaurant.ImportFormatGeoJSON
+	default:
+		return ""
+	}
+}
+
+// importBodyError 檔案超過大小上限時保留 *http.MaxBytesError，由錯誤處理 middleware 回應 413
+func importBodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return shared.ErrInvalidInput.WithMessage("Invalid import file").Wrap(err)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	restaurantQueries "github.com/chun-wei0413/pingnom/internal/application/queries/restaurant"
@@ -14,16 +15,19 @@ import (
 type RestaurantHandler struct {
 	searchHandler         *restaurantQueries.SearchRestaurantsHandler
 	recommendationHandler *restaurantQueries.GetRestaurantRecommendationsHandler
+	tasteProfileHandler   *restaurantQueries.GetTasteProfileHandler
 }
 
 // NewRestaurantHandler 建立新的餐廳處理器
 func NewRestaurantHandler(
 	searchHandler *restaurantQueries.SearchRestaurantsHandler,
 	recommendationHandler *restaurantQueries.GetRestaurantRecommendationsHandler,
+	tasteProfileHandler *restaurantQueries.GetTasteProfileHandler,
 ) *RestaurantHandler {
 	return &RestaurantHandler{
 		searchHandler:         searchHandler,
 		recommendationHandler: recommendationHandler,
+		tasteProfileHandler:   tasteProfileHandler,
 	}
 }
 
@@ -78,6 +82,19 @@ func (h *RestaurantHandler) SearchRestaurants(c *gin.Context) {
 		query.CuisineTypes = []restaurant.CuisineType{restaurant.CuisineType(cuisineTypesStr)}
 	}
 
+	// 營業時間：openAt 為 RFC 3339 時間，openNow=true 代表現在
+	if openAtStr := c.Query("openAt"); openAtStr != "" {
+		openAt, err := time.Parse(time.RFC3339, openAtStr)
+		if err != nil {
+			c.Error(shared.ErrInvalidInput.WithMessage("openAt must be an RFC 3339 time"))
+			return
+		}
+		query.OpenAt = &openAt
+	} else if c.Query("openNow") == "true" {
+		now := time.Now()
+		query.OpenAt = &now
+	}
+
 	// 排序
 	if sortBy := c.Query("sortBy"); sortBy != "" {
 		query.SortBy = restaurant.SortBy(sortBy)
@@ -93,7 +110,7 @@ func (h *RestaurantHandler) SearchRestaurants(c *gin.Context) {
 
 	restaurants, err := h.searchHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -109,16 +126,20 @@ func (h *RestaurantHandler) GetRecommendations(c *gin.Context) {
 		DietaryRestrictions  []restaurant.DietaryRestriction `json:"dietaryRestrictions,omitempty"`
 		MaxDistance          float64                         `json:"maxDistance,omitempty"`
 		MaxResults           int                             `json:"maxResults,omitempty"`
+		MealTime             *time.Time                      `json:"mealTime,omitempty"`
+		PingID               string                          `json:"pingId,omitempty"`
+		MeetingPointStrategy restaurant.MeetingPointStrategy   `json:"meetingPointStrategy,omitempty"`
+		WeightProfile        string                          `json:"weightProfile,omitempty"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body"))
 		return
 	}
 
 	// 驗證必要參數
 	if len(req.ParticipantLocations) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one participant location is required"})
+		c.Error(shared.ErrInvalidInput.WithMessage("At least one participant location is required"))
 		return
 	}
 
@@ -126,11 +147,16 @@ func (h *RestaurantHandler) GetRecommendations(c *gin.Context) {
 	for _, location := range req.ParticipantLocations {
 		if location.Latitude < -90 || location.Latitude > 90 ||
 			location.Longitude < -180 || location.Longitude > 180 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid location coordinates"})
+			c.Error(shared.ErrInvalidInput.WithMessage("Invalid location coordinates"))
 			return
 		}
 	}
 
+	if req.MeetingPointStrategy != "" && !req.MeetingPointStrategy.IsValid() {
+		c.Error(shared.ErrInvalidInput.WithMessage("meetingPointStrategy must be one of geometric_median, minimax, travel_time, centroid"))
+		return
+	}
+
 	query := restaurantQueries.GetRestaurantRecommendationsQuery{
 		ParticipantLocations: req.ParticipantLocations,
 		CuisinePreferences:   req.CuisinePreferences,
@@ -138,29 +164,60 @@ func (h *RestaurantHandler) GetRecommendations(c *gin.Context) {
 		DietaryRestrictions:  req.DietaryRestrictions,
 		MaxDistance:          req.MaxDistance,
 		MaxResults:           req.MaxResults,
+		MealTime:             req.MealTime,
+		PingID:               req.PingID,
+		MeetingPointStrategy: req.MeetingPointStrategy,
+		WeightProfile:        req.WeightProfile,
+	}
+	// 以提出請求的用戶 (或 ping 成員) 的用餐紀錄個人化
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
 	}
+	query.UserID = userID
 
 	recommendations, err := h.recommendationHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"recommendations": recommendations})
 }
 
+// GetTasteProfile 取得用戶依用餐紀錄計算的口味檔案，?pingId= 取得 ping 成員的群組口味檔案
+func (h *RestaurantHandler) GetTasteProfile(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	profile, err := h.tasteProfileHandler.Handle(c.Request.Context(), restaurantQueries.GetTasteProfileQuery{
+		UserID: userID,
+		PingID: c.Query("pingId"),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tasteProfile": profile})
+}
+
 // GetRestaurantByID 根據 ID 獲取餐廳詳情
 func (h *RestaurantHandler) GetRestaurantByID(c *gin.Context) {
 	restaurantID := c.Param("id")
 	if restaurantID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Restaurant ID is required"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Restaurant ID is required"))
 		return
 	}
 
 	// 轉換為 RestaurantID
 	id, err := shared.NewRestaurantIDFromString(restaurantID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid restaurant ID"})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
 		return
 	}
 
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	reviewcommands "github.com/chun-wei0413/pingnom/internal/application/commands/review"
+	reviewqueries "github.com/chun-wei0413/pingnom/internal/application/queries/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/review"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler 處理餐廳評論
+type ReviewHandler struct {
+	createReviewHandler         *reviewcommands.CreateReviewHandler
+	editReviewHandler           *reviewcommands.EditReviewHandler
+	deleteReviewHandler         *reviewcommands.DeleteReviewHandler
+	removeReviewHandler         *reviewcommands.RemoveReviewHandler
+	setHelpfulHandler           *reviewcommands.SetHelpfulHandler
+	getRestaurantReviewsHandler *reviewqueries.GetRestaurantReviewsHandler
+	getUserReviewsHandler       *reviewqueries.GetUserReviewsHandler
+}
+
+func NewReviewHandler(
+	createReviewHandler *reviewcommands.CreateReviewHandler,
+	editReviewHandler *reviewcommands.EditReviewHandler,
+	deleteReviewHandler *reviewcommands.DeleteReviewHandler,
+	removeReviewHandler *reviewcommands.RemoveReviewHandler,
+	setHelpfulHandler *reviewcommands.SetHelpfulHandler,
+	getRestaurantReviewsHandler *reviewqueries.GetRestaurantReviewsHandler,
+	getUserReviewsHandler *reviewqueries.GetUserReviewsHandler,
+) *ReviewHandler {
+	return &ReviewHandler{
+		createReviewHandler:         createReviewHandler,
+		editReviewHandler:           editReviewHandler,
+		deleteReviewHandler:         deleteReviewHandler,
+		removeReviewHandler:         removeReviewHandler,
+		setHelpfulHandler:           setHelpfulHandler,
+		getRestaurantReviewsHandler: getRestaurantReviewsHandler,
+		getUserReviewsHandler:       getUserReviewsHandler,
+	}
+}
+
+// POST /api/v1/restaurants/:id/reviews
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	restaurantID, err := shared.NewRestaurantIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
+		return
+	}
+
+	var cmd reviewcommands.CreateReviewCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.UserID = userID
+	cmd.RestaurantID = restaurantID
+
+	created, err := h.createReviewHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Review created successfully",
+		"data":    reviewqueries.NewReviewView(created, userID),
+	})
+}
+
+// GET /api/v1/restaurants/:id/reviews?sort=newest|most_helpful&limit=20&offset=0
+func (h *ReviewHandler) GetRestaurantReviews(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+
+	restaurantID, err := shared.NewRestaurantIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid restaurant ID"))
+		return
+	}
+
+	sort := review.SortOrder(c.DefaultQuery("sort", string(review.SortNewest)))
+	if sort != review.SortNewest && sort != review.SortMostHelpful {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid sort, expected newest or most_helpful"))
+		return
+	}
+	limit, offset := reviewPagination(c)
+
+	result, err := h.getRestaurantReviewsHandler.Handle(c.Request.Context(), reviewqueries.GetRestaurantReviewsQuery{
+		ViewerID:     userID,
+		RestaurantID: restaurantID,
+		Sort:         sort,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// GET /api/v1/users/reviews?limit=20&offset=0
+func (h *ReviewHandler) GetMyReviews(c *gin.Context) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return
+	}
+	limit, offset := reviewPagination(c)
+
+	reviews, err := h.getUserReviewsHandler.Handle(c.Request.Context(), reviewqueries.GetUserReviewsQuery{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reviews,
+	})
+}
+
+// PUT /api/v1/reviews/:id
+func (h *ReviewHandler) EditReview(c *gin.Context) {
+	userID, reviewID, ok := reviewRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	var cmd reviewcommands.EditReviewCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.UserID = userID
+	cmd.ReviewID = reviewID
+
+	updated, err := h.editReviewHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review updated successfully",
+		"data":    reviewqueries.NewReviewView(updated, userID),
+	})
+}
+
+// DELETE /api/v1/reviews/:id
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID, reviewID, ok := reviewRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	err := h.deleteReviewHandler.Handle(c.Request.Context(), reviewcommands.DeleteReviewCommand{
+		UserID:   userID,
+		ReviewID: reviewID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review deleted successfully",
+	})
+}
+
+// DELETE /api/v1/moderation/reviews/:id
+// 版主移除評論，不限作者
+func (h *ReviewHandler) RemoveReview(c *gin.Context) {
+	_, reviewID, ok := reviewRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	err := h.removeReviewHandler.Handle(c.Request.Context(), reviewcommands.RemoveReviewCommand{
+		ReviewID: reviewID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review removed successfully",
+	})
+}
+
+// PUT /api/v1/reviews/:id/helpful
+func (h *ReviewHandler) MarkHelpful(c *gin.Context) {
+	h.setHelpful(c, true)
+}
+
+// DELETE /api/v1/reviews/:id/helpful
+func (h *ReviewHandler) UnmarkHelpful(c *gin.Context) {
+	h.setHelpful(c, false)
+}
+
+func (h *ReviewHandler) setHelpful(c *gin.Context, helpful bool) {
+	userID, reviewID, ok := reviewRequestIDs(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.setHelpfulHandler.Handle(c.Request.Context(), reviewcommands.SetHelpfulCommand{
+		UserID:   userID,
+		ReviewID: reviewID,
+		Helpful:  helpful,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": reviewqueries.NewReviewView(updated, userID),
+	})
+}
+
+// reviewRequestIDs 解析目前用戶與路徑上的評論 ID，失敗時已寫入錯誤回應
+func reviewRequestIDs(c *gin.Context) (shared.UserID, shared.ID, bool) {
+	userID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return shared.UserID{}, shared.ID{}, false
+	}
+
+	reviewID, err := shared.ParseID(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid review ID").Wrap(err))
+		return shared.UserID{}, shared.ID{}, false
+	}
+	return userID, reviewID, true
+}
+
+func reviewPagination(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
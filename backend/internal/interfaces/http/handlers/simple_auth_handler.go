@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
 	"github.com/chun-wei0413/pingnom/internal/domain/user"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
 )
@@ -39,28 +41,21 @@ type UserInfo struct {
 func (h *SimpleAuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 
 	// 使用 UserService 驗證用戶
 	user, err := h.userService.AuthenticateUser(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Invalid credentials",
-		})
+		c.Error(shared.ErrInvalidCredentials)
 		return
 	}
 
 	// 生成 JWT token
-	token, err := h.jwtService.GenerateToken(user.ID, user.Email)
+	token, err := h.jwtService.GenerateToken(user.ID, user.Email, string(user.Role.OrDefault()))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to generate token",
-		})
+		c.Error(fmt.Errorf("failed to generate token: %w", err))
 		return
 	}
 
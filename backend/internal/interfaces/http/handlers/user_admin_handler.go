@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	usercommands "github.com/chun-wei0413/pingnom/internal/application/commands/user"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// UserAdminHandler 處理用戶帳號的管理 API
+type UserAdminHandler struct {
+	changeUserRoleHandler *usercommands.ChangeUserRoleHandler
+	deactivateUserHandler *usercommands.DeactivateUserHandler
+}
+
+func NewUserAdminHandler(
+	changeUserRoleHandler *usercommands.ChangeUserRoleHandler,
+	deactivateUserHandler *usercommands.DeactivateUserHandler,
+) *UserAdminHandler {
+	return &UserAdminHandler{
+		changeUserRoleHandler: changeUserRoleHandler,
+		deactivateUserHandler: deactivateUserHandler,
+	}
+}
+
+// PUT /api/v1/admin/users/:id/role
+// 變更後該用戶的 session 會被撤銷，重新登入後取得帶有新角色的 token
+func (h *UserAdminHandler) ChangeRole(c *gin.Context) {
+	actorID, targetID, ok := adminRequestUserIDs(c)
+	if !ok {
+		return
+	}
+
+	var cmd usercommands.ChangeUserRoleCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	cmd.ActorID = actorID
+	cmd.UserID = targetID
+
+	updated, err := h.changeUserRoleHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User role updated successfully",
+		"data":    updated,
+	})
+}
+
+// POST /api/v1/admin/users/:id/deactivate
+func (h *UserAdminHandler) DeactivateUser(c *gin.Context) {
+	actorID, targetID, ok := adminRequestUserIDs(c)
+	if !ok {
+		return
+	}
+
+	err := h.deactivateUserHandler.Handle(c.Request.Context(), usercommands.DeactivateUserCommand{
+		ActorID: actorID,
+		UserID:  targetID,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deactivated successfully",
+	})
+}
+
+// adminRequestUserIDs 解析目前的管理者與路徑上的用戶 ID，失敗時已寫入錯誤回應
+func adminRequestUserIDs(c *gin.Context) (shared.UserID, shared.UserID, bool) {
+	actorID, err := shared.NewUserIDFromString(c.GetString("userID"))
+	if err != nil {
+		c.Error(shared.ErrUnauthorized.WithMessage("Invalid user ID"))
+		return shared.UserID{}, shared.UserID{}, false
+	}
+
+	targetID, err := shared.NewUserIDFromString(c.Param("id"))
+	if err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid user ID"))
+		return shared.UserID{}, shared.UserID{}, false
+	}
+	return actorID, targetID, true
+}
@@ -11,13 +11,14 @@ import (
 )
 
 type UserHandler struct {
-	registerUserHandler      *usercommands.RegisterUserHandler
-	updateProfileHandler     *usercommands.UpdateProfileHandler
-	updatePreferencesHandler *usercommands.UpdatePreferencesHandler
-	updatePrivacyHandler     *usercommands.UpdatePrivacyHandler
-	changePasswordHandler    *usercommands.ChangePasswordHandler
-	getUserProfileHandler    *userqueries.GetUserProfileHandler
-	searchUsersHandler       *userqueries.SearchUsersHandler
+	registerUserHandler                  *usercommands.RegisterUserHandler
+	updateProfileHandler                 *usercommands.UpdateProfileHandler
+	updatePreferencesHandler             *usercommands.UpdatePreferencesHandler
+	updatePrivacyHandler                 *usercommands.UpdatePrivacyHandler
+	updateNotificationPreferencesHandler *usercommands.UpdateNotificationPreferencesHandler
+	changePasswordHandler                *usercommands.ChangePasswordHandler
+	getUserProfileHandler                *userqueries.GetUserProfileHandler
+	searchUsersHandler                   *userqueries.SearchUsersHandler
 }
 
 func NewUserHandler(
@@ -25,18 +26,20 @@ func NewUserHandler(
 	updateProfileHandler *usercommands.UpdateProfileHandler,
 	updatePreferencesHandler *usercommands.UpdatePreferencesHandler,
 	updatePrivacyHandler *usercommands.UpdatePrivacyHandler,
+	updateNotificationPreferencesHandler *usercommands.UpdateNotificationPreferencesHandler,
 	changePasswordHandler *usercommands.ChangePasswordHandler,
 	getUserProfileHandler *userqueries.GetUserProfileHandler,
 	searchUsersHandler *userqueries.SearchUsersHandler,
 ) *UserHandler {
 	return &UserHandler{
-		registerUserHandler:      registerUserHandler,
-		updateProfileHandler:     updateProfileHandler,
-		updatePreferencesHandler: updatePreferencesHandler,
-		updatePrivacyHandler:     updatePrivacyHandler,
-		changePasswordHandler:    changePasswordHandler,
-		getUserProfileHandler:    getUserProfileHandler,
-		searchUsersHandler:       searchUsersHandler,
+		registerUserHandler:                  registerUserHandler,
+		updateProfileHandler:                 updateProfileHandler,
+		updatePreferencesHandler:             updatePreferencesHandler,
+		updatePrivacyHandler:                 updatePrivacyHandler,
+		updateNotificationPreferencesHandler: updateNotificationPreferencesHandler,
+		changePasswordHandler:                changePasswordHandler,
+		getUserProfileHandler:                getUserProfileHandler,
+		searchUsersHandler:                   searchUsersHandler,
 	}
 }
 
@@ -44,26 +47,13 @@ func NewUserHandler(
 func (h *UserHandler) Register(c *gin.Context) {
 	var cmd usercommands.RegisterUserCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 	
 	result, err := h.registerUserHandler.Handle(c.Request.Context(), cmd)
 	if err != nil {
-		// 根據錯誤類型回傳適當的狀態碼
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserAlreadyExists {
-			statusCode = http.StatusConflict
-		} else if err == shared.ErrInvalidEmail || err == shared.ErrInvalidPhone || err == shared.ErrWeakPassword {
-			statusCode = http.StatusBadRequest
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -77,9 +67,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 func (h *UserHandler) GetProfile(c *gin.Context) {
 	userID, err := h.getUserIDFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		c.Error(shared.ErrUnauthorized)
 		return
 	}
 	
@@ -89,14 +77,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 	
 	result, err := h.getUserProfileHandler.Handle(c.Request.Context(), query)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserNotFound {
-			statusCode = http.StatusNotFound
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -109,34 +90,20 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID, err := h.getUserIDFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		c.Error(shared.ErrUnauthorized)
 		return
 	}
 	
 	var cmd usercommands.UpdateProfileCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 	
 	cmd.UserID = userID
 	
 	if err := h.updateProfileHandler.Handle(c.Request.Context(), cmd); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserNotFound {
-			statusCode = http.StatusNotFound
-		} else if err == shared.ErrInvalidInput {
-			statusCode = http.StatusBadRequest
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -149,34 +116,20 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 func (h *UserHandler) ChangePassword(c *gin.Context) {
 	userID, err := h.getUserIDFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		c.Error(shared.ErrUnauthorized)
 		return
 	}
 	
 	var cmd usercommands.ChangePasswordCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 	
 	cmd.UserID = userID
 	
 	if err := h.changePasswordHandler.Handle(c.Request.Context(), cmd); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserNotFound {
-			statusCode = http.StatusNotFound
-		} else if err == shared.ErrWeakPassword {
-			statusCode = http.StatusBadRequest
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -189,34 +142,20 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 func (h *UserHandler) UpdatePreferences(c *gin.Context) {
 	userID, err := h.getUserIDFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		c.Error(shared.ErrUnauthorized)
 		return
 	}
 	
 	var cmd usercommands.UpdatePreferencesCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 	
 	cmd.UserID = userID
 	
 	if err := h.updatePreferencesHandler.Handle(c.Request.Context(), cmd); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserNotFound {
-			statusCode = http.StatusNotFound
-		} else if err == shared.ErrInvalidInput {
-			statusCode = http.StatusBadRequest
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -229,32 +168,20 @@ func (h *UserHandler) UpdatePreferences(c *gin.Context) {
 func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
 	userID, err := h.getUserIDFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Unauthorized",
-		})
+		c.Error(shared.ErrUnauthorized)
 		return
 	}
 	
 	var cmd usercommands.UpdatePrivacyCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-			"details": err.Error(),
-		})
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
 		return
 	}
 	
 	cmd.UserID = userID
 	
 	if err := h.updatePrivacyHandler.Handle(c.Request.Context(), cmd); err != nil {
-		statusCode := http.StatusInternalServerError
-		if err == shared.ErrUserNotFound {
-			statusCode = http.StatusNotFound
-		}
-		
-		c.JSON(statusCode, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
@@ -263,8 +190,40 @@ func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
 	})
 }
 
+// PUT /api/users/notification-preferences
+func (h *UserHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, err := h.getUserIDFromContext(c)
+	if err != nil {
+		c.Error(shared.ErrUnauthorized)
+		return
+	}
+	
+	var cmd usercommands.UpdateNotificationPreferencesCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.Error(shared.ErrInvalidInput.WithMessage("Invalid request body").Wrap(err))
+		return
+	}
+	
+	cmd.UserID = userID
+	
+	if err := h.updateNotificationPreferencesHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.Error(err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preferences updated successfully",
+	})
+}
+
 // GET /api/users/search
 func (h *UserHandler) SearchUsers(c *gin.Context) {
+	userID, err := h.getUserIDFromContext(c)
+	if err != nil {
+		c.Error(shared.ErrUnauthorized)
+		return
+	}
+	
 	query := c.Query("q")
 	limitStr := c.DefaultQuery("limit", "20")
 	offsetStr := c.DefaultQuery("offset", "0")
@@ -280,16 +239,15 @@ func (h *UserHandler) SearchUsers(c *gin.Context) {
 	}
 	
 	searchQuery := userqueries.SearchUsersQuery{
-		Query:  query,
-		Limit:  limit,
-		Offset: offset,
+		ViewerID: userID,
+		Query:    query,
+		Limit:    limit,
+		Offset:   offset,
 	}
 	
 	result, err := h.searchUsersHandler.Handle(c.Request.Context(), searchQuery)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 	
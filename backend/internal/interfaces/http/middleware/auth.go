@@ -1,70 +1,123 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/chun-wei0413/pingnom/internal/domain/session"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
 	"github.com/chun-wei0413/pingnom/internal/infrastructure/auth"
 )
 
 type AuthMiddleware struct {
-	jwtService *auth.JWTService
+	jwtService  *auth.JWTService
+	revocations session.RevocationList
 }
 
-func NewAuthMiddleware(jwtService *auth.JWTService) *AuthMiddleware {
+func NewAuthMiddleware(jwtService *auth.JWTService, revocations session.RevocationList) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService: jwtService,
+		jwtService:  jwtService,
+		revocations: revocations,
 	}
 }
 
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return m.authenticate(false)
+}
+
+// RequireStreamAuth 用於 WebSocket / SSE 端點
+// 瀏覽器的 WebSocket 與 EventSource 無法設定 header，因此也接受 access_token query 參數
+func (m *AuthMiddleware) RequireStreamAuth() gin.HandlerFunc {
+	return m.authenticate(true)
+}
+
+func (m *AuthMiddleware) authenticate(allowQueryToken bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header required",
-			})
-			c.Abort()
-			return
+		queryToken := ""
+		if allowQueryToken {
+			queryToken = c.Query("access_token")
 		}
-		
-		// 檢查 Bearer token 格式
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid authorization header format",
-			})
-			c.Abort()
+		if authHeader == "" && queryToken == "" {
+			AbortWithError(c, shared.ErrUnauthorized.WithMessage("Authorization header required"))
 			return
 		}
 		
-		tokenString := parts[1]
+		tokenString := queryToken
+		if authHeader != "" {
+			// 檢查 Bearer token 格式
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				AbortWithError(c, shared.ErrUnauthorized.WithMessage("Invalid authorization header format"))
+				return
+			}
+			tokenString = parts[1]
+		}
 		
 		// 使用 JWT 服務驗證 token
 		claims, err := m.jwtService.ValidateToken(tokenString)
 		if err != nil {
-			var errorMsg string
-			switch err {
-			case auth.ErrExpiredToken:
-				errorMsg = "Token has expired"
-			case auth.ErrInvalidToken:
-				errorMsg = "Invalid token"
-			default:
-				errorMsg = "Token validation failed"
+			if !errors.Is(err, auth.ErrExpiredToken) {
+				err = auth.ErrInvalidToken
 			}
-			
-			c.JSON(http.StatusUnauthorized, gin.H{
-				"error": errorMsg,
-			})
-			c.Abort()
+			AbortWithError(c, err)
+			return
+		}
+		
+		// 檢查 token 或其 session 是否已被撤銷 (登出、refresh token 重複使用)
+		revoked, err := m.isRevoked(c, claims)
+		if err != nil {
+			AbortWithError(c, fmt.Errorf("token validation failed: %w", err))
+			return
+		}
+		if revoked {
+			AbortWithError(c, shared.ErrSessionRevoked.WithMessage("token has been revoked"))
 			return
 		}
 		
 		// 設置用戶資訊到 context
 		c.Set("userID", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("role", string(user.Role(claims.Role).OrDefault()))
+		c.Set("sessionID", claims.SessionID)
+		c.Set("tokenID", claims.ID)
+		c.Set("tokenExpiresAt", time.Unix(claims.ExpiresAt, 0))
 		
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// RequireRole 只允許擁有 role 權限的用戶 (管理者也擁有版主的權限)，需放在 RequireAuth 之後
+// 角色來自 access token，變更角色時會撤銷該用戶的 session，重新登入後才會取得新角色
+func (m *AuthMiddleware) RequireRole(role user.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("userID") == "" {
+			AbortWithError(c, shared.ErrUnauthorized.WithMessage("Authorization header required"))
+			return
+		}
+		
+		if !user.Role(c.GetString("role")).Includes(role) {
+			AbortWithError(c, user.ErrInsufficientRole.WithMessage(fmt.Sprintf("the %s role is required", role)))
+			return
+		}
+		
+		c.Next()
+	}
+}
+
+func (m *AuthMiddleware) isRevoked(c *gin.Context, claims *auth.Claims) (bool, error) {
+	for _, id := range []string{claims.ID, claims.SessionID} {
+		if id == "" {
+			continue
+		}
+		revoked, err := m.revocations.IsRevoked(c.Request.Context(), id)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+	return false, nil
+}
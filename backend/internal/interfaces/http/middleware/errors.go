@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType RFC 7807 錯誤回應的 Content-Type
+const ProblemContentType = "application/problem+json"
+
+// Problem RFC 7807 的錯誤回應，code 為穩定的錯誤碼，客戶端應依 code 而非 detail 判斷錯誤
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+// kindStatus 錯誤類別對應的 HTTP 狀態碼
+var kindStatus = map[shared.ErrorKind]int{
+	shared.KindInvalidInput:  http.StatusBadRequest,
+	shared.KindUnauthorized:  http.StatusUnauthorized,
+	shared.KindForbidden:     http.StatusForbidden,
+	shared.KindNotFound:      http.StatusNotFound,
+	shared.KindConflict:      http.StatusConflict,
+	shared.KindGone:          http.StatusGone,
+	shared.KindUnprocessable: http.StatusUnprocessableEntity,
+	shared.KindInternal:      http.StatusInternalServerError,
+}
+
+// ErrorHandler 將 handler 以 c.Error 回報的錯誤轉為 problem+json 回應
+// 需註冊在所有路由之前；handler 已寫入回應時不會再覆寫
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		WriteProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// AbortWithError 記錄錯誤並中止後續的 handler，由 ErrorHandler 寫入回應
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// WriteProblem 依錯誤的類別與錯誤碼寫入 problem+json 回應
+// 不是 DomainError 的錯誤視為內部錯誤，只記錄在 log 而不回傳細節
+func WriteProblem(c *gin.Context, err error) {
+	problem := NewProblem(err)
+	problem.Instance = c.Request.URL.Path
+	if problem.Status == http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// NewProblem 將錯誤轉為 Problem (不含 Instance)
+func NewProblem(err error) Problem {
+	var domainErr *shared.DomainError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &domainErr):
+		status, ok := kindStatus[domainErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		detail := err.Error()
+		if status == http.StatusInternalServerError {
+			detail = ""
+		}
+		return newProblem(status, domainErr.Code, detail)
+	case errors.As(err, &tooLarge):
+		return newProblem(http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", err.Error())
+	default:
+		return newProblem(http.StatusInternalServerError, "INTERNAL_ERROR", "")
+	}
+}
+
+func newProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chun-wei0413/pingnom/internal/domain/groupdining/aggregates"
+	"github.com/chun-wei0413/pingnom/internal/domain/shared"
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "domain error",
+			err:        shared.ErrUserNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "USER_NOT_FOUND",
+			wantDetail: "user not found",
+		},
+		{
+			name:       "wrapped domain error keeps the full detail",
+			err:        fmt.Errorf("%w: unknown role %q", shared.ErrInvalidInput, "owner"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_INPUT",
+			wantDetail: `invalid input: unknown role "owner"`,
+		},
+		{
+			name:       "specific message keeps the code",
+			err:        aggregates.ErrPlanFinalized.WithMessage("cannot cancel confirmed plans"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "GROUP_DINING_PLAN_FINALIZED",
+			wantDetail: "cannot cancel confirmed plans",
+		},
+		{
+			name:       "plan permission error uses the role code",
+			err:        &aggregates.PlanPermissionError{Action: aggregates.PlanActionFinalize, Required: aggregates.PlanRoleCreator},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "PLAN_CREATOR_REQUIRED",
+			wantDetail: "permission denied: only the plan creator can finalize",
+		},
+		{
+			name:       "request too large",
+			err:        &http.MaxBytesError{Limit: 10},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "REQUEST_TOO_LARGE",
+			wantDetail: "http: request body too large",
+		},
+		{
+			name:       "unknown error hides the detail",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "INTERNAL_ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(ErrorHandler())
+			engine.GET("/plans/:id", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/plans/42", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != ProblemContentType {
+				t.Errorf("Content-Type = %q, want %q", contentType, ProblemContentType)
+			}
+
+			var problem Problem
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			want := Problem{
+				Type:     "about:blank",
+				Title:    http.StatusText(tt.wantStatus),
+				Status:   tt.wantStatus,
+				Detail:   tt.wantDetail,
+				Instance: "/plans/42",
+				Code:     tt.wantCode,
+			}
+			if problem != want {
+				t.Errorf("problem = %+v, want %+v", problem, want)
+			}
+		})
+	}
+}
+
+func TestErrorHandler_KeepsWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(ErrorHandler())
+	engine.GET("/", func(c *gin.Context) {
+		_ = c.Error(errors.New("logged only"))
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != `{"ok":true}` {
+		t.Errorf("response = %d %s, want the handler's response", recorder.Code, recorder.Body.String())
+	}
+}
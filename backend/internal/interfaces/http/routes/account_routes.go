@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAccountRoutes 註冊 Email 驗證與密碼重設路由
+func SetupAccountRoutes(engine *gin.Engine, accountHandler *handlers.AccountHandler, authMiddleware *middleware.AuthMiddleware) {
+	auth := engine.Group("/api/v1/auth")
+	{
+		// Public: 以 Email 中的 token 完成驗證或重設密碼
+		auth.POST("/verify-email", accountHandler.VerifyEmail)
+		auth.POST("/password/forgot", accountHandler.ForgotPassword)
+		auth.POST("/password/reset", accountHandler.ResetPassword)
+
+		// Require Auth: 重新寄送驗證信
+		auth.POST("/verify-email/resend", authMiddleware.RequireAuth(), accountHandler.ResendVerification)
+	}
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAvailabilityRoutes 註冊用戶可用時間路由
+func SetupAvailabilityRoutes(engine *gin.Engine, availabilityHandler *handlers.AvailabilityHandler, authMiddleware *middleware.AuthMiddleware) {
+	availability := engine.Group("/api/v1/users/availability")
+	availability.Use(authMiddleware.RequireAuth())
+	{
+		availability.GET("", availabilityHandler.GetAvailability)
+		availability.PUT("", availabilityHandler.UpdateAvailability)
+	}
+}
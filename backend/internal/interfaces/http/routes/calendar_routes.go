@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupCalendarRoutes 註冊 .ics 匯出與行事曆訂閱路由
+// 訂閱網址本身帶有 token，因此不需要登入
+func SetupCalendarRoutes(engine *gin.Engine, calendarHandler *handlers.CalendarHandler, authMiddleware *middleware.AuthMiddleware) {
+	api := engine.Group("/api/v1")
+
+	protected := api.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("/pings/:id/calendar.ics", calendarHandler.GetPingCalendar)
+		protected.GET("/group-dining/plans/:id/calendar.ics", calendarHandler.GetPlanCalendar)
+		protected.POST("/calendar/feed", calendarHandler.CreateFeed)
+		protected.DELETE("/calendar/feed", calendarHandler.DeleteFeed)
+	}
+
+	api.GET("/calendar/feeds/:token/meals.ics", calendarHandler.GetFeed)
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/controllers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupGroupDiningRoutes 註冊多人聚餐相關路由 (Require Auth)
+func SetupGroupDiningRoutes(engine *gin.Engine, controller *controllers.GroupDiningController, authMiddleware *middleware.AuthMiddleware) {
+	groupDining := engine.Group("/api/v1/group-dining")
+	groupDining.Use(authMiddleware.RequireAuth())
+	{
+		// Create & Get Group Dining Plans
+		groupDining.POST("/plans", controller.CreateGroupDiningPlan)
+		groupDining.GET("/plans/:id", controller.GetGroupDiningPlan)
+		groupDining.GET("/plans", controller.GetGroupDiningPlansByCreator)
+		groupDining.GET("/participants/plans", controller.GetGroupDiningPlansByParticipant)
+
+		// Manage Time Slots & Restaurant Options
+		groupDining.POST("/plans/:id/time-slots", controller.AddTimeSlot)
+		groupDining.POST("/plans/:id/time-slots/suggestions", controller.SuggestTimeSlots)
+		groupDining.POST("/plans/:id/restaurants", controller.AddRestaurantOption)
+
+		// Invitations & Invite Links
+		groupDining.POST("/plans/:id/invitations", controller.InviteParticipants)
+		groupDining.POST("/plans/:id/invitations/respond", controller.RespondToInvitation)
+		groupDining.GET("/invitations", controller.GetPendingInvitations)
+		groupDining.POST("/plans/:id/invite-links", controller.CreateInviteLink)
+		groupDining.POST("/join", controller.JoinWithInviteLink)
+
+		// Join Plan & Voting
+		groupDining.POST("/plans/:id/join", controller.JoinGroupDiningPlan)
+		groupDining.POST("/plans/:id/start-voting", controller.StartVoting)
+		groupDining.POST("/plans/:id/vote", controller.SubmitVote)
+		groupDining.GET("/plans/:id/results", controller.GetVotingResults)
+
+		// Finalize or Cancel Plan
+		groupDining.POST("/plans/:id/finalize", controller.FinalizeGroupDiningPlan)
+		groupDining.POST("/plans/:id/cancel", controller.CancelGroupDiningPlan)
+	}
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupNotificationRoutes 註冊通知收件匣路由
+func SetupNotificationRoutes(engine *gin.Engine, notificationHandler *handlers.NotificationHandler, authMiddleware *middleware.AuthMiddleware) {
+	notifications := engine.Group("/api/v1/notifications")
+	notifications.Use(authMiddleware.RequireAuth())
+	{
+		notifications.GET("", notificationHandler.GetNotifications)
+		notifications.GET("/unread-count", notificationHandler.GetUnreadCount)
+		notifications.PUT("/read-all", notificationHandler.MarkAllRead)
+		notifications.PUT("/:id/read", notificationHandler.MarkRead)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPingSeriesRoutes 註冊重複 ping 的路由；單一發生的回覆沿用 PUT /pings/:id/respond
+func SetupPingSeriesRoutes(engine *gin.Engine, pingSeriesHandler *handlers.PingSeriesHandler, authMiddleware *middleware.AuthMiddleware) {
+	v1 := engine.Group("/api/v1")
+	v1.Use(authMiddleware.RequireAuth())
+	{
+		series := v1.Group("/ping-series")
+		series.POST("", pingSeriesHandler.CreateSeries)
+		series.GET("", pingSeriesHandler.GetMySeries)
+		series.GET("/:id", pingSeriesHandler.GetSeries)
+		series.PUT("/:id", pingSeriesHandler.UpdateSeries)
+		series.POST("/:id/cancel", pingSeriesHandler.CancelSeries)
+
+		// 只影響單一發生，不改動整個系列
+		v1.PUT("/pings/:id", pingSeriesHandler.EditOccurrence)
+		v1.POST("/pings/:id/skip", pingSeriesHandler.SkipOccurrence)
+	}
+}
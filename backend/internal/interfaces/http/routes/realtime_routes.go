@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRealtimeRoutes 註冊即時事件推送路由 (Require Auth，可用 access_token query 參數)
+func SetupRealtimeRoutes(engine *gin.Engine, realtimeHandler *handlers.RealtimeHandler, authMiddleware *middleware.AuthMiddleware) {
+	events := engine.Group("/api/v1/events")
+	events.Use(authMiddleware.RequireStreamAuth())
+	{
+		// WebSocket
+		events.GET("/ws", realtimeHandler.WebSocket)
+
+		// Server-Sent Events (無法使用 WebSocket 時的替代方案)
+		events.GET("/stream", realtimeHandler.Stream)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupRestaurantAdminRoutes 註冊餐廳目錄管理路由，只限管理者
+func SetupRestaurantAdminRoutes(engine *gin.Engine, restaurantAdminHandler *handlers.RestaurantAdminHandler, authMiddleware *middleware.AuthMiddleware) {
+	admin := engine.Group("/api/v1/admin")
+	admin.Use(authMiddleware.RequireAuth(), authMiddleware.RequireRole(user.RoleAdmin))
+	{
+		restaurants := admin.Group("/restaurants")
+		restaurants.POST("", restaurantAdminHandler.CreateRestaurant)
+		restaurants.POST("/import", restaurantAdminHandler.ImportRestaurants)
+		restaurants.GET("/:id", restaurantAdminHandler.GetRestaurant)
+		restaurants.PUT("/:id", restaurantAdminHandler.UpdateRestaurant)
+		restaurants.DELETE("/:id", restaurantAdminHandler.DeleteRestaurant)
+	}
+}
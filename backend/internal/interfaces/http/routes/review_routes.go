@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupReviewRoutes 註冊餐廳評論路由
+func SetupReviewRoutes(engine *gin.Engine, reviewHandler *handlers.ReviewHandler, authMiddleware *middleware.AuthMiddleware) {
+	v1 := engine.Group("/api/v1")
+	v1.Use(authMiddleware.RequireAuth())
+	{
+		v1.GET("/restaurants/:id/reviews", reviewHandler.GetRestaurantReviews)
+		v1.POST("/restaurants/:id/reviews", reviewHandler.CreateReview)
+		v1.GET("/users/reviews", reviewHandler.GetMyReviews)
+
+		reviews := v1.Group("/reviews")
+		reviews.PUT("/:id", reviewHandler.EditReview)
+		reviews.DELETE("/:id", reviewHandler.DeleteReview)
+		reviews.PUT("/:id/helpful", reviewHandler.MarkHelpful)
+		reviews.DELETE("/:id/helpful", reviewHandler.UnmarkHelpful)
+
+		// 版主 (與管理者) 可移除任何人的評論
+		moderation := v1.Group("/moderation", authMiddleware.RequireRole(user.RoleModerator))
+		moderation.DELETE("/reviews/:id", reviewHandler.RemoveReview)
+	}
+}
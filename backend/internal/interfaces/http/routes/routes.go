@@ -43,26 +43,32 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 	{
 		// Authentication
 		public.POST("/auth/login", r.authHandler.Login)
+		public.POST("/auth/refresh", r.authHandler.RefreshToken)
 		
 		// User registration
 		public.POST("/users/register", r.userHandler.Register)
-		
-		// User search (public for discovering friends)
-		public.GET("/users/search", r.userHandler.SearchUsers)
+
 	}
 	
 	// Protected routes (authentication required)
 	protected := v1.Group("/")
 	protected.Use(r.authMiddleware.RequireAuth())
 	{
+		// Session management
+		protected.POST("/auth/logout", r.authHandler.Logout)
+		
 		// User profile management
 		protected.GET("/users/profile", r.userHandler.GetProfile)
 		protected.PUT("/users/profile", r.userHandler.UpdateProfile)
 		protected.PUT("/users/password", r.userHandler.ChangePassword)
 		
+		// User search (hides users blocked by or blocking the current user)
+		protected.GET("/users/search", r.userHandler.SearchUsers)
+		
 		// User preferences and privacy
 		protected.PUT("/users/preferences", r.userHandler.UpdatePreferences)
 		protected.PUT("/users/privacy", r.userHandler.UpdatePrivacy)
+		protected.PUT("/users/notification-preferences", r.userHandler.UpdateNotificationPreferences)
 		
 		// Friendship routes
 		friends := protected.Group("/friends")
@@ -114,6 +120,9 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 			// Get restaurant recommendations
 			restaurants.POST("/recommendations", r.restaurantHandler.GetRecommendations)
 			
+			// Get taste profile learned from dining history
+			restaurants.GET("/taste-profile", r.restaurantHandler.GetTasteProfile)
+			
 			// Get restaurant by ID
 			restaurants.GET("/:id", r.restaurantHandler.GetRestaurantByID)
 		}
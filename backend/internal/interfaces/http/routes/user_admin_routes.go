@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/domain/user"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupUserAdminRoutes 註冊用戶帳號管理路由，只限管理者
+func SetupUserAdminRoutes(engine *gin.Engine, userAdminHandler *handlers.UserAdminHandler, authMiddleware *middleware.AuthMiddleware) {
+	admin := engine.Group("/api/v1/admin")
+	admin.Use(authMiddleware.RequireAuth(), authMiddleware.RequireRole(user.RoleAdmin))
+	{
+		users := admin.Group("/users")
+		users.PUT("/:id/role", userAdminHandler.ChangeRole)
+		users.POST("/:id/deactivate", userAdminHandler.DeactivateUser)
+	}
+}
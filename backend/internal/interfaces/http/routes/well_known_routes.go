@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/chun-wei0413/pingnom/internal/interfaces/http/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWellKnownRoutes 註冊 /.well-known 公開端點 (No Auth)
+func SetupWellKnownRoutes(engine *gin.Engine, jwksHandler *handlers.JWKSHandler) {
+	wellKnown := engine.Group("/.well-known")
+	{
+		// 供其他服務驗證 Pingnom access token 的公鑰
+		wellKnown.GET("/jwks.json", jwksHandler.GetJWKS)
+	}
+}
@@ -28,14 +28,10 @@ USER_ID=$(echo "$REGISTER_RESPONSE" | jq -r '.data.userId // empty')
 echo "註冊的使用者 ID: $USER_ID"
 echo ""
 
-# 3. 搜尋使用者 (不需認證)
-echo "🔍 3. 測試搜尋使用者"
-curl -s "$API_BASE/api/v1/users/search?q=test&limit=5" | jq .
-echo ""
-
-# 4. 測試需要認證的端點 (應該會失敗)
-echo "🔒 4. 測試未認證存取受保護端點"
+# 3. 測試需要認證的端點 (應該會失敗)
+echo "🔒 3. 測試未認證存取受保護端點"
 curl -s "$API_BASE/api/v1/users/profile" | jq .
+curl -s "$API_BASE/api/v1/users/search?q=test&limit=5" | jq .
 echo ""
 
 echo "✅ API 測試完成!"